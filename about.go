@@ -0,0 +1,274 @@
+package dfx
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// about dialog constants
+const (
+	aboutPopupID      = "##dfxAbout"
+	aboutIconSize     = 64
+	aboutCreditsLines = 6  // default height of the credits, in lines
+	aboutCreditsSpeed = 20 // default credits scroll speed, in pixels per second
+	aboutCopiedFor    = 2 * time.Second
+	dfxModulePath     = "github.com/michaelquigley/dfx"
+)
+
+// AppLink is a link shown in the about dialog.
+type AppLink struct {
+	Label string
+	URL   string
+}
+
+// AppInfo describes the application for its about dialog and diagnostics. set it as
+// Config.Info, then call App.ShowAbout from a Help menu.
+type AppInfo struct {
+	Name        string
+	Version     string
+	Description string
+	Authors     []string
+	Copyright   string
+	License     string
+	Links       []AppLink
+	Icon        image.Image // shown in the about dialog (nil = the first of Config.Icons)
+	Credits     []string    // scrolled in the about dialog, one line each
+
+	// Diagnostics returns extra lines for the copied diagnostics, such as the GPU model
+	// or the state of the app's own subsystems.
+	Diagnostics func() []string
+}
+
+// AboutDialog is a modal about dialog for an AppInfo: the app's icon, name, version and
+// description, its authors, copyright and license, links, scrolling credits, and a button
+// copying diagnostics (app, dfx, imgui and Go versions, OS and renderer) for bug reports.
+// App.ShowAbout opens one for Config.Info; draw your own to show it elsewhere.
+type AboutDialog struct {
+	Container
+	Info         AppInfo
+	CreditsLines int     // height of the credits, in lines (0 = 6)
+	CreditsSpeed float32 // credits scroll speed in pixels per second (0 = 20, negative = still)
+
+	open          bool
+	icon          *Texture
+	creditsScroll float32
+	copied        time.Time
+}
+
+// NewAboutDialog creates an about dialog for info. it shows once opened with Open.
+func NewAboutDialog(info AppInfo) *AboutDialog {
+	return &AboutDialog{Container: Container{Visible: true}, Info: info}
+}
+
+// Open shows the dialog from the next time it is drawn.
+func (d *AboutDialog) Open() {
+	d.open = true
+}
+
+// Draw implements Component, drawing the dialog while it is open.
+func (d *AboutDialog) Draw(state *State) {
+	if !d.Visible {
+		return
+	}
+	if d.open {
+		d.open = false
+		d.creditsScroll = 0
+		imgui.OpenPopupStr(aboutPopupID)
+	}
+
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if imgui.BeginPopupModalV(T("dfx.about.title", d.Info.Name)+aboutPopupID, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		d.drawHeader(state)
+		d.drawDetails()
+		d.drawCredits(state)
+		d.drawButtons()
+		imgui.EndPopup()
+	}
+
+	drawContainerExtensions(&d.Container, state)
+}
+
+// drawHeader draws the icon beside the name, version and description.
+func (d *AboutDialog) drawHeader(state *State) {
+	if icon := d.iconTexture(state); icon != nil {
+		DrawImage(icon, imgui.Vec2{X: aboutIconSize, Y: aboutIconSize}, ImageFit)
+		imgui.SameLine()
+	}
+	imgui.BeginGroup()
+	imgui.Text(d.Info.Name)
+	if d.Info.Version != "" {
+		imgui.TextDisabled(T("dfx.about.version", d.Info.Version))
+	}
+	if d.Info.Description != "" {
+		imgui.PushTextWrapPosV(imgui.CursorPosX() + imgui.FontSize()*20)
+		imgui.TextUnformatted(d.Info.Description)
+		imgui.PopTextWrapPos()
+	}
+	imgui.EndGroup()
+}
+
+// drawDetails draws the authors, copyright, license and links.
+func (d *AboutDialog) drawDetails() {
+	info := d.Info
+	if len(info.Authors) == 0 && info.Copyright == "" && info.License == "" && len(info.Links) == 0 {
+		return
+	}
+	imgui.Separator()
+	if len(info.Authors) > 0 {
+		imgui.Text(T("dfx.about.authors", strings.Join(info.Authors, ", ")))
+	}
+	if info.Copyright != "" {
+		imgui.Text(info.Copyright)
+	}
+	if info.License != "" {
+		imgui.Text(T("dfx.about.license", info.License))
+	}
+	for i, link := range info.Links {
+		if i > 0 {
+			imgui.SameLine()
+		}
+		label := link.Label
+		if label == "" {
+			label = link.URL
+		}
+		imgui.TextLinkOpenURLV(label, link.URL)
+	}
+}
+
+// drawCredits scrolls the credits upward, wrapping around at the end. hovering pauses
+// them so they can be scrolled by hand.
+func (d *AboutDialog) drawCredits(state *State) {
+	if len(d.Info.Credits) == 0 {
+		return
+	}
+	lines := d.CreditsLines
+	if lines <= 0 {
+		lines = aboutCreditsLines
+	}
+	imgui.Separator()
+	imgui.TextDisabled(T("dfx.about.credits"))
+	height := float32(lines) * imgui.TextLineHeightWithSpacing()
+	if imgui.BeginChildStrV("##credits", imgui.Vec2{X: 0, Y: height}, imgui.ChildFlagsBorders, imgui.WindowFlagsNoScrollbar) {
+		for _, line := range d.Info.Credits {
+			width := imgui.CalcTextSize(line).X
+			if offset := (imgui.ContentRegionAvail().X - width) / 2; offset > 0 {
+				imgui.SetCursorPosX(imgui.CursorPosX() + offset)
+			}
+			imgui.TextUnformatted(line)
+		}
+		if imgui.IsWindowHovered() {
+			d.creditsScroll = imgui.ScrollY()
+		} else {
+			d.creditsScroll = creditsScroll(d.creditsScroll, d.creditsSpeed()*float32(state.DeltaTime.Seconds()), imgui.ScrollMaxY())
+			imgui.SetScrollYFloat(d.creditsScroll)
+		}
+	}
+	imgui.EndChild()
+}
+
+func (d *AboutDialog) creditsSpeed() float32 {
+	if d.CreditsSpeed == 0 {
+		return aboutCreditsSpeed
+	}
+	return max(d.CreditsSpeed, 0)
+}
+
+// creditsScroll advances a scroll position by step, starting over past limit.
+func creditsScroll(scroll, step, limit float32) float32 {
+	if limit <= 0 {
+		return 0
+	}
+	scroll += step
+	if scroll > limit {
+		return 0
+	}
+	return scroll
+}
+
+// drawButtons draws the copy diagnostics and close buttons.
+func (d *AboutDialog) drawButtons() {
+	imgui.Separator()
+	label := T("dfx.about.copy")
+	if !d.copied.IsZero() && time.Since(d.copied) < aboutCopiedFor {
+		label = T("dfx.about.copied")
+	}
+	if imgui.Button(label + "###copy") {
+		imgui.SetClipboardText(d.Diagnostics())
+		d.copied = time.Now()
+	}
+	imgui.SameLine()
+	if imgui.Button(T("dfx.about.close")) || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		imgui.CloseCurrentPopup()
+	}
+}
+
+// iconTexture returns the texture of the icon, uploading it on first use.
+func (d *AboutDialog) iconTexture(state *State) *Texture {
+	if d.icon != nil {
+		return d.icon
+	}
+	icon := d.Info.Icon
+	if icon == nil && state != nil && state.App != nil && len(state.App.config.Icons) > 0 {
+		icon = state.App.config.Icons[0]
+	}
+	if icon == nil || state == nil || state.App == nil {
+		return nil
+	}
+	d.icon = state.App.Textures().Create(icon)
+	return d.icon
+}
+
+// Diagnostics returns the text copied by the dialog's copy diagnostics button: the app
+// and library versions, OS, and imgui backends, then the lines from Info.Diagnostics.
+// call it from the UI thread.
+func (d *AboutDialog) Diagnostics() string {
+	io := imgui.CurrentIO()
+	lines := diagnosticLines(d.Info, io.BackendPlatformName(), io.BackendRendererName())
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// diagnosticLines lists the diagnostics for info on the given imgui backends.
+func diagnosticLines(info AppInfo, platform, renderer string) []string {
+	name := strings.TrimSpace(info.Name + " " + info.Version)
+	lines := []string{
+		name,
+		"dfx: " + dfxVersion(),
+		"imgui: " + imgui.Version(),
+		"go: " + runtime.Version(),
+		fmt.Sprintf("os: %v/%v", runtime.GOOS, runtime.GOARCH),
+	}
+	if platform != "" || renderer != "" {
+		lines = append(lines, fmt.Sprintf("backend: %v, %v", platform, renderer))
+	}
+	if info.Diagnostics != nil {
+		lines = append(lines, info.Diagnostics()...)
+	}
+	return lines
+}
+
+// dfxVersion returns the version of dfx built into the binary, from its build info.
+func dfxVersion() string {
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if build.Main.Path == dfxModulePath {
+		return build.Main.Version
+	}
+	for _, dep := range build.Deps {
+		if dep.Path == dfxModulePath {
+			if dep.Replace != nil {
+				return dep.Version + " => " + dep.Replace.Path
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,49 @@
+package dfx
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDiagnosticLines(t *testing.T) {
+	info := AppInfo{
+		Name:        "Composer",
+		Version:     "1.2.0",
+		Diagnostics: func() []string { return []string{"gpu: test"} },
+	}
+	lines := diagnosticLines(info, "imgui_impl_glfw", "imgui_impl_opengl3")
+	if lines[0] != "Composer 1.2.0" {
+		t.Fatalf("expected name and version first, got '%v'", lines[0])
+	}
+	expected := map[string]bool{
+		"go: " + runtime.Version():                     false,
+		"os: " + runtime.GOOS + "/" + runtime.GOARCH:   false,
+		"backend: imgui_impl_glfw, imgui_impl_opengl3": false,
+		"gpu: test": false,
+	}
+	for _, line := range lines {
+		if _, ok := expected[line]; ok {
+			expected[line] = true
+		}
+	}
+	for line, found := range expected {
+		if !found {
+			t.Fatalf("expected line '%v' in '%v'", line, lines)
+		}
+	}
+	if len(diagnosticLines(AppInfo{Name: "x"}, "", "")) != 5 {
+		t.Fatalf("expected no backend line without backends")
+	}
+}
+
+func TestCreditsScroll(t *testing.T) {
+	if got := creditsScroll(10, 5, 100); got != 15 {
+		t.Fatalf("expected 15, got %v", got)
+	}
+	if got := creditsScroll(98, 5, 100); got != 0 {
+		t.Fatalf("expected wrap to 0, got %v", got)
+	}
+	if got := creditsScroll(10, 5, 0); got != 0 {
+		t.Fatalf("expected 0 when the credits fit, got %v", got)
+	}
+}
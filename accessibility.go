@@ -0,0 +1,223 @@
+package dfx
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// accessibility constants
+const (
+	DefaultKeySteps  = 50 // keyboard steps across a control's range
+	keyStepsFine     = 10 // Shift divides a step by this
+	keyStepsPage     = 10 // Page Up/Down move this many steps
+	announceEnvVar   = "DFX_ANNOUNCE"
+	announceMaxQueue = 1 // announcements waiting for the announcer; newer ones replace older
+)
+
+// Announcer speaks text to the user, bridging dfx to a platform screen reader or speech
+// service. Announce may block until the text has been spoken.
+type Announcer interface {
+	Announce(text string) error
+}
+
+// AnnouncerFunc adapts a function to an Announcer.
+type AnnouncerFunc func(text string) error
+
+// Announce implements Announcer.
+func (f AnnouncerFunc) Announce(text string) error {
+	return f(text)
+}
+
+// announcement state; the worker goroutine starts with the first announcer
+var (
+	announceMu    sync.Mutex
+	announcer     Announcer
+	announcements chan string
+)
+
+// SetAnnouncer sets the announcer that receives the accessible names and values of
+// controls as they gain focus or change by keyboard; nil stops announcing.
+func SetAnnouncer(a Announcer) {
+	announceMu.Lock()
+	defer announceMu.Unlock()
+	announcer = a
+	if a != nil && announcements == nil {
+		announcements = make(chan string, announceMaxQueue)
+		go announceLoop()
+	}
+}
+
+// Announce passes text to the announcer without blocking. when announcements arrive
+// faster than they are spoken, only the latest waits. errors from the announcer are
+// dropped; announcing is best effort.
+func Announce(text string) {
+	announceMu.Lock()
+	defer announceMu.Unlock()
+	if announcer == nil || text == "" {
+		return
+	}
+	for {
+		select {
+		case announcements <- text:
+			return
+		default:
+			select {
+			case <-announcements:
+			default:
+			}
+		}
+	}
+}
+
+func announceLoop() {
+	for text := range announcements {
+		announceMu.Lock()
+		a := announcer
+		announceMu.Unlock()
+		if a != nil {
+			_ = a.Announce(text)
+		}
+	}
+}
+
+// SpeechAnnouncer returns an announcer using the platform speech service: say on macOS,
+// speech-dispatcher (spd-say, which Orca also speaks through) on Linux and System.Speech
+// through PowerShell on Windows. it returns an error when the service is not installed.
+func SpeechAnnouncer() (Announcer, error) {
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name = "say"
+	case "linux":
+		name, args = "spd-say", []string{"--wait"}
+	case "windows":
+		name = "powershell"
+		args = []string{"-NoProfile", "-NonInteractive", "-Command",
+			"Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak($env:" + announceEnvVar + ")"}
+	default:
+		return nil, errors.Errorf("no speech service for '%v'", runtime.GOOS)
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding speech service '%v'", name)
+	}
+	return AnnouncerFunc(func(text string) error {
+		cmd := exec.Command(path, args...)
+		if runtime.GOOS == "windows" {
+			cmd.Env = append(os.Environ(), announceEnvVar+"="+text)
+		} else {
+			// text is a single argument; a leading dash would read as an option
+			cmd.Args = append(cmd.Args, strings.TrimLeft(text, "-"))
+		}
+		return errors.Wrapf(cmd.Run(), "error announcing with '%v'", name)
+	}), nil
+}
+
+// accessibleFocus is the imgui item whose focus was last announced.
+var accessibleFocus imgui.ID
+
+// AccessibleItem describes the last imgui item for assistive technology: when the item
+// gains keyboard focus, its label, value and description are announced. call it right
+// after drawing a custom control.
+func AccessibleItem(label, description, value string) {
+	id := imgui.ItemID()
+	if !imgui.IsItemFocused() {
+		if id == accessibleFocus {
+			accessibleFocus = 0 // announce again when focus returns
+		}
+		return
+	}
+	if id == accessibleFocus {
+		return
+	}
+	accessibleFocus = id
+	Announce(accessibleText(label, description, value))
+}
+
+// accessibleText joins the non-empty parts of an announcement.
+func accessibleText(parts ...string) string {
+	var text []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			text = append(text, part)
+		}
+	}
+	return strings.Join(text, ", ")
+}
+
+// componentAccessibleText returns the announcement for a component: the accessible
+// label and description of the Container it embeds.
+func componentAccessibleText(comp Component) string {
+	c := containerOf(comp)
+	if c == nil {
+		return ""
+	}
+	return accessibleText(c.AccessibleLabel, c.AccessibleDescription)
+}
+
+// stepKey is a key that adjusts a focused control.
+type stepKey int
+
+const (
+	stepNone stepKey = iota
+	stepUp
+	stepDown
+	stepPageUp
+	stepPageDown
+	stepHome
+	stepEnd
+)
+
+// pressedStepKey returns the adjustment key pressed this frame: arrows step, Page Up and
+// Page Down step further, Home and End jump to the ends.
+func pressedStepKey() stepKey {
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyUpArrow), imgui.IsKeyPressedBool(imgui.KeyRightArrow):
+		return stepUp
+	case imgui.IsKeyPressedBool(imgui.KeyDownArrow), imgui.IsKeyPressedBool(imgui.KeyLeftArrow):
+		return stepDown
+	case imgui.IsKeyPressedBool(imgui.KeyPageUp):
+		return stepPageUp
+	case imgui.IsKeyPressedBool(imgui.KeyPageDown):
+		return stepPageDown
+	case imgui.IsKeyPressedBool(imgui.KeyHome):
+		return stepHome
+	case imgui.IsKeyPressedBool(imgui.KeyEnd):
+		return stepEnd
+	}
+	return stepNone
+}
+
+// applyStepKey moves a 0..1 position by key, in steps across the range (fine divides a
+// step), clamped to the range.
+func applyStepKey(position, steps float32, key stepKey, fine bool) float32 {
+	if steps <= 0 {
+		steps = DefaultKeySteps
+	}
+	step := 1 / steps
+	if fine {
+		step /= keyStepsFine
+	}
+	switch key {
+	case stepUp:
+		position += step
+	case stepDown:
+		position -= step
+	case stepPageUp:
+		position += step * keyStepsPage
+	case stepPageDown:
+		position -= step * keyStepsPage
+	case stepHome:
+		position = 0
+	case stepEnd:
+		position = 1
+	}
+	return clamp(position, 0, 1)
+}
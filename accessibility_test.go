@@ -0,0 +1,75 @@
+package dfx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyStepKey(t *testing.T) {
+	cases := []struct {
+		position float32
+		key      stepKey
+		fine     bool
+		expected float32
+	}{
+		{0.5, stepUp, false, 0.52},
+		{0.5, stepDown, false, 0.48},
+		{0.5, stepUp, true, 0.502},
+		{0.5, stepPageUp, false, 0.7},
+		{0.5, stepPageDown, false, 0.3},
+		{0.5, stepHome, false, 0},
+		{0.5, stepEnd, false, 1},
+		{0.99, stepUp, false, 1},
+		{0.5, stepNone, false, 0.5},
+	}
+	for _, c := range cases {
+		if got := applyStepKey(c.position, 50, c.key, c.fine); got < c.expected-1e-5 || got > c.expected+1e-5 {
+			t.Fatalf("applyStepKey(%v, %v, %v): expected %v, got %v", c.position, c.key, c.fine, c.expected, got)
+		}
+	}
+	if got := applyStepKey(0.5, 0, stepUp, false); got < 0.52-1e-5 || got > 0.52+1e-5 {
+		t.Fatalf("expected default steps, got %v", got)
+	}
+}
+
+func TestAccessibleText(t *testing.T) {
+	if text := accessibleText("Volume", "", " -6 dB "); text != "Volume, -6 dB" {
+		t.Fatalf("unexpected text %q", text)
+	}
+	panel := &Container{Visible: true, AccessibleLabel: "Mixer", AccessibleDescription: "channel strips"}
+	if text := componentAccessibleText(panel); text != "Mixer, channel strips" {
+		t.Fatalf("unexpected component text %q", text)
+	}
+	if text := componentAccessibleText(NewSpacer()); text != "" {
+		t.Fatalf("expected no text for an unlabelled component, got %q", text)
+	}
+}
+
+func TestAnnounceOnFocus(t *testing.T) {
+	spoken := make(chan string, 4)
+	SetAnnouncer(AnnouncerFunc(func(text string) error {
+		spoken <- text
+		return nil
+	}))
+	defer SetAnnouncer(nil)
+
+	panel := &Container{Visible: true, AccessibleLabel: "Transport"}
+	fm := NewFocusManager()
+	fm.Focus(panel)
+	select {
+	case text := <-spoken:
+		if text != "Transport" {
+			t.Fatalf("expected 'Transport', got %q", text)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected focus to be announced")
+	}
+
+	SetAnnouncer(nil)
+	Announce("ignored")
+	select {
+	case text := <-spoken:
+		t.Fatalf("expected nothing announced without an announcer, got %q", text)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -28,9 +28,11 @@ type KeyEvent struct {
 type Action struct {
 	Id            string
 	Label         string // display name for menu items (if empty, uses Id)
-	Keys          string // e.g. "Ctrl+A", "Alt+Shift+F1"
+	Keys          string // e.g. "Ctrl+A", "Alt+Shift+F1", or a mouse gesture such as "Ctrl+WheelLeft"
+	Region        string // when set, the action only runs while the mouse is over this region (see MarkActionRegion)
 	Handler       func()
 	key           imgui.Key
+	gesture       actionGesture
 	mods          KeyModifier
 	shortcutLabel string // formatted shortcut for menu display
 }
@@ -41,8 +43,10 @@ type ActionRegistry struct {
 }
 
 type keyCombo struct {
-	key  imgui.Key
-	mods KeyModifier
+	key     imgui.Key
+	gesture actionGesture
+	mods    KeyModifier
+	region  string
 }
 
 func NewActionRegistry() *ActionRegistry {
@@ -51,9 +55,16 @@ func NewActionRegistry() *ActionRegistry {
 
 // Register adds an action to the registry
 func (r *ActionRegistry) Register(id, keys string, handler func()) error {
+	return r.RegisterRegion(id, keys, "", handler)
+}
+
+// RegisterRegion adds an action that only runs while the mouse is over the named region
+// (see MarkActionRegion), such as a double click on a timeline
+func (r *ActionRegistry) RegisterRegion(id, keys, region string, handler func()) error {
 	action := &Action{
 		Id:      id,
 		Keys:    keys,
+		Region:  region,
 		Handler: handler,
 	}
 
@@ -71,13 +82,18 @@ func (r *ActionRegistry) MustRegister(id, key string, handler func()) {
 	}
 }
 
+func (r *ActionRegistry) MustRegisterRegion(id, keys, region string, handler func()) {
+	if err := r.RegisterRegion(id, keys, region, handler); err != nil {
+		panic(err)
+	}
+}
+
 // RegisterAction adds a pre-created action (e.g., menu action) to the registry
 func (r *ActionRegistry) RegisterAction(action *Action) error {
 	// check for conflicts
-	combo := keyCombo{action.key, action.mods}
+	combo := action.combo()
 	for _, existing := range r.actions {
-		existingCombo := keyCombo{existing.key, existing.mods}
-		if combo == existingCombo {
+		if combo == existing.combo() {
 			return fmt.Errorf("key binding %q conflicts with action %q", action.Keys, existing.Id)
 		}
 	}
@@ -116,8 +132,12 @@ func (a *Action) parse() error {
 		}
 	}
 
-	// process the key
+	// process the key or mouse gesture
 	keyName := parts[len(parts)-1]
+	if gesture, ok := parseGesture(keyName); ok {
+		a.gesture = gesture
+		return nil
+	}
 	key, ok := parseKey(keyName)
 	if !ok {
 		return fmt.Errorf("unknown key: %s", keyName)
@@ -126,6 +146,23 @@ func (a *Action) parse() error {
 	return nil
 }
 
+// combo returns the binding of the action.
+func (a *Action) combo() keyCombo {
+	return keyCombo{key: a.key, gesture: a.gesture, mods: a.mods, region: a.Region}
+}
+
+// triggered reports whether the action's key was pressed or its gesture made this frame,
+// with mods held and the mouse over its region.
+func (a *Action) triggered(mods KeyModifier) bool {
+	if a.mods != mods || !actionRegions.hovers(a.Region) {
+		return false
+	}
+	if a.gesture != gestureNone {
+		return a.gesture.performed()
+	}
+	return imgui.IsKeyPressedBool(a.key)
+}
+
 // parseKey converts a key name to imgui.Key
 func parseKey(name string) (imgui.Key, bool) {
 	// single character keys
@@ -196,6 +233,11 @@ func parseKey(name string) (imgui.Key, bool) {
 		return imgui.KeyPageDown, true
 	}
 
+	// gamepad buttons
+	if key, ok := parseGamepadKey(name); ok {
+		return key, true
+	}
+
 	// function keys
 	if strings.HasPrefix(strings.ToLower(name), "f") && len(name) <= 3 {
 		var num int
@@ -240,7 +282,7 @@ func NewMenuAction(label, keys string, handler func()) *Action {
 		panic(fmt.Errorf("invalid action %q: %w", label, err))
 	}
 
-	action.shortcutLabel = formatShortcutLabel(action.mods, action.key)
+	action.shortcutLabel = action.combo().label()
 	return action
 }
 
@@ -270,6 +312,19 @@ func formatShortcutLabel(mods KeyModifier, key imgui.Key) string {
 	return strings.Join(parts, "+")
 }
 
+// label returns the binding in menu display format, e.g. "Ctrl+Shift+S" or
+// "Ctrl+Double Click".
+func (c keyCombo) label() string {
+	label := formatShortcutLabel(c.mods, c.key)
+	if c.gesture != gestureNone {
+		if label != "" {
+			label += "+"
+		}
+		label += c.gesture.label()
+	}
+	return label
+}
+
 // keyToLabel converts imgui.Key to human-readable label
 func keyToLabel(key imgui.Key) string {
 	// alphabetic keys
@@ -341,5 +396,5 @@ func keyToLabel(key imgui.Key) string {
 		return "`"
 	}
 
-	return ""
+	return gamepadKeyLabel(key)
 }
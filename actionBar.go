@@ -0,0 +1,226 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// ActionBar constants
+const (
+	ActionBarDefaultSpacing = 4
+)
+
+// ActionBarItem is a button, toggle or separator in an ActionBar.
+type ActionBarItem struct {
+	Icon      string
+	Label     string  // optional text after the icon
+	Tooltip   string  // shown on hover; defaults to the action's label and shortcut
+	Action    *Action // optional: clicking runs the action's handler
+	OnClick   func()  // called when clicked (after the action's handler)
+	Toggle    *bool   // makes the item a toggle button bound to the value
+	Separator bool    // draws a divider instead of a button
+	Hidden    bool
+}
+
+// ActionBar lays out icon buttons horizontally. items that do not fit in the available
+// width move into a "more" menu at the end of the bar.
+type ActionBar struct {
+	Container
+	Items         []*ActionBarItem
+	Spacing       float32
+	Contributions bool // also show the toolbar items of the components contributing (see MenuContribution)
+}
+
+// NewActionBar creates an empty action bar.
+func NewActionBar() *ActionBar {
+	return &ActionBar{
+		Container: Container{Visible: true},
+		Spacing:   ActionBarDefaultSpacing,
+	}
+}
+
+// Add appends a button and returns it for further configuration.
+func (b *ActionBar) Add(icon, label, tooltip string, onClick func()) *ActionBarItem {
+	return b.add(&ActionBarItem{Icon: icon, Label: label, Tooltip: tooltip, OnClick: onClick})
+}
+
+// AddAction appends an icon button running action; its tooltip shows the action's
+// label and shortcut.
+func (b *ActionBar) AddAction(icon string, action *Action) *ActionBarItem {
+	return b.add(&ActionBarItem{Icon: icon, Action: action})
+}
+
+// AddToggle appends a toggle button bound to value.
+func (b *ActionBar) AddToggle(icon, tooltip string, value *bool) *ActionBarItem {
+	return b.add(&ActionBarItem{Icon: icon, Tooltip: tooltip, Toggle: value})
+}
+
+// AddSeparator appends a divider.
+func (b *ActionBar) AddSeparator() *ActionBarItem {
+	return b.add(&ActionBarItem{Separator: true})
+}
+
+func (b *ActionBar) add(item *ActionBarItem) *ActionBarItem {
+	b.Items = append(b.Items, item)
+	return item
+}
+
+// Draw implements Component.
+func (b *ActionBar) Draw(state *State) {
+	if !b.Visible {
+		return
+	}
+	imgui.PushIDStr("##actionBar")
+	defer imgui.PopID()
+
+	all := b.Items
+	if b.Contributions {
+		all = contributions.appendToolbar(all[:len(all):len(all)])
+	}
+	items := make([]*ActionBarItem, 0, len(all))
+	widths := make([]float32, 0, len(all))
+	for _, item := range all {
+		if item.Hidden {
+			continue
+		}
+		items = append(items, item)
+		widths = append(widths, b.itemWidth(item))
+	}
+
+	available := imgui.ContentRegionAvail().X
+	moreWidth := IconButtonWidth(fonts.ICON_MORE_HORIZ, "")
+	visible := actionBarFit(widths, available, moreWidth, b.Spacing)
+	// don't end the visible part with a dangling divider
+	shown := visible
+	for shown > 0 && items[shown-1].Separator {
+		shown--
+	}
+
+	for i, item := range items[:shown] {
+		if i > 0 {
+			imgui.SameLineV(0, b.Spacing)
+		}
+		imgui.PushIDInt(int32(i))
+		b.drawItem(item)
+		imgui.PopID()
+	}
+
+	if visible < len(items) {
+		if shown > 0 {
+			imgui.SameLineV(0, b.Spacing)
+		}
+		if IconButton(fonts.ICON_MORE_HORIZ, "", "more") {
+			imgui.OpenPopupStr("##more")
+		}
+		if imgui.BeginPopup("##more") {
+			for i, item := range items[visible:] {
+				imgui.PushIDInt(int32(visible + i))
+				b.drawMenuItem(item)
+				imgui.PopID()
+			}
+			imgui.EndPopup()
+		}
+	}
+
+	drawContainerExtensions(&b.Container, state)
+}
+
+// itemWidth returns the width item occupies in the bar.
+func (b *ActionBar) itemWidth(item *ActionBarItem) float32 {
+	if item.Separator {
+		return 1
+	}
+	return IconButtonWidth(item.Icon, item.Label)
+}
+
+func (b *ActionBar) drawItem(item *ActionBarItem) {
+	if item.Separator {
+		pos := imgui.CursorScreenPos()
+		height := imgui.FrameHeight()
+		color := imgui.ColorConvertFloat4ToU32(imgui.CurrentStyle().Colors()[imgui.ColSeparator])
+		imgui.WindowDrawList().AddLine(pos, pos.Add(imgui.Vec2{Y: height}), color)
+		imgui.Dummy(imgui.Vec2{X: 1, Y: height})
+		return
+	}
+	tooltip := item.tooltip()
+	if item.Toggle != nil {
+		if IconToggle(item.Icon, item.Label, tooltip, item.Toggle) {
+			item.activate()
+		}
+		return
+	}
+	if IconButton(item.Icon, item.Label, tooltip) {
+		item.activate()
+	}
+}
+
+// drawMenuItem draws item as an entry in the overflow menu.
+func (b *ActionBar) drawMenuItem(item *ActionBarItem) {
+	if item.Separator {
+		imgui.Separator()
+		return
+	}
+	label := item.Label
+	if label == "" {
+		label = item.tooltip()
+	}
+	shortcut := ""
+	if item.Action != nil {
+		shortcut = item.Action.Keys
+	}
+	selected := item.Toggle != nil && *item.Toggle
+	if imgui.MenuItemBoolV(iconButtonText(item.Icon, label), shortcut, selected, true) {
+		if item.Toggle != nil {
+			*item.Toggle = !*item.Toggle
+		}
+		item.activate()
+	}
+}
+
+// tooltip returns the item's tooltip, falling back to its action's label and shortcut.
+func (item *ActionBarItem) tooltip() string {
+	if item.Tooltip != "" || item.Action == nil {
+		return item.Tooltip
+	}
+	label := item.Action.Label
+	if label == "" {
+		label = item.Action.Id
+	}
+	if item.Action.Keys != "" {
+		return label + " (" + item.Action.Keys + ")"
+	}
+	return label
+}
+
+func (item *ActionBarItem) activate() {
+	if item.Action != nil && item.Action.Handler != nil {
+		item.Action.Handler()
+	}
+	if item.OnClick != nil {
+		item.OnClick()
+	}
+}
+
+// actionBarFit returns how many items of the given widths fit in available width. when
+// not all fit, room is left for the overflow button.
+func actionBarFit(widths []float32, available, moreWidth, spacing float32) int {
+	total := float32(0)
+	for i, w := range widths {
+		if i > 0 {
+			total += spacing
+		}
+		total += w
+	}
+	if total <= available {
+		return len(widths)
+	}
+
+	used := moreWidth
+	for i, w := range widths {
+		if used+spacing+w > available {
+			return i
+		}
+		used += spacing + w
+	}
+	return len(widths)
+}
@@ -0,0 +1,57 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestActionBarFit(t *testing.T) {
+	widths := []float32{20, 20, 1, 20}
+	// 20 + 4 + 20 + 4 + 1 + 4 + 20 = 73
+	if n := actionBarFit(widths, 73, 20, 4); n != 4 {
+		t.Fatalf("expected all items to fit, got %v", n)
+	}
+	// overflow reserves room for the more button: 20 + 4 + 20 + 4 + 20 = 68
+	if n := actionBarFit(widths, 70, 20, 4); n != 2 {
+		t.Fatalf("expected 2 visible items, got %v", n)
+	}
+	if n := actionBarFit(widths, 10, 20, 4); n != 0 {
+		t.Fatalf("expected everything in the overflow menu, got %v", n)
+	}
+}
+
+func TestActionBarItem_Tooltip(t *testing.T) {
+	action := NewMenuAction("Save", "Ctrl+S", nil)
+	item := &ActionBarItem{Action: action}
+	if got := item.tooltip(); got != "Save (Ctrl+S)" {
+		t.Fatalf("expected action tooltip, got '%v'", got)
+	}
+	item.Tooltip = "save the file"
+	if got := item.tooltip(); got != "save the file" {
+		t.Fatalf("expected explicit tooltip, got '%v'", got)
+	}
+}
+
+func TestActionBarItem_Activate(t *testing.T) {
+	calls := []string{}
+	action := NewMenuAction("Run", "F5", func() { calls = append(calls, "action") })
+	bar := NewActionBar()
+	item := bar.AddAction("", action)
+	item.OnClick = func() { calls = append(calls, "click") }
+	item.activate()
+	if len(calls) != 2 || calls[0] != "action" || calls[1] != "click" {
+		t.Fatalf("expected action then click, got '%v'", calls)
+	}
+
+	bar.AddSeparator()
+	enabled := false
+	bar.AddToggle("", "enable", &enabled)
+	if len(bar.Items) != 3 || !bar.Items[1].Separator || bar.Items[2].Toggle != &enabled {
+		t.Fatalf("unexpected items")
+	}
+}
+
+func TestIconButtonText(t *testing.T) {
+	if iconButtonText("I", "") != "I" || iconButtonText("", "L") != "L" || iconButtonText("I", "L") != "I L" {
+		t.Fatalf("unexpected icon button text")
+	}
+}
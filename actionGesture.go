@@ -0,0 +1,115 @@
+package dfx
+
+import (
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// actionGesture is a mouse gesture an action is bound to instead of a key, named last in
+// its Keys like a key, after any modifiers: "Ctrl+WheelLeft", "DoubleClick".
+type actionGesture uint8
+
+const (
+	gestureNone        actionGesture = iota
+	gestureMiddleClick               // "MiddleClick"
+	gestureMouse4                    // "Mouse4", the back button
+	gestureMouse5                    // "Mouse5", the forward button
+	gestureDoubleClick               // "DoubleClick", with the left button
+	gestureTripleClick               // "TripleClick", with the left button
+	gestureWheelLeft                 // "WheelLeft", a horizontal wheel or trackpad swipe
+	gestureWheelRight                // "WheelRight"
+)
+
+// gestureLabels are the labels of the gestures, shown in menus and reports.
+var gestureLabels = [...]string{
+	gestureMiddleClick: "Middle Click",
+	gestureMouse4:      "Mouse 4",
+	gestureMouse5:      "Mouse 5",
+	gestureDoubleClick: "Double Click",
+	gestureTripleClick: "Triple Click",
+	gestureWheelLeft:   "Wheel Left",
+	gestureWheelRight:  "Wheel Right",
+}
+
+// parseGesture converts a gesture name to its gesture.
+func parseGesture(name string) (actionGesture, bool) {
+	switch strings.ToLower(name) {
+	case "middleclick", "mousemiddle":
+		return gestureMiddleClick, true
+	case "mouse4", "mousex1":
+		return gestureMouse4, true
+	case "mouse5", "mousex2":
+		return gestureMouse5, true
+	case "doubleclick":
+		return gestureDoubleClick, true
+	case "tripleclick":
+		return gestureTripleClick, true
+	case "wheelleft":
+		return gestureWheelLeft, true
+	case "wheelright":
+		return gestureWheelRight, true
+	}
+	return gestureNone, false
+}
+
+// label returns the gesture's label, empty for none.
+func (g actionGesture) label() string {
+	if int(g) < len(gestureLabels) {
+		return gestureLabels[g]
+	}
+	return ""
+}
+
+// performed reports whether the gesture was made this frame. a triple click is also a
+// double click on its second click.
+func (g actionGesture) performed() bool {
+	switch g {
+	case gestureMiddleClick:
+		return imgui.IsMouseClickedBool(imgui.MouseButtonMiddle)
+	case gestureMouse4:
+		return imgui.IsMouseClickedBool(imgui.MouseButton(3))
+	case gestureMouse5:
+		return imgui.IsMouseClickedBool(imgui.MouseButton(4))
+	case gestureDoubleClick:
+		return imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && imgui.MouseClickedCount(imgui.MouseButtonLeft) == 2
+	case gestureTripleClick:
+		return imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && imgui.MouseClickedCount(imgui.MouseButtonLeft) == 3
+	case gestureWheelLeft:
+		return imgui.CurrentIO().MouseWheelH() > 0
+	case gestureWheelRight:
+		return imgui.CurrentIO().MouseWheelH() < 0
+	}
+	return false
+}
+
+// actionRegions tracks the named region under the mouse, for actions bound to a Region. it
+// is only used from the UI thread.
+var actionRegions actionRegionTracker
+
+// actionRegionTracker keeps the region hovered in the frame before, as actions are
+// dispatched before the components are drawn.
+type actionRegionTracker struct {
+	frame   string // region hovered so far this frame
+	hovered string // region hovered in the frame before
+}
+
+// MarkActionRegion names the item just drawn, such as a child window after EndChild or an
+// InvisibleButton, as a region for actions bound with Action.Region: while the mouse is
+// over it, those actions run from their bindings. when regions overlap, the one marked
+// last wins.
+func MarkActionRegion(name string) {
+	if imgui.IsItemHoveredV(imgui.HoveredFlagsAllowWhenBlockedByActiveItem | imgui.HoveredFlagsAllowWhenBlockedByPopup) {
+		actionRegions.frame = name
+	}
+}
+
+// hovers reports whether an action bound to region may run: always for no region.
+func (t *actionRegionTracker) hovers(region string) bool {
+	return region == "" || region == t.hovered
+}
+
+// endFrame makes the region hovered this frame the current one.
+func (t *actionRegionTracker) endFrame() {
+	t.hovered, t.frame = t.frame, ""
+}
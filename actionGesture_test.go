@@ -0,0 +1,112 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestAction_ParsesGestures(t *testing.T) {
+	tests := []struct {
+		keys    string
+		gesture actionGesture
+		mods    KeyModifier
+		label   string
+	}{
+		{"MiddleClick", gestureMiddleClick, ModNone, "Middle Click"},
+		{"Mouse4", gestureMouse4, ModNone, "Mouse 4"},
+		{"mousex2", gestureMouse5, ModNone, "Mouse 5"},
+		{"Ctrl+DoubleClick", gestureDoubleClick, ModCtrl, "Ctrl+Double Click"},
+		{"TripleClick", gestureTripleClick, ModNone, "Triple Click"},
+		{"Shift+WheelLeft", gestureWheelLeft, ModShift, "Shift+Wheel Left"},
+		{"WheelRight", gestureWheelRight, ModNone, "Wheel Right"},
+	}
+	for _, test := range tests {
+		action := NewMenuAction("test", test.keys, func() {})
+		if action.gesture != test.gesture {
+			t.Fatalf("expected '%v', got '%v'", test.gesture, action.gesture)
+		}
+		if action.mods != test.mods {
+			t.Fatalf("expected '%v', got '%v'", test.mods, action.mods)
+		}
+		if action.shortcutLabel != test.label {
+			t.Fatalf("expected '%v', got '%v'", test.label, action.shortcutLabel)
+		}
+	}
+	if got := NewMenuAction("save", "Ctrl+S", func() {}).shortcutLabel; got != "Ctrl+S" {
+		t.Fatalf("expected '%v', got '%v'", "Ctrl+S", got)
+	}
+}
+
+func TestActionRegistry_GestureConflicts(t *testing.T) {
+	registry := NewActionRegistry()
+	if err := registry.Register("back", "Mouse4", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.Register("history", "Mouse4", func() {}) == nil {
+		t.Fatal("expected an error")
+	}
+	if err := registry.Register("forward", "Mouse5", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the same gesture in different regions does not conflict
+	if err := registry.RegisterRegion("zoom", "DoubleClick", "timeline", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := registry.RegisterRegion("rename", "DoubleClick", "tracks", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.RegisterRegion("select", "DoubleClick", "tracks", func() {}) == nil {
+		t.Fatal("expected an error")
+	}
+	if err := registry.Register("select", "DoubleClick", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestActionRegions_FollowPreviousFrame(t *testing.T) {
+	saved := actionRegions
+	t.Cleanup(func() { actionRegions = saved })
+	actionRegions = actionRegionTracker{}
+
+	if !actionRegions.hovers("") {
+		t.Fatal("expected actionRegions.hovers(\"\")")
+	}
+	if actionRegions.hovers("timeline") {
+		t.Fatal("unexpected actionRegions.hovers(\"timeline\")")
+	}
+
+	actionRegions.frame = "timeline"
+	if actionRegions.hovers("timeline") {
+		t.Fatal("regions apply from the end of the frame: unexpected actionRegions.hovers(\"timeline\")")
+	}
+	actionRegions.endFrame()
+	if !actionRegions.hovers("timeline") {
+		t.Fatal("expected actionRegions.hovers(\"timeline\")")
+	}
+	if actionRegions.hovers("tracks") {
+		t.Fatal("unexpected actionRegions.hovers(\"tracks\")")
+	}
+
+	actionRegions.endFrame()
+	if actionRegions.hovers("timeline") {
+		t.Fatal("unexpected actionRegions.hovers(\"timeline\")")
+	}
+}
+
+func TestValidateActions_GestureConflicts(t *testing.T) {
+	child := NewFunc(func(*State) {})
+	child.Actions().MustRegister("child.back", "Mouse4", func() {})
+	app := New(newEmbeddedContainerComponent(child), Config{})
+	app.Actions().MustRegister("back", "Mouse4", func() {})
+
+	report := app.ValidateActions()
+	if len(report.Conflicts) != 1 {
+		t.Fatalf("expected 1, got %d", len(report.Conflicts))
+	}
+	if got := report.Conflicts[0].Keys; got != "Mouse 4" {
+		t.Fatalf("expected '%v', got '%v'", "Mouse 4", got)
+	}
+	if len(report.Unreachable) != 1 {
+		t.Fatalf("expected 1, got %d", len(report.Unreachable))
+	}
+}
@@ -0,0 +1,192 @@
+package dfx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ActionBinding is an action and the component whose registry holds it.
+type ActionBinding struct {
+	Action    *Action
+	Component Component // nil for global actions
+}
+
+// scope describes where the action is registered.
+func (b ActionBinding) scope() string {
+	if b.Component == nil {
+		return "global"
+	}
+	return fmt.Sprintf("%T", b.Component)
+}
+
+// ActionConflict is a key binding shared by actions of several registries.
+type ActionConflict struct {
+	Keys     string          // the shared binding, as shown in menus, and its region
+	Bindings []ActionBinding // in dispatch order: the first runs, unless the component of another has focus
+}
+
+// ActionKeyError is an action whose keys do not parse, so it never runs from the keyboard.
+type ActionKeyError struct {
+	ActionBinding
+	Err error
+}
+
+// ActionReport lists the problems with the key bindings of an app's actions, found by
+// App.ValidateActions.
+type ActionReport struct {
+	Conflicts   []ActionConflict // bindings shared across registries
+	Unreachable []ActionBinding  // global actions shadowed by a component's, which never run
+	Invalid     []ActionKeyError // actions with keys that do not parse
+}
+
+// OK reports whether no problems were found.
+func (r *ActionReport) OK() bool {
+	return len(r.Conflicts) == 0 && len(r.Unreachable) == 0 && len(r.Invalid) == 0
+}
+
+// String lists the problems, one per line.
+func (r *ActionReport) String() string {
+	var b strings.Builder
+	for _, c := range r.Conflicts {
+		scopes := make([]string, len(c.Bindings))
+		for i, binding := range c.Bindings {
+			scopes[i] = fmt.Sprintf("%q (%v)", binding.Action.Id, binding.scope())
+		}
+		fmt.Fprintf(&b, "conflict: %v is bound by %v\n", c.Keys, strings.Join(scopes, ", "))
+	}
+	for _, u := range r.Unreachable {
+		fmt.Fprintf(&b, "unreachable: %q (%v) is shadowed by a component binding %v\n", u.Action.Id, u.scope(), u.Action.Keys)
+	}
+	for _, e := range r.Invalid {
+		fmt.Fprintf(&b, "invalid: %q (%v): %v\n", e.Action.Id, e.scope(), e.Err)
+	}
+	return b.String()
+}
+
+// ValidateActions checks the key bindings of the root hierarchy's and the global actions,
+// in the order keys are dispatched to them: keys bound in more than one registry, global
+// actions a component's binding keeps from ever running, and keys that do not parse.
+// actions without keys, such as menu-only actions, are skipped.
+func (app *App) ValidateActions() *ActionReport {
+	var bindings []ActionBinding
+	seen := make(map[*ActionRegistry]bool)
+	if app.root != nil {
+		bindings = appendActionBindings(bindings, app.root, seen, 0)
+	}
+	if !seen[app.actions] {
+		for _, action := range app.actions.actions {
+			bindings = append(bindings, ActionBinding{Action: action})
+		}
+	}
+	return validateBindings(bindings)
+}
+
+// appendActionBindings appends the actions of comp's hierarchy in dispatch order, as
+// appendComponentActions gathers their registries, skipping registries already seen.
+func appendActionBindings(bindings []ActionBinding, comp Component, seen map[*ActionRegistry]bool, depth int) []ActionBinding {
+	if comp == nil || depth > debugTreeMaxDepth {
+		return bindings
+	}
+	if cp, ok := comp.(ChildActionProvider); ok {
+		children := cp.ChildActions()
+		for i := len(children) - 1; i >= 0; i-- {
+			bindings = appendActionBindings(bindings, children[i], seen, depth+1)
+		}
+	}
+	if registry := componentActions(comp); registry != nil && !seen[registry] {
+		seen[registry] = true
+		for _, action := range registry.actions {
+			bindings = append(bindings, ActionBinding{Action: action, Component: comp})
+		}
+	}
+	return bindings
+}
+
+// validateBindings reports the problems with bindings, given in dispatch order.
+func validateBindings(bindings []ActionBinding) *ActionReport {
+	report := &ActionReport{}
+	groups := make(map[keyCombo][]ActionBinding)
+	var order []keyCombo
+	for _, binding := range bindings {
+		action := binding.Action
+		if action.Keys == "" {
+			continue
+		}
+		probe := Action{Keys: action.Keys}
+		if err := probe.parse(); err != nil {
+			report.Invalid = append(report.Invalid, ActionKeyError{ActionBinding: binding, Err: err})
+			continue
+		}
+		combo := action.combo()
+		if _, found := groups[combo]; !found {
+			order = append(order, combo)
+		}
+		groups[combo] = append(groups[combo], binding)
+	}
+
+	for _, combo := range order {
+		group := groups[combo]
+		if len(group) < 2 {
+			continue
+		}
+		keys := combo.label()
+		if combo.region != "" {
+			keys += " in " + combo.region
+		}
+		report.Conflicts = append(report.Conflicts, ActionConflict{Keys: keys, Bindings: group})
+		if group[0].Component == nil {
+			continue
+		}
+		for _, binding := range group[1:] {
+			if binding.Component == nil {
+				report.Unreachable = append(report.Unreachable, binding)
+			}
+		}
+	}
+	return report
+}
+
+// ActionReportView is a debug panel listing the problems App.ValidateActions finds with
+// the app's key bindings. the report is made when the view is first drawn and again when
+// its Refresh button is clicked.
+type ActionReportView struct {
+	Container
+	report *ActionReport
+}
+
+// NewActionReportView creates a view of the app's action report.
+func NewActionReportView() *ActionReportView {
+	return &ActionReportView{Container: Container{Visible: true}}
+}
+
+// Refresh makes the report again on the next draw.
+func (v *ActionReportView) Refresh() {
+	v.report = nil
+}
+
+// Draw implements Component.
+func (v *ActionReportView) Draw(state *State) {
+	if !v.Visible || state == nil || state.App == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("actionReport", v))
+	defer imgui.PopID()
+
+	if v.report == nil {
+		v.report = state.App.ValidateActions()
+	}
+	if imgui.Button(T("dfx.actions.refresh")) {
+		v.Refresh()
+	}
+	if v.report.OK() {
+		imgui.TextDisabled(T("dfx.actions.ok"))
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(v.report.String(), "\n"), "\n") {
+		if line != "" {
+			imgui.TextWrapped(line)
+		}
+	}
+	drawContainerExtensions(&v.Container, state)
+}
@@ -0,0 +1,90 @@
+package dfx
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateActions_ReportsConflictsAndUnreachable(t *testing.T) {
+	first := NewFunc(func(*State) {})
+	first.Actions().MustRegister("first.save", "Ctrl+S", func() {})
+	second := NewFunc(func(*State) {})
+	second.Actions().MustRegister("second.save", "Ctrl+S", func() {})
+	second.Actions().MustRegister("second.find", "Ctrl+F", func() {})
+	first.Actions().MustRegisterAction(&Action{Id: "menu only"}) // no keys, not reported
+	parent := newEmbeddedContainerComponent(first, second)
+
+	app := New(parent, Config{})
+	app.Actions().MustRegister("save", "Ctrl+S", func() {})
+	app.Actions().MustRegister("quit", "Ctrl+Q", func() {})
+	app.Actions().MustRegisterAction(&Action{Id: "broken", Keys: "Ctrl+Nope"})
+
+	report := app.ValidateActions()
+	if report.OK() {
+		t.Fatal("unexpected report.OK()")
+	}
+
+	// later children are dispatched to first
+	if len(report.Conflicts) != 1 {
+		t.Fatalf("expected 1, got %d", len(report.Conflicts))
+	}
+	c := report.Conflicts[0]
+	if c.Keys != "Ctrl+S" {
+		t.Fatalf("expected '%v', got '%v'", "Ctrl+S", c.Keys)
+	}
+	ids := make([]string, len(c.Bindings))
+	for i, binding := range c.Bindings {
+		ids[i] = binding.Action.Id
+	}
+	if !slices.Equal(ids, []string{"second.save", "first.save", "save"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"second.save", "first.save", "save"}, ids)
+	}
+	if got := c.Bindings[0].Component; got != second {
+		t.Fatalf("expected '%v', got '%v'", second, got)
+	}
+	if len(report.Unreachable) != 1 {
+		t.Fatalf("expected 1, got %d", len(report.Unreachable))
+	}
+	if got := report.Unreachable[0].Action.Id; got != "save" {
+		t.Fatalf("expected '%v', got '%v'", "save", got)
+	}
+	if got := report.Unreachable[0].Component; got != nil {
+		t.Fatalf("expected nil, got '%v'", got)
+	}
+	if len(report.Invalid) != 1 {
+		t.Fatalf("expected 1, got %d", len(report.Invalid))
+	}
+	if got := report.Invalid[0].Action.Id; got != "broken" {
+		t.Fatalf("expected '%v', got '%v'", "broken", got)
+	}
+	if !strings.Contains(report.Invalid[0].Err.Error(), "Nope") {
+		t.Fatalf("expected '%v' to contain '%v'", report.Invalid[0].Err.Error(), "Nope")
+	}
+
+	text := report.String()
+	if !strings.Contains(text, `conflict: Ctrl+S is bound by "second.save" (*dfx.Func), "first.save" (*dfx.Func), "save" (global)`) {
+		t.Fatalf("expected '%v' to contain '%v'", text, `conflict: Ctrl+S is bound by "second.save" (*dfx.Func), "first.save" (*dfx.Func), "save" (global)`)
+	}
+	if !strings.Contains(text, `unreachable: "save" (global)`) {
+		t.Fatalf("expected '%v' to contain '%v'", text, `unreachable: "save" (global)`)
+	}
+	if !strings.Contains(text, `invalid: "broken" (global)`) {
+		t.Fatalf("expected '%v' to contain '%v'", text, `invalid: "broken" (global)`)
+	}
+}
+
+func TestValidateActions_OK(t *testing.T) {
+	child := NewFunc(func(*State) {})
+	child.Actions().MustRegister("find", "Ctrl+F", func() {})
+	app := New(newEmbeddedContainerComponent(child), Config{})
+	app.Actions().MustRegister("quit", "Ctrl+Q", func() {})
+
+	report := app.ValidateActions()
+	if !report.OK() {
+		t.Fatal("expected report.OK()")
+	}
+	if got := report.String(); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
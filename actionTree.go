@@ -0,0 +1,82 @@
+package dfx
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// actionTreeVersion increases when the app is told the component tree changed (see
+// InvalidateActions), so the action registries gathered from it for key dispatch can be
+// kept between frames instead of gathered every frame.
+var actionTreeVersion atomic.Uint64
+
+// InvalidateActions tells the app the component tree changed, so the actions of its
+// components are gathered again before the next key is dispatched. the app notices by
+// itself when the components reached through ChildActions change; call it when a
+// component returns a different registry from Actions or LocalActions.
+func InvalidateActions() {
+	actionTreeVersion.Add(1)
+}
+
+// actionTreeSignature returns a signature of the identities of comp and the components
+// reached from it through ChildActions, in order. it changes when a child is added,
+// removed, replaced or moved, however the container draws its children.
+func actionTreeSignature(signature uint64, comp Component) uint64 {
+	var addr uintptr
+	if v := reflect.ValueOf(comp); v.Kind() == reflect.Pointer {
+		addr = v.Pointer()
+	}
+	signature = signature*31 + uint64(addr) + 1
+	if provider, ok := comp.(ChildActionProvider); ok {
+		children := provider.ChildActions()
+		for _, child := range children {
+			signature = actionTreeSignature(signature, child)
+		}
+		signature = signature*31 + uint64(len(children))
+	}
+	return signature
+}
+
+// actionCache holds the registries gathered for key dispatch, until the component tree or
+// the focused component changes.
+type actionCache struct {
+	valid      bool
+	version    uint64
+	focused    Component
+	signature  uint64            // actionTreeSignature of the focused and root hierarchies
+	registries []*ActionRegistry // reused between gatherings
+}
+
+// dispatchRegistries returns the registries keys are dispatched to, in order: the focused
+// component's, the root hierarchy's, then the global actions. they are gathered again
+// when the tree was invalidated, the focus moved or the children of a component changed;
+// checking the children only walks ChildActions, which costs far less than gathering. the
+// slice is reused, so it is only valid until the next call.
+func (app *App) dispatchRegistries() []*ActionRegistry {
+	c := &app.actionCache
+	focused := app.focus.Focused()
+	version := actionTreeVersion.Load()
+	var signature uint64
+	if focused != nil {
+		signature = actionTreeSignature(signature, focused)
+	}
+	if app.root != nil {
+		signature = actionTreeSignature(signature, app.root)
+	}
+	if c.valid && c.version == version && c.focused == focused && c.signature == signature {
+		return c.registries
+	}
+
+	registries := c.registries[:0]
+	if focused != nil {
+		registries = appendComponentActions(registries, focused)
+	}
+	if app.root != nil {
+		registries = appendComponentActions(registries, app.root)
+	}
+	registries = append(registries, app.actions)
+	clear(registries[len(registries):cap(registries)]) // drop registries of removed components
+
+	*c = actionCache{valid: true, version: version, focused: focused, signature: signature, registries: registries}
+	return registries
+}
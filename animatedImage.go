@@ -0,0 +1,497 @@
+package dfx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// frame delays shorter than minFrameDelay (including 0) play at defaultFrameDelay, as
+// browsers do.
+const (
+	minFrameDelay     = 20 * time.Millisecond
+	defaultFrameDelay = 100 * time.Millisecond
+)
+
+// ImageFrame is a frame of an animated image, composed onto the full canvas.
+type ImageFrame struct {
+	Image *image.RGBA
+	Delay time.Duration // how long the frame is shown
+}
+
+// ImageAnimation is a decoded GIF or APNG animation.
+type ImageAnimation struct {
+	Width  int
+	Height int
+	Frames []ImageFrame
+	Loops  int // times the animation plays (0 = forever)
+}
+
+// Duration returns the length of one pass through the frames.
+func (a *ImageAnimation) Duration() time.Duration {
+	var total time.Duration
+	for _, f := range a.Frames {
+		total += f.Delay
+	}
+	return total
+}
+
+// DecodeImageAnimation decodes a GIF or APNG animation. any other image dfx can decode
+// (PNG, JPEG) becomes a single frame.
+func DecodeImageAnimation(data []byte) (*ImageAnimation, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return decodeGIF(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return decodeAPNG(data)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding image")
+	}
+	return stillAnimation(img), nil
+}
+
+// LoadImageAnimation reads and decodes a GIF or APNG file.
+func LoadImageAnimation(path string) (*ImageAnimation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading image '%v'", path)
+	}
+	anim, err := DecodeImageAnimation(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding '%v'", path)
+	}
+	return anim, nil
+}
+
+// AnimatedImage plays a GIF or APNG animation, advancing on the frame clock. the frames
+// are uploaded through a TextureManager as they are first shown; call Release when the
+// image is no longer needed.
+type AnimatedImage struct {
+	Container
+	Scale    ImageScale
+	Size     imgui.Vec2 // space to draw in (0 = available region, per axis)
+	Tint     imgui.Vec4 // color multiplied with the image (zero = white)
+	Loops    int        // times the animation plays before stopping on the last frame (0 = forever); set from the file
+	Speed    float32    // playback rate (0 = 1)
+	OnFinish func()     // called when the animation stops after Loops plays
+
+	frames  []*Texture
+	delays  []time.Duration
+	frame   int
+	elapsed time.Duration // time the current frame has been shown
+	played  int           // completed plays
+	playing bool
+}
+
+// NewAnimatedImage creates a playing image showing anim, with its frames created in
+// textures (such as App.Textures()).
+func NewAnimatedImage(textures *TextureManager, anim *ImageAnimation) *AnimatedImage {
+	a := &AnimatedImage{
+		Container: Container{Visible: true},
+		Loops:     anim.Loops,
+		playing:   true,
+	}
+	for _, f := range anim.Frames {
+		a.frames = append(a.frames, textures.Create(f.Image))
+		a.delays = append(a.delays, f.Delay)
+	}
+	return a
+}
+
+// Play starts or resumes playback, from the start when the animation had finished.
+func (a *AnimatedImage) Play() {
+	if a.finished() {
+		a.Rewind()
+	}
+	a.playing = true
+}
+
+// Pause stops playback on the current frame.
+func (a *AnimatedImage) Pause() {
+	a.playing = false
+}
+
+// Toggle pauses a playing animation, or plays a paused one.
+func (a *AnimatedImage) Toggle() {
+	if a.playing {
+		a.Pause()
+	} else {
+		a.Play()
+	}
+}
+
+// Playing returns true while the animation is playing.
+func (a *AnimatedImage) Playing() bool {
+	return a.playing
+}
+
+// Rewind returns to the first frame and resets the play count.
+func (a *AnimatedImage) Rewind() {
+	a.frame, a.elapsed, a.played = 0, 0, 0
+}
+
+// Frame returns the index of the frame shown.
+func (a *AnimatedImage) Frame() int {
+	return a.frame
+}
+
+// SetFrame shows frame i, such as to step through a paused animation.
+func (a *AnimatedImage) SetFrame(i int) {
+	if i >= 0 && i < len(a.frames) {
+		a.frame, a.elapsed = i, 0
+	}
+}
+
+// FrameCount returns the number of frames.
+func (a *AnimatedImage) FrameCount() int {
+	return len(a.frames)
+}
+
+// Release releases the frame textures.
+func (a *AnimatedImage) Release() {
+	for _, t := range a.frames {
+		t.Release()
+	}
+}
+
+// Draw implements Component.
+func (a *AnimatedImage) Draw(state *State) {
+	if !a.Visible {
+		return
+	}
+	if state != nil {
+		a.advance(state.DeltaTime)
+	}
+	region := a.Size
+	avail := imgui.ContentRegionAvail()
+	if region.X <= 0 {
+		region.X = avail.X
+	}
+	if region.Y <= 0 {
+		region.Y = avail.Y
+	}
+	var texture *Texture
+	if a.frame < len(a.frames) {
+		texture = a.frames[a.frame]
+	}
+	drawTexture(texture, region, a.Scale, imgui.Vec2{}, imgui.Vec2{}, a.Tint)
+	drawContainerExtensions(&a.Container, state)
+}
+
+// advance moves the animation on by dt.
+func (a *AnimatedImage) advance(dt time.Duration) {
+	if !a.playing || len(a.delays) < 2 || dt <= 0 {
+		return
+	}
+	speed := a.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	a.elapsed += time.Duration(float64(dt) * float64(speed))
+	if a.Loops == 0 {
+		// skip whole passes after a long frame, such as when the window was hidden
+		if total := a.duration(); total > 0 && a.elapsed >= total {
+			a.elapsed %= total
+		}
+	}
+	for a.elapsed >= a.delays[a.frame] {
+		a.elapsed -= a.delays[a.frame]
+		if a.frame+1 < len(a.delays) {
+			a.frame++
+			continue
+		}
+		a.played++
+		if a.Loops > 0 && a.played >= a.Loops {
+			a.playing, a.elapsed = false, 0
+			if a.OnFinish != nil {
+				a.OnFinish()
+			}
+			return
+		}
+		a.frame = 0
+	}
+}
+
+// finished returns true when the animation stopped after playing Loops times.
+func (a *AnimatedImage) finished() bool {
+	return a.Loops > 0 && a.played >= a.Loops
+}
+
+func (a *AnimatedImage) duration() time.Duration {
+	var total time.Duration
+	for _, d := range a.delays {
+		total += d
+	}
+	return total
+}
+
+// frameDelay applies minFrameDelay to a delay read from a file.
+func frameDelay(d time.Duration) time.Duration {
+	if d < minFrameDelay {
+		return defaultFrameDelay
+	}
+	return d
+}
+
+// stillAnimation returns a single-frame animation showing img.
+func stillAnimation(img image.Image) *ImageAnimation {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return &ImageAnimation{
+		Width:  b.Dx(),
+		Height: b.Dy(),
+		Frames: []ImageFrame{{Image: rgba, Delay: defaultFrameDelay}},
+		Loops:  1,
+	}
+}
+
+// cloneRGBA returns a copy of img.
+func cloneRGBA(img *image.RGBA) *image.RGBA {
+	out := image.NewRGBA(img.Rect)
+	copy(out.Pix, img.Pix)
+	return out
+}
+
+// decodeGIF decodes a GIF, composing each frame onto the canvas left by the previous one
+// according to its disposal method.
+func decodeGIF(data []byte) (*ImageAnimation, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding gif")
+	}
+	anim := &ImageAnimation{Width: g.Config.Width, Height: g.Config.Height}
+	switch {
+	case g.LoopCount == 0:
+		anim.Loops = 0
+	case g.LoopCount < 0:
+		anim.Loops = 1
+	default:
+		anim.Loops = g.LoopCount + 1
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, anim.Width, anim.Height))
+	for i, frame := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		anim.Frames = append(anim.Frames, ImageFrame{
+			Image: cloneRGBA(canvas),
+			Delay: frameDelay(time.Duration(g.Delay[i]) * 10 * time.Millisecond),
+		})
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return anim, nil
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngChunk is a chunk of a PNG file.
+type pngChunk struct {
+	kind string
+	data []byte
+}
+
+// readPNGChunks splits a PNG file into its chunks.
+func readPNGChunks(data []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errors.New("not a png")
+	}
+	var chunks []pngChunk
+	for rest := data[len(pngSignature):]; len(rest) > 0; {
+		if len(rest) < 12 {
+			return nil, errors.New("truncated png chunk")
+		}
+		length := binary.BigEndian.Uint32(rest)
+		if uint64(len(rest)) < 12+uint64(length) {
+			return nil, errors.New("truncated png chunk")
+		}
+		chunks = append(chunks, pngChunk{kind: string(rest[4:8]), data: rest[8 : 8+length]})
+		rest = rest[12+length:]
+	}
+	return chunks, nil
+}
+
+// writePNGChunk appends a chunk to buf.
+func writePNGChunk(buf *bytes.Buffer, kind string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], kind)
+	buf.Write(header[:])
+	buf.Write(data)
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	buf.Write(sum[:])
+}
+
+// apngFrame is a frame control (fcTL) chunk of an APNG and the image data of its frame.
+type apngFrame struct {
+	rect           image.Rectangle
+	delay          time.Duration
+	dispose, blend byte
+	data           [][]byte
+}
+
+// APNG dispose and blend operations
+const (
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+	apngBlendOver         = 1
+)
+
+// decodeAPNG decodes an animated PNG. each frame is rebuilt as a PNG of its own, sharing
+// the palette and other ancillary chunks, decoded with image/png and composed onto the
+// canvas. a PNG without an animation control chunk is decoded as a still image.
+func decodeAPNG(data []byte) (*ImageAnimation, error) {
+	chunks, err := readPNGChunks(data)
+	if err != nil {
+		return nil, err
+	}
+	var ihdr []byte
+	var shared []pngChunk // ancillary chunks before the image data, such as PLTE and tRNS
+	var frames []*apngFrame
+	animated, seenIDAT := false, false
+	loops := 0
+	for _, c := range chunks {
+		switch c.kind {
+		case "IHDR":
+			ihdr = c.data
+		case "acTL":
+			if len(c.data) < 8 {
+				return nil, errors.New("invalid acTL chunk")
+			}
+			animated = true
+			loops = int(binary.BigEndian.Uint32(c.data[4:]))
+		case "fcTL":
+			f, err := parseFrameControl(c.data)
+			if err != nil {
+				return nil, err
+			}
+			frames = append(frames, f)
+		case "IDAT":
+			seenIDAT = true
+			// the default image is the first frame when a fcTL precedes it
+			if len(frames) == 1 {
+				frames[0].data = append(frames[0].data, c.data)
+			}
+		case "fdAT":
+			if len(c.data) < 4 || len(frames) == 0 {
+				return nil, errors.New("invalid fdAT chunk")
+			}
+			f := frames[len(frames)-1]
+			f.data = append(f.data, c.data[4:])
+		case "IEND":
+		default:
+			if !seenIDAT {
+				shared = append(shared, c)
+			}
+		}
+	}
+	if len(ihdr) != 13 {
+		return nil, errors.New("invalid IHDR chunk")
+	}
+	if !animated || len(frames) == 0 {
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding png")
+		}
+		return stillAnimation(img), nil
+	}
+
+	width, height := int(binary.BigEndian.Uint32(ihdr)), int(binary.BigEndian.Uint32(ihdr[4:]))
+	anim := &ImageAnimation{Width: width, Height: height, Loops: loops}
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i, f := range frames {
+		if !f.rect.In(canvas.Rect) || f.rect.Empty() {
+			return nil, errors.Errorf("apng frame %v lies outside the image", i)
+		}
+		img, err := decodeAPNGFrame(ihdr, shared, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding apng frame %v", i)
+		}
+		dispose := f.dispose
+		if i == 0 && dispose == apngDisposePrevious {
+			dispose = apngDisposeBackground
+		}
+		var previous *image.RGBA
+		if dispose == apngDisposePrevious {
+			previous = cloneRGBA(canvas)
+		}
+		op := draw.Src
+		if f.blend == apngBlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, f.rect, img, img.Bounds().Min, op)
+		anim.Frames = append(anim.Frames, ImageFrame{Image: cloneRGBA(canvas), Delay: f.delay})
+		switch dispose {
+		case apngDisposeBackground:
+			draw.Draw(canvas, f.rect, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			canvas = previous
+		}
+	}
+	return anim, nil
+}
+
+// parseFrameControl reads a fcTL chunk.
+func parseFrameControl(data []byte) (*apngFrame, error) {
+	if len(data) < 26 {
+		return nil, errors.New("invalid fcTL chunk")
+	}
+	w, h := int(binary.BigEndian.Uint32(data[4:])), int(binary.BigEndian.Uint32(data[8:]))
+	x, y := int(binary.BigEndian.Uint32(data[12:])), int(binary.BigEndian.Uint32(data[16:]))
+	num, den := binary.BigEndian.Uint16(data[20:]), binary.BigEndian.Uint16(data[22:])
+	if den == 0 {
+		den = 100
+	}
+	return &apngFrame{
+		rect:    image.Rect(x, y, x+w, y+h),
+		delay:   frameDelay(time.Duration(num) * time.Second / time.Duration(den)),
+		dispose: data[24],
+		blend:   data[25],
+	}, nil
+}
+
+// decodeAPNGFrame decodes the image of a frame, as a PNG the size of the frame.
+func decodeAPNGFrame(ihdr []byte, shared []pngChunk, f *apngFrame) (image.Image, error) {
+	header := append([]byte(nil), ihdr...)
+	binary.BigEndian.PutUint32(header, uint32(f.rect.Dx()))
+	binary.BigEndian.PutUint32(header[4:], uint32(f.rect.Dy()))
+
+	var buf bytes.Buffer
+	buf.Write(pngSignature)
+	writePNGChunk(&buf, "IHDR", header)
+	for _, c := range shared {
+		writePNGChunk(&buf, c.kind, c.data)
+	}
+	for _, d := range f.data {
+		writePNGChunk(&buf, "IDAT", d)
+	}
+	writePNGChunk(&buf, "IEND", nil)
+	return png.Decode(&buf)
+}
@@ -0,0 +1,242 @@
+package dfx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+var (
+	testRed  = color.RGBA{R: 255, A: 255}
+	testBlue = color.RGBA{B: 255, A: 255}
+)
+
+func solidPaletted(rect image.Rectangle, c color.Color) *image.Paletted {
+	img := image.NewPaletted(rect, color.Palette{color.Transparent, testRed, testBlue})
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDecodeImageAnimation_GIF(t *testing.T) {
+	g := &gif.GIF{
+		Image:     []*image.Paletted{solidPaletted(image.Rect(0, 0, 4, 4), testRed), solidPaletted(image.Rect(2, 2, 4, 4), testBlue)},
+		Delay:     []int{5, 0},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalBackground},
+		LoopCount: 2,
+		Config:    image.Config{Width: 4, Height: 4},
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := DecodeImageAnimation(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anim.Width != 4 {
+		t.Fatalf("expected '%v', got '%v'", 4, anim.Width)
+	}
+	if anim.Loops != 3 {
+		t.Fatalf("expected '%v', got '%v'", 3, anim.Loops)
+	}
+	if len(anim.Frames) != 2 {
+		t.Fatalf("expected 2, got %d", len(anim.Frames))
+	}
+	if got := anim.Frames[0].Delay; got != 50*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 50*time.Millisecond, got)
+	}
+	if got := anim.Frames[1].Delay; got != defaultFrameDelay {
+		t.Fatalf("expected '%v', got '%v'", defaultFrameDelay, got)
+	}
+
+	// the second frame draws over the first
+	second := anim.Frames[1].Image
+	if got := second.RGBAAt(0, 0); got != testRed {
+		t.Fatalf("expected '%v', got '%v'", testRed, got)
+	}
+	if got := second.RGBAAt(3, 3); got != testBlue {
+		t.Fatalf("expected '%v', got '%v'", testBlue, got)
+	}
+}
+
+// encodeTestAPNG builds an APNG from frames placed at their bounds, each shown for 1/10s.
+func encodeTestAPNG(t *testing.T, plays int, frames ...image.Image) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	out.Write(pngSignature)
+	seq := uint32(0)
+	for i, img := range frames {
+		var enc bytes.Buffer
+		if err := png.Encode(&enc, img); err != nil {
+			t.Fatal(err)
+		}
+		chunks, err := readPNGChunks(enc.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := img.Bounds()
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl, seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(b.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(b.Dy()))
+		binary.BigEndian.PutUint32(fctl[12:], uint32(b.Min.X))
+		binary.BigEndian.PutUint32(fctl[16:], uint32(b.Min.Y))
+		binary.BigEndian.PutUint16(fctl[20:], 1)
+		binary.BigEndian.PutUint16(fctl[22:], 10)
+		fctl[25] = apngBlendOver
+		seq++
+		if i > 0 {
+			writePNGChunk(&out, "fcTL", fctl)
+		}
+		for _, c := range chunks {
+			switch {
+			case c.kind == "IHDR" && i == 0:
+				writePNGChunk(&out, "IHDR", c.data)
+				actl := make([]byte, 8)
+				binary.BigEndian.PutUint32(actl, uint32(len(frames)))
+				binary.BigEndian.PutUint32(actl[4:], uint32(plays))
+				writePNGChunk(&out, "acTL", actl)
+				writePNGChunk(&out, "fcTL", fctl)
+			case c.kind == "IDAT" && i == 0:
+				writePNGChunk(&out, "IDAT", c.data)
+			case c.kind == "IDAT":
+				fdat := binary.BigEndian.AppendUint32(nil, seq)
+				seq++
+				writePNGChunk(&out, "fdAT", append(fdat, c.data...))
+			}
+		}
+	}
+	writePNGChunk(&out, "IEND", nil)
+	return out.Bytes()
+}
+
+func solidRGBA(rect image.Rectangle, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDecodeImageAnimation_APNG(t *testing.T) {
+	data := encodeTestAPNG(t, 0, solidRGBA(image.Rect(0, 0, 4, 4), testRed), solidRGBA(image.Rect(1, 1, 3, 3), testBlue))
+	anim, err := DecodeImageAnimation(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anim.Loops != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, anim.Loops)
+	}
+	if len(anim.Frames) != 2 {
+		t.Fatalf("expected 2, got %d", len(anim.Frames))
+	}
+	if got := anim.Frames[0].Delay; got != 100*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 100*time.Millisecond, got)
+	}
+	if got := anim.Frames[1].Image.RGBAAt(0, 0); got != testRed {
+		t.Fatalf("expected '%v', got '%v'", testRed, got)
+	}
+	if got := anim.Frames[1].Image.RGBAAt(1, 1); got != testBlue {
+		t.Fatalf("expected '%v', got '%v'", testBlue, got)
+	}
+	if got := anim.Frames[1].Image.RGBAAt(3, 3); got != testRed {
+		t.Fatalf("expected '%v', got '%v'", testRed, got)
+	}
+}
+
+func TestDecodeImageAnimation_StillPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, solidRGBA(image.Rect(0, 0, 2, 3), testRed)); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := DecodeImageAnimation(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Frames) != 1 {
+		t.Fatalf("expected 1, got %d", len(anim.Frames))
+	}
+	if anim.Height != 3 {
+		t.Fatalf("expected '%v', got '%v'", 3, anim.Height)
+	}
+}
+
+func newTestAnimatedImage(loops int, delays ...time.Duration) *AnimatedImage {
+	m, _ := newTestTextureManager()
+	anim := &ImageAnimation{Width: 1, Height: 1, Loops: loops}
+	for _, d := range delays {
+		anim.Frames = append(anim.Frames, ImageFrame{Image: image.NewRGBA(image.Rect(0, 0, 1, 1)), Delay: d})
+	}
+	return NewAnimatedImage(m, anim)
+}
+
+func TestAnimatedImage_AdvancesWithFrameTiming(t *testing.T) {
+	a := newTestAnimatedImage(0, 100*time.Millisecond, 50*time.Millisecond, 100*time.Millisecond)
+	a.advance(90 * time.Millisecond)
+	if got := a.Frame(); got != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, got)
+	}
+	a.advance(20 * time.Millisecond)
+	if got := a.Frame(); got != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, got)
+	}
+	a.advance(50 * time.Millisecond)
+	if got := a.Frame(); got != 2 {
+		t.Fatalf("expected '%v', got '%v'", 2, got)
+	}
+	a.advance(100 * time.Millisecond)
+	if got := a.Frame(); got != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, got)
+	} // loops forever
+
+	a.advance(10*time.Second + 120*time.Millisecond) // whole passes are skipped
+	if got := a.Frame(); got != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, got)
+	}
+
+	a.Pause()
+	a.advance(time.Second)
+	if got := a.Frame(); got != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, got)
+	}
+}
+
+func TestAnimatedImage_StopsAfterLoops(t *testing.T) {
+	a := newTestAnimatedImage(2, 100*time.Millisecond, 100*time.Millisecond)
+	finished := 0
+	a.OnFinish = func() { finished++ }
+	a.Speed = 2
+	a.advance(150 * time.Millisecond)
+	if !a.Playing() {
+		t.Fatal("expected a.Playing()")
+	}
+	a.advance(100 * time.Millisecond)
+	if a.Playing() {
+		t.Fatal("unexpected a.Playing()")
+	}
+	if got := a.Frame(); got != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, got)
+	} // stays on the last frame
+	if finished != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, finished)
+	}
+
+	a.Play()
+	if got := a.Frame(); got != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, got)
+	}
+	if !a.Playing() {
+		t.Fatal("expected a.Playing()")
+	}
+}
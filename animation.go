@@ -0,0 +1,184 @@
+package dfx
+
+import (
+	"math"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Easing maps linear progress t in [0, 1] to eased progress. eased progress starts at 0
+// and ends at 1, but may overshoot in between (see EaseSpring).
+type Easing func(t float32) float32
+
+// DefaultEasing is used by animations that do not specify an easing.
+var DefaultEasing Easing = EaseInOutCubic
+
+// EaseLinear progresses at a constant rate.
+func EaseLinear(t float32) float32 {
+	return t
+}
+
+// EaseInOut accelerates and decelerates gently (sine).
+func EaseInOut(t float32) float32 {
+	return float32(-(math.Cos(math.Pi*float64(t)) - 1) / 2)
+}
+
+// EaseInCubic starts slowly and accelerates.
+func EaseInCubic(t float32) float32 {
+	return t * t * t
+}
+
+// EaseOutCubic starts quickly and decelerates.
+func EaseOutCubic(t float32) float32 {
+	u := 1 - t
+	return 1 - u*u*u
+}
+
+// EaseInOutCubic accelerates through the first half and decelerates through the second.
+func EaseInOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	u := -2*t + 2
+	return 1 - u*u*u/2
+}
+
+// EaseSpring overshoots the target and settles like a damped spring.
+func EaseSpring(t float32) float32 {
+	if t >= 1 {
+		return 1
+	}
+	return float32(1 - math.Exp(-6*float64(t))*math.Cos(2.5*math.Pi*float64(t)))
+}
+
+// Animation interpolates a value toward a target over time. setting a new target
+// starts a new transition from the current value, so retargeting mid-animation is
+// smooth.
+type Animation struct {
+	Duration time.Duration // transition length; zero jumps straight to the target
+	Easing   Easing        // easing curve (nil = DefaultEasing)
+
+	from, to float32
+	start    time.Time
+	running  bool
+	now      func() time.Time
+}
+
+// NewAnimation creates an animation resting at value.
+func NewAnimation(value float32, duration time.Duration, easing Easing) *Animation {
+	return &Animation{Duration: duration, Easing: easing, from: value, to: value}
+}
+
+// SetTarget starts a transition from the current value to target. setting the same
+// target again leaves a running transition alone.
+func (a *Animation) SetTarget(target float32) {
+	a.SetTargetAt(target, a.clock())
+}
+
+// SetTargetAt is SetTarget at time now, such as State.Now.
+func (a *Animation) SetTargetAt(target float32, now time.Time) {
+	if target == a.to {
+		return
+	}
+	a.from = a.ValueAt(now)
+	a.to = target
+	a.start = now
+	a.running = true
+}
+
+// Snap jumps to value, stopping any transition.
+func (a *Animation) Snap(value float32) {
+	a.from = value
+	a.to = value
+	a.running = false
+}
+
+// Value returns the current value.
+func (a *Animation) Value() float32 {
+	return a.ValueAt(a.clock())
+}
+
+// ValueAt returns the value at time now, such as State.Now.
+func (a *Animation) ValueAt(now time.Time) float32 {
+	if !a.running {
+		return a.to
+	}
+	t := a.progress(now)
+	if t >= 1 {
+		a.running = false
+		return a.to
+	}
+	easing := a.Easing
+	if easing == nil {
+		easing = DefaultEasing
+	}
+	return a.from + (a.to-a.from)*easing(t)
+}
+
+// Target returns the value the animation is heading toward.
+func (a *Animation) Target() float32 {
+	return a.to
+}
+
+// Running reports whether a transition is in progress.
+func (a *Animation) Running() bool {
+	return a.running && a.progress(a.clock()) < 1
+}
+
+func (a *Animation) progress(now time.Time) float32 {
+	if a.Duration <= 0 {
+		return 1
+	}
+	return float32(now.Sub(a.start)) / float32(a.Duration)
+}
+
+func (a *Animation) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// animations holds the state behind Animate, keyed by imgui id.
+var animations = make(map[imgui.ID]*animateEntry)
+var animationsPruned time.Time
+
+type animateEntry struct {
+	anim *Animation
+	used time.Time
+}
+
+// animationIdleTimeout is how long Animate keeps state for ids that are no longer drawn.
+const animationIdleTimeout = 10 * time.Second
+
+// Animate is an immediate-mode helper: it returns a value that moves smoothly toward
+// target whenever target changes, timed by the frame clock (state.Clock). state is kept
+// per id within the current imgui id scope. the first call for an id returns target
+// without animating.
+//
+//	width := dfx.Animate(state, "panel", targetWidth, 200*time.Millisecond, dfx.EaseOutCubic)
+func Animate(state *State, id string, target float32, duration time.Duration, easing Easing) float32 {
+	key := imgui.IDStr(id)
+	now := state.Clock()
+
+	entry, ok := animations[key]
+	if !ok {
+		entry = &animateEntry{anim: NewAnimation(target, duration, easing)}
+		animations[key] = entry
+	}
+	entry.used = now
+	entry.anim.Duration = duration
+	entry.anim.Easing = easing
+	entry.anim.SetTargetAt(target, now)
+
+	if now.Sub(animationsPruned) > animationIdleTimeout {
+		animationsPruned = now
+		for k, e := range animations {
+			if now.Sub(e.used) > animationIdleTimeout {
+				delete(animations, k)
+			}
+		}
+	}
+	return entry.anim.ValueAt(now)
+}
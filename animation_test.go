@@ -0,0 +1,120 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEasing_Endpoints(t *testing.T) {
+	easings := map[string]Easing{
+		"linear":     EaseLinear,
+		"inOut":      EaseInOut,
+		"inCubic":    EaseInCubic,
+		"outCubic":   EaseOutCubic,
+		"inOutCubic": EaseInOutCubic,
+		"spring":     EaseSpring,
+	}
+	for name, easing := range easings {
+		if v := easing(0); math.Abs(float64(v)) > 1e-6 {
+			t.Fatalf("%v: expected '0' at start, got '%v'", name, v)
+		}
+		if v := easing(1); math.Abs(float64(v-1)) > 1e-6 {
+			t.Fatalf("%v: expected '1' at end, got '%v'", name, v)
+		}
+	}
+	if v := EaseInOutCubic(0.5); v != 0.5 {
+		t.Fatalf("expected in-out cubic midpoint '0.5', got '%v'", v)
+	}
+}
+
+func TestEaseSpring_Overshoots(t *testing.T) {
+	peak := float32(0)
+	for i := 0; i <= 100; i++ {
+		if v := EaseSpring(float32(i) / 100); v > peak {
+			peak = v
+		}
+	}
+	if peak <= 1 {
+		t.Fatalf("expected spring to overshoot, peak '%v'", peak)
+	}
+}
+
+func TestAnimation_TimeBased(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	a := NewAnimation(0, time.Second, EaseLinear)
+	a.now = func() time.Time { return clock }
+
+	a.SetTarget(100)
+	clock = clock.Add(250 * time.Millisecond)
+	if v := a.Value(); v != 25 {
+		t.Fatalf("expected '25', got '%v'", v)
+	}
+
+	// retargeting starts a new transition from the current value
+	a.SetTarget(0)
+	clock = clock.Add(500 * time.Millisecond)
+	if v := a.Value(); v != 12.5 {
+		t.Fatalf("expected '12.5', got '%v'", v)
+	}
+
+	clock = clock.Add(time.Second)
+	if v := a.Value(); v != 0 || a.Running() {
+		t.Fatalf("expected finished at '0', got '%v' (running %v)", v, a.Running())
+	}
+}
+
+func TestAnimation_FrameTime(t *testing.T) {
+	frame := time.Unix(1000, 0)
+	a := NewAnimation(0, time.Second, EaseLinear)
+	a.SetTargetAt(100, frame)
+	if v := a.ValueAt(frame.Add(500 * time.Millisecond)); v != 50 {
+		t.Fatalf("expected '50', got '%v'", v)
+	}
+	if v := a.ValueAt(frame.Add(2 * time.Second)); v != 100 {
+		t.Fatalf("expected '100', got '%v'", v)
+	}
+}
+
+func TestHCollapse_AnimatesWithFrameTime(t *testing.T) {
+	frame := time.Unix(1000, 0)
+	h := NewHCollapse(nil, HCollapseConfig{ExpandedWidth: 200, TransitionMs: 100})
+	h.Easing = EaseLinear
+	h.animate(frame)
+	h.Expanded = true
+	h.animate(frame)
+	h.animate(frame.Add(time.Duration(h.TransitionMs/2) * time.Millisecond))
+	middle := h.MinWidth + (h.ExpandedWidth-h.MinWidth)/2
+	if math.Abs(float64(h.CurrentWidth-middle)) > 0.5 {
+		t.Fatalf("expected width '%v' halfway through, got '%v'", middle, h.CurrentWidth)
+	}
+}
+
+func TestAnimation_ZeroDurationJumps(t *testing.T) {
+	a := NewAnimation(10, 0, nil)
+	a.SetTarget(50)
+	if v := a.Value(); v != 50 {
+		t.Fatalf("expected '50', got '%v'", v)
+	}
+	a.Snap(20)
+	if v := a.Value(); v != 20 || a.Target() != 20 {
+		t.Fatalf("expected snap to '20', got '%v'", v)
+	}
+}
+
+func TestHCollapse_StartsAtTargetWidth(t *testing.T) {
+	frame := time.Unix(1000, 0)
+	h := NewHCollapse(nil, HCollapseConfig{ExpandedWidth: 200, TransitionMs: 100})
+	h.Expanded = true
+	h.animate(frame)
+	if h.CurrentWidth != 200 {
+		t.Fatalf("expected first frame at '200', got '%v'", h.CurrentWidth)
+	}
+
+	h.Expanded = false
+	h.SkipAnimationOnce()
+	h.animate(frame.Add(10 * time.Millisecond))
+	if h.CurrentWidth != h.MinWidth {
+		t.Fatalf("expected jump to '%v', got '%v'", h.MinWidth, h.CurrentWidth)
+	}
+}
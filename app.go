@@ -2,6 +2,8 @@ package dfx
 
 import (
 	"image"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AllenDang/cimgui-go/backend"
@@ -15,9 +17,52 @@ type App struct {
 	config    Config
 	running   bool
 	actions   *ActionRegistry
+	tweens    *TweenManager
+	focus     *FocusManager
+	textures  *TextureManager
 	startTime time.Time
 	done      chan struct{} // signals Run() completion
 	runErr    error         // stores error from Run()
+
+	actionCache actionCache // registries keys are dispatched to, kept until the tree changes
+
+	appearance       Appearance   // last applied OS appearance
+	polledAppearance atomic.Int32 // latest appearance reported by the watcher
+	locale           string       // locale OnLocaleChange last reported
+	ime              IMEState     // text composition state of the last frame
+
+	pendingDrop []string  // files dropped since the last frame
+	fileDrop    *fileDrop // files dropped, delivered during this frame
+
+	about   *AboutDialog   // created by ShowAbout
+	search  *SearchOverlay // created by EnableSearch
+	quit    *QuitConfirm   // set by ConfirmQuit
+	crash   *crashDialog   // shows the report of a crash in the previous run
+	crashed bool           // a crash report was written this run
+	placed  bool           // the window placement was checked against the monitors
+
+	title       string        // current window title
+	titles      *TitleManager // composes the window title (nil = set with SetWindowTitle only)
+	titleBar    *TitleBar     // dfx-drawn title bar (nil = none)
+	maximized   bool          // maximized with Maximize
+	restoreRect WindowConfig  // placement restored by Restore
+	drag        windowDrag    // window being moved or resized with the mouse
+
+	screenshotErr error // result of screenshot mode, returned by Run
+
+	recorder  *InputRecorder // input being recorded (see RecordInput)
+	player    *InputPlayer   // input being replayed (see ReplayInput)
+	replayEnd uint64         // frame the replay finished in
+
+	debug  *DebugServer // opt-in diagnostics server (nil = disabled)
+	frames debugFrames  // frame timing, served by the debug server
+
+	dispatchMu sync.Mutex
+	dispatched []func() // functions queued by Dispatch for the next frame
+
+	frameCount uint64        // frames drawn before the current one
+	frameDelta time.Duration // time since the previous frame
+	lastFrame  time.Time     // start of the current frame
 }
 
 const menuBarFallbackHeight = 25.0
@@ -26,18 +71,82 @@ type Config struct {
 	Title          string
 	Width          int
 	Height         int
-	X              int            // window X position (0 = don't set)
-	Y              int            // window Y position (0 = don't set)
-	OnSetup        func(*App)     // called once after imgui context created
-	OnShutdown     func(*App)     // called before shutdown
-	OnTick         func(*App)     // called each frame before drawing
-	OnClose        func(*App)     // called when window is about to close (can call SetShouldClose to cancel)
-	OnSizeChange   func(int, int) // called when window is resized
-	MenuBar        Component      // optional menu bar component
-	Theme          Theme          // optional theme (defaults to DefaultTheme)
-	DisableFonts   bool           // if true, skip font setup (use default ImGui fonts)
-	DisableTheming bool           // if true, skip theme setup (use default ImGui theme)
-	Icons          []image.Image  // optional window icons
+	X              int                  // window X position (0 = don't set)
+	Y              int                  // window Y position (0 = don't set)
+	Monitor        int                  // monitor the window was saved on (WindowConfig.Monitor), used when X/Y are off-screen
+	OnSetup        func(*App)           // called once after imgui context created
+	OnShutdown     func(*App)           // called before shutdown
+	OnTick         func(*App)           // called each frame before drawing
+	OnClose        func(*App)           // called when window is about to close (can call SetShouldClose to cancel)
+	OnSizeChange   func(int, int)       // called when window is resized
+	OnFileDrop     func(*App, []string) // called with files dropped onto the window that no DropZone took
+	OnOpenFile     func(*App, []string) // called with files to open: Open entries and App.Open calls
+	OnOpenURL      func(*App, string)   // called with each deep link to open: Open entries and App.Open calls
+	Open           []string             // files and deep links to open on the first frame, usually command-line arguments
+	MenuBar        Component            // optional menu bar component
+	Theme          Theme                // optional theme (defaults to DefaultTheme)
+	DisableFonts   bool                 // if true, skip font setup (use default ImGui fonts)
+	DisableTheming bool                 // if true, skip theme setup (use default ImGui theme)
+	Icons          []image.Image        // optional window icons
+	Info           AppInfo              // optional application metadata, shown by ShowAbout
+	StateStore     *StateStore          // persistent per-component UI state, handed to components as State.Store
+
+	// DisablePlacementCheck leaves the window where it was created. otherwise the first
+	// frame moves a window that is off-screen, say after a monitor was disconnected, back
+	// onto a monitor (see ValidateWindowConfig).
+	DisablePlacementCheck bool
+
+	// screenshot mode renders ScreenshotFrames frames in a hidden window, saves the last
+	// one as a PNG at Screenshot and exits. dfx has no GL bindings of its own, so
+	// CaptureFrame reads the rendered frame; it is called with the GL context current.
+	Screenshot       string
+	ScreenshotFrames int                                          // frames drawn before capturing (0 = DefaultScreenshotFrames)
+	CaptureFrame     func(width, height int) (image.Image, error) // reads the framebuffer, such as with glReadPixels
+
+	// input recording: Record saves the session's mouse and keyboard input to a file when
+	// the app exits; Replay plays such a file back from the first frame, in a window of
+	// the size it was recorded at. with Screenshot, the screenshot is taken once the
+	// replay ends.
+	Record string
+	Replay string
+
+	// Frameless removes the OS window decorations; dfx draws TitleBar instead, and the
+	// window edges resize it
+	Frameless bool
+	TitleBar  *TitleBar // title bar of a frameless window (nil = NewTitleBar()); may also be set on a decorated window
+
+	// root window
+	RootPadding      *imgui.Vec2    // padding around the root component (nil = style window padding, &imgui.Vec2{} = edge to edge)
+	RootBackground   imgui.Vec4     // root window background; lower the alpha to show ClearColor (zero = theme window background)
+	RootNoBackground bool           // if true, the root window is not filled, leaving OnDrawBackground or ClearColor
+	RootBorder       float32        // root window border width, in the theme's border color (0 = style window border)
+	ClearColor       imgui.Vec4     // color the frame is cleared to, behind the root window (zero = backend default)
+	OnDrawBackground BackgroundFunc // draws a backdrop (gradient, image) behind the root component
+
+	// system appearance
+	FollowSystemTheme       bool                   // if true, switch between LightTheme and DarkTheme with the OS setting
+	LightTheme              Theme                  // theme used for a light OS appearance (defaults to ModernLight)
+	DarkTheme               Theme                  // theme used for a dark OS appearance (defaults to ModernDark)
+	SystemThemePollInterval time.Duration          // how often to re-check the OS appearance (defaults to 2s)
+	OnSystemThemeChange     func(*App, Appearance) // called on the UI thread when the OS appearance changes
+
+	// accessibility
+	KeyboardNavigation bool           // if true, arrow keys, Space and Enter move between and operate imgui widgets
+	KineticScrolling   *KineticParams // momentum scrolling for scroll areas, logs and trees (nil = off; see DefaultKineticParams)
+	Gamepad            bool           // if true, gamepads are read: actions can bind their buttons, the d-pad moves focus and the face buttons operate imgui widgets
+
+	// crash reports: a panic in Run writes a report before the app exits, and the next
+	// start shows it in a dialog. panics in other goroutines are not caught.
+	DisableCrashReports bool                      // if true, panics are not reported
+	CrashDir            string                    // where reports are written (default: ConfigPath(Info.Name or Title, "crashes"))
+	CrashLog            *LogBuffer                // recent log messages to include in reports
+	CrashSnapshot       func() interface{}        // returns a config snapshot to include in reports
+	OnCrashSubmit       func(report string) error // when set, the report dialog offers to submit the report
+
+	// internationalization
+	OnLocaleChange func(*App, string)   // called on the UI thread after SetLocale switches the locale
+	Format         *LocaleFormat        // number and date format for controls (nil = follow the locale)
+	OnIMEChange    func(*App, IMEState) // called when a text control gains or loses input, or its caret moves
 }
 
 var createBackend = func() (backend.Backend[glfwbackend.GLFWWindowFlags], error) {
@@ -57,15 +166,20 @@ func New(root Component, config Config) *App {
 	}
 
 	return &App{
-		root:    root,
-		config:  config,
-		actions: NewActionRegistry(),
-		done:    make(chan struct{}),
+		root:     root,
+		config:   config,
+		actions:  NewActionRegistry(),
+		tweens:   NewTweenManager(),
+		focus:    NewFocusManager(),
+		textures: NewTextureManager(),
+		done:     make(chan struct{}),
+		locale:   Locale(),
 	}
 }
 
 func (app *App) Run() error {
 	defer close(app.done)
+	defer app.recoverCrash()
 
 	// record start time for log timestamps
 	app.startTime = time.Now()
@@ -76,13 +190,36 @@ func (app *App) Run() error {
 		app.runErr = err
 		return app.runErr
 	}
+	if err := app.prepareScreenshot(); err != nil {
+		app.runErr = err
+		return app.runErr
+	}
+	if err := app.loadReplay(); err != nil {
+		app.runErr = err
+		return app.runErr
+	}
+	app.title = app.config.Title
+	app.titleBar = app.config.TitleBar
+	if app.config.Frameless {
+		app.backend.SetWindowFlags(glfwbackend.GLFWWindowFlagsDecorated, 0)
+		if app.titleBar == nil {
+			app.titleBar = NewTitleBar()
+		}
+	}
 	app.backend.CreateWindow(app.config.Title, app.config.Width, app.config.Height)
+	if app.config.Record != "" {
+		app.RecordInput()
+	}
 
 	// set window position if specified
 	if app.config.X != 0 || app.config.Y != 0 {
 		app.backend.SetWindowPos(app.config.X, app.config.Y)
 	}
 
+	if app.config.ClearColor != (imgui.Vec4{}) {
+		app.backend.SetBgColor(app.config.ClearColor)
+	}
+
 	// set window icons if specified
 	if len(app.config.Icons) > 0 {
 		app.backend.SetIcons(app.config.Icons...)
@@ -90,51 +227,109 @@ func (app *App) Run() error {
 
 	// setup fonts and styling
 	app.setupFontsAndTheme()
+	if app.config.Format != nil {
+		SetFormat(app.config.Format)
+	}
+	if app.config.KineticScrolling != nil {
+		SetKineticScrolling(*app.config.KineticScrolling)
+	}
 
 	// user setup
 	if app.config.OnSetup != nil {
 		app.config.OnSetup(app)
 	}
-	imgui.CurrentIO().SetConfigFlags(imgui.ConfigFlagsNone)
+	if !app.screenshotMode() {
+		app.checkCrashReport()
+	}
+	if len(app.config.Open) > 0 {
+		app.Open(app.config.Open...)
+	}
+	configFlags := imgui.ConfigFlagsNone
+	if app.config.KeyboardNavigation {
+		configFlags |= imgui.ConfigFlagsNavEnableKeyboard
+	}
+	if app.config.Gamepad {
+		// the backend only reads gamepads for imgui's gamepad navigation
+		configFlags |= imgui.ConfigFlagsNavEnableGamepad
+	}
+	imgui.CurrentIO().SetConfigFlags(configFlags)
 
-	// setup window callbacks
-	if app.config.OnClose != nil {
-		app.backend.SetCloseCallback(func() {
-			app.config.OnClose(app)
-		})
+	// follow the OS appearance if requested
+	if app.config.FollowSystemTheme || app.config.OnSystemThemeChange != nil {
+		go app.watchSystemAppearance()
 	}
+
+	// setup window callbacks
+	app.backend.SetCloseCallback(app.onClose)
 	if app.config.OnSizeChange != nil {
 		app.backend.SetSizeChangeCallback(func(width, height int) {
 			app.config.OnSizeChange(width, height)
 		})
 	}
+	app.backend.SetDropCallback(app.onBackendDrop)
+
+	// unmount components while the GL context still exists, so they can free GL resources
+	app.backend.SetBeforeDestroyContextHook(app.unmountComponents)
 
 	// run the main loop
 	app.running = true
 	app.backend.Run(func() {
+		defer app.recoverCrash()
 		if !app.running {
 			app.backend.SetShouldClose(true)
 			return
 		}
 
+		// advance the frame clock, then answer debug server requests
+		app.advanceFrame(time.Now())
+		app.debugFrame()
+
+		// move a restored window back on screen
+		app.checkPlacement()
+
+		// run functions dispatched from other goroutines
+		app.runDispatched()
+
+		// record and replay input
+		app.updateInputRecording()
+
+		// apply clicks and drop focus from components that stopped drawing
+		app.focus.beginFrame()
+
+		// apply any OS appearance change
+		app.checkSystemAppearance()
+
+		// tell the app about a locale switch
+		app.checkLocale()
+
+		// free released textures
+		app.textures.Update()
+
+		// advance animations
+		app.tweens.Update()
+
+		// deliver files dropped since the last frame to drop zones, then OnFileDrop
+		app.beginFileDrop(imgui.MousePos())
+		defer app.endFileDrop()
+
 		// user tick
 		if app.config.OnTick != nil {
 			app.config.OnTick(app)
 		}
 
-		// draw menu bar if configured
+		// set the composed window title when a part of it changed
+		app.updateTitle()
+
+		// draw the title bar of a frameless window, then the menu bar if configured
+		titleBarHeight := app.drawTitleBar()
 		menuBarHeight := float32(0)
 		if app.config.MenuBar != nil {
 			if imgui.BeginMainMenuBar() {
 				menuBarHeight = imgui.WindowSize().Y
-				menuState := &State{
-					Size:     imgui.Vec2{X: 0, Y: 0}, // menu bar size is managed by imgui
-					Position: imgui.Vec2{},
-					IO:       imgui.CurrentIO(),
-					App:      app,
-					Parent:   nil,
-				}
-				app.config.MenuBar.Draw(menuState)
+				// menu bar size is managed by imgui
+				menuState := app.frameState(imgui.Vec2{})
+				DrawChild(app.config.MenuBar, menuState)
+				contributions.drawMenus(menuState)
 				imgui.EndMainMenuBar()
 			}
 			if menuBarHeight <= 0 {
@@ -149,43 +344,108 @@ func (app *App) Run() error {
 			imgui.WindowFlagsNoTitleBar |
 			imgui.WindowFlagsNoScrollbar |
 			imgui.WindowFlagsNoScrollWithMouse
+		if app.config.RootNoBackground {
+			rootFlags |= imgui.WindowFlagsNoBackground
+		}
 
-		windowPos, windowSize := rootWindowRect(size, menuBarHeight, app.config.MenuBar != nil)
+		windowPos, windowSize := rootWindowRect(size, titleBarHeight+menuBarHeight, app.config.MenuBar != nil || titleBarHeight > 0)
 
 		imgui.SetNextWindowPos(windowPos)
 		imgui.SetNextWindowSize(windowSize)
 
-		if imgui.BeginV("##dfx_root", nil, rootFlags) {
-			// create state for root component
-			io := imgui.CurrentIO()
-			state := &State{
-				Size:     windowSize,
-				Position: imgui.Vec2{}, // position is relative to window
-				IO:       io,
-				App:      app,
-				Parent:   nil,
-			}
+		styleVars, styleColors := app.pushRootStyle()
+		rootOpen := imgui.BeginV("##dfx_root", nil, rootFlags)
+		imgui.PopStyleColorV(int32(styleColors))
+		imgui.PopStyleVarV(int32(styleVars))
+		if rootOpen {
+			app.drawRootBackground()
+
+			// create state for root component; position is relative to window
+			state := app.frameState(windowSize)
 
 			// handle events
 			app.processEvents(state)
 
 			// draw root component
 			if app.root != nil {
-				app.root.Draw(state)
+				DrawChild(app.root, state)
+			}
+			if app.about != nil {
+				DrawChild(app.about, state)
+			}
+			if app.search != nil {
+				DrawChild(app.search, state)
+			}
+			if app.crash != nil && !app.crash.draw() {
+				app.crash = nil
+			}
+			if app.quit != nil {
+				app.quit.draw(app)
 			}
 		}
 		imgui.End()
+
+		// hide lifecycle components that were not drawn this frame
+		lifecycle.endFrame()
+		contributions.endFrame()
+		actionRegions.endFrame()
+
+		// capture the state of components bound to the state store
+		app.config.StateStore.sync(app.lastFrame)
+
+		// move or resize the window with the mouse
+		app.updateWindowDrag()
+
+		// report the caret of the active text control to the IME
+		app.updateIME()
 	})
 
 	// shutdown
+	app.unmountComponents()
 	if app.config.OnShutdown != nil {
 		app.config.OnShutdown(app)
 	}
+	if app.debug != nil {
+		_ = app.debug.Close()
+	}
 
-	app.runErr = nil
+	app.runErr = app.screenshotErr
+	if err := app.saveRecording(); err != nil && app.runErr == nil {
+		app.runErr = err
+	}
 	return app.runErr
 }
 
+// unmountComponents captures the state store and unmounts every mounted component. it
+// runs before the GL context is destroyed, and again after the main loop in case the
+// backend did not call it; the second time does nothing.
+func (app *App) unmountComponents() {
+	app.config.StateStore.Sync()
+	lifecycle.unmountAll()
+}
+
+// advanceFrame updates the frame clock at the start of a frame.
+func (app *App) advanceFrame(now time.Time) {
+	if !app.lastFrame.IsZero() {
+		app.frameDelta = now.Sub(app.lastFrame)
+		app.frameCount++
+	}
+	app.lastFrame = now
+}
+
+// frameState returns the state for a top-level component of the current frame.
+func (app *App) frameState(size imgui.Vec2) *State {
+	return &State{
+		Size:       size,
+		IO:         imgui.CurrentIO(),
+		App:        app,
+		Now:        app.lastFrame,
+		DeltaTime:  app.frameDelta,
+		FrameIndex: app.frameCount,
+		Store:      app.config.StateStore,
+	}
+}
+
 func rootWindowRect(viewportSize imgui.Vec2, menuBarHeight float32, hasMenuBar bool) (imgui.Vec2, imgui.Vec2) {
 	if !hasMenuBar {
 		return imgui.Vec2{X: 0, Y: 0}, viewportSize
@@ -213,23 +473,131 @@ func (app *App) Wait() error {
 	return app.runErr
 }
 
-// SetRoot changes the root component
+// SetRoot changes the root component, unmounting the previous one (see Lifecycle).
 func (app *App) SetRoot(root Component) {
+	if app.root != nil && app.root != root {
+		UnmountComponent(app.root)
+	}
 	app.root = root
 }
 
+// Dispatch queues f to run on the UI thread at the start of the next frame, before
+// OnTick. it is safe to call from any goroutine, and is how background work (loading,
+// networking) hands its results to components.
+func (app *App) Dispatch(f func()) {
+	app.dispatchMu.Lock()
+	defer app.dispatchMu.Unlock()
+	app.dispatched = append(app.dispatched, f)
+}
+
+// runDispatched runs the functions queued by Dispatch, in order. functions they dispatch
+// run in the next frame.
+func (app *App) runDispatched() {
+	app.dispatchMu.Lock()
+	dispatched := app.dispatched
+	app.dispatched = nil
+	app.dispatchMu.Unlock()
+
+	for _, f := range dispatched {
+		f()
+	}
+}
+
 // Actions returns the action registry
 func (app *App) Actions() *ActionRegistry {
 	return app.actions
 }
 
+// Focus returns the app's focus manager.
+func (app *App) Focus() *FocusManager {
+	return app.focus
+}
+
+// Tweens returns the app's tween manager, which is updated once per frame before drawing.
+func (app *App) Tweens() *TweenManager {
+	return app.tweens
+}
+
+// Textures returns the app's texture manager. released textures are freed at the start
+// of each frame.
+func (app *App) Textures() *TextureManager {
+	return app.textures
+}
+
+// Info returns the application metadata from Config.Info.
+func (app *App) Info() AppInfo {
+	return app.config.Info
+}
+
+// ShowAbout opens an about dialog for Config.Info over the app.
+func (app *App) ShowAbout() {
+	if app.about == nil {
+		app.about = NewAboutDialog(app.config.Info)
+	}
+	app.about.Open()
+}
+
+// EnableSearch creates the app's search overlay, opened with Ctrl+P, searching the app's
+// actions and then providers, such as WorkspaceSearch, FileTreeSearch, RecentFilesSearch
+// or the app's own. calling it again adds providers to the overlay.
+func (app *App) EnableSearch(providers ...SearchProvider) *SearchOverlay {
+	if app.search == nil {
+		app.search = NewSearchOverlay(ActionSearch(app))
+		app.actions.MustRegister("Search Everywhere", searchKeys, app.search.Toggle)
+	}
+	for _, provider := range providers {
+		app.search.AddProvider(provider)
+	}
+	return app.search
+}
+
+// Search returns the overlay created by EnableSearch, or nil.
+func (app *App) Search() *SearchOverlay {
+	return app.search
+}
+
 // SetWindowTitle updates the window title
 func (app *App) SetWindowTitle(title string) {
+	app.title = title
 	if app.backend != nil {
 		app.backend.SetWindowTitle(title)
 	}
 }
 
+// Titles returns the app's title manager, created on first use titling Config.Info.Name,
+// or Config.Title without one. from then on the window title is composed by it.
+func (app *App) Titles() *TitleManager {
+	if app.titles == nil {
+		name := app.config.Info.Name
+		if name == "" {
+			name = app.config.Title
+		}
+		app.titles = newTitleManager(name)
+	}
+	return app.titles
+}
+
+// updateTitle sets the title composed by the title manager when it changed.
+func (app *App) updateTitle() {
+	if app.titles == nil {
+		return
+	}
+	if title, changed := app.titles.update(); changed {
+		app.SetWindowTitle(title)
+	}
+}
+
+// onClose handles a request to close the window, asking about unsaved changes first when
+// ConfirmQuit is set.
+func (app *App) onClose() {
+	if app.quit != nil && app.quit.intercept(app) {
+		return
+	}
+	if app.config.OnClose != nil {
+		app.config.OnClose(app)
+	}
+}
+
 // SetShouldClose sets whether the window should close
 // this can be used in OnClose callback to cancel closing
 func (app *App) SetShouldClose(shouldClose bool) {
@@ -266,11 +634,21 @@ func (app *App) setupFontsAndTheme() {
 	// apply default style
 	DefaultStyle()
 
+	// record the initial OS appearance so the watcher only reports changes
+	if app.config.FollowSystemTheme || app.config.OnSystemThemeChange != nil {
+		app.appearance = SystemAppearance()
+		app.polledAppearance.Store(int32(app.appearance))
+	}
+
 	// apply theme unless disabled
 	if !app.config.DisableTheming {
 		theme := app.config.Theme
 		if theme == nil {
-			theme = &ModernTheme{}
+			if app.config.FollowSystemTheme && app.appearance != AppearanceUnknown {
+				theme = app.themeForAppearance(app.appearance)
+			} else {
+				theme = &ModernTheme{}
+			}
 		}
 		SetTheme(theme)
 	}
@@ -283,33 +661,25 @@ func (app *App) processEvents(state *State) {
 		return
 	}
 
-	// collect all actions to check (component actions first, then global)
-	var actionsToCheck []*ActionRegistry
-
-	// gather component actions hierarchically
-	if app.root != nil {
-		actionsToCheck = app.gatherComponentActions(app.root)
-	}
-
-	// add global actions last
-	actionsToCheck = append(actionsToCheck, app.actions)
+	// actions to check: the focused component's first, then the root hierarchy's, then
+	// the global actions
+	actionsToCheck := app.dispatchRegistries()
 
 	// get current modifiers once
 	currentMods := app.getModifiers()
 
-	// check each action to see if its key combo is pressed
+	// check each action to see if its key combo is pressed or its gesture made
 	for _, registry := range actionsToCheck {
 		for _, action := range registry.actions {
-			if imgui.IsKeyPressedBool(action.key) {
-				if action.mods == currentMods {
-					if action.Handler != nil {
-						action.Handler()
-						return // stop processing after first match
-					}
-				}
+			if action.triggered(currentMods) && action.Handler != nil {
+				action.Handler()
+				return // stop processing after first match
 			}
 		}
 	}
+
+	// Tab and Shift+Tab move focus when no action claimed them
+	app.focus.handleKeys(currentMods)
 }
 
 func (app *App) getModifiers() KeyModifier {
@@ -333,12 +703,17 @@ func (app *App) getModifiers() KeyModifier {
 // gatherComponentActions collects all component actions hierarchically
 // using explicit child traversal plus local actions.
 func (app *App) gatherComponentActions(comp Component) []*ActionRegistry {
-	var registries []*ActionRegistry
+	return appendComponentActions(nil, comp)
+}
 
+// appendComponentActions appends the action registries of comp's hierarchy to
+// registries, children first. registries without actions are kept, as actions may be
+// registered after they are gathered.
+func appendComponentActions(registries []*ActionRegistry, comp Component) []*ActionRegistry {
 	if childProvider, ok := comp.(ChildActionProvider); ok {
 		children := childProvider.ChildActions()
 		for i := len(children) - 1; i >= 0; i-- {
-			registries = append(registries, app.gatherComponentActions(children[i])...)
+			registries = appendComponentActions(registries, children[i])
 		}
 	}
 
@@ -349,7 +724,7 @@ func (app *App) gatherComponentActions(comp Component) []*ActionRegistry {
 		actions = comp.Actions()
 	}
 
-	if actions != nil && len(actions.actions) > 0 {
+	if actions != nil {
 		registries = append(registries, actions)
 	}
 
@@ -84,3 +84,85 @@ func TestDash_IncludesComponentAndLocalActions(t *testing.T) {
 		t.Fatalf("expected dash order ['content', 'local'], got %v", got)
 	}
 }
+
+func TestDispatchRegistries_CachedUntilTreeChanges(t *testing.T) {
+	child := NewFunc(func(*State) {})
+	child.Actions().MustRegister("child", "Ctrl+1", func() {})
+	parent := newEmbeddedContainerComponent(child)
+
+	app := New(parent, Config{})
+	app.Actions().MustRegister("global", "Ctrl+G", func() {})
+	first := app.dispatchRegistries()
+	if got := actionIDs(first); len(got) != 2 || got[0] != "child" || got[1] != "global" {
+		t.Fatalf("expected ['child', 'global'], got %v", got)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { app.dispatchRegistries() }); allocs != 0 {
+		t.Fatalf("expected cached registries without allocations, got %v", allocs)
+	}
+
+	// actions registered after gathering are seen through the cached registries
+	parent.Actions().MustRegister("parent", "Ctrl+2", func() {})
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 3 || got[1] != "parent" {
+		t.Fatalf("expected late registration to be dispatched, got %v", got)
+	}
+
+	// children assigned directly are seen without invalidating
+	other := NewFunc(func(*State) {})
+	other.Actions().MustRegister("other", "Ctrl+3", func() {})
+	parent.Children = append(parent.Children, other)
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 4 || got[0] != "other" {
+		t.Fatalf("expected ['other', 'child', 'parent', 'global'], got %v", got)
+	}
+
+	// a registry replaced is only seen once the tree is invalidated
+	registry := NewActionRegistry()
+	registry.MustRegister("replaced", "Ctrl+4", func() {})
+	other.actions = registry
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 4 || got[0] != "other" {
+		t.Fatalf("expected cached registries before invalidation, got %v", got)
+	}
+	InvalidateActions()
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 4 || got[0] != "replaced" {
+		t.Fatalf("expected ['replaced', 'child', 'parent', 'global'], got %v", got)
+	}
+
+	// focus changes regather, with the focused component first
+	app.Focus().Focus(child)
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 5 || got[0] != "child" {
+		t.Fatalf("expected focused actions first, got %v", got)
+	}
+}
+
+// switchContainer draws one of its children with Draw rather than DrawChild, as a
+// container outside dfx might.
+type switchContainer struct {
+	Container
+	current Component
+}
+
+func (sc *switchContainer) Draw(state *State) {
+	sc.current.Draw(state)
+}
+
+func (sc *switchContainer) ChildActions() []Component {
+	return []Component{sc.current}
+}
+
+func TestDispatchRegistries_SeesChildrenOfPlainDrawContainers(t *testing.T) {
+	a, b := NewFunc(func(*State) {}), NewFunc(func(*State) {})
+	a.Actions().MustRegister("a", "Ctrl+1", func() {})
+	b.Actions().MustRegister("b", "Ctrl+2", func() {})
+	container := &switchContainer{Container: Container{Visible: true}, current: a}
+
+	app := New(container, Config{})
+	container.Draw(&State{})
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("expected ['a'], got %v", got)
+	}
+
+	container.current = b
+	container.Draw(&State{})
+	if got := actionIDs(app.dispatchRegistries()); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected ['b'] once the container switched, got %v", got)
+	}
+}
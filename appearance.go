@@ -0,0 +1,142 @@
+package dfx
+
+import (
+	"strings"
+	"time"
+)
+
+// Appearance describes the operating system's light/dark preference.
+type Appearance int
+
+const (
+	AppearanceUnknown Appearance = iota
+	AppearanceDark
+	AppearanceLight
+)
+
+// DefaultSystemThemePollInterval is how often the OS appearance is re-checked
+// when following the system theme.
+const DefaultSystemThemePollInterval = 2 * time.Second
+
+func (a Appearance) String() string {
+	switch a {
+	case AppearanceDark:
+		return "dark"
+	case AppearanceLight:
+		return "light"
+	default:
+		return "unknown"
+	}
+}
+
+// SystemAppearance queries the operating system for its current light/dark preference.
+// returns AppearanceUnknown when the platform does not expose the setting.
+func SystemAppearance() Appearance {
+	return detectSystemAppearance()
+}
+
+// parseGnomeColorScheme interprets the output of
+// `gsettings get org.gnome.desktop.interface color-scheme`.
+func parseGnomeColorScheme(out string) Appearance {
+	out = strings.Trim(strings.TrimSpace(out), "'\"")
+	switch out {
+	case "prefer-dark":
+		return AppearanceDark
+	case "prefer-light", "default":
+		return AppearanceLight
+	}
+	return AppearanceUnknown
+}
+
+// parseGtkThemeName interprets the output of
+// `gsettings get org.gnome.desktop.interface gtk-theme`.
+func parseGtkThemeName(out string) Appearance {
+	out = strings.Trim(strings.TrimSpace(out), "'\"")
+	if out == "" {
+		return AppearanceUnknown
+	}
+	if strings.Contains(strings.ToLower(out), "dark") {
+		return AppearanceDark
+	}
+	return AppearanceLight
+}
+
+// parseMacInterfaceStyle interprets the output of `defaults read -g AppleInterfaceStyle`.
+// the key is absent (command fails, empty output) when light mode is active.
+func parseMacInterfaceStyle(out string) Appearance {
+	if strings.EqualFold(strings.TrimSpace(out), "dark") {
+		return AppearanceDark
+	}
+	return AppearanceLight
+}
+
+// parseWindowsAppsUseLightTheme interprets the output of
+// `reg query HKCU\...\Themes\Personalize /v AppsUseLightTheme`.
+func parseWindowsAppsUseLightTheme(out string) Appearance {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "AppsUseLightTheme" {
+			switch fields[2] {
+			case "0x0":
+				return AppearanceDark
+			case "0x1":
+				return AppearanceLight
+			}
+		}
+	}
+	return AppearanceUnknown
+}
+
+// themeForAppearance selects the light or dark theme for an appearance,
+// falling back to ModernLight/ModernDark when none is configured.
+func (app *App) themeForAppearance(appearance Appearance) Theme {
+	if appearance == AppearanceLight {
+		if app.config.LightTheme != nil {
+			return app.config.LightTheme
+		}
+		return ModernLight
+	}
+	if app.config.DarkTheme != nil {
+		return app.config.DarkTheme
+	}
+	return ModernDark
+}
+
+// watchSystemAppearance polls the OS appearance until the app finishes running.
+// changes are published to the main loop, which applies them on the UI thread.
+func (app *App) watchSystemAppearance() {
+	interval := app.config.SystemThemePollInterval
+	if interval <= 0 {
+		interval = DefaultSystemThemePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-app.done:
+			return
+		case <-ticker.C:
+			app.polledAppearance.Store(int32(SystemAppearance()))
+		}
+	}
+}
+
+// checkSystemAppearance applies a pending appearance change; called once per frame.
+func (app *App) checkSystemAppearance() {
+	polled := Appearance(app.polledAppearance.Load())
+	if polled == AppearanceUnknown || polled == app.appearance {
+		return
+	}
+	app.appearance = polled
+	if app.config.FollowSystemTheme && !app.config.DisableTheming {
+		SetTheme(app.themeForAppearance(polled))
+	}
+	if app.config.OnSystemThemeChange != nil {
+		app.config.OnSystemThemeChange(app, polled)
+	}
+}
+
+// SystemAppearance returns the most recently observed OS appearance.
+func (app *App) SystemAppearance() Appearance {
+	return app.appearance
+}
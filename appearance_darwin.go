@@ -0,0 +1,9 @@
+package dfx
+
+import "os/exec"
+
+func detectSystemAppearance() Appearance {
+	// the command exits non-zero when the key is absent, which means light mode
+	out, _ := exec.Command("defaults", "read", "-g", "AppleInterfaceStyle").Output()
+	return parseMacInterfaceStyle(string(out))
+}
@@ -0,0 +1,15 @@
+package dfx
+
+import "os/exec"
+
+func detectSystemAppearance() Appearance {
+	if out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output(); err == nil {
+		if appearance := parseGnomeColorScheme(string(out)); appearance != AppearanceUnknown {
+			return appearance
+		}
+	}
+	if out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "gtk-theme").Output(); err == nil {
+		return parseGtkThemeName(string(out))
+	}
+	return AppearanceUnknown
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !windows
+
+package dfx
+
+func detectSystemAppearance() Appearance {
+	return AppearanceUnknown
+}
@@ -0,0 +1,69 @@
+package dfx
+
+import "testing"
+
+func TestParseGnomeColorScheme(t *testing.T) {
+	cases := map[string]Appearance{
+		"'prefer-dark'\n":  AppearanceDark,
+		"'prefer-light'\n": AppearanceLight,
+		"'default'\n":      AppearanceLight,
+		"":                 AppearanceUnknown,
+	}
+	for in, expected := range cases {
+		if got := parseGnomeColorScheme(in); got != expected {
+			t.Fatalf("expected '%v' for %q, got '%v'", expected, in, got)
+		}
+	}
+}
+
+func TestParseGtkThemeName(t *testing.T) {
+	if got := parseGtkThemeName("'Adwaita-dark'\n"); got != AppearanceDark {
+		t.Fatalf("expected 'dark', got '%v'", got)
+	}
+	if got := parseGtkThemeName("'Adwaita'\n"); got != AppearanceLight {
+		t.Fatalf("expected 'light', got '%v'", got)
+	}
+	if got := parseGtkThemeName(""); got != AppearanceUnknown {
+		t.Fatalf("expected 'unknown', got '%v'", got)
+	}
+}
+
+func TestParseMacInterfaceStyle(t *testing.T) {
+	if got := parseMacInterfaceStyle("Dark\n"); got != AppearanceDark {
+		t.Fatalf("expected 'dark', got '%v'", got)
+	}
+	if got := parseMacInterfaceStyle(""); got != AppearanceLight {
+		t.Fatalf("expected 'light' when key is absent, got '%v'", got)
+	}
+}
+
+func TestParseWindowsAppsUseLightTheme(t *testing.T) {
+	out := "\r\nHKEY_CURRENT_USER\\Software\\Microsoft\\Windows\\CurrentVersion\\Themes\\Personalize\r\n    AppsUseLightTheme    REG_DWORD    0x0\r\n"
+	if got := parseWindowsAppsUseLightTheme(out); got != AppearanceDark {
+		t.Fatalf("expected 'dark', got '%v'", got)
+	}
+	if got := parseWindowsAppsUseLightTheme("    AppsUseLightTheme    REG_DWORD    0x1"); got != AppearanceLight {
+		t.Fatalf("expected 'light', got '%v'", got)
+	}
+	if got := parseWindowsAppsUseLightTheme("garbage"); got != AppearanceUnknown {
+		t.Fatalf("expected 'unknown', got '%v'", got)
+	}
+}
+
+func TestThemeForAppearance_DefaultsAndOverrides(t *testing.T) {
+	app := New(nil, Config{})
+	if app.themeForAppearance(AppearanceLight) != ModernLight {
+		t.Fatalf("expected default light theme 'ModernLight'")
+	}
+	if app.themeForAppearance(AppearanceDark) != ModernDark {
+		t.Fatalf("expected default dark theme 'ModernDark'")
+	}
+
+	app = New(nil, Config{LightTheme: BlueLightTheme, DarkTheme: BlueTheme})
+	if app.themeForAppearance(AppearanceLight) != BlueLightTheme {
+		t.Fatalf("expected configured light theme")
+	}
+	if app.themeForAppearance(AppearanceUnknown) != BlueTheme {
+		t.Fatalf("expected unknown appearance to fall back to the dark theme")
+	}
+}
@@ -0,0 +1,11 @@
+package dfx
+
+import "os/exec"
+
+func detectSystemAppearance() Appearance {
+	out, err := exec.Command("reg", "query", `HKCU\Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`, "/v", "AppsUseLightTheme").Output()
+	if err != nil {
+		return AppearanceUnknown
+	}
+	return parseWindowsAppsUseLightTheme(string(out))
+}
@@ -0,0 +1,151 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// AsyncContent draws a value a Loader loads in the background: Placeholder (a spinner
+// when nil) while Key loads, Render once it is ready, and Failed (the error text when nil)
+// if loading failed. changing Key loads the new value; the loader's cache makes drawing
+// a key again free.
+type AsyncContent[T any] struct {
+	Container
+	Loader      *Loader[T]
+	Key         string
+	Render      func(state *State, value T)
+	Placeholder Component                     // drawn while loading (nil = spinner)
+	Failed      func(state *State, err error) // drawn when loading failed (nil = error text)
+}
+
+// NewAsyncContent creates a component rendering the value loader loads for key.
+func NewAsyncContent[T any](loader *Loader[T], key string, render func(state *State, value T)) *AsyncContent[T] {
+	return &AsyncContent[T]{
+		Container: Container{Visible: true},
+		Loader:    loader,
+		Key:       key,
+		Render:    render,
+	}
+}
+
+// Draw implements Component.
+func (ac *AsyncContent[T]) Draw(state *State) {
+	if !ac.Visible {
+		return
+	}
+	if ac.Loader != nil && ac.Key != "" {
+		value, status, err := ac.Loader.Get(ac.Key)
+		switch status {
+		case LoadPending:
+			drawPlaceholder(ac.Placeholder, state)
+		case LoadFailed:
+			if ac.Failed != nil {
+				ac.Failed(state, err)
+			} else {
+				drawLoadError(err)
+			}
+		case LoadReady:
+			if ac.Render != nil {
+				ac.Render(state, value)
+			}
+		}
+	}
+	drawContainerExtensions(&ac.Container, state)
+}
+
+// ChildActions returns the placeholder for action traversal.
+func (ac *AsyncContent[T]) ChildActions() []Component {
+	if ac.Placeholder == nil {
+		return ac.Children
+	}
+	return append([]Component{ac.Placeholder}, ac.Children...)
+}
+
+// AsyncImage displays an image from a file path or http(s) URL, loaded in the background
+// by a texture loader (see NewTextureLoader) that caches textures across images. a
+// spinner (or Placeholder) fills the image's space until the texture is ready.
+type AsyncImage struct {
+	Container
+	Loader      *Loader[*Texture]
+	Source      string // file path or http(s) URL
+	Scale       ImageScale
+	Size        imgui.Vec2 // space to draw in (0 = available region, per axis)
+	Tint        imgui.Vec4 // color multiplied with the image (zero = white)
+	Placeholder Component  // drawn while loading (nil = spinner)
+}
+
+// NewAsyncImage creates an image of source loaded by loader, scaled to fit.
+func NewAsyncImage(loader *Loader[*Texture], source string) *AsyncImage {
+	return &AsyncImage{
+		Container: Container{Visible: true},
+		Loader:    loader,
+		Source:    source,
+	}
+}
+
+// Draw implements Component.
+func (ai *AsyncImage) Draw(state *State) {
+	if !ai.Visible {
+		return
+	}
+	region := ai.Size
+	avail := imgui.ContentRegionAvail()
+	if region.X <= 0 {
+		region.X = avail.X
+	}
+	if region.Y <= 0 {
+		region.Y = avail.Y
+	}
+
+	var texture *Texture
+	status := LoadFailed
+	var err error
+	if ai.Loader != nil && ai.Source != "" {
+		texture, status, err = ai.Loader.Get(ai.Source)
+	}
+	switch status {
+	case LoadReady:
+		drawTexture(texture, region, ai.Scale, imgui.Vec2{}, imgui.Vec2{}, ai.Tint)
+	case LoadPending:
+		origin := imgui.CursorPos()
+		if ai.Placeholder != nil {
+			DrawChild(ai.Placeholder, state.Child(region, imgui.Vec2{}).WithParent(ai))
+		} else {
+			imgui.SetCursorPos(origin.Add(region.Mul(0.5)).Sub(imgui.Vec2{X: spinnerRadius(), Y: spinnerRadius()}))
+			DrawSpinner(spinnerRadius())
+		}
+		imgui.SetCursorPos(origin)
+		imgui.Dummy(region)
+	default:
+		origin := imgui.CursorPos()
+		if err != nil {
+			drawLoadError(err)
+		}
+		imgui.SetCursorPos(origin)
+		imgui.Dummy(region)
+	}
+	drawContainerExtensions(&ai.Container, state)
+}
+
+// ChildActions returns the placeholder for action traversal.
+func (ai *AsyncImage) ChildActions() []Component {
+	if ai.Placeholder == nil {
+		return ai.Children
+	}
+	return append([]Component{ai.Placeholder}, ai.Children...)
+}
+
+// drawPlaceholder draws placeholder, or a spinner when it is nil.
+func drawPlaceholder(placeholder Component, state *State) {
+	if placeholder != nil {
+		DrawChild(placeholder, state)
+		return
+	}
+	DrawSpinner(spinnerRadius())
+}
+
+// drawLoadError shows a loading error in the theme's error color.
+func drawLoadError(err error) {
+	imgui.PushStyleColorVec4(imgui.ColText, ThemeColors().Error)
+	imgui.TextWrapped(err.Error())
+	imgui.PopStyleColor()
+}
@@ -0,0 +1,229 @@
+// Package audiometer measures the level of live audio and feeds it to dfx meters. audio
+// comes from a pluggable Capture, so the package has no audio dependency of its own:
+// adapt whichever library the application uses (PortAudio, malgo, JACK, ...), or push
+// samples from an existing audio callback with Meter.Write.
+package audiometer
+
+import (
+	"io"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Capture opens audio input devices.
+type Capture interface {
+	// Devices returns the names of the available input devices.
+	Devices() ([]string, error)
+	// Open starts capturing channels of audio from device at sampleRate. deliver is called
+	// with blocks of interleaved samples in the range -1..1, from any goroutine; closing
+	// the returned closer stops capture.
+	Open(device string, channels, sampleRate int, deliver func(samples []float32)) (io.Closer, error)
+}
+
+// LevelSink receives per-channel levels in the range 0..1; dfx.VUMeter and
+// dfx.VUWaterfall are level sinks.
+type LevelSink interface {
+	SetLevels(levels []float32)
+}
+
+// Measure selects the level fed to sinks.
+type Measure int
+
+const (
+	MeasureRMS  Measure = iota // root mean square over each block (perceived loudness)
+	MeasurePeak                // highest absolute sample (catches transients and clipping)
+)
+
+// Scale maps a measured amplitude to a meter level.
+type Scale int
+
+const (
+	ScaleDecibel Scale = iota // FloorDB..0 dBFS maps to 0..1
+	ScaleLinear               // amplitude 0..1 maps to 0..1
+)
+
+// defaults
+const (
+	DefaultBlockSize  = 1024
+	DefaultSampleRate = 48000
+	DefaultFloorDB    = -60
+)
+
+// Config describes what to capture and how to measure it.
+type Config struct {
+	Device     string  // input device name
+	Channels   int     // channel count (default 2)
+	SampleRate int     // sample rate in Hz (default DefaultSampleRate)
+	BlockSize  int     // frames per measurement (default DefaultBlockSize)
+	Measure    Measure // level fed to sinks (default MeasureRMS)
+	Scale      Scale   // level mapping (default ScaleDecibel)
+	FloorDB    float32 // level shown as empty with ScaleDecibel (default DefaultFloorDB)
+}
+
+// Meter computes per-channel RMS and peak levels from interleaved audio, block by block.
+// samples are written from the audio goroutine; Update, called once per frame from the
+// UI thread (e.g. from dfx.Config.OnTick), feeds the latest levels to the sinks.
+type Meter struct {
+	config Config
+	closer io.Closer
+	sinks  []LevelSink
+
+	mu       sync.Mutex
+	sumSq    []float64 // per channel, over the current block
+	blockMax []float32 // per channel, over the current block
+	frames   int       // frames in the current block
+	partial  []float32 // samples of an incomplete frame carried to the next write
+	rms      []float32 // per channel, of the last complete block
+	peak     []float32 // per channel, highest since the last Update
+	levels   []float32 // scratch for Update
+}
+
+// New creates a meter for config that is fed with Write.
+func New(config Config) *Meter {
+	if config.Channels <= 0 {
+		config.Channels = 2
+	}
+	if config.SampleRate <= 0 {
+		config.SampleRate = DefaultSampleRate
+	}
+	if config.BlockSize <= 0 {
+		config.BlockSize = DefaultBlockSize
+	}
+	if config.FloorDB >= 0 {
+		config.FloorDB = DefaultFloorDB
+	}
+	n := config.Channels
+	return &Meter{
+		config:   config,
+		sumSq:    make([]float64, n),
+		blockMax: make([]float32, n),
+		rms:      make([]float32, n),
+		peak:     make([]float32, n),
+		levels:   make([]float32, n),
+	}
+}
+
+// Open creates a meter and starts capturing config.Device.
+func Open(capture Capture, config Config) (*Meter, error) {
+	m := New(config)
+	closer, err := capture.Open(m.config.Device, m.config.Channels, m.config.SampleRate, m.Write)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening audio device '%v'", m.config.Device)
+	}
+	m.closer = closer
+	return m, nil
+}
+
+// Close stops capturing. meters created with New have nothing to close.
+func (m *Meter) Close() error {
+	if m.closer == nil {
+		return nil
+	}
+	err := m.closer.Close()
+	m.closer = nil
+	return errors.Wrapf(err, "error closing audio device '%v'", m.config.Device)
+}
+
+// Config returns the meter configuration, with defaults applied.
+func (m *Meter) Config() Config {
+	return m.config
+}
+
+// Feed adds sinks that receive the meter's levels on each Update.
+func (m *Meter) Feed(sinks ...LevelSink) {
+	m.sinks = append(m.sinks, sinks...)
+}
+
+// Write measures interleaved samples; it is safe to call from the audio goroutine.
+// frames may be split across writes.
+func (m *Meter) Write(samples []float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	channels := m.config.Channels
+	if len(m.partial) > 0 {
+		need := channels - len(m.partial)
+		if len(samples) < need {
+			m.partial = append(m.partial, samples...)
+			return
+		}
+		m.partial = append(m.partial, samples[:need]...)
+		m.addFrame(m.partial)
+		m.partial = m.partial[:0]
+		samples = samples[need:]
+	}
+	for len(samples) >= channels {
+		m.addFrame(samples[:channels])
+		samples = samples[channels:]
+	}
+	m.partial = append(m.partial, samples...)
+}
+
+// addFrame accumulates one frame, completing the block when it is full.
+func (m *Meter) addFrame(frame []float32) {
+	for ch, s := range frame {
+		m.sumSq[ch] += float64(s) * float64(s)
+		if a := float32(math.Abs(float64(s))); a > m.blockMax[ch] {
+			m.blockMax[ch] = a
+		}
+	}
+	m.frames++
+	if m.frames < m.config.BlockSize {
+		return
+	}
+	for ch := range m.rms {
+		m.rms[ch] = float32(math.Sqrt(m.sumSq[ch] / float64(m.frames)))
+		m.peak[ch] = max(m.peak[ch], m.blockMax[ch])
+		m.sumSq[ch] = 0
+		m.blockMax[ch] = 0
+	}
+	m.frames = 0
+}
+
+// Levels returns the RMS amplitude of the last complete block and the peak amplitude
+// since the last Update, per channel, unscaled.
+func (m *Meter) Levels() (rms, peak []float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]float32(nil), m.rms...), append([]float32(nil), m.peak...)
+}
+
+// Update feeds the current levels, scaled, to every sink, and starts a new peak
+// measurement.
+func (m *Meter) Update() {
+	m.mu.Lock()
+	measured := m.rms
+	if m.config.Measure == MeasurePeak {
+		measured = m.peak
+	}
+	for ch, amplitude := range measured {
+		m.levels[ch] = m.Scale(amplitude)
+	}
+	clear(m.peak)
+	m.mu.Unlock()
+
+	for _, sink := range m.sinks {
+		sink.SetLevels(m.levels)
+	}
+}
+
+// Scale maps an amplitude to a meter level in the range 0..1.
+func (m *Meter) Scale(amplitude float32) float32 {
+	if m.config.Scale == ScaleLinear {
+		return min(max(amplitude, 0), 1)
+	}
+	if amplitude <= 0 {
+		return 0
+	}
+	return min(max(1-DB(amplitude)/m.config.FloorDB, 0), 1)
+}
+
+// DB converts an amplitude to dBFS.
+func DB(amplitude float32) float32 {
+	if amplitude <= 0 {
+		return float32(math.Inf(-1))
+	}
+	return 20 * float32(math.Log10(float64(amplitude)))
+}
@@ -0,0 +1,76 @@
+package audiometer
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+type testSink struct{ levels []float32 }
+
+func (s *testSink) SetLevels(levels []float32) { s.levels = append([]float32(nil), levels...) }
+
+type testCapture struct{ deliver func([]float32) }
+
+func (c *testCapture) Devices() ([]string, error) { return []string{"mic"}, nil }
+
+func (c *testCapture) Open(device string, channels, sampleRate int, deliver func([]float32)) (io.Closer, error) {
+	c.deliver = deliver
+	return io.NopCloser(nil), nil
+}
+
+func near(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-3 }
+
+func TestMeterLevels(t *testing.T) {
+	m := New(Config{Channels: 2, BlockSize: 4})
+
+	// left is a square wave at 0.5, right is silent; frames split across writes
+	m.Write([]float32{0.5, 0, -0.5})
+	m.Write([]float32{0, 0.5, 0, -0.5, 0})
+	rms, peak := m.Levels()
+	if !near(rms[0], 0.5) || rms[1] != 0 || !near(peak[0], 0.5) {
+		t.Fatalf("unexpected levels rms %v peak %v", rms, peak)
+	}
+	m.Update()
+	if _, peak := m.Levels(); peak[0] != 0 {
+		t.Fatalf("expected peak reset by Update, got %v", peak)
+	}
+
+	// an incomplete block is not measured yet
+	m.Write([]float32{1, 1, 1, 1})
+	if rms, _ := m.Levels(); !near(rms[0], 0.5) {
+		t.Fatalf("expected the previous block, got %v", rms)
+	}
+}
+
+func TestMeterScaleAndFeed(t *testing.T) {
+	capture := &testCapture{}
+	m, err := Open(capture, Config{Device: "mic", Channels: 1, BlockSize: 2, Measure: MeasurePeak})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &testSink{}
+	m.Feed(sink)
+
+	// -30 dBFS is half way up a -60 dB meter
+	amplitude := float32(math.Pow(10, -30.0/20))
+	capture.deliver([]float32{amplitude, -amplitude})
+	m.Update()
+	if len(sink.levels) != 1 || !near(sink.levels[0], 0.5) {
+		t.Fatalf("expected 0.5, got %v", sink.levels)
+	}
+	if m.Scale(2) != 1 || m.Scale(0.0001) != 0 || m.Scale(0) != 0 {
+		t.Fatalf("expected levels clamped")
+	}
+	if !near(DB(1), 0) || !math.IsInf(float64(DB(0)), -1) {
+		t.Fatalf("unexpected dB conversion")
+	}
+
+	linear := New(Config{Scale: ScaleLinear})
+	if linear.Scale(0.25) != 0.25 {
+		t.Fatalf("expected linear scale")
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
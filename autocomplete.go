@@ -0,0 +1,292 @@
+package dfx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// autocomplete constants
+const (
+	AutocompleteMaxSuggestions = 8 // suggestions shown when Autocomplete.MaxSuggestions is 0
+)
+
+// Autocomplete is the suggestion provider and popup state of an InputAutocomplete. set
+// Suggest for a provider that answers immediately, or SuggestAsync for one that answers
+// later (a search service, a database); answers for text that has since changed are
+// dropped. keep one Autocomplete per input.
+type Autocomplete struct {
+	Suggest        func(text string) []string                // synchronous provider
+	SuggestAsync   func(text string, deliver func([]string)) // asynchronous provider; deliver may be called from any goroutine
+	MaxSuggestions int                                       // suggestions shown (0 = AutocompleteMaxSuggestions)
+	MinChars       int                                       // characters typed before suggesting (0 = 1)
+
+	mu          sync.Mutex
+	requested   string   // text last passed to the provider
+	query       string   // text the suggestions answer
+	suggestions []string // suggestions for query
+	selected    int      // highlighted suggestion (-1 = none)
+	hovered     bool     // popup was hovered last frame, so a click in it is under way
+	refocus     bool     // give the input keyboard focus on the next frame
+}
+
+// NewAutocomplete creates an autocomplete with a synchronous provider.
+func NewAutocomplete(suggest func(text string) []string) *Autocomplete {
+	return &Autocomplete{Suggest: suggest, selected: -1}
+}
+
+// NewAsyncAutocomplete creates an autocomplete with an asynchronous provider, which
+// answers by calling deliver.
+func NewAsyncAutocomplete(suggest func(text string, deliver func([]string))) *Autocomplete {
+	return &Autocomplete{SuggestAsync: suggest, selected: -1}
+}
+
+// MatchSuggestions returns a provider suggesting the candidates that contain the typed
+// text, ignoring case; candidates starting with it come first.
+func MatchSuggestions(candidates []string) func(text string) []string {
+	return func(text string) []string {
+		var prefix, contains []string
+		for _, candidate := range candidates {
+			switch start, _, found := matchSpan(candidate, text); {
+			case !found:
+			case start == 0:
+				prefix = append(prefix, candidate)
+			default:
+				contains = append(contains, candidate)
+			}
+		}
+		sort.Strings(prefix)
+		sort.Strings(contains)
+		return append(prefix, contains...)
+	}
+}
+
+// InputAutocomplete is a text input offering suggestions from ac as the user types, in a
+// popup below the input with the typed text highlighted. Up and Down move through the
+// suggestions, Tab accepts the highlighted (or first) one, Enter the highlighted one, a
+// click the one clicked, and Escape dismisses them. returns (newValue, changed) following
+// dfx conventions.
+func InputAutocomplete(label string, value string, ac *Autocomplete) (string, bool) {
+	if ac.refocus {
+		imgui.SetKeyboardFocusHere()
+		ac.refocus = false
+	}
+	width := imgui.CalcItemWidth()
+	flags := imgui.InputTextFlagsCallbackCompletion | imgui.InputTextFlagsCallbackHistory
+	completed := false
+	newValue, changed := inputText(label, value, flags, func(data imgui.InputTextCallbackData) int {
+		switch data.EventFlag() {
+		case imgui.InputTextFlagsCallbackHistory:
+			ac.moveSelection(data.EventKey() == imgui.KeyUpArrow)
+		case imgui.InputTextFlagsCallbackCompletion:
+			if suggestion, found := ac.completion(); found {
+				data.DeleteChars(0, data.BufTextLen())
+				data.InsertChars(0, suggestion)
+				ac.dismiss()
+				completed = true
+			}
+		}
+		return 0
+	})
+	active := imgui.IsItemActive()
+	finished := imgui.IsItemDeactivated()
+	popupPos := imgui.Vec2{X: imgui.ItemRectMin().X, Y: imgui.ItemRectMax().Y}
+
+	enter := imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter)
+	if enter && (active || finished) {
+		if suggestion, found := ac.highlighted(); found {
+			newValue, changed = suggestion, true
+			ac.refocus = true
+		}
+		ac.dismiss()
+	}
+
+	switch {
+	case changed && active && !completed:
+		ac.request(newValue)
+	case !active && !ac.hovered:
+		ac.dismiss()
+	}
+
+	ac.mu.Lock()
+	suggestions, query, selected := ac.suggestions, ac.query, ac.selected
+	ac.mu.Unlock()
+	if query != newValue || len(suggestions) == 0 {
+		ac.hovered = false
+		return newValue, changed && newValue != value
+	}
+	if clicked, found := ac.drawPopup(popupPos, width, suggestions, query, selected); found {
+		newValue, changed = clicked, true
+		ac.dismiss()
+		ac.refocus = true
+	}
+	return newValue, changed && newValue != value
+}
+
+// drawPopup draws the suggestions below the input, returning the one clicked.
+func (ac *Autocomplete) drawPopup(pos imgui.Vec2, width float32, suggestions []string, query string, selected int) (string, bool) {
+	imgui.SetNextWindowPos(pos)
+	imgui.SetNextWindowSizeConstraints(imgui.Vec2{X: width}, imgui.Vec2{X: width, Y: math.MaxFloat32})
+	flags := imgui.WindowFlagsNoTitleBar | imgui.WindowFlagsNoResize | imgui.WindowFlagsNoMove |
+		imgui.WindowFlagsNoSavedSettings | imgui.WindowFlagsNoFocusOnAppearing | imgui.WindowFlagsNoNav |
+		imgui.WindowFlagsAlwaysAutoResize
+	clicked, found := "", false
+	if imgui.BeginV(fmt.Sprintf("##autocomplete_%p", ac), nil, flags) {
+		// stay above the window holding the input, which has focus
+		imgui.InternalBringWindowToDisplayFront(imgui.InternalCurrentWindow())
+		for i, suggestion := range suggestions {
+			if drawSuggestion(i, suggestion, query, i == selected) {
+				clicked, found = suggestion, true
+			}
+		}
+		ac.hovered = imgui.IsWindowHovered()
+	}
+	imgui.End()
+	return clicked, found
+}
+
+// drawSuggestion draws a selectable suggestion with the part matching query in the theme
+// accent color. returns true when clicked.
+func drawSuggestion(i int, suggestion, query string, selected bool) bool {
+	imgui.PushIDInt(int32(i))
+	defer imgui.PopID()
+	clicked := imgui.SelectableBoolV("##suggestion", selected, imgui.SelectableFlagsNone, imgui.Vec2{Y: imgui.TextLineHeight()})
+	pos := imgui.ItemRectMin()
+	text := imgui.ColorConvertFloat4ToU32(imgui.CurrentStyle().Colors()[imgui.ColText])
+	accent := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	drawList := imgui.WindowDrawList()
+	start, end, found := matchSpan(suggestion, query)
+	if !found {
+		start, end = len(suggestion), len(suggestion)
+	}
+	for _, part := range []struct {
+		text  string
+		color uint32
+	}{{suggestion[:start], text}, {suggestion[start:end], accent}, {suggestion[end:], text}} {
+		if part.text == "" {
+			continue
+		}
+		drawList.AddTextVec2(pos, part.color, part.text)
+		pos.X += imgui.CalcTextSize(part.text).X
+	}
+	return clicked
+}
+
+// request asks the provider for suggestions for text.
+func (ac *Autocomplete) request(text string) {
+	minChars := max(ac.MinChars, 1)
+	ac.mu.Lock()
+	ac.requested = text
+	ac.mu.Unlock()
+	if utf8.RuneCountInString(text) < minChars {
+		ac.deliver(text, nil)
+		return
+	}
+	switch {
+	case ac.Suggest != nil:
+		ac.deliver(text, ac.Suggest(text))
+	case ac.SuggestAsync != nil:
+		ac.SuggestAsync(text, func(suggestions []string) { ac.deliver(text, suggestions) })
+	}
+}
+
+// deliver stores the suggestions for text, unless text has been edited since.
+func (ac *Autocomplete) deliver(text string, suggestions []string) {
+	limit := ac.MaxSuggestions
+	if limit <= 0 {
+		limit = AutocompleteMaxSuggestions
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if text != ac.requested {
+		return
+	}
+	ac.query = text
+	ac.suggestions = suggestions[:min(len(suggestions), limit)]
+	ac.selected = -1
+}
+
+// moveSelection highlights the previous or next suggestion, wrapping around.
+func (ac *Autocomplete) moveSelection(up bool) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	n := len(ac.suggestions)
+	if n == 0 {
+		return
+	}
+	switch {
+	case up && ac.selected <= 0:
+		ac.selected = n - 1
+	case up:
+		ac.selected--
+	default:
+		ac.selected = (ac.selected + 1) % n
+	}
+}
+
+// highlighted returns the highlighted suggestion.
+func (ac *Autocomplete) highlighted() (string, bool) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if ac.selected < 0 || ac.selected >= len(ac.suggestions) {
+		return "", false
+	}
+	return ac.suggestions[ac.selected], true
+}
+
+// completion returns the suggestion Tab accepts: the highlighted one, or the first.
+func (ac *Autocomplete) completion() (string, bool) {
+	if suggestion, found := ac.highlighted(); found {
+		return suggestion, true
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if len(ac.suggestions) == 0 {
+		return "", false
+	}
+	return ac.suggestions[0], true
+}
+
+// dismiss hides the suggestions until the text is edited again.
+func (ac *Autocomplete) dismiss() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.requested, ac.query, ac.suggestions, ac.selected = "", "", nil, -1
+	ac.hovered = false
+}
+
+// matchSpan returns the byte span of the first occurrence of query in s, ignoring case.
+func matchSpan(s, query string) (int, int, bool) {
+	if query == "" {
+		return 0, 0, false
+	}
+	for start := range s {
+		if end, found := foldPrefix(s[start:], query); found {
+			return start, start + end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// foldPrefix reports whether s starts with prefix, ignoring case, and the length in s
+// of the match.
+func foldPrefix(s, prefix string) (int, bool) {
+	n := 0
+	for _, p := range prefix {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 || !equalFoldRune(r, p) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+func equalFoldRune(a, b rune) bool {
+	return a == b || strings.EqualFold(string(a), string(b))
+}
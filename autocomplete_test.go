@@ -0,0 +1,89 @@
+package dfx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchSpan(t *testing.T) {
+	tests := []struct {
+		s, query   string
+		start, end int
+		found      bool
+	}{
+		{"Hello World", "world", 6, 11, true},
+		{"Hello", "HE", 0, 2, true},
+		{"Straße", "SSE", 0, 0, false},
+		{"Ärger", "är", 0, 3, true},
+		{"abc", "", 0, 0, false},
+		{"abc", "abcd", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, end, found := matchSpan(tt.s, tt.query)
+		if start != tt.start || end != tt.end || found != tt.found {
+			t.Errorf("matchSpan(%q, %q) = %d, %d, %v, expected %d, %d, %v", tt.s, tt.query, start, end, found, tt.start, tt.end, tt.found)
+		}
+	}
+}
+
+func TestMatchSuggestions(t *testing.T) {
+	suggest := MatchSuggestions([]string{"sawtooth", "square", "sine", "noise", "pulse"})
+	if got, expected := suggest("s"), []string{"sawtooth", "sine", "square", "noise", "pulse"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := suggest("xyz"); len(got) != 0 {
+		t.Errorf("expected no suggestions, got %v", got)
+	}
+}
+
+func TestAutocompleteSuggestions(t *testing.T) {
+	ac := NewAutocomplete(func(text string) []string { return []string{text + "1", text + "2", text + "3"} })
+	ac.MaxSuggestions = 2
+	ac.request("ab")
+	if !reflect.DeepEqual(ac.suggestions, []string{"ab1", "ab2"}) || ac.query != "ab" {
+		t.Fatalf("expected two suggestions for 'ab', got %v for %q", ac.suggestions, ac.query)
+	}
+
+	if suggestion, _ := ac.completion(); suggestion != "ab1" {
+		t.Errorf("expected Tab to complete the first suggestion, got %q", suggestion)
+	}
+	if _, found := ac.highlighted(); found {
+		t.Error("expected nothing highlighted before moving")
+	}
+	ac.moveSelection(true)
+	if suggestion, _ := ac.highlighted(); suggestion != "ab2" {
+		t.Errorf("expected Up to wrap to the last suggestion, got %q", suggestion)
+	}
+	ac.moveSelection(false)
+	if suggestion, _ := ac.completion(); suggestion != "ab1" {
+		t.Errorf("expected Down to wrap to the first suggestion, got %q", suggestion)
+	}
+
+	ac.MinChars = 3
+	ac.request("ab")
+	if len(ac.suggestions) != 0 {
+		t.Errorf("expected no suggestions below MinChars, got %v", ac.suggestions)
+	}
+}
+
+func TestAutocompleteAsync(t *testing.T) {
+	pending := map[string]func([]string){}
+	ac := NewAsyncAutocomplete(func(text string, deliver func([]string)) { pending[text] = deliver })
+
+	ac.request("a")
+	ac.request("ab")
+	pending["a"]([]string{"stale"})
+	if len(ac.suggestions) != 0 {
+		t.Errorf("expected the answer for edited text to be dropped, got %v", ac.suggestions)
+	}
+	pending["ab"]([]string{"abc"})
+	if !reflect.DeepEqual(ac.suggestions, []string{"abc"}) {
+		t.Errorf("expected the answer for the current text, got %v", ac.suggestions)
+	}
+
+	ac.dismiss()
+	pending["ab"]([]string{"late"})
+	if len(ac.suggestions) != 0 {
+		t.Errorf("expected answers after dismissing to be dropped, got %v", ac.suggestions)
+	}
+}
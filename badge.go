@@ -0,0 +1,73 @@
+package dfx
+
+import (
+	"strconv"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// DefaultBadgeMax is the largest count a badge shows before overflowing to "99+".
+const DefaultBadgeMax = 99
+
+// BadgeParams configures a Badge.
+type BadgeParams struct {
+	Color     imgui.Vec4 // badge background (zero = theme Error)
+	TextColor imgui.Vec4 // count text (zero = white)
+	Max       int        // larger counts show as "Max+" (0 = DefaultBadgeMax)
+}
+
+// Badge overlays a count on the top right corner of the previously drawn item (a button,
+// a tab, a workspace tab), for unread counts and error totals. counts of zero or less
+// draw nothing.
+//
+//	imgui.Button("Inbox")
+//	dfx.Badge(unread, dfx.BadgeParams{})
+func Badge(count int, params BadgeParams) {
+	if count <= 0 {
+		return
+	}
+	text := badgeText(count, params.Max)
+	PushFont(SmallFont)
+	defer PopFont()
+
+	size := imgui.CalcTextSize(text)
+	height := size.Y + 2
+	width := max(size.X+height/2, height)
+	min, max := badgeRect(imgui.ItemRectMax().X, imgui.ItemRectMin().Y, width, height)
+
+	dl := imgui.WindowDrawList()
+	dl.PushClipRectFullScreen()
+	dl.AddRectFilledV(min, max, imgui.ColorConvertFloat4ToU32(themeColor(params.Color, ThemeColors().Error)), height/2, imgui.DrawFlagsNone)
+	textColor := themeColor(params.TextColor, imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1})
+	dl.AddTextVec2(imgui.Vec2{X: min.X + (width-size.X)/2, Y: min.Y + (height-size.Y)/2}, imgui.ColorConvertFloat4ToU32(textColor), text)
+	dl.PopClipRect()
+}
+
+// BadgeDot overlays a status dot on the top right corner of the previously drawn item, in
+// color (zero = theme Error).
+func BadgeDot(color imgui.Vec4) {
+	radius := imgui.FontSize() / 4
+	min, max := badgeRect(imgui.ItemRectMax().X, imgui.ItemRectMin().Y, radius*2, radius*2)
+	dl := imgui.WindowDrawList()
+	dl.PushClipRectFullScreen()
+	dl.AddCircleFilled(imgui.Vec2{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2}, radius, imgui.ColorConvertFloat4ToU32(themeColor(color, ThemeColors().Error)))
+	dl.PopClipRect()
+}
+
+// badgeText formats a count, overflowing above limit (0 = DefaultBadgeMax) to "limit+".
+func badgeText(count, limit int) string {
+	if limit <= 0 {
+		limit = DefaultBadgeMax
+	}
+	if count > limit {
+		return strconv.Itoa(limit) + "+"
+	}
+	return strconv.Itoa(count)
+}
+
+// badgeRect places a badge of width and height over the corner at right, top: centered
+// on it vertically, and overlapping the item by most of its width.
+func badgeRect(right, top, width, height float32) (imgui.Vec2, imgui.Vec2) {
+	min := imgui.Vec2{X: right - width*0.75, Y: top - height/2}
+	return min, imgui.Vec2{X: min.X + width, Y: min.Y + height}
+}
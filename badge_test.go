@@ -0,0 +1,27 @@
+package dfx
+
+import "testing"
+
+func TestBadgeText(t *testing.T) {
+	cases := []struct {
+		count, limit int
+		expected     string
+	}{
+		{7, 0, "7"},
+		{99, 0, "99"},
+		{100, 0, "99+"},
+		{12, 9, "9+"},
+	}
+	for _, c := range cases {
+		if got := badgeText(c.count, c.limit); got != c.expected {
+			t.Errorf("badgeText(%d, %d) = %q, expected %q", c.count, c.limit, got, c.expected)
+		}
+	}
+}
+
+func TestBadgeRect(t *testing.T) {
+	min, max := badgeRect(100, 20, 16, 12)
+	if min.X != 88 || max.X != 104 || min.Y != 14 || max.Y != 26 {
+		t.Errorf("expected the badge over the corner, got %v..%v", min, max)
+	}
+}
@@ -0,0 +1,458 @@
+package dfx
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// canvas constants
+const (
+	canvasMinCircleSegments = 12
+	canvasMaxCircleSegments = 64
+)
+
+// Transform is a 2D affine transform, mapping (x, y) to (A*x + C*y + E, B*x + D*y + F).
+type Transform struct {
+	A, B, C, D, E, F float32
+}
+
+// IdentityTransform returns the transform that leaves points where they are.
+func IdentityTransform() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// TranslateTransform returns a transform moving points by (x, y).
+func TranslateTransform(x, y float32) Transform {
+	return Transform{A: 1, D: 1, E: x, F: y}
+}
+
+// ScaleTransform returns a transform scaling points by (sx, sy) around the origin.
+func ScaleTransform(sx, sy float32) Transform {
+	return Transform{A: sx, D: sy}
+}
+
+// RotateTransform returns a transform rotating points by radians around the origin,
+// clockwise on screen (where y points down).
+func RotateTransform(radians float32) Transform {
+	sin, cos := math.Sincos(float64(radians))
+	return Transform{A: float32(cos), B: float32(sin), C: float32(-sin), D: float32(cos)}
+}
+
+// Mul returns the transform applying o and then t.
+func (t Transform) Mul(o Transform) Transform {
+	return Transform{
+		A: t.A*o.A + t.C*o.B,
+		B: t.B*o.A + t.D*o.B,
+		C: t.A*o.C + t.C*o.D,
+		D: t.B*o.C + t.D*o.D,
+		E: t.A*o.E + t.C*o.F + t.E,
+		F: t.B*o.E + t.D*o.F + t.F,
+	}
+}
+
+// Apply transforms point p.
+func (t Transform) Apply(p imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: t.A*p.X + t.C*p.Y + t.E, Y: t.B*p.X + t.D*p.Y + t.F}
+}
+
+// ApplyVector transforms the direction v, ignoring translation.
+func (t Transform) ApplyVector(v imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: t.A*v.X + t.C*v.Y, Y: t.B*v.X + t.D*v.Y}
+}
+
+// Invert returns the transform undoing t, or false when t collapses points onto a line.
+func (t Transform) Invert() (Transform, bool) {
+	det := t.A*t.D - t.B*t.C
+	if det == 0 {
+		return Transform{}, false
+	}
+	return Transform{
+		A: t.D / det,
+		B: -t.B / det,
+		C: -t.C / det,
+		D: t.A / det,
+		E: (t.C*t.F - t.D*t.E) / det,
+		F: (t.B*t.E - t.A*t.F) / det,
+	}, true
+}
+
+// ScaleFactor returns how much t scales lengths on average, which scales stroke widths
+// and text.
+func (t Transform) ScaleFactor() float32 {
+	return float32(math.Sqrt(math.Abs(float64(t.A*t.D - t.B*t.C))))
+}
+
+// Canvas is a drawing surface with its own coordinate system. OnDraw draws on it through
+// Graphics, in canvas coordinates that View maps onto the canvas area (with the origin
+// at its top left), and the input callbacks receive positions in canvas coordinates too.
+// a press on the canvas captures the mouse until it is released, so drags continue
+// outside it.
+type Canvas struct {
+	Container
+	Width      float32    // canvas width (0 = available width)
+	Height     float32    // canvas height (0 = available height)
+	Background imgui.Vec4 // fill behind the drawing (zero = none)
+	View       Transform  // canvas coordinates to the canvas area (zero = identity)
+
+	OnDraw      func(g *Graphics)
+	OnMouseDown func(p imgui.Vec2, button imgui.MouseButton)
+	OnMouseUp   func(p imgui.Vec2, button imgui.MouseButton)
+	OnMouseMove func(p imgui.Vec2)                                  // the pointer moved over the canvas
+	OnDrag      func(p, delta imgui.Vec2, button imgui.MouseButton) // the pointer moved with a button pressed on the canvas
+	OnWheel     func(p imgui.Vec2, wheel float32)
+
+	pressed [imgui.MouseButtonCOUNT]bool
+}
+
+// NewCanvas creates a canvas drawn by draw.
+func NewCanvas(draw func(g *Graphics)) *Canvas {
+	return &Canvas{Container: Container{Visible: true}, View: IdentityTransform(), OnDraw: draw}
+}
+
+// view returns View, or the identity when it is unset.
+func (c *Canvas) view() Transform {
+	if c.View == (Transform{}) {
+		return IdentityTransform()
+	}
+	return c.View
+}
+
+// Draw implements Component.
+func (c *Canvas) Draw(state *State) {
+	if !c.Visible {
+		return
+	}
+	imgui.PushIDStr(componentID("canvas", c))
+	defer imgui.PopID()
+
+	size := imgui.ContentRegionAvail()
+	if c.Width > 0 {
+		size.X = c.Width
+	}
+	if c.Height > 0 {
+		size.Y = c.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButtonV("##canvas", size, imgui.ButtonFlagsMouseButtonLeft|imgui.ButtonFlagsMouseButtonRight|imgui.ButtonFlagsMouseButtonMiddle)
+	hovered := imgui.IsItemHovered()
+
+	toScreen := TranslateTransform(pos.X, pos.Y).Mul(c.view())
+	c.handleInput(toScreen, hovered)
+
+	drawList := imgui.WindowDrawList()
+	drawList.PushClipRectV(pos, pos.Add(size), true)
+	if c.Background != (imgui.Vec4{}) {
+		drawList.AddRectFilled(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(c.Background))
+	}
+	if c.OnDraw != nil {
+		c.OnDraw(&Graphics{drawList: drawList, size: size, hovered: hovered, transform: toScreen})
+	}
+	drawList.PopClipRect()
+
+	drawContainerExtensions(&c.Container, state)
+}
+
+// handleInput reports presses, releases, moves, drags and the wheel in canvas coordinates.
+func (c *Canvas) handleInput(toScreen Transform, hovered bool) {
+	toCanvas, ok := toScreen.Invert()
+	if !ok {
+		return
+	}
+	io := imgui.CurrentIO()
+	p := toCanvas.Apply(imgui.MousePos())
+	delta := toCanvas.ApplyVector(io.MouseDelta())
+	moved := delta.X != 0 || delta.Y != 0
+	for _, button := range []imgui.MouseButton{imgui.MouseButtonLeft, imgui.MouseButtonRight, imgui.MouseButtonMiddle} {
+		if hovered && imgui.IsMouseClickedBool(button) {
+			c.pressed[button] = true
+			if c.OnMouseDown != nil {
+				c.OnMouseDown(p, button)
+			}
+		}
+		if !c.pressed[button] {
+			continue
+		}
+		if moved && c.OnDrag != nil {
+			c.OnDrag(p, delta, button)
+		}
+		if imgui.IsMouseReleased(button) {
+			c.pressed[button] = false
+			if c.OnMouseUp != nil {
+				c.OnMouseUp(p, button)
+			}
+		}
+	}
+	if hovered && moved && c.OnMouseMove != nil {
+		c.OnMouseMove(p)
+	}
+	if wheel := io.MouseWheel(); hovered && wheel != 0 && c.OnWheel != nil {
+		c.OnWheel(p, wheel)
+	}
+}
+
+// Graphics draws on a Canvas in canvas coordinates, through a transform stack: Translate,
+// Scale and Rotate change the current transform, and Save and Restore push and pop it.
+// stroke widths and text scale with the transform; text is not rotated.
+type Graphics struct {
+	drawList  *imgui.DrawList
+	size      imgui.Vec2
+	hovered   bool
+	transform Transform
+	stack     []Transform
+	paths     [][]pathOp
+}
+
+// pathOp is a segment of a path: a line to the last point, or a bezier through all three.
+// points are transformed as they are added, so the path keeps the transform it was built
+// with.
+type pathOp struct {
+	points []imgui.Vec2 // screen coordinates
+}
+
+// DrawList returns the underlying draw list, for drawing in screen coordinates.
+func (g *Graphics) DrawList() *imgui.DrawList {
+	return g.drawList
+}
+
+// Size returns the size of the canvas area in pixels.
+func (g *Graphics) Size() imgui.Vec2 {
+	return g.size
+}
+
+// Hovered reports whether the pointer is over the canvas.
+func (g *Graphics) Hovered() bool {
+	return g.hovered
+}
+
+// Mouse returns the pointer position in the current coordinates, for hit testing what
+// is drawn.
+func (g *Graphics) Mouse() imgui.Vec2 {
+	return g.ToLocal(imgui.MousePos())
+}
+
+// Transform returns the current transform, from the current coordinates to the screen.
+func (g *Graphics) Transform() Transform {
+	return g.transform
+}
+
+// SetTransform replaces the current transform.
+func (g *Graphics) SetTransform(t Transform) {
+	g.transform = t
+}
+
+// Save pushes the current transform.
+func (g *Graphics) Save() {
+	g.stack = append(g.stack, g.transform)
+}
+
+// Restore pops the transform pushed by the last Save.
+func (g *Graphics) Restore() {
+	if n := len(g.stack); n > 0 {
+		g.transform = g.stack[n-1]
+		g.stack = g.stack[:n-1]
+	}
+}
+
+// Translate moves the origin to (x, y).
+func (g *Graphics) Translate(x, y float32) {
+	g.transform = g.transform.Mul(TranslateTransform(x, y))
+}
+
+// Scale scales the coordinates by (sx, sy).
+func (g *Graphics) Scale(sx, sy float32) {
+	g.transform = g.transform.Mul(ScaleTransform(sx, sy))
+}
+
+// Rotate rotates the coordinates by radians, clockwise.
+func (g *Graphics) Rotate(radians float32) {
+	g.transform = g.transform.Mul(RotateTransform(radians))
+}
+
+// ToScreen returns the screen position of p in the current coordinates.
+func (g *Graphics) ToScreen(p imgui.Vec2) imgui.Vec2 {
+	return g.transform.Apply(p)
+}
+
+// ToLocal returns the position in the current coordinates of the screen position p.
+func (g *Graphics) ToLocal(p imgui.Vec2) imgui.Vec2 {
+	inverse, ok := g.transform.Invert()
+	if !ok {
+		return imgui.Vec2{}
+	}
+	return inverse.Apply(p)
+}
+
+// thickness scales a stroke width by the transform.
+func (g *Graphics) thickness(width float32) float32 {
+	return max(width*g.transform.ScaleFactor(), 1)
+}
+
+// Line draws a line from a to b.
+func (g *Graphics) Line(a, b imgui.Vec2, color imgui.Vec4, width float32) {
+	g.drawList.AddLineV(g.ToScreen(a), g.ToScreen(b), imgui.ColorConvertFloat4ToU32(color), g.thickness(width))
+}
+
+// StrokeRect outlines the rectangle from min to max.
+func (g *Graphics) StrokeRect(min, max imgui.Vec2, color imgui.Vec4, width float32) {
+	g.StrokePolygon(rectPoints(min, max), true, color, width)
+}
+
+// FillRect fills the rectangle from min to max.
+func (g *Graphics) FillRect(min, max imgui.Vec2, color imgui.Vec4) {
+	g.FillPolygon(rectPoints(min, max), color)
+}
+
+// StrokeCircle outlines the circle at center with radius.
+func (g *Graphics) StrokeCircle(center imgui.Vec2, radius float32, color imgui.Vec4, width float32) {
+	g.StrokePolygon(g.circlePoints(center, radius), true, color, width)
+}
+
+// FillCircle fills the circle at center with radius.
+func (g *Graphics) FillCircle(center imgui.Vec2, radius float32, color imgui.Vec4) {
+	g.FillPolygon(g.circlePoints(center, radius), color)
+}
+
+// StrokePolygon draws lines through points, back to the first when closed.
+func (g *Graphics) StrokePolygon(points []imgui.Vec2, closed bool, color imgui.Vec4, width float32) {
+	if len(points) < 2 {
+		return
+	}
+	for _, p := range points {
+		g.drawList.PathLineTo(g.ToScreen(p))
+	}
+	flags := imgui.DrawFlagsNone
+	if closed {
+		flags = imgui.DrawFlagsClosed
+	}
+	g.drawList.PathStrokeV(imgui.ColorConvertFloat4ToU32(color), flags, g.thickness(width))
+}
+
+// FillPolygon fills the polygon through points, which may be concave.
+func (g *Graphics) FillPolygon(points []imgui.Vec2, color imgui.Vec4) {
+	if len(points) < 3 {
+		return
+	}
+	for _, p := range points {
+		g.drawList.PathLineTo(g.ToScreen(p))
+	}
+	g.drawList.PathFillConcave(imgui.ColorConvertFloat4ToU32(color))
+}
+
+// Text draws text with its top left at pos, sized by the transform.
+func (g *Graphics) Text(pos imgui.Vec2, color imgui.Vec4, text string) {
+	size := imgui.FontSize() * g.transform.ScaleFactor()
+	g.drawList.AddTextFontPtr(imgui.CurrentFont(), size, g.ToScreen(pos), imgui.ColorConvertFloat4ToU32(color), text)
+}
+
+// MeasureText returns the size of text in the current coordinates.
+func (g *Graphics) MeasureText(text string) imgui.Vec2 {
+	return imgui.CalcTextSize(text)
+}
+
+// BeginPath starts a new path, discarding the current one.
+func (g *Graphics) BeginPath() {
+	g.paths = nil
+}
+
+// MoveTo starts a new subpath at p.
+func (g *Graphics) MoveTo(p imgui.Vec2) {
+	g.paths = append(g.paths, []pathOp{{points: []imgui.Vec2{g.ToScreen(p)}}})
+}
+
+// LineTo adds a line from the current point to p.
+func (g *Graphics) LineTo(p imgui.Vec2) {
+	g.addOp(pathOp{points: []imgui.Vec2{g.ToScreen(p)}})
+}
+
+// BezierTo adds a cubic bezier from the current point to p, with control points c1 and c2.
+func (g *Graphics) BezierTo(c1, c2, p imgui.Vec2) {
+	g.addOp(pathOp{points: []imgui.Vec2{g.ToScreen(c1), g.ToScreen(c2), g.ToScreen(p)}})
+}
+
+// ClosePath adds a line from the current point back to the start of the subpath.
+func (g *Graphics) ClosePath() {
+	if n := len(g.paths); n > 0 && len(g.paths[n-1]) > 0 {
+		g.addOp(pathOp{points: []imgui.Vec2{g.paths[n-1][0].points[0]}})
+	}
+}
+
+// addOp adds a segment to the current subpath, starting one at its end if there is none.
+func (g *Graphics) addOp(op pathOp) {
+	if len(g.paths) == 0 {
+		g.paths = append(g.paths, []pathOp{{points: op.points[len(op.points)-1:]}})
+		return
+	}
+	g.paths[len(g.paths)-1] = append(g.paths[len(g.paths)-1], op)
+}
+
+// Stroke draws the current path.
+func (g *Graphics) Stroke(color imgui.Vec4, width float32) {
+	for _, path := range g.paths {
+		g.tracePath(path)
+		g.drawList.PathStrokeV(imgui.ColorConvertFloat4ToU32(color), imgui.DrawFlagsNone, g.thickness(width))
+	}
+}
+
+// Fill fills the current path, each subpath closed.
+func (g *Graphics) Fill(color imgui.Vec4) {
+	for _, path := range g.paths {
+		g.tracePath(path)
+		g.drawList.PathFillConcave(imgui.ColorConvertFloat4ToU32(color))
+	}
+}
+
+// tracePath adds a subpath to the draw list's path.
+func (g *Graphics) tracePath(path []pathOp) {
+	for _, op := range path {
+		if len(op.points) == 3 {
+			g.drawList.PathBezierCubicCurveTo(op.points[0], op.points[1], op.points[2])
+		} else {
+			g.drawList.PathLineTo(op.points[0])
+		}
+	}
+}
+
+// circlePoints returns points around a circle, enough for it to look round at the
+// current scale.
+func (g *Graphics) circlePoints(center imgui.Vec2, radius float32) []imgui.Vec2 {
+	segments := int(clamp(radius*g.transform.ScaleFactor()/2, canvasMinCircleSegments, canvasMaxCircleSegments))
+	points := make([]imgui.Vec2, segments)
+	for i := range points {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(segments))
+		points[i] = imgui.Vec2{X: center.X + radius*float32(cos), Y: center.Y + radius*float32(sin)}
+	}
+	return points
+}
+
+func rectPoints(min, max imgui.Vec2) []imgui.Vec2 {
+	return []imgui.Vec2{min, {X: max.X, Y: min.Y}, max, {X: min.X, Y: max.Y}}
+}
+
+// HitRect reports whether p is inside the rectangle from min to max.
+func HitRect(p, min, max imgui.Vec2) bool {
+	return p.X >= min.X && p.X <= max.X && p.Y >= min.Y && p.Y <= max.Y
+}
+
+// HitCircle reports whether p is inside the circle at center with radius.
+func HitCircle(p, center imgui.Vec2, radius float32) bool {
+	return distance(p, center) <= radius
+}
+
+// HitSegment reports whether p is within tolerance of the line from a to b.
+func HitSegment(p, a, b imgui.Vec2, tolerance float32) bool {
+	return segmentDistance(a, b, p) <= tolerance
+}
+
+// HitPolygon reports whether p is inside the polygon through points, by the even-odd rule.
+func HitPolygon(p imgui.Vec2, points []imgui.Vec2) bool {
+	inside := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,90 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func nearVec(a, b imgui.Vec2) bool {
+	return math.Abs(float64(a.X-b.X)) < 1e-4 && math.Abs(float64(a.Y-b.Y)) < 1e-4
+}
+
+func TestTransform(t *testing.T) {
+	// translate, then rotate a quarter turn, then scale by 2: transforms compose right to left
+	m := ScaleTransform(2, 2).Mul(RotateTransform(math.Pi / 2)).Mul(TranslateTransform(1, 0))
+	if p := m.Apply(imgui.Vec2{X: 1, Y: 0}); !nearVec(p, imgui.Vec2{X: 0, Y: 4}) {
+		t.Errorf("expected (0, 4), got %v", p)
+	}
+	inverse, ok := m.Invert()
+	if !ok {
+		t.Fatal("expected the transform to invert")
+	}
+	if p := inverse.Apply(imgui.Vec2{X: 0, Y: 4}); !nearVec(p, imgui.Vec2{X: 1, Y: 0}) {
+		t.Errorf("expected the inverse to map back to (1, 0), got %v", p)
+	}
+	if s := m.ScaleFactor(); math.Abs(float64(s-2)) > 1e-4 {
+		t.Errorf("expected scale factor 2, got %v", s)
+	}
+	if v := TranslateTransform(5, 5).ApplyVector(imgui.Vec2{X: 1, Y: 2}); v != (imgui.Vec2{X: 1, Y: 2}) {
+		t.Errorf("expected vectors to ignore translation, got %v", v)
+	}
+	if _, ok := ScaleTransform(0, 1).Invert(); ok {
+		t.Error("expected a flattening transform not to invert")
+	}
+}
+
+func TestGraphicsTransformStack(t *testing.T) {
+	g := &Graphics{transform: TranslateTransform(100, 50)}
+	g.Save()
+	g.Translate(10, 0)
+	g.Scale(2, 2)
+	if p := g.ToScreen(imgui.Vec2{X: 5, Y: 5}); p != (imgui.Vec2{X: 120, Y: 60}) {
+		t.Errorf("expected (120, 60), got %v", p)
+	}
+	if p := g.ToLocal(imgui.Vec2{X: 120, Y: 60}); p != (imgui.Vec2{X: 5, Y: 5}) {
+		t.Errorf("expected (5, 5), got %v", p)
+	}
+	g.Restore()
+	g.Restore() // unbalanced restores are ignored
+	if g.Transform() != TranslateTransform(100, 50) {
+		t.Errorf("expected the saved transform restored, got %v", g.Transform())
+	}
+}
+
+func TestGraphicsPath(t *testing.T) {
+	g := &Graphics{transform: TranslateTransform(10, 0)}
+	g.BeginPath()
+	g.LineTo(imgui.Vec2{X: 1, Y: 1}) // starts a subpath
+	g.Translate(0, 10)
+	g.LineTo(imgui.Vec2{X: 2, Y: 2})
+	g.ClosePath()
+	if len(g.paths) != 1 || len(g.paths[0]) != 3 {
+		t.Fatalf("expected one subpath of three points, got %v", g.paths)
+	}
+	if p := g.paths[0][1].points[0]; p != (imgui.Vec2{X: 12, Y: 12}) {
+		t.Errorf("expected points transformed as added, got %v", p)
+	}
+	if p := g.paths[0][2].points[0]; p != (imgui.Vec2{X: 11, Y: 1}) {
+		t.Errorf("expected the path closed at its start, got %v", p)
+	}
+}
+
+func TestHitTests(t *testing.T) {
+	p := imgui.Vec2{X: 5, Y: 5}
+	if !HitRect(p, imgui.Vec2{}, imgui.Vec2{X: 10, Y: 10}) || HitRect(p, imgui.Vec2{X: 6}, imgui.Vec2{X: 10, Y: 10}) {
+		t.Error("HitRect")
+	}
+	if !HitCircle(p, imgui.Vec2{X: 8, Y: 9}, 5) || HitCircle(p, imgui.Vec2{X: 8, Y: 9}, 4.9) {
+		t.Error("HitCircle")
+	}
+	if !HitSegment(p, imgui.Vec2{X: 0, Y: 6}, imgui.Vec2{X: 10, Y: 6}, 1) || HitSegment(p, imgui.Vec2{X: 0, Y: 7}, imgui.Vec2{X: 10, Y: 7}, 1) {
+		t.Error("HitSegment")
+	}
+	// a U shape: the notch is outside
+	u := []imgui.Vec2{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 6}, {X: 7, Y: 6}, {X: 7, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	if HitPolygon(p, u) || !HitPolygon(imgui.Vec2{X: 5, Y: 8}, u) || !HitPolygon(imgui.Vec2{X: 1, Y: 1}, u) {
+		t.Error("HitPolygon")
+	}
+}
@@ -0,0 +1,393 @@
+package dfx
+
+import (
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// StripElement is a part of a ChannelStrip; combine them to choose which appear.
+type StripElement uint
+
+const (
+	StripLabel StripElement = 1 << iota // the channel name
+	StripGain                           // input gain knob
+	StripPan                            // pan knob
+	StripMute                           // mute toggle
+	StripSolo                           // solo toggle
+	StripArm                            // record arm toggle
+	StripFader                          // volume fader
+	StripMeter                          // meter in or beside the fader
+	StripSends                          // a knob per send
+
+	StripAll = StripLabel | StripGain | StripPan | StripMute | StripSolo | StripArm | StripFader | StripMeter | StripSends
+)
+
+// StripSend is a send from a ChannelStrip to a bus, at a level from 0 to 1.
+type StripSend struct {
+	Name  string
+	Level float32
+}
+
+// ChannelStrip is a mixer channel: from the top, its name, input gain and pan knobs, the
+// sends, mute, solo and arm toggles, and a volume fader with a meter, which takes the
+// height left over. Elements chooses which of these appear. gain and volume are
+// normalized (0-1) and read through the Gain and Fader params; pan runs from -1 (left)
+// to 1 (right). set the meter level each frame with SetLevel, from any goroutine.
+type ChannelStrip struct {
+	Container
+	Name     string
+	Elements StripElement // the parts drawn (0 = StripAll)
+	Width    float32      // strip width (0 = 4 frame heights)
+
+	Gain   float32
+	Pan    float32
+	Volume float32
+	Muted  bool
+	Soloed bool
+	Armed  bool
+	Sends  []StripSend
+
+	GainParams KnobParams  // gain knob (Format shows the gain in your units)
+	Fader      FaderParams // volume fader and its meter (Height is the least it shrinks to)
+
+	OnChange func(strip *ChannelStrip) // called after each change made in the strip
+
+	levelMu sync.Mutex // guards level, set from any goroutine
+	level   float32    // meter level
+}
+
+// NewChannelStrip creates a strip named name with every element.
+func NewChannelStrip(name string) *ChannelStrip {
+	fader := DefaultFaderParams()
+	fader.Height = 120
+	return &ChannelStrip{
+		Container:  Container{Visible: true},
+		Name:       name,
+		Elements:   StripAll,
+		GainParams: DefaultKnobParams(),
+		Fader:      fader,
+	}
+}
+
+// SetLevel sets the level shown by the meter (0.0 to 1.0). it is safe to call from any
+// goroutine.
+func (cs *ChannelStrip) SetLevel(level float32) {
+	cs.levelMu.Lock()
+	defer cs.levelMu.Unlock()
+	cs.level = clamp(level, 0, 1)
+}
+
+// meterLevel returns the level set for the meter.
+func (cs *ChannelStrip) meterLevel() float32 {
+	cs.levelMu.Lock()
+	defer cs.levelMu.Unlock()
+	return cs.level
+}
+
+// Has reports whether the strip draws element.
+func (cs *ChannelStrip) Has(element StripElement) bool {
+	elements := cs.Elements
+	if elements == 0 {
+		elements = StripAll
+	}
+	return elements&element != 0
+}
+
+// width returns Width, defaulting to 4 frame heights.
+func (cs *ChannelStrip) width() float32 {
+	if cs.Width > 0 {
+		return cs.Width
+	}
+	return imgui.FrameHeight() * 4
+}
+
+// Draw implements Component. the strip is as tall as state.Size, or fits its fader at
+// Fader.Height when there is no size.
+func (cs *ChannelStrip) Draw(state *State) {
+	if !cs.Visible {
+		return
+	}
+	var height float32
+	if state != nil {
+		height = state.Size.Y
+	}
+	imgui.PushIDStr(componentID("channelStrip", cs))
+	defer imgui.PopID()
+
+	flags := imgui.ChildFlagsBorders
+	if height <= 0 {
+		flags |= imgui.ChildFlagsAutoResizeY
+	}
+	if imgui.BeginChildStrV("##strip", imgui.Vec2{X: cs.width(), Y: height}, flags, imgui.WindowFlagsNoScrollbar) {
+		if cs.drawElements(height > 0) && cs.OnChange != nil {
+			cs.OnChange(cs)
+		}
+	}
+	imgui.EndChild()
+
+	drawContainerExtensions(&cs.Container, state)
+}
+
+// drawElements draws the parts of the strip, centered in its width, with the fader
+// filling the height left when fill. returns true when one was changed.
+func (cs *ChannelStrip) drawElements(fill bool) bool {
+	changed := false
+	width := imgui.ContentRegionAvail().X
+	knob := imgui.FrameHeight() * 1.5
+
+	if cs.Has(StripLabel) {
+		stripCentered(imgui.CalcTextSize(cs.Name).X, width)
+		imgui.Text(cs.Name)
+	}
+	if cs.Has(StripGain) {
+		stripCentered(knob, width)
+		params := cs.GainParams
+		params.Size = knob
+		if params.AccessibleLabel == "" {
+			params.AccessibleLabel = T("dfx.strip.gain")
+		}
+		if gain, ok := Knob("##gain", cs.Gain, 0, 1, params); ok {
+			cs.Gain, changed = gain, true
+		}
+	}
+	if cs.Has(StripPan) {
+		stripCentered(knob, width)
+		params := DefaultKnobParams()
+		params.Size = knob
+		params.Bipolar = true
+		params.Format = panFormat
+		params.AccessibleLabel = T("dfx.strip.pan")
+		if pan, ok := Knob("##pan", cs.Pan, -1, 1, params); ok {
+			cs.Pan, changed = pan, true
+		}
+	}
+	if cs.Has(StripSends) {
+		small := imgui.FrameHeight()
+		for i := range cs.Sends {
+			send := &cs.Sends[i]
+			imgui.PushIDInt(int32(i))
+			params := DefaultKnobParams()
+			params.Size = small
+			params.AccessibleLabel = send.Name
+			if level, ok := Knob("##send", send.Level, 0, 1, params); ok {
+				send.Level, changed = level, true
+			}
+			imgui.SameLineV(0, imgui.CurrentStyle().ItemInnerSpacing().X)
+			imgui.AlignTextToFramePadding()
+			imgui.Text(send.Name)
+			imgui.PopID()
+		}
+	}
+	if cs.drawToggles(width) {
+		changed = true
+	}
+	if cs.Has(StripFader) {
+		if cs.drawFader(width, fill) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// drawToggles draws the mute, solo and arm toggles the strip has on one row. returns true
+// when one was toggled.
+func (cs *ChannelStrip) drawToggles(width float32) bool {
+	type toggle struct {
+		element        StripElement
+		label, tooltip string
+		on             *bool
+		color          imgui.Vec4
+	}
+	colors := ThemeColors()
+	all := [...]toggle{
+		{StripMute, T("dfx.router.mute"), T("dfx.router.muteTooltip"), &cs.Muted, colors.Error},
+		{StripSolo, T("dfx.router.solo"), T("dfx.router.soloTooltip"), &cs.Soloed, colors.Warning},
+		{StripArm, T("dfx.strip.arm"), T("dfx.strip.armTooltip"), &cs.Armed, colors.MeterClip},
+	}
+	toggles := all[:0] // filtered in place, so drawing allocates nothing
+	for _, t := range all {
+		if cs.Has(t.element) {
+			toggles = append(toggles, t)
+		}
+	}
+	if len(toggles) == 0 {
+		return false
+	}
+
+	spacing := imgui.CurrentStyle().ItemInnerSpacing().X
+	stripCentered(float32(len(toggles))*imgui.FrameHeight()+float32(len(toggles)-1)*spacing, width)
+	changed := false
+	for i, t := range toggles {
+		if i > 0 {
+			imgui.SameLineV(0, spacing)
+		}
+		if routerToggle(t.label, t.tooltip, *t.on, t.color) {
+			*t.on = !*t.on
+			changed = true
+		}
+	}
+	return changed
+}
+
+// drawFader draws the volume fader, with the meter when the strip has one, filling the
+// height left in the strip when fill. returns true when the volume changed.
+func (cs *ChannelStrip) drawFader(width float32, fill bool) bool {
+	params := cs.Fader
+	if params.AccessibleLabel == "" {
+		params.AccessibleLabel = accessibleText(cs.Name, T("dfx.strip.volume"))
+	}
+	if avail := imgui.ContentRegionAvail().Y; fill && avail > params.Height {
+		params.Height = avail
+	}
+
+	if params.Width == 0 {
+		params.Width = DefaultFaderParams().Width
+	}
+	total := params.Width
+	if cs.Has(StripMeter) {
+		total += params.Meter.besideWidth()
+	}
+	stripCentered(total, width)
+
+	var volume float32
+	var changed bool
+	if cs.Has(StripMeter) {
+		volume, changed = FaderWithMeter("##volume", cs.Volume, cs.meterLevel(), params)
+	} else {
+		volume, changed = FaderN("##volume", cs.Volume, params)
+	}
+	if changed {
+		cs.Volume = volume
+	}
+	return changed
+}
+
+// stripCentered moves the cursor so an item of itemWidth is centered in width.
+func stripCentered(itemWidth, width float32) {
+	if offset := (width - itemWidth) / 2; offset > 0 {
+		imgui.SetCursorPosX(imgui.CursorPosX() + offset)
+	}
+}
+
+// panFormat formats a pan position as L, C or R with a percentage.
+func panFormat(pan float32) string {
+	percent := CurrentFormat().FormatFloat(float64(abs32(pan)*100), 0)
+	switch {
+	case pan < -0.005:
+		return "L " + percent
+	case pan > 0.005:
+		return "R " + percent
+	}
+	return "C"
+}
+
+// MixerView lays out channel strips side by side, scrolling horizontally when they do
+// not fit, with an optional master strip fixed at the right.
+type MixerView struct {
+	Container
+	Strips  []*ChannelStrip
+	Master  *ChannelStrip // drawn right of the scrolling strips (nil = none)
+	Spacing float32       // between strips (0 = theme item spacing)
+
+	scroll *ScrollArea
+}
+
+// NewMixerView creates a mixer of strips with an optional master.
+func NewMixerView(strips []*ChannelStrip, master *ChannelStrip) *MixerView {
+	mv := &MixerView{Container: Container{Visible: true}, Strips: strips, Master: master}
+	mv.scroll = NewScrollArea(NewFunc(mv.drawStrips))
+	mv.scroll.Horizontal = true
+	return mv
+}
+
+// Soloing reports whether any strip is soloed.
+func (mv *MixerView) Soloing() bool {
+	for _, strip := range mv.Strips {
+		if strip.Soloed {
+			return true
+		}
+	}
+	return false
+}
+
+// Audible reports whether strip would be heard: it is not muted, and it is soloed or
+// no strip is.
+func (mv *MixerView) Audible(strip *ChannelStrip) bool {
+	if strip.Muted {
+		return false
+	}
+	return strip.Soloed || !mv.Soloing()
+}
+
+// ScrollToStrip scrolls the strip at index into view.
+func (mv *MixerView) ScrollToStrip(index int) {
+	if index < 0 || index >= len(mv.Strips) {
+		return
+	}
+	x := float32(0)
+	for _, strip := range mv.Strips[:index] {
+		x += strip.width() + mv.spacing()
+	}
+	mv.scroll.ScrollToX(x)
+}
+
+func (mv *MixerView) spacing() float32 {
+	if mv.Spacing > 0 {
+		return mv.Spacing
+	}
+	return imgui.CurrentStyle().ItemSpacing().X
+}
+
+// Draw implements Component.
+func (mv *MixerView) Draw(state *State) {
+	if !mv.Visible {
+		return
+	}
+	size := state.Size
+	if size.X <= 0 || size.Y <= 0 {
+		size = imgui.ContentRegionAvail()
+	}
+	imgui.PushIDStr(componentID("mixerView", mv))
+	defer imgui.PopID()
+
+	stripsWidth := size.X
+	if mv.Master != nil && mv.Master.Visible {
+		stripsWidth -= mv.Master.width() + mv.spacing()
+	}
+	DrawChild(mv.scroll, state.Child(imgui.Vec2{X: max(stripsWidth, 0), Y: size.Y}, imgui.Vec2{}).WithParent(mv))
+	if mv.Master != nil && mv.Master.Visible {
+		imgui.SameLineV(0, mv.spacing())
+		DrawChild(mv.Master, state.Child(imgui.Vec2{X: mv.Master.width(), Y: size.Y}, imgui.Vec2{}).WithParent(mv))
+	}
+
+	drawContainerExtensions(&mv.Container, state)
+}
+
+// drawStrips draws the strips in a row, as tall as the scrolling area's content.
+func (mv *MixerView) drawStrips(state *State) {
+	height := imgui.ContentRegionAvail().Y
+	first := true
+	for _, strip := range mv.Strips {
+		if !strip.Visible {
+			continue
+		}
+		if !first {
+			imgui.SameLineV(0, mv.spacing())
+		}
+		first = false
+		DrawChild(strip, state.Child(imgui.Vec2{X: strip.width(), Y: height}, imgui.Vec2{}).WithParent(mv))
+	}
+}
+
+// ChildActions returns the strips and the master.
+func (mv *MixerView) ChildActions() []Component {
+	children := make([]Component, 0, len(mv.Strips)+1+len(mv.Children))
+	for _, strip := range mv.Strips {
+		children = append(children, strip)
+	}
+	if mv.Master != nil {
+		children = append(children, mv.Master)
+	}
+	return append(children, mv.Children...)
+}
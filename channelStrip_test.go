@@ -0,0 +1,52 @@
+package dfx
+
+import "testing"
+
+func TestChannelStripElements(t *testing.T) {
+	strip := NewChannelStrip("Kick")
+	if !strip.Has(StripSends) || !strip.Has(StripMeter) {
+		t.Error("expected a new strip to have every element")
+	}
+	strip.Elements = StripFader | StripMute
+	if !strip.Has(StripMute) || strip.Has(StripPan) {
+		t.Error("expected only the chosen elements")
+	}
+	strip.Elements = 0
+	if !strip.Has(StripPan) {
+		t.Error("expected no elements to mean all of them")
+	}
+
+	strip.SetLevel(1.5)
+	if strip.level != 1 {
+		t.Errorf("expected the level clamped, got %v", strip.level)
+	}
+}
+
+func TestMixerViewAudible(t *testing.T) {
+	kick, snare, hat := NewChannelStrip("Kick"), NewChannelStrip("Snare"), NewChannelStrip("Hat")
+	mv := NewMixerView([]*ChannelStrip{kick, snare, hat}, NewChannelStrip("Master"))
+	hat.Muted = true
+	if !mv.Audible(kick) || mv.Audible(hat) {
+		t.Error("expected every strip but the muted one audible")
+	}
+
+	snare.Soloed = true
+	if mv.Audible(kick) || !mv.Audible(snare) {
+		t.Error("expected only the soloed strip audible")
+	}
+	hat.Soloed = true
+	if mv.Audible(hat) {
+		t.Error("expected mute to win over solo")
+	}
+	if len(mv.ChildActions()) != 4 {
+		t.Errorf("expected the strips and master as children, got %d", len(mv.ChildActions()))
+	}
+}
+
+func TestPanFormat(t *testing.T) {
+	for pan, expected := range map[float32]string{0: "C", -1: "L 100", 0.25: "R 25"} {
+		if got := panFormat(pan); got != expected {
+			t.Errorf("panFormat(%v) = %q, expected %q", pan, got, expected)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package dfx
+
+import (
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// CheckState is the state of a TriStateCheckbox, or of a node's checkbox in a TreeView
+// with Checkboxes set.
+type CheckState int
+
+const (
+	Unchecked        CheckState = iota
+	Checked                     // drawn with a check mark
+	PartiallyChecked            // drawn with a dash: some, but not all, of what it summarizes are checked
+)
+
+// CheckStateOf summarizes values: Checked when all are set, Unchecked when none are (or
+// there are none), and PartiallyChecked otherwise.
+func CheckStateOf(values []bool) CheckState {
+	set := 0
+	for _, v := range values {
+		if v {
+			set++
+		}
+	}
+	switch set {
+	case 0:
+		return Unchecked
+	case len(values):
+		return Checked
+	}
+	return PartiallyChecked
+}
+
+// next returns the state after a click: checked becomes unchecked, anything else checked.
+func (s CheckState) next() CheckState {
+	if s == Checked {
+		return Unchecked
+	}
+	return Checked
+}
+
+// TriStateCheckbox is a checkbox that can also be partially checked, for a parent summarizing
+// a selection of children. clicking it checks it, or unchecks it when checked.
+// returns (newState, changed) following dfx conventions.
+func TriStateCheckbox(label string, state CheckState) (CheckState, bool) {
+	checked := state == Checked
+	if state == PartiallyChecked {
+		imgui.PushItemFlag(imgui.ItemFlags(imgui.ItemFlagsMixedValue), true)
+	}
+	clicked := imgui.Checkbox(label, &checked)
+	if state == PartiallyChecked {
+		imgui.PopItemFlag()
+	}
+	if !clicked {
+		return state, false
+	}
+	return state.next(), true
+}
+
+// CheckboxGroup draws a checkbox per item, bound to values, under a header: a tri-state
+// checkbox with label summarizing them, which checks or unchecks them all, and All and
+// None buttons. values shorter than items count as unchecked.
+// returns (newValues, changed), where newValues is a copy as long as items when changed.
+func CheckboxGroup(label string, values []bool, items []string) ([]bool, bool) {
+	imgui.PushIDStr(label)
+	defer imgui.PopID()
+
+	current := make([]bool, len(items))
+	copy(current, values)
+	newValues := slices.Clone(current)
+
+	setAll := func(v bool) {
+		for i := range newValues {
+			newValues[i] = v
+		}
+	}
+	if state, changed := TriStateCheckbox(label, CheckStateOf(current)); changed {
+		setAll(state == Checked)
+	}
+	imgui.SameLine()
+	if imgui.SmallButton(T("dfx.check.all")) {
+		setAll(true)
+	}
+	imgui.SameLine()
+	if imgui.SmallButton(T("dfx.check.none")) {
+		setAll(false)
+	}
+
+	imgui.Indent()
+	for i, item := range items {
+		imgui.PushIDInt(int32(i))
+		newValues[i], _ = Checkbox(item, newValues[i])
+		imgui.PopID()
+	}
+	imgui.Unindent()
+
+	if slices.Equal(newValues, current) {
+		return values, false
+	}
+	return newValues, true
+}
+
+// CheckboxGroupMask is CheckboxGroup bound to a bitmask, where bit i is item i.
+// returns (newMask, changed); bits beyond the items are kept.
+func CheckboxGroupMask(label string, mask uint64, items []string) (uint64, bool) {
+	values, changed := CheckboxGroup(label, maskBools(mask, len(items)), items)
+	if !changed {
+		return mask, false
+	}
+	return boolsMask(mask, values), true
+}
+
+// maskBools returns the lowest n bits of mask as bools.
+func maskBools(mask uint64, n int) []bool {
+	values := make([]bool, n)
+	for i := range values {
+		values[i] = mask&(1<<i) != 0
+	}
+	return values
+}
+
+// boolsMask sets the lowest bits of mask from values, keeping the bits above them.
+func boolsMask(mask uint64, values []bool) uint64 {
+	for i, v := range values {
+		if v {
+			mask |= 1 << i
+		} else {
+			mask &^= 1 << i
+		}
+	}
+	return mask
+}
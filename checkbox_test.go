@@ -0,0 +1,36 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckStateOf(t *testing.T) {
+	cases := []struct {
+		values   []bool
+		expected CheckState
+	}{
+		{nil, Unchecked},
+		{[]bool{false, false}, Unchecked},
+		{[]bool{true, true}, Checked},
+		{[]bool{true, false}, PartiallyChecked},
+	}
+	for _, c := range cases {
+		if got := CheckStateOf(c.values); got != c.expected {
+			t.Errorf("CheckStateOf(%v) = %v, expected %v", c.values, got, c.expected)
+		}
+	}
+
+	if PartiallyChecked.next() != Checked || Unchecked.next() != Checked || Checked.next() != Unchecked {
+		t.Error("expected a click to check, or uncheck when checked")
+	}
+}
+
+func TestCheckboxGroupMask(t *testing.T) {
+	if got := maskBools(0b1010, 3); !slices.Equal(got, []bool{false, true, false}) {
+		t.Errorf("expected the lowest bits as bools, got %v", got)
+	}
+	if got := boolsMask(0b11000, []bool{true, false, true, false}); got != 0b10101 {
+		t.Errorf("expected the low bits set and the high bit kept, got %b", got)
+	}
+}
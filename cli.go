@@ -0,0 +1,171 @@
+package dfx
+
+import (
+	"flag"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CLIFlags holds the common command-line flags of a dfx app. ParseFlags parses them from
+// the command line; apps with flags of their own register them on their flag set with
+// RegisterFlags. Apply copies them into a Config.
+type CLIFlags struct {
+	Width      int        // -width: window width (0 = keep)
+	Height     int        // -height: window height (0 = keep)
+	X          int        // -x: window position (0 = keep)
+	Y          int        // -y
+	ConfigPath string     // -config: config file to use instead of the app's default
+	Theme      string     // -theme: theme name (see ThemeByName)
+	LogLevel   slog.Level // -log-level: debug, info, warn or error
+	Screenshot string     // -screenshot: render in a hidden window, save a PNG here and exit
+	Record     string     // -record: save the session's input here on exit
+	Replay     string     // -replay: replay the input recorded here
+	Args       []string   // positional arguments: files to open and deep links
+}
+
+// RegisterFlags registers the common flags on fs. once fs is parsed, set Args to fs.Args().
+func RegisterFlags(fs *flag.FlagSet) *CLIFlags {
+	f := &CLIFlags{}
+	fs.IntVar(&f.Width, "width", 0, "window width")
+	fs.IntVar(&f.Height, "height", 0, "window height")
+	fs.IntVar(&f.X, "x", 0, "window x position")
+	fs.IntVar(&f.Y, "y", 0, "window y position")
+	fs.StringVar(&f.ConfigPath, "config", "", "config file `path`")
+	fs.StringVar(&f.Theme, "theme", "", "theme `name`: "+strings.Join(themeNames(), ", "))
+	fs.TextVar(&f.LogLevel, "log-level", slog.LevelInfo, "log `level`: debug, info, warn or error")
+	fs.StringVar(&f.Screenshot, "screenshot", "", "render headless, save a PNG screenshot to `path` and exit")
+	fs.StringVar(&f.Record, "record", "", "record mouse and keyboard input to `path` on exit")
+	fs.StringVar(&f.Replay, "replay", "", "replay the input recorded at `path`")
+	return f
+}
+
+// ParseFlags parses the common flags from args, usually os.Args[1:].
+func ParseFlags(args []string) (*CLIFlags, error) {
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	if err := fs.Parse(cliArgs(args)); err != nil {
+		return nil, err
+	}
+	f.Args = fs.Args()
+	return f, nil
+}
+
+// Apply overrides the window size and position, theme, screenshot path and input
+// recording paths of config with the flags that were given, and passes Args on to be opened (see Config.Open).
+func (f *CLIFlags) Apply(config *Config) error {
+	if f.Width > 0 {
+		config.Width = f.Width
+	}
+	if f.Height > 0 {
+		config.Height = f.Height
+	}
+	if f.X != 0 {
+		config.X = f.X
+	}
+	if f.Y != 0 {
+		config.Y = f.Y
+	}
+	if f.Theme != "" {
+		theme, ok := ThemeByName(f.Theme)
+		if !ok {
+			return errors.Errorf("unknown theme '%v' (available: %v)", f.Theme, strings.Join(themeNames(), ", "))
+		}
+		config.Theme = theme
+		config.FollowSystemTheme = false
+	}
+	if f.Screenshot != "" {
+		config.Screenshot = f.Screenshot
+	}
+	if f.Record != "" {
+		config.Record = f.Record
+	}
+	if f.Replay != "" {
+		config.Replay = f.Replay
+	}
+	config.Open = append(config.Open, f.Args...)
+	return nil
+}
+
+// cliArgs drops the process serial number argument older macOS versions pass to apps
+// launched from the Finder.
+func cliArgs(args []string) []string {
+	var kept []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-psn_") {
+			kept = append(kept, arg)
+		}
+	}
+	return kept
+}
+
+// ThemeByName returns the predefined theme named name, ignoring case, spaces and hyphens,
+// so "modern-dark" finds ModernDark.
+func ThemeByName(name string) (Theme, bool) {
+	key := themeKey(name)
+	for _, theme := range predefinedThemes() {
+		if themeKey(theme.Name()) == key {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+func predefinedThemes() []Theme {
+	return []Theme{ModernDark, ModernLight, BlueTheme, GreenTheme, RedTheme, PurpleTheme, BlueLightTheme, GreenLightTheme, HighContrast}
+}
+
+// themeNames returns the flag names of the predefined themes.
+func themeNames() []string {
+	var names []string
+	for _, theme := range predefinedThemes() {
+		names = append(names, strings.ToLower(strings.ReplaceAll(theme.Name(), " ", "-")))
+	}
+	return names
+}
+
+func themeKey(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+// Open delivers files and deep links to the app on the UI thread at the start of the next
+// frame: file paths (and file:// URLs) to OnOpenFile, other URLs to OnOpenURL. command-line
+// arguments in Config.Open are opened this way on the first frame; call it to pass on
+// files the OS asks the running app to open, or arguments forwarded from a second
+// instance. it is safe to call from any goroutine.
+func (app *App) Open(args ...string) {
+	files, links := splitOpenArgs(args)
+	app.Dispatch(func() {
+		if len(files) > 0 && app.config.OnOpenFile != nil {
+			app.config.OnOpenFile(app, files)
+		}
+		if app.config.OnOpenURL != nil {
+			for _, link := range links {
+				app.config.OnOpenURL(app, link)
+			}
+		}
+	})
+}
+
+// splitOpenArgs sorts arguments into file paths and deep links.
+func splitOpenArgs(args []string) (files, links []string) {
+	for _, arg := range args {
+		u, err := url.Parse(arg)
+		switch {
+		case err != nil || len(u.Scheme) < 2: // not a URL, or a windows drive letter
+			files = append(files, arg)
+		case u.Scheme == "file":
+			path := u.Path
+			if len(path) > 2 && path[0] == '/' && path[2] == ':' { // file:///C:/...
+				path = path[1:]
+			}
+			files = append(files, path)
+		default:
+			links = append(links, arg)
+		}
+	}
+	return files, links
+}
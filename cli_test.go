@@ -0,0 +1,60 @@
+package dfx
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	flags, err := ParseFlags([]string{"-psn_0_1234", "-width", "1280", "-height=720", "-theme", "modern-light", "-log-level", "debug", "song.wav", "myapp://open?id=7"})
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if flags.Width != 1280 || flags.Height != 720 || flags.LogLevel != slog.LevelDebug {
+		t.Fatalf("unexpected flags '%+v'", flags)
+	}
+	if !reflect.DeepEqual(flags.Args, []string{"song.wav", "myapp://open?id=7"}) {
+		t.Fatalf("expected positional arguments, got '%v'", flags.Args)
+	}
+
+	config := Config{Width: 800, Height: 600, X: 50, FollowSystemTheme: true}
+	if err := flags.Apply(&config); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+	if config.Width != 1280 || config.Height != 720 || config.X != 50 {
+		t.Fatalf("expected size overridden and position kept, got '%vx%v+%v'", config.Width, config.Height, config.X)
+	}
+	if config.Theme != ModernLight || config.FollowSystemTheme {
+		t.Fatalf("expected theme '%v', got '%v'", ModernLight.Name(), config.Theme)
+	}
+	if !reflect.DeepEqual(config.Open, flags.Args) {
+		t.Fatalf("expected arguments passed to Open, got '%v'", config.Open)
+	}
+
+	flags.Theme = "neon"
+	if err := flags.Apply(&config); err == nil {
+		t.Fatalf("expected error for unknown theme")
+	}
+}
+
+func TestThemeByName(t *testing.T) {
+	for name, expected := range map[string]Theme{"Modern Dark": ModernDark, "high-contrast": HighContrast, "BLUE_LIGHT": BlueLightTheme} {
+		if theme, ok := ThemeByName(name); !ok || theme != expected {
+			t.Errorf("expected '%v' for '%v', got '%v'", expected.Name(), name, theme)
+		}
+	}
+	if _, ok := ThemeByName("neon"); ok {
+		t.Errorf("expected no theme for 'neon'")
+	}
+}
+
+func TestSplitOpenArgs(t *testing.T) {
+	files, links := splitOpenArgs([]string{"song.wav", `C:\music\beat.wav`, "file:///home/me/a.wav", "file:///C:/b.wav", "myapp://open?id=7", "mailto:me@example.com"})
+	if !reflect.DeepEqual(files, []string{"song.wav", `C:\music\beat.wav`, "/home/me/a.wav", "C:/b.wav"}) {
+		t.Fatalf("unexpected files '%v'", files)
+	}
+	if !reflect.DeepEqual(links, []string{"myapp://open?id=7", "mailto:me@example.com"}) {
+		t.Fatalf("unexpected links '%v'", links)
+	}
+}
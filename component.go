@@ -1,8 +1,17 @@
 package dfx
 
-import "github.com/AllenDang/cimgui-go/imgui"
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
 
 // Component is the core abstraction - a drawable, interactive UI element.
+//
+// components are used from the UI thread: their fields and methods may only be touched
+// there, while the component is not being drawn. the exceptions are documented as safe to
+// call from any goroutine, such as the level setters of the meters, which are fed from
+// audio callbacks; anything else is handed to the UI thread with App.Dispatch.
 type Component interface {
 	// Draw renders the component. Unlike Surface.DrawF, we pass a State
 	// that contains more than just size - it has everything needed to draw.
@@ -15,7 +24,8 @@ type Component interface {
 
 // ChildActionProvider exposes child components for action traversal.
 // components that compose other components can implement this to participate
-// in hierarchical action lookup.
+// in hierarchical action lookup. the app gathers the actions again when the children
+// returned here change.
 type ChildActionProvider interface {
 	ChildActions() []Component
 }
@@ -27,6 +37,52 @@ type LocalActionProvider interface {
 	LocalActions() *ActionRegistry
 }
 
+// StatefulComponent is implemented by components that can persist their UI state
+// (selection, scroll position, layout, etc.) across restarts. the state must consist of
+// values the config codecs can serialize: strings, bools, numbers, slices and maps.
+// numbers may come back as a different numeric type than they were captured as,
+// depending on the file format.
+type StatefulComponent interface {
+	CaptureState() map[string]any
+	RestoreState(state map[string]any)
+}
+
+// stateFloat reads a number from restored component state, whatever numeric type the
+// config codec decoded it as.
+func stateFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// stateStrings reads a list of strings from restored component state, which the config
+// codecs decode as []any.
+func stateStrings(v any) ([]string, bool) {
+	switch list := v.(type) {
+	case []string:
+		return list, true
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	}
+	return nil, false
+}
+
 // State provides everything a component needs to draw.
 // this consolidates what Surface scattered across multiple parameters.
 type State struct {
@@ -44,15 +100,65 @@ type State struct {
 
 	// Parent component (nil for root)
 	Parent Component
+
+	// Now is when the current frame started, read once per frame from the monotonic
+	// clock so every component animates against the same time
+	Now time.Time
+
+	// DeltaTime is the time elapsed since the previous frame (0 on the first frame)
+	DeltaTime time.Duration
+
+	// FrameIndex numbers the frames, starting from 0
+	FrameIndex uint64
+
+	// Store keeps UI state across restarts, scoped by component id (nil = not persisted;
+	// its methods are safe to call on nil)
+	Store *StateStore
+}
+
+// Clock returns Now, or the current time for a state built without a frame clock (such as
+// a hand-built state in a test).
+func (s *State) Clock() time.Time {
+	if s == nil || s.Now.IsZero() {
+		return time.Now()
+	}
+	return s.Now
+}
+
+// Child returns the state for a child component given size at position. everything else,
+// including Parent, is inherited; chain WithParent to name the drawing component:
+//
+//	dfx.DrawChild(child, state.Child(paneSize, imgui.Vec2{}).WithParent(s))
+func (s *State) Child(size, position imgui.Vec2) *State {
+	child := *s
+	child.Size = size
+	child.Position = position
+	return &child
+}
+
+// WithParent returns a copy of the state with Parent set to parent.
+func (s *State) WithParent(parent Component) *State {
+	child := *s
+	child.Parent = parent
+	return &child
 }
 
 // Container is a basic component implementation that others can embed.
-// provides default implementations and common fields.
+// provides default implementations and common fields. embedding components draw their
+// Children with DrawChild.
 type Container struct {
 	Visible  bool
 	Children []Component
 	OnDraw   func(*State)
 	actions  *ActionRegistry
+
+	// StateID persists the state of a StatefulComponent in State.Store under this id,
+	// restoring it when the component is first drawn ("" = not persisted)
+	StateID string
+
+	// accessibility: announced when the component gains keyboard focus
+	AccessibleLabel       string
+	AccessibleDescription string
 }
 
 // Draw implements Component with a simple delegation pattern
@@ -68,6 +174,16 @@ func (c *Container) ChildActions() []Component {
 	return c.Children
 }
 
+// isVisible reports Visible to DrawChild through embedding components.
+func (c *Container) isVisible() bool {
+	return c.Visible
+}
+
+// stateID reports StateID to DrawChild through embedding components.
+func (c *Container) stateID() string {
+	return c.StateID
+}
+
 // Actions implements Component
 func (c *Container) Actions() *ActionRegistry {
 	if c.actions == nil {
@@ -115,10 +231,11 @@ func (f *Func) LocalActions() *ActionRegistry {
 
 // drawContainerExtensions runs standard post-content container extension drawing.
 func drawContainerExtensions(c *Container, state *State) {
+	recordDraw(c, state)
 	if c.OnDraw != nil {
 		c.OnDraw(state)
 	}
 	for _, child := range c.Children {
-		child.Draw(state)
+		DrawChild(child, state)
 	}
 }
@@ -0,0 +1,66 @@
+package dfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestStateChildInheritsFields(t *testing.T) {
+	app := &App{}
+	parent := &Container{}
+	state := &State{
+		Size:       imgui.Vec2{X: 800, Y: 600},
+		Position:   imgui.Vec2{X: 4, Y: 4},
+		App:        app,
+		Parent:     parent,
+		DeltaTime:  16 * time.Millisecond,
+		FrameIndex: 42,
+	}
+
+	child := state.Child(imgui.Vec2{X: 200, Y: 100}, imgui.Vec2{X: 10})
+	if child.Size != (imgui.Vec2{X: 200, Y: 100}) || child.Position != (imgui.Vec2{X: 10}) {
+		t.Fatalf("unexpected child geometry %v %v", child.Size, child.Position)
+	}
+	if child.App != app || child.Parent != parent || child.DeltaTime != 16*time.Millisecond || child.FrameIndex != 42 {
+		t.Fatalf("expected child to inherit app, parent and frame fields, got %+v", child)
+	}
+
+	owner := &Container{}
+	withParent := child.WithParent(owner)
+	if withParent.Parent != owner || withParent.Size != child.Size {
+		t.Fatalf("unexpected state %+v", withParent)
+	}
+	if child.Parent != parent || state.Size.X != 800 {
+		t.Fatal("expected builders to leave the original state untouched")
+	}
+}
+
+func TestStateClock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if clock := (&State{Now: now}).Clock(); !clock.Equal(now) {
+		t.Fatalf("expected the frame time, got %v", clock)
+	}
+	before := time.Now()
+	if clock := (&State{}).Clock(); clock.Before(before) {
+		t.Fatalf("expected the current time, got %v", clock)
+	}
+	var nilState *State
+	if nilState.Clock().IsZero() {
+		t.Fatal("expected the current time for a nil state")
+	}
+}
+
+func TestAdvanceFrame(t *testing.T) {
+	app := &App{}
+	start := time.Now()
+	app.advanceFrame(start)
+	if app.frameCount != 0 || app.frameDelta != 0 {
+		t.Fatalf("expected first frame 0 with no delta, got %d %v", app.frameCount, app.frameDelta)
+	}
+	app.advanceFrame(start.Add(20 * time.Millisecond))
+	if app.frameCount != 1 || app.frameDelta != 20*time.Millisecond {
+		t.Fatalf("expected frame 1 after 20ms, got %d %v", app.frameCount, app.frameDelta)
+	}
+}
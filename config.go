@@ -4,14 +4,15 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/michaelquigley/df/dd"
 	"github.com/pkg/errors"
 )
 
 // DashConfig holds configuration for a single dashboard panel
 type DashConfig struct {
-	Visible bool
-	Size    int
+	Visible    bool
+	Size       int
+	ActiveTab  string   // active tab id for dashes with stacked tabs
+	HiddenTabs []string // ids of tabs hidden from the tab strip
 }
 
 // WindowConfig holds window position and size configuration
@@ -21,6 +22,14 @@ type WindowConfig struct {
 	Width     int
 	Height    int
 	Maximized bool // window maximized state (capture only, restore not yet implemented)
+	Monitor   int  // index of the monitor holding the window (see App.Monitors)
+}
+
+// WorkspaceConfig holds the current workspace and the state of workspace components
+// that implement StatefulComponent, keyed by workspace id
+type WorkspaceConfig struct {
+	Current string
+	States  map[string]map[string]any
 }
 
 // GetDefaultWindowConfig returns sensible default window configuration
@@ -44,28 +53,17 @@ func ConfigPath(appName, filename string) (string, error) {
 }
 
 // SaveJSON saves any struct to a JSON file with proper formatting and error handling
-// Creates parent directories if they don't exist
+// Creates parent directories if they don't exist; the write is atomic and the previous
+// file is kept as a backup (see SaveConfig)
 func SaveJSON(path string, config interface{}) error {
-	// create parent directory if it doesn't exist
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return errors.Wrapf(err, "error creating directory '%v'", dir)
-	}
-	return dd.UnbindJSONFile(config, path)
+	return saveConfigWith(jsonCodec{}, path, config)
 }
 
 // LoadJSON loads a JSON file into a struct
 // If the file doesn't exist, the config parameter is left unchanged (use defaults)
 // Returns error only if the file exists but can't be read or parsed
 func LoadJSON(path string, config interface{}) error {
-	// check if file exists
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil // file doesn't exist, use defaults
-	}
-	if err := dd.MergeJSONFile(config, path); err != nil {
-		return err
-	}
-	return nil
+	return loadConfigWith(jsonCodec{}, path, config)
 }
 
 // dashSlots returns a map of slot name to dash pointer for iteration.
@@ -81,7 +79,13 @@ func CaptureDashState(dm *DashManager) map[string]DashConfig {
 	config := make(map[string]DashConfig)
 	for name, dash := range dashSlots(dm) {
 		if dash != nil {
-			config[name] = DashConfig{Visible: dash.Visible, Size: dash.TargetSize}
+			cfg := DashConfig{Visible: dash.Visible, Size: dash.TargetSize, ActiveTab: dash.ActiveTab()}
+			for _, tab := range dash.tabs {
+				if !tab.Visible {
+					cfg.HiddenTabs = append(cfg.HiddenTabs, tab.Id)
+				}
+			}
+			config[name] = cfg
 		}
 	}
 	return config
@@ -96,6 +100,17 @@ func RestoreDashState(dm *DashManager, config map[string]DashConfig) {
 				dash.Visible = cfg.Visible
 				dash.TargetSize = cfg.Size
 				dash.CurrentSize = cfg.Size
+				if len(dash.tabs) > 0 {
+					for _, tab := range dash.tabs {
+						tab.Visible = true
+					}
+					for _, id := range cfg.HiddenTabs {
+						dash.SetTabVisible(id, false)
+					}
+					if cfg.ActiveTab != "" {
+						dash.SetActiveTab(cfg.ActiveTab)
+					}
+				}
 			}
 		}
 	}
@@ -110,11 +125,54 @@ func CaptureWindowState(app *App) WindowConfig {
 	// For now, always set to false
 	maximized := false
 
+	monitor, _ := app.WindowMonitor()
+
 	return WindowConfig{
 		X:         x,
 		Y:         y,
 		Width:     width,
 		Height:    height,
 		Maximized: maximized,
+		Monitor:   monitor.Index,
+	}
+}
+
+// CaptureWorkspaceState extracts configuration from a Workspace, including the state of
+// every workspace component that implements StatefulComponent.
+func CaptureWorkspaceState(ws *Workspace) WorkspaceConfig {
+	config := WorkspaceConfig{
+		Current: ws.Current(),
+		States:  make(map[string]map[string]any),
+	}
+	for _, item := range ws.items {
+		if sc, ok := item.Component.(StatefulComponent); ok {
+			if state := sc.CaptureState(); state != nil {
+				config.States[item.Id] = state
+			}
+		} else if item.Component == nil && item.pendingState != nil {
+			// lazy workspace that is not loaded; keep the state it will be restored with
+			config.States[item.Id] = item.pendingState
+		}
+	}
+	return config
+}
+
+// RestoreWorkspaceState applies configuration to a Workspace. workspaces that no longer
+// exist are ignored, so configs stay valid when an app adds or removes workspaces.
+func RestoreWorkspaceState(ws *Workspace, config WorkspaceConfig) {
+	for _, item := range ws.items {
+		state, found := config.States[item.Id]
+		if !found {
+			continue
+		}
+		if sc, ok := item.Component.(StatefulComponent); ok {
+			sc.RestoreState(state)
+		} else if item.Component == nil && item.factory != nil {
+			// lazy workspace; restore once it is constructed
+			item.pendingState = state
+		}
+	}
+	if config.Current != "" {
+		ws.Switch(config.Current)
 	}
 }
@@ -0,0 +1,229 @@
+package dfx
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/michaelquigley/df/dd"
+	"github.com/pkg/errors"
+)
+
+// ConfigBackupSuffix is appended to a config path to name the backup of the previous version.
+const ConfigBackupSuffix = ".bak"
+
+// ConfigCodec converts configuration structs to and from a file format.
+type ConfigCodec interface {
+	// Marshal serializes a config struct.
+	Marshal(config interface{}) ([]byte, error)
+
+	// Merge deserializes data on top of an existing config struct, leaving
+	// fields that are absent from data untouched.
+	Merge(config interface{}, data []byte) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(config interface{}) ([]byte, error) {
+	return dd.UnbindJSON(config)
+}
+
+func (jsonCodec) Merge(config interface{}, data []byte) error {
+	return dd.MergeJSON(config, data)
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Marshal(config interface{}) ([]byte, error) {
+	return dd.UnbindYAML(config)
+}
+
+func (yamlCodec) Merge(config interface{}, data []byte) error {
+	return dd.MergeYAML(config, data)
+}
+
+type tomlCodec struct{}
+
+func (tomlCodec) Marshal(config interface{}) ([]byte, error) {
+	m, err := dd.Unbind(config)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(m); err != nil {
+		return nil, errors.Wrap(err, "error encoding toml")
+	}
+	return buf.Bytes(), nil
+}
+
+func (tomlCodec) Merge(config interface{}, data []byte) error {
+	var m map[string]any
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return errors.Wrap(err, "error decoding toml")
+	}
+	return dd.Merge(config, m)
+}
+
+// configCodecs maps lower-case file extensions to codecs.
+var configCodecs = map[string]ConfigCodec{
+	".json": jsonCodec{},
+	".yaml": yamlCodec{},
+	".yml":  yamlCodec{},
+	".toml": tomlCodec{},
+}
+
+// RegisterConfigCodec associates a codec with a file extension (including the dot).
+// this can be used to add formats or replace the built-in JSON, YAML and TOML codecs.
+func RegisterConfigCodec(ext string, codec ConfigCodec) {
+	configCodecs[strings.ToLower(ext)] = codec
+}
+
+// ConfigCodecFor returns the codec registered for the extension of path.
+func ConfigCodecFor(path string) (ConfigCodec, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	codec, ok := configCodecs[ext]
+	if !ok {
+		return nil, errors.Errorf("no config codec for extension '%v'", ext)
+	}
+	return codec, nil
+}
+
+// SaveConfig saves a config struct using the codec selected by the file extension.
+// the file is written atomically (temp file + rename) and the previous version,
+// if any, is kept alongside it with ConfigBackupSuffix.
+func SaveConfig(path string, config interface{}) error {
+	codec, err := ConfigCodecFor(path)
+	if err != nil {
+		return err
+	}
+	return saveConfigWith(codec, path, config)
+}
+
+// LoadConfig merges a config file into config using the codec selected by the file
+// extension. config should already hold default values: fields absent from the file
+// (for example, fields added in a newer version of the app) keep their defaults.
+// if the file doesn't exist, config is left unchanged.
+func LoadConfig(path string, config interface{}) error {
+	codec, err := ConfigCodecFor(path)
+	if err != nil {
+		return err
+	}
+	return loadConfigWith(codec, path, config)
+}
+
+// LoadConfigWithDefaults returns defaults with the contents of the config file at path
+// merged on top. new fields added in upgrades get their default values. the result is a
+// deep copy made through the codec, so loading never changes the slices and maps of
+// defaults; fields the codec doesn't encode are left zero.
+func LoadConfigWithDefaults[T any](path string, defaults T) (T, error) {
+	codec, err := ConfigCodecFor(path)
+	if err != nil {
+		return defaults, err
+	}
+	data, err := codec.Marshal(defaults)
+	if err != nil {
+		return defaults, errors.Wrapf(err, "error encoding defaults for '%v'", path)
+	}
+	var config T
+	if err := codec.Merge(&config, data); err != nil {
+		return defaults, errors.Wrapf(err, "error copying defaults for '%v'", path)
+	}
+	if err := loadConfigWith(codec, path, &config); err != nil {
+		return defaults, err
+	}
+	return config, nil
+}
+
+func saveConfigWith(codec ConfigCodec, path string, config interface{}) error {
+	data, err := codec.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding config for '%v'", path)
+	}
+	return writeConfigData(path, data)
+}
+
+// writeConfigData writes already-encoded config data to path, creating parent
+// directories and keeping a backup of the previous version.
+func writeConfigData(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating directory '%v'", dir)
+	}
+	if err := backupConfig(path); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0600)
+}
+
+func loadConfigWith(codec ConfigCodec, path string, config interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // file doesn't exist, use defaults
+		}
+		return errors.Wrapf(err, "error reading config '%v'", path)
+	}
+	if err := codec.Merge(config, data); err != nil {
+		return errors.Wrapf(err, "error decoding config '%v'", path)
+	}
+	return nil
+}
+
+// backupConfig copies an existing file at path to path+ConfigBackupSuffix, with the
+// same permissions.
+func backupConfig(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error reading '%v' for backup", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "error reading '%v' for backup", path)
+	}
+	return writeFileMode(path+ConfigBackupSuffix, data, info.Mode().Perm())
+}
+
+// writeFileAtomic writes data to a temp file in the target directory and renames it into place,
+// so readers never observe a partially written file. an existing file keeps its permissions;
+// a new one is created with perm.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	return writeFileMode(path, data, perm)
+}
+
+// writeFileMode is writeFileAtomic with the permissions of the result given by mode.
+func writeFileMode(path string, data []byte, mode os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return errors.Wrapf(err, "error creating temp file in '%v'", dir)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "error writing '%v'", tmpPath)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "error syncing '%v'", tmpPath)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "error closing '%v'", tmpPath)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return errors.Wrapf(err, "error setting permissions on '%v'", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "error renaming '%v' to '%v'", tmpPath, path)
+	}
+	return nil
+}
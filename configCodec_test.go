@@ -0,0 +1,186 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type codecTestConfig struct {
+	Name    string
+	Volume  float64
+	Count   int
+	Enabled bool
+	Window  WindowConfig
+	Tags    []string
+}
+
+func codecTestValue() codecTestConfig {
+	return codecTestConfig{
+		Name:    "dfx",
+		Volume:  0.75,
+		Count:   3,
+		Enabled: true,
+		Window:  WindowConfig{X: 10, Y: 20, Width: 640, Height: 480},
+		Tags:    []string{"a", "b"},
+	}
+}
+
+func TestSaveLoadConfig_RoundTripsAllFormats(t *testing.T) {
+	for _, ext := range []string{".json", ".yaml", ".yml", ".toml"} {
+		path := filepath.Join(t.TempDir(), "config"+ext)
+		expected := codecTestValue()
+		if err := SaveConfig(path, &expected); err != nil {
+			t.Fatalf("%v: unexpected save error: %v", ext, err)
+		}
+
+		var loaded codecTestConfig
+		if err := LoadConfig(path, &loaded); err != nil {
+			t.Fatalf("%v: unexpected load error: %v", ext, err)
+		}
+		if loaded.Name != expected.Name || loaded.Volume != expected.Volume || loaded.Count != expected.Count ||
+			loaded.Enabled != expected.Enabled || loaded.Window != expected.Window || strings.Join(loaded.Tags, ",") != "a,b" {
+			t.Fatalf("%v: expected '%+v', got '%+v'", ext, expected, loaded)
+		}
+	}
+}
+
+func TestSaveConfig_UnknownExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ini")
+	if err := SaveConfig(path, &codecTestConfig{}); err == nil {
+		t.Fatalf("expected error for unknown extension")
+	}
+}
+
+func TestSaveConfig_KeepsBackupAndNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	first := codecTestValue()
+	if err := SaveConfig(path, &first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second := codecTestValue()
+	second.Name = "second"
+	if err := SaveConfig(path, &second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var backup codecTestConfig
+	if err := loadConfigWith(jsonCodec{}, path+ConfigBackupSuffix, &backup); err != nil {
+		t.Fatalf("unexpected backup load error: %v", err)
+	}
+	if backup.Name != "dfx" {
+		t.Fatalf("expected backup name 'dfx', got '%v'", backup.Name)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Fatalf("expected no temp files, found '%v'", entry.Name())
+		}
+	}
+}
+
+func TestLoadConfigWithDefaults_KeepsNewFieldDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("name: saved\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defaults := codecTestValue()
+	loaded, err := LoadConfigWithDefaults(path, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Name != "saved" {
+		t.Fatalf("expected name 'saved', got '%v'", loaded.Name)
+	}
+	if loaded.Count != 3 || loaded.Window.Width != 640 {
+		t.Fatalf("expected missing fields to keep defaults, got '%+v'", loaded)
+	}
+}
+
+func TestLoadConfigWithDefaults_LeavesDefaultsUntouched(t *testing.T) {
+	for _, ext := range []string{".json", ".yaml", ".toml"} {
+		path := filepath.Join(t.TempDir(), "config"+ext)
+		saved := codecTestValue()
+		saved.Tags = []string{"x", "y"}
+		if err := SaveConfig(path, &saved); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		defaults := codecTestValue()
+		loaded, err := LoadConfigWithDefaults(path, defaults)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(loaded.Tags, []string{"x", "y"}) {
+			t.Fatalf("expected tags '[x y]' from %v, got '%v'", ext, loaded.Tags)
+		}
+		loaded.Tags[0] = "changed"
+		if !reflect.DeepEqual(defaults, codecTestValue()) {
+			t.Fatalf("expected defaults to be untouched by %v, got '%+v'", ext, defaults)
+		}
+
+		missing, err := LoadConfigWithDefaults(filepath.Join(t.TempDir(), "missing"+ext), defaults)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(missing, defaults) {
+			t.Fatalf("expected a copy of the defaults from %v, got '%+v'", ext, missing)
+		}
+		missing.Tags[0] = "changed"
+		if defaults.Tags[0] != "a" {
+			t.Fatalf("expected the copy not to share tags with the defaults, got '%v'", defaults.Tags)
+		}
+	}
+}
+
+func TestSaveConfig_KeepsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := codecTestValue()
+	if err := SaveConfig(path, &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode := fileMode(t, path); mode != 0600 {
+		t.Fatalf("expected new config mode '0600', got '%v'", mode)
+	}
+
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SaveConfig(path, &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode := fileMode(t, path); mode != 0640 {
+		t.Fatalf("expected saved config to keep mode '0640', got '%v'", mode)
+	}
+	if mode := fileMode(t, path+ConfigBackupSuffix); mode != 0640 {
+		t.Fatalf("expected backup mode '0640', got '%v'", mode)
+	}
+}
+
+func fileMode(t *testing.T, path string) os.FileMode {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return info.Mode().Perm()
+}
+
+func TestLoadConfig_MissingFileLeavesDefaults(t *testing.T) {
+	config := codecTestValue()
+	if err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Name != "dfx" {
+		t.Fatalf("expected defaults to be untouched, got '%+v'", config)
+	}
+}
@@ -0,0 +1,299 @@
+package dfx
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultConfigSaveDelay is how long ConfigManager waits after the last change before saving.
+const DefaultConfigSaveDelay = 2 * time.Second
+
+// DefaultConfigWatchInterval is how often ConfigManager checks registered configs for changes.
+const DefaultConfigWatchInterval = 500 * time.Millisecond
+
+// ConfigManager saves registered config structs automatically. changes are detected either
+// by periodically re-encoding each config and comparing it with the last saved version, or
+// by explicit MarkDirty calls. writes are debounced so a burst of changes results in a
+// single save, and file i/o happens on a background goroutine.
+//
+// call Tick once per frame from the ui thread (e.g. from Config.OnTick) and Flush on
+// shutdown. configs are only read from the thread that calls Tick and Flush; OnSaved and
+// OnError are also invoked from that thread.
+type ConfigManager struct {
+	SaveDelay     time.Duration                // debounce delay after the last change (default DefaultConfigSaveDelay)
+	WatchInterval time.Duration                // change detection interval; negative disables watching (default DefaultConfigWatchInterval)
+	OnSaved       func(path string)            // called after a config is written
+	OnError       func(path string, err error) // called when encoding or writing a config fails
+
+	entries   []*configEntry
+	seqs      map[string]uint64 // last version encoded per path, kept across re-registration
+	lastWatch time.Time
+	now       func() time.Time
+	writeFile func(path string, data []byte) error // writes encoded data (nil = writeConfigData)
+
+	mu      sync.Mutex
+	results []configResult
+	writes  sync.WaitGroup
+	writeMu sync.Mutex // serializes writes so older data never lands after newer data
+	written map[string]uint64
+}
+
+type configEntry struct {
+	path       string
+	config     interface{}
+	codec      ConfigCodec
+	seen       []byte // contents when registered, last seen changed or last encoded for a save
+	pendingSeq uint64 // version being written; not saved until the write succeeds
+	dirty      bool
+	deadline   time.Time
+}
+
+type configResult struct {
+	path string
+	seq  uint64 // version written (0 = encoding failed)
+	err  error
+}
+
+// NewConfigManager creates a ConfigManager with default timing.
+func NewConfigManager() *ConfigManager {
+	return &ConfigManager{
+		SaveDelay:     DefaultConfigSaveDelay,
+		WatchInterval: DefaultConfigWatchInterval,
+		now:           time.Now,
+	}
+}
+
+// Register adds a config struct to be saved at path, using the codec selected by the
+// file extension. the current contents of config are treated as already saved.
+func (m *ConfigManager) Register(path string, config interface{}) error {
+	codec, err := ConfigCodecFor(path)
+	if err != nil {
+		return err
+	}
+	data, err := codec.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding config for '%v'", path)
+	}
+	if e := m.entry(path); e != nil {
+		e.config = config
+		e.codec = codec
+		e.seen = data
+		e.pendingSeq = 0
+		e.dirty = false
+		return nil
+	}
+	m.entries = append(m.entries, &configEntry{path: path, config: config, codec: codec, seen: data})
+	return nil
+}
+
+// Unregister stops managing the config at path. pending changes are discarded; call
+// Flush first to keep them.
+func (m *ConfigManager) Unregister(path string) {
+	for i, e := range m.entries {
+		if e.path == path {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// MarkDirty schedules the config at path to be saved after SaveDelay. each call
+// restarts the delay.
+func (m *ConfigManager) MarkDirty(path string) {
+	if e := m.entry(path); e != nil {
+		m.markDirty(e)
+	}
+}
+
+// MarkAllDirty schedules every registered config to be saved.
+func (m *ConfigManager) MarkAllDirty() {
+	for _, e := range m.entries {
+		m.markDirty(e)
+	}
+}
+
+// Dirty reports whether any registered config has unsaved changes.
+func (m *ConfigManager) Dirty() bool {
+	for _, e := range m.entries {
+		if e.dirty {
+			return true
+		}
+	}
+	return false
+}
+
+// Tick detects changes, starts saves whose debounce delay has elapsed and delivers
+// OnSaved/OnError callbacks for completed saves.
+func (m *ConfigManager) Tick() {
+	now := m.clock()
+
+	if m.WatchInterval >= 0 && now.Sub(m.lastWatch) >= m.watchInterval() {
+		m.lastWatch = now
+		for _, e := range m.entries {
+			data, err := e.codec.Marshal(e.config)
+			if err != nil {
+				m.report(e.path, 0, errors.Wrapf(err, "error encoding config for '%v'", e.path))
+				continue
+			}
+			if !bytes.Equal(data, e.seen) {
+				e.seen = data
+				m.markDirty(e)
+			}
+		}
+	}
+
+	for _, e := range m.entries {
+		if e.dirty && !now.Before(e.deadline) {
+			if data, seq, ok := m.encode(e); ok {
+				m.writes.Add(1)
+				go func(path string, seq uint64, data []byte) {
+					defer m.writes.Done()
+					if written, err := m.write(path, seq, data); written || err != nil {
+						m.report(path, seq, err)
+					}
+				}(e.path, seq, data)
+			}
+		}
+	}
+
+	m.deliver()
+}
+
+// Flush synchronously saves all configs with pending changes, waits for in-flight
+// background saves to finish and delivers their callbacks. call it on shutdown. it
+// returns the last error of those saves; configs that failed to save stay dirty.
+func (m *ConfigManager) Flush() error {
+	for _, e := range m.entries {
+		if !e.dirty {
+			// catch changes made since the last watch
+			if data, err := e.codec.Marshal(e.config); err == nil && !bytes.Equal(data, e.seen) {
+				e.dirty = true
+			}
+		}
+		if e.dirty {
+			data, seq, ok := m.encode(e)
+			if !ok {
+				continue
+			}
+			if written, err := m.write(e.path, seq, data); written || err != nil {
+				m.report(e.path, seq, err)
+			}
+		}
+	}
+	m.writes.Wait()
+	return m.deliver()
+}
+
+func (m *ConfigManager) entry(path string) *configEntry {
+	for _, e := range m.entries {
+		if e.path == path {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *ConfigManager) markDirty(e *configEntry) {
+	e.dirty = true
+	e.deadline = m.clock().Add(m.saveDelay())
+}
+
+// encode captures the current contents of a config for a save and clears its dirty flag,
+// returning the version of the path the data is. versions keep counting when a path is
+// registered again, so its saves are not mistaken for ones already written. deliver marks
+// the config dirty again if the write fails.
+func (m *ConfigManager) encode(e *configEntry) ([]byte, uint64, bool) {
+	e.dirty = false
+	data, err := e.codec.Marshal(e.config)
+	if err != nil {
+		m.report(e.path, 0, errors.Wrapf(err, "error encoding config for '%v'", e.path))
+		return nil, 0, false
+	}
+	if m.seqs == nil {
+		m.seqs = make(map[string]uint64)
+	}
+	m.seqs[e.path]++
+	e.seen = data
+	e.pendingSeq = m.seqs[e.path]
+	return data, e.pendingSeq, true
+}
+
+// write saves encoded data unless a newer version of the same config has already been written.
+func (m *ConfigManager) write(path string, seq uint64, data []byte) (bool, error) {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	if m.written == nil {
+		m.written = make(map[string]uint64)
+	}
+	if seq <= m.written[path] {
+		return false, nil
+	}
+	writeFile := m.writeFile
+	if writeFile == nil {
+		writeFile = writeConfigData
+	}
+	if err := writeFile(path, data); err != nil {
+		return false, err
+	}
+	m.written[path] = seq
+	return true, nil
+}
+
+func (m *ConfigManager) report(path string, seq uint64, err error) {
+	m.mu.Lock()
+	m.results = append(m.results, configResult{path: path, seq: seq, err: err})
+	m.mu.Unlock()
+}
+
+// deliver applies the results of completed saves and invokes their callbacks, returning
+// the last error. a failed write of a config's latest version marks it dirty again, so
+// the save is retried.
+func (m *ConfigManager) deliver() error {
+	m.mu.Lock()
+	results := m.results
+	m.results = nil
+	m.mu.Unlock()
+
+	var lastErr error
+	for _, r := range results {
+		if e := m.entry(r.path); e != nil && r.seq != 0 && r.seq == e.pendingSeq {
+			e.pendingSeq = 0
+			if r.err != nil && !e.dirty {
+				m.markDirty(e)
+			}
+		}
+		if r.err != nil {
+			lastErr = r.err
+			if m.OnError != nil {
+				m.OnError(r.path, r.err)
+			}
+		} else if m.OnSaved != nil {
+			m.OnSaved(r.path)
+		}
+	}
+	return lastErr
+}
+
+func (m *ConfigManager) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+func (m *ConfigManager) saveDelay() time.Duration {
+	if m.SaveDelay > 0 {
+		return m.SaveDelay
+	}
+	return DefaultConfigSaveDelay
+}
+
+func (m *ConfigManager) watchInterval() time.Duration {
+	if m.WatchInterval > 0 {
+		return m.WatchInterval
+	}
+	return DefaultConfigWatchInterval
+}
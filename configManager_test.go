@@ -0,0 +1,245 @@
+package dfx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestConfigManager(clock *time.Time) *ConfigManager {
+	m := NewConfigManager()
+	m.now = func() time.Time { return *clock }
+	return m
+}
+
+func TestConfigManager_DebouncesWatchedChanges(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+	var saved []string
+	m.OnSaved = func(path string) { saved = append(saved, path) }
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+
+	cfg.Count = 10
+	m.Tick()
+	if !m.Dirty() {
+		t.Fatalf("expected change to be detected")
+	}
+
+	// further changes inside the debounce window restart the delay
+	clock = clock.Add(time.Second)
+	cfg.Count = 11
+	m.Tick()
+	clock = clock.Add(1500 * time.Millisecond)
+	m.Tick()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no save before debounce delay elapsed")
+	}
+
+	clock = clock.Add(time.Second)
+	m.Tick()
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if len(saved) != 1 || saved[0] != path {
+		t.Fatalf("expected one save of '%v', got '%v'", path, saved)
+	}
+
+	var loaded codecTestConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Count != 11 {
+		t.Fatalf("expected count '11', got '%v'", loaded.Count)
+	}
+}
+
+func TestConfigManager_MarkDirty(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+	m.WatchInterval = -1
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+
+	cfg.Name = "changed"
+	m.Tick()
+	if m.Dirty() {
+		t.Fatalf("expected no change detection with watching disabled")
+	}
+
+	m.MarkDirty(path)
+	clock = clock.Add(m.SaveDelay)
+	m.Tick()
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	var loaded codecTestConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Name != "changed" {
+		t.Fatalf("expected name 'changed', got '%v'", loaded.Name)
+	}
+}
+
+func TestConfigManager_FlushSavesPendingChanges(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+
+	cfg.Volume = 0.25
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	var loaded codecTestConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Volume != 0.25 {
+		t.Fatalf("expected volume '0.25', got '%v'", loaded.Volume)
+	}
+}
+
+func TestConfigManager_SavesAfterReregistering(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+	var saved []string
+	m.OnSaved = func(path string) { saved = append(saved, path) }
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+	cfg.Count = 1
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	m.Unregister(path)
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+	cfg.Count = 2
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected two saves, got '%v'", saved)
+	}
+
+	var loaded codecTestConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Count != 2 {
+		t.Fatalf("expected count '2', got '%v'", loaded.Count)
+	}
+}
+
+func TestConfigManager_ReportsErrors(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+	var errPath string
+	m.OnError = func(path string, err error) { errPath = path }
+
+	// a file in place of the parent directory makes the write fail
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "blocker")
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(blocker, "config.json")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+
+	m.MarkDirty(path)
+	if err := m.Flush(); err == nil {
+		t.Fatalf("expected flush error")
+	}
+	if errPath != path {
+		t.Fatalf("expected error for '%v', got '%v'", path, errPath)
+	}
+}
+
+func TestConfigManager_RetriesFailedWrites(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestConfigManager(&clock)
+	failures := 1
+	m.writeFile = func(path string, data []byte) error {
+		if failures > 0 {
+			failures--
+			return errors.New("disk full")
+		}
+		return writeConfigData(path, data)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := codecTestValue()
+	if err := m.Register(path, &cfg); err != nil {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+
+	// a failed background save leaves the config dirty and is retried
+	cfg.Count = 5
+	m.Tick()
+	clock = clock.Add(m.SaveDelay)
+	m.Tick()
+	m.writes.Wait()
+	m.Tick()
+	if !m.Dirty() {
+		t.Fatalf("expected config to stay dirty after a failed write")
+	}
+	clock = clock.Add(m.SaveDelay)
+	m.Tick()
+	m.writes.Wait()
+	m.Tick()
+	if m.Dirty() {
+		t.Fatalf("expected the retried write to succeed")
+	}
+	var loaded codecTestConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Count != 5 {
+		t.Fatalf("expected count '5', got '%v'", loaded.Count)
+	}
+
+	// Flush reports the failure and keeps the change for the next Flush
+	failures = 1
+	cfg.Count = 6
+	if err := m.Flush(); err == nil {
+		t.Fatalf("expected flush error")
+	}
+	if !m.Dirty() {
+		t.Fatalf("expected config to stay dirty after a failed flush")
+	}
+	if err := m.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.Count != 6 {
+		t.Fatalf("expected count '6', got '%v'", loaded.Count)
+	}
+}
@@ -18,9 +18,14 @@ const (
 
 // Input is a simplified text input that returns the new value and whether it changed
 func Input(label string, value string) (string, bool) {
+	return inputText(label, value, imgui.InputTextFlagsNone, nil)
+}
+
+// inputText is Input with imgui flags and an optional callback, for the specialized inputs.
+func inputText(label string, value string, flags imgui.InputTextFlags, callback imgui.InputTextCallback) (string, bool) {
 	// imgui expects a mutable string buffer
 	buf := value
-	changed := imgui.InputTextWithHint(label, "", &buf, imgui.InputTextFlagsNone, nil)
+	changed := imgui.InputTextWithHint(label, "", &buf, flags, callback)
 	return buf, changed
 }
 
@@ -104,8 +109,20 @@ func ColorEdit4(label string, r, g, b, a float32) (float32, float32, float32, fl
 // when inactive (false), the button is dimmed. when active (true), it uses the checkmark color.
 // returns (newValue, changed) following dfx conventions.
 func Toggle(label string, value bool) (bool, bool) {
-	// set button color based on state
-	if !value {
+	pushToggleColor(value)
+	defer imgui.PopStyleColor()
+
+	// render button and toggle on click
+	if imgui.Button(label) {
+		return !value, true // newValue, changed
+	}
+	return value, false // no change
+}
+
+// pushToggleColor sets the button color of a toggle: the checkmark color when active,
+// dimmed when not. pop it with imgui.PopStyleColor.
+func pushToggleColor(active bool) {
+	if !active {
 		// inactive: dim the button
 		buttonColor := imgui.CurrentStyle().Colors()[imgui.ColButton]
 		buttonColor.W = toggleInactiveAlpha
@@ -114,13 +131,76 @@ func Toggle(label string, value bool) (bool, bool) {
 		// active: use checkmark color
 		imgui.PushStyleColorVec4(imgui.ColButton, imgui.CurrentStyle().Colors()[imgui.ColCheckMark])
 	}
-	defer imgui.PopStyleColor()
+}
 
-	// render button and toggle on click
-	if imgui.Button(label) {
-		return !value, true // newValue, changed
+// RadioGroup draws items as a row of toggle buttons with exactly one active, followed by
+// the visible part of label. clicking a button makes it the active one.
+// returns (newIndex, changed) following dfx conventions.
+func RadioGroup(label string, current int, items []string) (int, bool) {
+	imgui.PushIDStr(label)
+	defer imgui.PopID()
+
+	newIndex := current
+	spacing := imgui.CurrentStyle().ItemInnerSpacing().X
+	for i, item := range items {
+		if i > 0 {
+			imgui.SameLineV(0, spacing)
+		}
+		imgui.PushIDInt(int32(i))
+		if _, clicked := Toggle(item, i == current); clicked {
+			newIndex = i
+		}
+		imgui.PopID()
 	}
-	return value, false // no change
+	if text := visibleLabel(label); text != "" {
+		imgui.SameLineV(0, spacing)
+		imgui.AlignTextToFramePadding()
+		imgui.Text(text)
+	}
+	return newIndex, newIndex != current
+}
+
+// SegmentedControl draws items as a joined row of equal-width buttons acting like tabs:
+// the current one is highlighted, and clicking another selects it. the segments are as
+// wide as the widest item, or share width when it is positive.
+// returns (newIndex, changed) following dfx conventions.
+func SegmentedControl(label string, current int, items []string, width float32) (int, bool) {
+	if len(items) == 0 {
+		return current, false
+	}
+	imgui.PushIDStr(label)
+	defer imgui.PopID()
+
+	const gap = 1 // between segments
+	padding := imgui.CurrentStyle().FramePadding().X
+	segment := (width - gap*float32(len(items)-1)) / float32(len(items))
+	if width <= 0 {
+		for _, item := range items {
+			segment = max(segment, imgui.CalcTextSize(visibleLabel(item)).X+padding*2)
+		}
+	}
+
+	imgui.PushStyleVarVec2(imgui.StyleVarItemSpacing, imgui.Vec2{X: gap, Y: imgui.CurrentStyle().ItemSpacing().Y})
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameRounding, 0)
+	newIndex := current
+	for i, item := range items {
+		if i > 0 {
+			imgui.SameLine()
+		}
+		imgui.PushIDInt(int32(i))
+		if i == current {
+			imgui.PushStyleColorVec4(imgui.ColButton, imgui.CurrentStyle().Colors()[imgui.ColCheckMark])
+		} else {
+			imgui.PushStyleColorVec4(imgui.ColButton, imgui.CurrentStyle().Colors()[imgui.ColFrameBg])
+		}
+		if imgui.ButtonV(item, imgui.Vec2{X: segment, Y: 0}) {
+			newIndex = i
+		}
+		imgui.PopStyleColor()
+		imgui.PopID()
+	}
+	imgui.PopStyleVarV(2)
+	return newIndex, newIndex != current
 }
 
 // WheelSlider creates a slider that responds to mouse wheel when hovered.
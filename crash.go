@@ -0,0 +1,236 @@
+package dfx
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// crash report constants
+const (
+	crashDirName      = "crashes"
+	crashPendingName  = "pending" // holds the path of the report not yet shown
+	crashLogMessages  = 200       // most recent log messages included in a report
+	crashPopupID      = "##dfxCrash"
+	crashTimeLayout   = "20060102-150405"
+	crashPreviewLines = 12
+)
+
+// recoverCrash records a crash report for a panic, then lets the panic continue. it must
+// be deferred directly.
+func (app *App) recoverCrash() {
+	if r := recover(); r != nil {
+		app.recordCrash(r, debug.Stack())
+		panic(r)
+	}
+}
+
+// recordCrash writes a crash report for the panic value r, once per run.
+func (app *App) recordCrash(r any, stack []byte) {
+	if app.config.DisableCrashReports || app.crashed {
+		return
+	}
+	app.crashed = true
+	dir := app.crashDir()
+	if dir == "" {
+		return
+	}
+	var snapshot interface{}
+	if app.config.CrashSnapshot != nil {
+		snapshot = app.config.CrashSnapshot()
+	}
+	report := crashReport(app.config.Info, r, stack, app.config.CrashLog, snapshot, time.Now())
+	if path, err := writeCrashReport(dir, report, time.Now()); err == nil {
+		fmt.Fprintf(os.Stderr, "crash report written to '%v'\n", path)
+	}
+}
+
+// crashDir returns CrashDir, defaulting to a crashes directory in the config directory
+// named for the app.
+func (app *App) crashDir() string {
+	if app.config.CrashDir != "" {
+		return app.config.CrashDir
+	}
+	name := app.config.Info.Name
+	if name == "" {
+		name = app.config.Title
+	}
+	path, err := ConfigPath(strings.ToLower(strings.ReplaceAll(name, " ", "-")), crashDirName)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// checkCrashReport prepares the report dialog when the previous run left a crash report.
+func (app *App) checkCrashReport() {
+	if app.config.DisableCrashReports {
+		return
+	}
+	dir := app.crashDir()
+	if path, ok := pendingCrashReport(dir); ok {
+		app.crash = newCrashDialog(dir, path, app.config.OnCrashSubmit)
+	}
+}
+
+// crashReport formats a crash report: what panicked and where, the environment, the most
+// recent log messages, and a snapshot of the config.
+func crashReport(info AppInfo, r any, stack []byte, log *LogBuffer, snapshot interface{}, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "crash report, %v\n\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n\n", r)
+	for _, line := range diagnosticLines(info, "", "") {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\nstack:\n")
+	b.Write(stack)
+
+	if log != nil {
+		messages := log.Tail(crashLogMessages)
+		b.WriteString("\nrecent log:\n")
+		for i := range messages {
+			b.WriteString(formatLogMessage(&messages[i]) + "\n")
+		}
+	}
+
+	if snapshot != nil {
+		b.WriteString("\nconfig:\n")
+		if data, err := (jsonCodec{}).Marshal(snapshot); err == nil {
+			b.Write(data)
+			b.WriteString("\n")
+		} else {
+			fmt.Fprintf(&b, "%+v\n", snapshot)
+		}
+	}
+	return b.String()
+}
+
+// writeCrashReport writes report to a new file in dir and marks it pending, to be shown
+// on the next start. reports can hold config values and log messages, so they are only
+// readable by their owner. returns the report's path.
+func writeCrashReport(dir, report string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Wrapf(err, "error creating crash directory '%v'", dir)
+	}
+	path := filepath.Join(dir, "crash-"+now.Format(crashTimeLayout)+".txt")
+	if err := os.WriteFile(path, []byte(report), 0600); err != nil {
+		return "", errors.Wrapf(err, "error writing crash report '%v'", path)
+	}
+	if err := os.WriteFile(filepath.Join(dir, crashPendingName), []byte(path), 0600); err != nil {
+		return path, errors.Wrapf(err, "error marking crash report '%v' pending", path)
+	}
+	return path, nil
+}
+
+// pendingCrashReport returns the path of the crash report in dir not yet shown.
+func pendingCrashReport(dir string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, crashPendingName))
+	if err != nil {
+		return "", false
+	}
+	path := strings.TrimSpace(string(data))
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// clearPendingCrashReport marks the pending crash report in dir as shown.
+func clearPendingCrashReport(dir string) {
+	_ = os.Remove(filepath.Join(dir, crashPendingName))
+}
+
+// openInShell opens path with the application the OS associates with it.
+func openInShell(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	case "darwin":
+		cmd = exec.Command("open", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	return cmd.Start()
+}
+
+// crashDialog tells the user the previous run crashed, and offers to open, copy or
+// submit its report.
+type crashDialog struct {
+	dir    string
+	path   string
+	report string
+	submit func(report string) error
+	open   bool
+	status string
+}
+
+func newCrashDialog(dir, path string, submit func(report string) error) *crashDialog {
+	report, _ := os.ReadFile(path)
+	return &crashDialog{dir: dir, path: path, report: string(report), submit: submit, open: true}
+}
+
+// draw draws the dialog while it is open. returns false once it is dismissed.
+func (d *crashDialog) draw() bool {
+	if d.open {
+		d.open = false
+		imgui.OpenPopupStr(crashPopupID)
+	}
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(T("dfx.crash.title")+crashPopupID, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		return true
+	}
+	imgui.Text(T("dfx.crash.message"))
+	imgui.TextDisabled(d.path)
+
+	lines := imgui.TextLineHeight() * crashPreviewLines
+	imgui.InputTextMultiline("##report", &d.report, imgui.Vec2{X: imgui.FontSize() * 36, Y: lines}, imgui.InputTextFlagsReadOnly, nil)
+	if d.status != "" {
+		imgui.TextUnformatted(d.status)
+	}
+	imgui.Separator()
+
+	dismissed := false
+	if imgui.Button(T("dfx.crash.open")) {
+		if err := openInShell(d.path); err != nil {
+			d.status = err.Error()
+		}
+	}
+	imgui.SameLine()
+	if imgui.Button(T("dfx.crash.copy")) {
+		imgui.SetClipboardText(d.report)
+		d.status = T("dfx.about.copied")
+	}
+	if d.submit != nil {
+		imgui.SameLine()
+		if imgui.Button(T("dfx.crash.submit")) {
+			if err := d.submit(d.report); err != nil {
+				d.status = T("dfx.crash.failed", err)
+			} else {
+				dismissed = true
+			}
+		}
+	}
+	imgui.SameLine()
+	if imgui.Button(T("dfx.crash.dismiss")) || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		dismissed = true
+	}
+	if dismissed {
+		clearPendingCrashReport(d.dir)
+		imgui.CloseCurrentPopup()
+	}
+	imgui.EndPopup()
+	return !dismissed
+}
@@ -0,0 +1,81 @@
+package dfx
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashReport(t *testing.T) {
+	log := NewLogBuffer(10)
+	log.Add(LogMessage{Time: time.Now(), Level: slog.LevelInfo, Message: "loaded song"})
+	snapshot := struct{ Volume float64 }{Volume: 0.5}
+
+	report := crashReport(AppInfo{Name: "Composer", Version: "1.0"}, "boom", []byte("goroutine 1 [running]:\n"), log, snapshot, time.Now())
+	for _, expected := range []string{"panic: boom", "Composer 1.0", "goroutine 1 [running]", "loaded song", "config:", "volume"} {
+		if !strings.Contains(report, expected) {
+			t.Fatalf("expected '%v' in report:\n%v", expected, report)
+		}
+	}
+	if strings.Contains(crashReport(AppInfo{}, "boom", nil, nil, nil, time.Now()), "recent log:") {
+		t.Fatalf("expected no log section without a log buffer")
+	}
+}
+
+func TestWriteCrashReport_Pending(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crashes")
+	if _, ok := pendingCrashReport(dir); ok {
+		t.Fatalf("expected no pending report")
+	}
+
+	path, err := writeCrashReport(dir, "report", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "report" {
+		t.Fatalf("expected the report written, got '%v'", string(data))
+	}
+	for path, expected := range map[string]os.FileMode{dir: 0700, path: 0600, filepath.Join(dir, crashPendingName): 0600} {
+		if mode := fileMode(t, path); mode != expected {
+			t.Fatalf("expected '%v' to have mode '%v', got '%v'", path, expected, mode)
+		}
+	}
+	pending, ok := pendingCrashReport(dir)
+	if !ok || pending != path {
+		t.Fatalf("expected '%v' pending, got '%v'", path, pending)
+	}
+
+	clearPendingCrashReport(dir)
+	if _, ok := pendingCrashReport(dir); ok {
+		t.Fatalf("expected no pending report once shown")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the report kept: %v", err)
+	}
+}
+
+func TestApp_RecordCrash(t *testing.T) {
+	dir := t.TempDir()
+	app := New(nil, Config{CrashDir: dir})
+	func() {
+		defer func() { _ = recover() }()
+		defer app.recoverCrash()
+		panic("boom")
+	}()
+	path, ok := pendingCrashReport(dir)
+	if !ok {
+		t.Fatalf("expected a pending crash report")
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "panic: boom") {
+		t.Fatalf("expected the panic in the report, got '%v'", string(data))
+	}
+
+	disabled := New(nil, Config{CrashDir: t.TempDir(), DisableCrashReports: true})
+	disabled.recordCrash("boom", nil)
+	if _, ok := pendingCrashReport(disabled.config.CrashDir); ok {
+		t.Fatalf("expected no report when disabled")
+	}
+}
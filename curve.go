@@ -0,0 +1,212 @@
+package dfx
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// CurveShape is the shape of the segment from a curve point to the next.
+type CurveShape int
+
+const (
+	CurveLinear      CurveShape = iota // straight line
+	CurveExponential                   // bent by the point's Curvature
+	CurveBezier                        // cubic bezier through the point's handles
+)
+
+// curve constants
+const (
+	CurveCurvatureScale = 8 // exponent of an exponential segment at Curvature 1
+	curveBezierSteps    = 24
+)
+
+var curveShapeNames = map[CurveShape]string{
+	CurveLinear:      "linear",
+	CurveExponential: "exponential",
+	CurveBezier:      "bezier",
+}
+
+// String returns the shape name.
+func (s CurveShape) String() string {
+	return curveShapeNames[s]
+}
+
+// MarshalText implements encoding.TextMarshaler, so curves serialize with shape names.
+func (s CurveShape) MarshalText() ([]byte, error) {
+	name, found := curveShapeNames[s]
+	if !found {
+		return nil, errors.Errorf("unknown curve shape %d", int(s))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *CurveShape) UnmarshalText(text []byte) error {
+	for shape, name := range curveShapeNames {
+		if name == string(text) {
+			*s = shape
+			return nil
+		}
+	}
+	return errors.Errorf("unknown curve shape '%v'", string(text))
+}
+
+// CurvePoint is a breakpoint of a Curve, and the shape of the segment that follows it.
+type CurvePoint struct {
+	T         float32    `json:"t"`                   // time (or input) position
+	V         float32    `json:"v"`                   // value
+	Shape     CurveShape `json:"shape,omitempty"`     // shape of the segment to the next point
+	Curvature float32    `json:"curvature,omitempty"` // -1..1 bend of an exponential segment; positive rises late
+	Handle1   [2]float32 `json:"handle1"`             // first bezier control point, in segment units (0..1 across, 0..1 up)
+	Handle2   [2]float32 `json:"handle2"`             // second bezier control point
+}
+
+// Curve is a breakpoint envelope: points sorted by T joined by shaped segments. it
+// serializes to JSON with its points, and evaluates to the first point's value before
+// it and the last point's value after it. a curve over 0..1 rising from 0 to 1 is also a
+// Taper, for faders with custom response curves.
+type Curve struct {
+	Points []CurvePoint `json:"points"`
+}
+
+// NewCurve creates a curve through points, sorted by T.
+func NewCurve(points ...CurvePoint) *Curve {
+	c := &Curve{Points: append([]CurvePoint(nil), points...)}
+	c.Sort()
+	return c
+}
+
+// Sort orders the points by T.
+func (c *Curve) Sort() {
+	sort.SliceStable(c.Points, func(i, j int) bool { return c.Points[i].T < c.Points[j].T })
+}
+
+// Evaluate returns the value of the curve at t.
+func (c *Curve) Evaluate(t float32) float32 {
+	n := len(c.Points)
+	switch {
+	case n == 0:
+		return 0
+	case t <= c.Points[0].T:
+		return c.Points[0].V
+	case t >= c.Points[n-1].T:
+		return c.Points[n-1].V
+	}
+	i := sort.Search(n, func(i int) bool { return c.Points[i].T > t }) - 1
+	from, to := c.Points[i], c.Points[i+1]
+	span := to.T - from.T
+	if span <= 0 {
+		return to.V
+	}
+	return from.V + (to.V-from.V)*segmentShape(from, (t-from.T)/span)
+}
+
+// segmentShape maps x (0..1 across a segment) to the share (0..1) of the rise from the
+// segment's start value to its end value.
+func segmentShape(p CurvePoint, x float32) float32 {
+	switch p.Shape {
+	case CurveExponential:
+		k := float64(clamp(p.Curvature, -1, 1) * CurveCurvatureScale)
+		if math.Abs(k) < 1e-6 {
+			return x
+		}
+		return float32(math.Expm1(k*float64(x)) / math.Expm1(k))
+	case CurveBezier:
+		return bezierShape(p.Handle1, p.Handle2, x)
+	default:
+		return x
+	}
+}
+
+// bezierShape evaluates the cubic bezier (0,0) h1 h2 (1,1) at x, finding the bezier
+// parameter by bisection (handle x values are clamped to 0..1 so x increases along it).
+func bezierShape(h1, h2 [2]float32, x float32) float32 {
+	x1, x2 := clamp(h1[0], 0, 1), clamp(h2[0], 0, 1)
+	lo, hi := float32(0), float32(1)
+	s := x
+	for range curveBezierSteps {
+		s = (lo + hi) / 2
+		if cubicBezier(0, x1, x2, 1, s) < x {
+			lo = s
+		} else {
+			hi = s
+		}
+	}
+	return cubicBezier(0, h1[1], h2[1], 1, s)
+}
+
+func cubicBezier(p0, p1, p2, p3, s float32) float32 {
+	u := 1 - s
+	return u*u*u*p0 + 3*u*u*s*p1 + 3*u*s*s*p2 + s*s*s*p3
+}
+
+// DefaultBezierHandles are the handles a segment gets when it becomes a bezier: an ease
+// in and out.
+var DefaultBezierHandles = [2][2]float32{{0.5, 0}, {0.5, 1}}
+
+// Insert adds a point at (t, v) with a linear segment, returning its index.
+func (c *Curve) Insert(t, v float32) int {
+	i := sort.Search(len(c.Points), func(i int) bool { return c.Points[i].T > t })
+	c.Points = append(c.Points, CurvePoint{})
+	copy(c.Points[i+1:], c.Points[i:])
+	c.Points[i] = CurvePoint{T: t, V: v}
+	return i
+}
+
+// Remove deletes point i.
+func (c *Curve) Remove(i int) {
+	if i < 0 || i >= len(c.Points) {
+		return
+	}
+	c.Points = append(c.Points[:i], c.Points[i+1:]...)
+}
+
+// Move places point i at (t, v), keeping t between its neighbors so the points stay
+// sorted.
+func (c *Curve) Move(i int, t, v float32) {
+	if i < 0 || i >= len(c.Points) {
+		return
+	}
+	if i > 0 {
+		t = max(t, c.Points[i-1].T)
+	}
+	if i < len(c.Points)-1 {
+		t = min(t, c.Points[i+1].T)
+	}
+	c.Points[i].T, c.Points[i].V = t, v
+}
+
+// SetShape sets the shape of the segment after point i, giving new bezier segments the
+// DefaultBezierHandles.
+func (c *Curve) SetShape(i int, shape CurveShape) {
+	if i < 0 || i >= len(c.Points) {
+		return
+	}
+	p := &c.Points[i]
+	if shape == CurveBezier && p.Shape != CurveBezier {
+		p.Handle1, p.Handle2 = DefaultBezierHandles[0], DefaultBezierHandles[1]
+	}
+	p.Shape = shape
+}
+
+// Apply implements Taper: the curve's value at normalized.
+func (c *Curve) Apply(normalized float32) float32 {
+	return clamp(c.Evaluate(normalized), 0, 1)
+}
+
+// Invert implements Taper, finding the input whose value is tapered by bisection; the
+// curve should rise steadily for the result to be meaningful.
+func (c *Curve) Invert(tapered float32) float32 {
+	lo, hi := float32(0), float32(1)
+	for range curveBezierSteps {
+		mid := (lo + hi) / 2
+		if c.Evaluate(mid) < tapered {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
@@ -0,0 +1,420 @@
+package dfx
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// CurveEditor constants
+const (
+	CurveEditorPointRadius   = 4    // breakpoint radius in pixels
+	CurveEditorMaxZoom       = 64   // deepest time zoom
+	curveEditorHitSlop       = 3    // extra pixels around points that still hit them
+	curveEditorZoomStep      = 1.25 // zoom factor per wheel notch
+	curveEditorCurvatureDrag = 0.01 // curvature change per pixel dragged
+	curveEditorMinGridGap    = 8    // snap grid lines closer than this are not drawn
+)
+
+// curve editor drag targets
+const (
+	curveDragNone = iota
+	curveDragPoint
+	curveDragHandle1
+	curveDragHandle2
+	curveDragCurvature
+	curveDragPan
+)
+
+// CurveEditor edits a breakpoint Curve: double-click to add a point, drag points to move
+// them, right-click a point to delete it or change the shape of the segment after it, and
+// drag an exponential segment up or down to bend it. the selected point's bezier handles
+// are dragged the same way. Ctrl+wheel zooms time around the pointer, the wheel or a
+// middle drag pans. points snap to SnapT and SnapV unless Shift is held, and hovering or
+// dragging a point shows its position.
+type CurveEditor struct {
+	Container
+	Curve      *Curve                    // curve being edited
+	MinT, MaxT float32                   // time range (MinT == MaxT = 0..1)
+	MinV, MaxV float32                   // value range (MinV == MaxV = 0..1)
+	Height     float32                   // editor height (0 = available height)
+	SnapT      float32                   // time grid points snap to (0 = none)
+	SnapV      float32                   // value grid points snap to (0 = none)
+	Format     func(t, v float32) string // point readout (nil = both with 3 decimals in CurrentFormat())
+	LineColor  imgui.Vec4                // curve color (zero = theme accent)
+	OnChange   func(curve *Curve)        // called after each edit
+
+	zoom     float32 // time zoom (1 = whole range)
+	pan      float32 // time at the left edge
+	selected int     // selected point (-1 = none)
+	drag     int     // what is being dragged
+	dragIdx  int     // point whose part is being dragged
+}
+
+// NewCurveEditor creates an editor for curve over 0..1 in time and value.
+func NewCurveEditor(curve *Curve) *CurveEditor {
+	return &CurveEditor{Container: Container{Visible: true}, Curve: curve, zoom: 1, selected: -1}
+}
+
+// curveView maps curve coordinates to the editor rectangle.
+type curveView struct {
+	min, size               imgui.Vec2
+	start, span, minV, maxV float32
+}
+
+func (v curveView) toScreen(t, value float32) imgui.Vec2 {
+	return imgui.Vec2{
+		X: v.min.X + (t-v.start)/v.span*v.size.X,
+		Y: v.min.Y + v.size.Y - (value-v.minV)/(v.maxV-v.minV)*v.size.Y,
+	}
+}
+
+func (v curveView) fromScreen(p imgui.Vec2) (float32, float32) {
+	return v.start + (p.X-v.min.X)/v.size.X*v.span, v.minV + (v.min.Y+v.size.Y-p.Y)/v.size.Y*(v.maxV-v.minV)
+}
+
+// ranges returns the time and value ranges, defaulting to 0..1.
+func (ce *CurveEditor) ranges() (float32, float32, float32, float32) {
+	minT, maxT, minV, maxV := ce.MinT, ce.MaxT, ce.MinV, ce.MaxV
+	if maxT <= minT {
+		minT, maxT = 0, 1
+	}
+	if maxV <= minV {
+		minV, maxV = 0, 1
+	}
+	return minT, maxT, minV, maxV
+}
+
+// view returns the mapping for the editor rectangle at the current zoom and pan.
+func (ce *CurveEditor) view(pos, size imgui.Vec2) curveView {
+	minT, maxT, minV, maxV := ce.ranges()
+	ce.zoom = clamp(ce.zoom, 1, CurveEditorMaxZoom)
+	span := (maxT - minT) / ce.zoom
+	ce.pan = clamp(ce.pan, minT, maxT-span)
+	return curveView{min: pos, size: size, start: ce.pan, span: span, minV: minV, maxV: maxV}
+}
+
+// Draw implements Component.
+func (ce *CurveEditor) Draw(state *State) {
+	if !ce.Visible || ce.Curve == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("curveEditor", ce))
+	defer imgui.PopID()
+
+	size := imgui.ContentRegionAvail()
+	if ce.Height > 0 {
+		size.Y = ce.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButtonV("##curve", size, imgui.ButtonFlagsMouseButtonLeft|imgui.ButtonFlagsMouseButtonRight|imgui.ButtonFlagsMouseButtonMiddle)
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+
+	ce.handleWheel(hovered, pos, size)
+	view := ce.view(pos, size)
+	mouse := imgui.MousePos()
+	hit := ce.hitPoint(view, mouse)
+
+	changed := ce.handleMouse(view, mouse, hit, hovered, active)
+	if ce.selected >= len(ce.Curve.Points) {
+		ce.selected = -1
+	}
+	if ce.selected >= 0 && imgui.IsWindowFocused() && imgui.IsKeyPressedBool(imgui.KeyDelete) {
+		ce.Curve.Remove(ce.selected)
+		ce.selected = -1
+		changed = true
+	}
+	if ce.drawContextMenu() {
+		changed = true
+	}
+
+	ce.drawCurve(view)
+	if hit < 0 && ce.drag == curveDragPoint {
+		hit = ce.dragIdx
+	}
+	if hit >= 0 && hit < len(ce.Curve.Points) && (hovered || active) {
+		p := ce.Curve.Points[hit]
+		imgui.SetTooltip(ce.readout(p.T, p.V))
+	}
+
+	if changed && ce.OnChange != nil {
+		ce.OnChange(ce.Curve)
+	}
+	drawContainerExtensions(&ce.Container, state)
+}
+
+// handleWheel zooms (Ctrl) or pans time with the mouse wheel.
+func (ce *CurveEditor) handleWheel(hovered bool, pos, size imgui.Vec2) {
+	wheel := imgui.CurrentIO().MouseWheel()
+	if !hovered || wheel == 0 {
+		return
+	}
+	view := ce.view(pos, size)
+	if imgui.CurrentIO().KeyCtrl() {
+		// keep the time under the pointer in place
+		at, _ := view.fromScreen(imgui.MousePos())
+		ce.zoom *= float32(math.Pow(curveEditorZoomStep, float64(wheel)))
+		minT, maxT, _, _ := ce.ranges()
+		ce.zoom = clamp(ce.zoom, 1, CurveEditorMaxZoom)
+		span := (maxT - minT) / ce.zoom
+		ce.pan = at - (imgui.MousePos().X-pos.X)/size.X*span
+		return
+	}
+	ce.pan -= wheel * view.span / 10
+}
+
+// handleMouse starts, continues and ends drags and adds points. returns true when the
+// curve changed.
+func (ce *CurveEditor) handleMouse(view curveView, mouse imgui.Vec2, hit int, hovered, active bool) bool {
+	c := ce.Curve
+	switch {
+	case hovered && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && hit < 0:
+		t, v := view.fromScreen(mouse)
+		t, v = ce.snap(t, v)
+		ce.selected = c.Insert(t, v)
+		ce.drag = curveDragNone
+		return true
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft):
+		ce.drag, ce.dragIdx = ce.dragTarget(view, mouse, hit)
+		if ce.drag == curveDragPoint {
+			ce.selected = hit
+		} else if ce.drag == curveDragNone {
+			ce.selected = -1
+		}
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonMiddle):
+		ce.drag = curveDragPan
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonRight) && hit >= 0:
+		ce.selected = hit
+		imgui.OpenPopupStr("##curvePoint")
+	}
+	if !active {
+		ce.drag = curveDragNone
+		return false
+	}
+
+	delta := imgui.CurrentIO().MouseDelta()
+	if delta.X == 0 && delta.Y == 0 {
+		return false
+	}
+	switch ce.drag {
+	case curveDragPoint:
+		t, v := view.fromScreen(mouse)
+		t, v = ce.snap(t, v)
+		minT, maxT, minV, maxV := ce.ranges()
+		c.Move(ce.dragIdx, clamp(t, minT, maxT), clamp(v, minV, maxV))
+		return true
+	case curveDragHandle1, curveDragHandle2:
+		return ce.dragHandle(view, mouse)
+	case curveDragCurvature:
+		p := &c.Points[ce.dragIdx]
+		rising := c.Points[ce.dragIdx+1].V >= p.V
+		bend := delta.Y * curveEditorCurvatureDrag
+		if !rising {
+			bend = -bend
+		}
+		p.Curvature = clamp(p.Curvature+bend, -1, 1)
+		return true
+	case curveDragPan:
+		ce.pan -= delta.X / view.size.X * view.span
+	}
+	return false
+}
+
+// dragTarget returns what a click at mouse starts dragging: a point, a handle of the
+// selected point, or an exponential segment.
+func (ce *CurveEditor) dragTarget(view curveView, mouse imgui.Vec2, hit int) (int, int) {
+	if hit >= 0 {
+		return curveDragPoint, hit
+	}
+	if h1, h2, found := ce.handles(view, ce.selected); found {
+		if distance(mouse, h1) <= CurveEditorPointRadius+curveEditorHitSlop {
+			return curveDragHandle1, ce.selected
+		}
+		if distance(mouse, h2) <= CurveEditorPointRadius+curveEditorHitSlop {
+			return curveDragHandle2, ce.selected
+		}
+	}
+	t, _ := view.fromScreen(mouse)
+	points := ce.Curve.Points
+	for i := 0; i+1 < len(points); i++ {
+		if points[i].Shape == CurveExponential && t > points[i].T && t < points[i+1].T {
+			on := view.toScreen(t, ce.Curve.Evaluate(t))
+			if math.Abs(float64(on.Y-mouse.Y)) <= CurveEditorPointRadius+curveEditorHitSlop*2 {
+				return curveDragCurvature, i
+			}
+		}
+	}
+	return curveDragNone, -1
+}
+
+// dragHandle moves the dragged bezier handle to the mouse, in segment units.
+func (ce *CurveEditor) dragHandle(view curveView, mouse imgui.Vec2) bool {
+	points := ce.Curve.Points
+	if ce.dragIdx < 0 || ce.dragIdx+1 >= len(points) {
+		return false
+	}
+	from, to := points[ce.dragIdx], points[ce.dragIdx+1]
+	t, v := view.fromScreen(mouse)
+	handle := [2]float32{clamp((t-from.T)/max(to.T-from.T, 1e-6), 0, 1), 0}
+	if to.V != from.V {
+		handle[1] = (v - from.V) / (to.V - from.V)
+	}
+	if ce.drag == curveDragHandle1 {
+		ce.Curve.Points[ce.dragIdx].Handle1 = handle
+	} else {
+		ce.Curve.Points[ce.dragIdx].Handle2 = handle
+	}
+	return true
+}
+
+// handles returns the screen positions of the bezier handles of the segment after point
+// i, if it is a bezier segment.
+func (ce *CurveEditor) handles(view curveView, i int) (imgui.Vec2, imgui.Vec2, bool) {
+	points := ce.Curve.Points
+	if i < 0 || i+1 >= len(points) || points[i].Shape != CurveBezier {
+		return imgui.Vec2{}, imgui.Vec2{}, false
+	}
+	from, to := points[i], points[i+1]
+	at := func(h [2]float32) imgui.Vec2 {
+		return view.toScreen(from.T+h[0]*(to.T-from.T), from.V+h[1]*(to.V-from.V))
+	}
+	return at(from.Handle1), at(from.Handle2), true
+}
+
+// hitPoint returns the point under mouse, or -1.
+func (ce *CurveEditor) hitPoint(view curveView, mouse imgui.Vec2) int {
+	hit, best := -1, float32(CurveEditorPointRadius+curveEditorHitSlop)
+	for i, p := range ce.Curve.Points {
+		if d := distance(mouse, view.toScreen(p.T, p.V)); d <= best {
+			hit, best = i, d
+		}
+	}
+	return hit
+}
+
+// snap rounds a position to the snap grid unless Shift is held.
+func (ce *CurveEditor) snap(t, v float32) (float32, float32) {
+	if imgui.CurrentIO().KeyShift() {
+		return t, v
+	}
+	return snapTo(t, ce.SnapT), snapTo(v, ce.SnapV)
+}
+
+// snapTo rounds value to the nearest multiple of step (0 = unchanged).
+func snapTo(value, step float32) float32 {
+	if step <= 0 {
+		return value
+	}
+	return float32(math.Round(float64(value/step))) * step
+}
+
+func distance(a, b imgui.Vec2) float32 {
+	return float32(math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y)))
+}
+
+// readout formats a point position for its tooltip.
+func (ce *CurveEditor) readout(t, v float32) string {
+	if ce.Format != nil {
+		return ce.Format(t, v)
+	}
+	f := CurrentFormat()
+	return f.FormatFloat(float64(t), 3) + ", " + f.FormatFloat(float64(v), 3)
+}
+
+// drawContextMenu draws the menu of the right-clicked point. returns true when the curve
+// changed.
+func (ce *CurveEditor) drawContextMenu() bool {
+	if !imgui.BeginPopup("##curvePoint") {
+		return false
+	}
+	defer imgui.EndPopup()
+	i := ce.selected
+	if i < 0 || i >= len(ce.Curve.Points) {
+		imgui.CloseCurrentPopup()
+		return false
+	}
+	changed := false
+	last := i == len(ce.Curve.Points)-1 // the last point has no segment to shape
+	for _, shape := range []CurveShape{CurveLinear, CurveExponential, CurveBezier} {
+		if imgui.MenuItemBoolV(T("dfx.curve."+shape.String()), "", ce.Curve.Points[i].Shape == shape, !last) {
+			ce.Curve.SetShape(i, shape)
+			changed = true
+		}
+	}
+	imgui.Separator()
+	if imgui.MenuItemBoolV(T("dfx.curve.delete"), "Del", false, true) {
+		ce.Curve.Remove(i)
+		ce.selected = -1
+		changed = true
+	}
+	return changed
+}
+
+// drawCurve draws the frame, snap grid, curve, points and the selected point's handles.
+func (ce *CurveEditor) drawCurve(view curveView) {
+	drawList := imgui.WindowDrawList()
+	style := imgui.CurrentStyle()
+	colors := style.Colors()
+	maxPos := view.min.Add(view.size)
+	drawList.AddRectFilledV(view.min, maxPos, imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]), style.FrameRounding(), imgui.DrawFlagsNone)
+	drawList.PushClipRectV(view.min, maxPos, true)
+	defer drawList.PopClipRect()
+
+	grid := colors[imgui.ColBorder]
+	grid.W *= 0.5
+	gridColor := imgui.ColorConvertFloat4ToU32(grid)
+	if ce.SnapT > 0 && ce.SnapT/view.span*view.size.X >= curveEditorMinGridGap {
+		for t := float32(math.Ceil(float64(view.start/ce.SnapT))) * ce.SnapT; t <= view.start+view.span; t += ce.SnapT {
+			x := view.toScreen(t, view.minV).X
+			drawList.AddLine(imgui.Vec2{X: x, Y: view.min.Y}, imgui.Vec2{X: x, Y: maxPos.Y}, gridColor)
+		}
+	}
+	if ce.SnapV > 0 && ce.SnapV/(view.maxV-view.minV)*view.size.Y >= curveEditorMinGridGap {
+		for v := float32(math.Ceil(float64(view.minV/ce.SnapV))) * ce.SnapV; v <= view.maxV; v += ce.SnapV {
+			y := view.toScreen(view.start, v).Y
+			drawList.AddLine(imgui.Vec2{X: view.min.X, Y: y}, imgui.Vec2{X: maxPos.X, Y: y}, gridColor)
+		}
+	}
+
+	lineColor := imgui.ColorConvertFloat4ToU32(themeColor(ce.LineColor, ThemeColors().Accent))
+	if len(ce.Curve.Points) > 0 {
+		for x := float32(0); x <= view.size.X; x += 2 {
+			t := view.start + x/view.size.X*view.span
+			drawList.PathLineTo(view.toScreen(t, ce.Curve.Evaluate(t)))
+		}
+		drawList.PathStrokeV(lineColor, imgui.DrawFlagsNone, 2)
+	}
+
+	textColor := imgui.ColorConvertFloat4ToU32(colors[imgui.ColText])
+	if h1, h2, found := ce.handles(view, ce.selected); found {
+		from := ce.Curve.Points[ce.selected]
+		to := ce.Curve.Points[ce.selected+1]
+		drawList.AddLine(view.toScreen(from.T, from.V), h1, textColor)
+		drawList.AddLine(view.toScreen(to.T, to.V), h2, textColor)
+		drawList.AddCircle(h1, CurveEditorPointRadius-1, textColor)
+		drawList.AddCircle(h2, CurveEditorPointRadius-1, textColor)
+	}
+	for i, p := range ce.Curve.Points {
+		color := textColor
+		if i == ce.selected {
+			color = lineColor
+		}
+		drawList.AddCircleFilled(view.toScreen(p.T, p.V), CurveEditorPointRadius, color)
+	}
+}
+
+// CaptureState implements StatefulComponent.
+func (ce *CurveEditor) CaptureState() map[string]any {
+	return map[string]any{"zoom": ce.zoom, "pan": ce.pan}
+}
+
+// RestoreState implements StatefulComponent.
+func (ce *CurveEditor) RestoreState(state map[string]any) {
+	if zoom, ok := stateFloat(state["zoom"]); ok {
+		ce.zoom = float32(zoom)
+	}
+	if pan, ok := stateFloat(state["pan"]); ok {
+		ce.pan = float32(pan)
+	}
+}
@@ -0,0 +1,50 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestCurveView(t *testing.T) {
+	ce := NewCurveEditor(NewCurve())
+	ce.MinT, ce.MaxT, ce.MinV, ce.MaxV = 0, 10, -1, 1
+	view := ce.view(imgui.Vec2{X: 100, Y: 50}, imgui.Vec2{X: 200, Y: 100})
+
+	if p := view.toScreen(5, 0); p.X != 200 || p.Y != 100 {
+		t.Errorf("expected the middle at (200, 100), got %v", p)
+	}
+	if tv, v := view.fromScreen(imgui.Vec2{X: 300, Y: 50}); tv != 10 || v != 1 {
+		t.Errorf("expected the top right at (10, 1), got (%v, %v)", tv, v)
+	}
+
+	// zoomed in, panning stays inside the range
+	ce.zoom, ce.pan = 4, 9
+	view = ce.view(imgui.Vec2{}, imgui.Vec2{X: 100, Y: 100})
+	if view.span != 2.5 || view.start != 7.5 {
+		t.Errorf("expected a 2.5 span from 7.5, got %v from %v", view.span, view.start)
+	}
+	ce.zoom = 1000
+	if ce.view(imgui.Vec2{}, imgui.Vec2{X: 100, Y: 100}); ce.zoom != CurveEditorMaxZoom {
+		t.Errorf("expected zoom clamped to %v, got %v", CurveEditorMaxZoom, ce.zoom)
+	}
+}
+
+func TestSnapTo(t *testing.T) {
+	if got := snapTo(0.37, 0.25); got != 0.25 {
+		t.Errorf("expected 0.25, got %v", got)
+	}
+	if got := snapTo(0.37, 0); got != 0.37 {
+		t.Errorf("expected no snapping without a step, got %v", got)
+	}
+}
+
+func TestCurveEditorState(t *testing.T) {
+	ce := NewCurveEditor(NewCurve())
+	ce.zoom, ce.pan = 3, 0.25
+	restored := NewCurveEditor(NewCurve())
+	restored.RestoreState(ce.CaptureState())
+	if restored.zoom != 3 || restored.pan != 0.25 {
+		t.Errorf("expected zoom 3 and pan 0.25, got %v and %v", restored.zoom, restored.pan)
+	}
+}
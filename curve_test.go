@@ -0,0 +1,97 @@
+package dfx
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-3
+}
+
+func TestCurveEvaluate(t *testing.T) {
+	c := NewCurve(CurvePoint{T: 1, V: 0}, CurvePoint{T: 0, V: 1}, CurvePoint{T: 2, V: 1})
+	tests := map[float32]float32{-1: 1, 0: 1, 0.5: 0.5, 1: 0, 1.25: 0.25, 2: 1, 3: 1}
+	for at, expected := range tests {
+		if got := c.Evaluate(at); !near(got, expected) {
+			t.Errorf("Evaluate(%v) = %v, expected %v", at, got, expected)
+		}
+	}
+	if (&Curve{}).Evaluate(0.5) != 0 {
+		t.Error("expected an empty curve to evaluate to 0")
+	}
+}
+
+func TestCurveShapes(t *testing.T) {
+	c := NewCurve(CurvePoint{T: 0, V: 0, Shape: CurveExponential, Curvature: 1}, CurvePoint{T: 1, V: 1})
+	if got := c.Evaluate(0.5); got >= 0.1 {
+		t.Errorf("expected positive curvature to rise late, got %v at the middle", got)
+	}
+	c.Points[0].Curvature = -1
+	if got := c.Evaluate(0.5); got <= 0.9 {
+		t.Errorf("expected negative curvature to rise early, got %v at the middle", got)
+	}
+	c.Points[0].Curvature = 0
+	if got := c.Evaluate(0.3); !near(got, 0.3) {
+		t.Errorf("expected zero curvature to be linear, got %v", got)
+	}
+
+	c.SetShape(0, CurveBezier)
+	if c.Points[0].Handle1 != DefaultBezierHandles[0] {
+		t.Errorf("expected default handles, got %v", c.Points[0].Handle1)
+	}
+	if got := c.Evaluate(0.5); !near(got, 0.5) {
+		t.Errorf("expected the symmetric ease to pass the middle, got %v", got)
+	}
+	if got := c.Evaluate(0.1); got >= 0.1 {
+		t.Errorf("expected the ease to start slowly, got %v", got)
+	}
+	c.Points[0].Handle1, c.Points[0].Handle2 = [2]float32{1.0 / 3, 1.0 / 3}, [2]float32{2.0 / 3, 2.0 / 3}
+	if got := c.Evaluate(0.8); !near(got, 0.8) {
+		t.Errorf("expected collinear handles to be linear, got %v", got)
+	}
+}
+
+func TestCurveEditing(t *testing.T) {
+	c := NewCurve(CurvePoint{T: 0, V: 0}, CurvePoint{T: 1, V: 1})
+	if i := c.Insert(0.5, 0.2); i != 1 || len(c.Points) != 3 {
+		t.Fatalf("expected the point inserted at 1, got %d with %d points", i, len(c.Points))
+	}
+	c.Move(1, 2, 0.4)
+	if c.Points[1].T != 1 || c.Points[1].V != 0.4 {
+		t.Errorf("expected the move clamped to the next point, got %+v", c.Points[1])
+	}
+	c.Remove(1)
+	c.Remove(5)
+	if len(c.Points) != 2 {
+		t.Errorf("expected 2 points after removing, got %d", len(c.Points))
+	}
+}
+
+func TestCurveJSON(t *testing.T) {
+	c := NewCurve(CurvePoint{T: 0, V: 0, Shape: CurveExponential, Curvature: 0.5}, CurvePoint{T: 1, V: 1})
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Curve
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Points) != 2 || decoded.Points[0].Shape != CurveExponential || decoded.Points[0].Curvature != 0.5 {
+		t.Errorf("expected the curve to round trip, got %+v from %s", decoded, data)
+	}
+	if err := json.Unmarshal([]byte(`{"points":[{"t":0,"v":0,"shape":"wobbly"}]}`), &decoded); err == nil {
+		t.Error("expected an error for an unknown shape")
+	}
+}
+
+func TestCurveTaper(t *testing.T) {
+	var taper Taper = NewCurve(CurvePoint{T: 0, V: 0, Shape: CurveExponential, Curvature: 0.5}, CurvePoint{T: 1, V: 1})
+	for _, n := range []float32{0, 0.25, 0.5, 0.9, 1} {
+		if got := taper.Invert(taper.Apply(n)); !near(got, n) {
+			t.Errorf("Invert(Apply(%v)) = %v", n, got)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package dfx
 
 import (
+	"math"
+	"time"
+
 	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/michaelquigley/dfx/fonts"
 )
@@ -23,7 +26,26 @@ type Dash struct {
 	MaxSize      int
 	Resizable    bool
 	TransitionMs int
+	Easing       Easing // show/hide easing curve (nil = DefaultEasing)
 	Focused      bool
+
+	// AnimateOnStart animates from CurrentSize on the first frame, rather than starting
+	// at the target size.
+	AnimateOnStart bool
+
+	tabs       []*dashTab
+	activeTab  int
+	tabSync    bool // a programmatic tab switch needs to be applied to the tab strip
+	tabActions bool // tab cycling actions have been registered
+
+	// set by DashManager
+	doubleClickToggle bool // double-clicking the title bar or drag handle toggles visibility
+	focusRing         bool // outline the dash while it is focused
+	focusRequest      bool // the dash should take keyboard focus when next drawn
+
+	anim     *Animation
+	animSize int  // CurrentSize as last set by the animation
+	skipAnim bool // jump to the target size on the next frame
 }
 
 func NewDash(name string, component Component) *Dash {
@@ -54,11 +76,19 @@ func (d *Dash) DrawDash(state *State, bounds Bounds, attachment DashAttachment)
 
 		imgui.BeginChildStrV(d.Name, imgui.Vec2{X: bounds.W, Y: bounds.H}, imgui.ChildFlagsNone, windowFlags)
 
+		// the outer window is only hovered directly over the title bar, handle and padding
+		if d.doubleClickToggle && d.Visible && imgui.IsWindowHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+			d.Visible = false
+		}
+		if d.focusRing && d.Focused && d.Visible {
+			drawFocusRing()
+		}
+
 		if d.CurrentSize == d.TargetSize {
 			if d.Resizable {
 				dhp := d.dragHandlePos(bounds, attachment)
 				imgui.SetCursorPos(dhp)
-				imgui.PushStyleColorVec4(imgui.ColText, imgui.CurrentStyle().Colors()[imgui.ColHeaderActive])
+				imgui.PushStyleColorVec4(imgui.ColText, ThemeColors().Accent)
 				imgui.TextUnformatted(fonts.ICON_DRAG_INDICATOR)
 				imgui.PopStyleColor()
 
@@ -118,22 +148,24 @@ func (d *Dash) DrawDash(state *State, bounds Bounds, attachment DashAttachment)
 			}
 			imgui.PushStyleVarFloat(imgui.StyleVarScrollbarSize, DashScrollbarSize)
 			imgui.BeginChildStrV("##dashSurface", childSize, 0, 0)
+			if d.focusRequest {
+				imgui.SetWindowFocus()
+				d.focusRequest = false
+			}
 			if d.Visible && d.Component != nil {
 				windowPadding := imgui.CurrentStyle().WindowPadding()
 				sfSize = sfSize.Sub(imgui.Vec2{X: windowPadding.X * 2, Y: windowPadding.Y * 2})
 				if d.Resizable {
 					sfSize = sfSize.Sub(imgui.Vec2{X: 0, Y: DashSurfacePadding})
 				}
-
-				// create state for the child component
-				childState := &State{
-					Size:     sfSize,
-					Position: imgui.Vec2{}, // position is relative to the child window
-					IO:       state.IO,
-					App:      state.App,
-					Parent:   d,
+				if len(d.tabs) > 0 {
+					tabsTop := imgui.CursorPosY()
+					d.drawTabs()
+					sfSize.Y -= imgui.CursorPosY() - tabsTop
 				}
-				d.Component.Draw(childState)
+
+				// position is relative to the child window
+				DrawChild(d.Component, state.Child(sfSize, imgui.Vec2{}).WithParent(d))
 			}
 			d.Focused = d.Visible && imgui.IsWindowFocused()
 			imgui.EndChild()
@@ -147,21 +179,57 @@ func (d *Dash) DrawDash(state *State, bounds Bounds, attachment DashAttachment)
 		imgui.PopStyleVar()
 	}
 
+	d.animate(state.Clock())
+}
+
+// SkipAnimationOnce jumps to the target size on the next frame instead of animating, for
+// programmatic layout changes.
+func (d *Dash) SkipAnimationOnce() {
+	d.skipAnim = true
+}
+
+// targetSize returns TargetSize when visible, or zero when hidden.
+func (d *Dash) targetSize() int {
 	if d.Visible {
-		if d.CurrentSize < d.TargetSize {
-			d.CurrentSize += int(d.dashPxPerFrame())
-			if d.CurrentSize > d.TargetSize {
-				d.CurrentSize = d.TargetSize
-			}
-		}
-	} else {
-		if d.CurrentSize > 0 {
-			d.CurrentSize -= int(d.dashPxPerFrame())
-			if d.CurrentSize < 0 {
-				d.CurrentSize = 0
-			}
+		return d.TargetSize
+	}
+	return 0
+}
+
+// settle jumps CurrentSize to the target size on the first frame (unless AnimateOnStart)
+// or after SkipAnimationOnce. DashManager settles its dashes before laying them out.
+func (d *Dash) settle() {
+	if !d.skipAnim && (d.anim != nil || d.AnimateOnStart) {
+		return
+	}
+	d.skipAnim = false
+	if d.anim == nil {
+		d.anim = NewAnimation(0, 0, nil)
+	}
+	d.CurrentSize = d.targetSize()
+	d.anim.Snap(float32(d.CurrentSize))
+	d.animSize = d.CurrentSize
+}
+
+// animate moves CurrentSize toward TargetSize when visible, or toward zero when hidden.
+func (d *Dash) animate(now time.Time) {
+	d.settle()
+	if d.anim == nil || d.CurrentSize != d.animSize {
+		// first frame, or the size was changed by resizing or restoring config
+		if d.anim == nil {
+			d.anim = NewAnimation(0, 0, nil)
 		}
+		d.anim.Snap(float32(d.CurrentSize))
 	}
+	d.anim.Duration = time.Duration(d.TransitionMs) * time.Millisecond
+	d.anim.Easing = d.Easing
+
+	d.anim.SetTargetAt(float32(d.targetSize()), now)
+	d.CurrentSize = int(math.Round(float64(d.anim.ValueAt(now))))
+	if d.CurrentSize < 0 {
+		d.CurrentSize = 0
+	}
+	d.animSize = d.CurrentSize
 }
 
 func (d *Dash) boundsAndSize(bounds Bounds, attachment DashAttachment) imgui.Vec2 {
@@ -209,15 +277,11 @@ func (d *Dash) dragHandlePos(bounds Bounds, attachment DashAttachment) imgui.Vec
 	}
 }
 
-func (d *Dash) dashPxPerFrame() float32 {
-	return pxPerFrame(float32(d.TargetSize), d.TransitionMs)
-}
-
 // Draw implements Component interface - this is for when Dash is used as a standalone component
 func (d *Dash) Draw(state *State) {
 	// when used as a standalone component, we just draw our inner component
 	if d.Visible && d.Component != nil {
-		d.Component.Draw(state)
+		DrawChild(d.Component, state)
 	}
 }
 
@@ -0,0 +1,130 @@
+package dfx
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// default dash manager bindings
+const (
+	DefaultDashFocusNextKeys     = "F6"
+	DefaultDashFocusPreviousKeys = "Shift+F6"
+)
+
+// EnableFocusCycling registers actions that move keyboard focus between the visible
+// dashes and the inner component, in the order top, left, inner, right, bottom. empty
+// keys use DefaultDashFocusNextKeys and DefaultDashFocusPreviousKeys.
+func (d *DashManager) EnableFocusCycling(nextKeys, previousKeys string) error {
+	if nextKeys == "" {
+		nextKeys = DefaultDashFocusNextKeys
+	}
+	if previousKeys == "" {
+		previousKeys = DefaultDashFocusPreviousKeys
+	}
+	actions := d.Container.Actions()
+	if err := actions.Register("Focus Next Panel", nextKeys, func() { d.FocusNext() }); err != nil {
+		return err
+	}
+	return actions.Register("Focus Previous Panel", previousKeys, func() { d.FocusPrevious() })
+}
+
+// BindToggle registers an action that toggles the visibility of the dash at attachment.
+func (d *DashManager) BindToggle(attachment DashAttachment, keys string) error {
+	name := map[DashAttachment]string{LeftDash: "Left", RightDash: "Right", TopDash: "Top", BottomDash: "Bottom"}[attachment]
+	return d.Container.Actions().Register(fmt.Sprintf("Toggle %v Panel", name), keys, func() { d.Toggle(attachment) })
+}
+
+// Toggle flips the visibility of the dash at attachment.
+func (d *DashManager) Toggle(attachment DashAttachment) {
+	if dash := d.dashAt(attachment); dash != nil {
+		dash.Visible = !dash.Visible
+	}
+}
+
+// FocusNext moves keyboard focus to the next visible dash or the inner component.
+func (d *DashManager) FocusNext() bool {
+	return d.cycleFocus(1)
+}
+
+// FocusPrevious moves keyboard focus to the previous visible dash or the inner component.
+func (d *DashManager) FocusPrevious() bool {
+	return d.cycleFocus(-1)
+}
+
+// cycleFocus requests focus for the target dir steps from the focused one. the inner
+// component is represented by a nil dash.
+func (d *DashManager) cycleFocus(dir int) bool {
+	targets := d.focusTargets()
+	if len(targets) == 0 {
+		return false
+	}
+	current := -1
+	for i, target := range targets {
+		if target == d.Focused {
+			current = i
+			break
+		}
+	}
+	if current < 0 && dir < 0 {
+		current = 0
+	}
+	n := len(targets)
+	target := targets[((current+dir)%n+n)%n]
+	if target == nil {
+		d.focusInner = true
+	} else {
+		target.focusRequest = true
+	}
+	return true
+}
+
+// focusTargets lists the focusable panels in cycling order.
+func (d *DashManager) focusTargets() []*Dash {
+	var targets []*Dash
+	for _, dash := range []*Dash{d.Top, d.Left} {
+		if dash != nil && dash.Visible {
+			targets = append(targets, dash)
+		}
+	}
+	if d.Inner != nil {
+		targets = append(targets, nil)
+	}
+	for _, dash := range []*Dash{d.Right, d.Bottom} {
+		if dash != nil && dash.Visible {
+			targets = append(targets, dash)
+		}
+	}
+	return targets
+}
+
+func (d *DashManager) dashAt(attachment DashAttachment) *Dash {
+	switch attachment {
+	case LeftDash:
+		return d.Left
+	case RightDash:
+		return d.Right
+	case TopDash:
+		return d.Top
+	default:
+		return d.Bottom
+	}
+}
+
+// prepareDashes passes the manager's interaction settings to its dashes for this frame,
+// and settles their sizes before they are laid out.
+func (d *DashManager) prepareDashes() {
+	for _, dash := range []*Dash{d.Left, d.Top, d.Right, d.Bottom} {
+		if dash != nil {
+			dash.doubleClickToggle = d.DoubleClickToggle
+			dash.focusRing = d.FocusRing
+			dash.settle()
+		}
+	}
+}
+
+// drawFocusRing outlines the current window in the accent color.
+func drawFocusRing() {
+	topLeft := imgui.WindowPos()
+	drawFocusRect(topLeft, topLeft.Add(imgui.WindowSize()), DashWindowRounding)
+}
@@ -22,6 +22,11 @@ type DashManager struct {
 	Bottom     *Dash
 	Focused    *Dash
 	Inner      Component
+
+	DoubleClickToggle bool // double-clicking a dash's title bar or drag handle toggles it
+	FocusRing         bool // outline the focused dash in the accent color
+
+	focusInner bool // the inner component should take keyboard focus
 }
 
 func NewDashManager() *DashManager {
@@ -32,6 +37,9 @@ func NewDashManager() *DashManager {
 		Precedence: HorizontalPrecedence,
 		TopMargin:  0.0,
 		Margin:     5.0,
+
+		DoubleClickToggle: true,
+		FocusRing:         true,
 	}
 }
 
@@ -41,6 +49,7 @@ func (d *DashManager) Draw(state *State) {
 	}
 
 	size := state.Size
+	d.prepareDashes()
 	d.Focused = nil
 	leftWidth := float32(0)
 	topHeight := float32(0)
@@ -121,16 +130,13 @@ func (d *DashManager) Draw(state *State) {
 		windowFlags := imgui.WindowFlagsNoResize | imgui.WindowFlagsNoMove | imgui.WindowFlagsNoTitleBar | imgui.WindowFlagsNoScrollbar | imgui.WindowFlagsNoScrollWithMouse
 
 		imgui.BeginChildStrV("##dashManagerInner", innerSize, imgui.ChildFlagsNone, windowFlags)
-
-		// create state for the inner component
-		innerState := &State{
-			Size:     innerSize,
-			Position: imgui.Vec2{}, // position is relative to the child window
-			IO:       state.IO,
-			App:      state.App,
-			Parent:   d,
+		if d.focusInner {
+			imgui.SetWindowFocus()
+			d.focusInner = false
 		}
-		d.Inner.Draw(innerState)
+
+		// position is relative to the child window
+		DrawChild(d.Inner, state.Child(innerSize, imgui.Vec2{}).WithParent(d))
 		imgui.EndChild()
 	}
 
@@ -156,10 +162,11 @@ func (d *DashManager) LocalActions() *ActionRegistry {
 	return d.Container.Actions()
 }
 
-// ChildActions returns the active child component for action traversal.
+// ChildActions returns the focused dash (and through it, its component) or the inner
+// component for action traversal.
 func (d *DashManager) ChildActions() []Component {
 	if d.Focused != nil && d.Focused.Component != nil {
-		return []Component{d.Focused.Component}
+		return []Component{d.Focused}
 	}
 	if d.Inner != nil {
 		return []Component{d.Inner}
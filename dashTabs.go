@@ -0,0 +1,237 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// dash tab constants
+const (
+	dashNextTabKeys     = "Ctrl+PageDown"
+	dashPreviousTabKeys = "Ctrl+PageUp"
+)
+
+// dashTab is one named component stacked in a Dash.
+type dashTab struct {
+	Id        string    // stable identifier used in code and config
+	Name      string    // display name (can include icons)
+	Component Component // the component shown when the tab is active
+	Visible   bool      // hidden tabs are left out of the tab strip
+}
+
+// AddTab stacks a named component in the dash, shown as a tab. a component already set on
+// the dash becomes the first tab, using the dash name as its id. the first tab added
+// becomes active; Component always refers to the active tab's component.
+func (d *Dash) AddTab(id, name string, component Component) {
+	if len(d.tabs) == 0 && d.Component != nil {
+		d.tabs = append(d.tabs, &dashTab{Id: d.Name, Name: d.Name, Component: d.Component, Visible: true})
+	}
+	if d.tabIndex(id) >= 0 {
+		return
+	}
+	d.tabs = append(d.tabs, &dashTab{Id: id, Name: name, Component: component, Visible: true})
+	if len(d.tabs) == 1 {
+		d.activeTab = 0
+	}
+	d.syncTab()
+	d.registerTabActions()
+}
+
+// RemoveTab removes a tab. if it was active, the next visible tab becomes active.
+func (d *Dash) RemoveTab(id string) bool {
+	idx := d.tabIndex(id)
+	if idx < 0 {
+		return false
+	}
+	UnmountComponent(d.tabs[idx].Component)
+	d.tabs = append(d.tabs[:idx], d.tabs[idx+1:]...)
+	if idx < d.activeTab || d.activeTab >= len(d.tabs) {
+		d.activeTab--
+	}
+	if d.activeTab < 0 && len(d.tabs) > 0 {
+		d.activeTab = 0
+	}
+	d.ensureVisibleTab()
+	d.syncTab()
+	return true
+}
+
+// TabIds returns the ids of all tabs in order, including hidden ones.
+func (d *Dash) TabIds() []string {
+	ids := make([]string, len(d.tabs))
+	for i, tab := range d.tabs {
+		ids[i] = tab.Id
+	}
+	return ids
+}
+
+// ActiveTab returns the id of the active tab, or "" when the dash has no tabs.
+func (d *Dash) ActiveTab() string {
+	if d.activeTab < 0 || d.activeTab >= len(d.tabs) {
+		return ""
+	}
+	return d.tabs[d.activeTab].Id
+}
+
+// SetActiveTab makes a tab active, showing it if it was hidden.
+func (d *Dash) SetActiveTab(id string) bool {
+	idx := d.tabIndex(id)
+	if idx < 0 {
+		return false
+	}
+	d.tabs[idx].Visible = true
+	d.activeTab = idx
+	d.syncTab()
+	return true
+}
+
+// TabVisible reports whether a tab is shown in the tab strip.
+func (d *Dash) TabVisible(id string) bool {
+	idx := d.tabIndex(id)
+	return idx >= 0 && d.tabs[idx].Visible
+}
+
+// SetTabVisible shows or hides a tab. the last visible tab cannot be hidden; hiding the
+// active tab activates the next visible one.
+func (d *Dash) SetTabVisible(id string, visible bool) bool {
+	idx := d.tabIndex(id)
+	if idx < 0 {
+		return false
+	}
+	if !visible && d.visibleTabCount() <= 1 && d.tabs[idx].Visible {
+		return false
+	}
+	d.tabs[idx].Visible = visible
+	if idx == d.activeTab && !visible {
+		d.cycleTab(1)
+	}
+	return true
+}
+
+// NextTab activates the following visible tab, wrapping around.
+func (d *Dash) NextTab() bool {
+	return d.cycleTab(1)
+}
+
+// PreviousTab activates the preceding visible tab, wrapping around.
+func (d *Dash) PreviousTab() bool {
+	return d.cycleTab(-1)
+}
+
+// cycleTab moves the active tab by dir, skipping hidden tabs.
+func (d *Dash) cycleTab(dir int) bool {
+	n := len(d.tabs)
+	for step := 1; step < n; step++ {
+		idx := ((d.activeTab+dir*step)%n + n) % n
+		if d.tabs[idx].Visible {
+			d.activeTab = idx
+			d.syncTab()
+			return true
+		}
+	}
+	return false
+}
+
+// registerTabActions adds tab cycling actions once the dash has more than one tab.
+func (d *Dash) registerTabActions() {
+	if d.tabActions || len(d.tabs) < 2 {
+		return
+	}
+	d.tabActions = true
+	actions := d.Container.Actions()
+	actions.MustRegister("Next Tab", dashNextTabKeys, func() { d.NextTab() })
+	actions.MustRegister("Previous Tab", dashPreviousTabKeys, func() { d.PreviousTab() })
+}
+
+// syncTab points Component at the active tab and asks the tab strip to select it.
+func (d *Dash) syncTab() {
+	if d.activeTab >= 0 && d.activeTab < len(d.tabs) {
+		d.Component = d.tabs[d.activeTab].Component
+	} else if len(d.tabs) == 0 {
+		d.Component = nil
+	}
+	d.tabSync = true
+}
+
+// ensureVisibleTab keeps at least one tab visible and the active tab among them.
+func (d *Dash) ensureVisibleTab() {
+	if len(d.tabs) == 0 {
+		return
+	}
+	if d.visibleTabCount() == 0 {
+		d.tabs[d.activeTab].Visible = true
+	}
+	if !d.tabs[d.activeTab].Visible {
+		d.cycleTab(1)
+	}
+}
+
+func (d *Dash) visibleTabCount() int {
+	count := 0
+	for _, tab := range d.tabs {
+		if tab.Visible {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *Dash) tabIndex(id string) int {
+	for i, tab := range d.tabs {
+		if tab.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawTabs renders the tab strip. right-clicking a tab opens a menu for toggling tab
+// visibility.
+func (d *Dash) drawTabs() {
+	flags := imgui.TabBarFlagsFittingPolicyScroll | imgui.TabBarFlagsNoCloseWithMiddleMouseButton
+	if !imgui.BeginTabBarV("##dashTabs", flags) {
+		return
+	}
+
+	sync := d.tabSync
+	d.tabSync = false
+	selected := -1
+	openMenu := false
+	for i, tab := range d.tabs {
+		if !tab.Visible {
+			continue
+		}
+		var itemFlags imgui.TabItemFlags
+		if sync && i == d.activeTab {
+			itemFlags |= imgui.TabItemFlagsSetSelected
+		}
+		// the ### suffix keeps the tab identity stable when the name changes
+		if imgui.BeginTabItemV(tab.Name+"###"+tab.Id, nil, itemFlags) {
+			selected = i
+			imgui.EndTabItem()
+		}
+		if imgui.IsItemClickedV(imgui.MouseButtonRight) {
+			openMenu = true
+		}
+	}
+	imgui.EndTabBar()
+
+	// while a programmatic switch is being applied, imgui still reports the old tab
+	if !sync && selected >= 0 && selected != d.activeTab {
+		d.activeTab = selected
+		d.syncTab()
+		d.tabSync = false
+	}
+
+	if openMenu {
+		imgui.OpenPopupStr("##dashTabMenu")
+	}
+	if imgui.BeginPopup("##dashTabMenu") {
+		for _, tab := range d.tabs {
+			enabled := !tab.Visible || d.visibleTabCount() > 1
+			if imgui.MenuItemBoolV(tab.Name+"###"+tab.Id, "", tab.Visible, enabled) {
+				d.SetTabVisible(tab.Id, !tab.Visible)
+			}
+		}
+		imgui.EndPopup()
+	}
+}
@@ -0,0 +1,193 @@
+package dfx
+
+import (
+	"testing"
+	"time"
+)
+
+func newTabbedDash() *Dash {
+	d := NewDash("tools", nil)
+	d.AddTab("output", "Output", &Container{Visible: true})
+	d.AddTab("problems", "Problems", &Container{Visible: true})
+	d.AddTab("terminal", "Terminal", &Container{Visible: true})
+	return d
+}
+
+func TestDash_TabsCycleSkippingHidden(t *testing.T) {
+	d := newTabbedDash()
+	if d.ActiveTab() != "output" || d.Component != d.tabs[0].Component {
+		t.Fatalf("expected first tab active, got '%v'", d.ActiveTab())
+	}
+
+	d.SetTabVisible("problems", false)
+	d.NextTab()
+	if d.ActiveTab() != "terminal" {
+		t.Fatalf("expected hidden tab to be skipped, got '%v'", d.ActiveTab())
+	}
+	d.NextTab()
+	if d.ActiveTab() != "output" {
+		t.Fatalf("expected cycling to wrap, got '%v'", d.ActiveTab())
+	}
+	d.PreviousTab()
+	if d.ActiveTab() != "terminal" || d.Component != d.tabs[2].Component {
+		t.Fatalf("expected 'terminal', got '%v'", d.ActiveTab())
+	}
+}
+
+func TestDash_LastVisibleTabCannotBeHidden(t *testing.T) {
+	d := newTabbedDash()
+	d.SetTabVisible("output", false)
+	d.SetTabVisible("problems", false)
+	if d.SetTabVisible("terminal", false) {
+		t.Fatalf("expected hiding the last visible tab to fail")
+	}
+	if d.ActiveTab() != "terminal" {
+		t.Fatalf("expected active tab to move to the visible tab, got '%v'", d.ActiveTab())
+	}
+}
+
+func TestDash_ExistingComponentBecomesFirstTab(t *testing.T) {
+	original := &Container{Visible: true}
+	d := NewDash("Files", original)
+	d.AddTab("search", "Search", &Container{Visible: true})
+
+	if ids := d.TabIds(); len(ids) != 2 || ids[0] != "Files" {
+		t.Fatalf("expected existing component as first tab, got '%v'", ids)
+	}
+	if d.Component != original {
+		t.Fatalf("expected existing component to stay active")
+	}
+}
+
+func TestDash_RemoveActiveTab(t *testing.T) {
+	d := newTabbedDash()
+	d.SetActiveTab("terminal")
+	d.RemoveTab("terminal")
+	if d.ActiveTab() != "problems" {
+		t.Fatalf("expected 'problems', got '%v'", d.ActiveTab())
+	}
+	d.RemoveTab("output")
+	if d.ActiveTab() != "problems" {
+		t.Fatalf("expected 'problems' to stay active, got '%v'", d.ActiveTab())
+	}
+}
+
+func TestDash_TabStateRoundTrip(t *testing.T) {
+	dm := NewDashManager()
+	dm.Bottom = newTabbedDash()
+	dm.Bottom.SetActiveTab("terminal")
+	dm.Bottom.SetTabVisible("output", false)
+
+	config := CaptureDashState(dm)
+
+	restored := NewDashManager()
+	restored.Bottom = newTabbedDash()
+	RestoreDashState(restored, config)
+
+	if restored.Bottom.ActiveTab() != "terminal" {
+		t.Fatalf("expected active tab 'terminal', got '%v'", restored.Bottom.ActiveTab())
+	}
+	if restored.Bottom.TabVisible("output") || !restored.Bottom.TabVisible("problems") {
+		t.Fatalf("expected tab visibility to be restored")
+	}
+}
+
+func TestDashManager_FocusCyclingOrder(t *testing.T) {
+	dm := NewDashManager()
+	dm.Left = NewDash("left", nil)
+	dm.Right = NewDash("right", nil)
+	dm.Bottom = NewDash("bottom", nil)
+	dm.Bottom.Visible = false
+	dm.Inner = &Container{Visible: true}
+
+	// no focused dash means the inner component has focus
+	dm.FocusNext()
+	if !dm.Right.focusRequest {
+		t.Fatalf("expected right dash after inner component")
+	}
+	dm.Right.focusRequest = false
+
+	dm.Focused = dm.Left
+	dm.FocusNext()
+	if !dm.focusInner {
+		t.Fatalf("expected inner component after left dash")
+	}
+	dm.focusInner = false
+
+	// hidden dashes are skipped when wrapping backwards
+	dm.Focused = dm.Left
+	dm.FocusPrevious()
+	if !dm.Right.focusRequest || dm.Bottom.focusRequest {
+		t.Fatalf("expected right dash before left when wrapping, skipping hidden bottom")
+	}
+}
+
+func TestDashManager_BindToggle(t *testing.T) {
+	dm := NewDashManager()
+	dm.Left = NewDash("left", nil)
+	if err := dm.BindToggle(LeftDash, "Ctrl+B"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dm.EnableFocusCycling("", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dm.BindToggle(RightDash, "F6"); err == nil {
+		t.Fatalf("expected conflict with focus cycling binding")
+	}
+
+	dm.Toggle(LeftDash)
+	if dm.Left.Visible {
+		t.Fatalf("expected left dash to be hidden")
+	}
+}
+
+func TestDash_StartsAtTargetSize(t *testing.T) {
+	frame := time.Unix(1000, 0)
+	d := NewDash("left", nil)
+	d.TargetSize = 300
+	d.settle()
+	if d.CurrentSize != 300 {
+		t.Fatalf("expected first frame at '300', got '%v'", d.CurrentSize)
+	}
+
+	hidden := NewDash("right", nil)
+	hidden.Visible = false
+	hidden.animate(frame)
+	if hidden.CurrentSize != 0 {
+		t.Fatalf("expected hidden dash at '0', got '%v'", hidden.CurrentSize)
+	}
+
+	animated := NewDash("top", nil)
+	animated.TargetSize = 300
+	animated.AnimateOnStart = true
+	animated.animate(frame)
+	if animated.CurrentSize != DefaultDashSize {
+		t.Fatalf("expected animation from '%v', got '%v'", DefaultDashSize, animated.CurrentSize)
+	}
+}
+
+func TestDash_SkipAnimationOnce(t *testing.T) {
+	frame := time.Unix(1000, 0)
+	d := NewDash("left", nil)
+	d.TransitionMs = 100
+	d.animate(frame)
+
+	d.Visible = false
+	d.animate(frame)
+	d.animate(frame.Add(50 * time.Millisecond))
+	if d.CurrentSize == 0 || d.CurrentSize == d.TargetSize {
+		t.Fatalf("expected hide to animate, got '%v'", d.CurrentSize)
+	}
+
+	d.Visible = true
+	d.SkipAnimationOnce()
+	d.animate(frame.Add(60 * time.Millisecond))
+	if d.CurrentSize != d.TargetSize {
+		t.Fatalf("expected jump to '%v', got '%v'", d.TargetSize, d.CurrentSize)
+	}
+	d.Visible = false
+	d.animate(frame.Add(70 * time.Millisecond))
+	if d.CurrentSize != d.TargetSize {
+		t.Fatalf("expected skip to apply once, got '%v'", d.CurrentSize)
+	}
+}
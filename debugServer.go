@@ -0,0 +1,363 @@
+package dfx
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// DebugServer constants
+const (
+	DebugServerTimeout = 2 * time.Second // how long a request waits for the UI thread to answer
+	DebugLogTail       = 200             // log messages served when the request does not say
+	debugFrameWindow   = 120             // frames the frame time statistics cover
+	debugTreeMaxDepth  = 64              // guards against component cycles
+)
+
+// DebugServer serves diagnostics about a running app over HTTP as JSON, for inspecting
+// apps on machines without a developer at the screen. it is opt-in, via
+// App.EnableDebugServer, and listens on the loopback interface unless given another
+// host. anyone who can reach it can read the app's state, so set a token (see SetToken)
+// before listening anywhere else.
+//
+//	/tree     the component tree, with visibility, actions and captured state
+//	/actions  global and component actions with their key bindings
+//	/config   the value returned by Config (the window state when Config is nil); return
+//	          only what may be shown to whoever can reach the server
+//	/log      the last messages of Log (?n= sets how many)
+//	/perf     frame rate, frame times, memory and goroutine counters
+//
+// the answers are built on the UI thread between frames, so the fields may be set from
+// the UI thread (e.g. in Config.OnSetup) at any time.
+type DebugServer struct {
+	Config func() any // value served as /config
+	Log    *LogBuffer // buffer served as /log
+
+	app      *App
+	listener net.Listener
+	server   *http.Server
+	requests chan debugRequest
+	token    atomic.Pointer[string]
+}
+
+// debugRequest is a request waiting for the UI thread.
+type debugRequest struct {
+	build func() any
+	reply chan any
+}
+
+// debugFrames records the frame times of the last frames for /perf.
+type debugFrames struct {
+	times [debugFrameWindow]time.Duration
+}
+
+// debugNode describes a component in /tree.
+type debugNode struct {
+	Type     string         `json:"type"`
+	Visible  *bool          `json:"visible,omitempty"`
+	Actions  []string       `json:"actions,omitempty"`
+	State    map[string]any `json:"state,omitempty"`
+	Children []*debugNode   `json:"children,omitempty"`
+}
+
+// debugAction describes an action in /actions.
+type debugAction struct {
+	Id        string `json:"id"`
+	Label     string `json:"label,omitempty"`
+	Keys      string `json:"keys"`
+	Component string `json:"component,omitempty"` // owning component type (empty = global)
+}
+
+// EnableDebugServer starts a debug server listening on addr (e.g. "localhost:6061", or
+// ":0" for any free port). an addr without a host listens on 127.0.0.1; name the host,
+// such as "0.0.0.0:6061", to listen on other interfaces. call it before Run or from the
+// UI thread; the server is shut down when Run returns. enabling it twice returns the
+// running server.
+func (app *App) EnableDebugServer(addr string) (*DebugServer, error) {
+	if app.debug != nil {
+		return app.debug, nil
+	}
+	addr = debugServerAddr(addr)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listening on '%v'", addr)
+	}
+	ds := &DebugServer{
+		app:      app,
+		listener: listener,
+		requests: make(chan debugRequest, 16),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", ds.index)
+	mux.HandleFunc("/tree", ds.handle(ds.tree))
+	mux.HandleFunc("/actions", ds.handle(ds.actions))
+	mux.HandleFunc("/config", ds.handle(ds.config))
+	mux.HandleFunc("/log", func(w http.ResponseWriter, r *http.Request) {
+		n := DebugLogTail
+		if v, err := strconv.Atoi(r.URL.Query().Get("n")); err == nil && v >= 0 {
+			n = v
+		}
+		ds.handle(func() any { return ds.logTail(n) })(w, r)
+	})
+	mux.HandleFunc("/perf", ds.handle(ds.perf))
+	ds.server = &http.Server{Handler: ds.authorize(mux), ReadHeaderTimeout: DebugServerTimeout}
+	go func() { _ = ds.server.Serve(listener) }()
+
+	app.debug = ds
+	return ds, nil
+}
+
+// debugServerAddr binds addresses without a host, such as ":0", to the loopback interface.
+func debugServerAddr(addr string) string {
+	if addr == "" {
+		return "127.0.0.1:0"
+	}
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return addr
+}
+
+// DebugServer returns the running debug server, or nil.
+func (app *App) DebugServer() *DebugServer {
+	return app.debug
+}
+
+// Addr returns the address the server is listening on.
+func (ds *DebugServer) Addr() net.Addr {
+	return ds.listener.Addr()
+}
+
+// SetToken requires requests to carry token, as "Authorization: Bearer <token>" or the
+// token query parameter; "" (the default) serves everyone who can connect. it may be
+// called from any goroutine.
+func (ds *DebugServer) SetToken(token string) {
+	ds.token.Store(&token)
+}
+
+// authorize refuses requests without the token, when one is set.
+func (ds *DebugServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := ds.token.Load(); token != nil && *token != "" {
+			given := r.URL.Query().Get("token")
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				given = bearer
+			}
+			if subtle.ConstantTimeCompare([]byte(given), []byte(*token)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Close stops the server. call it from the UI thread.
+func (ds *DebugServer) Close() error {
+	if ds.app.debug == ds {
+		ds.app.debug = nil
+	}
+	return errors.Wrap(ds.server.Close(), "error closing debug server")
+}
+
+// debugFrame records the frame time and answers pending debug requests; it runs on the
+// UI thread at the start of every frame, after the frame clock advanced.
+func (app *App) debugFrame() {
+	if app.frameCount > 0 {
+		app.frames.times[(app.frameCount-1)%debugFrameWindow] = app.frameDelta
+	}
+
+	if app.debug == nil {
+		return
+	}
+	for {
+		select {
+		case req := <-app.debug.requests:
+			req.reply <- req.build()
+		default:
+			return
+		}
+	}
+}
+
+// handle answers a request with the JSON encoding of what build returns on the UI thread.
+func (ds *DebugServer) handle(build func() any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := debugRequest{build: build, reply: make(chan any, 1)}
+		timeout := time.After(DebugServerTimeout)
+		select {
+		case ds.requests <- req:
+		case <-timeout:
+			http.Error(w, "ui thread busy", http.StatusServiceUnavailable)
+			return
+		}
+		select {
+		case value := <-req.reply:
+			w.Header().Set("Content-Type", "application/json")
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(value); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		case <-timeout:
+			http.Error(w, "ui thread not responding", http.StatusServiceUnavailable)
+		}
+	}
+}
+
+func (ds *DebugServer) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "dfx debug server")
+	fmt.Fprintln(w)
+	for _, path := range []string{"/tree", "/actions", "/config", "/log?n=200", "/perf"} {
+		fmt.Fprintln(w, path)
+	}
+}
+
+func (ds *DebugServer) tree() any {
+	tree := map[string]any{"root": debugTree(ds.app.root, 0)}
+	if ds.app.config.MenuBar != nil {
+		tree["menuBar"] = debugTree(ds.app.config.MenuBar, 0)
+	}
+	return tree
+}
+
+// debugTree describes comp and its action-traversable children.
+func debugTree(comp Component, depth int) *debugNode {
+	if comp == nil || depth > debugTreeMaxDepth {
+		return nil
+	}
+	node := &debugNode{Type: fmt.Sprintf("%T", comp), Visible: debugVisible(comp)}
+	if actions := componentActions(comp); actions != nil {
+		for _, action := range actions.actions {
+			node.Actions = append(node.Actions, action.Id)
+		}
+	}
+	if sc, ok := comp.(StatefulComponent); ok {
+		node.State = sc.CaptureState()
+	}
+	if cp, ok := comp.(ChildActionProvider); ok {
+		for _, child := range cp.ChildActions() {
+			if childNode := debugTree(child, depth+1); childNode != nil {
+				node.Children = append(node.Children, childNode)
+			}
+		}
+	}
+	return node
+}
+
+// debugVisible returns the Visible field of a component struct, if it has one.
+func debugVisible(comp Component) *bool {
+	field, ok := visibleField(comp)
+	if !ok {
+		return nil
+	}
+	visible := field.Bool()
+	return &visible
+}
+
+// componentActions returns the registry processEvents checks for comp.
+func componentActions(comp Component) *ActionRegistry {
+	if lp, ok := comp.(LocalActionProvider); ok {
+		return lp.LocalActions()
+	}
+	return comp.Actions()
+}
+
+func (ds *DebugServer) actions() any {
+	var actions []debugAction
+	for _, action := range ds.app.actions.actions {
+		actions = append(actions, debugAction{Id: action.Id, Label: action.Label, Keys: action.Keys})
+	}
+	var walk func(comp Component, depth int)
+	walk = func(comp Component, depth int) {
+		if comp == nil || depth > debugTreeMaxDepth {
+			return
+		}
+		if registry := componentActions(comp); registry != nil && registry != ds.app.actions {
+			for _, action := range registry.actions {
+				actions = append(actions, debugAction{Id: action.Id, Label: action.Label, Keys: action.Keys, Component: fmt.Sprintf("%T", comp)})
+			}
+		}
+		if cp, ok := comp.(ChildActionProvider); ok {
+			for _, child := range cp.ChildActions() {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(ds.app.root, 0)
+	return actions
+}
+
+func (ds *DebugServer) config() any {
+	if ds.Config != nil {
+		return ds.Config()
+	}
+	return map[string]any{"window": CaptureWindowState(ds.app)}
+}
+
+func (ds *DebugServer) logTail(n int) any {
+	if ds.Log == nil {
+		return []string{}
+	}
+	messages := ds.Log.Tail(n)
+	lines := make([]string, len(messages))
+	for i := range messages {
+		lines[i] = formatLogMessage(&messages[i])
+	}
+	return lines
+}
+
+func (ds *DebugServer) perf() any {
+	frames := &ds.app.frames
+	n := min(ds.app.frameCount, debugFrameWindow)
+	var total, worst time.Duration
+	for i := uint64(0); i < n; i++ {
+		total += frames.times[i]
+		worst = max(worst, frames.times[i])
+	}
+	var average time.Duration
+	if n > 0 {
+		average = total / time.Duration(n)
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	perf := map[string]any{
+		"uptimeSeconds":  time.Since(ds.app.startTime).Seconds(),
+		"frames":         ds.app.frameCount,
+		"frameTimeMs":    durationMs(ds.app.frameDelta),
+		"frameTimeAvgMs": durationMs(average),
+		"frameTimeMaxMs": durationMs(worst),
+		"goroutines":     runtime.NumGoroutine(),
+		"heapAllocBytes": mem.HeapAlloc,
+		"heapObjects":    mem.HeapObjects,
+		"gcCycles":       mem.NumGC,
+		"activeTweens":   ds.app.tweens.Active(),
+		"loadedTextures": ds.app.textures.Count(),
+		"gcPauseTotalMs": durationMs(time.Duration(mem.PauseTotalNs)),
+		"frameWindow":    n,
+	}
+	if ctx := imgui.CurrentContext(); ctx != nil && ctx.CData != nil {
+		perf["fps"] = imgui.CurrentIO().Framerate()
+	}
+	return perf
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
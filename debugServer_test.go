@@ -0,0 +1,205 @@
+package dfx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startDebugServer enables the debug server on a free port and answers its requests
+// from a stand-in UI loop until the test ends.
+func startDebugServer(t *testing.T, app *App) *DebugServer {
+	t.Helper()
+	ds, err := app.EnableDebugServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+				app.advanceFrame(time.Now())
+				app.debugFrame()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+		_ = ds.Close()
+	})
+	return ds
+}
+
+func getDebugJSON(t *testing.T, ds *DebugServer, path string, v any) {
+	t.Helper()
+	resp, err := http.Get(fmt.Sprintf("http://%v%v", ds.Addr(), path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for '%v', got %v: %s", path, resp.Status, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("invalid json for '%v': %v", path, err)
+	}
+}
+
+func TestDebugServerTreeAndActions(t *testing.T) {
+	child := &Container{Visible: false}
+	child.Actions().MustRegister("child", "Ctrl+K", func() {})
+	root := &Container{Visible: true, Children: []Component{child, NewSizeDebugger()}}
+	app := New(root, Config{})
+	app.Actions().MustRegister("quit", "Ctrl+Q", func() {})
+	ds := startDebugServer(t, app)
+
+	var tree map[string]*debugNode
+	getDebugJSON(t, ds, "/tree", &tree)
+	node := tree["root"]
+	if node == nil || node.Type != "*dfx.Container" || node.Visible == nil || !*node.Visible {
+		t.Fatalf("unexpected root node %+v", node)
+	}
+	if len(node.Children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(node.Children))
+	}
+	if c := node.Children[0]; c.Visible == nil || *c.Visible || len(c.Actions) != 1 || c.Actions[0] != "child" {
+		t.Fatalf("unexpected child node %+v", c)
+	}
+	if c := node.Children[1]; c.Type != "*dfx.SizeDebugger" || c.Visible != nil {
+		t.Fatalf("unexpected debugger node %+v", c)
+	}
+
+	var actions []debugAction
+	getDebugJSON(t, ds, "/actions", &actions)
+	found := map[string]debugAction{}
+	for _, action := range actions {
+		found[action.Id] = action
+	}
+	if a := found["quit"]; a.Keys != "Ctrl+Q" || a.Component != "" {
+		t.Fatalf("unexpected global action %+v", a)
+	}
+	if a := found["child"]; a.Keys != "Ctrl+K" || a.Component != "*dfx.Container" {
+		t.Fatalf("unexpected component action %+v", a)
+	}
+	if a := found["disable"]; a.Component != "*dfx.SizeDebugger" {
+		t.Fatalf("unexpected debugger action %+v", a)
+	}
+}
+
+func TestDebugServerConfigLogAndPerf(t *testing.T) {
+	app := New(nil, Config{})
+	ds := startDebugServer(t, app)
+	buffer := NewLogBuffer(10)
+	for i := range 5 {
+		buffer.Add(LogMessage{Level: slog.LevelInfo, Message: fmt.Sprintf("message %d", i)})
+	}
+	app.debugFrameSync(func() {
+		ds.Config = func() any { return map[string]int{"volume": 7} }
+		ds.Log = buffer
+	})
+
+	var config map[string]int
+	getDebugJSON(t, ds, "/config", &config)
+	if config["volume"] != 7 {
+		t.Fatalf("unexpected config %v", config)
+	}
+
+	var lines []string
+	getDebugJSON(t, ds, "/log?n=2", &lines)
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "message 3") || !strings.HasSuffix(lines[1], "message 4") {
+		t.Fatalf("unexpected log tail %v", lines)
+	}
+
+	var perf map[string]any
+	getDebugJSON(t, ds, "/perf", &perf)
+	if frames, _ := perf["frames"].(float64); frames < 1 {
+		t.Fatalf("expected frames to be counted, got %v", perf["frames"])
+	}
+	if _, found := perf["heapAllocBytes"]; !found {
+		t.Fatalf("expected memory counters, got %v", perf)
+	}
+}
+
+func TestDebugServerTimesOutWithoutUIThread(t *testing.T) {
+	app := New(nil, Config{})
+	ds, err := app.EnableDebugServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+	if again, _ := app.EnableDebugServer("127.0.0.1:0"); again != ds {
+		t.Fatal("expected enabling twice to return the running server")
+	}
+	resp, err := http.Get(fmt.Sprintf("http://%v/perf", ds.Addr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %v", resp.Status)
+	}
+}
+
+func TestDebugServerListensOnLoopbackByDefault(t *testing.T) {
+	for addr, expected := range map[string]string{
+		"":             "127.0.0.1:0",
+		":0":           "127.0.0.1:0",
+		":6061":        "127.0.0.1:6061",
+		"0.0.0.0:6061": "0.0.0.0:6061",
+		"[::1]:0":      "[::1]:0",
+	} {
+		if got := debugServerAddr(addr); got != expected {
+			t.Fatalf("expected '%v' for '%v', got '%v'", expected, addr, got)
+		}
+	}
+}
+
+func TestDebugServerToken(t *testing.T) {
+	app := New(nil, Config{})
+	ds := startDebugServer(t, app)
+	ds.SetToken("secret")
+
+	status := func(path, authorization string) int {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%v%v", ds.Addr(), path), nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if got := status("/config", ""); got != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the token, got %v", got)
+	}
+	if got := status("/config", "Bearer wrong"); got != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with the wrong token, got %v", got)
+	}
+	if got := status("/config", "Bearer secret"); got != http.StatusOK {
+		t.Fatalf("expected 200 with the bearer token, got %v", got)
+	}
+	if got := status("/perf?token=secret", ""); got != http.StatusOK {
+		t.Fatalf("expected 200 with the token parameter, got %v", got)
+	}
+}
+
+// debugFrameSync runs f on the stand-in UI loop, through the debug request queue.
+func (app *App) debugFrameSync(f func()) {
+	req := debugRequest{build: func() any { f(); return nil }, reply: make(chan any, 1)}
+	app.debug.requests <- req
+	<-req.reply
+}
@@ -0,0 +1,264 @@
+// Package dfxbench measures the per-frame cost of dfx components, to keep performance
+// from regressing:
+//
+//	func TestLogViewerBudget(t *testing.T) {
+//		buffer := dfx.NewLogBuffer(100_000)
+//		for i := 0; i < 100_000; i++ {
+//			buffer.Add(message(i))
+//		}
+//		stats := dfxbench.Measure(dfx.NewLogViewer(buffer), dfxbench.DefaultParams())
+//		dfxbench.AssertBudget(t, stats, dfxbench.Budget{FrameTime: 4 * time.Millisecond, DrawCalls: 50})
+//	}
+//
+// components are drawn headless, in a dfxtest.Session. frame times are CPU times of
+// drawing the frame and building its draw data, without rendering it.
+package dfxbench
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+	"github.com/michaelquigley/dfx/dfxtest"
+)
+
+// measurement defaults
+const (
+	DefaultFrames = 120
+	DefaultWarmup = 5
+)
+
+// Workload changes the component before a frame is drawn, such as feeding it data. frame
+// counts the frames drawn, including the warmup.
+type Workload func(frame int, state *dfx.State)
+
+// Params configures Measure.
+type Params struct {
+	Frames   int        // frames measured (0 = DefaultFrames)
+	Warmup   int        // frames drawn before measuring, so layouts settle and glyphs are cached (0 = DefaultWarmup)
+	Size     imgui.Vec2 // root window size (zero = 1280x800)
+	Workload Workload   // optional work done before each frame; its time and allocations are not counted
+}
+
+// DefaultParams returns the parameters Measure uses when none are given.
+func DefaultParams() Params {
+	return Params{Frames: DefaultFrames, Warmup: DefaultWarmup, Size: imgui.Vec2{X: 1280, Y: 800}}
+}
+
+// Stats is the cost of the frames measured.
+type Stats struct {
+	Frames    int
+	Mean      time.Duration // CPU time per frame
+	Median    time.Duration //
+	P95       time.Duration //
+	Max       time.Duration //
+	DrawCalls int           // most draw commands in a frame
+	Vertices  int           // most vertices in a frame
+	Indices   int           // most indices in a frame
+	Allocs    float64       // heap allocations per frame
+	Bytes     float64       // heap bytes allocated per frame
+}
+
+// String summarizes the stats on a line.
+func (s Stats) String() string {
+	return fmt.Sprintf("%d frames: mean %v, median %v, p95 %v, max %v; %d draw calls, %d vertices, %d indices; %.1f allocs (%.0f B) per frame",
+		s.Frames, s.Mean, s.Median, s.P95, s.Max, s.DrawCalls, s.Vertices, s.Indices, s.Allocs, s.Bytes)
+}
+
+// Measure draws c headless for params.Warmup frames, then measures params.Frames frames.
+func Measure(c dfx.Component, params Params) Stats {
+	if params.Frames <= 0 {
+		params.Frames = DefaultFrames
+	}
+	if params.Warmup <= 0 {
+		params.Warmup = DefaultWarmup
+	}
+	if params.Size == (imgui.Vec2{}) {
+		params.Size = DefaultParams().Size
+	}
+	var workload frameCost
+	render := dfxtest.DefaultRenderParams()
+	render.OnFrame = workloadFunc(params.Workload, &workload)
+	s := dfxtest.NewSession(c, params.Size, render)
+	defer s.Close()
+
+	for i := 0; i < params.Warmup; i++ {
+		s.Frame()
+	}
+	var frames []frameCost
+	var before, after runtime.MemStats
+	for i := 0; i < params.Frames; i++ {
+		workload = frameCost{}
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		s.Frame()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		cost := frameCost{
+			time:   elapsed - workload.time,
+			allocs: after.Mallocs - before.Mallocs - workload.allocs,
+			bytes:  after.TotalAlloc - before.TotalAlloc - workload.bytes,
+		}
+		cost.drawCalls, cost.vertices, cost.indices = drawStats(s.DrawData())
+		frames = append(frames, cost)
+	}
+	return summarize(frames)
+}
+
+// workloadFunc wraps workload to add its time and allocations to cost, so they can be
+// left out of the frame's.
+func workloadFunc(workload Workload, cost *frameCost) func(int, *dfx.State) {
+	if workload == nil {
+		return nil
+	}
+	return func(frame int, state *dfx.State) {
+		start := time.Now()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		workload(frame, state)
+		runtime.ReadMemStats(&after)
+		cost.allocs += after.Mallocs - before.Mallocs
+		cost.bytes += after.TotalAlloc - before.TotalAlloc
+		cost.time += time.Since(start) // including reading the stats
+	}
+}
+
+// frameCost is the cost of a frame measured.
+type frameCost struct {
+	time                         time.Duration
+	allocs, bytes                uint64
+	drawCalls, vertices, indices int
+}
+
+// drawStats counts the draw commands, vertices and indices of data.
+func drawStats(data *imgui.DrawData) (drawCalls, vertices, indices int) {
+	for _, list := range data.CommandLists() {
+		drawCalls += len(list.Commands())
+	}
+	return drawCalls, int(data.TotalVtxCount()), int(data.TotalIdxCount())
+}
+
+// summarize computes the stats of frames.
+func summarize(frames []frameCost) Stats {
+	stats := Stats{Frames: len(frames)}
+	if len(frames) == 0 {
+		return stats
+	}
+	times := make([]time.Duration, len(frames))
+	var total time.Duration
+	var allocs, bytes uint64
+	for i, f := range frames {
+		times[i] = f.time
+		total += f.time
+		allocs += f.allocs
+		bytes += f.bytes
+		stats.DrawCalls = max(stats.DrawCalls, f.drawCalls)
+		stats.Vertices = max(stats.Vertices, f.vertices)
+		stats.Indices = max(stats.Indices, f.indices)
+	}
+	slices.Sort(times)
+	n := len(times)
+	stats.Mean = total / time.Duration(n)
+	stats.Median = times[n/2]
+	stats.P95 = times[min((n*95+99)/100, n)-1]
+	stats.Max = times[n-1]
+	stats.Allocs = float64(allocs) / float64(n)
+	stats.Bytes = float64(bytes) / float64(n)
+	return stats
+}
+
+// Budget is the most a component may cost per frame. zero limits are not checked.
+type Budget struct {
+	FrameTime time.Duration // 95th percentile CPU time per frame, scaled by TimeScale
+	DrawCalls int           // draw commands in a frame
+	Vertices  int           // vertices in a frame
+	Allocs    float64       // heap allocations per frame
+}
+
+// TimeScale multiplies Budget.FrameTime, for slow or busy machines such as CI runners and
+// race-detector builds. it is read from DFXBENCH_TIME_SCALE when set.
+var TimeScale = timeScale()
+
+func timeScale() float64 {
+	if scale, err := strconv.ParseFloat(os.Getenv("DFXBENCH_TIME_SCALE"), 64); err == nil && scale > 0 {
+		return scale
+	}
+	return 1
+}
+
+// Exceeded describes each limit of budget the stats exceed.
+func (s Stats) Exceeded(budget Budget) []string {
+	var exceeded []string
+	if limit := time.Duration(float64(budget.FrameTime) * TimeScale); budget.FrameTime > 0 && s.P95 > limit {
+		exceeded = append(exceeded, fmt.Sprintf("p95 frame time %v over %v", s.P95, limit))
+	}
+	if budget.DrawCalls > 0 && s.DrawCalls > budget.DrawCalls {
+		exceeded = append(exceeded, fmt.Sprintf("%d draw calls over %d", s.DrawCalls, budget.DrawCalls))
+	}
+	if budget.Vertices > 0 && s.Vertices > budget.Vertices {
+		exceeded = append(exceeded, fmt.Sprintf("%d vertices over %d", s.Vertices, budget.Vertices))
+	}
+	if budget.Allocs > 0 && s.Allocs > budget.Allocs {
+		exceeded = append(exceeded, fmt.Sprintf("%.1f allocs per frame over %.1f", s.Allocs, budget.Allocs))
+	}
+	return exceeded
+}
+
+// AssertBudget fails t for each limit of budget the stats exceed, logging the stats.
+func AssertBudget(t testing.TB, stats Stats, budget Budget) {
+	t.Helper()
+	t.Log(stats)
+	for _, exceeded := range stats.Exceeded(budget) {
+		t.Errorf("over budget: %v", exceeded)
+	}
+}
+
+// Benchmark draws c headless b.N times, after params.Warmup frames, reporting the draw
+// calls and vertices per frame along with the time and allocations. the workload runs
+// with the timer stopped.
+//
+//	func BenchmarkLogViewer(b *testing.B) {
+//		dfxbench.Benchmark(b, dfx.NewLogViewer(buffer), dfxbench.DefaultParams())
+//	}
+func Benchmark(b *testing.B, c dfx.Component, params Params) {
+	if params.Warmup <= 0 {
+		params.Warmup = DefaultWarmup
+	}
+	if params.Size == (imgui.Vec2{}) {
+		params.Size = DefaultParams().Size
+	}
+	render := dfxtest.DefaultRenderParams()
+	if params.Workload != nil {
+		render.OnFrame = func(frame int, state *dfx.State) {
+			b.StopTimer()
+			params.Workload(frame, state)
+			b.StartTimer()
+		}
+	}
+	s := dfxtest.NewSession(c, params.Size, render)
+	defer s.Close()
+	for i := 0; i < params.Warmup; i++ {
+		s.Frame()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var drawCalls, vertices int
+	for i := 0; i < b.N; i++ {
+		s.Frame()
+		b.StopTimer()
+		calls, verts, _ := drawStats(s.DrawData())
+		drawCalls += calls
+		vertices += verts
+		b.StartTimer()
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(drawCalls)/float64(b.N), "draws/frame")
+	b.ReportMetric(float64(vertices)/float64(b.N), "vertices/frame")
+}
@@ -0,0 +1,165 @@
+package dfxbench
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+)
+
+func TestSummarize(t *testing.T) {
+	var frames []frameCost
+	for i := 1; i <= 20; i++ {
+		frames = append(frames, frameCost{time: time.Duration(i) * time.Millisecond, allocs: 10, bytes: 100, drawCalls: i, vertices: 4 * i, indices: 6 * i})
+	}
+	stats := summarize(frames)
+	if stats.Frames != 20 {
+		t.Fatalf("expected '%v', got '%v'", 20, stats.Frames)
+	}
+	if stats.Mean != 10500*time.Microsecond {
+		t.Fatalf("expected '%v', got '%v'", 10500*time.Microsecond, stats.Mean)
+	}
+	if stats.Median != 11*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 11*time.Millisecond, stats.Median)
+	}
+	if stats.P95 != 19*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 19*time.Millisecond, stats.P95)
+	}
+	if stats.Max != 20*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 20*time.Millisecond, stats.Max)
+	}
+	if stats.DrawCalls != 20 {
+		t.Fatalf("expected '%v', got '%v'", 20, stats.DrawCalls)
+	}
+	if stats.Vertices != 80 {
+		t.Fatalf("expected '%v', got '%v'", 80, stats.Vertices)
+	}
+	if stats.Indices != 120 {
+		t.Fatalf("expected '%v', got '%v'", 120, stats.Indices)
+	}
+	if stats.Allocs != 10.0 {
+		t.Fatalf("expected '%v', got '%v'", 10.0, stats.Allocs)
+	}
+	if stats.Bytes != 100.0 {
+		t.Fatalf("expected '%v', got '%v'", 100.0, stats.Bytes)
+	}
+
+	if got := summarize(nil); got != (Stats{}) {
+		t.Fatalf("expected '%v', got '%v'", Stats{}, got)
+	}
+}
+
+func TestStats_Exceeded(t *testing.T) {
+	stats := Stats{P95: 3 * time.Millisecond, DrawCalls: 40, Vertices: 1000, Allocs: 12}
+	if got := stats.Exceeded(Budget{}); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+	if got := stats.Exceeded(Budget{FrameTime: 4 * time.Millisecond, DrawCalls: 40, Vertices: 1000, Allocs: 12}); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+	if len(stats.Exceeded(Budget{FrameTime: 2 * time.Millisecond, DrawCalls: 39, Vertices: 999, Allocs: 11})) != 4 {
+		t.Fatalf("expected 4, got %d", len(stats.Exceeded(Budget{FrameTime: 2 * time.Millisecond, DrawCalls: 39, Vertices: 999, Allocs: 11})))
+	}
+
+	defer func(scale float64) { TimeScale = scale }(TimeScale)
+	TimeScale = 2
+	if got := stats.Exceeded(Budget{FrameTime: 2 * time.Millisecond}); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
+
+func testLogBuffer(n int) *dfx.LogBuffer {
+	buffer := dfx.NewLogBuffer(n)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		buffer.Add(dfx.LogMessage{Time: start.Add(time.Duration(i) * time.Millisecond), Level: slog.LevelInfo, Message: fmt.Sprintf("message %d", i)})
+	}
+	return buffer
+}
+
+func TestMeasure_LogViewer(t *testing.T) {
+	buffer := testLogBuffer(100_000)
+	added := 0
+	params := DefaultParams()
+	params.Frames = 30
+	params.Workload = func(frame int, state *dfx.State) {
+		buffer.Add(dfx.LogMessage{Level: slog.LevelWarn, Message: "more"})
+		added++
+	}
+	stats := Measure(dfx.NewLogViewer(buffer), params)
+	if stats.Frames != 30 {
+		t.Fatalf("expected '%v', got '%v'", 30, stats.Frames)
+	}
+	if added != 30+DefaultWarmup {
+		t.Fatalf("expected '%v', got '%v'", 30+DefaultWarmup, added)
+	}
+	if stats.DrawCalls <= 0 {
+		t.Fatalf("expected '%v' > '%v'", stats.DrawCalls, 0)
+	}
+	if stats.Vertices <= 0 {
+		t.Fatalf("expected '%v' > '%v'", stats.Vertices, 0)
+	}
+
+	// generous, so only a regression in kind (such as drawing every message) fails
+	AssertBudget(t, stats, Budget{FrameTime: 50 * time.Millisecond, DrawCalls: 200, Vertices: 200_000})
+}
+
+func BenchmarkLogViewer(b *testing.B) {
+	Benchmark(b, dfx.NewLogViewer(testLogBuffer(100_000)), DefaultParams())
+}
+
+func testMixer(strips int) *dfx.MixerView {
+	var channels []*dfx.ChannelStrip
+	for i := 0; i < strips; i++ {
+		strip := dfx.NewChannelStrip(fmt.Sprintf("ch %d", i+1))
+		strip.SetLevel(0.5)
+		channels = append(channels, strip)
+	}
+	return dfx.NewMixerView(channels, dfx.NewChannelStrip("master"))
+}
+
+func TestMeasure_MixerView(t *testing.T) {
+	params := DefaultParams()
+	params.Frames = 30
+	stats := Measure(testMixer(16), params)
+
+	// most allocations left are made by the imgui bindings; generous, so only a regression
+	// in kind (such as formatting every label every frame) fails
+	AssertBudget(t, stats, Budget{FrameTime: 50 * time.Millisecond, Allocs: 1500})
+}
+
+func BenchmarkMixerView(b *testing.B) {
+	Benchmark(b, testMixer(16), DefaultParams())
+}
+
+func BenchmarkVUMeter(b *testing.B) {
+	meter := dfx.NewVUMeter(32)
+	params := DefaultParams()
+	params.Workload = func(frame int, state *dfx.State) {
+		for ch := 0; ch < 32; ch++ {
+			meter.SetLevel(ch, float32((frame+ch)%100)/100)
+		}
+	}
+	Benchmark(b, meter, params)
+}
+
+func BenchmarkKnobs(b *testing.B) {
+	values := make([]float32, 32)
+	labels := make([]string, len(values))
+	for i := range labels {
+		values[i] = float32(i) / float32(len(values))
+		labels[i] = fmt.Sprintf("##knob%d", i)
+	}
+	knobs := dfx.NewFunc(func(state *dfx.State) {
+		for i := range values {
+			if i%8 > 0 {
+				imgui.SameLine()
+			}
+			values[i], _ = dfx.Knob(labels[i], values[i], 0, 1, dfx.DefaultKnobParams())
+		}
+	})
+	Benchmark(b, knobs, DefaultParams())
+}
@@ -0,0 +1,126 @@
+package dfxtest
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// GoldenDir is where CompareGolden keeps golden images, relative to the package under
+// test.
+var GoldenDir = filepath.Join("testdata", "golden")
+
+// ChannelTolerance is how far a color channel may differ (0-255) before CompareGolden
+// counts the pixel as changed, allowing for rounding differences between platforms.
+var ChannelTolerance uint8 = 2
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite the golden images of CompareGolden")
+
+// CompareGolden fails t when img differs from the golden image GoldenDir/name.png in more
+// than tolerance of its pixels (0-1, 0 = none). when it fails, the image and a diff, with
+// the changed pixels in red, are written to the directory named by DFXTEST_OUTPUT, or a
+// dfxtest directory in the system temp directory, and their paths logged.
+//
+// run the tests with -update-golden to write img as the golden image instead, after
+// checking the change is wanted.
+func CompareGolden(t testing.TB, name string, img image.Image, tolerance float64) {
+	t.Helper()
+	path := filepath.Join(GoldenDir, name+".png")
+	if *updateGolden {
+		if err := writePNG(path, img); err != nil {
+			t.Fatalf("error writing golden image '%v': %v", path, err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden image '%v' (run with -update-golden to create it): %v", path, err)
+	}
+	golden, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("error decoding golden image '%v': %v", path, err)
+	}
+	if golden.Bounds().Size() != img.Bounds().Size() {
+		t.Errorf("image '%v' is %v, the golden image is %v", name, img.Bounds().Size(), golden.Bounds().Size())
+		writeFailure(t, name, img, nil)
+		return
+	}
+	changed, diff := Diff(golden, img, ChannelTolerance)
+	total := img.Bounds().Dx() * img.Bounds().Dy()
+	if float64(changed) > tolerance*float64(total) {
+		t.Errorf("image '%v' differs from the golden image in %v of %v pixels", name, changed, total)
+		writeFailure(t, name, img, diff)
+	}
+}
+
+// Diff compares two images of the same size. it returns the number of pixels with a
+// channel differing by more than threshold, and an image of b dimmed with those pixels in
+// red.
+func Diff(a, b image.Image, threshold uint8) (int, *image.RGBA) {
+	bounds := b.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	offset := a.Bounds().Min.Sub(bounds.Min)
+	changed := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			ca := color.RGBAModel.Convert(a.At(x+offset.X, y+offset.Y)).(color.RGBA)
+			cb := color.RGBAModel.Convert(b.At(x, y)).(color.RGBA)
+			out := color.RGBA{R: cb.R / 4, G: cb.G / 4, B: cb.B / 4, A: 255}
+			if channelDiff(ca.R, cb.R) > threshold || channelDiff(ca.G, cb.G) > threshold ||
+				channelDiff(ca.B, cb.B) > threshold || channelDiff(ca.A, cb.A) > threshold {
+				out = color.RGBA{R: 255, A: 255}
+				changed++
+			}
+			diff.SetRGBA(x-bounds.Min.X, y-bounds.Min.Y, out)
+		}
+	}
+	return changed, diff
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// writeFailure saves the image of a failed comparison, and its diff when there is one.
+func writeFailure(t testing.TB, name string, img image.Image, diff image.Image) {
+	t.Helper()
+	dir := filepath.Join(os.TempDir(), "dfxtest")
+	if out := os.Getenv("DFXTEST_OUTPUT"); out != "" {
+		dir = out
+	}
+	actual := filepath.Join(dir, name+".actual.png")
+	if err := writePNG(actual, img); err != nil {
+		t.Logf("error writing '%v': %v", actual, err)
+		return
+	}
+	t.Logf("rendered image written to '%v'", actual)
+	if diff != nil {
+		path := filepath.Join(dir, name+".diff.png")
+		if err := writePNG(path, diff); err != nil {
+			t.Logf("error writing '%v': %v", path, err)
+			return
+		}
+		t.Logf("diff written to '%v'", path)
+	}
+}
+
+// writePNG saves img to path as a PNG, creating its directory.
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
@@ -0,0 +1,346 @@
+package dfxtest
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+)
+
+func TestDiff(t *testing.T) {
+	a := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	b := image.NewRGBA(image.Rect(10, 10, 12, 12))
+	b.SetRGBA(10, 10, color.RGBA{R: 2})
+	b.SetRGBA(11, 11, color.RGBA{G: 9})
+
+	changed, diff := Diff(a, b, 2)
+	if changed != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, changed)
+	}
+	if got := diff.RGBAAt(1, 1); got != (color.RGBA{R: 255, A: 255}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{R: 255, A: 255}, got)
+	}
+	if got := diff.RGBAAt(0, 0); got != (color.RGBA{A: 255}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{A: 255}, got)
+	}
+
+	changed, _ = Diff(a, b, 0)
+	if changed != 2 {
+		t.Fatalf("expected '%v', got '%v'", 2, changed)
+	}
+}
+
+func TestRenderComponent_IsDeterministic(t *testing.T) {
+	button := dfx.NewFunc(func(state *dfx.State) { imgui.Button("hello") })
+	first := RenderComponent(button, imgui.Vec2{X: 120, Y: 60})
+	second := RenderComponent(button, imgui.Vec2{X: 120, Y: 60})
+	changed, _ := Diff(first, second, 0)
+	if got := changed; got != 0 {
+		t.Fatalf("expected 0, got '%v'", got)
+	}
+	if got := first.Bounds(); got != image.Rect(0, 0, 120, 60) {
+		t.Fatalf("expected '%v', got '%v'", image.Rect(0, 0, 120, 60), got)
+	}
+
+	// the background is the theme's window color, and the button draws over it
+	bg := first.RGBAAt(119, 59)
+	if first.RGBAAt(20, 15) == bg {
+		t.Fatalf("expected a value other than '%v'", bg)
+	}
+}
+
+func TestGolden_Controls(t *testing.T) {
+	controls := dfx.NewFunc(func(state *dfx.State) {
+		imgui.Button("Button")
+		dfx.Checkbox("Checkbox", true)
+		dfx.Knob("##knob", 0.3, 0, 1, dfx.DefaultKnobParams())
+		imgui.SameLine()
+		dfx.FaderF("##fader", 0.7, 0, 1, dfx.DefaultFaderParams())
+	})
+	CompareGolden(t, "controls", RenderComponent(controls, imgui.Vec2{X: 240, Y: 320}), 0.001)
+}
+
+func TestGolden_VUMeter(t *testing.T) {
+	meter := dfx.NewVUMeter(2)
+	meter.SetLevels([]float32{0.4, 0.9})
+	meter.SetLabels([]string{"L", "R"})
+	CompareGolden(t, "vuMeter", RenderComponent(meter, imgui.Vec2{X: 120, Y: 240}), 0.001)
+}
+
+func TestGolden_VUMeterModes(t *testing.T) {
+	for name, mode := range map[string]dfx.VUMeterMode{"vuMeterHighres": dfx.VUMeterHighres, "vuMeterSegmented": dfx.VUMeterSegmented} {
+		meter := dfx.NewVUMeter(3)
+		meter.Mode = mode
+		meter.SetLevels([]float32{0.35, 0.75, 1})
+		CompareGolden(t, name, RenderComponent(meter, imgui.Vec2{X: 120, Y: 240}), 0.001)
+	}
+}
+
+// the level setters are safe from any goroutine; go test -race checks it
+func TestSession_ConcurrentLevelSetters(t *testing.T) {
+	meter := dfx.NewVUMeter(2)
+	waterfall := dfx.NewVUWaterfall(2)
+	waterfall.SampleInterval = 0
+	strip := dfx.NewChannelStrip("ch 1")
+	root := dfx.NewFunc(func(state *dfx.State) {
+		dfx.DrawChild(meter, state)
+		imgui.SameLine()
+		dfx.DrawChild(waterfall, state)
+		imgui.SameLine()
+		dfx.DrawChild(strip, state)
+	})
+
+	s := NewSession(root, imgui.Vec2{X: 400, Y: 320}, DefaultRenderParams())
+	defer s.Close()
+	s.Concurrent(30,
+		func(i int) { meter.SetLevels([]float32{float32(i%10) / 10, 1}) },
+		func(i int) { meter.SetLevel(0, 0.5) },
+		func(i int) {
+			waterfall.SetLevels([]float32{0.5, float32(i%10) / 10})
+			if i%50 == 0 {
+				waterfall.Annotate("mark")
+			}
+		},
+		func(i int) { strip.SetLevel(float32(i%10) / 10) },
+	)
+	if got := s.Frames(); got != 30 {
+		t.Fatalf("expected '%v', got '%v'", 30, got)
+	}
+	if len(waterfall.History()) == 0 {
+		t.Fatal("expected waterfall.History() to be non-empty")
+	}
+}
+
+func TestHCollapse_RightAnchored(t *testing.T) {
+	panel := dfx.NewHCollapse(dfx.NewFunc(func(*dfx.State) {}), dfx.HCollapseConfig{Title: "right", ExpandedWidth: 120, Resizable: true, Expanded: true, Direction: dfx.HCollapseRight})
+	var right, avail float32
+	root := dfx.NewFunc(func(state *dfx.State) {
+		avail = imgui.CursorScreenPos().X + imgui.ContentRegionAvail().X
+		dfx.DrawChild(panel, state)
+		right = imgui.ItemRectMax().X
+	})
+	s := NewSession(root, imgui.Vec2{X: 400, Y: 200}, DefaultRenderParams())
+	defer s.Close()
+	s.Frame()
+	if math.Abs(float64(right)-float64(avail)) > 0.5 {
+		t.Fatalf("the panel ends at the right edge: expected '%v', got '%v'", avail, right)
+	}
+
+	// collapsing keeps the right edge in place
+	panel.Toggle()
+	panel.SkipAnimationOnce()
+	s.Frame()
+	if math.Abs(float64(right)-float64(avail)) > 0.5 {
+		t.Fatalf("expected '%v', got '%v'", avail, right)
+	}
+	if panel.CurrentWidth != panel.MinWidth {
+		t.Fatalf("expected '%v', got '%v'", panel.MinWidth, panel.CurrentWidth)
+	}
+}
+
+func TestState_Gamepad(t *testing.T) {
+	var pad dfx.GamepadState
+	root := dfx.NewFunc(func(state *dfx.State) { pad = state.Gamepad() })
+	params := DefaultRenderParams()
+	params.OnFrame = func(frame int, state *dfx.State) {
+		if frame == 0 {
+			state.IO.SetBackendFlags(state.IO.BackendFlags() | imgui.BackendFlagsHasGamepad)
+			state.IO.AddKeyEvent(imgui.KeyGamepadFaceDown, true)
+			state.IO.AddKeyAnalogEvent(imgui.KeyGamepadLStickRight, true, 0.5)
+			state.IO.AddKeyAnalogEvent(imgui.KeyGamepadLStickUp, true, 0.25)
+			state.IO.AddKeyAnalogEvent(imgui.KeyGamepadR2, true, 1)
+		}
+	}
+	s := NewSession(root, imgui.Vec2{X: 100, Y: 100}, params)
+	defer s.Close()
+	s.Frame()
+
+	if !pad.Connected {
+		t.Fatal("expected pad.Connected")
+	}
+	if !pad.Down(dfx.PadA) {
+		t.Fatal("expected pad.Down(dfx.PadA)")
+	}
+	if pad.Down(dfx.PadB) {
+		t.Fatal("unexpected pad.Down(dfx.PadB)")
+	}
+	if pad.LeftStick != (imgui.Vec2{X: 0.5, Y: -0.25}) {
+		t.Fatalf("expected '%v', got '%v'", imgui.Vec2{X: 0.5, Y: -0.25}, pad.LeftStick)
+	}
+	if pad.RightTrigger != float32(1) {
+		t.Fatalf("expected '%v', got '%v'", float32(1), pad.RightTrigger)
+	}
+	if pad.LeftTrigger != float32(0) {
+		t.Fatalf("expected '%v', got '%v'", float32(0), pad.LeftTrigger)
+	}
+}
+
+func TestScrollArea_KineticDrag(t *testing.T) {
+	area := dfx.NewScrollArea(dfx.NewFunc(func(state *dfx.State) {
+		for i := 0; i < 200; i++ {
+			imgui.Text("line")
+		}
+	}))
+	params := dfx.DefaultKineticParams()
+	area.Kinetic.Params = &params
+
+	render := DefaultRenderParams()
+	render.OnFrame = func(frame int, state *dfx.State) {
+		switch {
+		case frame == 1:
+			state.IO.AddMousePosEvent(100, 150)
+		case frame == 2:
+			state.IO.AddMouseButtonEvent(int32(imgui.MouseButtonLeft), true)
+		case frame >= 3 && frame <= 6:
+			// flick upwards, scrolling the content down
+			state.IO.AddMousePosEvent(100, 150-float32(frame-2)*20)
+		case frame == 7:
+			state.IO.AddMouseButtonEvent(int32(imgui.MouseButtonLeft), false)
+		}
+	}
+	s := NewSession(area, imgui.Vec2{X: 200, Y: 200}, render)
+	defer s.Close()
+	for s.Frames() < 9 {
+		s.Frame()
+	}
+	released := area.ScrollY()
+	if released <= float32(40) {
+		t.Fatalf("the drag scrolls the content: expected '%v' > '%v'", released, float32(40))
+	}
+	if !area.Kinetic.Moving() {
+		t.Fatal("expected area.Kinetic.Moving()")
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Frame()
+	}
+	if area.ScrollY() <= released {
+		t.Fatalf("keeps moving after the release: expected '%v' > '%v'", area.ScrollY(), released)
+	}
+	for i := 0; i < 120; i++ {
+		s.Frame()
+	}
+	if area.Kinetic.Moving() {
+		t.Fatal("and comes to a stop: unexpected area.Kinetic.Moving()")
+	}
+}
+
+func TestZoomPan_WheelAndClick(t *testing.T) {
+	clicked := 0
+	zp := dfx.NewZoomPan(dfx.NewFunc(func(state *dfx.State) {
+		if imgui.ButtonV("hit", imgui.Vec2{X: 40, Y: 20}) {
+			clicked++
+		}
+	}))
+
+	render := DefaultRenderParams()
+	render.OnFrame = func(frame int, state *dfx.State) {
+		switch frame {
+		case 1:
+			state.IO.AddMousePosEvent(10, 10)
+		case 2:
+			state.IO.AddMouseWheelEvent(0, 5)
+		case 3:
+			// back out a notch, which would scroll the area were it not for the view
+			state.IO.AddMouseWheelEvent(0, -1)
+		case 4:
+			// the button's far corner, only inside it when zoomed
+			state.IO.AddMousePosEvent(70, 35)
+		case 5:
+			state.IO.AddMouseButtonEvent(int32(imgui.MouseButtonLeft), true)
+		case 6:
+			state.IO.AddMouseButtonEvent(int32(imgui.MouseButtonLeft), false)
+		}
+	}
+	// in a scrolling area, which the wheel must not scroll too
+	area := dfx.NewScrollArea(dfx.NewFunc(func(state *dfx.State) {
+		zp.Draw(state.Child(imgui.Vec2{X: 184, Y: 184}, imgui.Vec2{}))
+		imgui.Dummy(imgui.Vec2{X: 10, Y: 1000})
+	}))
+	s := NewSession(area, imgui.Vec2{X: 200, Y: 200}, render)
+	defer s.Close()
+	for s.Frames() < 5 {
+		s.Frame()
+	}
+	if got := zp.Zoom(); math.Abs(float64(got)-float64(1.75)) > 0.01 {
+		t.Fatalf("the wheel zooms: expected '%v', got '%v'", 1.75, got)
+	}
+	if got := area.ScrollY(); got != float32(0) {
+		t.Fatalf("and does not scroll around the view: expected '%v', got '%v'", float32(0), got)
+	}
+	for s.Frames() < 9 {
+		s.Frame()
+	}
+	if clicked != 1 {
+		t.Fatalf("the pointer is mapped into the zoomed content: expected '%v', got '%v'", 1, clicked)
+	}
+
+	zp.Fit()
+	s.Frame()
+	img := s.Image()
+	// the button now spans the view's width; unzoomed it would end 40 pixels in
+	if img.RGBAAt(150, 100) == img.RGBAAt(150, 30) {
+		t.Fatalf("the drawing is scaled: expected a value other than '%v'", img.RGBAAt(150, 30))
+	}
+	lo, hi := zp.MinimapViewport()
+	if math.Abs(float64(lo.X)-float64(0)) > 0.01 {
+		t.Fatalf("the measured content fits the view's width: expected '%v', got '%v'", 0, lo.X)
+	}
+	if math.Abs(float64(hi.X)-float64(40)) > 0.01 {
+		t.Fatalf("expected '%v', got '%v'", 40, hi.X)
+	}
+}
+
+func TestAnimate_FrameClock(t *testing.T) {
+	var values []float32
+	root := dfx.NewFunc(func(state *dfx.State) {
+		target := float32(0)
+		if len(values) > 0 {
+			target = 100
+		}
+		values = append(values, dfx.Animate(state, "fade", target, 100*time.Millisecond, dfx.EaseLinear))
+	})
+	params := DefaultRenderParams()
+	params.FrameTime = 25 * time.Millisecond
+	s := NewSession(root, imgui.Vec2{X: 100, Y: 100}, params)
+	defer s.Close()
+	for range 6 {
+		s.Frame()
+	}
+
+	expected := []float32{0, 0, 25, 50, 75, 100}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("expected '%v', got '%v'", expected, values)
+	}
+}
+
+func TestWorkspace_RestoresWindowingKeys(t *testing.T) {
+	ws := dfx.NewWorkspace()
+	ws.Add("a", "A", &dfx.Container{Visible: true})
+	ws.EnableCycling()
+	s := NewSession(ws, imgui.Vec2{X: 100, Y: 100}, DefaultRenderParams())
+	defer s.Close()
+
+	ctx := imgui.CurrentContext()
+	next, prev := ctx.ConfigNavWindowingKeyNext(), ctx.ConfigNavWindowingKeyPrev()
+	if next == 0 || prev == 0 {
+		t.Fatalf("expected imgui's default windowing keys, got '%v' '%v'", next, prev)
+	}
+	s.Frame()
+	s.Frame()
+	if ctx.ConfigNavWindowingKeyNext() != 0 || ctx.ConfigNavWindowingKeyPrev() != 0 {
+		t.Fatalf("expected the workspace to own Ctrl+Tab while shown")
+	}
+	dfx.UnmountComponent(ws)
+	if got := ctx.ConfigNavWindowingKeyNext(); got != next {
+		t.Fatalf("expected '%v' restored, got '%v'", next, got)
+	}
+	if got := ctx.ConfigNavWindowingKeyPrev(); got != prev {
+		t.Fatalf("expected '%v' restored, got '%v'", prev, got)
+	}
+}
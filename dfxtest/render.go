@@ -0,0 +1,398 @@
+// Package dfxtest renders dfx components without a window, for visual regression tests:
+//
+//	func TestFader(t *testing.T) {
+//		img := dfxtest.RenderComponent(newMixer(), imgui.Vec2{X: 320, Y: 240})
+//		dfxtest.CompareGolden(t, "mixer", img, 0.001)
+//	}
+//
+// components are drawn in an imgui context of their own, with the dfx fonts, a fixed
+// theme, locale and clock, and the draw data is rasterized in software, so the images do
+// not depend on the machine's GPU or settings. textures other than the font atlas are
+// drawn as solid white. imgui contexts are global, so tests that render must not run in
+// parallel.
+package dfxtest
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+)
+
+// DefaultFrames is the number of frames drawn before capturing, so layouts settle.
+const DefaultFrames = 3
+
+// Epoch is the clock of the first frame rendered; State.Now advances from it by
+// RenderParams.FrameTime each frame.
+var Epoch = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// RenderParams configures RenderParamsComponent and Session.
+type RenderParams struct {
+	Frames    int                               // frames drawn before capturing (0 = DefaultFrames)
+	FrameTime time.Duration                     // clock step between frames (0 = 1/60s)
+	Theme     dfx.Theme                         // theme applied for the render (nil = dfx.ModernDark)
+	Locale    string                            // locale for the render ("" = "en")
+	Padding   *imgui.Vec2                       // padding around the component (nil = style window padding)
+	OnFrame   func(frame int, state *dfx.State) // called before each frame is drawn, such as to feed a meter
+}
+
+// DefaultRenderParams returns the parameters RenderComponent uses.
+func DefaultRenderParams() RenderParams {
+	return RenderParams{Frames: DefaultFrames, FrameTime: time.Second / 60, Theme: dfx.ModernDark, Locale: "en"}
+}
+
+// RenderComponent draws c in a root window of size points and returns the last frame.
+func RenderComponent(c dfx.Component, size imgui.Vec2) *image.RGBA {
+	return RenderParamsComponent(c, size, DefaultRenderParams())
+}
+
+// RenderParamsComponent draws c as RenderComponent does, configured by params. c is
+// unmounted afterwards, so components release what they hold (see dfx.Unmounter).
+func RenderParamsComponent(c dfx.Component, size imgui.Vec2, params RenderParams) *image.RGBA {
+	if params.Frames <= 0 {
+		params.Frames = DefaultFrames
+	}
+	s := NewSession(c, size, params)
+	defer s.Close()
+	for frame := 0; frame < params.Frames; frame++ {
+		s.Frame()
+	}
+	return s.Image()
+}
+
+// Session draws a component frame by frame in an imgui context of its own, as
+// RenderComponent does, for tests that change the component between frames or measure
+// it (see dfxbench). params.Frames is not used. close it when done; only one session can
+// be open at a time.
+type Session struct {
+	component dfx.Component
+	size      imgui.Vec2
+	params    RenderParams
+	ctx       *imgui.Context
+	raster    *rasterizer
+	frame     int
+
+	previousTheme  dfx.Theme
+	previousLocale string
+}
+
+// NewSession creates the imgui context for drawing c in a root window of size points.
+func NewSession(c dfx.Component, size imgui.Vec2, params RenderParams) *Session {
+	if params.FrameTime <= 0 {
+		params.FrameTime = time.Second / 60
+	}
+	if params.Theme == nil {
+		params.Theme = dfx.ModernDark
+	}
+	if params.Locale == "" {
+		params.Locale = "en"
+	}
+	s := &Session{
+		component:      c,
+		size:           size,
+		params:         params,
+		raster:         &rasterizer{textures: make(map[imgui.TextureID]*image.RGBA)},
+		previousTheme:  dfx.CurrentTheme(),
+		previousLocale: dfx.Locale(),
+	}
+	s.ctx = imgui.CreateContext()
+	io := imgui.CurrentIO()
+	io.SetIniFilename("")
+	io.SetDisplaySize(size)
+	io.SetDeltaTime(float32(params.FrameTime.Seconds()))
+	io.SetBackendFlags(imgui.BackendFlagsRendererHasTextures | imgui.BackendFlagsRendererHasVtxOffset)
+	dfx.SetupFonts()
+	dfx.SetTheme(params.Theme)
+	dfx.SetLocale(params.Locale)
+	return s
+}
+
+// Frame draws the next frame, calling OnFrame first.
+func (s *Session) Frame() {
+	state := &dfx.State{
+		Size:       s.size,
+		IO:         imgui.CurrentIO(),
+		Now:        Epoch.Add(time.Duration(s.frame) * s.params.FrameTime),
+		FrameIndex: uint64(s.frame),
+	}
+	if s.frame > 0 {
+		state.DeltaTime = s.params.FrameTime
+	}
+	if s.params.OnFrame != nil {
+		s.params.OnFrame(s.frame, state)
+	}
+	imgui.NewFrame()
+	drawRoot(s.component, state, s.params.Padding)
+	imgui.Render()
+	s.raster.updateTextures(imgui.CurrentIO().Fonts().TexData())
+	s.frame++
+}
+
+// Concurrent draws frames frames while each writer is called over and over on a goroutine
+// of its own, with the number of times it was called, as audio callbacks and network
+// handlers update components from outside the UI thread. drawing starts once every writer
+// has been called, so they overlap the frames. run it under the race detector
+// (go test -race) to check that what the writers call is safe from any goroutine:
+//
+//	meter := dfx.NewVUMeter(2)
+//	s := dfxtest.NewSession(meter, imgui.Vec2{X: 100, Y: 220}, dfxtest.DefaultRenderParams())
+//	defer s.Close()
+//	s.Concurrent(60, func(i int) { meter.SetLevels([]float32{0.5, float32(i%10) / 10}) })
+func (s *Session) Concurrent(frames int, writers ...func(i int)) {
+	done := make(chan struct{})
+	var started, wg sync.WaitGroup
+	for _, write := range writers {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			write(0)
+			started.Done()
+			for i := 1; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				write(i)
+			}
+		}()
+	}
+	started.Wait()
+	for i := 0; i < frames; i++ {
+		s.Frame()
+	}
+	close(done)
+	wg.Wait()
+}
+
+// Frames returns the number of frames drawn.
+func (s *Session) Frames() int {
+	return s.frame
+}
+
+// DrawData returns the draw data of the last frame.
+func (s *Session) DrawData() *imgui.DrawData {
+	return imgui.CurrentDrawData()
+}
+
+// Image rasterizes the last frame.
+func (s *Session) Image() *image.RGBA {
+	return s.raster.render(imgui.CurrentDrawData(), s.size, imgui.CurrentStyle().Colors()[imgui.ColWindowBg])
+}
+
+// Close unmounts the component and destroys the context, restoring the theme and locale
+// that were set before.
+func (s *Session) Close() {
+	if s.ctx == nil {
+		return
+	}
+	dfx.UnmountComponent(s.component)
+	if s.previousTheme != nil {
+		dfx.SetTheme(s.previousTheme) // applied to the session's context, which is dropped
+	}
+	dfx.SetLocale(s.previousLocale)
+	imgui.DestroyContextV(s.ctx)
+	s.ctx = nil
+	dfx.Fonts = dfx.Fonts[:0] // they belonged to the destroyed context
+}
+
+// drawRoot draws c in a window covering the display, as the app's root window does.
+func drawRoot(c dfx.Component, state *dfx.State, padding *imgui.Vec2) {
+	flags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsNoSavedSettings | imgui.WindowFlagsNoMove |
+		imgui.WindowFlagsNoScrollWithMouse
+	imgui.SetNextWindowPos(imgui.Vec2{})
+	imgui.SetNextWindowSize(state.Size)
+	if padding != nil {
+		imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, *padding)
+	}
+	open := imgui.BeginV("##dfxtest_root", nil, flags)
+	if padding != nil {
+		imgui.PopStyleVar()
+	}
+	if open {
+		state.Size = imgui.ContentRegionAvail()
+		dfx.DrawChild(c, state)
+	}
+	imgui.End()
+}
+
+// rasterizer draws imgui draw data into an image.
+type rasterizer struct {
+	textures map[imgui.TextureID]*image.RGBA
+}
+
+// updateTextures copies the font atlas when imgui created or changed it.
+func (r *rasterizer) updateTextures(tex *imgui.TextureData) {
+	switch tex.Status() {
+	case imgui.TextureStatusWantCreate, imgui.TextureStatusWantUpdates:
+	default:
+		return
+	}
+	width, height := int(tex.Width()), int(tex.Height())
+	bpp := int(tex.BytesPerPixel())
+	pixels := tex.Pixels()
+	data := unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&pixels))), width*height*bpp)
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		if tex.Format() == imgui.TextureFormatAlpha8 {
+			copy(img.Pix[i*4:], []byte{255, 255, 255, data[i]})
+		} else {
+			copy(img.Pix[i*4:], data[i*4:i*4+4])
+		}
+	}
+	id := tex.TexID()
+	if id == 0 {
+		id = imgui.TextureID(tex.UniqueID() + 1)
+		tex.SetTexID(id)
+	}
+	r.textures[id] = img
+	tex.SetStatus(imgui.TextureStatusOK)
+}
+
+// vertex is a draw list vertex.
+type vertex struct {
+	x, y, u, v float32
+	color      [4]float32 // straight alpha, 0-1
+}
+
+// render rasterizes data onto a background of color background.
+func (r *rasterizer) render(data *imgui.DrawData, size imgui.Vec2, background imgui.Vec4) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(size.X), int(size.Y)))
+	bg := color.RGBA{R: channel(background.X), G: channel(background.Y), B: channel(background.Z), A: 255}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = bg.R, bg.G, bg.B, bg.A
+	}
+
+	vtxSize, posOffset, uvOffset, colOffset := imgui.VertexBufferLayout()
+	idxSize := imgui.IndexBufferLayout()
+	for _, list := range data.CommandLists() {
+		vtxPtr, vtxBytes := list.GetVertexBuffer()
+		idxPtr, idxBytes := list.GetIndexBuffer()
+		vtxData := unsafe.Slice((*byte)(vtxPtr), vtxBytes)
+		idxData := unsafe.Slice((*byte)(idxPtr), idxBytes)
+		readVertex := func(i int) vertex {
+			b := vtxData[i*vtxSize:]
+			c := *(*uint32)(unsafe.Pointer(&b[colOffset]))
+			return vertex{
+				x: *(*float32)(unsafe.Pointer(&b[posOffset])), y: *(*float32)(unsafe.Pointer(&b[posOffset+4])),
+				u: *(*float32)(unsafe.Pointer(&b[uvOffset])), v: *(*float32)(unsafe.Pointer(&b[uvOffset+4])),
+				color: [4]float32{float32(c&0xff) / 255, float32(c>>8&0xff) / 255, float32(c>>16&0xff) / 255, float32(c>>24) / 255},
+			}
+		}
+		readIndex := func(i int) int {
+			if idxSize == 4 {
+				return int(*(*uint32)(unsafe.Pointer(&idxData[i*4])))
+			}
+			return int(*(*uint16)(unsafe.Pointer(&idxData[i*2])))
+		}
+
+		for _, cmd := range list.Commands() {
+			if cmd.HasUserCallback() {
+				continue
+			}
+			clip := cmd.ClipRect()
+			bounds := image.Rect(int(math.Floor(float64(clip.X))), int(math.Floor(float64(clip.Y))),
+				int(math.Ceil(float64(clip.Z))), int(math.Ceil(float64(clip.W)))).Intersect(img.Bounds())
+			if bounds.Empty() {
+				continue
+			}
+			tex := r.textures[cmd.TexID()]
+			first, base := int(cmd.IdxOffset()), int(cmd.VtxOffset())
+			for i := first; i+2 < first+int(cmd.ElemCount()); i += 3 {
+				fillTriangle(img, bounds, tex,
+					readVertex(base+readIndex(i)), readVertex(base+readIndex(i+1)), readVertex(base+readIndex(i+2)))
+			}
+		}
+	}
+	return img
+}
+
+// fillTriangle blends a triangle onto img within bounds, sampling tex (nil = white) at the
+// nearest texel. pixels are covered when their centers are inside the triangle.
+func fillTriangle(img *image.RGBA, bounds image.Rectangle, tex *image.RGBA, a, b, c vertex) {
+	area := edge(a, b, c.x, c.y)
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		b, c = c, b
+		area = -area
+	}
+	minX := max(bounds.Min.X, int(math.Floor(float64(min(a.x, b.x, c.x)))))
+	maxX := min(bounds.Max.X, int(math.Ceil(float64(max(a.x, b.x, c.x)))))
+	minY := max(bounds.Min.Y, int(math.Floor(float64(min(a.y, b.y, c.y)))))
+	maxY := min(bounds.Max.Y, int(math.Ceil(float64(max(a.y, b.y, c.y)))))
+	for y := minY; y < maxY; y++ {
+		py := float32(y) + 0.5
+		for x := minX; x < maxX; x++ {
+			px := float32(x) + 0.5
+			w0, w1, w2 := edge(b, c, px, py), edge(c, a, px, py), edge(a, b, px, py)
+			if !covers(w0, b, c) || !covers(w1, c, a) || !covers(w2, a, b) {
+				continue
+			}
+			w0, w1, w2 = w0/area, w1/area, w2/area
+			var src [4]float32
+			for i := range src {
+				src[i] = a.color[i]*w0 + b.color[i]*w1 + c.color[i]*w2
+			}
+			if tex != nil {
+				texel := sample(tex, a.u*w0+b.u*w1+c.u*w2, a.v*w0+b.v*w1+c.v*w2)
+				for i := range src {
+					src[i] *= float32(texel[i]) / 255
+				}
+			}
+			blend(img, x, y, src)
+		}
+	}
+}
+
+// edge returns twice the signed area of the triangle p, q, (x, y).
+func edge(p, q vertex, x, y float32) float32 {
+	return (q.x-p.x)*(y-p.y) - (q.y-p.y)*(x-p.x)
+}
+
+// covers reports whether a pixel center at edge value w of p->q is inside. centers on the
+// edge count for one direction of it only, so of two triangles sharing the edge, which
+// run along it in opposite directions, exactly one covers them.
+func covers(w float32, p, q vertex) bool {
+	if w != 0 {
+		return w > 0
+	}
+	dx, dy := q.x-p.x, q.y-p.y
+	return (dy == 0 && dx < 0) || dy > 0
+}
+
+// sample returns the texel of tex nearest to u, v.
+func sample(tex *image.RGBA, u, v float32) [4]uint8 {
+	w, h := tex.Rect.Dx(), tex.Rect.Dy()
+	x := min(max(int(u*float32(w)), 0), w-1)
+	y := min(max(int(v*float32(h)), 0), h-1)
+	i := tex.PixOffset(x, y)
+	return [4]uint8{tex.Pix[i], tex.Pix[i+1], tex.Pix[i+2], tex.Pix[i+3]}
+}
+
+// blend draws src over the pixel at x, y with straight alpha, as imgui's renderers do.
+func blend(img *image.RGBA, x, y int, src [4]float32) {
+	alpha := min(max(src[3], 0), 1)
+	if alpha == 0 {
+		return
+	}
+	i := img.PixOffset(x, y)
+	for ch := 0; ch < 3; ch++ {
+		dst := float32(img.Pix[i+ch]) / 255
+		img.Pix[i+ch] = channel(src[ch]*alpha + dst*(1-alpha))
+	}
+	img.Pix[i+3] = channel(alpha + float32(img.Pix[i+3])/255*(1-alpha))
+}
+
+// channel converts a 0-1 color channel to a byte.
+func channel(v float32) uint8 {
+	return uint8(min(max(v, 0), 1)*255 + 0.5)
+}
@@ -0,0 +1,124 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// payload kinds used by dfx components
+const (
+	DragKindFile     = "dfx/file"     // *FileNode dragged from a FileTree
+	DragKindListRows = "dfx/listRows" // ListDrag dragged from a ListView
+)
+
+// ListDrag is the payload of rows dragged from a ListView.
+type ListDrag struct {
+	Source  *ListView
+	Indices []int // dragged model indices, ascending
+}
+
+// activeDrag holds the Go value of the drag in progress. imgui payloads are raw bytes, so
+// only the payload kind is handed to imgui and the value stays here.
+var activeDrag struct {
+	kind  string
+	value any
+}
+
+// DragSource makes the last item draggable, carrying value as a payload of the given
+// kind (at most 32 bytes, e.g. "app/channel"). preview draws the tooltip that follows the
+// mouse while dragging; nil shows none. it returns true while the item is being dragged.
+func DragSource[T any](kind string, value T, preview func()) bool {
+	flags := imgui.DragDropFlagsNone
+	if preview == nil {
+		flags |= imgui.DragDropFlagsSourceNoPreviewTooltip
+	}
+	if !imgui.BeginDragDropSourceV(flags) {
+		return false
+	}
+	activeDrag.kind, activeDrag.value = kind, value
+	imgui.SetDragDropPayload(kind, 0, 0)
+	if preview != nil {
+		preview()
+	}
+	imgui.EndDragDropSource()
+	return true
+}
+
+// DragText returns a DragSource preview that shows text.
+func DragText(text string) func() {
+	return func() { imgui.TextUnformatted(text) }
+}
+
+// DropTarget makes the last item accept payloads of the given kind. while a matching drag
+// hovers the item it is highlighted, and when the drag is released over it the dragged
+// value is returned with true. wrap several items in imgui.BeginGroup/EndGroup to make
+// them one target.
+func DropTarget[T any](kind string) (T, bool) {
+	return DropTargetIf[T](kind, nil)
+}
+
+// DropTargetIf is DropTarget with an accept predicate: payloads it rejects are neither
+// highlighted nor delivered.
+func DropTargetIf[T any](kind string, accept func(T) bool) (T, bool) {
+	var zero T
+	value, ok := DragPayload[T](kind)
+	if !ok || (accept != nil && !accept(value)) || !acceptDrop(kind, HighlightDropTarget) {
+		return zero, false
+	}
+	return value, true
+}
+
+// acceptDrop makes the last item a drop target for kind, calling highlight with the item
+// rectangle while a drag hovers it, and reports whether the drag was released over it.
+func acceptDrop(kind string, highlight func(topLeft, bottomRight imgui.Vec2)) bool {
+	if !imgui.BeginDragDropTarget() {
+		return false
+	}
+	defer imgui.EndDragDropTarget()
+
+	payload := imgui.AcceptDragDropPayloadV(kind, imgui.DragDropFlagsAcceptNoDrawDefaultRect)
+	if payload.CData == nil {
+		return false
+	}
+	highlight(imgui.ItemRectMin(), imgui.ItemRectMax())
+	if !payload.IsDelivery() {
+		return false
+	}
+	activeDrag.kind, activeDrag.value = "", nil
+	return true
+}
+
+// DragPayload returns the value of the drag in progress when it is of the given kind.
+// use it to highlight every place a drag could be dropped, or to preview the drop.
+func DragPayload[T any](kind string) (T, bool) {
+	var zero T
+	payload := imgui.DragDropPayload()
+	if payload.CData == nil || !payload.IsDataType(kind) || activeDrag.kind != kind {
+		return zero, false
+	}
+	value, ok := activeDrag.value.(T)
+	return value, ok
+}
+
+// DragActive reports whether a drag of the given kind is in progress.
+func DragActive(kind string) bool {
+	payload := imgui.DragDropPayload()
+	return payload.CData != nil && payload.IsDataType(kind)
+}
+
+// HighlightDropTarget draws the hover highlight of a drop target over a rectangle in
+// screen coordinates.
+func HighlightDropTarget(topLeft, bottomRight imgui.Vec2) {
+	drawList := imgui.WindowDrawList()
+	drawList.AddRectFilled(topLeft, bottomRight, imgui.ColorU32ColV(imgui.ColDragDropTarget, 0.15))
+	drawList.AddRectV(topLeft, bottomRight, imgui.ColorU32Col(imgui.ColDragDropTarget), 0, imgui.DrawFlagsNone, 2)
+}
+
+// highlightDropLine draws an insertion line along the top or bottom edge of a rectangle,
+// for drops that go between items.
+func highlightDropLine(topLeft, bottomRight imgui.Vec2, after bool) {
+	y := topLeft.Y
+	if after {
+		y = bottomRight.Y
+	}
+	imgui.WindowDrawList().AddLineV(imgui.Vec2{X: topLeft.X, Y: y}, imgui.Vec2{X: bottomRight.X, Y: y}, imgui.ColorU32Col(imgui.ColDragDropTarget), 2)
+}
@@ -11,6 +11,8 @@ func main() {
 	// state for our component
 	showExtra := false
 	counter := 0
+	left := counterPanel("Left Panel")
+	right := counterPanel("Right Panel")
 
 	// create a component with the Container type for more control
 	root := &dfx.Container{
@@ -39,6 +41,13 @@ func main() {
 			imgui.Text("  Ctrl+= - Increment counter (component action)")
 			imgui.Text("  Ctrl+- - Decrement counter (component action)")
 			imgui.Text("  Ctrl+Q - Quit application (global action)")
+			imgui.Text("  Tab/Shift+Tab - Move focus between the panels below")
+			imgui.Text("  Up/Down - Change the focused panel's count (focused actions win)")
+
+			imgui.Spacing()
+			left.Draw(state)
+			imgui.SameLine()
+			right.Draw(state)
 		},
 	}
 
@@ -70,3 +79,20 @@ func main() {
 		panic(err)
 	}
 }
+
+// counterPanel creates a focusable panel; clicking it or pressing Tab focuses it, and
+// while it is focused its Up/Down actions take precedence over the other panel's.
+func counterPanel(name string) *dfx.Container {
+	count := 0
+	panel := &dfx.Container{Visible: true}
+	panel.OnDraw = func(state *dfx.State) {
+		imgui.BeginChildStrV(name, imgui.Vec2{X: 180, Y: 60}, imgui.ChildFlagsBorders, imgui.WindowFlagsNone)
+		imgui.Text(name)
+		imgui.Text(fmt.Sprintf("count: %d", count))
+		imgui.EndChild()
+		state.App.Focus().Focusable(panel, imgui.ItemRectMin(), imgui.ItemRectMax())
+	}
+	panel.Actions().MustRegister("up", "Up", func() { count++ })
+	panel.Actions().MustRegister("down", "Down", func() { count-- })
+	return panel
+}
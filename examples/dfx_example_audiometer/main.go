@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+	"github.com/michaelquigley/dfx/audiometer"
+)
+
+// toneCapture is an audiometer.Capture that synthesizes a pulsing stereo tone in real
+// time. replace it with an adapter over a real audio library (PortAudio, malgo, JACK) to
+// meter a live input.
+type toneCapture struct{}
+
+func (toneCapture) Devices() ([]string, error) { return []string{"tone"}, nil }
+
+func (toneCapture) Open(device string, channels, sampleRate int, deliver func([]float32)) (io.Closer, error) {
+	stop := make(chan struct{})
+	go func() {
+		const frames = 256
+		block := make([]float32, frames*channels)
+		ticker := time.NewTicker(time.Duration(frames) * time.Second / time.Duration(sampleRate))
+		defer ticker.Stop()
+		n := 0
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			for i := 0; i < frames; i++ {
+				t := float64(n) / float64(sampleRate)
+				tone := math.Sin(2 * math.Pi * 220 * t)
+				for ch := 0; ch < channels; ch++ {
+					// each channel pulses at its own rate
+					envelope := 0.5 + 0.5*math.Sin(2*math.Pi*(0.3+0.2*float64(ch))*t)
+					block[i*channels+ch] = float32(tone * envelope * envelope)
+				}
+				n++
+			}
+			deliver(block)
+		}
+	}()
+	return closerFunc(func() error { close(stop); return nil }), nil
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func main() {
+	meter := dfx.NewVUMeter(2)
+	meter.SetLabels([]string{"L", "R"})
+	waterfall := dfx.NewVUWaterfall(2)
+	waterfall.Height = 200
+	waterfall.ChannelWidth = 30
+	waterfall.RowHeight = 2
+	waterfall.HistorySize = 100
+
+	input, err := audiometer.Open(toneCapture{}, audiometer.Config{Device: "tone", Channels: 2})
+	if err != nil {
+		panic(err)
+	}
+	defer input.Close()
+	input.Feed(meter, waterfall)
+
+	root := dfx.NewFunc(func(state *dfx.State) {
+		meter.Draw(state)
+		imgui.SameLine()
+		waterfall.Draw(state)
+		imgui.SameLine()
+		imgui.BeginGroup()
+		rms, _ := input.Levels()
+		for ch, label := range []string{"L", "R"} {
+			imgui.Text(fmt.Sprintf("%s %6.1f dBFS", label, audiometer.DB(rms[ch])))
+		}
+		imgui.EndGroup()
+	})
+
+	app := dfx.New(root, dfx.Config{
+		Title:  "Audio Meter Example",
+		Width:  500,
+		Height: 300,
+		OnTick: func(*dfx.App) { input.Update() },
+	})
+	app.Run()
+}
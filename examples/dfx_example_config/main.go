@@ -47,6 +47,7 @@ type appState struct {
 	cfgPath            string
 	message            string
 	dashMgr            *dfx.DashManager
+	cfgMgr             *dfx.ConfigManager
 	saveCount          int
 	configLoadedFromFS bool
 }
@@ -74,6 +75,19 @@ func main() {
 		configLoadedFromFS: true,
 	}
 
+	// auto-save the configuration two seconds after the last change
+	state.cfgMgr = dfx.NewConfigManager()
+	state.cfgMgr.OnSaved = func(path string) {
+		state.saveCount++
+		state.message = "configuration auto-saved"
+	}
+	state.cfgMgr.OnError = func(path string, err error) {
+		state.message = fmt.Sprintf("error auto-saving: %v", err)
+	}
+	if err := state.cfgMgr.Register(cfgPath, state.cfg); err != nil {
+		panic(err)
+	}
+
 	// create dashboard panels
 	state.dashMgr = dfx.NewDashManager()
 	state.dashMgr.Precedence = dfx.HorizontalPrecedence
@@ -126,7 +140,7 @@ func main() {
 			imgui.Text("Debug Controls")
 
 			if imgui.Button("Reset to Defaults") {
-				state.cfg = defaultConfig()
+				*state.cfg = *defaultConfig()
 				state.message = "configuration reset to defaults"
 			}
 		}
@@ -261,12 +275,19 @@ func main() {
 			})
 		},
 
+		OnTick: func(app *dfx.App) {
+			// keep window and dashboard state current so the config manager sees changes
+			state.cfg.Window = dfx.CaptureWindowState(app)
+			state.cfg.Dashes = dfx.CaptureDashState(state.dashMgr)
+			state.cfgMgr.Tick()
+		},
+
 		OnClose: func(app *dfx.App) {
-			// save configuration on close
+			// save any pending changes on close
 			state.cfg.Window = dfx.CaptureWindowState(app)
 			state.cfg.Dashes = dfx.CaptureDashState(state.dashMgr)
 
-			if err := state.saveConfig(); err != nil {
+			if err := state.cfgMgr.Flush(); err != nil {
 				fmt.Printf("error saving config on close: %v\n", err)
 			} else {
 				fmt.Println("configuration saved on close")
@@ -285,6 +306,6 @@ func main() {
 }
 
 func (s *appState) saveConfig() error {
-	s.saveCount++
-	return dfx.SaveJSON(s.cfgPath, s.cfg)
+	s.cfgMgr.MarkDirty(s.cfgPath)
+	return s.cfgMgr.Flush()
 }
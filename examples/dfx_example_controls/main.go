@@ -18,11 +18,27 @@ type state struct {
 	mouseTracking bool
 	loopEnabled   bool
 
+	// radio group and segmented control states
+	waveform int
+	view     int
+
 	// wheel slider states
 	zoom        float32
 	volume      float32
 	speed       float32
 	temperature float32
+
+	// number input states
+	frequency float32
+	delay     float32
+	gain      float32
+	voices    int
+
+	// range slider states
+	bandLo float32
+	bandHi float32
+	trimLo int
+	trimHi int
 }
 
 func main() {
@@ -36,8 +52,28 @@ func main() {
 		volume:         0.5,
 		speed:          1.0,
 		temperature:    0.7,
+		frequency:      440,
+		delay:          0.25,
+		gain:           -6,
+		voices:         8,
+		bandLo:         200,
+		bandHi:         2000,
+		trimLo:         10,
+		trimHi:         90,
 	}
 
+	// action bar: icon buttons, toggles and separators; narrow the window to see items
+	// move into the overflow menu
+	bar := dfx.NewActionBar()
+	bar.AddAction(fonts.ICON_SAVE, dfx.NewMenuAction("Save", "Ctrl+S", func() { fmt.Println("save") }))
+	bar.Add(fonts.ICON_CONTENT_COPY, "", "copy", func() { fmt.Println("copy") })
+	bar.AddSeparator()
+	bar.AddToggle(fonts.ICON_REPEAT, "loop", &s.loopEnabled)
+	bar.AddToggle(fonts.ICON_MOUSE, "mouse tracking", &s.mouseTracking)
+	bar.AddSeparator()
+	bar.Add(fonts.ICON_PLAY_ARROW, "Play", "start playback", func() { s.playEnabled = true })
+	bar.Add(fonts.ICON_STOP, "Stop", "stop playback", func() { s.playEnabled = false })
+
 	root := dfx.NewFunc(func(state *dfx.State) {
 		imgui.Text("dfx Control Wrappers Demo")
 		imgui.Separator()
@@ -87,6 +123,21 @@ func main() {
 		imgui.Separator()
 		imgui.Spacing()
 
+		// RadioGroup and SegmentedControl demo
+		imgui.Text("RadioGroup and SegmentedControl - one of N:")
+		if newIndex, changed := dfx.RadioGroup("Waveform", s.waveform, []string{"Sine", "Square", "Saw", "Noise"}); changed {
+			s.waveform = newIndex
+			fmt.Printf("waveform: %d\n", s.waveform)
+		}
+		if newIndex, changed := dfx.SegmentedControl("##view", s.view, []string{"Mixer", "Editor", "Browser"}, 0); changed {
+			s.view = newIndex
+			fmt.Printf("view: %d\n", s.view)
+		}
+
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+
 		// WheelSlider demo
 		imgui.Text("WheelSlider - Hover and use mouse wheel to adjust")
 		imgui.Text("(Ctrl = 10x faster, Alt = 10x slower)")
@@ -120,6 +171,56 @@ func main() {
 		imgui.Separator()
 		imgui.Spacing()
 
+		// NumberInput / IntInput demo
+		imgui.Text("NumberInput - drag, wheel, or click to type (\"10k\", \"3.5ms\", \"2*440\")")
+		imgui.Spacing()
+
+		if newValue, changed := dfx.NumberInput("Frequency", s.frequency, dfx.NumberOptions{Min: 20, Max: 20000, Precision: 2, Unit: "Hz", SI: true}); changed {
+			s.frequency = newValue
+		}
+		if newValue, changed := dfx.NumberInput("Delay", s.delay, dfx.NumberOptions{Min: 0, Max: 2, Precision: 1, Unit: "s", SI: true}); changed {
+			s.delay = newValue
+		}
+		if newValue, changed := dfx.NumberInput("Gain", s.gain, dfx.NumberOptions{Min: -60, Max: 12, Precision: 1, Unit: "dB"}); changed {
+			s.gain = newValue
+		}
+		if newValue, changed := dfx.IntInput("Voices", s.voices, dfx.NumberOptions{Min: 1, Max: 64}); changed {
+			s.voices = newValue
+		}
+
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+
+		// RangeSlider demo
+		imgui.Text("RangeSlider - drag a handle, or the fill to move both")
+		imgui.Spacing()
+
+		band := dfx.DefaultRangeSliderParams()
+		band.Taper = dfx.LogTaper(3)
+		band.MinGap = 50
+		band.Format = func(v float32) string { return dfx.FormatNumber(v, dfx.NumberOptions{Precision: 1, Unit: "Hz", SI: true}) }
+		if lo, hi, changed := dfx.RangeSlider("Band", s.bandLo, s.bandHi, 20, 20000, band); changed {
+			s.bandLo, s.bandHi = lo, hi
+		}
+		trim := dfx.DefaultRangeSliderParams()
+		trim.MinGap = 5
+		if lo, hi, changed := dfx.RangeSliderI("Trim", s.trimLo, s.trimHi, 0, 100, trim); changed {
+			s.trimLo, s.trimHi = lo, hi
+		}
+
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+
+		// ActionBar / IconButton demo
+		imgui.Text("ActionBar - icon buttons with overflow menu:")
+		bar.Draw(state)
+
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+
 		// Standard controls for comparison
 		imgui.Text("Standard Controls (for comparison):")
 
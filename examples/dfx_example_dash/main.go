@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/michaelquigley/dfx"
 )
 
@@ -10,9 +11,10 @@ func main() {
 	leftDash.TargetSize = 300
 	leftDash.CurrentSize = 300
 
-	rightDash := dfx.NewDash("RightDash", dfx.NewSizeDebugger())
-	rightDash.TargetSize = 250
-	rightDash.CurrentSize = 250
+	// the inspector shows the live component tree; hover a node to outline it
+	rightDash := dfx.NewDash("Inspector", dfx.NewInspector())
+	rightDash.TargetSize = 320
+	rightDash.CurrentSize = 320
 
 	topDash := dfx.NewDash("TopDash", dfx.NewSizeDebugger())
 	topDash.TargetSize = 200
@@ -21,6 +23,9 @@ func main() {
 	bottomDash := dfx.NewDash("BottomDash", dfx.NewSizeDebugger())
 	bottomDash.TargetSize = 150
 	bottomDash.CurrentSize = 150
+	bottomDash.AddTab("output", "Output", dfx.NewFunc(func(state *dfx.State) {
+		imgui.TextUnformatted("stacked tab; Ctrl+PageUp/PageDown cycles tabs, right-click a tab to hide it")
+	}))
 
 	// create dash manager
 	dashManager := dfx.NewDashManager()
@@ -30,6 +35,7 @@ func main() {
 	dashManager.Bottom = bottomDash
 	dashManager.Inner = dfx.NewSizeDebugger()
 	dashManager.Precedence = dfx.HorizontalPrecedence
+	dashManager.EnableFocusCycling("", "")
 
 	// create app
 	app := dfx.New(dashManager, dfx.Config{
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/michaelquigley/dfx"
 )
 
@@ -46,11 +47,35 @@ func main() {
 		return ext == ".go" || ext == ".md"
 	}
 
-	// create application
-	app := dfx.New(fileTree, dfx.Config{
+	// nodes can be dragged onto drop zones
+	fileTree.Draggable = true
+
+	// a drop zone that takes Go sources from the tree or from a file manager
+	var dropped []string
+	zone := dfx.NewDropZone("drop .go files here, from the tree or a file manager", func(paths []string) {
+		dropped = append(dropped, paths...)
+	})
+	zone.Accept = []string{".go"}
+	zone.OnFileNode = func(node *dfx.FileNode) {
+		if !node.Dir {
+			dropped = append(dropped, node.Path())
+		}
+	}
+
+	layout := dfx.NewFunc(func(state *dfx.State) {
+		zone.Draw(state)
+		imgui.Text(fmt.Sprintf("dropped: %d files", len(dropped)))
+		fileTree.Draw(state)
+	})
+
+	// create application; files dropped outside the zone are reported here
+	app := dfx.New(layout, dfx.Config{
 		Title:  "File Tree Example",
 		Width:  800,
 		Height: 600,
+		OnFileDrop: func(_ *dfx.App, paths []string) {
+			fmt.Printf("dropped outside the zone: %v\n", paths)
+		},
 	})
 
 	// run application
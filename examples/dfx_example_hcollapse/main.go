@@ -13,18 +13,13 @@ import (
 type MixerChannel struct {
 	name       string
 	normalized float32
-	meter      *dfx.VUMeter
+	level      float32
 }
 
 func newMixerChannel(name string, initial float32) *MixerChannel {
-	meter := dfx.NewVUMeter(1)
-	meter.Height = 180
-	meter.ChannelWidth = 10
-	meter.SetLabel(0, "")
 	return &MixerChannel{
 		name:       name,
 		normalized: initial,
-		meter:      meter,
 	}
 }
 
@@ -65,6 +60,29 @@ func main() {
 		Expanded:      true,
 	})
 
+	// group the panels: they share the window width with the main content area
+	exclusive := false
+	group := dfx.NewHCollapseGroup(drumsCollapse, synthsCollapse)
+	group.MinContentWidth = 200
+	group.Content = dfx.NewFunc(func(state *dfx.State) {
+		imgui.Text("Main Content Area")
+		imgui.Separator()
+		imgui.Spacing()
+		imgui.Text("This area expands as panels collapse.")
+		imgui.Spacing()
+		imgui.Text(fmt.Sprintf("Drums panel: %.0fpx", drumsCollapse.CurrentWidth))
+		imgui.Text(fmt.Sprintf("Synths panel: %.0fpx", synthsCollapse.CurrentWidth))
+		imgui.Text(fmt.Sprintf("Main area: %.0fpx", state.Size.X))
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+		imgui.Text("Tips:")
+		imgui.BulletText("Click chevron icons to toggle panels")
+		imgui.BulletText("Drag the resize handle to adjust width")
+		imgui.BulletText("Use keyboard shortcuts [ and ] to toggle")
+		imgui.BulletText("Panels close automatically when the window is too narrow")
+	})
+
 	// simulation state
 	startTime := time.Now()
 	paused := false
@@ -86,6 +104,11 @@ func main() {
 		if imgui.Button("Toggle Synths") {
 			synthsCollapse.Toggle()
 		}
+		imgui.SameLine()
+		if newValue, changed := dfx.Checkbox("Exclusive", exclusive); changed {
+			exclusive = newValue
+			group.Exclusive = exclusive
+		}
 
 		imgui.Spacing()
 		imgui.Separator()
@@ -98,50 +121,10 @@ func main() {
 			simulateLevels(synthChannels, t, 4)
 		}
 
-		// draw the collapsible panels side by side
-		// calculate available height for panels
+		// draw the collapsible panels side by side; the group fills the remaining width
+		// with the main content area and keeps the panels within the window
 		panelHeight := state.Size.Y - 120 // leave room for header and controls
-
-		// create a state with adjusted height for the panels
-		panelState := &dfx.State{
-			Size:     imgui.Vec2{X: state.Size.X, Y: panelHeight},
-			Position: state.Position,
-			IO:       state.IO,
-			App:      state.App,
-			Parent:   nil,
-		}
-
-		// first collapsible panel (draws directly, no wrapper)
-		drumsCollapse.Draw(panelState)
-
-		imgui.SameLine()
-
-		// second collapsible panel
-		synthsCollapse.Draw(panelState)
-
-		imgui.SameLine()
-
-		// main content area (fills remaining space)
-		remaining := state.Size.X - drumsCollapse.CurrentWidth - synthsCollapse.CurrentWidth - 20
-		if remaining > 50 {
-			imgui.BeginChildStrV("main", imgui.Vec2{X: remaining, Y: panelHeight}, imgui.ChildFlagsBorders, 0)
-			imgui.Text("Main Content Area")
-			imgui.Separator()
-			imgui.Spacing()
-			imgui.Text("This area expands as panels collapse.")
-			imgui.Spacing()
-			imgui.Text(fmt.Sprintf("Drums panel: %.0fpx", drumsCollapse.CurrentWidth))
-			imgui.Text(fmt.Sprintf("Synths panel: %.0fpx", synthsCollapse.CurrentWidth))
-			imgui.Text(fmt.Sprintf("Main area: %.0fpx", remaining))
-			imgui.Spacing()
-			imgui.Separator()
-			imgui.Spacing()
-			imgui.Text("Tips:")
-			imgui.BulletText("Click chevron icons to toggle panels")
-			imgui.BulletText("Drag the resize handle to adjust width")
-			imgui.BulletText("Use keyboard shortcuts [ and ] to toggle")
-			imgui.EndChild()
-		}
+		group.Draw(state.Child(imgui.Vec2{X: state.Size.X, Y: panelHeight}, state.Position))
 	})
 
 	app := dfx.New(root, dfx.Config{
@@ -190,21 +173,7 @@ func createMixerPanel(id string, channels []*MixerChannel) dfx.Component {
 				imgui.Text(ch.name)
 			}
 
-			// row 2: meters
-			imgui.TableNextRow()
-			for _, ch := range channels {
-				imgui.TableNextColumn()
-				// center the meter in the column
-				meterWidth := ch.meter.Width()
-				padding := (channelWidth - meterWidth) / 2
-				if padding > 0 {
-					imgui.Dummy(imgui.Vec2{X: padding, Y: 0})
-					imgui.SameLine()
-				}
-				ch.meter.Draw(state)
-			}
-
-			// row 3: faders
+			// row 2: faders with their meters
 			imgui.TableNextRow()
 			for i, ch := range channels {
 				imgui.TableNextColumn()
@@ -221,12 +190,12 @@ func createMixerPanel(id string, channels []*MixerChannel) dfx.Component {
 					return fmt.Sprintf("%.1f dB", db)
 				}
 
-				if newValue, changed := dfx.FaderN(fmt.Sprintf("##%s_fader%d", id, i), ch.normalized, params); changed {
+				if newValue, changed := dfx.FaderWithMeter(fmt.Sprintf("##%s_fader%d", id, i), ch.normalized, ch.level, params); changed {
 					ch.normalized = newValue
 				}
 			}
 
-			// row 4: values
+			// row 3: values
 			imgui.TableNextRow()
 			for _, ch := range channels {
 				imgui.TableNextColumn()
@@ -268,6 +237,6 @@ func simulateLevels(channels []*MixerChannel, t float64, offset int) {
 			level = 1.0
 		}
 
-		ch.meter.SetLevel(0, level)
+		ch.level = level
 	}
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+)
+
+func main() {
+	// the textures are generated so the example needs no image files; use
+	// app.Textures().LoadFile(path) to load a PNG or JPEG from disk
+	var picture *dfx.Image
+	var buttons []*dfx.ImageButton
+	scale := 0
+	scales := []string{"fit", "fill", "stretch", "none"}
+	clicked := -1
+
+	root := dfx.NewFunc(func(state *dfx.State) {
+		if newScale, changed := dfx.Combo("scale", scale, scales); changed {
+			scale = newScale
+			picture.Scale = dfx.ImageScale(scale)
+		}
+		imgui.SameLine()
+		tint := [3]float32{picture.Tint.X, picture.Tint.Y, picture.Tint.Z}
+		if picture.Tint == (imgui.Vec4{}) {
+			tint = [3]float32{1, 1, 1}
+		}
+		if r, g, b, changed := dfx.ColorEdit3("tint", tint[0], tint[1], tint[2]); changed {
+			picture.Tint = imgui.Vec4{X: r, Y: g, Z: b, W: 1}
+		}
+
+		// buttons drawing quarters of a sprite sheet via UV sub-rects
+		for i, b := range buttons {
+			if i > 0 {
+				imgui.SameLine()
+			}
+			b.Draw(state)
+		}
+		imgui.SameLine()
+		if clicked >= 0 {
+			imgui.Text(fmt.Sprintf("clicked tile %d", clicked))
+		}
+
+		imgui.Separator()
+		picture.Draw(state)
+	})
+
+	app := dfx.New(root, dfx.Config{
+		Title:  "Image Example",
+		Width:  800,
+		Height: 600,
+		OnSetup: func(app *dfx.App) {
+			picture = dfx.NewImage(app.Textures().Load("gradient", gradient(320, 160)))
+
+			sheet := app.Textures().Load("sheet", spriteSheet(64))
+			for i := 0; i < 4; i++ {
+				tile := i
+				b := dfx.NewImageButton(fmt.Sprintf("tile%d", i), sheet, func() { clicked = tile })
+				b.Size = imgui.Vec2{X: 32, Y: 32}
+				b.UV0 = imgui.Vec2{X: float32(i%2) * 0.5, Y: float32(i/2) * 0.5}
+				b.UV1 = b.UV0.Add(imgui.Vec2{X: 0.5, Y: 0.5})
+				b.Tooltip = fmt.Sprintf("tile %d", i)
+				buttons = append(buttons, b)
+			}
+		},
+	})
+
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// gradient creates a w x h image blending across both axes.
+func gradient(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(255 * x / w), G: uint8(255 * y / h), B: 160, A: 255})
+		}
+	}
+	return img
+}
+
+// spriteSheet creates a size x size image with four colored quadrants.
+func spriteSheet(size int) image.Image {
+	colors := []color.RGBA{{230, 80, 80, 255}, {80, 200, 90, 255}, {80, 120, 230, 255}, {230, 200, 60, 255}}
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	half := size / 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, colors[(y/half)*2+x/half])
+		}
+	}
+	return img
+}
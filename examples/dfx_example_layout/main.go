@@ -15,6 +15,7 @@ import (
 // See docs/LAYOUT_GUIDE.md for comprehensive documentation.
 
 func main() {
+	components := layoutComponents()
 	root := dfx.NewFunc(func(state *dfx.State) {
 		// Show dfx state.Size at the top
 		imgui.Text("dfx Layout & Sizing Demo")
@@ -32,6 +33,7 @@ func main() {
 		columnsDemo()
 		tablesDemo()
 		practicalPatternsDemo()
+		layoutComponentsDemo(state, components)
 	})
 
 	app := dfx.New(root, dfx.Config{
@@ -607,3 +609,60 @@ func practicalPatternsDemo() {
 	imgui.SameLine()
 	imgui.Button("About")
 }
+
+// =============================================================================
+// Demo 10: dfx Layout Components
+// =============================================================================
+
+// layoutComponents builds the toolbar/sidebar/content layout of practicalPatternsDemo
+// from VStack, HStack, Grid, Spacer and Align, with no pixel math.
+func layoutComponents() dfx.Component {
+	button := func(label string) dfx.Component {
+		return dfx.NewFunc(func(state *dfx.State) {
+			imgui.ButtonV(label, state.Size)
+		})
+	}
+	panel := func(label string) dfx.Component {
+		return dfx.NewFunc(func(state *dfx.State) {
+			imgui.Text(fmt.Sprintf("%v (%.0f x %.0f)", label, state.Size.X, state.Size.Y))
+		})
+	}
+
+	toolbar := dfx.NewHStack(
+		dfx.Fixed(button("File"), 60),
+		dfx.Fixed(button("Edit"), 60),
+		dfx.Fixed(button("View"), 60),
+		dfx.Flex(dfx.NewSpacer(), 1),
+		dfx.Fixed(button("Help"), 60),
+	)
+
+	grid := dfx.NewGrid(3)
+	for i := range 6 {
+		grid.Cells = append(grid.Cells, button(fmt.Sprintf("Cell %d", i+1)))
+	}
+
+	body := dfx.NewHStack(
+		dfx.Flex(panel("30%"), 3),
+		dfx.Flex(dfx.NewVStack(
+			dfx.Flex(grid, 2),
+			dfx.Flex(dfx.NewAlign(button("Centered"), 120, 0), 1),
+		), 7),
+	)
+
+	return dfx.NewVStack(
+		dfx.Fixed(toolbar, 24),
+		dfx.Flex(body, 1),
+		dfx.Fixed(dfx.NewAlign(panel("Footer"), 160, 0), 20),
+	)
+}
+
+func layoutComponentsDemo(state *dfx.State, layout dfx.Component) {
+	if !imgui.CollapsingHeaderTreeNodeFlagsV("10. dfx Layout Components", imgui.TreeNodeFlagsNone) {
+		return
+	}
+
+	imgui.TextWrapped("VStack, HStack, Grid, Spacer and Align size their children from state.Size. " +
+		"The same toolbar, split and footer as the patterns above, without SameLine or Dummy.")
+	avail := imgui.ContentRegionAvail()
+	layout.Draw(state.Child(imgui.NewVec2(avail.X, 240), imgui.Vec2{}))
+}
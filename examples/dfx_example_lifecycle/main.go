@@ -3,19 +3,18 @@ package main
 import (
 	"fmt"
 
-	"github.com/michaelquigley/dfx"
 	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
 )
 
 type state struct {
-	windowWidth     int
-	windowHeight    int
-	windowX         int
-	windowY         int
-	resizeCount     int
-	titleCounter    int
-	showCloseDialog bool
-	unsavedChanges  bool
+	windowWidth    int
+	windowHeight   int
+	windowX        int
+	windowY        int
+	resizeCount    int
+	titleCounter   int
+	unsavedChanges bool
 }
 
 func main() {
@@ -70,32 +69,6 @@ func main() {
 		if imgui.Button("Get Current Size") {
 			s.windowWidth, s.windowHeight = state.App.GetWindowSize()
 		}
-
-		// close confirmation dialog
-		if s.showCloseDialog {
-			imgui.OpenPopupStr("Confirm Close")
-			s.showCloseDialog = false
-		}
-
-		// modal dialog
-		if imgui.BeginPopupModalV("Confirm Close", nil, imgui.WindowFlagsAlwaysAutoResize) {
-			imgui.Text("You have unsaved changes!")
-			imgui.Text("Are you sure you want to close?")
-			imgui.Spacing()
-
-			if imgui.Button("Close Anyway") {
-				s.unsavedChanges = false
-				state.App.SetShouldClose(true)
-				imgui.CloseCurrentPopup()
-			}
-			imgui.SameLine()
-			if imgui.Button("Cancel") {
-				// window will not close
-				imgui.CloseCurrentPopup()
-			}
-
-			imgui.EndPopup()
-		}
 	})
 
 	app := dfx.New(root, dfx.Config{
@@ -114,12 +87,8 @@ func main() {
 			})
 		},
 		OnClose: func(app *dfx.App) {
-			fmt.Println("window close requested")
-			if s.unsavedChanges {
-				// cancel the close and show confirmation dialog
-				app.SetShouldClose(false)
-				s.showCloseDialog = true
-			}
+			// called once unsaved changes are saved or discarded
+			fmt.Println("window closing")
 		},
 		OnSizeChange: func(width, height int) {
 			fmt.Printf("window resized to %d x %d\n", width, height)
@@ -129,5 +98,15 @@ func main() {
 		},
 	})
 
+	// ask to save or discard unsaved changes when the window is closed
+	app.ConfirmQuit(&dfx.QuitConfirm{
+		Dirty: func() bool { return s.unsavedChanges },
+		Save: func() error {
+			fmt.Println("saving changes")
+			s.unsavedChanges = false
+			return nil
+		},
+	})
+
 	app.Run()
 }
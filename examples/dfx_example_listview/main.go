@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+)
+
+// tracks is a ListModel over a large generated playlist; only the visible rows are
+// rendered, so the list stays responsive at any size.
+type tracks struct {
+	names     []string
+	durations []int
+}
+
+func newTracks(n int) *tracks {
+	adjectives := []string{"Blue", "Silent", "Electric", "Golden", "Midnight", "Paper", "Velvet", "Broken"}
+	nouns := []string{"Harbor", "Signal", "Garden", "Engine", "Satellite", "River", "Machine", "Lantern"}
+	t := &tracks{}
+	for i := 0; i < n; i++ {
+		t.names = append(t.names, fmt.Sprintf("%s %s %d", adjectives[i%len(adjectives)], nouns[(i/len(adjectives))%len(nouns)], i))
+		t.durations = append(t.durations, 90+(i*37)%300)
+	}
+	return t
+}
+
+func (t *tracks) Count() int { return len(t.names) }
+
+func (t *tracks) Render(index int) {
+	imgui.TextUnformatted(t.names[index])
+	imgui.SameLineV(imgui.ContentRegionAvail().X-40, 0)
+	d := t.durations[index]
+	imgui.TextColored(dfx.ThemeColors().Muted, fmt.Sprintf("%d:%02d", d/60, d%60))
+}
+
+func (t *tracks) Match(index int, query string) bool {
+	return strings.Contains(strings.ToLower(t.names[index]), strings.ToLower(query))
+}
+
+// reorder moves the items at indices (ascending) to before or after target.
+func reorder(items dfx.StringList, indices []int, target int, after bool) dfx.StringList {
+	if after {
+		target++
+	}
+	var moved, kept dfx.StringList
+	insert := 0
+	for i, item := range items {
+		if slices.Contains(indices, i) {
+			moved = append(moved, item)
+			continue
+		}
+		if i < target {
+			insert++
+		}
+		kept = append(kept, item)
+	}
+	return slices.Concat(kept[:insert], moved, kept[insert:])
+}
+
+func main() {
+	playlist := newTracks(100000)
+	playing := -1
+
+	list := dfx.NewListView(playlist)
+	list.ShowSearch = true
+	list.SearchHint = "search 100,000 tracks"
+	list.Selection.Mode = dfx.SelectMulti
+	list.OnActivate = func(index int) { playing = index }
+	list.Draggable = true
+	var queued []string
+
+	fruits := dfx.NewListView(dfx.StringList{"apple", "banana", "cherry", "date", "elderberry", "fig", "grape"})
+	fruits.Height = 150
+	fruits.Draggable = true
+	fruits.OnDrop = func(drag dfx.ListDrag, target int, after bool) {
+		// reorder within the list; drags from the playlist are ignored
+		if drag.Source == fruits {
+			fruits.Model = reorder(fruits.Model.(dfx.StringList), drag.Indices, target, after)
+			fruits.Selection.Clear()
+		}
+	}
+
+	root := dfx.NewFunc(func(state *dfx.State) {
+		imgui.Text("Click, Ctrl+click and Shift+click to select; arrow keys, Home/End and PageUp/PageDown")
+		imgui.Text("navigate; type to jump to a matching track; double-click or Enter to play. Drag tracks to")
+		imgui.Text("the queue, and drag fruits to reorder them.")
+		if playing >= 0 {
+			imgui.Text(fmt.Sprintf("playing: %s", playlist.names[playing]))
+		} else {
+			imgui.Text("playing: -")
+		}
+		imgui.SameLine()
+		imgui.TextColored(dfx.ThemeColors().Muted, fmt.Sprintf("(%d shown, %d selected)", list.DisplayedCount(), list.Selection.Len()))
+		imgui.Separator()
+
+		if imgui.BeginTable("##lists", 2) {
+			imgui.TableSetupColumnV("playlist", imgui.TableColumnFlagsWidthStretch, 3, 0)
+			imgui.TableSetupColumnV("fruits", imgui.TableColumnFlagsWidthStretch, 1, 0)
+			imgui.TableNextColumn()
+			list.Draw(state)
+			imgui.TableNextColumn()
+			imgui.Text("StringList:")
+			fruits.Draw(state)
+			if selected, ok := fruits.Selection.First(); ok {
+				imgui.Text(fmt.Sprintf("selected: %s", fruits.Model.(dfx.StringList)[selected]))
+			}
+			imgui.Separator()
+			imgui.Button(fmt.Sprintf("drop tracks here to queue (%d queued)", len(queued)))
+			fromPlaylist := func(drag dfx.ListDrag) bool { return drag.Source == list }
+			if drag, ok := dfx.DropTargetIf(dfx.DragKindListRows, fromPlaylist); ok {
+				for _, index := range drag.Indices {
+					queued = append(queued, playlist.names[index])
+				}
+			}
+			imgui.EndTable()
+		}
+	})
+
+	app := dfx.New(root, dfx.Config{
+		Title:  "ListView Example",
+		Width:  900,
+		Height: 700,
+	})
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
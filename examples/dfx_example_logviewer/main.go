@@ -45,9 +45,8 @@ func main() {
 	viewer.ShowFunc = true
 	viewer.ShowFields = true
 
-	// create toolbar with controls
-	toolbar := dfx.NewFunc(func(state *dfx.State) {
-		// copy button
+	// add app-specific controls to the viewer's built-in filter toolbar
+	viewer.ToolbarExtra = func() {
 		if imgui.Button(fonts.ICON_COPY_ALL) {
 			text := buffer.AllText()
 			clipboard.Write(clipboard.FmtText, []byte(text))
@@ -78,20 +77,16 @@ func main() {
 		imgui.SameLine()
 		imgui.Text(fmt.Sprintf("Messages: %d", buffer.Count()))
 
+		imgui.SameLine()
+		if imgui.Button(fonts.ICON_WRAP_TEXT) {
+			viewer.WrapMessages = !viewer.WrapMessages
+		}
+		imgui.SetItemTooltip("wrap long messages")
+
 		imgui.SameLine()
 		if imgui.Button("Generate Logs") {
 			generateTestLogs()
 		}
-	})
-
-	// create container with toolbar and viewer
-	root := &dfx.Container{
-		Visible: true,
-		OnDraw: func(state *dfx.State) {
-			toolbar.Draw(state)
-			imgui.Separator()
-		},
-		Children: []dfx.Component{viewer},
 	}
 
 	// generate some initial log messages
@@ -102,7 +97,7 @@ func main() {
 	dl.Log().With("key1", "value1").With("key2", 42).Info("message with fields")
 
 	// create and run application
-	app := dfx.New(root, dfx.Config{
+	app := dfx.New(viewer, dfx.Config{
 		Title:  "Log Viewer Example",
 		Width:  1000,
 		Height: 600,
@@ -134,4 +129,9 @@ func generateTestLogs() {
 	dl.Log().Error("this is an error message")
 	dl.Log().With("timestamp", time.Now()).With("count", 123).Info("info message with fields")
 	dl.Log().With("details", "some detailed information").Warn("warning with context")
+	dl.ChannelLog("network").With("port", 8080).Info("listening")
+	dl.ChannelLog("audio").Debug("buffer underrun")
+	dl.Log().With("request", map[string]any{"method": "GET", "path": "/api/v1/status", "headers": []string{"accept", "user-agent"}}).
+		With("elapsed", 0.0123).With("cached", false).Info("request complete")
+	dl.Log().Warn("configuration reloaded\n  2 sections changed\n  1 section removed")
 }
@@ -139,6 +139,7 @@ func main() {
 					case 2: // Audio taper with scale
 						params := dfx.DefaultFaderParams()
 						params.Taper = dfx.AudioTaper()
+						params.Style = dfx.HardwareFaderStyle()
 						params.Format = func(norm float32) string {
 							return fmt.Sprintf("Audio: %.3f", norm)
 						}
@@ -85,6 +85,8 @@ func main() {
 		{"editor", "sidebar"},
 		{"terminal", "properties"},
 	})
+	flexLayout.SetMinSize("sidebar", 160, 0)
+	flexLayout.SetMinSize("terminal", 0, 80)
 
 	// create fixed grid layout
 	gridLayout := dfx.NewGridLayout(3, 2)
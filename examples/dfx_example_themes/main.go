@@ -20,6 +20,10 @@ func main() {
 		dfx.RedTheme,
 		dfx.PurpleTheme,
 		dfx.ModernDark,
+		dfx.ModernLight,
+		dfx.BlueLightTheme,
+		dfx.GreenLightTheme,
+		dfx.HighContrast,
 	}
 
 	root := dfx.NewFunc(func(state *dfx.State) {
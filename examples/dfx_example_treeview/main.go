@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// library is a TreeModel over a sample library of banks, folders and patches. folder
+// contents are generated the first time they are requested, as a slow source would load
+// them, and nodes can be reordered by dragging.
+type library struct {
+	children map[string][]string
+	loads    int
+}
+
+func newLibrary() *library {
+	return &library{children: map[string][]string{
+		"": {"bank/factory", "bank/user", "bank/empty"},
+	}}
+}
+
+func (l *library) Children(id string) []string {
+	if children, found := l.children[id]; found {
+		return children
+	}
+	l.loads++
+	var children []string
+	switch {
+	case strings.HasPrefix(id, "bank/"):
+		for _, folder := range []string{"bass", "keys", "pads", "leads"} {
+			children = append(children, id+"/"+folder)
+		}
+	case strings.Count(id, "/") == 2:
+		for i := 1; i <= 8; i++ {
+			children = append(children, fmt.Sprintf("%s/%02d", id, i))
+		}
+	}
+	l.children[id] = children
+	return children
+}
+
+func (l *library) Label(id string) string {
+	label := id[strings.LastIndex(id, "/")+1:]
+	if strings.Count(id, "/") == 3 {
+		return "patch " + label
+	}
+	return label
+}
+
+func (l *library) Icon(id string) string {
+	switch strings.Count(id, "/") {
+	case 1:
+		return fonts.ICON_LIBRARY_MUSIC
+	case 2:
+		return fonts.ICON_FOLDER
+	}
+	return fonts.ICON_MUSIC_NOTE
+}
+
+func (l *library) IsLeaf(id string) bool {
+	return strings.Count(id, "/") == 3 || id == "bank/empty"
+}
+
+// move implements dragging: id is removed from its parent and inserted relative to target.
+func (l *library) move(id, target string, pos dfx.TreeDropPosition, parentOf func(string) string) {
+	from := parentOf(id)
+	l.children[from] = slices.DeleteFunc(l.children[from], func(c string) bool { return c == id })
+	if pos == dfx.TreeDropInto {
+		l.children[target] = append(l.Children(target), id)
+		return
+	}
+	to := parentOf(target)
+	siblings := l.children[to]
+	i := slices.Index(siblings, target)
+	if pos == dfx.TreeDropAfter {
+		i++
+	}
+	l.children[to] = slices.Insert(siblings, i, id)
+}
+
+func main() {
+	model := newLibrary()
+	tree := dfx.NewTreeView(model)
+	tree.Selection.Mode = dfx.SelectMulti
+	tree.Checkboxes = true
+	tree.SetExpanded("bank/factory", true)
+
+	activated := "-"
+	tree.OnActivate = func(id string) { activated = model.Label(id) }
+
+	// only patches move, and only within folders
+	tree.CanDrop = func(id, target string, pos dfx.TreeDropPosition) bool {
+		if strings.Count(id, "/") != 3 {
+			return false
+		}
+		if pos == dfx.TreeDropInto {
+			return strings.Count(target, "/") == 2
+		}
+		return strings.Count(target, "/") == 3
+	}
+	tree.OnDrop = func(id, target string, pos dfx.TreeDropPosition) {
+		model.move(id, target, pos, func(id string) string {
+			parent, _ := tree.Parent(id)
+			return parent
+		})
+	}
+
+	root := dfx.NewFunc(func(state *dfx.State) {
+		imgui.Text("Click the arrows or use Left/Right to expand; folders are loaded on first expansion.")
+		imgui.Text("Drag patches to reorder them or move them to another folder; Space toggles checkboxes.")
+		imgui.TextColored(dfx.ThemeColors().Muted, fmt.Sprintf("activated: %s | selected: %d | checked: %d | folders loaded: %d",
+			activated, tree.Selection.Len(), len(tree.CheckedIDs()), model.loads))
+		imgui.Separator()
+		tree.Draw(state)
+	})
+
+	app := dfx.New(root, dfx.Config{
+		Title:  "TreeView Example",
+		Width:  700,
+		Height: 700,
+	})
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
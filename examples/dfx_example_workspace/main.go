@@ -11,7 +11,6 @@ import (
 func main() {
 	// shared application state
 	var editorText = "// edit your code here\npackage main\n\nfunc main() {\n    fmt.Println(\"oh, wow!\")\n}"
-	var viewerScale float32 = 1.0
 	var settingsUsername = "user"
 	var settingsDarkMode = true
 	var settingsVolume float32 = 50.0
@@ -33,50 +32,34 @@ func main() {
 		imgui.Text(fmt.Sprintf("lines: %d | chars: %d", countLines(editorText), len(editorText)))
 	})
 
-	// create viewer workspace
-	viewer := dfx.NewFunc(func(state *dfx.State) {
-		imgui.Text("Content Viewer")
-		imgui.Separator()
-		imgui.Spacing()
-
-		// zoom control
-		newScale, changed := dfx.Slider("Zoom", viewerScale, 0.5, 3.0)
-		if changed {
-			viewerScale = newScale
+	// create viewer workspace; the content draws at its actual size and the zoom pan view
+	// scales it (wheel to zoom, middle or space drag to pan, Shift+1 to fit)
+	viewer := dfx.NewZoomPan(dfx.NewFunc(func(state *dfx.State) {
+		drawList := imgui.WindowDrawList()
+		pos := imgui.CursorScreenPos()
+
+		// colored rectangles to simulate content
+		baseSize := float32(50)
+		spacing := float32(10)
+		colors := []imgui.Vec4{
+			{X: 0.8, Y: 0.2, Z: 0.2, W: 1.0}, // red
+			{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0}, // green
+			{X: 0.2, Y: 0.2, Z: 0.8, W: 1.0}, // blue
+			{X: 0.8, Y: 0.8, Z: 0.2, W: 1.0}, // yellow
 		}
 
-		imgui.Spacing()
-		imgui.Separator()
-
-		// simulate viewing content with colored rectangles
-		if imgui.BeginChildStrV("viewer_content", imgui.Vec2{X: 0, Y: 0}, imgui.ChildFlagsNone, imgui.WindowFlagsNone) {
-			drawList := imgui.WindowDrawList()
-			pos := imgui.CursorScreenPos()
-
-			// draw scaled rectangles to simulate content
-			baseSize := float32(50) * viewerScale
-			spacing := float32(10)
-			colors := []imgui.Vec4{
-				{X: 0.8, Y: 0.2, Z: 0.2, W: 1.0}, // red
-				{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0}, // green
-				{X: 0.2, Y: 0.2, Z: 0.8, W: 1.0}, // blue
-				{X: 0.8, Y: 0.8, Z: 0.2, W: 1.0}, // yellow
-			}
-
-			for i, col := range colors {
-				offset := float32(i) * (baseSize + spacing)
-				rectPos := pos.Add(imgui.Vec2{X: offset, Y: 0})
-				rectEnd := rectPos.Add(imgui.Vec2{X: baseSize, Y: baseSize})
-				drawList.AddRectFilled(rectPos, rectEnd, imgui.ColorConvertFloat4ToU32(col))
-			}
-
-			// advance cursor
-			imgui.Dummy(imgui.Vec2{X: 0, Y: baseSize + spacing})
-			imgui.Text(fmt.Sprintf("viewing at %.1fx zoom", viewerScale))
-
-			imgui.EndChild()
+		for i, col := range colors {
+			offset := float32(i) * (baseSize + spacing)
+			rectPos := pos.Add(imgui.Vec2{X: offset, Y: 0})
+			rectEnd := rectPos.Add(imgui.Vec2{X: baseSize, Y: baseSize})
+			drawList.AddRectFilled(rectPos, rectEnd, imgui.ColorConvertFloat4ToU32(col))
 		}
-	})
+
+		// advance cursor
+		imgui.Dummy(imgui.Vec2{X: 0, Y: baseSize + spacing})
+		imgui.Text("Content Viewer")
+	}))
+	viewer.Fit()
 
 	// create settings workspace
 	settings := dfx.NewFunc(func(state *dfx.State) {
@@ -128,6 +111,8 @@ func main() {
 	ws.ShowSelector = true
 	ws.SelectorLabel = "Workspace"
 	ws.SelectorWidth = 200
+	ws.ShowSwitcher = true
+	ws.EnableCycling() // ctrl+tab / ctrl+shift+tab
 
 	// add switch callback (receives stable IDs)
 	ws.OnSwitch = func(oldID, newID string) {
@@ -170,6 +155,14 @@ func main() {
 			if imgui.MenuItemBoolV(fonts.ICON_SETTINGS+" Settings", "Ctrl+3", false, true) {
 				ws.Switch("settings")
 			}
+			imgui.Separator()
+			if imgui.MenuItemBoolV("Tab Strip Selector", "", ws.SelectorStyle == dfx.WorkspaceSelectorTabs, true) {
+				if ws.SelectorStyle == dfx.WorkspaceSelectorTabs {
+					ws.SelectorStyle = dfx.WorkspaceSelectorCombo
+				} else {
+					ws.SelectorStyle = dfx.WorkspaceSelectorTabs
+				}
+			}
 			imgui.EndMenu()
 		}
 
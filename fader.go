@@ -1,10 +1,13 @@
 package dfx
 
 import (
-	"fmt"
 	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
 )
 
 // ============================================================================
@@ -176,6 +179,13 @@ func CustomTaper(apply, invert func(float32) float32) Taper {
 // Fader Parameters
 // ============================================================================
 
+// fader constants
+const (
+	DefaultFineDrag     = 0.1                    // Shift+drag resolution: a tenth of the mouse movement
+	DefaultDetentRange  = 6.0                    // pixels from a detent within which a drag snaps to it
+	FaderDetentDuration = 250 * time.Millisecond // highlight shown when a drag snaps to a detent
+)
+
 // FaderParams configures extended fader behavior.
 type FaderParams struct {
 	// Taper curve (affects UI feel, not values)
@@ -194,14 +204,45 @@ type FaderParams struct {
 	Height float32 // default 300.0
 
 	// Display options
-	Format      func(normalized float32) string // optional: custom tooltip format
+	Format      func(normalized float32) string // optional: custom tooltip format (default: 3 decimals in CurrentFormat())
 	ShowTooltip bool                            // show value on hover (default true)
 
+	// Typed entry: double-click opens a text field with the formatted value, and Parse
+	// reads what was typed back as a normalized value (default: a number as in NumberInput,
+	// in the FaderF/FaderI range when those draw the fader)
+	Parse func(text string) (float32, error)
+
 	// Mouse wheel sensitivity
 	WheelSteps float32 // default 100.0 (finer = more steps)
 
 	// Custom track/background color (nil = use theme default)
 	TrackColor *imgui.Vec4
+
+	// Custom drawing of the groove, cap and value text (nil = imgui slider look)
+	Style *FaderStyle
+
+	// Meter drawn by FaderWithMeter, in or beside the track
+	Meter FaderMeterParams
+
+	// Keyboard steps across the range while focused or active (default 50; Shift = 10x finer).
+	// Page Up/Down move PageSteps steps (default 10), Home and End jump to the stops
+	KeySteps  float32
+	PageSteps float32
+
+	// Shift+drag resolution: the fader moves this fraction of the mouse movement (default 0.1)
+	FineDrag float32
+
+	// Detents (in normalized 0-1 space): dragging within DetentRange pixels of one snaps
+	// the fader to it, with a brief highlight as it engages. hold Alt to drag past them
+	Detents     []float32
+	DetentRange float32 // default 6.0
+
+	// Accessibility: announced with the value when the fader gains keyboard focus
+	AccessibleLabel       string // default = the visible part of the label
+	AccessibleDescription string // also shown in the tooltip
+
+	// drawn over the track and below the cap, given the span the cap travels (set by FaderWithMeter)
+	underCap func(min, max imgui.Vec2)
 }
 
 // DefaultFaderParams returns sensible default parameters.
@@ -215,6 +256,11 @@ func DefaultFaderParams() FaderParams {
 		Height:      300.0,
 		ShowTooltip: true,
 		WheelSteps:  100.0,
+		KeySteps:    DefaultKeySteps,
+		PageSteps:   keyStepsPage,
+		FineDrag:    DefaultFineDrag,
+		DetentRange: DefaultDetentRange,
+		Meter:       DefaultFaderMeterParams(),
 	}
 }
 
@@ -241,10 +287,24 @@ func FaderN(label string, value float32, params FaderParams) (float32, bool) {
 	if params.MaxStop == 0 {
 		params.MaxStop = 1.0
 	}
+	if params.PageSteps == 0 {
+		params.PageSteps = keyStepsPage
+	}
+	if params.FineDrag == 0 {
+		params.FineDrag = DefaultFineDrag
+	}
+	if params.DetentRange == 0 {
+		params.DetentRange = DefaultDetentRange
+	}
 
 	// Clamp value to range stops
 	value = clamp(value, params.MinStop, params.MaxStop)
 
+	// Draw the typed entry in place of the fader while it is open
+	if faderEntry.id == imgui.IDStr(label) {
+		return faderTypedEntry(label, value, params)
+	}
+
 	// Apply taper to get UI position
 	uiPosition := params.Taper.Apply(value)
 
@@ -257,11 +317,55 @@ func FaderN(label string, value float32, params FaderParams) (float32, bool) {
 	// Draw vertical slider
 	newUIPosition := uiPosition
 	size := imgui.Vec2{X: params.Width, Y: params.Height}
+	var hidden int32
+	if params.Style != nil {
+		hidden = pushFaderStyle()
+	}
+	dl := imgui.WindowDrawList()
+	underTrack := params.underCap != nil && params.Style == nil
+	if underTrack {
+		// the slider draws its grab over a track drawn afterwards in a lower channel
+		dl.ChannelsSplit(2)
+		dl.ChannelsSetCurrent(1)
+		hidden += pushFaderTrack()
+	}
 	changed := imgui.VSliderFloatV(label, size, &newUIPosition, 0.0, 1.0, "", imgui.SliderFlagsNone)
+	imgui.PopStyleColorV(hidden)
+	rectMin, rectMax, active := imgui.ItemRectMin(), imgui.ItemRectMax(), imgui.IsItemActive()
+	if underTrack {
+		dl.ChannelsSetCurrent(0)
+		drawFaderTrack(rectMin, rectMax, active, imgui.IsItemHovered())
+		params.underCap(rectMin, rectMax)
+		dl.ChannelsMerge()
+	}
+	if position, fine := fineDrag(imgui.ItemID(), uiPosition, params); fine {
+		newUIPosition = position
+		changed = newUIPosition != uiPosition
+	}
+	id := imgui.ItemID()
 
 	// Invert taper to get normalized value
 	newValue := params.Taper.Invert(newUIPosition)
 
+	// Snap drags to detents
+	if snapped, ok := faderDetents(id, newUIPosition, params); ok {
+		newValue = snapped
+		changed = newValue != value
+	}
+
+	// Handle double-click typed entry, undoing the jump of the first click
+	if imgui.IsItemActivated() && !imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+		faderPress.id, faderPress.value = id, value
+	}
+	if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+		if faderPress.id == id {
+			newValue = faderPress.value
+		}
+		imgui.InternalClearActiveID()
+		faderEntry.id, faderEntry.text, faderEntry.focus, faderEntry.err = id, faderFormat(newValue, params), true, nil
+		return newValue, newValue != value
+	}
+
 	// Handle right-click reset
 	if imgui.IsItemHovered() && imgui.IsMouseClickedBool(imgui.MouseButtonRight) {
 		newValue = params.ResetValue
@@ -301,23 +405,266 @@ func FaderN(label string, value float32, params FaderParams) (float32, bool) {
 		}
 	}
 
-	// Show tooltip
-	if params.ShowTooltip && imgui.IsItemHovered() {
-		var tooltipText string
-		if params.Format != nil {
-			tooltipText = params.Format(newValue)
-		} else {
-			tooltipText = fmt.Sprintf("%.3f", newValue)
+	// Handle keyboard adjustment while focused or active
+	keyed := false
+	if imgui.IsItemFocused() || imgui.IsItemActive() {
+		if key := pressedStepKey(); key != stepNone {
+			newValue = faderStep(newValue, params, key, imgui.CurrentIO().KeyShift())
+			keyed = true
+			if newValue != value {
+				changed = true
+			}
 		}
-		imgui.SetTooltip(tooltipText)
 	}
 
 	// Clamp final value to range stops
 	newValue = clamp(newValue, params.MinStop, params.MaxStop)
 
+	valueText := faderFormat(newValue, params)
+	if params.Style != nil {
+		drawFaderStyle(rectMin, rectMax, params.Taper.Apply(newValue), valueText, active, params)
+	}
+
+	// Show tooltip
+	if params.ShowTooltip && imgui.IsItemHovered() {
+		imgui.SetTooltip(accessibleText(valueText, params.AccessibleDescription))
+	}
+
+	// Describe for assistive technology
+	accessibleLabel := params.AccessibleLabel
+	if accessibleLabel == "" {
+		accessibleLabel = visibleLabel(label)
+	}
+	AccessibleItem(accessibleLabel, params.AccessibleDescription, valueText)
+	if keyed && changed {
+		Announce(valueText)
+	}
+
 	return newValue, changed
 }
 
+// faderFormat formats a normalized value with Format, or 3 decimals in CurrentFormat().
+func faderFormat(value float32, params FaderParams) string {
+	if params.Format != nil {
+		return params.Format(value)
+	}
+	return floatText(value, 3)
+}
+
+// faderPress is the value a fader had when it was last pressed, restored when the press
+// turns out to be the first half of a double-click.
+var faderPress struct {
+	id    imgui.ID
+	value float32
+}
+
+// faderEntry is the fader being typed into, at most one at a time.
+var faderEntry struct {
+	id    imgui.ID
+	text  string
+	focus bool  // focus the text field on its next frame
+	err   error // why the last Enter was refused
+}
+
+// faderTypedEntry draws the text field used while typing a fader's value, in the fader's
+// place. Enter commits a valid value, clamped to the stops; an invalid one is outlined and
+// explained in a tooltip, and the field stays open. Escape or clicking away cancels.
+func faderTypedEntry(label string, value float32, params FaderParams) (float32, bool) {
+	pos := imgui.CursorScreenPos()
+	if faderEntry.focus {
+		imgui.SetKeyboardFocusHere()
+		faderEntry.focus = false
+	}
+	invalid := faderEntry.err != nil
+	if invalid {
+		imgui.PushStyleColorVec4(imgui.ColBorder, ThemeColors().Error)
+		imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, validationBorderSize)
+	}
+	imgui.SetNextItemWidth(max(params.Width, imgui.FrameHeight()*3))
+	flags := imgui.InputTextFlagsEnterReturnsTrue | imgui.InputTextFlagsAutoSelectAll
+	entered := imgui.InputTextWithHint("##entry"+label, "", &faderEntry.text, flags, nil)
+	if invalid {
+		imgui.PopStyleVar()
+		imgui.PopStyleColor()
+		imgui.SetItemTooltip(faderEntry.err.Error())
+	}
+	cancelled := imgui.IsKeyPressedBool(imgui.KeyEscape) || imgui.IsItemDeactivated()
+
+	// Keep the fader's space so the layout doesn't move while typing
+	imgui.SetCursorScreenPos(pos)
+	imgui.Dummy(imgui.Vec2{X: params.Width, Y: params.Height})
+
+	switch {
+	case entered:
+		newValue, err := parseFaderEntry(faderEntry.text, params)
+		if err != nil {
+			faderEntry.err, faderEntry.focus = err, true
+			return value, false
+		}
+		faderEntry.id = 0
+		Announce(faderFormat(newValue, params))
+		return newValue, newValue != value
+	case cancelled:
+		faderEntry.id = 0
+	}
+	return value, false
+}
+
+// parseFaderEntry reads typed text with Parse, or as a number in CurrentFormat(), and
+// clamps the normalized result to the stops.
+func parseFaderEntry(text string, params FaderParams) (float32, error) {
+	parse := params.Parse
+	if parse == nil {
+		parse = func(text string) (float32, error) {
+			v, err := ParseNumber(CurrentFormat().Canonical(text), "")
+			return float32(v), err
+		}
+	}
+	value, err := parse(strings.TrimSpace(text))
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(float64(value)) {
+		return 0, errors.Errorf("'%v' is not a number", text)
+	}
+	return clamp(value, params.MinStop, params.MaxStop), nil
+}
+
+// rangeFaderParams gives FaderF and FaderI a default Format and Parse working in their
+// range rather than in normalized space. integer values are rounded.
+func rangeFaderParams(params FaderParams, min, max float32, integer bool) FaderParams {
+	if params.Format == nil {
+		params.Format = func(normalized float32) string {
+			v := float64(normalized*(max-min) + min)
+			if integer {
+				return CurrentFormat().FormatInt(int64(math.Round(v)))
+			}
+			return CurrentFormat().FormatFloat(v, 3)
+		}
+	}
+	if params.Parse == nil {
+		params.Parse = func(text string) (float32, error) {
+			v, err := ParseNumber(CurrentFormat().Canonical(text), "")
+			if err != nil {
+				return 0, err
+			}
+			if integer {
+				v = math.Round(v)
+			}
+			return (float32(v) - min) / (max - min), nil
+		}
+	}
+	return params
+}
+
+// faderDetent is the detent a fader drag last snapped to, for its highlight.
+var faderDetent struct {
+	id      imgui.ID
+	detent  float32
+	engaged bool      // the drag is still held at the detent
+	at      time.Time // when it engaged
+}
+
+// faderDetents snaps a drag of the fader with id to the nearest detent within range,
+// unless Alt is held, and draws the highlight of a detent that recently engaged. returns
+// the detent and true while the drag is snapped.
+func faderDetents(id imgui.ID, position float32, params FaderParams) (float32, bool) {
+	detent, snapped := float32(0), false
+	if imgui.IsItemActive() && imgui.IsMouseDown(imgui.MouseButtonLeft) && !imgui.CurrentIO().KeyAlt() {
+		detent, snapped = nearestDetent(position, params, params.DetentRange/params.Height)
+	}
+	if snapped && (faderDetent.id != id || !faderDetent.engaged || faderDetent.detent != detent) {
+		faderDetent.id, faderDetent.detent, faderDetent.engaged, faderDetent.at = id, detent, true, time.Now()
+	} else if !snapped && faderDetent.id == id {
+		faderDetent.engaged = false
+	}
+
+	if since := time.Since(faderDetent.at); faderDetent.id == id && since < FaderDetentDuration {
+		min, max := imgui.ItemRectMin(), imgui.ItemRectMax()
+		y := max.Y - params.Taper.Apply(faderDetent.detent)*(max.Y-min.Y)
+		color := ThemeColors().Accent
+		color.W *= 1 - float32(since)/float32(FaderDetentDuration)
+		imgui.WindowDrawList().AddLineV(imgui.Vec2{X: min.X, Y: y}, imgui.Vec2{X: max.X, Y: y}, imgui.ColorConvertFloat4ToU32(color), 2)
+	}
+	return detent, snapped
+}
+
+// nearestDetent returns the detent nearest the tapered position, and whether it lies
+// within threshold of it in tapered space. detents outside the stops are ignored.
+func nearestDetent(position float32, params FaderParams, threshold float32) (float32, bool) {
+	nearest, found := float32(0), false
+	best := threshold
+	for _, detent := range params.Detents {
+		if detent < params.MinStop || detent > params.MaxStop {
+			continue
+		}
+		if d := float32(math.Abs(float64(params.Taper.Apply(detent) - position))); d <= best {
+			nearest, found, best = detent, true, d
+		}
+	}
+	return nearest, found
+}
+
+// faderFine is the fader being dragged relatively, once Shift was held during its drag.
+var faderFine struct {
+	id       imgui.ID
+	position float32 // tapered position, unclamped by the mouse
+}
+
+// fineDrag handles Shift+drag: once Shift is pressed during a drag the fader follows the
+// mouse movement rather than its position, scaled by FineDrag while Shift is held, so
+// pressing and releasing Shift never makes it jump. returns the tapered position and true
+// while the fader with id is dragged this way.
+func fineDrag(id imgui.ID, position float32, params FaderParams) (float32, bool) {
+	io := imgui.CurrentIO()
+	if !imgui.IsItemActive() || !imgui.IsMouseDown(imgui.MouseButtonLeft) {
+		if faderFine.id == id {
+			faderFine.id = 0
+		}
+		return position, false
+	}
+	if faderFine.id != id {
+		if !io.KeyShift() {
+			return position, false
+		}
+		faderFine.id, faderFine.position = id, position
+	}
+	scale := float32(1)
+	if io.KeyShift() {
+		scale = params.FineDrag
+	}
+	faderFine.position = fineDragPosition(faderFine.position, io.MouseDelta().Y, params.Height, scale)
+	return faderFine.position, true
+}
+
+// fineDragPosition moves a tapered position by a vertical mouse movement of dy pixels
+// on a fader height pixels tall, scaled by scale.
+func fineDragPosition(position, dy, height, scale float32) float32 {
+	return clamp(position-dy/height*scale, 0, 1)
+}
+
+// faderStep returns value moved by a step key: arrows move KeySteps steps across the
+// fader's travel (Shift = finer), Page Up and Down move PageSteps steps at once, and Home
+// and End jump to the stops. steps are taken in tapered space, so they feel even.
+func faderStep(value float32, params FaderParams, key stepKey, fine bool) float32 {
+	steps := params.KeySteps
+	if steps <= 0 {
+		steps = DefaultKeySteps
+	}
+	switch key {
+	case stepHome:
+		return params.MinStop
+	case stepEnd:
+		return params.MaxStop
+	case stepPageUp:
+		steps, key = steps/max(params.PageSteps, 1), stepUp
+	case stepPageDown:
+		steps, key = steps/max(params.PageSteps, 1), stepDown
+	}
+	position := applyStepKey(params.Taper.Apply(value), steps, key, fine)
+	return clamp(params.Taper.Invert(position), params.MinStop, params.MaxStop)
+}
+
 // FaderF draws a vertical fader working in an arbitrary float range.
 // Internally converts to/from normalized 0-1 space.
 // Example: -60.0 to +12.0 dB, 20.0 to 20000.0 Hz
@@ -327,7 +674,7 @@ func FaderF(label string, value, min, max float32, params FaderParams) (float32,
 	normalized = clamp(normalized, 0.0, 1.0)
 
 	// Call FaderN
-	newNormalized, changed := FaderN(label, normalized, params)
+	newNormalized, changed := FaderN(label, normalized, rangeFaderParams(params, min, max, false))
 
 	// Denormalize to original range
 	newValue := newNormalized*(max-min) + min
@@ -345,7 +692,7 @@ func FaderI(label string, value int, min, max int, params FaderParams) (int, boo
 	normalized = clamp(normalized, 0.0, 1.0)
 
 	// Call FaderN
-	newNormalized, changed := FaderN(label, normalized, params)
+	newNormalized, changed := FaderN(label, normalized, rangeFaderParams(params, float32(min), float32(max), true))
 
 	// Denormalize to original range and round
 	newValue := int(newNormalized*rangeF+0.5) + min
@@ -379,6 +726,9 @@ type ScaleConfig struct {
 	TickLength  float32 // Length of tick marks in pixels (default: 5.0)
 	LabelOffset float32 // Distance from ticks to labels in pixels (default: 3.0)
 	Position    string  // "left" or "right" (default: "left")
+
+	// Snap the fader to the marks, as extra detents
+	Detents bool
 }
 
 // DefaultScaleConfig returns sensible defaults for a fader scale.
@@ -462,23 +812,31 @@ func drawFaderScale(taper Taper, scale ScaleConfig) {
 	}
 }
 
+// scaleDetents adds the scale's marks to the fader's detents when the scale asks for it.
+func scaleDetents(params FaderParams, scale ScaleConfig) FaderParams {
+	if scale.Detents {
+		params.Detents = append(slices.Clip(params.Detents), scale.Marks...)
+	}
+	return params
+}
+
 // FaderWithScaleN draws a normalized fader (0.0-1.0) with tick marks and labels.
 func FaderWithScaleN(label string, value float32, params FaderParams, scale ScaleConfig) (float32, bool) {
-	newValue, changed := FaderN(label, value, params)
+	newValue, changed := FaderN(label, value, scaleDetents(params, scale))
 	drawFaderScale(params.Taper, scale)
 	return newValue, changed
 }
 
 // FaderWithScaleF draws a float-range fader with tick marks and labels.
 func FaderWithScaleF(label string, value, min, max float32, params FaderParams, scale ScaleConfig) (float32, bool) {
-	newValue, changed := FaderF(label, value, min, max, params)
+	newValue, changed := FaderF(label, value, min, max, scaleDetents(params, scale))
 	drawFaderScale(params.Taper, scale)
 	return newValue, changed
 }
 
 // FaderWithScaleI draws an integer-range fader with tick marks and labels.
 func FaderWithScaleI(label string, value int, min, max int, params FaderParams, scale ScaleConfig) (int, bool) {
-	newValue, changed := FaderI(label, value, min, max, params)
+	newValue, changed := FaderI(label, value, min, max, scaleDetents(params, scale))
 	drawFaderScale(params.Taper, scale)
 	return newValue, changed
 }
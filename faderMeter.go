@@ -0,0 +1,165 @@
+package dfx
+
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// FaderMeterPosition places the meter of FaderWithMeter.
+type FaderMeterPosition int
+
+const (
+	// FaderMeterBeside draws the meter right of the fader, as tall as its track (default).
+	FaderMeterBeside FaderMeterPosition = iota
+	// FaderMeterInside draws the meter in the fader's track, below the cap.
+	FaderMeterInside
+)
+
+// FaderMeterParams configures the meter drawn by FaderWithMeter. the level is drawn
+// through the fader's taper, so a level reads against the same scale as the fader.
+type FaderMeterParams struct {
+	Position FaderMeterPosition
+	Mode     VUMeterMode // rendering style (default: VUMeterSolid)
+
+	Width float32 // meter width, at most the track's inside (default: 8; 0 inside = a third of the track)
+	Gap   float32 // space between the fader and a meter beside it (default: 4)
+
+	// peak hold configuration
+	PeakHoldMs    int     // peak hold duration in ms, 0 = disabled (default: 1000)
+	PeakDecayRate float32 // peak decay rate per second (default: 0.5)
+}
+
+// DefaultFaderMeterParams returns sensible defaults for a fader's meter.
+func DefaultFaderMeterParams() FaderMeterParams {
+	return FaderMeterParams{
+		Width:         8,
+		Gap:           4,
+		PeakHoldMs:    1000,
+		PeakDecayRate: 0.5,
+	}
+}
+
+// faderMeters holds the meters behind FaderWithMeter, keyed by imgui id.
+var faderMeters = make(map[imgui.ID]*faderMeterEntry)
+var faderMetersPruned time.Time
+
+type faderMeterEntry struct {
+	meter *VUMeter
+	used  time.Time
+}
+
+// faderMeterIdleTimeout is how long FaderWithMeter keeps meters for ids no longer drawn.
+const faderMeterIdleTimeout = 10 * time.Second
+
+// FaderWithMeter draws a normalized fader (0.0-1.0) with a meter showing level (0.0-1.0)
+// in or beside its track, configured by params.Meter. peak hold is kept per label within
+// the current imgui id scope.
+//
+//	gain, changed := dfx.FaderWithMeter("##gain", gain, peak, params)
+func FaderWithMeter(label string, value, level float32, params FaderParams) (float32, bool) {
+	if params.Taper == nil {
+		params.Taper = LinearTaper()
+	}
+	meter := faderMeter(imgui.IDStr(label), params.Taper.Apply(clamp(level, 0, 1)), params)
+
+	if params.Meter.Position == FaderMeterInside {
+		params.underCap = func(min, max imgui.Vec2) {
+			min, max = faderMeterRect(min, max, params.Meter)
+			drawFaderMeter(meter, min, max)
+		}
+		return FaderN(label, value, params)
+	}
+
+	newValue, changed := FaderN(label, value, params)
+	faderMin, faderMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+	imgui.SameLineV(0, params.Meter.gap())
+	cursor := imgui.CursorScreenPos()
+	min, max := faderMeterRect(imgui.Vec2{X: cursor.X, Y: faderMin.Y}, imgui.Vec2{X: cursor.X, Y: faderMax.Y}, params.Meter)
+	drawFaderMeter(meter, min, max)
+	imgui.Dummy(imgui.Vec2{X: max.X - min.X, Y: max.Y - cursor.Y})
+	return newValue, changed
+}
+
+// faderMeter returns the meter for the fader with id, showing the tapered level with its
+// peak updated since it was last drawn.
+func faderMeter(id imgui.ID, level float32, params FaderParams) *VUMeter {
+	now := time.Now()
+	entry, ok := faderMeters[id]
+	if !ok {
+		entry = &faderMeterEntry{meter: NewVUMeter(1), used: now}
+		faderMeters[id] = entry
+	}
+	meter := entry.meter
+	meter.Mode = params.Meter.Mode
+	meter.PeakHoldMs = params.Meter.PeakHoldMs
+	meter.PeakDecayRate = params.Meter.PeakDecayRate
+	meter.SetLevel(0, level)
+	meter.takeLevels()
+	meter.updatePeaks(now, float32(now.Sub(entry.used).Seconds()))
+	entry.used = now
+
+	if now.Sub(faderMetersPruned) > faderMeterIdleTimeout {
+		faderMetersPruned = now
+		for k, e := range faderMeters {
+			if now.Sub(e.used) > faderMeterIdleTimeout {
+				delete(faderMeters, k)
+			}
+		}
+	}
+	return meter
+}
+
+// gap returns Gap, defaulting to 4.
+func (m FaderMeterParams) gap() float32 {
+	if m.Gap <= 0 {
+		return DefaultFaderMeterParams().Gap
+	}
+	return m.Gap
+}
+
+// besideWidth returns the width the meter adds beside the fader, with its gap.
+func (m FaderMeterParams) besideWidth() float32 {
+	if m.Position != FaderMeterBeside {
+		return 0
+	}
+	width := m.Width
+	if width <= 0 {
+		width = DefaultFaderMeterParams().Width
+	}
+	return m.gap() + width
+}
+
+// faderMeterRect places a meter in the span min..max: centered in it when inside the
+// track, starting at min.X when beside it. the meter takes the full height of the span.
+func faderMeterRect(min, max imgui.Vec2, meter FaderMeterParams) (imgui.Vec2, imgui.Vec2) {
+	if meter.Position == FaderMeterInside {
+		width := meter.Width
+		if width <= 0 {
+			width = (max.X - min.X) / 3
+		}
+		width = clamp(width, 0, max.X-min.X)
+		centerX := (min.X + max.X) / 2
+		return imgui.Vec2{X: centerX - width/2, Y: min.Y}, imgui.Vec2{X: centerX + width/2, Y: max.Y}
+	}
+	width := meter.Width
+	if width <= 0 {
+		width = DefaultFaderMeterParams().Width
+	}
+	return min, imgui.Vec2{X: min.X + width, Y: max.Y}
+}
+
+// drawFaderMeter draws the single channel of meter in the rectangle min..max.
+func drawFaderMeter(meter *VUMeter, min, max imgui.Vec2) {
+	meter.colors = resolveVUColors(meter.ColorLow, meter.ColorMid, meter.ColorHigh, meter.ColorOff, meter.ColorPeak, meter.ColorClip)
+	meter.ChannelWidth = max.X - min.X
+	height := max.Y - min.Y
+	p := meter.colors.palette()
+	meter.batch.reset()
+	if meter.Mode == VUMeterSolid {
+		meter.addSolidChannel(&meter.batch, p, min.X, meter.levels[0], meter.peaks[0], min.Y, height)
+	} else {
+		meter.addSegmentedChannel(&meter.batch, p, min.X, meter.levels[0], meter.peaks[0], min.Y, height)
+	}
+	meter.batch.draw(imgui.WindowDrawList(), imgui.Vec2{})
+}
@@ -0,0 +1,46 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestFaderMeterRect(t *testing.T) {
+	min, max := imgui.Vec2{X: 100, Y: 10}, imgui.Vec2{X: 130, Y: 310}
+
+	meter := DefaultFaderMeterParams()
+	if mMin, mMax := faderMeterRect(min, max, meter); mMin != min || mMax.X != 108 || mMax.Y != 310 {
+		t.Errorf("expected an 8 pixel meter beside the fader at its height, got %v..%v", mMin, mMax)
+	}
+
+	meter.Position = FaderMeterInside
+	if mMin, mMax := faderMeterRect(min, max, meter); mMin.X != 111 || mMax.X != 119 || mMin.Y != 10 || mMax.Y != 310 {
+		t.Errorf("expected an 8 pixel meter centered in the track, got %v..%v", mMin, mMax)
+	}
+
+	meter.Width = 0
+	if mMin, mMax := faderMeterRect(min, max, meter); mMin.X != 110 || mMax.X != 120 {
+		t.Errorf("expected a third of the track by default, got %v..%v", mMin, mMax)
+	}
+
+	meter.Width = 50
+	if mMin, mMax := faderMeterRect(min, max, meter); mMin.X != 100 || mMax.X != 130 {
+		t.Errorf("expected the meter limited to the track, got %v..%v", mMin, mMax)
+	}
+}
+
+func TestFaderMeterPeaks(t *testing.T) {
+	id := imgui.ID(0xfade)
+	defer delete(faderMeters, id)
+
+	params := DefaultFaderParams()
+	meter := faderMeter(id, 0.8, params)
+	if meter.levels[0] != 0.8 || meter.peaks[0] != 0.8 {
+		t.Errorf("expected the level and its peak, got %v, %v", meter.levels[0], meter.peaks[0])
+	}
+
+	if again := faderMeter(id, 0.2, params); again != meter || meter.levels[0] != 0.2 || meter.peaks[0] != 0.8 {
+		t.Errorf("expected the same meter holding its peak, got %v, %v", meter.levels[0], meter.peaks[0])
+	}
+}
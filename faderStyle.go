@@ -0,0 +1,171 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// FaderStyle draws a fader like a mixing desk fader instead of an imgui slider: a groove
+// the cap travels in, lines across the track, the groove filled below the cap, a cap with
+// a line or a texture, and optionally the value inside the track. zero colors fall back
+// to the theme; the fader still behaves exactly as an unstyled one.
+type FaderStyle struct {
+	GrooveWidth float32    // width of the slot the cap travels in (0 = the fader width)
+	GrooveColor imgui.Vec4 // slot color (zero = TrackColor, or theme frame background)
+	FillColor   imgui.Vec4 // slot below the cap (zero = not filled)
+	Ticks       int        // lines evenly across the cap's travel (0 = none)
+	TickColor   imgui.Vec4 // (zero = theme border)
+
+	CapWidth    float32    // (0 = the fader width)
+	CapHeight   float32    // (0 = half the cap width)
+	CapColor    imgui.Vec4 // (zero = theme slider grab, active grab while dragged)
+	CapLine     imgui.Vec4 // line across the middle of the cap (zero = none)
+	CapTexture  *Texture   // drawn over the cap, stretched to fit (nil = none)
+	CapRounding float32
+
+	ValueText      bool       // draw the formatted value at the bottom of the track
+	ValueTextColor imgui.Vec4 // (zero = theme text)
+}
+
+// HardwareFaderStyle returns a style resembling a hardware mixer fader: a narrow dark
+// slot with scale lines behind a dark cap with a white line.
+func HardwareFaderStyle() *FaderStyle {
+	return &FaderStyle{
+		GrooveWidth: 6,
+		GrooveColor: imgui.Vec4{X: 0.05, Y: 0.05, Z: 0.05, W: 1},
+		Ticks:       11,
+		TickColor:   imgui.Vec4{X: 0.5, Y: 0.5, Z: 0.5, W: 0.6},
+		CapColor:    imgui.Vec4{X: 0.22, Y: 0.22, Z: 0.24, W: 1},
+		CapLine:     imgui.Vec4{X: 0.95, Y: 0.95, Z: 0.95, W: 1},
+		CapRounding: 2,
+	}
+}
+
+// faderShape is where the parts of a styled fader are drawn, in screen coordinates.
+type faderShape struct {
+	grooveMin, grooveMax imgui.Vec2
+	fillMin              imgui.Vec2 // the fill ends at grooveMax
+	capMin, capMax       imgui.Vec2
+	travelMin, travelMax imgui.Vec2 // the groove between the highest and lowest cap centers
+	ticks                []float32  // y of each line across the track
+}
+
+// layoutFader places the parts of a styled fader in the rectangle min..max with the cap
+// at the tapered position. the cap's center travels between half a cap from either end.
+func layoutFader(min, max imgui.Vec2, position float32, style *FaderStyle) faderShape {
+	width, height := max.X-min.X, max.Y-min.Y
+	centerX := (min.X + max.X) / 2
+
+	capWidth := style.CapWidth
+	if capWidth <= 0 {
+		capWidth = width
+	}
+	capHeight := style.CapHeight
+	if capHeight <= 0 {
+		capHeight = capWidth / 2
+	}
+	capHeight = clamp(capHeight, 1, height)
+	travel := height - capHeight
+	capY := max.Y - capHeight/2 - clamp(position, 0, 1)*travel
+
+	grooveWidth := style.GrooveWidth
+	if grooveWidth <= 0 || grooveWidth > width {
+		grooveWidth = width
+	}
+
+	shape := faderShape{
+		grooveMin: imgui.Vec2{X: centerX - grooveWidth/2, Y: min.Y},
+		grooveMax: imgui.Vec2{X: centerX + grooveWidth/2, Y: max.Y},
+		fillMin:   imgui.Vec2{X: centerX - grooveWidth/2, Y: capY},
+		capMin:    imgui.Vec2{X: centerX - capWidth/2, Y: capY - capHeight/2},
+		capMax:    imgui.Vec2{X: centerX + capWidth/2, Y: capY + capHeight/2},
+		travelMin: imgui.Vec2{X: centerX - grooveWidth/2, Y: min.Y + capHeight/2},
+		travelMax: imgui.Vec2{X: centerX + grooveWidth/2, Y: max.Y - capHeight/2},
+	}
+	for i := range style.Ticks {
+		fraction := float32(0)
+		if style.Ticks > 1 {
+			fraction = float32(i) / float32(style.Ticks-1)
+		}
+		shape.ticks = append(shape.ticks, max.Y-capHeight/2-fraction*travel)
+	}
+	return shape
+}
+
+// pushFaderStyle hides the slider's own frame and grab, so only the styled fader drawn
+// over it shows. returns the number of colors pushed.
+func pushFaderStyle() int32 {
+	for _, col := range []imgui.Col{imgui.ColFrameBg, imgui.ColFrameBgHovered, imgui.ColFrameBgActive, imgui.ColSliderGrab, imgui.ColSliderGrabActive} {
+		imgui.PushStyleColorVec4(col, imgui.Vec4{})
+	}
+	return 5
+}
+
+// pushFaderTrack hides the slider's frame background, so a track drawn below the slider
+// shows through it. returns the number of colors pushed.
+func pushFaderTrack() int32 {
+	for _, col := range []imgui.Col{imgui.ColFrameBg, imgui.ColFrameBgHovered, imgui.ColFrameBgActive} {
+		imgui.PushStyleColorVec4(col, imgui.Vec4{})
+	}
+	return 3
+}
+
+// drawFaderTrack draws the frame background pushFaderTrack hid, as the slider would have.
+func drawFaderTrack(min, max imgui.Vec2, active, hovered bool) {
+	colors := imgui.CurrentStyle().Colors()
+	color := colors[imgui.ColFrameBg]
+	if active {
+		color = colors[imgui.ColFrameBgActive]
+	} else if hovered {
+		color = colors[imgui.ColFrameBgHovered]
+	}
+	imgui.WindowDrawList().AddRectFilledV(min, max, imgui.ColorConvertFloat4ToU32(color), imgui.CurrentStyle().FrameRounding(), imgui.DrawFlagsNone)
+}
+
+// drawFaderStyle draws a styled fader in the rectangle min..max of its slider.
+func drawFaderStyle(min, max imgui.Vec2, position float32, valueText string, active bool, params FaderParams) {
+	style := params.Style
+	shape := layoutFader(min, max, position, style)
+	colors := imgui.CurrentStyle().Colors()
+	dl := imgui.WindowDrawList()
+
+	tick := imgui.ColorConvertFloat4ToU32(themeColor(style.TickColor, colors[imgui.ColBorder]))
+	for _, y := range shape.ticks {
+		dl.AddLine(imgui.Vec2{X: min.X, Y: y}, imgui.Vec2{X: max.X, Y: y}, tick)
+	}
+
+	track := colors[imgui.ColFrameBg]
+	if params.TrackColor != nil {
+		track = *params.TrackColor
+	}
+	rounding := imgui.CurrentStyle().FrameRounding()
+	dl.AddRectFilledV(shape.grooveMin, shape.grooveMax, imgui.ColorConvertFloat4ToU32(themeColor(style.GrooveColor, track)), rounding, imgui.DrawFlagsNone)
+	if style.FillColor != (imgui.Vec4{}) {
+		dl.AddRectFilledV(shape.fillMin, shape.grooveMax, imgui.ColorConvertFloat4ToU32(style.FillColor), rounding, imgui.DrawFlagsNone)
+	}
+	if params.underCap != nil {
+		params.underCap(shape.travelMin, shape.travelMax)
+	}
+
+	if style.ValueText && valueText != "" {
+		size := imgui.CalcTextSize(valueText)
+		pos := imgui.Vec2{X: (min.X + max.X - size.X) / 2, Y: max.Y - size.Y - imgui.CurrentStyle().FramePadding().Y}
+		dl.PushClipRectV(min, max, true)
+		dl.AddTextVec2(pos, imgui.ColorConvertFloat4ToU32(themeColor(style.ValueTextColor, colors[imgui.ColText])), valueText)
+		dl.PopClipRect()
+	}
+
+	grab := colors[imgui.ColSliderGrab]
+	if active {
+		grab = colors[imgui.ColSliderGrabActive]
+	}
+	dl.AddRectFilledV(shape.capMin, shape.capMax, imgui.ColorConvertFloat4ToU32(themeColor(style.CapColor, grab)), style.CapRounding, imgui.DrawFlagsNone)
+	if style.CapTexture != nil {
+		if ref, ok := style.CapTexture.TextureRef(); ok {
+			dl.AddImageV(ref, shape.capMin, shape.capMax, imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1}, imgui.ColorConvertFloat4ToU32(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}))
+		}
+	}
+	if style.CapLine != (imgui.Vec4{}) {
+		y := (shape.capMin.Y + shape.capMax.Y) / 2
+		dl.AddLineV(imgui.Vec2{X: shape.capMin.X, Y: y}, imgui.Vec2{X: shape.capMax.X, Y: y}, imgui.ColorConvertFloat4ToU32(style.CapLine), 2)
+	}
+}
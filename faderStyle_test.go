@@ -0,0 +1,42 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestLayoutFader(t *testing.T) {
+	min, max := imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 30, Y: 300}
+	style := HardwareFaderStyle()
+
+	bottom := layoutFader(min, max, 0, style)
+	if bottom.capMax.Y != 300 || bottom.capMin.Y != 285 || bottom.capMin.X != 0 || bottom.capMax.X != 30 {
+		t.Errorf("expected a 30x15 cap resting on the bottom, got %v..%v", bottom.capMin, bottom.capMax)
+	}
+	if bottom.grooveMin.X != 12 || bottom.grooveMax.X != 18 {
+		t.Errorf("expected a 6 pixel groove centered, got %v..%v", bottom.grooveMin.X, bottom.grooveMax.X)
+	}
+
+	top := layoutFader(min, max, 1, style)
+	if top.capMin.Y != 0 || top.fillMin.Y != 7.5 {
+		t.Errorf("expected the cap at the top with the fill below its center, got %v, fill %v", top.capMin.Y, top.fillMin.Y)
+	}
+
+	if top.travelMin.Y != 7.5 || top.travelMax.Y != 292.5 || top.travelMin.X != 12 {
+		t.Errorf("expected the groove between the cap's centers as its travel, got %v..%v", top.travelMin, top.travelMax)
+	}
+
+	if len(top.ticks) != 11 || top.ticks[0] != 292.5 || top.ticks[10] != 7.5 {
+		t.Errorf("expected 11 ticks across the cap's travel, got %v", top.ticks)
+	}
+
+	wide := &FaderStyle{GrooveWidth: 100, CapWidth: 20, CapHeight: 500}
+	shape := layoutFader(min, max, 0.5, wide)
+	if shape.grooveMax.X-shape.grooveMin.X != 30 {
+		t.Errorf("expected the groove limited to the fader width, got %v", shape.grooveMax.X-shape.grooveMin.X)
+	}
+	if shape.capMin.Y != 0 || shape.capMax.Y != 300 || shape.capMin.X != 5 {
+		t.Errorf("expected the cap limited to the fader height, got %v..%v", shape.capMin, shape.capMax)
+	}
+}
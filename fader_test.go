@@ -0,0 +1,142 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestFaderStep(t *testing.T) {
+	params := DefaultFaderParams()
+	params.MinStop, params.MaxStop = 0.1, 0.9
+	cases := []struct {
+		value    float32
+		key      stepKey
+		fine     bool
+		expected float32
+	}{
+		{0.5, stepUp, false, 0.52},
+		{0.5, stepDown, true, 0.498},
+		{0.5, stepPageUp, false, 0.7},
+		{0.5, stepPageDown, false, 0.3},
+		{0.5, stepHome, false, 0.1},
+		{0.5, stepEnd, false, 0.9},
+		{0.89, stepUp, false, 0.9},
+	}
+	for _, c := range cases {
+		if got := faderStep(c.value, params, c.key, c.fine); got < c.expected-1e-5 || got > c.expected+1e-5 {
+			t.Errorf("faderStep(%v, %v, %v) = %v, expected %v", c.value, c.key, c.fine, got, c.expected)
+		}
+	}
+
+	params.PageSteps = 25
+	if got := faderStep(0.5, params, stepPageDown, false); got < 0.1-1e-5 || got > 0.1+1e-5 {
+		t.Errorf("expected 25 steps down to stop at MinStop, got %v", got)
+	}
+}
+
+func TestFineDragPosition(t *testing.T) {
+	// dragging up 30 pixels of a 300 pixel fader at a tenth of the resolution
+	if got := fineDragPosition(0.5, -30, 300, 0.1); got < 0.51-1e-5 || got > 0.51+1e-5 {
+		t.Errorf("expected 0.51, got %v", got)
+	}
+	if got := fineDragPosition(0.95, -300, 300, 1); got != 1 {
+		t.Errorf("expected the position clamped to 1, got %v", got)
+	}
+}
+
+func TestParseFaderEntry(t *testing.T) {
+	params := DefaultFaderParams()
+	params.MinStop, params.MaxStop = 0.1, 0.9
+	cases := []struct {
+		text     string
+		expected float32
+	}{
+		{"0.5", 0.5},
+		{" 0.25 ", 0.25},
+		{"1/4", 0.25},
+		{"2", 0.9},
+		{"-1", 0.1},
+	}
+	for _, c := range cases {
+		got, err := parseFaderEntry(c.text, params)
+		if err != nil {
+			t.Errorf("parseFaderEntry(%q): %v", c.text, err)
+			continue
+		}
+		if got < c.expected-1e-5 || got > c.expected+1e-5 {
+			t.Errorf("parseFaderEntry(%q) = %v, expected %v", c.text, got, c.expected)
+		}
+	}
+	if _, err := parseFaderEntry("loud", params); err == nil {
+		t.Error("expected an error for text that is not a number")
+	}
+}
+
+func TestRangeFaderParams(t *testing.T) {
+	params := rangeFaderParams(DefaultFaderParams(), -60, 12, false)
+	got, err := parseFaderEntry("-24", params)
+	if err != nil || got < 0.5-1e-5 || got > 0.5+1e-5 {
+		t.Errorf("expected -24 to parse to 0.5, got %v (%v)", got, err)
+	}
+	if text := params.Format(0.5); text != CurrentFormat().FormatFloat(-24, 3) {
+		t.Errorf("expected the value formatted in range, got %q", text)
+	}
+
+	integer := rangeFaderParams(DefaultFaderParams(), 0, 127, true)
+	if text := integer.Format(0.5); text != "64" {
+		t.Errorf("expected 64, got %q", text)
+	}
+	got, err = parseFaderEntry("63.6", integer)
+	if err != nil || got < 64.0/127-1e-5 || got > 64.0/127+1e-5 {
+		t.Errorf("expected 63.6 to round to 64, got %v (%v)", got*127, err)
+	}
+
+	custom := DefaultFaderParams()
+	custom.Parse = func(text string) (float32, error) { return 0.75, nil }
+	if kept := rangeFaderParams(custom, 0, 1, false); kept.Parse == nil {
+		t.Error("expected a custom Parse to be kept")
+	} else if v, _ := kept.Parse(""); v != 0.75 {
+		t.Errorf("expected the custom Parse to be kept, got %v", v)
+	}
+}
+
+func TestNearestDetent(t *testing.T) {
+	params := DefaultFaderParams()
+	params.MinStop = 0.1
+	params.Detents = []float32{0.05, 0.5, 0.55}
+	cases := []struct {
+		position float32
+		expected float32
+		snapped  bool
+	}{
+		{0.51, 0.5, true},
+		{0.535, 0.55, true},
+		{0.45, 0, false},
+		{0.06, 0, false}, // below MinStop
+	}
+	for _, c := range cases {
+		got, snapped := nearestDetent(c.position, params, 0.02)
+		if snapped != c.snapped || got != c.expected {
+			t.Errorf("nearestDetent(%v) = %v, %v, expected %v, %v", c.position, got, snapped, c.expected, c.snapped)
+		}
+	}
+
+	// detents are matched in tapered space
+	params.Taper = AudioTaper()
+	params.Detents = []float32{0.5}
+	if _, snapped := nearestDetent(0.125, params, 0.01); !snapped {
+		t.Error("expected the detent to be found at its tapered position")
+	}
+}
+
+func TestScaleDetents(t *testing.T) {
+	params := DefaultFaderParams()
+	params.Detents = []float32{0.8}
+	scale := DefaultScaleConfig()
+	if got := scaleDetents(params, scale); len(got.Detents) != 1 {
+		t.Errorf("expected marks ignored without Detents, got %v", got.Detents)
+	}
+	scale.Detents = true
+	if got := scaleDetents(params, scale); len(got.Detents) != 6 || len(params.Detents) != 1 {
+		t.Errorf("expected the marks added to a copy, got %v", got.Detents)
+	}
+}
@@ -0,0 +1,192 @@
+package dfx
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// DropZone constants
+const (
+	DropZoneFlashDuration = 400 * time.Millisecond // highlight shown after files land on a zone
+	DropZoneMinHeight     = 60                     // zone height when Height is 0 and there is no content
+)
+
+// fileDrop is a set of files dropped onto the window from another application, delivered
+// during the frame after the drop.
+type fileDrop struct {
+	paths    []string
+	pos      imgui.Vec2 // mouse position when the files were dropped
+	consumed []bool     // per path, taken by a drop zone
+}
+
+// onBackendDrop queues files dropped onto the window; it runs on the UI thread while the
+// backend polls events, before the next frame is drawn.
+func (app *App) onBackendDrop(paths []string) {
+	app.pendingDrop = append(app.pendingDrop, paths...)
+}
+
+// beginFileDrop makes queued files the drop of the current frame.
+func (app *App) beginFileDrop(mousePos imgui.Vec2) {
+	app.fileDrop = nil
+	if len(app.pendingDrop) == 0 {
+		return
+	}
+	app.fileDrop = &fileDrop{paths: app.pendingDrop, pos: mousePos, consumed: make([]bool, len(app.pendingDrop))}
+	app.pendingDrop = nil
+}
+
+// endFileDrop hands files no drop zone took to Config.OnFileDrop.
+func (app *App) endFileDrop() {
+	drop := app.fileDrop
+	app.fileDrop = nil
+	if drop == nil || app.config.OnFileDrop == nil {
+		return
+	}
+	var rest []string
+	for i, path := range drop.paths {
+		if !drop.consumed[i] {
+			rest = append(rest, path)
+		}
+	}
+	if len(rest) > 0 {
+		app.config.OnFileDrop(app, rest)
+	}
+}
+
+// TakeFileDrop returns the files dropped from another application this frame onto the
+// screen rectangle from topLeft to bottomRight, and marks them handled so they are not
+// passed to Config.OnFileDrop. accept filters the files as for DropZone.Accept; nil
+// takes every file. it is the building block for custom drop targets.
+func (app *App) TakeFileDrop(topLeft, bottomRight imgui.Vec2, accept []string) []string {
+	drop := app.fileDrop
+	if drop == nil || drop.pos.X < topLeft.X || drop.pos.Y < topLeft.Y || drop.pos.X >= bottomRight.X || drop.pos.Y >= bottomRight.Y {
+		return nil
+	}
+	var paths []string
+	for i, path := range drop.paths {
+		if !drop.consumed[i] && MatchDropType(path, accept) {
+			drop.consumed[i] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// MatchDropType reports whether a dropped file matches any accept pattern: a file
+// extension (".wav"), a MIME type ("audio/wav") or a MIME type wildcard ("audio/*").
+// MIME types are looked up from the extension. an empty accept list matches everything.
+func MatchDropType(path string, accept []string) bool {
+	if len(accept) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	mimeType, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	for _, pattern := range accept {
+		pattern = strings.ToLower(pattern)
+		switch {
+		case strings.HasPrefix(pattern, "."):
+			if ext == pattern {
+				return true
+			}
+		case strings.HasSuffix(pattern, "/*"):
+			if mimeType != "" && strings.HasPrefix(mimeType, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		case mimeType == pattern:
+			return true
+		}
+	}
+	return false
+}
+
+// DropZone is an area that accepts files dragged in from file managers and other
+// applications, optionally filtered by type. it also accepts nodes dragged from a
+// Draggable FileTree, and highlights while one hovers over it. external drags are only
+// reported by the windowing system once they are dropped, so for those the zone flashes
+// when files land instead.
+type DropZone struct {
+	Container
+	Content    Component            // drawn inside the zone (nil = an outlined box showing Hint)
+	Hint       string               // text shown in the empty zone
+	Accept     []string             // extensions and MIME types to accept (nil = any file)
+	Height     float32              // zone height when there is no content (0 = DropZoneMinHeight)
+	OnDrop     func(paths []string) // called with the accepted files dropped from other applications
+	OnFileNode func(node *FileNode) // called with a FileTree node dropped on the zone (nil = not accepted)
+	droppedAt  time.Time            // when files last landed, for the flash
+}
+
+// NewDropZone creates an empty drop zone showing hint.
+func NewDropZone(hint string, onDrop func(paths []string)) *DropZone {
+	return &DropZone{
+		Container: Container{Visible: true},
+		Hint:      hint,
+		OnDrop:    onDrop,
+	}
+}
+
+// Draw implements Component.
+func (dz *DropZone) Draw(state *State) {
+	if !dz.Visible {
+		return
+	}
+	imgui.PushIDStr(componentID("dropZone", dz))
+	defer imgui.PopID()
+
+	if dz.Content != nil {
+		imgui.BeginGroup()
+		DrawChild(dz.Content, state)
+		imgui.EndGroup()
+	} else {
+		dz.drawEmpty()
+	}
+	topLeft, bottomRight := imgui.ItemRectMin(), imgui.ItemRectMax()
+	now := state.Clock()
+
+	if dz.OnFileNode != nil {
+		accept := func(node *FileNode) bool { return node.Dir || MatchDropType(node.Name, dz.Accept) }
+		if node, ok := DropTargetIf(DragKindFile, accept); ok {
+			dz.droppedAt = now
+			dz.OnFileNode(node)
+		}
+	}
+	if state != nil && state.App != nil {
+		if paths := state.App.TakeFileDrop(topLeft, bottomRight, dz.Accept); len(paths) > 0 {
+			dz.droppedAt = now
+			if dz.OnDrop != nil {
+				dz.OnDrop(paths)
+			}
+		}
+	}
+	if since := now.Sub(dz.droppedAt); since < DropZoneFlashDuration {
+		alpha := 1 - float32(since)/float32(DropZoneFlashDuration)
+		imgui.WindowDrawList().AddRectFilled(topLeft, bottomRight, imgui.ColorU32ColV(imgui.ColDragDropTarget, 0.3*alpha))
+	}
+
+	drawContainerExtensions(&dz.Container, state)
+}
+
+// drawEmpty draws an outlined box with the hint centered in it.
+func (dz *DropZone) drawEmpty() {
+	height := dz.Height
+	if height <= 0 {
+		height = DropZoneMinHeight
+	}
+	size := imgui.Vec2{X: max(imgui.ContentRegionAvail().X, 1), Y: height}
+	imgui.InvisibleButton("##zone", size)
+	topLeft, bottomRight := imgui.ItemRectMin(), imgui.ItemRectMax()
+
+	drawList := imgui.WindowDrawList()
+	drawList.AddRectV(topLeft, bottomRight, imgui.ColorU32Col(imgui.ColBorder), imgui.CurrentStyle().FrameRounding(), imgui.DrawFlagsNone, 1)
+	if dz.Hint != "" {
+		textSize := imgui.CalcTextSize(dz.Hint)
+		pos := imgui.Vec2{
+			X: topLeft.X + (size.X-textSize.X)/2,
+			Y: topLeft.Y + (size.Y-textSize.Y)/2,
+		}
+		drawList.AddTextVec2(pos, imgui.ColorU32Col(imgui.ColTextDisabled), dz.Hint)
+	}
+}
@@ -0,0 +1,61 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestMatchDropType(t *testing.T) {
+	cases := []struct {
+		path   string
+		accept []string
+		want   bool
+	}{
+		{"/tmp/a.wav", nil, true},
+		{"/tmp/a.WAV", []string{".wav"}, true},
+		{"/tmp/a.flac", []string{".wav", ".aif"}, false},
+		{"/tmp/a.png", []string{"image/*"}, true},
+		{"/tmp/a.png", []string{"image/png"}, true},
+		{"/tmp/a.png", []string{"text/*"}, false},
+		{"/tmp/noext", []string{"image/*"}, false},
+	}
+	for _, c := range cases {
+		if got := MatchDropType(c.path, c.accept); got != c.want {
+			t.Fatalf("MatchDropType(%v, %v) = %v, expected %v", c.path, c.accept, got, c.want)
+		}
+	}
+}
+
+func TestFileDropRouting(t *testing.T) {
+	var fallback []string
+	app := New(nil, Config{OnFileDrop: func(_ *App, paths []string) { fallback = paths }})
+
+	app.onBackendDrop([]string{"a.png", "b.txt"})
+	app.onBackendDrop([]string{"c.png"})
+	app.beginFileDrop(imgui.Vec2{X: 50, Y: 50})
+
+	// a zone the drop missed takes nothing
+	if paths := app.TakeFileDrop(imgui.Vec2{X: 100, Y: 0}, imgui.Vec2{X: 200, Y: 100}, nil); paths != nil {
+		t.Fatalf("expected nothing outside the drop position, got %v", paths)
+	}
+	paths := app.TakeFileDrop(imgui.Vec2{}, imgui.Vec2{X: 100, Y: 100}, []string{".png"})
+	if !slices.Equal(paths, []string{"a.png", "c.png"}) {
+		t.Fatalf("expected the png files, got %v", paths)
+	}
+	if paths := app.TakeFileDrop(imgui.Vec2{}, imgui.Vec2{X: 100, Y: 100}, []string{".png"}); paths != nil {
+		t.Fatalf("expected files taken once, got %v", paths)
+	}
+
+	app.endFileDrop()
+	if !slices.Equal(fallback, []string{"b.txt"}) {
+		t.Fatalf("expected the remaining file passed to OnFileDrop, got %v", fallback)
+	}
+
+	// the next frame has no drop
+	app.beginFileDrop(imgui.Vec2{X: 50, Y: 50})
+	if app.TakeFileDrop(imgui.Vec2{}, imgui.Vec2{X: 100, Y: 100}, nil) != nil {
+		t.Fatalf("expected no drop on the next frame")
+	}
+}
@@ -132,6 +132,8 @@ type FileTree struct {
 	OnSelect      func(*FileNode)
 	OnDoubleClick func(*FileNode)
 	Filter        func(*FileNode) bool
+	Draggable     bool            // nodes can be dragged as DragKindFile payloads
+	Kinetic       KineticScroller // momentum after flicks, when kinetic scrolling is on (see SetKineticScrolling)
 }
 
 // NewFileTree creates a new filesystem tree component.
@@ -168,6 +170,7 @@ func (ft *FileTree) Draw(state *State) {
 	// render the tree recursively
 	ft.visitNode(ft.Root)
 
+	ft.Kinetic.Update()
 	imgui.EndChild()
 	drawContainerExtensions(&ft.Container, state)
 }
@@ -190,7 +193,9 @@ func (ft *FileTree) visitNode(node *FileNode) {
 
 	if node.Dir {
 		// render directory node
-		if imgui.TreeNodeExStrV(node.Name, baseFlags) {
+		open := imgui.TreeNodeExStrV(node.Name, baseFlags)
+		ft.dragSource(node)
+		if open {
 			// render children recursively
 			for _, child := range node.Children {
 				ft.visitNode(child)
@@ -222,6 +227,7 @@ func (ft *FileTree) visitNode(node *FileNode) {
 		}
 
 		imgui.TreeNodeExStrV(node.Name, leafFlags)
+		ft.dragSource(node)
 
 		// handle file selection
 		if imgui.IsItemClicked() {
@@ -239,3 +245,10 @@ func (ft *FileTree) visitNode(node *FileNode) {
 		}
 	}
 }
+
+// dragSource makes the node just drawn draggable when the tree is Draggable.
+func (ft *FileTree) dragSource(node *FileNode) {
+	if ft.Draggable {
+		DragSource(DragKindFile, node, DragText(node.Name))
+	}
+}
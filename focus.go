@@ -0,0 +1,181 @@
+package dfx
+
+import (
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+const focusRingThickness = 2
+
+// FocusManager tracks which component has keyboard focus. components take part by calling
+// Focusable each frame while they draw; the order they draw in is the Tab order. the
+// focused component's actions are checked before any other, Tab and Shift+Tab move focus
+// (unless an action claims those keys), clicking a focusable component focuses it, and
+// the focused component is outlined in the theme accent color.
+//
+//	func (p *Panel) Draw(state *dfx.State) {
+//	    topLeft := imgui.CursorScreenPos()
+//	    ... draw ...
+//	    state.App.Focus().Focusable(p, topLeft, topLeft.Add(state.Size))
+//	}
+//
+// a focused component that stops drawing loses focus. use it from the UI thread.
+type FocusManager struct {
+	Ring     bool                    // outline the focused component (default true)
+	OnChange func(focused Component) // called when focus moves; focused is nil when cleared
+
+	focused     Component
+	seen        bool        // the focused component drew (or was focused) since the last frame began
+	order       []Component // focusable components drawn last frame, in draw order
+	drawing     []Component // focusable components drawn this frame
+	clicked     Component   // smallest focusable component clicked this frame
+	clickedArea float32
+}
+
+// NewFocusManager creates a focus manager with nothing focused.
+func NewFocusManager() *FocusManager {
+	return &FocusManager{Ring: true}
+}
+
+// Focused returns the focused component, or nil.
+func (fm *FocusManager) Focused() Component {
+	return fm.focused
+}
+
+// IsFocused reports whether comp has focus.
+func (fm *FocusManager) IsFocused(comp Component) bool {
+	return comp != nil && fm.focused == comp
+}
+
+// Focus gives comp keyboard focus; nil clears it.
+func (fm *FocusManager) Focus(comp Component) {
+	fm.seen = true
+	if fm.focused == comp {
+		return
+	}
+	fm.focused = comp
+	if fm.OnChange != nil {
+		fm.OnChange(comp)
+	}
+	if comp != nil {
+		Announce(componentAccessibleText(comp))
+	}
+}
+
+// Blur clears keyboard focus.
+func (fm *FocusManager) Blur() {
+	fm.Focus(nil)
+}
+
+// Next moves focus to the next focusable component, wrapping around. it returns false
+// when nothing is focusable.
+func (fm *FocusManager) Next() bool {
+	return fm.cycle(1)
+}
+
+// Previous moves focus to the previous focusable component, wrapping around.
+func (fm *FocusManager) Previous() bool {
+	return fm.cycle(-1)
+}
+
+// Focusables returns the focusable components drawn in the last frame, in Tab order.
+func (fm *FocusManager) Focusables() []Component {
+	return slices.Clone(fm.order)
+}
+
+// Focusable registers comp as focusable this frame, occupying the screen rectangle from
+// topLeft to bottomRight, and reports whether it has focus. it draws the focus ring and
+// handles focusing by mouse click.
+func (fm *FocusManager) Focusable(comp Component, topLeft, bottomRight imgui.Vec2) bool {
+	fm.register(comp)
+	if imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && imgui.IsMouseHoveringRect(topLeft, bottomRight) {
+		fm.click(comp, (bottomRight.X-topLeft.X)*(bottomRight.Y-topLeft.Y))
+	}
+	focused := fm.IsFocused(comp)
+	if focused && fm.Ring {
+		drawFocusRect(topLeft, bottomRight, 0)
+	}
+	return focused
+}
+
+// register adds comp to this frame's Tab order.
+func (fm *FocusManager) register(comp Component) {
+	fm.drawing = append(fm.drawing, comp)
+	if comp == fm.focused {
+		fm.seen = true
+	}
+}
+
+// click records a click on comp; the innermost (smallest) clicked component takes focus
+// when the frame ends.
+func (fm *FocusManager) click(comp Component, area float32) {
+	if fm.clicked == nil || area < fm.clickedArea {
+		fm.clicked = comp
+		fm.clickedArea = area
+	}
+}
+
+// beginFrame completes the previous frame: the clicked component takes focus, and a
+// focused component that did not draw loses it. the app calls it at the start of every
+// frame.
+func (fm *FocusManager) beginFrame() {
+	if fm.clicked != nil {
+		fm.Focus(fm.clicked)
+		fm.clicked = nil
+	} else if fm.focused != nil && !fm.seen {
+		fm.Blur()
+	}
+	fm.seen = false
+	fm.order, fm.drawing = fm.drawing, fm.order[:0]
+}
+
+func (fm *FocusManager) cycle(dir int) bool {
+	n := len(fm.order)
+	if n == 0 {
+		return false
+	}
+	current := -1
+	for i, comp := range fm.order {
+		if comp == fm.focused {
+			current = i
+			break
+		}
+	}
+	if current < 0 && dir < 0 {
+		current = 0
+	}
+	fm.Focus(fm.order[((current+dir)%n+n)%n])
+	return true
+}
+
+// handleKeys moves focus on Tab and Shift+Tab, and on the gamepad d-pad (down and right
+// for the next component); it runs after no action claimed the keys.
+func (fm *FocusManager) handleKeys(mods KeyModifier) bool {
+	if len(fm.order) == 0 {
+		return false
+	}
+	if imgui.IsKeyPressedBool(imgui.KeyTab) {
+		switch mods {
+		case ModNone:
+			return fm.Next()
+		case ModShift:
+			return fm.Previous()
+		}
+		return false
+	}
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyGamepadDpadDown) || imgui.IsKeyPressedBool(imgui.KeyGamepadDpadRight):
+		return fm.Next()
+	case imgui.IsKeyPressedBool(imgui.KeyGamepadDpadUp) || imgui.IsKeyPressedBool(imgui.KeyGamepadDpadLeft):
+		return fm.Previous()
+	}
+	return false
+}
+
+// drawFocusRect outlines a screen rectangle in the accent color, inside its edges.
+func drawFocusRect(topLeft, bottomRight imgui.Vec2, rounding float32) {
+	inset := imgui.Vec2{X: focusRingThickness / 2, Y: focusRingThickness / 2}
+	color := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	imgui.WindowDrawList().AddRectV(topLeft.Add(inset), bottomRight.Sub(inset), color, rounding, 0, focusRingThickness)
+}
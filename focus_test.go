@@ -0,0 +1,83 @@
+package dfx
+
+import "testing"
+
+func TestFocusManagerCycling(t *testing.T) {
+	fm := NewFocusManager()
+	a, b, c := &Container{}, &Container{}, &Container{}
+	var changes []Component
+	fm.OnChange = func(focused Component) { changes = append(changes, focused) }
+
+	if fm.Next() {
+		t.Fatal("expected nothing to focus before any frame")
+	}
+	for _, comp := range []Component{a, b, c} {
+		fm.register(comp)
+	}
+	fm.beginFrame()
+
+	if !fm.Next() || fm.Focused() != a {
+		t.Fatal("expected Next to focus the first component")
+	}
+	fm.Next()
+	fm.Next()
+	if fm.Focused() != c {
+		t.Fatal("expected the third component")
+	}
+	fm.Next()
+	if fm.Focused() != a {
+		t.Fatal("expected Next to wrap to the first component")
+	}
+	fm.Previous()
+	if fm.Focused() != c {
+		t.Fatal("expected Previous to wrap to the last component")
+	}
+	if len(changes) != 5 {
+		t.Fatalf("expected 5 focus changes, got %d", len(changes))
+	}
+
+	fm.Blur()
+	fm.Previous()
+	if fm.Focused() != c {
+		t.Fatal("expected Previous with nothing focused to focus the last component")
+	}
+}
+
+func TestFocusManagerDropsUndrawnFocus(t *testing.T) {
+	fm := NewFocusManager()
+	a, b := &Container{}, &Container{}
+
+	// focusing before the component draws keeps focus for a frame
+	fm.Focus(a)
+	fm.beginFrame()
+	if !fm.IsFocused(a) {
+		t.Fatal("expected focus to survive the first frame")
+	}
+	fm.register(a)
+	fm.register(b)
+	fm.beginFrame()
+	if !fm.IsFocused(a) {
+		t.Fatal("expected a drawn component to keep focus")
+	}
+	fm.register(b)
+	fm.beginFrame()
+	if fm.Focused() != nil {
+		t.Fatal("expected focus to be dropped when the component stops drawing")
+	}
+	if got := fm.Focusables(); len(got) != 1 || got[0] != b {
+		t.Fatalf("expected b to be the only focusable, got %v", got)
+	}
+}
+
+func TestFocusManagerInnermostClickWins(t *testing.T) {
+	fm := NewFocusManager()
+	outer, inner := &Container{}, &Container{}
+	fm.register(inner)
+	fm.click(inner, 100)
+	fm.register(outer)
+	fm.click(outer, 10000)
+	fm.beginFrame()
+	if fm.Focused() != inner {
+		t.Fatal("expected the smaller clicked component to take focus")
+	}
+}
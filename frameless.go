@@ -0,0 +1,201 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// frameless window constants
+const (
+	FramelessResizeBorder = 6   // width of the band along the window edges that resizes a frameless window
+	FramelessMinWidth     = 200 // smallest size edge resizing allows
+	FramelessMinHeight    = 120
+)
+
+// windowEdge is a set of window edges being resized.
+type windowEdge int
+
+const (
+	edgeLeft windowEdge = 1 << iota
+	edgeRight
+	edgeTop
+	edgeBottom
+)
+
+// windowDrag tracks a window being moved or resized with the mouse. positions are in
+// screen coordinates, so they stay valid while the window moves under the mouse.
+type windowDrag struct {
+	active bool
+	edges  windowEdge   // edges being resized (none = moving)
+	mouse  imgui.Vec2   // mouse position when the drag started
+	rect   WindowConfig // window placement when the drag started
+}
+
+// Maximize fills the work area of the window's monitor with the window, remembering its
+// placement for Restore. frameless windows have no OS maximize button, so dfx emulates it.
+func (app *App) Maximize() {
+	if app.backend == nil || app.maximized {
+		return
+	}
+	m, ok := app.WindowMonitor()
+	if !ok {
+		return
+	}
+	app.restoreRect = app.windowRect()
+	app.maximized = true
+	app.setWindowRect(WindowConfig{X: int(m.WorkArea.X), Y: int(m.WorkArea.Y), Width: int(m.WorkArea.W), Height: int(m.WorkArea.H)})
+}
+
+// Restore returns a window maximized with Maximize to its previous placement.
+func (app *App) Restore() {
+	if app.backend == nil || !app.maximized {
+		return
+	}
+	app.maximized = false
+	app.setWindowRect(app.restoreRect)
+}
+
+// ToggleMaximize maximizes the window, or restores it when maximized.
+func (app *App) ToggleMaximize() {
+	if app.maximized {
+		app.Restore()
+	} else {
+		app.Maximize()
+	}
+}
+
+// Maximized returns true while the window is maximized with Maximize.
+func (app *App) Maximized() bool {
+	return app.maximized
+}
+
+// Close asks the window to close, as the OS close button does: the ConfirmQuit prompt is
+// shown for unsaved changes, and OnClose is called and can cancel it with
+// SetShouldClose(false).
+func (app *App) Close() {
+	if app.backend == nil {
+		return
+	}
+	app.backend.SetShouldClose(true)
+	app.onClose()
+}
+
+// windowRect returns the window's current placement.
+func (app *App) windowRect() WindowConfig {
+	x, y := app.GetWindowPos()
+	width, height := app.GetWindowSize()
+	return WindowConfig{X: x, Y: y, Width: width, Height: height}
+}
+
+// setWindowRect moves and sizes the window.
+func (app *App) setWindowRect(rect WindowConfig) {
+	app.backend.SetWindowPos(rect.X, rect.Y)
+	app.backend.SetWindowSize(rect.Width, rect.Height)
+}
+
+// screenMouse returns the mouse position in screen coordinates.
+func (app *App) screenMouse() imgui.Vec2 {
+	x, y := app.GetWindowPos()
+	return imgui.MousePos().Add(imgui.Vec2{X: float32(x), Y: float32(y)})
+}
+
+// beginWindowMove starts moving the window with the mouse, until the left button is released.
+func (app *App) beginWindowMove() {
+	if app.backend == nil || app.maximized {
+		return
+	}
+	app.drag = windowDrag{active: true, mouse: app.screenMouse(), rect: app.windowRect()}
+}
+
+// updateWindowDrag starts resizing a frameless window from its edges, and moves or resizes
+// the window while a drag is in progress.
+func (app *App) updateWindowDrag() {
+	if app.backend == nil {
+		return
+	}
+	if !app.drag.active {
+		if !app.config.Frameless || app.maximized {
+			return
+		}
+		size := imgui.MainViewport().Size()
+		edges := windowEdgeAt(imgui.MousePos(), size, FramelessResizeBorder)
+		if edges == 0 || imgui.IsAnyItemActive() {
+			return
+		}
+		imgui.SetMouseCursor(edgeCursor(edges))
+		if imgui.IsMouseClickedBool(imgui.MouseButtonLeft) {
+			app.drag = windowDrag{active: true, edges: edges, mouse: app.screenMouse(), rect: app.windowRect()}
+		}
+		return
+	}
+
+	if !imgui.IsMouseDown(imgui.MouseButtonLeft) {
+		app.drag = windowDrag{}
+		return
+	}
+	delta := app.screenMouse().Sub(app.drag.mouse)
+	dx, dy := int(delta.X), int(delta.Y)
+	if app.drag.edges == 0 {
+		rect := app.drag.rect
+		app.backend.SetWindowPos(rect.X+dx, rect.Y+dy)
+		return
+	}
+	imgui.SetMouseCursor(edgeCursor(app.drag.edges))
+	rect := resizeWindowRect(app.drag.rect, app.drag.edges, dx, dy, FramelessMinWidth, FramelessMinHeight)
+	if rect != app.windowRect() {
+		app.setWindowRect(rect)
+	}
+}
+
+// windowEdgeAt returns the edges of a window of size within border of mouse, given in
+// window coordinates.
+func windowEdgeAt(mouse, size imgui.Vec2, border float32) windowEdge {
+	if mouse.X < 0 || mouse.Y < 0 || mouse.X >= size.X || mouse.Y >= size.Y {
+		return 0
+	}
+	var edges windowEdge
+	if mouse.X < border {
+		edges |= edgeLeft
+	} else if mouse.X >= size.X-border {
+		edges |= edgeRight
+	}
+	if mouse.Y < border {
+		edges |= edgeTop
+	} else if mouse.Y >= size.Y-border {
+		edges |= edgeBottom
+	}
+	return edges
+}
+
+// resizeWindowRect moves edges of rect by dx, dy, keeping it at least minWidth x
+// minHeight. the opposite edges stay put.
+func resizeWindowRect(rect WindowConfig, edges windowEdge, dx, dy, minWidth, minHeight int) WindowConfig {
+	if edges&edgeLeft != 0 {
+		dx = min(dx, rect.Width-minWidth)
+		rect.X += dx
+		rect.Width -= dx
+	} else if edges&edgeRight != 0 {
+		rect.Width = max(rect.Width+dx, minWidth)
+	}
+	if edges&edgeTop != 0 {
+		dy = min(dy, rect.Height-minHeight)
+		rect.Y += dy
+		rect.Height -= dy
+	} else if edges&edgeBottom != 0 {
+		rect.Height = max(rect.Height+dy, minHeight)
+	}
+	return rect
+}
+
+// edgeCursor returns the mouse cursor for resizing edges.
+func edgeCursor(edges windowEdge) imgui.MouseCursor {
+	switch edges {
+	case edgeLeft, edgeRight:
+		return imgui.MouseCursorResizeEW
+	case edgeTop, edgeBottom:
+		return imgui.MouseCursorResizeNS
+	case edgeLeft | edgeTop, edgeRight | edgeBottom:
+		return imgui.MouseCursorResizeNWSE
+	default:
+		return imgui.MouseCursorResizeNESW
+	}
+}
@@ -0,0 +1,50 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestWindowEdgeAt(t *testing.T) {
+	size := imgui.Vec2{X: 800, Y: 600}
+	tests := []struct {
+		mouse imgui.Vec2
+		edges windowEdge
+	}{
+		{imgui.Vec2{X: 400, Y: 300}, 0},
+		{imgui.Vec2{X: 2, Y: 300}, edgeLeft},
+		{imgui.Vec2{X: 797, Y: 300}, edgeRight},
+		{imgui.Vec2{X: 400, Y: 1}, edgeTop},
+		{imgui.Vec2{X: 799, Y: 599}, edgeRight | edgeBottom},
+		{imgui.Vec2{X: 0, Y: 0}, edgeLeft | edgeTop},
+		{imgui.Vec2{X: -1, Y: 300}, 0},
+	}
+	for _, test := range tests {
+		if edges := windowEdgeAt(test.mouse, size, FramelessResizeBorder); edges != test.edges {
+			t.Errorf("expected edges '%v' at '%v', got '%v'", test.edges, test.mouse, edges)
+		}
+	}
+}
+
+func TestResizeWindowRect(t *testing.T) {
+	rect := WindowConfig{X: 100, Y: 100, Width: 800, Height: 600}
+
+	// right and bottom edges grow the window in place
+	if got := resizeWindowRect(rect, edgeRight|edgeBottom, 50, -20, 200, 120); got != (WindowConfig{X: 100, Y: 100, Width: 850, Height: 580}) {
+		t.Fatalf("unexpected resize '%+v'", got)
+	}
+
+	// left and top edges move the window, keeping the opposite edges put
+	if got := resizeWindowRect(rect, edgeLeft|edgeTop, -50, 30, 200, 120); got != (WindowConfig{X: 50, Y: 130, Width: 850, Height: 570}) {
+		t.Fatalf("unexpected resize '%+v'", got)
+	}
+
+	// the minimum size holds on both sides
+	if got := resizeWindowRect(rect, edgeLeft, 700, 0, 200, 120); got.X != 700 || got.Width != 200 {
+		t.Fatalf("expected left edge stopped at '700', got '%+v'", got)
+	}
+	if got := resizeWindowRect(rect, edgeBottom, 0, -1000, 200, 120); got.Height != 120 {
+		t.Fatalf("expected height clamped to '120', got '%+v'", got)
+	}
+}
@@ -0,0 +1,129 @@
+package dfx
+
+import (
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// GamepadButton is a controller button, named after the Xbox layout: A is the bottom face
+// button (Cross on PlayStation controllers).
+type GamepadButton uint8
+
+const (
+	PadA     GamepadButton = iota // bottom face button
+	PadB                          // right face button
+	PadX                          // left face button
+	PadY                          // top face button
+	PadUp                         // d-pad
+	PadDown                       // d-pad
+	PadLeft                       // d-pad
+	PadRight                      // d-pad
+	PadL1                         // left bumper
+	PadR1                         // right bumper
+	PadL2                         // left trigger
+	PadR2                         // right trigger
+	PadL3                         // left stick press
+	PadR3                         // right stick press
+	PadStart                      // menu / options
+	PadBack                       // view / share
+	gamepadButtonCount
+)
+
+// gamepadKeys are the imgui keys of the buttons.
+var gamepadKeys = [gamepadButtonCount]imgui.Key{
+	PadA:     imgui.KeyGamepadFaceDown,
+	PadB:     imgui.KeyGamepadFaceRight,
+	PadX:     imgui.KeyGamepadFaceLeft,
+	PadY:     imgui.KeyGamepadFaceUp,
+	PadUp:    imgui.KeyGamepadDpadUp,
+	PadDown:  imgui.KeyGamepadDpadDown,
+	PadLeft:  imgui.KeyGamepadDpadLeft,
+	PadRight: imgui.KeyGamepadDpadRight,
+	PadL1:    imgui.KeyGamepadL1,
+	PadR1:    imgui.KeyGamepadR1,
+	PadL2:    imgui.KeyGamepadL2,
+	PadR2:    imgui.KeyGamepadR2,
+	PadL3:    imgui.KeyGamepadL3,
+	PadR3:    imgui.KeyGamepadR3,
+	PadStart: imgui.KeyGamepadStart,
+	PadBack:  imgui.KeyGamepadBack,
+}
+
+// gamepadNames are the names of the buttons in action bindings ("PadA", "Ctrl+PadStart"),
+// also shown in menus.
+var gamepadNames = [gamepadButtonCount]string{
+	PadA: "PadA", PadB: "PadB", PadX: "PadX", PadY: "PadY",
+	PadUp: "PadUp", PadDown: "PadDown", PadLeft: "PadLeft", PadRight: "PadRight",
+	PadL1: "PadL1", PadR1: "PadR1", PadL2: "PadL2", PadR2: "PadR2", PadL3: "PadL3", PadR3: "PadR3",
+	PadStart: "PadStart", PadBack: "PadBack",
+}
+
+// String returns the button's name in action bindings.
+func (b GamepadButton) String() string {
+	if b < gamepadButtonCount {
+		return gamepadNames[b]
+	}
+	return ""
+}
+
+// parseGamepadKey converts a gamepad button name to its imgui key.
+func parseGamepadKey(name string) (imgui.Key, bool) {
+	for button, buttonName := range gamepadNames {
+		if strings.EqualFold(name, buttonName) {
+			return gamepadKeys[button], true
+		}
+	}
+	return 0, false
+}
+
+// gamepadKeyLabel returns the name of a gamepad button's imgui key, empty for other keys.
+func gamepadKeyLabel(key imgui.Key) string {
+	for button, buttonKey := range gamepadKeys {
+		if key == buttonKey {
+			return gamepadNames[button]
+		}
+	}
+	return ""
+}
+
+// GamepadState is the state of the gamepads in the current frame, merged as imgui merges
+// them. gamepads are only read with Config.Gamepad set.
+type GamepadState struct {
+	Connected    bool       // the backend reports a gamepad
+	LeftStick    imgui.Vec2 // -1 to 1 on each axis, +Y down
+	RightStick   imgui.Vec2 // -1 to 1 on each axis, +Y down
+	LeftTrigger  float32    // 0 to 1
+	RightTrigger float32    // 0 to 1
+	down         [gamepadButtonCount]bool
+}
+
+// Down reports whether button is held.
+func (g GamepadState) Down(button GamepadButton) bool {
+	return button < gamepadButtonCount && g.down[button]
+}
+
+// Gamepad returns the state of the gamepads in this frame.
+func (s *State) Gamepad() GamepadState {
+	g := GamepadState{
+		Connected:    imgui.CurrentIO().BackendFlags()&imgui.BackendFlagsHasGamepad != 0,
+		LeftStick:    gamepadAxes(imgui.KeyGamepadLStickLeft, imgui.KeyGamepadLStickRight, imgui.KeyGamepadLStickUp, imgui.KeyGamepadLStickDown),
+		RightStick:   gamepadAxes(imgui.KeyGamepadRStickLeft, imgui.KeyGamepadRStickRight, imgui.KeyGamepadRStickUp, imgui.KeyGamepadRStickDown),
+		LeftTrigger:  gamepadAnalog(imgui.KeyGamepadL2),
+		RightTrigger: gamepadAnalog(imgui.KeyGamepadR2),
+	}
+	for button, key := range gamepadKeys {
+		g.down[button] = imgui.IsKeyDown(key)
+	}
+	return g
+}
+
+// gamepadAxes returns the position of a stick from the analog values of its directions.
+func gamepadAxes(left, right, up, down imgui.Key) imgui.Vec2 {
+	return imgui.Vec2{X: gamepadAnalog(right) - gamepadAnalog(left), Y: gamepadAnalog(down) - gamepadAnalog(up)}
+}
+
+// gamepadAnalog returns the analog value of a gamepad key.
+func gamepadAnalog(key imgui.Key) float32 {
+	return imgui.InternalKeyDataKey(key).AnalogValue()
+}
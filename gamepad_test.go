@@ -0,0 +1,46 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestAction_ParsesGamepadButtons(t *testing.T) {
+	for button := PadA; button < gamepadButtonCount; button++ {
+		action := NewMenuAction("pad", button.String(), func() {})
+		if action.key != gamepadKeys[button] {
+			t.Fatalf("expected '%v', got '%v'", gamepadKeys[button], action.key)
+		}
+		if action.shortcutLabel != button.String() {
+			t.Fatalf("expected '%v', got '%v'", button.String(), action.shortcutLabel)
+		}
+	}
+	action := NewMenuAction("menu", "ctrl+padstart", func() {})
+	if action.shortcutLabel != "Ctrl+PadStart" {
+		t.Fatalf("expected '%v', got '%v'", "Ctrl+PadStart", action.shortcutLabel)
+	}
+
+	registry := NewActionRegistry()
+	if err := registry.Register("play", "PadA", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.Register("select", "PadA", func() {}) == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestGamepadState_Down(t *testing.T) {
+	var g GamepadState
+	g.down[PadStart] = true
+	if !g.Down(PadStart) {
+		t.Fatal("expected g.Down(PadStart)")
+	}
+	if g.Down(PadA) {
+		t.Fatal("unexpected g.Down(PadA)")
+	}
+	if g.Down(gamepadButtonCount) {
+		t.Fatal("unexpected g.Down(gamepadButtonCount)")
+	}
+	if got := gamepadButtonCount.String(); got != "" {
+		t.Fatalf("expected '%v', got '%v'", "", got)
+	}
+}
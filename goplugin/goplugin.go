@@ -0,0 +1,75 @@
+// Package goplugin loads dfx components from Go plugins, for dfx.PluginHost:
+//
+//	host := dfx.NewPluginHost("plugins", goplugin.Open)
+//	mixer := host.Panel("mixer") // loads plugins/mixer.so
+//
+// a plugin is a main package exporting dfx.PluginSymbol:
+//
+//	func NewComponent() dfx.Component { return newMixer() }
+//
+// Go never unloads a plugin and refuses to load two builds with the same plugin path, so
+// build each version with a unique one:
+//
+//	go build -buildmode=plugin -ldflags="-pluginpath=mixer-$(date +%s)" -o plugins/mixer.so ./mixer
+//
+// plugins must be built with the same Go version and dependency versions as the app. Go
+// supports plugins on Linux, macOS and FreeBSD only, and an app importing this package is
+// linked for dynamic loading, which needs the imgui libraries built as position
+// independent code.
+package goplugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"plugin"
+	"sync/atomic"
+
+	"github.com/michaelquigley/dfx"
+	"github.com/pkg/errors"
+)
+
+var opened atomic.Int64 // plugins opened so far, naming the copies handed to plugin.Open
+
+// Open opens the Go plugin at path and returns its dfx.PluginSymbol function. plugin.Open
+// returns the plugin it already opened for a path, so each version is opened from a fresh
+// copy.
+func Open(path string) (func() dfx.Component, error) {
+	copyPath := filepath.Join(os.TempDir(), fmt.Sprintf("dfx-plugin-%d-%d-%v", os.Getpid(), opened.Add(1), filepath.Base(path)))
+	if err := copyFile(path, copyPath); err != nil {
+		return nil, errors.Wrapf(err, "error copying plugin '%v'", path)
+	}
+	defer func() { _ = os.Remove(copyPath) }() // the opened plugin stays mapped
+
+	plug, err := plugin.Open(copyPath)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := plug.Lookup(dfx.PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	factory, ok := sym.(func() dfx.Component)
+	if !ok {
+		return nil, errors.Errorf("'%v' is '%T', not 'func() dfx.Component'", dfx.PluginSymbol, sym)
+	}
+	return factory, nil
+}
+
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
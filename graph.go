@@ -0,0 +1,179 @@
+package dfx
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// GraphPin is a named connection point of a node. pins with a Type only connect to pins
+// of the same type; an empty Type connects to anything.
+type GraphPin struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// GraphNode is a node of a Graph, placed on the canvas at X, Y.
+type GraphNode struct {
+	ID      string     `json:"id"`
+	Title   string     `json:"title"`
+	X       float32    `json:"x"`
+	Y       float32    `json:"y"`
+	Inputs  []GraphPin `json:"inputs,omitempty"`
+	Outputs []GraphPin `json:"outputs,omitempty"`
+	Data    any        `json:"data,omitempty"` // application data
+}
+
+// input returns the input pin with id.
+func (n *GraphNode) input(id string) (GraphPin, bool) {
+	for _, pin := range n.Inputs {
+		if pin.ID == id {
+			return pin, true
+		}
+	}
+	return GraphPin{}, false
+}
+
+// output returns the output pin with id.
+func (n *GraphNode) output(id string) (GraphPin, bool) {
+	for _, pin := range n.Outputs {
+		if pin.ID == id {
+			return pin, true
+		}
+	}
+	return GraphPin{}, false
+}
+
+// GraphLink connects an output pin to an input pin.
+type GraphLink struct {
+	FromNode string `json:"fromNode"`
+	FromPin  string `json:"fromPin"`
+	ToNode   string `json:"toNode"`
+	ToPin    string `json:"toPin"`
+}
+
+// Graph is a serializable model of nodes and the links between their pins. an input pin
+// takes one link unless MultiInput is set; an output feeds any number.
+type Graph struct {
+	Nodes      []*GraphNode `json:"nodes"`
+	Links      []GraphLink  `json:"links"`
+	MultiInput bool         `json:"multiInput,omitempty"`
+}
+
+// NewGraph creates a graph of nodes.
+func NewGraph(nodes ...*GraphNode) *Graph {
+	return &Graph{Nodes: nodes}
+}
+
+// Node returns the node with id, or nil.
+func (g *Graph) Node(id string) *GraphNode {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// AddNode adds node, which must have an id not already in the graph.
+func (g *Graph) AddNode(node *GraphNode) error {
+	if node.ID == "" {
+		return errors.New("node has no id")
+	}
+	if g.Node(node.ID) != nil {
+		return errors.Errorf("duplicate node '%v'", node.ID)
+	}
+	g.Nodes = append(g.Nodes, node)
+	return nil
+}
+
+// RemoveNode removes the node with id and its links, returning the links removed.
+func (g *Graph) RemoveNode(id string) []GraphLink {
+	g.Nodes = slices.DeleteFunc(g.Nodes, func(node *GraphNode) bool { return node.ID == id })
+	var removed []GraphLink
+	g.Links = slices.DeleteFunc(g.Links, func(link GraphLink) bool {
+		if link.FromNode == id || link.ToNode == id {
+			removed = append(removed, link)
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// CanConnect returns why link cannot be made, or nil: both pins must exist, have
+// compatible types and not already be linked, and the link must not join a node to itself.
+func (g *Graph) CanConnect(link GraphLink) error {
+	from, to := g.Node(link.FromNode), g.Node(link.ToNode)
+	if from == nil {
+		return errors.Errorf("unknown node '%v'", link.FromNode)
+	}
+	if to == nil {
+		return errors.Errorf("unknown node '%v'", link.ToNode)
+	}
+	if from == to {
+		return errors.New("cannot connect a node to itself")
+	}
+	out, found := from.output(link.FromPin)
+	if !found {
+		return errors.Errorf("node '%v' has no output '%v'", link.FromNode, link.FromPin)
+	}
+	in, found := to.input(link.ToPin)
+	if !found {
+		return errors.Errorf("node '%v' has no input '%v'", link.ToNode, link.ToPin)
+	}
+	if out.Type != "" && in.Type != "" && out.Type != in.Type {
+		return errors.Errorf("cannot connect %v to %v", out.Type, in.Type)
+	}
+	if slices.Contains(g.Links, link) {
+		return errors.New("already connected")
+	}
+	return nil
+}
+
+// Connect adds link after checking it with CanConnect. unless MultiInput is set, a link
+// already into the same input is replaced and returned.
+func (g *Graph) Connect(link GraphLink) ([]GraphLink, error) {
+	if err := g.CanConnect(link); err != nil {
+		return nil, err
+	}
+	var replaced []GraphLink
+	if !g.MultiInput {
+		replaced = g.LinksTo(link.ToNode, link.ToPin)
+		for _, old := range replaced {
+			g.Disconnect(old)
+		}
+	}
+	g.Links = append(g.Links, link)
+	return replaced, nil
+}
+
+// Disconnect removes link, returning false when it was not in the graph.
+func (g *Graph) Disconnect(link GraphLink) bool {
+	n := len(g.Links)
+	g.Links = slices.DeleteFunc(g.Links, func(l GraphLink) bool { return l == link })
+	return len(g.Links) != n
+}
+
+// LinksTo returns the links into an input pin.
+func (g *Graph) LinksTo(node, pin string) []GraphLink {
+	var links []GraphLink
+	for _, link := range g.Links {
+		if link.ToNode == node && link.ToPin == pin {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// LinksFrom returns the links out of an output pin.
+func (g *Graph) LinksFrom(node, pin string) []GraphLink {
+	var links []GraphLink
+	for _, link := range g.Links {
+		if link.FromNode == node && link.FromPin == pin {
+			links = append(links, link)
+		}
+	}
+	return links
+}
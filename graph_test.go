@@ -0,0 +1,80 @@
+package dfx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func testGraph() *Graph {
+	return NewGraph(
+		&GraphNode{ID: "osc", Title: "Oscillator", Outputs: []GraphPin{{ID: "out", Name: "Out", Type: "audio"}}},
+		&GraphNode{ID: "lfo", Title: "LFO", Outputs: []GraphPin{{ID: "out", Name: "Out", Type: "cv"}}},
+		&GraphNode{ID: "vca", Title: "VCA",
+			Inputs:  []GraphPin{{ID: "in", Name: "In", Type: "audio"}, {ID: "gain", Name: "Gain", Type: "cv"}},
+			Outputs: []GraphPin{{ID: "out", Name: "Out", Type: "audio"}},
+		},
+	)
+}
+
+func TestGraphConnect(t *testing.T) {
+	g := testGraph()
+	link := GraphLink{FromNode: "osc", FromPin: "out", ToNode: "vca", ToPin: "in"}
+	if _, err := g.Connect(link); err != nil {
+		t.Fatalf("expected the link to connect, got %v", err)
+	}
+
+	rejected := []GraphLink{
+		{FromNode: "osc", FromPin: "out", ToNode: "vca", ToPin: "gain"},   // audio into cv
+		{FromNode: "vca", FromPin: "out", ToNode: "vca", ToPin: "in"},     // to itself
+		{FromNode: "osc", FromPin: "missing", ToNode: "vca", ToPin: "in"}, // no such output
+		{FromNode: "gone", FromPin: "out", ToNode: "vca", ToPin: "in"},    // no such node
+		link, // already connected
+	}
+	for _, l := range rejected {
+		if err := g.CanConnect(l); err == nil {
+			t.Errorf("expected %+v to be rejected", l)
+		}
+	}
+
+	// a second link into the same input replaces the first
+	g.Nodes = append(g.Nodes, &GraphNode{ID: "noise", Outputs: []GraphPin{{ID: "out"}}})
+	replaced, err := g.Connect(GraphLink{FromNode: "noise", FromPin: "out", ToNode: "vca", ToPin: "in"})
+	if err != nil || !reflect.DeepEqual(replaced, []GraphLink{link}) || len(g.Links) != 1 {
+		t.Errorf("expected the oscillator link replaced, got %v, %v with %v", replaced, err, g.Links)
+	}
+
+	g.MultiInput = true
+	if replaced, _ := g.Connect(link); len(replaced) != 0 || len(g.LinksTo("vca", "in")) != 2 {
+		t.Errorf("expected both links into the input with MultiInput, got %v", g.Links)
+	}
+}
+
+func TestGraphRemoveNode(t *testing.T) {
+	g := testGraph()
+	g.Connect(GraphLink{FromNode: "osc", FromPin: "out", ToNode: "vca", ToPin: "in"})
+	g.Connect(GraphLink{FromNode: "lfo", FromPin: "out", ToNode: "vca", ToPin: "gain"})
+	if removed := g.RemoveNode("vca"); len(removed) != 2 || len(g.Links) != 0 || g.Node("vca") != nil {
+		t.Errorf("expected the node and both links removed, got %v removed, %v left", removed, g.Links)
+	}
+	if err := g.AddNode(&GraphNode{ID: "osc"}); err == nil {
+		t.Error("expected a duplicate id to be rejected")
+	}
+}
+
+func TestGraphJSON(t *testing.T) {
+	g := testGraph()
+	g.Nodes[0].X, g.Nodes[0].Y = 40, 80
+	g.Connect(GraphLink{FromNode: "osc", FromPin: "out", ToNode: "vca", ToPin: "in"})
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored Graph
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&restored, g) {
+		t.Errorf("expected the graph to round trip, got %s", data)
+	}
+}
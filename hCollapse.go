@@ -1,13 +1,27 @@
 package dfx
 
 import (
+	"time"
+
 	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/michaelquigley/dfx/fonts"
 )
 
+// HCollapseDirection is the edge an HCollapse is anchored to, which stays in place as the
+// panel expands and collapses.
+type HCollapseDirection int
+
+const (
+	// HCollapseLeft anchors the panel to the left and expands it rightward (default).
+	HCollapseLeft HCollapseDirection = iota
+	// HCollapseRight anchors the panel to the right edge of the space left on its line and
+	// expands it leftward, for right-hand sidebars.
+	HCollapseRight
+)
+
 // HCollapse is a horizontal collapsible component that contains content to its right.
 // when collapsed, only the toggle button is visible. when expanded, shows a header
-// bar with title and the content below.
+// bar with title and the content below. Direction mirrors it for right-hand sidebars.
 type HCollapse struct {
 	Container
 	Title         string              // displayed in header when expanded (also used for imgui ID)
@@ -15,12 +29,26 @@ type HCollapse struct {
 	ExpandedWidth float32             // width when fully expanded
 	CurrentWidth  float32             // animated width (internal)
 	MinWidth      float32             // collapsed width (toggle button only)
-	MaxWidth      float32             // maximum width when resizing (0 = no limit)
+	MaxWidth      float32             // maximum expanded width (0 = no limit)
+	WidthPercent  float32             // expanded width as a percentage of State.Size.X, kept as the parent resizes (0 = ExpandedWidth fixed)
+	MinExpanded   float32             // minimum expanded width (0 = MinWidth)
 	Height        float32             // vertical height (0 = use available height from state.Size.Y)
 	TransitionMs  int                 // animation duration
+	Easing        Easing              // expand/collapse easing curve (nil = DefaultEasing)
 	Resizable     bool                // allow drag-to-resize when expanded
+	Direction     HCollapseDirection  // anchored edge (default: HCollapseLeft)
 	Content       Component           // the component to show/hide
 	OnToggle      func(expanded bool) // optional callback on state change
+	OnResize      func(width float32) // optional callback as the expanded width is dragged
+
+	// AnimateOnStart animates from CurrentWidth on the first frame, rather than starting
+	// at the target width.
+	AnimateOnStart bool
+
+	anim        *Animation
+	animWidth   float32 // CurrentWidth as last set by the animation
+	skipAnim    bool    // jump to the target width on the next frame
+	percentBase float32 // parent width WidthPercent was last applied to
 }
 
 // HCollapseConfig provides configuration options for NewHCollapse.
@@ -29,10 +57,14 @@ type HCollapseConfig struct {
 	ExpandedWidth float32
 	MinWidth      float32 // defaults to HCollapseDefaultMinWidth
 	MaxWidth      float32 // 0 = no limit
+	WidthPercent  float32 // percentage of the parent width; ExpandedWidth is then unused
+	MinExpanded   float32 // minimum expanded width (0 = MinWidth)
 	Height        float32 // 0 = fill available height from parent
 	TransitionMs  int     // defaults to HCollapseDefaultTransition
+	Easing        Easing  // defaults to DefaultEasing
 	Resizable     bool
-	Expanded      bool // initial state
+	Direction     HCollapseDirection // defaults to HCollapseLeft
+	Expanded      bool               // initial state
 }
 
 // HCollapse constants
@@ -73,9 +105,13 @@ func NewHCollapse(content Component, cfg HCollapseConfig) *HCollapse {
 		CurrentWidth:  currentWidth,
 		MinWidth:      minWidth,
 		MaxWidth:      cfg.MaxWidth,
+		WidthPercent:  cfg.WidthPercent,
+		MinExpanded:   cfg.MinExpanded,
 		Height:        cfg.Height,
 		TransitionMs:  transitionMs,
+		Easing:        cfg.Easing,
 		Resizable:     cfg.Resizable,
+		Direction:     cfg.Direction,
 		Content:       content,
 	}
 }
@@ -110,7 +146,9 @@ func (h *HCollapse) Draw(state *State) {
 	}
 
 	// animate toward target width
-	h.animate()
+	h.applyPercent(state.Size.X)
+	h.animate(state.Clock())
+	h.anchor()
 
 	// when collapsed or collapsing, just draw the toggle button without any child windows
 	// this avoids scrollbar issues when the panel is narrow
@@ -163,7 +201,7 @@ func (h *HCollapse) drawCollapsedToggle(state *State) {
 	imgui.PushStyleColorVec4(imgui.ColButtonHovered, imgui.CurrentStyle().Colors()[imgui.ColHeaderHovered])
 	imgui.PushStyleColorVec4(imgui.ColButtonActive, imgui.CurrentStyle().Colors()[imgui.ColHeaderActive])
 
-	if imgui.Button(fonts.ICON_CHEVRON_RIGHT + h.imguiID() + "_toggle") {
+	if imgui.Button(h.chevron(true) + h.imguiID() + "_toggle") {
 		h.Toggle()
 	}
 	if imgui.IsItemHovered() && h.Title != "" {
@@ -176,15 +214,37 @@ func (h *HCollapse) drawCollapsedToggle(state *State) {
 	imgui.PopStyleVar()
 }
 
-// drawHeader draws the header bar with toggle button and title.
-func (h *HCollapse) drawHeader() {
-	windowPadding := imgui.CurrentStyle().WindowPadding()
-	imgui.SetCursorPos(windowPadding)
+// anchor moves the cursor so a right-anchored panel ends at the right edge of the space
+// left on its line.
+func (h *HCollapse) anchor() {
+	if h.Direction != HCollapseRight {
+		return
+	}
+	if offset := imgui.ContentRegionAvail().X - h.CurrentWidth; offset > 0 {
+		imgui.SetCursorPosX(imgui.CursorPosX() + offset)
+	}
+}
 
-	// toggle button
-	icon := fonts.ICON_CHEVRON_RIGHT
-	if h.Expanded {
-		icon = fonts.ICON_CHEVRON_LEFT
+// chevron returns the toggle icon, pointing the way the free edge moves: outward to
+// expand, inward to collapse.
+func (h *HCollapse) chevron(expand bool) string {
+	if expand == (h.Direction == HCollapseRight) {
+		return fonts.ICON_CHEVRON_LEFT
+	}
+	return fonts.ICON_CHEVRON_RIGHT
+}
+
+// drawHeader draws the header bar with toggle button and title. the toggle sits at the
+// anchored edge.
+func (h *HCollapse) drawHeader() {
+	style := imgui.CurrentStyle()
+	windowPadding := style.WindowPadding()
+	icon := h.chevron(!h.Expanded)
+	toggleWidth := imgui.CalcTextSize(icon).X + 2*style.FramePadding().X
+	if h.Direction == HCollapseRight {
+		imgui.SetCursorPos(imgui.Vec2{X: h.CurrentWidth - windowPadding.X - toggleWidth, Y: windowPadding.Y})
+	} else {
+		imgui.SetCursorPos(windowPadding)
 	}
 
 	imgui.PushStyleColorVec4(imgui.ColButton, imgui.Vec4{})
@@ -197,9 +257,18 @@ func (h *HCollapse) drawHeader() {
 
 	imgui.PopStyleColorV(3)
 
-	// title (only if there's room)
+	// title (only if there's room), after the toggle or, mirrored, clear of the resize
+	// handle on the left
 	if h.CurrentWidth > h.MinWidth+50 && h.Title != "" {
-		imgui.SameLine()
+		if h.Direction == HCollapseRight {
+			x := windowPadding.X
+			if h.Resizable {
+				x = HCollapseResizeHandleSize + style.ItemSpacing().X
+			}
+			imgui.SetCursorPos(imgui.Vec2{X: x, Y: windowPadding.Y + style.FramePadding().Y})
+		} else {
+			imgui.SameLine()
+		}
 		imgui.TextUnformatted(h.Title)
 	}
 }
@@ -223,29 +292,25 @@ func (h *HCollapse) drawContent(state *State) {
 	imgui.BeginChildStrV(h.imguiID()+"_content", imgui.Vec2{X: contentWidth, Y: contentHeight}, 0, contentFlags)
 
 	if h.Content != nil {
-		childState := &State{
-			Size:     imgui.Vec2{X: contentWidth, Y: contentHeight},
-			Position: imgui.Vec2{},
-			IO:       state.IO,
-			App:      state.App,
-			Parent:   h,
-		}
-		h.Content.Draw(childState)
+		DrawChild(h.Content, state.Child(imgui.Vec2{X: contentWidth, Y: contentHeight}, imgui.Vec2{}).WithParent(h))
 	}
 
 	imgui.EndChild()
 	imgui.PopStyleVar() // window padding
 }
 
-// drawResizeHandle draws the resize handle on the right edge as an overlay.
+// drawResizeHandle draws the resize handle on the free edge as an overlay.
 func (h *HCollapse) drawResizeHandle(state *State) {
 	handlePos := imgui.Vec2{
 		X: h.CurrentWidth - HCollapseResizeHandleSize,
 		Y: DefaultItemSpacing + 5,
 	}
+	if h.Direction == HCollapseRight {
+		handlePos.X = 0
+	}
 	imgui.SetCursorPos(handlePos)
 
-	imgui.PushStyleColorVec4(imgui.ColText, imgui.CurrentStyle().Colors()[imgui.ColHeaderActive])
+	imgui.PushStyleColorVec4(imgui.ColText, ThemeColors().Accent)
 	imgui.TextUnformatted(fonts.ICON_DRAG_INDICATOR)
 	imgui.PopStyleColor()
 
@@ -257,48 +322,127 @@ func (h *HCollapse) drawResizeHandle(state *State) {
 	}
 
 	if imgui.IsItemActive() {
-		delta := imgui.CurrentIO().MouseDelta().X
-		h.CurrentWidth += delta
-		h.ExpandedWidth += delta
-
-		// clamp to bounds
-		if h.CurrentWidth < h.MinWidth {
-			h.CurrentWidth = h.MinWidth
-			h.ExpandedWidth = h.MinWidth
-		}
-		if h.MaxWidth > 0 && h.CurrentWidth > h.MaxWidth {
-			h.CurrentWidth = h.MaxWidth
-			h.ExpandedWidth = h.MaxWidth
+		width := h.ExpandedWidth
+		h.resizeBy(imgui.CurrentIO().MouseDelta().X, state.Size.X)
+		if h.ExpandedWidth != width {
+			h.keepPercent()
+			if h.OnResize != nil {
+				h.OnResize(h.ExpandedWidth)
+			}
 		}
-		if h.CurrentWidth > state.Size.X-50 {
-			h.CurrentWidth = state.Size.X - 50
-			h.ExpandedWidth = state.Size.X - 50
+	}
+}
+
+// resizeBy moves the free edge by dx, within the width limits, leaving 50 points of the
+// available width for whatever shares the line.
+func (h *HCollapse) resizeBy(dx, available float32) {
+	if h.Direction == HCollapseRight {
+		dx = -dx // the free edge is on the left
+	}
+	h.CurrentWidth += dx
+	h.ExpandedWidth += dx
+
+	// clamp to bounds
+	if minWidth := max(h.MinExpanded, h.MinWidth); h.CurrentWidth < minWidth {
+		h.CurrentWidth = minWidth
+		h.ExpandedWidth = minWidth
+	}
+	if h.MaxWidth > 0 && h.CurrentWidth > h.MaxWidth {
+		h.CurrentWidth = h.MaxWidth
+		h.ExpandedWidth = h.MaxWidth
+	}
+	if h.CurrentWidth > available-50 {
+		h.CurrentWidth = available - 50
+		h.ExpandedWidth = available - 50
+	}
+}
+
+// SetExpandedWidth sets the expanded width, within MinExpanded and MaxWidth. an expanded
+// panel animates to it. with WidthPercent set, the percentage changes to match.
+func (h *HCollapse) SetExpandedWidth(width float32) {
+	h.ExpandedWidth = h.clampExpanded(width)
+	h.keepPercent()
+}
+
+// clampExpanded limits an expanded width to MinExpanded (or MinWidth) and MaxWidth.
+func (h *HCollapse) clampExpanded(width float32) float32 {
+	if h.MaxWidth > 0 {
+		width = min(width, h.MaxWidth)
+	}
+	return max(width, h.MinExpanded, h.MinWidth)
+}
+
+// applyPercent sizes the expanded width from WidthPercent when the parent width changed.
+// an expanded panel follows the parent without animating, while one expanding or
+// collapsing keeps animating toward the new width.
+func (h *HCollapse) applyPercent(parentWidth float32) {
+	if h.WidthPercent <= 0 || parentWidth <= 0 || parentWidth == h.percentBase {
+		return
+	}
+	fully := h.anim != nil && h.isFullyExpanded()
+	h.percentBase = parentWidth
+	width := h.clampExpanded(parentWidth * h.WidthPercent / 100)
+	if width != h.ExpandedWidth {
+		h.ExpandedWidth = width
+		if fully {
+			h.skipAnim = true
 		}
 	}
 }
 
-// animate updates CurrentWidth toward the target width.
-func (h *HCollapse) animate() {
-	target := h.MinWidth
+// keepPercent updates WidthPercent to the expanded width, after it was set or dragged, so
+// the panel stays the new size relative to the parent.
+func (h *HCollapse) keepPercent() {
+	if h.WidthPercent > 0 && h.percentBase > 0 {
+		h.WidthPercent = h.ExpandedWidth / h.percentBase * 100
+	}
+}
+
+// SkipAnimationOnce jumps to the target width on the next frame instead of animating, for
+// programmatic layout changes.
+func (h *HCollapse) SkipAnimationOnce() {
+	h.skipAnim = true
+}
+
+// targetWidth returns ExpandedWidth when expanded, or MinWidth when collapsed.
+func (h *HCollapse) targetWidth() float32 {
 	if h.Expanded {
-		target = h.ExpandedWidth
+		return h.ExpandedWidth
 	}
+	return h.MinWidth
+}
 
-	if h.CurrentWidth < target {
-		h.CurrentWidth += h.collapsePxPerFrame()
-		if h.CurrentWidth > target {
-			h.CurrentWidth = target
-		}
-	} else if h.CurrentWidth > target {
-		h.CurrentWidth -= h.collapsePxPerFrame()
-		if h.CurrentWidth < target {
-			h.CurrentWidth = target
-		}
+// settle jumps CurrentWidth to the target width on the first frame (unless AnimateOnStart)
+// or after SkipAnimationOnce.
+func (h *HCollapse) settle() {
+	if !h.skipAnim && (h.anim != nil || h.AnimateOnStart) {
+		return
+	}
+	h.skipAnim = false
+	if h.anim == nil {
+		h.anim = NewAnimation(0, 0, nil)
 	}
+	h.CurrentWidth = h.targetWidth()
+	h.anim.Snap(h.CurrentWidth)
+	h.animWidth = h.CurrentWidth
 }
 
-func (h *HCollapse) collapsePxPerFrame() float32 {
-	return pxPerFrame(h.ExpandedWidth, h.TransitionMs)
+// animate updates CurrentWidth toward the target width.
+func (h *HCollapse) animate(now time.Time) {
+	h.settle()
+	if h.anim == nil || h.CurrentWidth != h.animWidth {
+		// first frame, or the width was changed by resizing
+		if h.anim == nil {
+			h.anim = NewAnimation(0, 0, nil)
+		}
+		h.anim.Snap(h.CurrentWidth)
+	}
+	h.anim.Duration = time.Duration(h.TransitionMs) * time.Millisecond
+	h.anim.Easing = h.Easing
+
+	h.anim.SetTargetAt(h.targetWidth(), now)
+	h.CurrentWidth = h.anim.ValueAt(now)
+	h.animWidth = h.CurrentWidth
 }
 
 // isFullyExpanded returns true if the animation has completed to expanded state.
@@ -0,0 +1,222 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// HCollapseGroup constants
+const (
+	HCollapseGroupDefaultSpacing = 8
+)
+
+// HCollapseGroup lays out HCollapse panels in a row and coordinates them: it keeps their
+// combined width within a budget and can restrict the row to a single expanded panel.
+// panels that have to make room animate closed. an optional Content component fills the
+// width left over to the right of the panels.
+type HCollapseGroup struct {
+	Container
+	Panels          []*HCollapse
+	Content         Component // optional component filling the remaining width
+	Exclusive       bool      // only one panel may be expanded at a time
+	MaxWidth        float32   // width budget for the panels (0 = available width minus MinContentWidth)
+	MinContentWidth float32   // width reserved for Content when MaxWidth is 0
+	Spacing         float32   // gap between panels
+
+	seen   map[*HCollapse]hCollapseSnapshot
+	recent []*HCollapse // panels in the order they were last expanded or resized, most recent last
+}
+
+type hCollapseSnapshot struct {
+	expanded bool
+	width    float32
+}
+
+// NewHCollapseGroup creates a group containing panels.
+func NewHCollapseGroup(panels ...*HCollapse) *HCollapseGroup {
+	return &HCollapseGroup{
+		Container: Container{Visible: true},
+		Panels:    panels,
+		Spacing:   HCollapseGroupDefaultSpacing,
+		seen:      make(map[*HCollapse]hCollapseSnapshot),
+	}
+}
+
+// Add appends a panel to the group.
+func (g *HCollapseGroup) Add(panel *HCollapse) {
+	g.Panels = append(g.Panels, panel)
+}
+
+// Expand expands panel, collapsing others as needed to respect Exclusive and the width budget.
+func (g *HCollapseGroup) Expand(panel *HCollapse) {
+	if !panel.Expanded {
+		panel.Toggle()
+	}
+}
+
+// CollapseAll collapses every panel in the group.
+func (g *HCollapseGroup) CollapseAll() {
+	for _, p := range g.Panels {
+		if p.Expanded {
+			p.Toggle()
+		}
+	}
+}
+
+// SkipAnimationOnce jumps every panel to its target width on the next frame.
+func (g *HCollapseGroup) SkipAnimationOnce() {
+	for _, p := range g.Panels {
+		p.SkipAnimationOnce()
+	}
+}
+
+// Draw implements Component.
+func (g *HCollapseGroup) Draw(state *State) {
+	if !g.Visible {
+		return
+	}
+
+	g.arrange(g.budget(state.Size.X))
+
+	used := float32(0)
+	for i, p := range g.Panels {
+		if i > 0 {
+			imgui.SameLineV(0, g.Spacing)
+			used += g.Spacing
+		}
+		DrawChild(p, state.WithParent(g))
+		used += p.CurrentWidth
+	}
+
+	if g.Content != nil {
+		if len(g.Panels) > 0 {
+			imgui.SameLineV(0, g.Spacing)
+			used += g.Spacing
+		}
+		size := imgui.Vec2{X: state.Size.X - used, Y: imgui.ContentRegionAvail().Y}
+		if size.X > 0 && size.Y > 0 {
+			imgui.BeginChildStrV("##hcollapseGroupContent", size, 0, 0)
+			DrawChild(g.Content, state.Child(size, imgui.Vec2{}).WithParent(g))
+			imgui.EndChild()
+		}
+	}
+
+	drawContainerExtensions(&g.Container, state)
+}
+
+// budget returns the width available to the panels.
+func (g *HCollapseGroup) budget(available float32) float32 {
+	if g.MaxWidth > 0 {
+		return g.MaxWidth
+	}
+	return available - g.MinContentWidth
+}
+
+// arrange reacts to panels that were expanded or resized since the last frame: it
+// enforces exclusivity, then collapses the least recently used panels (and finally
+// narrows the most recent one) until the row fits within budget.
+func (g *HCollapseGroup) arrange(budget float32) {
+	if g.seen == nil {
+		g.seen = make(map[*HCollapse]hCollapseSnapshot)
+	}
+
+	changed := false
+	for _, p := range g.Panels {
+		prev, known := g.seen[p]
+		opened := p.Expanded && (!known || !prev.expanded)
+		resized := p.Expanded && known && prev.expanded && p.ExpandedWidth != prev.width
+		if opened || resized {
+			g.touch(p)
+			changed = true
+		}
+	}
+
+	if changed {
+		newest := g.recent[len(g.recent)-1]
+		if g.Exclusive {
+			for _, p := range g.Panels {
+				if p != newest && p.Expanded {
+					p.Toggle()
+				}
+			}
+		}
+		// make room by closing the least recently used panels first
+		for _, p := range g.recent {
+			if g.targetWidth() <= budget {
+				break
+			}
+			if p != newest && p.Expanded {
+				p.Toggle()
+			}
+		}
+	}
+
+	// narrow the most recently used panel if it still does not fit
+	if over := g.targetWidth() - budget; over > 0 {
+		for i := len(g.recent) - 1; i >= 0; i-- {
+			p := g.recent[i]
+			if !p.Expanded || p.ExpandedWidth <= p.MinWidth {
+				continue
+			}
+			width := p.ExpandedWidth - over
+			if width < p.MinWidth {
+				width = p.MinWidth
+			}
+			over -= p.ExpandedWidth - width
+			p.ExpandedWidth = width
+			if p.CurrentWidth > width {
+				p.CurrentWidth = width
+			}
+			if over <= 0 {
+				break
+			}
+		}
+	}
+
+	for _, p := range g.Panels {
+		g.seen[p] = hCollapseSnapshot{expanded: p.Expanded, width: p.ExpandedWidth}
+	}
+}
+
+// targetWidth is the width the row will occupy once animations finish.
+func (g *HCollapseGroup) targetWidth() float32 {
+	total := float32(0)
+	for i, p := range g.Panels {
+		if i > 0 {
+			total += g.Spacing
+		}
+		if p.Expanded {
+			total += p.ExpandedWidth
+		} else {
+			total += p.MinWidth
+		}
+	}
+	return total
+}
+
+// touch moves panel to the most recent end of the usage order.
+func (g *HCollapseGroup) touch(panel *HCollapse) {
+	for i, p := range g.recent {
+		if p == panel {
+			g.recent = append(g.recent[:i], g.recent[i+1:]...)
+			break
+		}
+	}
+	g.recent = append(g.recent, panel)
+}
+
+// LocalActions returns group-local actions.
+func (g *HCollapseGroup) LocalActions() *ActionRegistry {
+	return g.Container.Actions()
+}
+
+// ChildActions returns the panels and content for action traversal.
+func (g *HCollapseGroup) ChildActions() []Component {
+	children := make([]Component, 0, len(g.Panels)+1)
+	for _, p := range g.Panels {
+		children = append(children, p)
+	}
+	if g.Content != nil {
+		children = append(children, g.Content)
+	}
+	return children
+}
@@ -0,0 +1,64 @@
+package dfx
+
+import "testing"
+
+func newTestPanel(title string, expanded bool) *HCollapse {
+	return NewHCollapse(nil, HCollapseConfig{Title: title, ExpandedWidth: 200, MinWidth: 40, Expanded: expanded})
+}
+
+func TestHCollapseGroup_Exclusive(t *testing.T) {
+	a := newTestPanel("a", true)
+	b := newTestPanel("b", false)
+	g := NewHCollapseGroup(a, b)
+	g.Exclusive = true
+	g.arrange(1000)
+
+	var toggled []bool
+	a.OnToggle = func(expanded bool) { toggled = append(toggled, expanded) }
+
+	b.Toggle()
+	g.arrange(1000)
+	if a.Expanded || !b.Expanded {
+		t.Fatalf("expected only 'b' expanded, got a=%v b=%v", a.Expanded, b.Expanded)
+	}
+	if len(toggled) != 1 || toggled[0] {
+		t.Fatalf("expected collapse callback for 'a', got '%v'", toggled)
+	}
+}
+
+func TestHCollapseGroup_BudgetCollapsesLeastRecent(t *testing.T) {
+	a := newTestPanel("a", true)
+	b := newTestPanel("b", true)
+	c := newTestPanel("c", false)
+	g := NewHCollapseGroup(a, b, c)
+	g.Spacing = 0
+	g.arrange(500)
+
+	// 200 + 200 + 200 exceeds 500: the oldest expanded panel closes
+	c.Toggle()
+	g.arrange(500)
+	if a.Expanded || !b.Expanded || !c.Expanded {
+		t.Fatalf("expected 'a' collapsed, got a=%v b=%v c=%v", a.Expanded, b.Expanded, c.Expanded)
+	}
+	if w := g.targetWidth(); w != 440 {
+		t.Fatalf("expected target width '440', got '%v'", w)
+	}
+}
+
+func TestHCollapseGroup_BudgetNarrowsResizedPanel(t *testing.T) {
+	a := newTestPanel("a", true)
+	b := newTestPanel("b", false)
+	g := NewHCollapseGroup(a, b)
+	g.Spacing = 0
+	g.arrange(400)
+
+	a.ExpandedWidth = 500
+	a.CurrentWidth = 500
+	g.arrange(400)
+	if a.ExpandedWidth != 360 || a.CurrentWidth != 360 {
+		t.Fatalf("expected 'a' narrowed to '360', got '%v/%v'", a.ExpandedWidth, a.CurrentWidth)
+	}
+	if b.Expanded {
+		t.Fatalf("expected 'b' to stay collapsed")
+	}
+}
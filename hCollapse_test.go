@@ -0,0 +1,138 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+func TestHCollapse_Chevron(t *testing.T) {
+	h := NewHCollapse(nil, HCollapseConfig{ExpandedWidth: 200})
+	if got := h.chevron(true); got != fonts.ICON_CHEVRON_RIGHT {
+		t.Fatalf("expected '%v', got '%v'", fonts.ICON_CHEVRON_RIGHT, got)
+	}
+	if got := h.chevron(false); got != fonts.ICON_CHEVRON_LEFT {
+		t.Fatalf("expected '%v', got '%v'", fonts.ICON_CHEVRON_LEFT, got)
+	}
+
+	h.Direction = HCollapseRight
+	if got := h.chevron(true); got != fonts.ICON_CHEVRON_LEFT {
+		t.Fatalf("expected '%v', got '%v'", fonts.ICON_CHEVRON_LEFT, got)
+	}
+	if got := h.chevron(false); got != fonts.ICON_CHEVRON_RIGHT {
+		t.Fatalf("expected '%v', got '%v'", fonts.ICON_CHEVRON_RIGHT, got)
+	}
+}
+
+func TestHCollapse_ResizeBy(t *testing.T) {
+	h := NewHCollapse(nil, HCollapseConfig{ExpandedWidth: 200, MaxWidth: 300, Expanded: true})
+	h.resizeBy(20, 1000)
+	if h.ExpandedWidth != float32(220) {
+		t.Fatalf("expected '%v', got '%v'", float32(220), h.ExpandedWidth)
+	}
+
+	// the free edge of a right-anchored panel is on the left, so dragging left widens it
+	h.Direction = HCollapseRight
+	h.resizeBy(-30, 1000)
+	if h.ExpandedWidth != float32(250) {
+		t.Fatalf("expected '%v', got '%v'", float32(250), h.ExpandedWidth)
+	}
+	h.resizeBy(30, 1000)
+	if h.ExpandedWidth != float32(220) {
+		t.Fatalf("expected '%v', got '%v'", float32(220), h.ExpandedWidth)
+	}
+
+	h.resizeBy(-500, 1000)
+	if h.ExpandedWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.ExpandedWidth)
+	}
+	h.resizeBy(-500, 200)
+	if h.CurrentWidth != float32(150) {
+		t.Fatalf("expected '%v', got '%v'", float32(150), h.CurrentWidth)
+	}
+	h.resizeBy(500, 1000)
+	if h.CurrentWidth != h.MinWidth {
+		t.Fatalf("expected '%v', got '%v'", h.MinWidth, h.CurrentWidth)
+	}
+}
+
+func TestHCollapse_SetExpandedWidth(t *testing.T) {
+	h := NewHCollapse(nil, HCollapseConfig{ExpandedWidth: 200, MaxWidth: 300, MinExpanded: 100, Expanded: true})
+	h.SetExpandedWidth(250)
+	if h.ExpandedWidth != float32(250) {
+		t.Fatalf("expected '%v', got '%v'", float32(250), h.ExpandedWidth)
+	}
+	h.SetExpandedWidth(400)
+	if h.ExpandedWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.ExpandedWidth)
+	}
+	h.SetExpandedWidth(10)
+	if h.ExpandedWidth != float32(100) {
+		t.Fatalf("expected '%v', got '%v'", float32(100), h.ExpandedWidth)
+	}
+
+	// an expanded panel animates to the new width
+	now := time.Now()
+	h.animate(now)
+	h.SetExpandedWidth(300)
+	h.animate(now)
+	if h.CurrentWidth != float32(100) {
+		t.Fatalf("expected '%v', got '%v'", float32(100), h.CurrentWidth)
+	}
+	h.animate(now.Add(time.Second))
+	if h.CurrentWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.CurrentWidth)
+	}
+}
+
+func TestHCollapse_WidthPercent(t *testing.T) {
+	h := NewHCollapse(nil, HCollapseConfig{WidthPercent: 30, MinExpanded: 120, MaxWidth: 400, Expanded: true})
+	h.applyPercent(1000)
+	if h.ExpandedWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.ExpandedWidth)
+	}
+	h.applyPercent(200)
+	if h.ExpandedWidth != float32(120) {
+		t.Fatalf("clamped to MinExpanded: expected '%v', got '%v'", float32(120), h.ExpandedWidth)
+	}
+	h.applyPercent(2000)
+	if h.ExpandedWidth != float32(400) {
+		t.Fatalf("clamped to MaxWidth: expected '%v', got '%v'", float32(400), h.ExpandedWidth)
+	}
+
+	// setting the width keeps the panel that size relative to the parent
+	h.applyPercent(1000)
+	h.SetExpandedWidth(250)
+	if math.Abs(float64(h.WidthPercent)-float64(25)) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", 25, h.WidthPercent)
+	}
+	h.applyPercent(1200)
+	if h.ExpandedWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.ExpandedWidth)
+	}
+
+	// the width only follows changes of the parent, so others (such as a group) may narrow it
+	h.ExpandedWidth = 200
+	h.applyPercent(1200)
+	if h.ExpandedWidth != float32(200) {
+		t.Fatalf("expected '%v', got '%v'", float32(200), h.ExpandedWidth)
+	}
+}
+
+func TestHCollapse_WidthPercentFollowsWithoutAnimating(t *testing.T) {
+	h := NewHCollapse(nil, HCollapseConfig{WidthPercent: 50, Expanded: true})
+	now := time.Now()
+	h.applyPercent(400)
+	h.animate(now)
+	if h.CurrentWidth != float32(200) {
+		t.Fatalf("expected '%v', got '%v'", float32(200), h.CurrentWidth)
+	}
+
+	h.applyPercent(600)
+	h.animate(now)
+	if h.CurrentWidth != float32(300) {
+		t.Fatalf("expected '%v', got '%v'", float32(300), h.CurrentWidth)
+	}
+}
@@ -0,0 +1,378 @@
+package dfx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// DefaultLocale is the locale whose catalog is consulted when the current locale has no
+// translation for a key. dfx's own strings are built into it.
+const DefaultLocale = "en"
+
+// Catalog maps translation keys to the text of one locale. texts may contain fmt verbs
+// filled in by T's arguments.
+type Catalog map[string]string
+
+// translation state, shared like the current theme
+var (
+	translationMu sync.RWMutex
+	catalogs      = map[string]Catalog{DefaultLocale: builtinCatalog()}
+	locale        = DefaultLocale
+)
+
+// T returns the text for key in the current locale, falling back to the language without
+// its region ("pt-BR" -> "pt"), then to DefaultLocale, then to the key itself. with args,
+// the text is formatted with fmt.Sprintf.
+//
+//	imgui.Text(dfx.T("files.count", len(files))) // "files.count": "%d files"
+func T(key string, args ...any) string {
+	translationMu.RLock()
+	text, found := lookupTranslation(locale, key)
+	translationMu.RUnlock()
+	if !found {
+		text = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// lookupTranslation finds key for loc along the fallback chain. the caller holds
+// translationMu.
+func lookupTranslation(loc, key string) (string, bool) {
+	fallbacks, n := localeFallbacks(loc)
+	for _, candidate := range fallbacks[:n] {
+		if text, found := catalogs[candidate][key]; found {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// localeFallbacks returns the locales consulted for loc, most specific first, in the first
+// n entries. they are returned in an array, as every translation looks them up.
+func localeFallbacks(loc string) (fallbacks [3]string, n int) {
+	fallbacks[0], n = loc, 1
+	if language := localeLanguage(loc); language != loc {
+		fallbacks[n] = language
+		n++
+	}
+	if fallbacks[n-1] != DefaultLocale {
+		fallbacks[n] = DefaultLocale
+		n++
+	}
+	return fallbacks, n
+}
+
+// localeLanguage returns the language part of a locale ("pt-BR", "pt_BR.UTF-8" -> "pt").
+func localeLanguage(loc string) string {
+	if i := strings.IndexAny(loc, "-_."); i >= 0 {
+		return loc[:i]
+	}
+	return loc
+}
+
+// AddCatalog adds translations for a locale, replacing texts already present for the
+// same keys. it may be called from any goroutine.
+func AddCatalog(loc string, catalog Catalog) {
+	translationMu.Lock()
+	defer translationMu.Unlock()
+	existing, found := catalogs[loc]
+	if !found {
+		existing = make(Catalog, len(catalog))
+		catalogs[loc] = existing
+	}
+	for key, text := range catalog {
+		existing[key] = text
+	}
+}
+
+// SetLocale switches the current locale (e.g. "de", "pt-BR"). apps are told through
+// Config.OnLocaleChange at the start of the next frame.
+func SetLocale(loc string) {
+	translationMu.Lock()
+	defer translationMu.Unlock()
+	locale = loc
+}
+
+// Locale returns the current locale.
+func Locale() string {
+	translationMu.RLock()
+	defer translationMu.RUnlock()
+	return locale
+}
+
+// Locales returns the locales that have catalogs.
+func Locales() []string {
+	translationMu.RLock()
+	defer translationMu.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for loc := range catalogs {
+		locales = append(locales, loc)
+	}
+	return locales
+}
+
+// rtlLanguages are the languages written right to left.
+var rtlLanguages = map[string]bool{
+	"ar": true, "dv": true, "fa": true, "he": true, "iw": true, "ks": true,
+	"ku": true, "ps": true, "sd": true, "ug": true, "ur": true, "yi": true,
+}
+
+// IsRTLLocale reports whether a locale's language is written right to left.
+func IsRTLLocale(loc string) bool {
+	return rtlLanguages[strings.ToLower(localeLanguage(loc))]
+}
+
+// IsRTL reports whether the current locale is written right to left; components use it
+// to align text to the right edge.
+func IsRTL() bool {
+	return IsRTLLocale(Locale())
+}
+
+// LocaleText draws text aligned to the start of a line in the current locale: the left
+// edge, or the right edge of the available width for right-to-left locales.
+func LocaleText(text string) {
+	if IsRTL() {
+		if width := imgui.CalcTextSize(text).X; width < imgui.ContentRegionAvail().X {
+			imgui.SetCursorPosX(imgui.CursorPosX() + imgui.ContentRegionAvail().X - width)
+		}
+	}
+	imgui.TextUnformatted(text)
+}
+
+// ParseJSONCatalog parses a JSON catalog: an object of keys to texts. nested objects
+// are flattened with dots, so {"file": {"open": "Open"}} defines "file.open".
+func ParseJSONCatalog(data []byte) (Catalog, error) {
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, errors.Wrap(err, "error parsing json catalog")
+	}
+	catalog := make(Catalog)
+	if err := flattenCatalog(catalog, "", tree); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+func flattenCatalog(catalog Catalog, prefix string, tree map[string]any) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			catalog[key] = v
+		case map[string]any:
+			if err := flattenCatalog(catalog, key, v); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("error parsing json catalog: '%v' is not a string", key)
+		}
+	}
+	return nil
+}
+
+// ParsePOCatalog parses a gettext PO catalog, keyed by msgid. untranslated entries, the
+// header and obsolete entries are skipped; for plurals the singular form is used.
+func ParsePOCatalog(data []byte) (Catalog, error) {
+	catalog := make(Catalog)
+	var msgid, msgstr *strings.Builder
+	var current *strings.Builder
+	flush := func() {
+		if msgid != nil && msgstr != nil && msgid.Len() > 0 && msgstr.Len() > 0 {
+			catalog[msgid.String()] = msgstr.String()
+		}
+		msgid, msgstr, current = nil, nil, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		keyword, rest, _ := strings.Cut(line, " ")
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			if line == "" {
+				flush()
+			}
+			continue
+		case strings.HasPrefix(line, "\""):
+			rest = line
+		case keyword == "msgctxt":
+			flush()
+			current = &strings.Builder{} // contexts are not part of the key
+		case keyword == "msgid":
+			flush()
+			msgid = &strings.Builder{}
+			current = msgid
+		case keyword == "msgid_plural", strings.HasPrefix(keyword, "msgstr[") && keyword != "msgstr[0]":
+			current = &strings.Builder{} // plural forms beyond the singular are ignored
+		case keyword == "msgstr", keyword == "msgstr[0]":
+			msgstr = &strings.Builder{}
+			current = msgstr
+		default:
+			return nil, errors.Errorf("error parsing po catalog: line %d: unexpected '%v'", lineNumber, keyword)
+		}
+		if current == nil {
+			return nil, errors.Errorf("error parsing po catalog: line %d: string outside an entry", lineNumber)
+		}
+		text, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing po catalog: line %d", lineNumber)
+		}
+		current.WriteString(text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading po catalog")
+	}
+	flush()
+	return catalog, nil
+}
+
+// LoadCatalogFile reads a .json or .po catalog file and adds it for loc.
+func LoadCatalogFile(loc, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "error reading catalog '%v'", path)
+	}
+	var catalog Catalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		catalog, err = ParseJSONCatalog(data)
+	case ".po":
+		catalog, err = ParsePOCatalog(data)
+	default:
+		return errors.Errorf("unsupported catalog format '%v'", path)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error loading catalog '%v'", path)
+	}
+	AddCatalog(loc, catalog)
+	return nil
+}
+
+// checkLocale tells the app about a locale change; it runs at the start of every frame.
+func (app *App) checkLocale() {
+	current := Locale()
+	if current == app.locale {
+		return
+	}
+	app.locale = current
+	if app.config.OnLocaleChange != nil {
+		app.config.OnLocaleChange(app, current)
+	}
+}
+
+// builtinCatalog returns the English texts of dfx's own components. translate them by
+// adding the same keys to a catalog for another locale.
+func builtinCatalog() Catalog {
+	return Catalog{
+		"dfx.inspector.empty":    "nothing to inspect",
+		"dfx.inspector.visible":  "Visible",
+		"dfx.inspector.noState":  "no draw state recorded",
+		"dfx.inspector.actions":  "Actions",
+		"dfx.inspector.state":    "State",
+		"dfx.actions.refresh":    "Refresh",
+		"dfx.actions.ok":         "no conflicting, unreachable or invalid key bindings",
+		"dfx.log.search":         "search log",
+		"dfx.log.noMatches":      "no matches",
+		"dfx.log.noSources":      "no sources yet",
+		"dfx.log.expandTip":      "expand (or double-click the line)",
+		"dfx.log.expand":         "Expand",
+		"dfx.log.collapseAll":    "Collapse All",
+		"dfx.log.selectAll":      "Select All",
+		"dfx.log.clearSelection": "Clear Selection",
+		"dfx.log.time":           "Time",
+		"dfx.log.function":       "Function",
+		"dfx.log.fields":         "Fields",
+		"dfx.password.show":      "show password",
+		"dfx.password.hide":      "hide password",
+		"dfx.password.paste":     "Paste",
+		"dfx.curve.linear":       "Linear",
+		"dfx.curve.exponential":  "Exponential",
+		"dfx.curve.bezier":       "Bezier",
+		"dfx.curve.delete":       "Delete Point",
+		"dfx.graph.delete":       "Delete Node",
+		"dfx.graph.disconnect":   "Disconnect",
+		"dfx.router.mute":        "M",
+		"dfx.router.muteTooltip": "Mute",
+		"dfx.router.solo":        "S",
+		"dfx.router.soloTooltip": "Solo",
+		"dfx.strip.gain":         "Gain",
+		"dfx.strip.pan":          "Pan",
+		"dfx.strip.volume":       "Volume",
+		"dfx.strip.arm":          "R",
+		"dfx.strip.armTooltip":   "Record Arm",
+		"dfx.check.all":          "All",
+		"dfx.check.none":         "None",
+		"dfx.grid.restore":       "click to restore",
+		"dfx.settings.notStruct": "settings: config must be a pointer to a struct",
+		"dfx.settings.apply":     "Apply",
+		"dfx.settings.revert":    "Revert",
+		"dfx.settings.reset":     "Reset to Defaults",
+		"dfx.settings.resetRow":  "Reset to Default",
+		"dfx.settings.search":    "Search settings",
+		"dfx.recent.menu":        "Open Recent",
+		"dfx.recent.clear":       "Clear Recent",
+		"dfx.recent.none":        "no recent files",
+		"dfx.recent.pin":         "Pin",
+		"dfx.recent.unpin":       "Unpin",
+		"dfx.recent.remove":      "Remove from List",
+		"dfx.doc.untitled":       "Untitled",
+		"dfx.doc.unsavedTitle":   "Unsaved Changes",
+		"dfx.doc.unsaved":        "Save changes to '%v' first?",
+		"dfx.doc.save":           "Save",
+		"dfx.doc.discard":        "Discard",
+		"dfx.doc.cancel":         "Cancel",
+		"dfx.doc.recoveryTitle":  "Recover Changes",
+		"dfx.doc.recovery":       "'%v' has autosaved changes that were never saved. recover them?",
+		"dfx.doc.recover":        "Recover",
+		"dfx.quit.unsaved":       "Save changes before closing?",
+		"dfx.quit.failed":        "saving failed: %v",
+		"dfx.zoom.fit":           "Zoom to Fit",
+		"dfx.zoom.actual":        "Actual Size",
+		"dfx.about.title":        "About %v",
+		"dfx.about.version":      "version %v",
+		"dfx.about.authors":      "by %v",
+		"dfx.about.license":      "License: %v",
+		"dfx.about.credits":      "Credits",
+		"dfx.about.copy":         "Copy Diagnostics",
+		"dfx.about.copied":       "Copied",
+		"dfx.about.close":        "Close",
+		"dfx.crash.title":        "Crash Report",
+		"dfx.crash.message":      "the app quit unexpectedly last time. a report was saved:",
+		"dfx.crash.open":         "Open Report",
+		"dfx.crash.copy":         "Copy",
+		"dfx.crash.submit":       "Submit",
+		"dfx.crash.failed":       "submitting failed: %v",
+		"dfx.crash.dismiss":      "Dismiss",
+		"dfx.title.minimize":     "Minimize",
+		"dfx.title.maximize":     "Maximize",
+		"dfx.title.restore":      "Restore",
+		"dfx.title.close":        "Close",
+		"dfx.plugin.missing":     "plugin '%v' is not loaded",
+		"dfx.plugin.reload":      "Reload",
+		"dfx.search.hint":        "Search everywhere",
+		"dfx.search.none":        "no results",
+		"dfx.search.actions":     "Actions",
+		"dfx.search.workspaces":  "Workspaces",
+		"dfx.search.files":       "Files",
+		"dfx.search.recent":      "Recent Files",
+		"dfx.vu.exportPNG":       "Export PNG",
+		"dfx.vu.exportCSV":       "Export CSV",
+		"dfx.workspace.none":     "no workspaces configured",
+		"dfx.workspace.switch":   "Switch Workspace",
+	}
+}
@@ -0,0 +1,184 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withLocale restores the translation state after a test.
+func withLocale(t *testing.T) {
+	t.Helper()
+	translationMu.Lock()
+	saved := make(map[string]Catalog, len(catalogs))
+	for loc, catalog := range catalogs {
+		copied := make(Catalog, len(catalog))
+		for key, text := range catalog {
+			copied[key] = text
+		}
+		saved[loc] = copied
+	}
+	savedLocale := locale
+	translationMu.Unlock()
+	t.Cleanup(func() {
+		translationMu.Lock()
+		catalogs, locale = saved, savedLocale
+		translationMu.Unlock()
+	})
+}
+
+func TestTFallbacks(t *testing.T) {
+	withLocale(t)
+	AddCatalog("pt", Catalog{"greeting": "Olá", "farewell": "Tchau"})
+	AddCatalog("pt-BR", Catalog{"greeting": "Oi"})
+	AddCatalog(DefaultLocale, Catalog{"greeting": "Hello", "help": "Help"})
+
+	SetLocale("pt-BR")
+	tests := map[string]string{
+		"greeting":          "Oi",
+		"farewell":          "Tchau",
+		"help":              "Help",
+		"missing":           "missing",
+		"dfx.log.noMatches": "no matches",
+	}
+	for key, expected := range tests {
+		if got := T(key); got != expected {
+			t.Errorf("T(%q) = %q, expected %q", key, got, expected)
+		}
+	}
+}
+
+func TestTFormats(t *testing.T) {
+	withLocale(t)
+	AddCatalog("de", Catalog{"files": "%d Dateien"})
+	SetLocale("de")
+	if got := T("files", 3); got != "3 Dateien" {
+		t.Errorf("expected '3 Dateien', got %q", got)
+	}
+	if got := T("files"); got != "%d Dateien" {
+		t.Errorf("expected the unformatted text without args, got %q", got)
+	}
+}
+
+func TestIsRTLLocale(t *testing.T) {
+	tests := map[string]bool{
+		"ar":          true,
+		"he-IL":       true,
+		"fa_IR.UTF-8": true,
+		"en":          false,
+		"pt-BR":       false,
+		"":            false,
+	}
+	for loc, expected := range tests {
+		if got := IsRTLLocale(loc); got != expected {
+			t.Errorf("IsRTLLocale(%q) = %v, expected %v", loc, got, expected)
+		}
+	}
+}
+
+func TestParseJSONCatalog(t *testing.T) {
+	catalog, err := ParseJSONCatalog([]byte(`{"title": "Titel", "file": {"open": "Öffnen", "recent": {"clear": "Leeren"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Catalog{"title": "Titel", "file.open": "Öffnen", "file.recent.clear": "Leeren"}
+	if len(catalog) != len(expected) {
+		t.Fatalf("expected %d entries, got %v", len(expected), catalog)
+	}
+	for key, text := range expected {
+		if catalog[key] != text {
+			t.Errorf("%q = %q, expected %q", key, catalog[key], text)
+		}
+	}
+
+	if _, err := ParseJSONCatalog([]byte(`{"count": 3}`)); err == nil {
+		t.Error("expected an error for a non-string value")
+	}
+}
+
+func TestParsePOCatalog(t *testing.T) {
+	po := `# German translation
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#: settings.go:189
+msgid "dfx.settings.apply"
+msgstr "Übernehmen"
+
+msgid "long"
+msgstr ""
+"first "
+"second\n"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Öffnen"
+
+msgid "file"
+msgid_plural "files"
+msgstr[0] "Datei"
+msgstr[1] "Dateien"
+
+msgid "untranslated"
+msgstr ""
+`
+	catalog, err := ParsePOCatalog([]byte(po))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Catalog{
+		"dfx.settings.apply": "Übernehmen",
+		"long":               "first second\n",
+		"Open":               "Öffnen",
+		"file":               "Datei",
+	}
+	if len(catalog) != len(expected) {
+		t.Fatalf("expected %d entries, got %v", len(expected), catalog)
+	}
+	for key, text := range expected {
+		if catalog[key] != text {
+			t.Errorf("%q = %q, expected %q", key, catalog[key], text)
+		}
+	}
+
+	if _, err := ParsePOCatalog([]byte("msgid \"unterminated\nmsgstr \"x\"\n")); err == nil {
+		t.Error("expected an error for an unterminated string")
+	}
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	withLocale(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fr.json")
+	if err := os.WriteFile(path, []byte(`{"dfx": {"settings": {"apply": "Appliquer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCatalogFile("fr", path); err != nil {
+		t.Fatal(err)
+	}
+	SetLocale("fr-CA")
+	if got := T("dfx.settings.apply"); got != "Appliquer" {
+		t.Errorf("expected 'Appliquer', got %q", got)
+	}
+	if got := T("dfx.settings.revert"); got != "Revert" {
+		t.Errorf("expected the built-in fallback 'Revert', got %q", got)
+	}
+
+	if err := LoadCatalogFile("fr", filepath.Join(dir, "fr.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestCheckLocale(t *testing.T) {
+	withLocale(t)
+	var reported []string
+	app := New(nil, Config{OnLocaleChange: func(_ *App, loc string) { reported = append(reported, loc) }})
+	app.checkLocale()
+	SetLocale("ar")
+	app.checkLocale()
+	app.checkLocale()
+	if len(reported) != 1 || reported[0] != "ar" {
+		t.Errorf("expected one change to 'ar', got %v", reported)
+	}
+}
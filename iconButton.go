@@ -0,0 +1,62 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// IconButton draws a button showing an icon glyph, followed by label when it is not
+// empty. icon-only buttons are square (one frame high), so rows of them line up
+// regardless of glyph widths. tooltip, if set, is shown on hover and also keeps the
+// button's id unique among buttons sharing an icon. returns true when clicked.
+func IconButton(icon, label, tooltip string) bool {
+	text := iconButtonText(icon, label)
+	clicked := imgui.ButtonV(text+"##"+tooltip, iconButtonSize(text, label == ""))
+	if tooltip != "" {
+		imgui.SetItemTooltip(tooltip)
+	}
+	return clicked
+}
+
+// IconToggle draws an IconButton bound to value: clicking flips it, and the button is
+// shown pressed while value is set. returns true when value changed.
+func IconToggle(icon, label, tooltip string, value *bool) bool {
+	on := *value
+	if on {
+		imgui.PushStyleColorVec4(imgui.ColButton, imgui.CurrentStyle().Colors()[imgui.ColButtonActive])
+	}
+	clicked := IconButton(icon, label, tooltip)
+	if on {
+		imgui.PopStyleColor()
+	}
+	if clicked {
+		*value = !*value
+	}
+	return clicked
+}
+
+// IconButtonWidth returns the width IconButton will occupy for icon and label.
+func IconButtonWidth(icon, label string) float32 {
+	return iconButtonSize(iconButtonText(icon, label), label == "").X
+}
+
+func iconButtonText(icon, label string) string {
+	switch {
+	case label == "":
+		return icon
+	case icon == "":
+		return label
+	default:
+		return icon + " " + label
+	}
+}
+
+// iconButtonSize returns the button size for text: one frame high and at least as wide,
+// exactly square for icon-only buttons.
+func iconButtonSize(text string, square bool) imgui.Vec2 {
+	height := imgui.FrameHeight()
+	if square {
+		return imgui.Vec2{X: height, Y: height}
+	}
+	width := imgui.CalcTextSize(text).X + 2*imgui.CurrentStyle().FramePadding().X
+	return imgui.Vec2{X: max(width, height), Y: height}
+}
@@ -0,0 +1,184 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ImageScale controls how an image is sized to the space it is drawn in.
+type ImageScale int
+
+const (
+	ImageFit     ImageScale = iota // scale to fit entirely, preserving aspect ratio (letterboxed)
+	ImageFill                      // scale to cover the space, preserving aspect ratio (cropped)
+	ImageStretch                   // scale to the space exactly, ignoring aspect ratio
+	ImageNone                      // draw at the texture's pixel size
+)
+
+// Image displays a texture.
+type Image struct {
+	Container
+	Texture *Texture
+	Scale   ImageScale
+	Size    imgui.Vec2 // space to draw in (0 = available region, per axis)
+	Tint    imgui.Vec4 // color multiplied with the image (zero = white)
+	UV0     imgui.Vec2 // top-left of the sub-rect to draw, in texture coordinates
+	UV1     imgui.Vec2 // bottom-right of the sub-rect (zero UV0 and UV1 = whole texture)
+}
+
+// NewImage creates an image component displaying texture, scaled to fit.
+func NewImage(texture *Texture) *Image {
+	return &Image{
+		Container: Container{Visible: true},
+		Texture:   texture,
+	}
+}
+
+// Draw implements Component.
+func (i *Image) Draw(state *State) {
+	if !i.Visible {
+		return
+	}
+	region := i.Size
+	avail := imgui.ContentRegionAvail()
+	if region.X <= 0 {
+		region.X = avail.X
+	}
+	if region.Y <= 0 {
+		region.Y = avail.Y
+	}
+	drawTexture(i.Texture, region, i.Scale, i.UV0, i.UV1, i.Tint)
+	drawContainerExtensions(&i.Container, state)
+}
+
+// DrawImage draws texture within region using scale, for immediate-mode use.
+func DrawImage(texture *Texture, region imgui.Vec2, scale ImageScale) {
+	drawTexture(texture, region, scale, imgui.Vec2{}, imgui.Vec2{}, imgui.Vec4{})
+}
+
+// drawTexture lays out and draws a texture, reserving region in the layout.
+func drawTexture(texture *Texture, region imgui.Vec2, scale ImageScale, uv0, uv1 imgui.Vec2, tint imgui.Vec4) {
+	if texture == nil {
+		imgui.Dummy(region)
+		return
+	}
+	ref, ok := texture.TextureRef()
+	if !ok {
+		imgui.Dummy(region)
+		return
+	}
+	layout := layoutImage(texture.Size(), region, scale, uv0, uv1)
+	if scale == ImageNone {
+		region = layout.size
+	}
+
+	origin := imgui.CursorPos()
+	imgui.SetCursorPos(origin.Add(layout.offset))
+	imgui.ImageWithBgV(ref, layout.size, layout.uv0, layout.uv1, imgui.Vec4{}, imageTint(tint))
+	imgui.SetCursorPos(origin)
+	imgui.Dummy(region)
+}
+
+// ImageButton is a clickable texture.
+type ImageButton struct {
+	Container
+	Id         string
+	Texture    *Texture
+	Size       imgui.Vec2 // image size (0 = texture size, per axis)
+	Tint       imgui.Vec4 // color multiplied with the image (zero = white)
+	Background imgui.Vec4 // drawn behind the image (zero = transparent)
+	UV0        imgui.Vec2 // top-left of the sub-rect to draw, in texture coordinates
+	UV1        imgui.Vec2 // bottom-right of the sub-rect (zero UV0 and UV1 = whole texture)
+	Tooltip    string
+	OnClick    func()
+}
+
+// NewImageButton creates an image button that calls onClick when pressed.
+func NewImageButton(id string, texture *Texture, onClick func()) *ImageButton {
+	return &ImageButton{
+		Container: Container{Visible: true},
+		Id:        id,
+		Texture:   texture,
+		OnClick:   onClick,
+	}
+}
+
+// Draw implements Component.
+func (b *ImageButton) Draw(state *State) {
+	if !b.Visible || b.Texture == nil {
+		return
+	}
+	ref, ok := b.Texture.TextureRef()
+	if !ok {
+		return
+	}
+	size := b.Size
+	if size.X <= 0 {
+		size.X = float32(b.Texture.Width)
+	}
+	if size.Y <= 0 {
+		size.Y = float32(b.Texture.Height)
+	}
+	uv0, uv1 := imageUVs(b.UV0, b.UV1)
+	if imgui.ImageButtonV(b.Id, ref, size, uv0, uv1, b.Background, imageTint(b.Tint)) && b.OnClick != nil {
+		b.OnClick()
+	}
+	if b.Tooltip != "" {
+		imgui.SetItemTooltip(b.Tooltip)
+	}
+	drawContainerExtensions(&b.Container, state)
+}
+
+// imageLayout is where and how to draw an image within a region.
+type imageLayout struct {
+	size     imgui.Vec2 // drawn size
+	offset   imgui.Vec2 // position within the region
+	uv0, uv1 imgui.Vec2 // texture coordinates to sample
+}
+
+// layoutImage computes how a texture of textureSize (or its uv0-uv1 sub-rect) is drawn
+// within region using scale.
+func layoutImage(textureSize, region imgui.Vec2, scale ImageScale, uv0, uv1 imgui.Vec2) imageLayout {
+	uv0, uv1 = imageUVs(uv0, uv1)
+	source := imgui.Vec2{X: textureSize.X * (uv1.X - uv0.X), Y: textureSize.Y * (uv1.Y - uv0.Y)}
+	layout := imageLayout{size: region, uv0: uv0, uv1: uv1}
+	if source.X <= 0 || source.Y <= 0 || region.X <= 0 || region.Y <= 0 {
+		layout.size = imgui.Vec2{}
+		return layout
+	}
+
+	switch scale {
+	case ImageFit:
+		k := min(region.X/source.X, region.Y/source.Y)
+		layout.size = source.Mul(k)
+		layout.offset = region.Sub(layout.size).Mul(0.5)
+
+	case ImageFill:
+		k := max(region.X/source.X, region.Y/source.Y)
+		// crop the sampled sub-rect evenly on the overflowing axis
+		visible := imgui.Vec2{X: region.X / (source.X * k), Y: region.Y / (source.Y * k)}
+		span := uv1.Sub(uv0)
+		inset := imgui.Vec2{X: span.X * (1 - visible.X) / 2, Y: span.Y * (1 - visible.Y) / 2}
+		layout.uv0 = uv0.Add(inset)
+		layout.uv1 = uv1.Sub(inset)
+
+	case ImageNone:
+		layout.size = source
+	}
+	return layout
+}
+
+// imageUVs returns the sub-rect to sample, defaulting to the whole texture.
+func imageUVs(uv0, uv1 imgui.Vec2) (imgui.Vec2, imgui.Vec2) {
+	if uv0 == (imgui.Vec2{}) && uv1 == (imgui.Vec2{}) {
+		return imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1}
+	}
+	return uv0, uv1
+}
+
+// imageTint returns tint, defaulting to white.
+func imageTint(tint imgui.Vec4) imgui.Vec4 {
+	if tint == (imgui.Vec4{}) {
+		return imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}
+	}
+	return tint
+}
@@ -0,0 +1,46 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestLayoutImage(t *testing.T) {
+	texture := imgui.Vec2{X: 200, Y: 100}
+	region := imgui.Vec2{X: 100, Y: 100}
+
+	fit := layoutImage(texture, region, ImageFit, imgui.Vec2{}, imgui.Vec2{})
+	if fit.size != (imgui.Vec2{X: 100, Y: 50}) || fit.offset != (imgui.Vec2{X: 0, Y: 25}) {
+		t.Fatalf("unexpected fit layout %+v", fit)
+	}
+
+	fill := layoutImage(texture, region, ImageFill, imgui.Vec2{}, imgui.Vec2{})
+	if fill.size != region || fill.uv0 != (imgui.Vec2{X: 0.25, Y: 0}) || fill.uv1 != (imgui.Vec2{X: 0.75, Y: 1}) {
+		t.Fatalf("unexpected fill layout %+v", fill)
+	}
+
+	stretch := layoutImage(texture, region, ImageStretch, imgui.Vec2{}, imgui.Vec2{})
+	if stretch.size != region || stretch.uv1 != (imgui.Vec2{X: 1, Y: 1}) {
+		t.Fatalf("unexpected stretch layout %+v", stretch)
+	}
+
+	none := layoutImage(texture, region, ImageNone, imgui.Vec2{}, imgui.Vec2{})
+	if none.size != texture {
+		t.Fatalf("unexpected unscaled layout %+v", none)
+	}
+}
+
+func TestLayoutImage_SubRect(t *testing.T) {
+	// the left half of a 200x100 texture is a 100x100 square
+	uv0, uv1 := imgui.Vec2{}, imgui.Vec2{X: 0.5, Y: 1}
+	fit := layoutImage(imgui.Vec2{X: 200, Y: 100}, imgui.Vec2{X: 50, Y: 100}, ImageFit, uv0, uv1)
+	if fit.size != (imgui.Vec2{X: 50, Y: 50}) || fit.uv1 != uv1 {
+		t.Fatalf("unexpected sub-rect layout %+v", fit)
+	}
+
+	fill := layoutImage(imgui.Vec2{X: 200, Y: 100}, imgui.Vec2{X: 50, Y: 100}, ImageFill, uv0, uv1)
+	if fill.uv0 != (imgui.Vec2{X: 0.125, Y: 0}) || fill.uv1 != (imgui.Vec2{X: 0.375, Y: 1}) {
+		t.Fatalf("unexpected sub-rect fill %+v", fill)
+	}
+}
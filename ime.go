@@ -0,0 +1,72 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// IMEState describes text entry through an input method editor (IME) in the current
+// frame: whether a text control wants input, where its caret is and what is being
+// composed.
+type IMEState struct {
+	Active      bool       // a text control has keyboard input; the IME should be enabled
+	Pos         imgui.Vec2 // caret position in screen coordinates, where the candidate window belongs
+	LineHeight  float32    // height of the caret line
+	Composition string     // text being composed but not yet committed, from SetComposition
+}
+
+// IME returns the IME state of the last frame.
+func (app *App) IME() IMEState {
+	return app.ime
+}
+
+// SetComposition reports the text the platform IME is composing (its preedit string),
+// for backends and platform integrations that receive it; dfx draws it at the caret of
+// the active text control until it is committed as ordinary text input or cleared with
+// "". call it on the UI thread, or through Dispatch.
+func (app *App) SetComposition(text string) {
+	app.ime.Composition = text
+}
+
+// updateIME reads the caret imgui reported for this frame's active text control, draws
+// any composition there and reports changes through Config.OnIMEChange, so platform
+// integrations can move the candidate window. it runs after the root component draws.
+func (app *App) updateIME() {
+	data := imgui.CurrentContext().PlatformImeData()
+	next := imeState(data.WantVisible() || data.WantTextInput(), data.InputPos(), data.InputLineHeight(), app.ime.Composition)
+	if next.Composition != "" {
+		drawComposition(next)
+	}
+	app.setIME(next)
+}
+
+// imeState builds the state for a frame; without an active text control there is no
+// caret and nothing can be composed.
+func imeState(active bool, pos imgui.Vec2, lineHeight float32, composition string) IMEState {
+	if !active {
+		return IMEState{}
+	}
+	return IMEState{Active: true, Pos: pos, LineHeight: lineHeight, Composition: composition}
+}
+
+// setIME stores the state, calling Config.OnIMEChange when it differs from the last.
+func (app *App) setIME(next IMEState) {
+	if next == app.ime {
+		return
+	}
+	app.ime = next
+	if app.config.OnIMEChange != nil {
+		app.config.OnIMEChange(app, next)
+	}
+}
+
+// drawComposition draws the composed text over the caret, underlined as IMEs show it.
+func drawComposition(ime IMEState) {
+	drawList := imgui.ForegroundDrawListViewportPtr()
+	colors := imgui.CurrentStyle().Colors()
+	size := imgui.CalcTextSize(ime.Composition)
+	height := max(ime.LineHeight, size.Y)
+	bottomRight := ime.Pos.Add(imgui.Vec2{X: size.X, Y: height})
+	drawList.AddRectFilled(ime.Pos, bottomRight, imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]))
+	drawList.AddTextVec2(imgui.Vec2{X: ime.Pos.X, Y: ime.Pos.Y + (height-size.Y)/2}, imgui.ColorConvertFloat4ToU32(colors[imgui.ColText]), ime.Composition)
+	drawList.AddLine(imgui.Vec2{X: ime.Pos.X, Y: bottomRight.Y - 1}, imgui.Vec2{X: bottomRight.X, Y: bottomRight.Y - 1}, imgui.ColorConvertFloat4ToU32(colors[imgui.ColText]))
+}
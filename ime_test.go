@@ -0,0 +1,40 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestIMEState(t *testing.T) {
+	pos := imgui.Vec2{X: 40, Y: 120}
+	if got := imeState(false, pos, 16, "にほ"); got != (IMEState{}) {
+		t.Errorf("expected an empty state without an active text control, got %+v", got)
+	}
+	got := imeState(true, pos, 16, "にほ")
+	expected := IMEState{Active: true, Pos: pos, LineHeight: 16, Composition: "にほ"}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestIMEChange(t *testing.T) {
+	var reported []IMEState
+	app := New(nil, Config{OnIMEChange: func(_ *App, ime IMEState) { reported = append(reported, ime) }})
+
+	caret := imeState(true, imgui.Vec2{X: 10, Y: 20}, 16, "")
+	app.setIME(caret)
+	app.setIME(caret)
+	app.SetComposition("ni")
+	if got := app.IME().Composition; got != "ni" {
+		t.Errorf("expected the composition 'ni', got %q", got)
+	}
+	app.setIME(imeState(false, imgui.Vec2{}, 0, app.IME().Composition))
+
+	if len(reported) != 2 || !reported[0].Active || reported[1].Active {
+		t.Errorf("expected activation then deactivation, got %+v", reported)
+	}
+	if app.IME().Composition != "" {
+		t.Error("expected the composition to clear with the text control")
+	}
+}
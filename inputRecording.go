@@ -0,0 +1,310 @@
+package dfx
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// InputEventKind is the kind of an InputEvent.
+type InputEventKind string
+
+const (
+	InputMouseMove   InputEventKind = "move"   // the pointer moved to X, Y
+	InputMouseButton InputEventKind = "button" // Button was pressed (Down) or released
+	InputMouseWheel  InputEventKind = "wheel"  // the wheel moved by X, Y
+	InputKey         InputEventKind = "key"    // Key was pressed (Down) or released
+	InputChar        InputEventKind = "char"   // Char was typed
+)
+
+// inputModifiers are the modifier keys, reported apart from the keys that set them.
+var inputModifiers = []imgui.Key{imgui.ModCtrl, imgui.ModShift, imgui.ModAlt, imgui.ModSuper}
+
+// InputEvent is a mouse or keyboard event of an InputRecording.
+type InputEvent struct {
+	Frame  uint64         `json:"frame"` // frame of the recording it arrived in, from 0
+	Time   time.Duration  `json:"time"`  // since the recording started
+	Kind   InputEventKind `json:"kind"`
+	X      float32        `json:"x,omitempty"`      // pointer position, or wheel movement
+	Y      float32        `json:"y,omitempty"`      //
+	Button int            `json:"button,omitempty"` // 0 = left, 1 = right, 2 = middle
+	Key    imgui.Key      `json:"key,omitempty"`    // imgui key, or a modifier such as imgui.ModCtrl
+	Down   bool           `json:"down,omitempty"`   // pressed rather than released
+	Char   rune           `json:"char,omitempty"`   // character typed
+}
+
+// InputRecording is a session of mouse and keyboard input, captured by an InputRecorder
+// and replayed by an InputPlayer. pointer positions are in points from the top-left of
+// the window, so replay it in a window of the size it was recorded at.
+type InputRecording struct {
+	Width    int           `json:"width"`  // window size it was recorded at, in points
+	Height   int           `json:"height"` //
+	Frames   uint64        `json:"frames"`
+	Duration time.Duration `json:"duration"`
+	Events   []InputEvent  `json:"events"`
+}
+
+// LoadInputRecording reads a recording saved with Save.
+func LoadInputRecording(path string) (*InputRecording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading input recording '%v'", path)
+	}
+	rec := &InputRecording{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, errors.Wrapf(err, "error decoding input recording '%v'", path)
+	}
+	return rec, nil
+}
+
+// Save writes the recording to path as JSON.
+func (r *InputRecording) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error encoding input recording")
+	}
+	return writeFileAtomic(path, data, 0600)
+}
+
+// inputSnapshot is the state of the mouse and keyboard in a frame.
+type inputSnapshot struct {
+	mouse   imgui.Vec2
+	buttons [5]bool
+	wheel   imgui.Vec2
+	keys    map[imgui.Key]bool // keys held, including modifiers
+	chars   []rune             // characters typed
+}
+
+// readInputSnapshot reads the input of the current frame from imgui.
+func readInputSnapshot() inputSnapshot {
+	io := imgui.CurrentIO()
+	snap := inputSnapshot{
+		mouse:   io.MousePos(),
+		buttons: io.MouseDown(),
+		wheel:   imgui.Vec2{X: io.MouseWheelH(), Y: io.MouseWheel()},
+		keys:    make(map[imgui.Key]bool),
+	}
+	for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyReservedForModCtrl; key++ {
+		if imgui.IsKeyDown(key) {
+			snap.keys[key] = true
+		}
+	}
+	for i, down := range []bool{io.KeyCtrl(), io.KeyShift(), io.KeyAlt(), io.KeySuper()} {
+		if down {
+			snap.keys[inputModifiers[i]] = true
+		}
+	}
+	for _, c := range io.InputQueueCharacters().Slice() {
+		snap.chars = append(snap.chars, rune(c))
+	}
+	return snap
+}
+
+// inputEvents returns the events that turn prev into next: the pointer moving, modifiers
+// pressed, buttons and keys changing, the wheel, characters typed, then modifiers
+// released.
+func inputEvents(prev, next inputSnapshot) []InputEvent {
+	var events []InputEvent
+	if next.mouse != prev.mouse {
+		events = append(events, InputEvent{Kind: InputMouseMove, X: next.mouse.X, Y: next.mouse.Y})
+	}
+	for _, mod := range inputModifiers {
+		if next.keys[mod] && !prev.keys[mod] {
+			events = append(events, InputEvent{Kind: InputKey, Key: mod, Down: true})
+		}
+	}
+	for button := range next.buttons {
+		if next.buttons[button] != prev.buttons[button] {
+			events = append(events, InputEvent{Kind: InputMouseButton, Button: button, Down: next.buttons[button]})
+		}
+	}
+	for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyReservedForModCtrl; key++ {
+		if next.keys[key] != prev.keys[key] {
+			events = append(events, InputEvent{Kind: InputKey, Key: key, Down: next.keys[key]})
+		}
+	}
+	if next.wheel != (imgui.Vec2{}) {
+		events = append(events, InputEvent{Kind: InputMouseWheel, X: next.wheel.X, Y: next.wheel.Y})
+	}
+	for _, c := range next.chars {
+		events = append(events, InputEvent{Kind: InputChar, Char: c})
+	}
+	for _, mod := range inputModifiers {
+		if prev.keys[mod] && !next.keys[mod] {
+			events = append(events, InputEvent{Kind: InputKey, Key: mod})
+		}
+	}
+	return events
+}
+
+// InputRecorder captures the app's mouse and keyboard input, frame by frame. start one
+// with App.RecordInput and end it with Stop.
+type InputRecorder struct {
+	recording InputRecording
+	start     time.Time
+	prev      inputSnapshot
+	active    bool
+}
+
+// Recording reports whether the recorder is capturing input.
+func (r *InputRecorder) Recording() bool {
+	return r.active
+}
+
+// Stop ends the recording and returns it.
+func (r *InputRecorder) Stop() *InputRecording {
+	r.active = false
+	rec := r.recording
+	return &rec
+}
+
+// capture records the input of a frame starting at now.
+func (r *InputRecorder) capture(now time.Time, snap inputSnapshot) {
+	if !r.active {
+		return
+	}
+	if r.recording.Frames == 0 {
+		r.start = now
+	}
+	elapsed := now.Sub(r.start)
+	for _, event := range inputEvents(r.prev, snap) {
+		event.Frame, event.Time = r.recording.Frames, elapsed
+		r.recording.Events = append(r.recording.Events, event)
+	}
+	r.prev = snap
+	r.recording.Frames++
+	r.recording.Duration = elapsed
+}
+
+// InputPlayer replays an InputRecording into the app as if the user gave the input.
+// start one with App.ReplayInput. the events of a frame reach components in the
+// following frame, as input from the backend does.
+type InputPlayer struct {
+	Recording *InputRecording
+	ByFrame   bool   // replay events on the frame they were recorded in, rather than at their time, so runs do not depend on the frame rate
+	OnFinish  func() // called when the last event was replayed
+
+	start   time.Time
+	frame   uint64
+	next    int
+	playing bool
+}
+
+// Playing reports whether the player has events left to replay.
+func (p *InputPlayer) Playing() bool {
+	return p.playing
+}
+
+// Stop ends the replay early. OnFinish is not called.
+func (p *InputPlayer) Stop() {
+	p.playing = false
+}
+
+// due returns the events to replay in a frame starting at now, and advances past them.
+func (p *InputPlayer) due(now time.Time) []InputEvent {
+	if !p.playing {
+		return nil
+	}
+	if p.frame == 0 {
+		p.start = now
+	}
+	elapsed := now.Sub(p.start)
+	events := p.Recording.Events
+	first := p.next
+	for p.next < len(events) {
+		event := events[p.next]
+		if (p.ByFrame && event.Frame > p.frame) || (!p.ByFrame && event.Time > elapsed) {
+			break
+		}
+		p.next++
+	}
+	p.frame++
+	if p.next == len(events) {
+		p.playing = false
+		if p.OnFinish != nil {
+			p.OnFinish()
+		}
+	}
+	return events[first:p.next]
+}
+
+// play queues the events due in a frame starting at now with imgui.
+func (p *InputPlayer) play(now time.Time) {
+	io := imgui.CurrentIO()
+	for _, event := range p.due(now) {
+		switch event.Kind {
+		case InputMouseMove:
+			io.AddMousePosEvent(event.X, event.Y)
+		case InputMouseButton:
+			io.AddMouseButtonEvent(int32(event.Button), event.Down)
+		case InputMouseWheel:
+			io.AddMouseWheelEvent(event.X, event.Y)
+		case InputKey:
+			io.AddKeyEvent(event.Key, event.Down)
+		case InputChar:
+			io.AddInputCharacter(uint32(event.Char))
+		}
+	}
+}
+
+// RecordInput starts recording the mouse and keyboard input from the next frame,
+// replacing a recording in progress. stop it with InputRecorder.Stop; with
+// Config.Record, it is saved when the app exits.
+func (app *App) RecordInput() *InputRecorder {
+	width, height := app.GetWindowSize()
+	app.recorder = &InputRecorder{recording: InputRecording{Width: width, Height: height}, active: true}
+	return app.recorder
+}
+
+// ReplayInput plays rec back into the app from the next frame, replacing a replay in
+// progress. real input still arrives while it plays.
+func (app *App) ReplayInput(rec *InputRecording) *InputPlayer {
+	app.player = &InputPlayer{Recording: rec, playing: true}
+	return app.player
+}
+
+// updateInputRecording records and replays the input of the frame.
+func (app *App) updateInputRecording() {
+	if app.player != nil && app.player.Playing() {
+		app.player.play(app.lastFrame)
+		if !app.player.Playing() {
+			app.replayEnd = app.frameCount
+		}
+	}
+	if app.recorder != nil && app.recorder.Recording() {
+		app.recorder.capture(app.lastFrame, readInputSnapshot())
+	}
+}
+
+// replaying reports whether a replay is in progress.
+func (app *App) replaying() bool {
+	return app.player != nil && app.player.Playing()
+}
+
+// loadReplay loads Config.Replay, sizing the window to the recording. call it before the
+// window is created.
+func (app *App) loadReplay() error {
+	if app.config.Replay == "" {
+		return nil
+	}
+	rec, err := LoadInputRecording(app.config.Replay)
+	if err != nil {
+		return err
+	}
+	if rec.Width > 0 && rec.Height > 0 {
+		app.config.Width, app.config.Height = rec.Width, rec.Height
+	}
+	app.ReplayInput(rec)
+	return nil
+}
+
+// saveRecording saves the recording in progress to Config.Record.
+func (app *App) saveRecording() error {
+	if app.config.Record == "" || app.recorder == nil {
+		return nil
+	}
+	return app.recorder.Stop().Save(app.config.Record)
+}
@@ -0,0 +1,167 @@
+package dfx
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestInputEvents(t *testing.T) {
+	prev := inputSnapshot{mouse: imgui.Vec2{X: 10, Y: 10}, keys: map[imgui.Key]bool{imgui.KeyA: true}}
+	next := inputSnapshot{
+		mouse:   imgui.Vec2{X: 12, Y: 10},
+		buttons: [5]bool{true},
+		wheel:   imgui.Vec2{Y: -1},
+		keys:    map[imgui.Key]bool{imgui.ModCtrl: true, imgui.KeyS: true},
+		chars:   []rune{'s'},
+	}
+	if got := inputEvents(prev, next); !slices.Equal(got, []InputEvent{
+		{Kind: InputMouseMove, X: 12, Y: 10},
+		{Kind: InputKey, Key: imgui.ModCtrl, Down: true}, // modifiers first, so shortcuts see them
+		{Kind: InputMouseButton, Button: 0, Down: true},
+		{Kind: InputKey, Key: imgui.KeyA},
+		{Kind: InputKey, Key: imgui.KeyS, Down: true},
+		{Kind: InputMouseWheel, Y: -1},
+		{Kind: InputChar, Char: 's'},
+	}) {
+		t.Fatalf("expected '%v', got '%v'", []InputEvent{
+			{Kind: InputMouseMove, X: 12, Y: 10},
+			{Kind: InputKey, Key: imgui.ModCtrl, Down: true}, // modifiers first, so shortcuts see them
+			{Kind: InputMouseButton, Button: 0, Down: true},
+			{Kind: InputKey, Key: imgui.KeyA},
+			{Kind: InputKey, Key: imgui.KeyS, Down: true},
+			{Kind: InputMouseWheel, Y: -1},
+			{Kind: InputChar, Char: 's'},
+		}, got)
+	}
+
+	released := inputEvents(next, inputSnapshot{mouse: next.mouse})
+	if !slices.Equal(released, []InputEvent{
+		{Kind: InputMouseButton, Button: 0},
+		{Kind: InputKey, Key: imgui.KeyS},
+		{Kind: InputKey, Key: imgui.ModCtrl}, // modifiers last
+	}) {
+		t.Fatalf("expected '%v', got '%v'", []InputEvent{
+			{Kind: InputMouseButton, Button: 0},
+			{Kind: InputKey, Key: imgui.KeyS},
+			{Kind: InputKey, Key: imgui.ModCtrl}, // modifiers last
+		}, released)
+	}
+
+	if got := inputEvents(prev, prev); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
+
+func TestInputRecorder_Capture(t *testing.T) {
+	r := &InputRecorder{active: true}
+	start := time.Now()
+	r.capture(start, inputSnapshot{mouse: imgui.Vec2{X: 1}})
+	r.capture(start.Add(16*time.Millisecond), inputSnapshot{mouse: imgui.Vec2{X: 1}})
+	r.capture(start.Add(33*time.Millisecond), inputSnapshot{mouse: imgui.Vec2{X: 2}, buttons: [5]bool{false, true}})
+
+	rec := r.Stop()
+	if r.Recording() {
+		t.Fatal("unexpected r.Recording()")
+	}
+	if rec.Frames != uint64(3) {
+		t.Fatalf("expected '%v', got '%v'", uint64(3), rec.Frames)
+	}
+	if rec.Duration != 33*time.Millisecond {
+		t.Fatalf("expected '%v', got '%v'", 33*time.Millisecond, rec.Duration)
+	}
+	if !slices.Equal(rec.Events, []InputEvent{
+		{Frame: 0, Kind: InputMouseMove, X: 1},
+		{Frame: 2, Time: 33 * time.Millisecond, Kind: InputMouseMove, X: 2},
+		{Frame: 2, Time: 33 * time.Millisecond, Kind: InputMouseButton, Button: 1, Down: true},
+	}) {
+		t.Fatalf("expected '%v', got '%v'", []InputEvent{
+			{Frame: 0, Kind: InputMouseMove, X: 1},
+			{Frame: 2, Time: 33 * time.Millisecond, Kind: InputMouseMove, X: 2},
+			{Frame: 2, Time: 33 * time.Millisecond, Kind: InputMouseButton, Button: 1, Down: true},
+		}, rec.Events)
+	}
+
+	r.capture(start.Add(time.Second), inputSnapshot{})
+	if got := r.Stop().Frames; got != uint64(3) {
+		t.Fatalf("expected '%v', got '%v'", uint64(3), got)
+	} // stopped recorders ignore frames
+}
+
+func testInputRecording() *InputRecording {
+	return &InputRecording{
+		Width: 640, Height: 480, Frames: 10, Duration: 100 * time.Millisecond,
+		Events: []InputEvent{
+			{Frame: 0, Kind: InputMouseMove, X: 5, Y: 5},
+			{Frame: 2, Time: 20 * time.Millisecond, Kind: InputMouseButton, Down: true},
+			{Frame: 6, Time: 60 * time.Millisecond, Kind: InputMouseButton},
+		},
+	}
+}
+
+func TestInputPlayer_ByTime(t *testing.T) {
+	finished := 0
+	p := &InputPlayer{Recording: testInputRecording(), playing: true, OnFinish: func() { finished++ }}
+	start := time.Now()
+	if len(p.due(start)) != 1 {
+		t.Fatalf("expected 1, got %d", len(p.due(start)))
+	}
+	if got := p.due(start.Add(10 * time.Millisecond)); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+	if len(p.due(start.Add(30*time.Millisecond))) != 1 {
+		t.Fatalf("expected 1, got %d", len(p.due(start.Add(30*time.Millisecond))))
+	}
+	if !p.Playing() {
+		t.Fatal("expected p.Playing()")
+	}
+	if len(p.due(start.Add(70*time.Millisecond))) != 1 {
+		t.Fatalf("expected 1, got %d", len(p.due(start.Add(70*time.Millisecond))))
+	}
+	if p.Playing() {
+		t.Fatal("unexpected p.Playing()")
+	}
+	if finished != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, finished)
+	}
+	if got := p.due(start.Add(time.Second)); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
+
+func TestInputPlayer_ByFrame(t *testing.T) {
+	p := &InputPlayer{Recording: testInputRecording(), ByFrame: true, playing: true}
+	now := time.Now() // the clock does not matter
+	counts := []int{}
+	for p.Playing() {
+		counts = append(counts, len(p.due(now)))
+	}
+	if !slices.Equal(counts, []int{1, 0, 1, 0, 0, 0, 1}) {
+		t.Fatalf("expected '%v', got '%v'", []int{1, 0, 1, 0, 0, 0, 1}, counts)
+	}
+}
+
+func TestInputRecording_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	rec := testInputRecording()
+	rec.Events = append(rec.Events, InputEvent{Frame: 7, Kind: InputKey, Key: imgui.ModShift, Down: true}, InputEvent{Frame: 7, Kind: InputChar, Char: 'é'})
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadInputRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, rec) {
+		t.Fatalf("expected '%v', got '%v'", rec, loaded)
+	}
+
+	_, err = LoadInputRecording(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
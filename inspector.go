@@ -0,0 +1,254 @@
+package dfx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Inspector constants
+const (
+	InspectorTreeFraction = 0.6 // share of the inspector height given to the component tree
+)
+
+// drawRecord is the State a container was last drawn with, and the screen bounds it
+// describes.
+type drawRecord struct {
+	state    State
+	min, max imgui.Vec2
+}
+
+// drawRecorder collects draw records while an Inspector is shown (nil = not recording).
+var drawRecorder map[*Container]drawRecord
+
+// recordDraw records the State a container is drawn with, for the Inspector.
+func recordDraw(c *Container, state *State) {
+	if drawRecorder == nil || state == nil {
+		return
+	}
+	origin := imgui.WindowPos().Add(state.Position)
+	drawRecorder[c] = drawRecord{state: *state, min: origin, max: origin.Add(state.Size)}
+}
+
+// Inspector shows the live component hierarchy, like browser dev tools for dfx: each
+// component's type, visibility (which can be toggled), the State it was drawn with, its
+// actions and captured state. hovering a component outlines its bounds on screen, and
+// the selected component stays outlined.
+//
+// the hierarchy is the one used for action traversal (ChildActionProvider). sizes and
+// bounds are known for components that embed Container, from the State they were last
+// drawn with; one Inspector should be shown at a time.
+type Inspector struct {
+	Container
+	Target Component // root of the inspected hierarchy (nil = the app root)
+
+	records  map[*Container]drawRecord
+	selected Component
+	hovered  Component
+}
+
+// NewInspector creates an inspector of the app root.
+func NewInspector() *Inspector {
+	return &Inspector{Container: Container{Visible: true}}
+}
+
+// Selected returns the selected component, or nil.
+func (in *Inspector) Selected() Component {
+	return in.selected
+}
+
+// Select selects a component.
+func (in *Inspector) Select(comp Component) {
+	in.selected = comp
+}
+
+// Draw implements Component.
+func (in *Inspector) Draw(state *State) {
+	if !in.Visible {
+		drawRecorder = nil
+		return
+	}
+	// the records collected since the last draw cover one whole frame
+	if drawRecorder != nil {
+		in.records = drawRecorder
+	}
+	drawRecorder = make(map[*Container]drawRecord, len(in.records))
+
+	imgui.PushIDStr(componentID("inspector", in))
+	defer imgui.PopID()
+
+	target := in.Target
+	if target == nil && state != nil && state.App != nil {
+		target = state.App.root
+	}
+	if target == nil {
+		imgui.TextDisabled(T("dfx.inspector.empty"))
+		drawContainerExtensions(&in.Container, state)
+		return
+	}
+
+	avail := imgui.ContentRegionAvail()
+	treeHeight := avail.Y
+	if in.selected != nil {
+		treeHeight = max(avail.Y*InspectorTreeFraction, imgui.FrameHeightWithSpacing())
+	}
+	in.hovered = nil
+	imgui.BeginChildStrV("##inspectorTree", imgui.Vec2{Y: treeHeight}, imgui.ChildFlagsNone, imgui.WindowFlagsHorizontalScrollbar)
+	in.drawNode(target, 0, 0)
+	imgui.EndChild()
+
+	if in.selected != nil {
+		imgui.Separator()
+		imgui.BeginChildStrV("##inspectorDetails", imgui.Vec2{}, imgui.ChildFlagsNone, imgui.WindowFlagsNone)
+		in.drawDetails(in.selected)
+		imgui.EndChild()
+	}
+
+	in.highlight()
+	drawContainerExtensions(&in.Container, state)
+}
+
+// drawNode draws comp and its children as tree nodes.
+func (in *Inspector) drawNode(comp Component, index, depth int) {
+	if comp == nil || depth > debugTreeMaxDepth {
+		return
+	}
+	imgui.PushIDInt(int32(index))
+	defer imgui.PopID()
+
+	if visible, ok := visibleField(comp); ok {
+		v := visible.Bool()
+		if imgui.Checkbox("##visible", &v) {
+			visible.SetBool(v)
+		}
+		imgui.SetItemTooltip(T("dfx.inspector.visible"))
+	} else {
+		imgui.Dummy(imgui.Vec2{X: imgui.FrameHeight(), Y: imgui.FrameHeight()})
+	}
+	imgui.SameLine()
+
+	var children []Component
+	if cp, ok := comp.(ChildActionProvider); ok {
+		children = cp.ChildActions()
+	}
+	flags := imgui.TreeNodeFlagsOpenOnArrow | imgui.TreeNodeFlagsOpenOnDoubleClick | imgui.TreeNodeFlagsSpanAvailWidth
+	if len(children) == 0 {
+		flags |= imgui.TreeNodeFlagsLeaf
+	}
+	if depth < 2 {
+		flags |= imgui.TreeNodeFlagsDefaultOpen
+	}
+	if comp == in.selected {
+		flags |= imgui.TreeNodeFlagsSelected
+	}
+	label := fmt.Sprintf("%T", comp)
+	if record, found := in.record(comp); found {
+		label += fmt.Sprintf("  %.0fx%.0f", record.state.Size.X, record.state.Size.Y)
+	}
+	open := imgui.TreeNodeExStrV(label+"##node", flags)
+	if imgui.IsItemClicked() && !imgui.IsItemToggledOpen() {
+		in.selected = comp
+	}
+	if imgui.IsItemHovered() {
+		in.hovered = comp
+	}
+	if open {
+		for i, child := range children {
+			in.drawNode(child, i, depth+1)
+		}
+		imgui.TreePop()
+	}
+}
+
+// drawDetails describes the selected component.
+func (in *Inspector) drawDetails(comp Component) {
+	imgui.TextUnformatted(fmt.Sprintf("%T", comp))
+	if visible, ok := visibleField(comp); ok {
+		imgui.TextUnformatted(fmt.Sprintf("visible: %v", visible.Bool()))
+	}
+	if record, found := in.record(comp); found {
+		s := record.state
+		imgui.TextUnformatted(fmt.Sprintf("size: %.1f x %.1f", s.Size.X, s.Size.Y))
+		imgui.TextUnformatted(fmt.Sprintf("position: %.1f, %.1f", s.Position.X, s.Position.Y))
+		if s.Parent != nil {
+			imgui.TextUnformatted(fmt.Sprintf("parent: %T", s.Parent))
+		}
+		imgui.TextUnformatted(fmt.Sprintf("bounds: (%.0f, %.0f) - (%.0f, %.0f)", record.min.X, record.min.Y, record.max.X, record.max.Y))
+	} else {
+		imgui.TextDisabled(T("dfx.inspector.noState"))
+	}
+
+	if actions := componentActions(comp); actions != nil && len(actions.actions) > 0 {
+		imgui.SeparatorText(T("dfx.inspector.actions"))
+		for _, action := range actions.actions {
+			imgui.TextUnformatted(fmt.Sprintf("%v  %v", action.Keys, action.Id))
+		}
+	}
+	if sc, ok := comp.(StatefulComponent); ok {
+		if captured := sc.CaptureState(); len(captured) > 0 {
+			imgui.SeparatorText(T("dfx.inspector.state"))
+			keys := make([]string, 0, len(captured))
+			for key := range captured {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				imgui.TextWrapped(fmt.Sprintf("%v: %v", key, captured[key]))
+			}
+		}
+	}
+}
+
+// highlight outlines the hovered and selected components on top of everything.
+func (in *Inspector) highlight() {
+	drawList := imgui.ForegroundDrawListViewportPtr()
+	color := imgui.ColorU32Col(imgui.ColDragDropTarget)
+	if record, found := in.record(in.selected); found {
+		drawList.AddRectV(record.min, record.max, color, 0, imgui.DrawFlagsNone, 2)
+	}
+	if record, found := in.record(in.hovered); found {
+		drawList.AddRectFilled(record.min, record.max, imgui.ColorU32ColV(imgui.ColDragDropTarget, 0.2))
+		drawList.AddRectV(record.min, record.max, color, 0, imgui.DrawFlagsNone, 1)
+	}
+}
+
+// record returns the draw record of a component that embeds Container.
+func (in *Inspector) record(comp Component) (drawRecord, bool) {
+	c := containerOf(comp)
+	if c == nil {
+		return drawRecord{}, false
+	}
+	record, found := in.records[c]
+	return record, found
+}
+
+// containerOf returns the Container a component is or embeds, or nil.
+func containerOf(comp Component) *Container {
+	if c, ok := comp.(*Container); ok {
+		return c
+	}
+	v := reflect.ValueOf(comp)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := v.Elem().FieldByName("Container")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(Container{}) {
+		return nil
+	}
+	return field.Addr().Interface().(*Container)
+}
+
+// visibleField returns the settable Visible field of a component struct, if it has one.
+func visibleField(comp Component) (reflect.Value, bool) {
+	v := reflect.ValueOf(comp)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	field := v.Elem().FieldByName("Visible")
+	if !field.IsValid() || field.Kind() != reflect.Bool || !field.CanSet() {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
@@ -0,0 +1,54 @@
+package dfx
+
+import "testing"
+
+func TestContainerOf(t *testing.T) {
+	c := &Container{Visible: true}
+	if containerOf(c) != c {
+		t.Fatal("expected a container to be its own container")
+	}
+	lv := NewListView(StringList{"a"})
+	if containerOf(lv) != &lv.Container {
+		t.Fatal("expected the embedded container of a list view")
+	}
+	if containerOf(NewSizeDebugger()) != nil {
+		t.Fatal("expected no container for a size debugger")
+	}
+	if containerOf(NewFunc(nil)) != nil {
+		t.Fatal("expected no container for a func")
+	}
+}
+
+func TestVisibleField(t *testing.T) {
+	lv := NewListView(StringList{"a"})
+	field, ok := visibleField(lv)
+	if !ok || !field.Bool() {
+		t.Fatal("expected a visible field set to true")
+	}
+	field.SetBool(false)
+	if lv.Visible {
+		t.Fatal("expected setting the field to hide the list view")
+	}
+	if _, ok := visibleField(NewSizeDebugger()); ok {
+		t.Fatal("expected no visible field for a size debugger")
+	}
+	if v := debugVisible(lv); v == nil || *v {
+		t.Fatalf("expected debugVisible false, got %v", v)
+	}
+}
+
+func TestInspectorSelect(t *testing.T) {
+	in := NewInspector()
+	lv := NewListView(StringList{"a"})
+	in.Select(lv)
+	if in.Selected() != lv {
+		t.Fatal("expected the list view to be selected")
+	}
+	in.records = map[*Container]drawRecord{&lv.Container: {state: State{Parent: in}}}
+	if record, found := in.record(lv); !found || record.state.Parent != in {
+		t.Fatal("expected the list view's draw record")
+	}
+	if _, found := in.record(NewSizeDebugger()); found {
+		t.Fatal("expected no draw record for a size debugger")
+	}
+}
@@ -0,0 +1,160 @@
+package dfx
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// kinetic constants
+const (
+	kineticSmoothing = 0.5 // weight of the latest frame in the tracked velocity
+)
+
+// KineticParams configures kinetic scrolling: scrolled content keeps moving after a flick
+// and slows to a stop, as on touch screens.
+type KineticParams struct {
+	Enabled     bool
+	Friction    float32 // how quickly momentum fades, per second (higher stops sooner)
+	MinVelocity float32 // speed in pixels per second below which scrolling stops
+	Drag        bool    // dragging the background of the content scrolls it, as a finger does
+}
+
+// DefaultKineticParams returns kinetic scrolling turned on, with momentum fading over
+// about half a second.
+func DefaultKineticParams() KineticParams {
+	return KineticParams{Enabled: true, Friction: 5, MinVelocity: 30, Drag: true}
+}
+
+// kineticParams configures the scrollers without params of their own. UI thread only.
+var kineticParams KineticParams
+
+// SetKineticScrolling configures kinetic scrolling for ScrollArea, LogViewer, FileTree and
+// KineticScrollers without params of their own. it is off until set; Config.KineticScrolling
+// sets it when the app starts.
+func SetKineticScrolling(p KineticParams) {
+	kineticParams = p
+}
+
+// KineticScrolling returns the kinetic scrolling configuration.
+func KineticScrolling() KineticParams {
+	return kineticParams
+}
+
+// KineticScroller adds momentum to the scrolling of a child window. call Update between
+// BeginChild and EndChild, after drawing the content:
+//
+//	imgui.BeginChildStr("##list")
+//	... draw items ...
+//	list.kinetic.Update()
+//	imgui.EndChild()
+//
+// trackpad scrolling and drags of the window background (with Drag) are followed, and keep
+// going when they end, slowing down with Friction. mouse wheels that scroll in whole
+// notches scroll in steps as before. a click stops the motion.
+type KineticScroller struct {
+	Params *KineticParams // overrides the global configuration (nil = KineticScrolling())
+
+	velocity imgui.Vec2 // pixels per second
+	last     imgui.Vec2 // scroll position set or seen in the last update
+	dragging bool
+}
+
+// Moving reports whether the content is coasting or being dragged.
+func (k *KineticScroller) Moving() bool {
+	return k.dragging || k.velocity != (imgui.Vec2{})
+}
+
+// Stop stops the motion.
+func (k *KineticScroller) Stop() {
+	k.velocity = imgui.Vec2{}
+	k.dragging = false
+}
+
+// Update follows and continues the scrolling of the current window.
+func (k *KineticScroller) Update() {
+	p := kineticParams
+	if k.Params != nil {
+		p = *k.Params
+	}
+	io := imgui.CurrentIO()
+	dt := io.DeltaTime()
+	scroll := imgui.Vec2{X: imgui.ScrollX(), Y: imgui.ScrollY()}
+	if !p.Enabled || dt <= 0 {
+		k.Stop()
+		k.last = scroll
+		return
+	}
+	hovered := imgui.IsWindowHovered()
+
+	if p.Drag && hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && !imgui.IsAnyItemHovered() {
+		k.Stop()
+		k.dragging = true
+	}
+	if k.dragging {
+		if imgui.IsMouseDown(imgui.MouseButtonLeft) {
+			delta := io.MouseDelta()
+			k.track(imgui.Vec2{X: -delta.X, Y: -delta.Y}, dt)
+			k.scrollTo(scroll.Sub(delta))
+			return
+		}
+		k.dragging = false
+	}
+
+	switch {
+	case hovered && trackpadScroll(io.MouseWheel(), io.MouseWheelH()):
+		// imgui has scrolled the window; follow its speed
+		k.track(scroll.Sub(k.last), dt)
+		k.last = scroll
+	case hovered && (io.MouseWheel() != 0 || io.MouseWheelH() != 0 || imgui.IsMouseClickedBool(imgui.MouseButtonLeft)):
+		// a notched wheel or a click stops the motion
+		k.Stop()
+		k.last = scroll
+	case k.velocity != (imgui.Vec2{}):
+		var step imgui.Vec2
+		step, k.velocity = kineticCoast(k.velocity, dt, p)
+		target := scroll.Add(step)
+		// stop at the ends of the content
+		if target.X <= 0 || target.X >= imgui.ScrollMaxX() {
+			k.velocity.X = 0
+		}
+		if target.Y <= 0 || target.Y >= imgui.ScrollMaxY() {
+			k.velocity.Y = 0
+		}
+		k.scrollTo(target)
+	default:
+		k.last = scroll
+	}
+}
+
+// track mixes the speed of a frame that moved by delta into the velocity.
+func (k *KineticScroller) track(delta imgui.Vec2, dt float32) {
+	k.velocity = k.velocity.Mul(1 - kineticSmoothing).Add(delta.Mul(kineticSmoothing / dt))
+}
+
+// scrollTo scrolls the current window to target, clamped to the content.
+func (k *KineticScroller) scrollTo(target imgui.Vec2) {
+	target.X = clamp(target.X, 0, imgui.ScrollMaxX())
+	target.Y = clamp(target.Y, 0, imgui.ScrollMaxY())
+	imgui.SetScrollXFloat(target.X)
+	imgui.SetScrollYFloat(target.Y)
+	k.last = target
+}
+
+// kineticCoast returns how far content moving at velocity travels in dt seconds, and its
+// velocity afterwards, slowed by friction and stopped below the minimum speed.
+func kineticCoast(velocity imgui.Vec2, dt float32, p KineticParams) (imgui.Vec2, imgui.Vec2) {
+	step := velocity.Mul(dt)
+	velocity = velocity.Mul(float32(math.Exp(float64(-p.Friction * dt))))
+	if math.Hypot(float64(velocity.X), float64(velocity.Y)) < float64(p.MinVelocity) {
+		velocity = imgui.Vec2{}
+	}
+	return step, velocity
+}
+
+// trackpadScroll reports whether wheel input came from a trackpad or another smooth
+// scrolling device, which report fractions of a notch.
+func trackpadScroll(wheel, wheelH float32) bool {
+	fractional := func(v float32) bool { return v != 0 && v != float32(math.Trunc(float64(v))) }
+	return fractional(wheel) || fractional(wheelH)
+}
@@ -0,0 +1,77 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestKineticCoast_SlowsToAStop(t *testing.T) {
+	p := DefaultKineticParams()
+	velocity := imgui.Vec2{Y: 1200}
+	travelled := float32(0)
+	frames := 0
+	for velocity != (imgui.Vec2{}) && frames < 1000 {
+		var step imgui.Vec2
+		step, velocity = kineticCoast(velocity, 1.0/60, p)
+		travelled += step.Y
+		frames++
+	}
+	if frames >= 60 {
+		t.Fatalf("stops within a second: expected '%v' < '%v'", frames, 60)
+	}
+	// friction 5 travels about velocity/friction
+	if math.Abs(float64(travelled)-float64(1200.0/5)) > 30 {
+		t.Fatalf("expected '%v', got '%v'", 1200.0/5, travelled)
+	}
+
+	p.Friction = 1
+	step, slower := kineticCoast(imgui.Vec2{X: -600}, 1.0/60, p)
+	if math.Abs(float64(step.X)-float64(-10)) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", -10, step.X)
+	}
+	if slower.X <= float32(-600) {
+		t.Fatalf("expected '%v' > '%v'", slower.X, float32(-600))
+	}
+}
+
+func TestTrackpadScroll(t *testing.T) {
+	if !trackpadScroll(0.25, 0) {
+		t.Fatal("expected trackpadScroll(0.25, 0)")
+	}
+	if !trackpadScroll(0, -1.5) {
+		t.Fatal("expected trackpadScroll(0, -1.5)")
+	}
+	if trackpadScroll(1, 0) {
+		t.Fatal("a mouse wheel notch: unexpected trackpadScroll(1, 0)")
+	}
+	if trackpadScroll(0, 0) {
+		t.Fatal("unexpected trackpadScroll(0, 0)")
+	}
+}
+
+func TestKineticScrolling_Global(t *testing.T) {
+	saved := KineticScrolling()
+	t.Cleanup(func() { SetKineticScrolling(saved) })
+	if KineticScrolling().Enabled {
+		t.Fatal("off by default: unexpected KineticScrolling().Enabled")
+	}
+	SetKineticScrolling(DefaultKineticParams())
+	if !(KineticScrolling().Enabled) {
+		t.Fatal("expected KineticScrolling().Enabled")
+	}
+
+	var k KineticScroller
+	if k.Moving() {
+		t.Fatal("unexpected k.Moving()")
+	}
+	k.velocity = imgui.Vec2{Y: 100}
+	if !k.Moving() {
+		t.Fatal("expected k.Moving()")
+	}
+	k.Stop()
+	if k.Moving() {
+		t.Fatal("unexpected k.Moving()")
+	}
+}
@@ -0,0 +1,152 @@
+package dfx
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Knob constants
+const (
+	DefaultKnobDragSteps = 200.0 // pixels of vertical drag across a knob's range
+	knobSweep            = 1.5 * math.Pi
+	knobStart            = 0.75 * math.Pi // angle of the minimum, measured clockwise from +x
+)
+
+// KnobParams configures a Knob.
+type KnobParams struct {
+	Size       float32                    // diameter (default: 2 frame heights)
+	ResetValue float32                    // right-click reset target, in the knob's range
+	Bipolar    bool                       // the arc starts at the middle of the range (pan, balance)
+	DragSteps  float32                    // pixels of vertical drag across the range (default 200; Shift = 10x finer)
+	WheelSteps float32                    // wheel notches across the range (default 100)
+	KeySteps   float32                    // arrow key steps across the range while focused (default 50)
+	Format     func(value float32) string // tooltip text (default: 2 decimals in CurrentFormat())
+
+	// Accessibility: announced with the value when the knob gains keyboard focus
+	AccessibleLabel       string // default = the visible part of the label
+	AccessibleDescription string // also shown in the tooltip
+}
+
+// DefaultKnobParams returns sensible default parameters.
+func DefaultKnobParams() KnobParams {
+	return KnobParams{
+		DragSteps:  DefaultKnobDragSteps,
+		WheelSteps: 100.0,
+		KeySteps:   DefaultKeySteps,
+	}
+}
+
+// Knob draws a rotary control for value in min..max: drag up or down to turn it (Shift
+// for fine adjustment), or use the wheel or the arrow keys; right-click resets it.
+// returns (newValue, changed) following dfx conventions.
+func Knob(label string, value, min, max float32, params KnobParams) (float32, bool) {
+	if max <= min {
+		return value, false
+	}
+	size := params.Size
+	if size <= 0 {
+		size = imgui.FrameHeight() * 2
+	}
+	value = clamp(value, min, max)
+	position := (value - min) / (max - min)
+
+	imgui.InvisibleButton(label, imgui.Vec2{X: size, Y: size})
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+	io := imgui.CurrentIO()
+	newPosition := position
+
+	if active && imgui.IsMouseDraggingV(imgui.MouseButtonLeft, 0) {
+		newPosition = knobDrag(newPosition, io.MouseDelta().Y, params.DragSteps, io.KeyShift())
+	}
+	if hovered {
+		if wheel := io.MouseWheel(); wheel != 0 {
+			steps := params.WheelSteps
+			if steps <= 0 {
+				steps = 100.0
+			}
+			newPosition = clamp(newPosition+wheel/steps, 0, 1)
+		}
+		if imgui.IsMouseClickedBool(imgui.MouseButtonRight) {
+			newPosition = (clamp(params.ResetValue, min, max) - min) / (max - min)
+		}
+	}
+	if imgui.IsItemFocused() || active {
+		if key := pressedStepKey(); key != stepNone {
+			newPosition = applyStepKey(newPosition, params.KeySteps, key, io.KeyShift())
+		}
+	}
+
+	newValue := min + newPosition*(max-min)
+	changed := newValue != value
+	valueText := knobFormat(newValue, params)
+	drawKnob(imgui.ItemRectMin(), size, newPosition, params.Bipolar, hovered || active)
+
+	if hovered || active {
+		imgui.SetTooltip(accessibleText(valueText, params.AccessibleDescription))
+	}
+	accessibleLabel := params.AccessibleLabel
+	if accessibleLabel == "" {
+		accessibleLabel = visibleLabel(label)
+	}
+	AccessibleItem(accessibleLabel, params.AccessibleDescription, valueText)
+	return newValue, changed
+}
+
+// knobFormat formats a value with Format, or 2 decimals in CurrentFormat().
+func knobFormat(value float32, params KnobParams) string {
+	if params.Format != nil {
+		return params.Format(value)
+	}
+	return floatText(value, 2)
+}
+
+// knobDrag moves a 0..1 position by a vertical drag of dy pixels (up turns it up), where
+// steps pixels cross the whole range, or ten times that when fine.
+func knobDrag(position, dy, steps float32, fine bool) float32 {
+	if steps <= 0 {
+		steps = DefaultKnobDragSteps
+	}
+	if fine {
+		steps *= keyStepsFine
+	}
+	return clamp(position-dy/steps, 0, 1)
+}
+
+// knobAngle returns the angle of a 0..1 position on the knob's sweep, clockwise from +x
+// as imgui draws arcs.
+func knobAngle(position float32) float32 {
+	return knobStart + position*knobSweep
+}
+
+// drawKnob draws a knob of diameter size at pos: the sweep as a track, the arc from the
+// minimum (or the middle when bipolar) to position, and a pointer.
+func drawKnob(pos imgui.Vec2, size, position float32, bipolar, hot bool) {
+	colors := imgui.CurrentStyle().Colors()
+	dl := imgui.WindowDrawList()
+	center := imgui.Vec2{X: pos.X + size/2, Y: pos.Y + size/2}
+	radius := size/2 - 2
+	thickness := max(size/10, 2)
+
+	body := colors[imgui.ColFrameBg]
+	if hot {
+		body = colors[imgui.ColFrameBgHovered]
+	}
+	dl.AddCircleFilled(center, radius-thickness, imgui.ColorConvertFloat4ToU32(body))
+
+	dl.PathArcTo(center, radius, knobStart, knobStart+knobSweep)
+	dl.PathStrokeV(imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]), imgui.DrawFlagsNone, thickness)
+
+	from := float32(0)
+	if bipolar {
+		from = 0.5
+	}
+	if a, b := knobAngle(min(from, position)), knobAngle(max(from, position)); b > a {
+		dl.PathArcTo(center, radius, a, b)
+		dl.PathStrokeV(imgui.ColorConvertFloat4ToU32(ThemeColors().Accent), imgui.DrawFlagsNone, thickness)
+	}
+
+	angle := float64(knobAngle(position))
+	tip := imgui.Vec2{X: center.X + float32(math.Cos(angle))*(radius-thickness), Y: center.Y + float32(math.Sin(angle))*(radius-thickness)}
+	dl.AddLineV(center, tip, imgui.ColorConvertFloat4ToU32(colors[imgui.ColText]), 2)
+}
@@ -0,0 +1,27 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKnobDrag(t *testing.T) {
+	if got := knobDrag(0.5, -20, 200, false); got != 0.6 {
+		t.Errorf("expected dragging up 20 of 200 pixels to turn up a tenth, got %v", got)
+	}
+	if got := knobDrag(0.5, 20, 200, true); got != 0.49 {
+		t.Errorf("expected a fine drag to move a tenth as far, got %v", got)
+	}
+	if got := knobDrag(0.9, -100, 0, false); got != 1 {
+		t.Errorf("expected the drag clamped to the range, got %v", got)
+	}
+}
+
+func TestKnobAngle(t *testing.T) {
+	if got := knobAngle(0.5); math.Abs(float64(got)-1.5*math.Pi) > 1e-6 {
+		t.Errorf("expected the middle of the sweep straight up, got %v", got)
+	}
+	if got := knobAngle(1) - knobAngle(0); math.Abs(float64(got)-1.5*math.Pi) > 1e-6 {
+		t.Errorf("expected a 270 degree sweep, got %v", got)
+	}
+}
@@ -0,0 +1,344 @@
+package dfx
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Alignment positions a child within the space given to it.
+type Alignment int
+
+const (
+	AlignStretch Alignment = iota // fill the space
+	AlignStart                    // left or top
+	AlignCenter                   // centered
+	AlignEnd                      // right or bottom
+)
+
+// layout constants
+const (
+	StackDefaultSpacing = 4 // gap between stack items and grid cells
+)
+
+// StackItem is a child of a VStack or HStack. along the stack axis an item takes Size
+// pixels, or when Size is 0 a Weight share of the space the fixed items leave. across
+// the stack it fills the stack unless CrossSize is set, in which case Align places it.
+type StackItem struct {
+	Component Component // nil leaves the space empty
+	Size      float32   // fixed size along the stack axis (0 = flexible)
+	Weight    float32   // share of the flexible space (0 = 1)
+	CrossSize float32   // size across the stack (0 = the full stack)
+	Align     Alignment // placement across the stack when CrossSize is set (AlignStretch = the stack's Align)
+}
+
+// Fixed returns a stack item of a fixed size along the stack axis.
+func Fixed(comp Component, size float32) StackItem {
+	return StackItem{Component: comp, Size: size}
+}
+
+// Flex returns a stack item sharing the flexible space by weight.
+func Flex(comp Component, weight float32) StackItem {
+	return StackItem{Component: comp, Weight: weight}
+}
+
+// stack lays out items along one axis; VStack and HStack embed it.
+type stack struct {
+	Container
+	Items   []StackItem
+	Spacing float32   // gap between items
+	Align   Alignment // default placement across the stack for items with CrossSize
+}
+
+// VStack arranges its items top to bottom within the space its State gives it.
+type VStack struct {
+	stack
+}
+
+// NewVStack creates a vertical stack of items.
+func NewVStack(items ...StackItem) *VStack {
+	return &VStack{stack{Container: Container{Visible: true}, Items: items, Spacing: StackDefaultSpacing}}
+}
+
+// Draw implements Component.
+func (s *VStack) Draw(state *State) {
+	s.draw(s, state, false)
+}
+
+// HStack arranges its items left to right within the space its State gives it.
+type HStack struct {
+	stack
+}
+
+// NewHStack creates a horizontal stack of items.
+func NewHStack(items ...StackItem) *HStack {
+	return &HStack{stack{Container: Container{Visible: true}, Items: items, Spacing: StackDefaultSpacing}}
+}
+
+// Draw implements Component.
+func (s *HStack) Draw(state *State) {
+	s.draw(s, state, true)
+}
+
+// ChildActions returns the item components for action traversal.
+func (s *stack) ChildActions() []Component {
+	var children []Component
+	for _, item := range s.Items {
+		if item.Component != nil {
+			children = append(children, item.Component)
+		}
+	}
+	return append(children, s.Children...)
+}
+
+func (s *stack) draw(owner Component, state *State, horizontal bool) {
+	if !s.Visible {
+		return
+	}
+	size := layoutSize(state)
+	main, cross := size.Y, size.X
+	if horizontal {
+		main, cross = size.X, size.Y
+	}
+	lengths := stackLengths(main, s.Spacing, s.Items)
+
+	imgui.PushIDStr(componentID("stack", s))
+	origin := imgui.CursorPos()
+	offset := float32(0)
+	for i, item := range s.Items {
+		crossSize, crossOffset := alignSpan(cross, item.CrossSize, item.Align, s.Align)
+		pos := imgui.Vec2{X: crossOffset, Y: offset}
+		itemSize := imgui.Vec2{X: crossSize, Y: lengths[i]}
+		if horizontal {
+			pos = imgui.Vec2{X: offset, Y: crossOffset}
+			itemSize = imgui.Vec2{X: lengths[i], Y: crossSize}
+		}
+		drawLayoutCell(fmt.Sprintf("##item%d", i), item.Component, origin.Add(pos), itemSize, owner, state)
+		offset += lengths[i] + s.Spacing
+	}
+	endLayout(origin, size)
+	imgui.PopID()
+
+	drawContainerExtensions(&s.Container, state)
+}
+
+// stackLengths divides total along the stack axis: fixed items get their size, flexible
+// items share what is left by weight, never below zero.
+func stackLengths(total, spacing float32, items []StackItem) []float32 {
+	lengths := make([]float32, len(items))
+	if len(items) == 0 {
+		return lengths
+	}
+	remaining := total - spacing*float32(len(items)-1)
+	var weights float32
+	for i, item := range items {
+		if item.Size > 0 {
+			lengths[i] = item.Size
+			remaining -= item.Size
+		} else {
+			weights += itemWeight(item)
+		}
+	}
+	remaining = max(remaining, 0)
+	for i, item := range items {
+		if item.Size <= 0 && weights > 0 {
+			lengths[i] = remaining * itemWeight(item) / weights
+		}
+	}
+	return lengths
+}
+
+func itemWeight(item StackItem) float32 {
+	if item.Weight <= 0 {
+		return 1
+	}
+	return item.Weight
+}
+
+// alignSpan returns the size and offset of a child within space. a child without a size
+// fills the space; otherwise align (or fallback when align is AlignStretch) places it.
+func alignSpan(space, size float32, align, fallback Alignment) (float32, float32) {
+	if size <= 0 || size >= space {
+		return space, 0
+	}
+	if align == AlignStretch {
+		align = fallback
+	}
+	switch align {
+	case AlignStretch:
+		return space, 0
+	case AlignCenter:
+		return size, (space - size) / 2
+	case AlignEnd:
+		return size, space - size
+	}
+	return size, 0
+}
+
+// layoutSize returns the space a layout component fills: its State size, or the
+// available region when the state has none.
+func layoutSize(state *State) imgui.Vec2 {
+	if state != nil && state.Size.X > 0 && state.Size.Y > 0 {
+		return state.Size
+	}
+	return imgui.ContentRegionAvail()
+}
+
+// drawLayoutCell draws comp in a borderless child window of size at window position pos.
+func drawLayoutCell(id string, comp Component, pos, size imgui.Vec2, parent Component, state *State) {
+	if comp == nil || size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	imgui.SetCursorPos(pos)
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{})
+	if imgui.BeginChildStrV(id, size, imgui.ChildFlagsNone, imgui.WindowFlagsNoScrollbar|imgui.WindowFlagsNoBackground) {
+		DrawChild(comp, state.Child(size, imgui.Vec2{}).WithParent(parent))
+	}
+	imgui.EndChild()
+	imgui.PopStyleVar()
+}
+
+// endLayout leaves the cursor below a layout of size drawn at window position origin.
+func endLayout(origin, size imgui.Vec2) {
+	imgui.SetCursorPos(origin)
+	imgui.Dummy(size)
+}
+
+// Spacer is an empty component; as a flexible stack item it pushes its neighbors apart.
+type Spacer struct {
+	Container
+}
+
+// NewSpacer creates a spacer.
+func NewSpacer() *Spacer {
+	return &Spacer{Container: Container{Visible: true}}
+}
+
+// Draw implements Component.
+func (s *Spacer) Draw(state *State) {
+	if !s.Visible {
+		return
+	}
+	drawContainerExtensions(&s.Container, state)
+}
+
+// Align places its content within the space its State gives it. content with a Width or
+// Height is positioned by Horizontal and Vertical; without one it fills that axis.
+type Align struct {
+	Container
+	Content    Component
+	Width      float32   // content width (0 = fill)
+	Height     float32   // content height (0 = fill)
+	Horizontal Alignment // horizontal placement
+	Vertical   Alignment // vertical placement
+}
+
+// NewAlign creates an Align centering content of the given size.
+func NewAlign(content Component, width, height float32) *Align {
+	return &Align{
+		Container:  Container{Visible: true},
+		Content:    content,
+		Width:      width,
+		Height:     height,
+		Horizontal: AlignCenter,
+		Vertical:   AlignCenter,
+	}
+}
+
+// Draw implements Component.
+func (a *Align) Draw(state *State) {
+	if !a.Visible {
+		return
+	}
+	size := layoutSize(state)
+	width, x := alignSpan(size.X, a.Width, a.Horizontal, AlignStart)
+	height, y := alignSpan(size.Y, a.Height, a.Vertical, AlignStart)
+
+	imgui.PushIDStr(componentID("align", a))
+	origin := imgui.CursorPos()
+	drawLayoutCell("##content", a.Content, origin.Add(imgui.Vec2{X: x, Y: y}), imgui.Vec2{X: width, Y: height}, a, state)
+	endLayout(origin, size)
+	imgui.PopID()
+
+	drawContainerExtensions(&a.Container, state)
+}
+
+// ChildActions returns the content for action traversal.
+func (a *Align) ChildActions() []Component {
+	if a.Content == nil {
+		return a.Children
+	}
+	return append([]Component{a.Content}, a.Children...)
+}
+
+// Grid arranges its cells in rows of Columns equal-width columns, left to right and top
+// to bottom. rows share the height equally unless RowHeight is set.
+type Grid struct {
+	Container
+	Cells     []Component // nil cells are left empty
+	Columns   int         // cells per row (0 = 1)
+	RowHeight float32     // fixed row height (0 = divide the height between the rows)
+	Spacing   float32     // gap between cells
+}
+
+// NewGrid creates a grid with columns columns.
+func NewGrid(columns int, cells ...Component) *Grid {
+	return &Grid{
+		Container: Container{Visible: true},
+		Cells:     cells,
+		Columns:   columns,
+		Spacing:   StackDefaultSpacing,
+	}
+}
+
+// Draw implements Component.
+func (g *Grid) Draw(state *State) {
+	if !g.Visible {
+		return
+	}
+	size := layoutSize(state)
+	columns, rows := g.dimensions()
+	cell := g.cellSize(size, columns, rows)
+
+	imgui.PushIDStr(componentID("grid", g))
+	origin := imgui.CursorPos()
+	for i, comp := range g.Cells {
+		col, row := i%columns, i/columns
+		pos := imgui.Vec2{X: float32(col) * (cell.X + g.Spacing), Y: float32(row) * (cell.Y + g.Spacing)}
+		drawLayoutCell(fmt.Sprintf("##cell%d", i), comp, origin.Add(pos), cell, g, state)
+	}
+	if g.RowHeight > 0 {
+		size.Y = float32(rows)*(g.RowHeight+g.Spacing) - g.Spacing
+	}
+	endLayout(origin, size)
+	imgui.PopID()
+
+	drawContainerExtensions(&g.Container, state)
+}
+
+// ChildActions returns the cells for action traversal.
+func (g *Grid) ChildActions() []Component {
+	var children []Component
+	for _, comp := range g.Cells {
+		if comp != nil {
+			children = append(children, comp)
+		}
+	}
+	return append(children, g.Children...)
+}
+
+// dimensions returns the column and row counts.
+func (g *Grid) dimensions() (int, int) {
+	columns := max(g.Columns, 1)
+	return columns, (len(g.Cells) + columns - 1) / columns
+}
+
+// cellSize returns the size of every cell when the grid fills size.
+func (g *Grid) cellSize(size imgui.Vec2, columns, rows int) imgui.Vec2 {
+	width := (size.X - g.Spacing*float32(columns-1)) / float32(columns)
+	height := g.RowHeight
+	if height <= 0 && rows > 0 {
+		height = (size.Y - g.Spacing*float32(rows-1)) / float32(rows)
+	}
+	return imgui.Vec2{X: max(width, 0), Y: max(height, 0)}
+}
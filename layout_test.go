@@ -0,0 +1,84 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestStackLengths(t *testing.T) {
+	items := []StackItem{Fixed(nil, 20), Flex(nil, 1), Flex(nil, 3), {}}
+	lengths := stackLengths(140, 10, items)
+	// 140 - 3 gaps - 20 fixed = 90 flexible, shared 1:3:1
+	expected := []float32{20, 18, 54, 18}
+	for i := range expected {
+		if lengths[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, lengths)
+		}
+	}
+
+	lengths = stackLengths(30, 10, []StackItem{Fixed(nil, 40), Flex(nil, 1)})
+	if lengths[0] != 40 || lengths[1] != 0 {
+		t.Fatalf("expected flexible items to clamp at zero, got %v", lengths)
+	}
+	if len(stackLengths(100, 10, nil)) != 0 {
+		t.Fatal("expected no lengths for no items")
+	}
+}
+
+func TestAlignSpan(t *testing.T) {
+	cases := []struct {
+		size            float32
+		align, fallback Alignment
+		length, offset  float32
+	}{
+		{0, AlignCenter, AlignStart, 100, 0},
+		{120, AlignCenter, AlignStart, 100, 0},
+		{40, AlignStart, AlignEnd, 40, 0},
+		{40, AlignCenter, AlignStart, 40, 30},
+		{40, AlignEnd, AlignStart, 40, 60},
+		{40, AlignStretch, AlignEnd, 40, 60},
+		{40, AlignStretch, AlignStretch, 100, 0},
+	}
+	for _, c := range cases {
+		length, offset := alignSpan(100, c.size, c.align, c.fallback)
+		if length != c.length || offset != c.offset {
+			t.Fatalf("alignSpan(100, %v, %v, %v): expected %v/%v, got %v/%v", c.size, c.align, c.fallback, c.length, c.offset, length, offset)
+		}
+	}
+}
+
+func TestGridCells(t *testing.T) {
+	g := NewGrid(3, nil, nil, nil, nil)
+	columns, rows := g.dimensions()
+	if columns != 3 || rows != 2 {
+		t.Fatalf("expected 3x2, got %dx%d", columns, rows)
+	}
+	cell := g.cellSize(imgui.Vec2{X: 308, Y: 104}, columns, rows)
+	if cell.X != 100 || cell.Y != 50 {
+		t.Fatalf("expected 100x50 cells, got %v", cell)
+	}
+	g.RowHeight = 30
+	if cell = g.cellSize(imgui.Vec2{X: 308, Y: 104}, columns, rows); cell.Y != 30 {
+		t.Fatalf("expected fixed row height, got %v", cell)
+	}
+	g.Columns = 0
+	if columns, rows = g.dimensions(); columns != 1 || rows != 4 {
+		t.Fatalf("expected a single column, got %dx%d", columns, rows)
+	}
+}
+
+func TestLayoutChildActions(t *testing.T) {
+	a, b := NewSpacer(), NewSpacer()
+	stack := NewVStack(Fixed(a, 10), Flex(nil, 1), Flex(NewAlign(b, 10, 10), 1))
+	children := stack.ChildActions()
+	if len(children) != 2 || children[0] != a {
+		t.Fatalf("unexpected stack children %v", children)
+	}
+	if align := children[1].(*Align); len(align.ChildActions()) != 1 || align.ChildActions()[0] != b {
+		t.Fatal("expected align content in child actions")
+	}
+	if children := NewGrid(2, nil, a).ChildActions(); len(children) != 1 || children[0] != a {
+		t.Fatalf("unexpected grid children %v", children)
+	}
+}
@@ -0,0 +1,204 @@
+package dfx
+
+import (
+	"reflect"
+)
+
+// Mounter, Unmounter, Shower and Hider are implemented by components that hold resources
+// tied to being part of the UI, such as tickers, subscriptions or file watchers; a
+// component implements only those it needs. the App and the built-in containers call them
+// as components come and go:
+//
+//   - OnMount when the component is drawn for the first time
+//   - OnShow when it is drawn after not being drawn the frame before (right after OnMount
+//     the first time)
+//   - OnHide when a frame ends without it being drawn: it was hidden, its tab or workspace
+//     was switched away, or its container collapsed
+//   - OnUnmount when it is removed from its container, replaced as the root, or the app
+//     shuts down (after OnHide if it was shown)
+//
+// all four are called on the UI thread. a component is drawn through its parent, so
+// containers of your own should draw their children with DrawChild and call
+// UnmountComponent on children they remove.
+type Mounter interface {
+	OnMount()
+}
+
+// Unmounter is implemented by components releasing resources when removed (see Mounter).
+type Unmounter interface {
+	OnUnmount()
+}
+
+// Shower is implemented by components resuming work when drawn again (see Mounter).
+type Shower interface {
+	OnShow()
+}
+
+// Hider is implemented by components pausing work when no longer drawn (see Mounter).
+type Hider interface {
+	OnHide()
+}
+
+// Lifecycle is implemented by components that handle all four lifecycle calls.
+type Lifecycle interface {
+	Mounter
+	Unmounter
+	Shower
+	Hider
+}
+
+// DrawChild draws comp as a child of the component being drawn, calling its lifecycle
+// methods as it is shown for the first time or again, and binding it to State.Store when
+// it has a Container.StateID. use it in place of comp.Draw in containers.
+func DrawChild(comp Component, state *State) {
+	if comp == nil {
+		return
+	}
+	if lifecycleAware(comp) && componentVisible(comp) {
+		lifecycle.drawn(comp)
+	}
+	contributions.drawn(comp)
+	if state != nil && state.Store != nil {
+		bindComponentState(state.Store, comp)
+	}
+	comp.Draw(state)
+}
+
+// UnmountComponent calls OnUnmount on comp and the components below it that are mounted,
+// hiding them first if they are shown. containers call it on the children they remove.
+func UnmountComponent(comp Component) {
+	lifecycle.unmount(comp)
+}
+
+// componentVisible returns false for components with a Container that is not Visible,
+// whose Draw returns without drawing anything.
+func componentVisible(comp Component) bool {
+	if v, ok := comp.(interface{ isVisible() bool }); ok {
+		return v.isVisible()
+	}
+	return true
+}
+
+// lifecycle tracks the components of the app with lifecycle methods. it is only used from the UI thread.
+var lifecycle = newLifecycleTracker()
+
+// lifecycleTracker follows which components with lifecycle methods are mounted and
+// shown, from the components drawn each frame.
+type lifecycleTracker struct {
+	mounted map[Component]bool
+	order   []Component // mounted components, in mount order
+	shown   map[Component]bool
+	frame   map[Component]bool // drawn this frame
+}
+
+func newLifecycleTracker() *lifecycleTracker {
+	return &lifecycleTracker{
+		mounted: make(map[Component]bool),
+		shown:   make(map[Component]bool),
+		frame:   make(map[Component]bool),
+	}
+}
+
+// drawn records comp as drawn this frame, mounting and showing it if needed.
+func (t *lifecycleTracker) drawn(comp Component) {
+	if !lifecycleComparable(comp) || t.frame[comp] {
+		return
+	}
+	t.frame[comp] = true
+	if !t.mounted[comp] {
+		t.mounted[comp] = true
+		t.order = append(t.order, comp)
+		if m, ok := comp.(Mounter); ok {
+			m.OnMount()
+		}
+	}
+	if !t.shown[comp] {
+		t.shown[comp] = true
+		if s, ok := comp.(Shower); ok {
+			s.OnShow()
+		}
+	}
+}
+
+// endFrame hides the components that were shown but not drawn this frame.
+func (t *lifecycleTracker) endFrame() {
+	for _, comp := range t.order {
+		if t.shown[comp] && !t.frame[comp] {
+			t.hide(comp)
+		}
+	}
+	clear(t.frame)
+}
+
+// unmount unmounts comp and its subtree: the children it exposes through ChildActions and
+// the Children of its Container.
+func (t *lifecycleTracker) unmount(comp Component) {
+	if comp == nil {
+		return
+	}
+	if cp, ok := comp.(ChildActionProvider); ok {
+		for _, child := range cp.ChildActions() {
+			t.unmount(child)
+		}
+	}
+	if c := containerOf(comp); c != nil {
+		for _, child := range c.Children {
+			t.unmount(child)
+		}
+	}
+	if lifecycleAware(comp) && lifecycleComparable(comp) {
+		t.release(comp)
+	}
+}
+
+// unmountAll unmounts every mounted component, most recently mounted first. the app calls
+// it when shutting down.
+func (t *lifecycleTracker) unmountAll() {
+	for len(t.order) > 0 {
+		t.release(t.order[len(t.order)-1])
+	}
+}
+
+// hide marks comp as no longer shown.
+func (t *lifecycleTracker) hide(comp Component) {
+	delete(t.shown, comp)
+	if h, ok := comp.(Hider); ok {
+		h.OnHide()
+	}
+}
+
+// release hides and unmounts comp if it is mounted.
+func (t *lifecycleTracker) release(comp Component) {
+	if !t.mounted[comp] {
+		return
+	}
+	if t.shown[comp] {
+		t.hide(comp)
+	}
+	delete(t.mounted, comp)
+	delete(t.frame, comp)
+	for i, other := range t.order {
+		if other == comp {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	if u, ok := comp.(Unmounter); ok {
+		u.OnUnmount()
+	}
+}
+
+// lifecycleAware reports whether comp implements any of the lifecycle methods.
+func lifecycleAware(comp Component) bool {
+	switch comp.(type) {
+	case Mounter, Unmounter, Shower, Hider:
+		return true
+	}
+	return false
+}
+
+// lifecycleComparable returns false for components that cannot be map keys, such as
+// value types holding slices; their lifecycle is not tracked.
+func lifecycleComparable(comp Component) bool {
+	return reflect.TypeOf(comp).Comparable()
+}
@@ -0,0 +1,189 @@
+package dfx
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type lifecycleProbe struct {
+	Container
+	events []string
+	name   string
+	log    *[]string // records name on mount and unmount
+}
+
+func newLifecycleProbe() *lifecycleProbe {
+	return &lifecycleProbe{Container: Container{Visible: true}}
+}
+
+func (p *lifecycleProbe) Draw(state *State) {}
+func (p *lifecycleProbe) OnMount()          { p.record("mount") }
+func (p *lifecycleProbe) OnUnmount()        { p.record("unmount") }
+func (p *lifecycleProbe) OnShow()           { p.events = append(p.events, "show") }
+func (p *lifecycleProbe) OnHide()           { p.events = append(p.events, "hide") }
+
+func (p *lifecycleProbe) record(event string) {
+	p.events = append(p.events, event)
+	if p.log != nil {
+		*p.log = append(*p.log, p.name)
+	}
+}
+
+// unmountProbe implements only Unmounter.
+type unmountProbe struct {
+	Container
+	unmounted int
+}
+
+func (p *unmountProbe) OnUnmount() { p.unmounted++ }
+
+func withLifecycleTracker(t *testing.T) {
+	saved := lifecycle
+	lifecycle = newLifecycleTracker()
+	t.Cleanup(func() { lifecycle = saved })
+}
+
+func TestLifecycle_MountShowHide(t *testing.T) {
+	withLifecycleTracker(t)
+	p := newLifecycleProbe()
+
+	DrawChild(p, &State{})
+	DrawChild(p, &State{}) // drawn twice in a frame
+	lifecycle.endFrame()
+	if !slices.Equal(p.events, []string{"mount", "show"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show"}, p.events)
+	}
+
+	DrawChild(p, &State{})
+	lifecycle.endFrame()
+	if !slices.Equal(p.events, []string{"mount", "show"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show"}, p.events)
+	}
+
+	lifecycle.endFrame() // not drawn
+	if !slices.Equal(p.events, []string{"mount", "show", "hide"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide"}, p.events)
+	}
+
+	DrawChild(p, &State{})
+	lifecycle.endFrame()
+	if !slices.Equal(p.events, []string{"mount", "show", "hide", "show"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "show"}, p.events)
+	}
+}
+
+func TestLifecycle_InvisibleIsHidden(t *testing.T) {
+	withLifecycleTracker(t)
+	p := newLifecycleProbe()
+
+	DrawChild(p, &State{})
+	lifecycle.endFrame()
+	p.Visible = false
+	DrawChild(p, &State{})
+	lifecycle.endFrame()
+	if !slices.Equal(p.events, []string{"mount", "show", "hide"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide"}, p.events)
+	}
+}
+
+func TestLifecycle_UnmountSubtree(t *testing.T) {
+	withLifecycleTracker(t)
+	parent := newLifecycleProbe()
+	child := newLifecycleProbe()
+	parent.Children = []Component{child}
+
+	DrawChild(parent, &State{})
+	DrawChild(child, &State{})
+	lifecycle.endFrame()
+
+	UnmountComponent(parent)
+	if !slices.Equal(parent.events, []string{"mount", "show", "hide", "unmount"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "unmount"}, parent.events)
+	}
+	if !slices.Equal(child.events, []string{"mount", "show", "hide", "unmount"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "unmount"}, child.events)
+	}
+
+	// unmounting again does nothing; drawing again mounts again
+	UnmountComponent(parent)
+	DrawChild(parent, &State{})
+	if !slices.Equal(parent.events, []string{"mount", "show", "hide", "unmount", "mount", "show"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "unmount", "mount", "show"}, parent.events)
+	}
+}
+
+func TestLifecycle_UnmountAllInReverseOrder(t *testing.T) {
+	withLifecycleTracker(t)
+	var order []string
+	first, second := newLifecycleProbe(), newLifecycleProbe()
+	first.name, first.log = "first", &order
+	second.name, second.log = "second", &order
+	DrawChild(first, &State{})
+	DrawChild(second, &State{})
+
+	lifecycle.unmountAll()
+	if !slices.Equal(order, []string{"first", "second", "second", "first"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"first", "second", "second", "first"}, order)
+	} // mounted, then unmounted
+	if got := lifecycle.order; len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+	if !slices.Equal(first.events, []string{"mount", "show", "hide", "unmount"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "unmount"}, first.events)
+	}
+}
+
+func TestLifecycle_MultiGridRemoveUnmounts(t *testing.T) {
+	withLifecycleTracker(t)
+	mg := NewMultiGrid()
+	p := newLifecycleProbe()
+	mg.AddComponent("a", p)
+	DrawChild(p, &State{})
+
+	mg.RemoveComponent("a")
+	if !slices.Equal(p.events, []string{"mount", "show", "hide", "unmount"}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"mount", "show", "hide", "unmount"}, p.events)
+	}
+}
+
+func TestLifecycle_WorkspaceReplaceUnmounts(t *testing.T) {
+	withLifecycleTracker(t)
+	ws := NewWorkspace()
+	old, lazy := newLifecycleProbe(), newLifecycleProbe()
+	ws.Add("a", "A", old)
+	DrawChild(old, &State{})
+
+	ws.Add("a", "A", old) // the same component again stays mounted
+	if len(old.events) != 2 {
+		t.Fatalf("expected 'old' to stay mounted, got %v", old.events)
+	}
+	ws.Add("a", "A", newLifecycleProbe())
+	if got := strings.Join(old.events, " "); got != "mount show hide unmount" {
+		t.Fatalf("expected the replaced component unmounted, got '%v'", got)
+	}
+
+	ws.AddLazy("b", "B", func() Component { return lazy })
+	ws.Switch("b")
+	DrawChild(lazy, &State{})
+	ws.AddLazy("b", "B", func() Component { return newLifecycleProbe() })
+	if got := strings.Join(lazy.events, " "); got != "mount show hide unmount" {
+		t.Fatalf("expected the replaced lazy component unmounted, got '%v'", got)
+	}
+}
+
+func TestLifecycle_SingleMethod(t *testing.T) {
+	withLifecycleTracker(t)
+	p := &unmountProbe{Container: Container{Visible: true}}
+	DrawChild(p, &State{})
+	lifecycle.endFrame()
+	lifecycle.endFrame() // hidden, without a Hider
+	if !lifecycle.mounted[p] {
+		t.Fatal("expected a component with only OnUnmount to be tracked")
+	}
+	UnmountComponent(p)
+	UnmountComponent(p)
+	if p.unmounted != 1 {
+		t.Fatalf("expected one OnUnmount, got %v", p.unmounted)
+	}
+}
@@ -0,0 +1,438 @@
+package dfx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ListView constants
+const (
+	ListTypeAheadTimeout = time.Second // pause after which type-ahead starts a new search
+)
+
+// ListModel supplies the items displayed by a ListView. items are identified by their
+// index, 0 to Count()-1.
+type ListModel interface {
+	// Count returns the number of items.
+	Count() int
+	// Render draws the item at index. it is drawn over the item's selectable row and
+	// should fit within the list's ItemHeight (one text line by default).
+	Render(index int)
+	// Match reports whether the item at index matches a search query; used by the search
+	// box and type-ahead.
+	Match(index int, query string) bool
+}
+
+// StringList is a ListModel of plain strings, matched case-insensitively.
+type StringList []string
+
+// Count implements ListModel.
+func (l StringList) Count() int { return len(l) }
+
+// Render implements ListModel.
+func (l StringList) Render(index int) { imgui.TextUnformatted(l[index]) }
+
+// Match implements ListModel.
+func (l StringList) Match(index int, query string) bool {
+	return strings.Contains(strings.ToLower(l[index]), strings.ToLower(query))
+}
+
+// ListView displays the items of a ListModel in a scrolling list. only the visible rows
+// are drawn, so lists of any size stay fast. items are selected with the mouse (Ctrl and
+// Shift extend the selection in multi mode) or the arrow keys, and typing while the list
+// is focused jumps to the next matching item.
+type ListView struct {
+	Container
+	Model      ListModel
+	Selection  *Selection[int] // selected model indices
+	ShowSearch bool            // show a search box that filters the list
+	Search     string          // current search query
+	SearchHint string          // placeholder shown in the empty search box
+	ItemHeight float32         // row height (0 = one text line)
+	Height     float32         // list height (0 = fill the available region)
+	OnActivate func(index int) // called when an item is double-clicked or Enter is pressed
+	Draggable  bool            // rows can be dragged as DragKindListRows payloads
+
+	// OnDrop receives rows dragged from any Draggable ListView (including this one) and
+	// dropped before or after the item at target; reordering is up to the model.
+	OnDrop func(drag ListDrag, target int, after bool)
+
+	rows        []int // model indices of the displayed items, ascending (nil = all items)
+	rowsQuery   string
+	rowsCount   int
+	rowsDirty   bool
+	cursor      int // model index of the keyboard cursor (-1 = none)
+	scrollTo    bool
+	pageRows    int
+	typed       string
+	typedAt     time.Time
+	focusSearch bool
+}
+
+// NewListView creates a single-selection list view of model.
+func NewListView(model ListModel) *ListView {
+	return &ListView{
+		Container:  Container{Visible: true},
+		Model:      model,
+		Selection:  NewSelection[int](SelectSingle),
+		SearchHint: "search",
+		rowsDirty:  true,
+		cursor:     -1,
+		pageRows:   10,
+	}
+}
+
+// Refresh re-applies the search filter on the next frame; call after the model's items
+// change without their count changing.
+func (lv *ListView) Refresh() {
+	lv.rowsDirty = true
+}
+
+// FocusSearch shows the search box and gives it keyboard focus.
+func (lv *ListView) FocusSearch() {
+	lv.ShowSearch = true
+	lv.focusSearch = true
+}
+
+// DisplayedCount returns the number of items shown after filtering.
+func (lv *ListView) DisplayedCount() int {
+	lv.updateRows()
+	return lv.rowCount()
+}
+
+// SelectedIndices returns the selected model indices in ascending order.
+func (lv *ListView) SelectedIndices() []int {
+	indices := lv.Selection.Keys()
+	sort.Ints(indices)
+	return indices
+}
+
+// ScrollTo moves the keyboard cursor to the item at index and brings it into view.
+func (lv *ListView) ScrollTo(index int) {
+	lv.cursor = index
+	lv.scrollTo = true
+}
+
+// Draw implements Component.
+func (lv *ListView) Draw(state *State) {
+	if !lv.Visible || lv.Model == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("listView", lv))
+	defer imgui.PopID()
+
+	if lv.ShowSearch {
+		lv.drawSearch()
+	}
+	lv.updateRows()
+
+	imgui.BeginChildStrV("##items", imgui.Vec2{Y: lv.Height}, imgui.ChildFlagsNone, imgui.WindowFlagsNone)
+	height := lv.ItemHeight
+	if height <= 0 {
+		height = imgui.TextLineHeight()
+	}
+	stride := height + imgui.CurrentStyle().ItemSpacing().Y
+	lv.pageRows = max(1, int(imgui.WindowHeight()/stride)-1)
+
+	if imgui.IsWindowFocused() && !imgui.IsAnyItemActive() {
+		lv.handleKeys(state.Clock())
+	}
+	lv.drawRows(height, stride)
+	imgui.EndChild()
+
+	drawContainerExtensions(&lv.Container, state)
+}
+
+func (lv *ListView) drawSearch() {
+	if lv.focusSearch {
+		imgui.SetKeyboardFocusHere()
+		lv.focusSearch = false
+	}
+	imgui.SetNextItemWidth(-1)
+	imgui.InputTextWithHint("##search", lv.SearchHint, &lv.Search, imgui.InputTextFlagsNone, nil)
+	if imgui.IsItemFocused() && (imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter)) {
+		// enter in the search box activates the cursor item, or the first match
+		lv.updateRows()
+		if lv.rowOf(lv.cursor) < 0 && lv.rowCount() > 0 {
+			lv.moveCursor(0, false)
+		}
+		lv.activate()
+	}
+}
+
+// drawRows draws the visible rows; every row is stride apart.
+func (lv *ListView) drawRows(height, stride float32) {
+	n := lv.rowCount()
+	if lv.scrollTo {
+		lv.scrollTo = false
+		if row := lv.rowOf(lv.cursor); row >= 0 {
+			top := float32(row) * stride
+			if top < imgui.ScrollY() {
+				imgui.SetScrollYFloat(top)
+			} else if bottom := top + stride; bottom > imgui.ScrollY()+imgui.WindowHeight() {
+				imgui.SetScrollYFloat(bottom - imgui.WindowHeight() + 2*imgui.CurrentStyle().WindowPadding().Y)
+			}
+		}
+	}
+
+	VirtualList(n, stride, func(row int) {
+		lv.drawRow(row, height)
+	})
+}
+
+// drawRow draws the item at row over a full-width selectable.
+func (lv *ListView) drawRow(row int, height float32) {
+	index := lv.rowIndex(row)
+	imgui.PushIDInt(int32(index))
+	defer imgui.PopID()
+
+	pos := imgui.CursorScreenPos()
+	if imgui.SelectableBoolV("##item", lv.Selection.Contains(index), imgui.SelectableFlagsAllowOverlap, imgui.Vec2{Y: height}) {
+		io := imgui.CurrentIO()
+		lv.clickRow(row, io.KeyShift(), io.KeyCtrl())
+	}
+	if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+		lv.cursor = index
+		lv.activate()
+	}
+	if lv.Draggable {
+		lv.dragSource(index)
+	}
+	if lv.OnDrop != nil {
+		lv.dropTarget(index)
+	}
+
+	imgui.SetCursorScreenPos(pos)
+	imgui.BeginGroup()
+	lv.Model.Render(index)
+	imgui.EndGroup()
+	// keep rows exactly stride apart regardless of what Render drew
+	imgui.SetCursorScreenPos(pos)
+	imgui.Dummy(imgui.Vec2{Y: height})
+}
+
+// dragSource makes the row of the item at index draggable.
+func (lv *ListView) dragSource(index int) {
+	indices := lv.dragIndices(index)
+	DragSource(DragKindListRows, ListDrag{Source: lv, Indices: indices}, func() {
+		lv.Model.Render(indices[0])
+		if len(indices) > 1 {
+			imgui.TextDisabled(fmt.Sprintf("+%d more", len(indices)-1))
+		}
+	})
+}
+
+// dragIndices returns the items dragged from the item at index: the selection when the
+// item is selected, and the item alone otherwise.
+func (lv *ListView) dragIndices(index int) []int {
+	if lv.Selection.Contains(index) {
+		return lv.SelectedIndices()
+	}
+	return []int{index}
+}
+
+// dropTarget accepts rows dropped on the row of the item at index, before or after it
+// depending on which half of the row the mouse is over.
+func (lv *ListView) dropTarget(index int) {
+	drag, ok := DragPayload[ListDrag](DragKindListRows)
+	if !ok {
+		return
+	}
+	top, bottom := imgui.ItemRectMin().Y, imgui.ItemRectMax().Y
+	after := imgui.MousePos().Y-top > (bottom-top)/2
+	if acceptDrop(DragKindListRows, func(topLeft, bottomRight imgui.Vec2) {
+		highlightDropLine(topLeft, bottomRight, after)
+	}) {
+		lv.OnDrop(drag, index, after)
+	}
+}
+
+// handleKeys applies keyboard navigation and type-ahead while the list is focused.
+func (lv *ListView) handleKeys(now time.Time) {
+	n := lv.rowCount()
+	if n == 0 {
+		return
+	}
+	io := imgui.CurrentIO()
+	row := lv.rowOf(lv.cursor)
+	shift := io.KeyShift()
+
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyDownArrow):
+		lv.moveCursor(row+1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyUpArrow):
+		if row < 0 {
+			row = n
+		}
+		lv.moveCursor(row-1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyPageDown):
+		lv.moveCursor(row+lv.pageRows, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyPageUp):
+		lv.moveCursor(row-lv.pageRows, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyHome):
+		lv.moveCursor(0, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyEnd):
+		lv.moveCursor(n-1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter):
+		lv.activate()
+	case io.KeyCtrl() && imgui.IsKeyPressedBool(imgui.KeyA):
+		lv.SelectAll()
+	case io.KeyCtrl() && imgui.IsKeyPressedBool(imgui.KeyF):
+		lv.FocusSearch()
+	case !io.KeyCtrl():
+		for _, c := range io.InputQueueCharacters().Slice() {
+			lv.typeAhead(rune(c), now)
+		}
+	}
+}
+
+// SelectAll selects every displayed item (multi selection only).
+func (lv *ListView) SelectAll() {
+	if lv.Selection.Mode != SelectMulti {
+		return
+	}
+	lv.updateRows()
+	indices := make([]int, lv.rowCount())
+	for row := range indices {
+		indices[row] = lv.rowIndex(row)
+	}
+	lv.Selection.SetAll(indices)
+	lv.Selection.changed()
+}
+
+// clickRow applies a click on the displayed row to the selection.
+func (lv *ListView) clickRow(row int, extend, toggle bool) {
+	index := lv.rowIndex(row)
+	lv.cursor = index
+	lv.Selection.Click(index, extend, toggle, lv.span)
+}
+
+// moveCursor moves the keyboard cursor to row (clamped) and selects its item, extending
+// the selection from the anchor when extend is set.
+func (lv *ListView) moveCursor(row int, extend bool) {
+	n := lv.rowCount()
+	if n == 0 {
+		return
+	}
+	row = min(max(row, 0), n-1)
+	index := lv.rowIndex(row)
+	lv.cursor = index
+	lv.scrollTo = true
+	lv.Selection.Click(index, extend, false, lv.span)
+}
+
+// typeAhead adds c to the type-ahead query and moves to the next matching item.
+func (lv *ListView) typeAhead(c rune, now time.Time) {
+	if now.Sub(lv.typedAt) > ListTypeAheadTimeout {
+		lv.typed = ""
+	}
+	lv.typed += string(c)
+	lv.typedAt = now
+
+	// a new search starts after the cursor, so repeating a letter cycles through matches;
+	// a longer query may still match the cursor item
+	start := max(lv.rowOf(lv.cursor), 0)
+	if len([]rune(lv.typed)) == 1 {
+		start++
+	}
+	if row := typeAheadFind(lv.rowCount(), start, func(row int) bool {
+		return lv.Model.Match(lv.rowIndex(row), lv.typed)
+	}); row >= 0 {
+		lv.moveCursor(row, false)
+	}
+}
+
+// typeAheadFind returns the first of n rows, searching from start and wrapping around,
+// for which match is true, or -1.
+func typeAheadFind(n, start int, match func(row int) bool) int {
+	for i := range n {
+		row := (start + i) % n
+		if match(row) {
+			return row
+		}
+	}
+	return -1
+}
+
+func (lv *ListView) activate() {
+	if lv.cursor >= 0 && lv.OnActivate != nil {
+		lv.OnActivate(lv.cursor)
+	}
+}
+
+// updateRows re-applies the search filter when the query or the item count changed.
+func (lv *ListView) updateRows() {
+	count := lv.Model.Count()
+	if !lv.rowsDirty && lv.Search == lv.rowsQuery && count == lv.rowsCount {
+		return
+	}
+	lv.rowsDirty = false
+	lv.rowsQuery = lv.Search
+	lv.rowsCount = count
+	if lv.Search == "" {
+		lv.rows = nil
+		return
+	}
+	lv.rows = lv.rows[:0]
+	if lv.rows == nil {
+		lv.rows = []int{}
+	}
+	for i := range count {
+		if lv.Model.Match(i, lv.Search) {
+			lv.rows = append(lv.rows, i)
+		}
+	}
+}
+
+func (lv *ListView) rowCount() int {
+	if lv.rows == nil {
+		return lv.rowsCount
+	}
+	return len(lv.rows)
+}
+
+// rowIndex returns the model index of the item displayed at row.
+func (lv *ListView) rowIndex(row int) int {
+	if lv.rows == nil {
+		return row
+	}
+	return lv.rows[row]
+}
+
+// rowOf returns the displayed row of the item at model index, or -1.
+func (lv *ListView) rowOf(index int) int {
+	if index < 0 {
+		return -1
+	}
+	if lv.rows == nil {
+		if index < lv.rowsCount {
+			return index
+		}
+		return -1
+	}
+	row := sort.SearchInts(lv.rows, index)
+	if row < len(lv.rows) && lv.rows[row] == index {
+		return row
+	}
+	return -1
+}
+
+// span returns the model indices displayed from one item to another, inclusive.
+func (lv *ListView) span(from, to int) []int {
+	a, b := lv.rowOf(from), lv.rowOf(to)
+	if a < 0 || b < 0 {
+		return []int{to}
+	}
+	if a > b {
+		a, b = b, a
+	}
+	indices := make([]int, 0, b-a+1)
+	for row := a; row <= b; row++ {
+		indices = append(indices, lv.rowIndex(row))
+	}
+	return indices
+}
@@ -0,0 +1,119 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestListViewFilter(t *testing.T) {
+	lv := NewListView(StringList{"apple", "Banana", "cherry", "grape", "pineapple"})
+	if lv.DisplayedCount() != 5 {
+		t.Fatalf("expected 5 items, got %v", lv.DisplayedCount())
+	}
+	lv.Search = "APP"
+	if lv.DisplayedCount() != 2 || lv.rowIndex(1) != 4 {
+		t.Fatalf("expected apple and pineapple, got %v", lv.rows)
+	}
+	if lv.rowOf(4) != 1 || lv.rowOf(1) != -1 {
+		t.Fatalf("unexpected rows for model indices")
+	}
+	lv.Search = ""
+	if lv.DisplayedCount() != 5 || lv.rowOf(4) != 4 || lv.rowOf(5) != -1 {
+		t.Fatalf("expected unfiltered rows")
+	}
+
+	// growing the model is picked up without a refresh
+	lv.Model = append(lv.Model.(StringList), "date")
+	if lv.DisplayedCount() != 6 {
+		t.Fatalf("expected 6 items, got %v", lv.DisplayedCount())
+	}
+}
+
+func TestListViewSelection(t *testing.T) {
+	lv := NewListView(StringList{"a", "b", "c", "d", "e", "f"})
+	lv.Selection.Mode = SelectMulti
+	lv.updateRows()
+
+	lv.clickRow(1, false, false)
+	lv.moveCursor(3, true)
+	if !slices.Equal(lv.SelectedIndices(), []int{1, 2, 3}) {
+		t.Fatalf("expected 1-3, got %v", lv.SelectedIndices())
+	}
+	lv.moveCursor(10, false)
+	if !slices.Equal(lv.SelectedIndices(), []int{5}) || lv.cursor != 5 {
+		t.Fatalf("expected cursor clamped to 5, got %v", lv.SelectedIndices())
+	}
+
+	// ranges span only displayed items
+	lv.Model = StringList{"x1", "y", "x2", "y", "x3"}
+	lv.Search = "x"
+	lv.updateRows()
+	lv.Selection.Clear()
+	lv.clickRow(0, false, false)
+	lv.clickRow(2, true, false)
+	if !slices.Equal(lv.SelectedIndices(), []int{0, 2, 4}) {
+		t.Fatalf("expected displayed items only, got %v", lv.SelectedIndices())
+	}
+
+	lv.Selection.Clear()
+	lv.SelectAll()
+	if lv.Selection.Len() != 3 {
+		t.Fatalf("expected 3 selected, got %v", lv.Selection.Len())
+	}
+
+	activated := -1
+	lv.OnActivate = func(index int) { activated = index }
+	lv.clickRow(1, false, false)
+	lv.activate()
+	if activated != 2 {
+		t.Fatalf("expected item 2 activated, got %v", activated)
+	}
+}
+
+func TestListViewTypeAhead(t *testing.T) {
+	lv := NewListView(StringList{"alpha", "beta", "bravo", "charlie", "bingo"})
+	lv.updateRows()
+	now := time.Now()
+
+	lv.typeAhead('b', now)
+	if lv.cursor != 1 {
+		t.Fatalf("expected beta, got %v", lv.cursor)
+	}
+	// repeating a letter after the timeout cycles through matches
+	now = now.Add(2 * ListTypeAheadTimeout)
+	lv.typeAhead('b', now)
+	if lv.cursor != 2 {
+		t.Fatalf("expected bravo, got %v", lv.cursor)
+	}
+	// typing more narrows the match from the cursor
+	lv.typeAhead('i', now.Add(time.Millisecond))
+	if lv.cursor != 4 {
+		t.Fatalf("expected bingo, got %v", lv.cursor)
+	}
+	if !slices.Equal(lv.SelectedIndices(), []int{4}) {
+		t.Fatalf("expected bingo selected, got %v", lv.SelectedIndices())
+	}
+
+	if typeAheadFind(3, 2, func(row int) bool { return row == 0 }) != 0 {
+		t.Fatalf("expected search to wrap around")
+	}
+	if typeAheadFind(3, 0, func(int) bool { return false }) != -1 {
+		t.Fatalf("expected no match")
+	}
+}
+
+func TestListViewDragIndices(t *testing.T) {
+	lv := NewListView(StringList{"a", "b", "c", "d"})
+	lv.Selection.Mode = SelectMulti
+	lv.updateRows()
+	lv.clickRow(3, false, false)
+	lv.clickRow(1, false, true)
+
+	if !slices.Equal(lv.dragIndices(1), []int{1, 3}) {
+		t.Fatalf("expected the selection dragged, got %v", lv.dragIndices(1))
+	}
+	if !slices.Equal(lv.dragIndices(2), []int{2}) {
+		t.Fatalf("expected an unselected item dragged alone, got %v", lv.dragIndices(2))
+	}
+}
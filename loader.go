@@ -0,0 +1,208 @@
+package dfx
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LoadStatus is the state of a value in a Loader.
+type LoadStatus int
+
+const (
+	LoadPending LoadStatus = iota // loading on a goroutine
+	LoadReady                     // loaded
+	LoadFailed                    // loading returned an error
+)
+
+// Loader constants
+const (
+	LoaderHTTPTimeout = 30 * time.Second // timeout for loading http(s) sources
+)
+
+// Loader loads values by key (a URL, a path) on goroutines and caches them. Get starts
+// loading a key the first time it is asked for and reports LoadPending until the result
+// arrives; results are handed to the UI thread through dispatch (usually App.Dispatch),
+// so Get always sees a consistent cache. with MaxEntries set, the least recently used
+// values are evicted, through OnEvict, to make room.
+//
+//	thumbnails := dfx.NewLoader(app.Dispatch, func(key string) (*Thumbnail, error) {
+//	    return renderThumbnail(key)
+//	})
+//
+// use a loader from the UI thread.
+type Loader[T any] struct {
+	MaxEntries int                       // values kept (0 = unlimited)
+	OnEvict    func(key string, value T) // called when a loaded value leaves the cache
+
+	load     func(key string) (T, error)
+	dispatch func(func())
+	entries  map[string]*loaderEntry[T]
+	uses     uint64 // use counter, for least-recently-used eviction
+}
+
+type loaderEntry[T any] struct {
+	status  LoadStatus
+	value   T
+	err     error
+	lastUse uint64
+}
+
+// NewLoader creates a loader that runs load on goroutines and delivers the results with
+// dispatch.
+func NewLoader[T any](dispatch func(func()), load func(key string) (T, error)) *Loader[T] {
+	return &Loader[T]{
+		load:     load,
+		dispatch: dispatch,
+		entries:  make(map[string]*loaderEntry[T]),
+	}
+}
+
+// Get returns the value for key, starting to load it if it is not cached. the status
+// says whether value is ready; err is the loading error when it failed.
+func (l *Loader[T]) Get(key string) (value T, status LoadStatus, err error) {
+	e, found := l.entries[key]
+	if !found {
+		e = l.start(key)
+	}
+	l.uses++
+	e.lastUse = l.uses
+	return e.value, e.status, e.err
+}
+
+// Status returns the status of key without loading it; found is false when the key is
+// not cached.
+func (l *Loader[T]) Status(key string) (status LoadStatus, found bool) {
+	if e, found := l.entries[key]; found {
+		return e.status, true
+	}
+	return LoadPending, false
+}
+
+// Reload drops the cached value for key and loads it again.
+func (l *Loader[T]) Reload(key string) {
+	l.Evict(key)
+	l.start(key)
+}
+
+// Evict drops the cached value for key. a load in progress is discarded when it finishes.
+func (l *Loader[T]) Evict(key string) {
+	if e, found := l.entries[key]; found {
+		delete(l.entries, key)
+		l.evicted(key, e)
+	}
+}
+
+// Clear drops every cached value.
+func (l *Loader[T]) Clear() {
+	for key := range l.entries {
+		l.Evict(key)
+	}
+}
+
+// Len returns the number of cached keys, including those still loading.
+func (l *Loader[T]) Len() int {
+	return len(l.entries)
+}
+
+// start begins loading key on a goroutine.
+func (l *Loader[T]) start(key string) *loaderEntry[T] {
+	e := &loaderEntry[T]{status: LoadPending}
+	l.entries[key] = e
+	go func() {
+		value, err := l.load(key)
+		l.dispatch(func() { l.finish(key, e, value, err) })
+	}()
+	return e
+}
+
+// finish stores a result on the UI thread, unless its entry was evicted meanwhile.
+func (l *Loader[T]) finish(key string, e *loaderEntry[T], value T, err error) {
+	if l.entries[key] != e {
+		if err == nil && l.OnEvict != nil {
+			l.OnEvict(key, value)
+		}
+		return
+	}
+	if err != nil {
+		e.status, e.err = LoadFailed, err
+	} else {
+		e.status, e.value = LoadReady, value
+	}
+	l.trim(key)
+}
+
+// trim evicts the least recently used finished values beyond MaxEntries, sparing keep.
+func (l *Loader[T]) trim(keep string) {
+	for l.MaxEntries > 0 && len(l.entries) > l.MaxEntries {
+		var oldest string
+		var oldestUse uint64
+		for key, e := range l.entries {
+			if key == keep || e.status == LoadPending {
+				continue
+			}
+			if oldest == "" || e.lastUse < oldestUse {
+				oldest, oldestUse = key, e.lastUse
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		l.Evict(oldest)
+	}
+}
+
+func (l *Loader[T]) evicted(key string, e *loaderEntry[T]) {
+	if e.status == LoadReady && l.OnEvict != nil {
+		l.OnEvict(key, e.value)
+	}
+}
+
+// NewTextureLoader creates a loader of PNG and JPEG images from files and http(s) URLs
+// into app textures. the textures belong to the loader: evicted textures are released.
+func NewTextureLoader(app *App) *Loader[*Texture] {
+	l := NewLoader(app.Dispatch, func(key string) (*Texture, error) {
+		data, err := ReadSource(key)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding image '%v'", key)
+		}
+		return app.Textures().Create(img), nil
+	})
+	l.OnEvict = func(_ string, t *Texture) { t.Release() }
+	return l
+}
+
+// ReadSource reads the contents of a file path or an http(s) URL.
+func ReadSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading '%v'", source)
+		}
+		return data, nil
+	}
+	client := &http.Client{Timeout: LoaderHTTPTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting '%v'", source)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("error requesting '%v': %v", source, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading '%v'", source)
+	}
+	return data, nil
+}
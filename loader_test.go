@@ -0,0 +1,166 @@
+package dfx
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testDispatcher collects dispatched functions for the test to run as the UI thread.
+type testDispatcher struct {
+	mu    sync.Mutex
+	queue []func()
+}
+
+func (d *testDispatcher) dispatch(f func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = append(d.queue, f)
+}
+
+// wait runs dispatched functions until n have run.
+func (d *testDispatcher) wait(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for n > 0 {
+		d.mu.Lock()
+		queue := d.queue
+		d.queue = nil
+		d.mu.Unlock()
+		for _, f := range queue {
+			f()
+			n--
+		}
+		if n > 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for loads")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestLoaderLoadsAndCaches(t *testing.T) {
+	d := &testDispatcher{}
+	var mu sync.Mutex
+	loads := map[string]int{}
+	l := NewLoader(d.dispatch, func(key string) (string, error) {
+		mu.Lock()
+		loads[key]++
+		mu.Unlock()
+		if key == "bad" {
+			return "", fmt.Errorf("no such thing")
+		}
+		return "value of " + key, nil
+	})
+
+	if _, status, _ := l.Get("a"); status != LoadPending {
+		t.Fatalf("expected pending, got %v", status)
+	}
+	l.Get("bad")
+	d.wait(t, 2)
+
+	if value, status, err := l.Get("a"); status != LoadReady || value != "value of a" || err != nil {
+		t.Fatalf("unexpected result %q/%v/%v", value, status, err)
+	}
+	if _, status, err := l.Get("bad"); status != LoadFailed || err == nil {
+		t.Fatalf("expected failure, got %v/%v", status, err)
+	}
+	if loads["a"] != 1 {
+		t.Fatalf("expected a single load, got %d", loads["a"])
+	}
+
+	l.Reload("a")
+	d.wait(t, 1)
+	if loads["a"] != 2 {
+		t.Fatalf("expected reload to load again, got %d", loads["a"])
+	}
+}
+
+func TestLoaderEviction(t *testing.T) {
+	d := &testDispatcher{}
+	l := NewLoader(d.dispatch, func(key string) (string, error) { return key, nil })
+	l.MaxEntries = 2
+	var evicted []string
+	l.OnEvict = func(key, value string) { evicted = append(evicted, key) }
+
+	l.Get("a")
+	l.Get("b")
+	d.wait(t, 2)
+	l.Get("a") // b is now the least recently used
+	l.Get("c")
+	d.wait(t, 1)
+	if len(evicted) != 1 || evicted[0] != "b" || l.Len() != 2 {
+		t.Fatalf("expected b evicted, got %v (%d cached)", evicted, l.Len())
+	}
+
+	// a load finishing after its key was evicted is discarded
+	l.Get("d")
+	l.Evict("d")
+	d.wait(t, 1)
+	if _, found := l.Status("d"); found {
+		t.Fatal("expected evicted load to be discarded")
+	}
+	if evicted[len(evicted)-1] != "d" {
+		t.Fatalf("expected discarded value to be evicted, got %v", evicted)
+	}
+
+	l.Clear()
+	if l.Len() != 0 {
+		t.Fatalf("expected empty loader, got %d", l.Len())
+	}
+}
+
+func TestReadSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/image.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("remote"))
+	}))
+	defer server.Close()
+
+	if data, err := ReadSource(server.URL + "/image.png"); err != nil || string(data) != "remote" {
+		t.Fatalf("unexpected remote read %q/%v", data, err)
+	}
+	if _, err := ReadSource(server.URL + "/missing.png"); err == nil {
+		t.Fatal("expected an error for a missing url")
+	}
+
+	path := filepath.Join(t.TempDir(), "image.png")
+	if err := os.WriteFile(path, []byte("local"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ReadSource(path); err != nil || string(data) != "local" {
+		t.Fatalf("unexpected local read %q/%v", data, err)
+	}
+}
+
+func TestAppDispatch(t *testing.T) {
+	app := New(nil, Config{})
+	var order []int
+	done := make(chan struct{})
+	go func() {
+		app.Dispatch(func() { order = append(order, 1) })
+		app.Dispatch(func() {
+			order = append(order, 2)
+			app.Dispatch(func() { order = append(order, 3) })
+		})
+		close(done)
+	}()
+	<-done
+	app.runDispatched()
+	if len(order) != 2 {
+		t.Fatalf("expected functions dispatched during a run to wait, got %v", order)
+	}
+	app.runDispatched()
+	if len(order) != 3 || order[2] != 3 {
+		t.Fatalf("unexpected order %v", order)
+	}
+}
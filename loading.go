@@ -0,0 +1,224 @@
+package dfx
+
+import (
+	"math"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// loading indicator constants
+const (
+	SpinnerPeriod       = 1 * time.Second         // time for one spinner revolution
+	ProgressSweepPeriod = 1500 * time.Millisecond // time for the progress bar segment to cross the bar
+	SkeletonPulsePeriod = 1200 * time.Millisecond // time for one skeleton fade in and out
+
+	spinnerArc            = 1.5 * math.Pi
+	spinnerThickness      = 2
+	progressSegment       = 0.3  // share of the bar the moving segment covers
+	skeletonMinAlpha      = 0.45 // skeleton fill alpha at the dimmest point of the pulse
+	skeletonLastLineWidth = 0.6  // width of the last SkeletonText line, as a share of the others
+)
+
+// Spinner is an animated circular loading indicator, drawn in the theme accent color.
+type Spinner struct {
+	Container
+	Radius    float32    // radius in pixels (0 = half the text line height)
+	Thickness float32    // stroke thickness (0 = 2px)
+	Color     imgui.Vec4 // stroke color (zero = theme accent)
+	Label     string     // optional text shown beside the spinner
+}
+
+// NewSpinner creates a spinner sized to the text.
+func NewSpinner() *Spinner {
+	return &Spinner{Container: Container{Visible: true}}
+}
+
+// Draw implements Component.
+func (s *Spinner) Draw(state *State) {
+	if !s.Visible {
+		return
+	}
+	radius := s.Radius
+	if radius <= 0 {
+		radius = spinnerRadius()
+	}
+	thickness := s.Thickness
+	if thickness <= 0 {
+		thickness = spinnerThickness
+	}
+	drawSpinner(state.Clock(), radius, thickness, themeColor(s.Color, ThemeColors().Accent))
+	if s.Label != "" {
+		imgui.SameLine()
+		imgui.AlignTextToFramePadding()
+		imgui.TextUnformatted(s.Label)
+	}
+	drawContainerExtensions(&s.Container, state)
+}
+
+// DrawSpinner draws a spinner of radius pixels at the cursor, for immediate-mode use.
+func DrawSpinner(radius float32) {
+	drawSpinner(time.Now(), radius, spinnerThickness, ThemeColors().Accent)
+}
+
+func drawSpinner(now time.Time, radius, thickness float32, color imgui.Vec4) {
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(imgui.Vec2{X: radius * 2, Y: radius * 2})
+	center := pos.Add(imgui.Vec2{X: radius, Y: radius})
+	start := loadingPhase(now, SpinnerPeriod) * 2 * math.Pi
+	drawList := imgui.WindowDrawList()
+	drawList.PathArcToV(center, radius-thickness, start, start+spinnerArc, 24)
+	drawList.PathStrokeV(imgui.ColorConvertFloat4ToU32(color), imgui.DrawFlagsNone, thickness)
+}
+
+// spinnerRadius is the default spinner radius, scaled with the font.
+func spinnerRadius() float32 {
+	return imgui.TextLineHeight() / 2
+}
+
+// IndeterminateProgressBar shows that work is under way without knowing how much is left:
+// a segment in the theme accent color sweeps across a frame-colored track.
+type IndeterminateProgressBar struct {
+	Container
+	Width  float32    // bar width (0 = available width)
+	Height float32    // bar height (0 = a third of the text line height)
+	Color  imgui.Vec4 // segment color (zero = theme accent)
+}
+
+// NewIndeterminateProgressBar creates a full-width indeterminate progress bar.
+func NewIndeterminateProgressBar() *IndeterminateProgressBar {
+	return &IndeterminateProgressBar{Container: Container{Visible: true}}
+}
+
+// Draw implements Component.
+func (p *IndeterminateProgressBar) Draw(state *State) {
+	if !p.Visible {
+		return
+	}
+	size := imgui.Vec2{X: p.Width, Y: p.Height}
+	if size.X <= 0 {
+		size.X = imgui.ContentRegionAvail().X
+	}
+	if size.Y <= 0 {
+		size.Y = max(imgui.TextLineHeight()/3, 2)
+	}
+	drawIndeterminateProgress(state.Clock(), size, themeColor(p.Color, ThemeColors().Accent))
+	drawContainerExtensions(&p.Container, state)
+}
+
+// DrawIndeterminateProgressBar draws an indeterminate progress bar of size at the cursor,
+// for immediate-mode use.
+func DrawIndeterminateProgressBar(size imgui.Vec2) {
+	drawIndeterminateProgress(time.Now(), size, ThemeColors().Accent)
+}
+
+func drawIndeterminateProgress(now time.Time, size imgui.Vec2, color imgui.Vec4) {
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+	rounding := size.Y / 2
+	drawList := imgui.WindowDrawList()
+	drawList.AddRectFilledV(pos, pos.Add(size), imgui.ColorU32Col(imgui.ColFrameBg), rounding, imgui.DrawFlagsNone)
+	from, to := progressSegmentSpan(loadingPhase(now, ProgressSweepPeriod))
+	if to > from {
+		drawList.AddRectFilledV(
+			imgui.Vec2{X: pos.X + from*size.X, Y: pos.Y},
+			imgui.Vec2{X: pos.X + to*size.X, Y: pos.Y + size.Y},
+			imgui.ColorConvertFloat4ToU32(color), rounding, imgui.DrawFlagsNone)
+	}
+}
+
+// progressSegmentSpan returns the part of the bar (0..1) the moving segment covers at
+// phase; the segment enters at the left and leaves at the right.
+func progressSegmentSpan(phase float32) (float32, float32) {
+	head := phase * (1 + progressSegment)
+	return max(head-progressSegment, 0), min(head, 1)
+}
+
+// SkeletonText stands in for text that is still loading: pulsing bars one text line
+// high, the last one shorter.
+type SkeletonText struct {
+	Container
+	Lines int     // number of lines (0 = 1)
+	Width float32 // line width (0 = available width)
+}
+
+// NewSkeletonText creates a skeleton of lines lines of text.
+func NewSkeletonText(lines int) *SkeletonText {
+	return &SkeletonText{Container: Container{Visible: true}, Lines: lines}
+}
+
+// Draw implements Component.
+func (st *SkeletonText) Draw(state *State) {
+	if !st.Visible {
+		return
+	}
+	width := st.Width
+	if width <= 0 {
+		width = imgui.ContentRegionAvail().X
+	}
+	lines := max(st.Lines, 1)
+	height := imgui.TextLineHeight() * 0.75
+	gap := imgui.TextLineHeightWithSpacing() - height
+	alpha := skeletonAlpha(state.Clock())
+	for i := range lines {
+		lineWidth := width
+		if lines > 1 && i == lines-1 {
+			lineWidth *= skeletonLastLineWidth
+		}
+		drawSkeleton(imgui.Vec2{X: lineWidth, Y: height}, height/3, alpha)
+		imgui.Dummy(imgui.Vec2{Y: gap - imgui.CurrentStyle().ItemSpacing().Y})
+	}
+	drawContainerExtensions(&st.Container, state)
+}
+
+// SkeletonBlock stands in for an image, card or other block that is still loading.
+type SkeletonBlock struct {
+	Container
+	Size     imgui.Vec2 // block size (0 = available space, per axis)
+	Rounding float32    // corner rounding (0 = the theme frame rounding)
+}
+
+// NewSkeletonBlock creates a skeleton block of size.
+func NewSkeletonBlock(size imgui.Vec2) *SkeletonBlock {
+	return &SkeletonBlock{Container: Container{Visible: true}, Size: size}
+}
+
+// Draw implements Component.
+func (sb *SkeletonBlock) Draw(state *State) {
+	if !sb.Visible {
+		return
+	}
+	size := sb.Size
+	avail := imgui.ContentRegionAvail()
+	if size.X <= 0 {
+		size.X = avail.X
+	}
+	if size.Y <= 0 {
+		size.Y = avail.Y
+	}
+	rounding := sb.Rounding
+	if rounding <= 0 {
+		rounding = imgui.CurrentStyle().FrameRounding()
+	}
+	drawSkeleton(size, rounding, skeletonAlpha(state.Clock()))
+	drawContainerExtensions(&sb.Container, state)
+}
+
+// drawSkeleton draws a frame-colored placeholder rectangle at the cursor.
+func drawSkeleton(size imgui.Vec2, rounding, alpha float32) {
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+	imgui.WindowDrawList().AddRectFilledV(pos, pos.Add(size), imgui.ColorU32ColV(imgui.ColFrameBg, alpha), rounding, imgui.DrawFlagsNone)
+}
+
+// skeletonAlpha returns the skeleton fill alpha at now, pulsing smoothly between
+// skeletonMinAlpha and 1.
+func skeletonAlpha(now time.Time) float32 {
+	wave := (1 - float32(math.Cos(float64(loadingPhase(now, SkeletonPulsePeriod))*2*math.Pi))) / 2
+	return skeletonMinAlpha + (1-skeletonMinAlpha)*wave
+}
+
+// loadingPhase returns how far through a period (0..1) now is.
+func loadingPhase(now time.Time, period time.Duration) float32 {
+	return float32(now.UnixNano()%int64(period)) / float32(period)
+}
@@ -0,0 +1,44 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLoadingPhase(t *testing.T) {
+	base := time.Unix(100, 0)
+	if phase := loadingPhase(base, time.Second); phase != 0 {
+		t.Fatalf("expected phase 0 on a period boundary, got %v", phase)
+	}
+	if phase := loadingPhase(base.Add(250*time.Millisecond), time.Second); phase != 0.25 {
+		t.Fatalf("expected phase 0.25, got %v", phase)
+	}
+	if phase := loadingPhase(base.Add(1750*time.Millisecond), time.Second); phase != 0.75 {
+		t.Fatalf("expected phase to wrap to 0.75, got %v", phase)
+	}
+}
+
+func TestProgressSegmentSpan(t *testing.T) {
+	cases := []struct{ phase, from, to float32 }{
+		{0, 0, 0},
+		{0.5, 0.35, 0.65},
+		{1, 1, 1},
+	}
+	for _, c := range cases {
+		from, to := progressSegmentSpan(c.phase)
+		if math.Abs(float64(from-c.from)) > 1e-5 || math.Abs(float64(to-c.to)) > 1e-5 {
+			t.Fatalf("phase %v: expected %v-%v, got %v-%v", c.phase, c.from, c.to, from, to)
+		}
+	}
+}
+
+func TestSkeletonAlpha(t *testing.T) {
+	base := time.Unix(0, 0).Add(100 * SkeletonPulsePeriod)
+	if alpha := skeletonAlpha(base); math.Abs(float64(alpha-skeletonMinAlpha)) > 1e-5 {
+		t.Fatalf("expected minimum alpha at the start of the pulse, got %v", alpha)
+	}
+	if alpha := skeletonAlpha(base.Add(SkeletonPulsePeriod / 2)); math.Abs(float64(alpha-1)) > 1e-5 {
+		t.Fatalf("expected full alpha halfway through the pulse, got %v", alpha)
+	}
+}
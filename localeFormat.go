@@ -0,0 +1,200 @@
+package dfx
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LocaleFormat describes how a locale writes numbers, dates and times. empty fields use
+// the DefaultLocale conventions.
+type LocaleFormat struct {
+	Decimal string // decimal separator ("" = ".")
+	Group   string // thousands separator ("" = no grouping)
+	Date    string // date layout, as for time.Format ("" = "2006-01-02")
+	Time    string // time of day layout ("" = "15:04")
+}
+
+// locale format defaults
+const (
+	defaultDecimal    = "."
+	defaultDateLayout = "2006-01-02"
+	defaultTimeLayout = "15:04"
+)
+
+// number and date formats by locale, looked up with the same fallbacks as T
+var (
+	formatMu       sync.RWMutex
+	formatOverride *LocaleFormat
+	formats        = map[string]LocaleFormat{
+		"en":    {Decimal: ".", Group: ",", Date: "01/02/2006", Time: "3:04 PM"},
+		"en-GB": {Decimal: ".", Group: ",", Date: "02/01/2006", Time: "15:04"},
+		"de":    {Decimal: ",", Group: ".", Date: "02.01.2006", Time: "15:04"},
+		"de-CH": {Decimal: ".", Group: "'", Date: "02.01.2006", Time: "15:04"},
+		"fr":    {Decimal: ",", Group: " ", Date: "02/01/2006", Time: "15:04"},
+		"es":    {Decimal: ",", Group: ".", Date: "02/01/2006", Time: "15:04"},
+		"it":    {Decimal: ",", Group: ".", Date: "02/01/2006", Time: "15:04"},
+		"pt":    {Decimal: ",", Group: ".", Date: "02/01/2006", Time: "15:04"},
+		"nl":    {Decimal: ",", Group: ".", Date: "02-01-2006", Time: "15:04"},
+		"pl":    {Decimal: ",", Group: " ", Date: "02.01.2006", Time: "15:04"},
+		"ru":    {Decimal: ",", Group: " ", Date: "02.01.2006", Time: "15:04"},
+		"sv":    {Decimal: ",", Group: " ", Date: "2006-01-02", Time: "15:04"},
+		"ja":    {Decimal: ".", Group: ",", Date: "2006/01/02", Time: "15:04"},
+		"zh":    {Decimal: ".", Group: ",", Date: "2006/01/02", Time: "15:04"},
+		"ko":    {Decimal: ".", Group: ",", Date: "2006.01.02", Time: "15:04"},
+	}
+)
+
+// RegisterFormat sets the number and date format of a locale, replacing any built-in one.
+func RegisterFormat(loc string, f LocaleFormat) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+	formats[loc] = f
+}
+
+// FormatFor returns the format of a locale, falling back to its language and then to
+// DefaultLocale.
+func FormatFor(loc string) LocaleFormat {
+	formatMu.RLock()
+	defer formatMu.RUnlock()
+	fallbacks, n := localeFallbacks(loc)
+	for _, candidate := range fallbacks[:n] {
+		if f, found := formats[candidate]; found {
+			return f
+		}
+	}
+	return LocaleFormat{}
+}
+
+// SetFormat overrides the format controls use, whatever the locale; nil follows the
+// locale again. Config.Format sets it for an app.
+func SetFormat(f *LocaleFormat) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+	formatOverride = f
+}
+
+// CurrentFormat returns the format controls use by default: the SetFormat override, or
+// the format of the current locale.
+func CurrentFormat() LocaleFormat {
+	formatMu.RLock()
+	override := formatOverride
+	formatMu.RUnlock()
+	if override != nil {
+		return *override
+	}
+	return FormatFor(Locale())
+}
+
+// FormatFloat formats v with precision decimal places, grouping thousands.
+//
+//	dfx.FormatFor("de").FormatFloat(1234.5, 2) // "1.234,50"
+func (f LocaleFormat) FormatFloat(v float64, precision int) string {
+	text := strconv.FormatFloat(v, 'f', max(precision, 0), 64)
+	if text == "NaN" || strings.HasSuffix(text, "Inf") {
+		return text
+	}
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(text, ".")
+	text = sign + groupDigits(integer, f.Group)
+	if hasFraction {
+		text += f.decimal() + fraction
+	}
+	return text
+}
+
+// FormatInt formats v, grouping thousands.
+func (f LocaleFormat) FormatInt(v int64) string {
+	return f.FormatFloat(float64(v), 0)
+}
+
+// groupDigits inserts sep between groups of three digits.
+func groupDigits(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// Canonical rewrites text written in this format with Go's conventions: thousands
+// separators removed and "." as the decimal separator, ready for strconv or ParseNumber.
+func (f LocaleFormat) Canonical(text string) string {
+	if f.Group != "" {
+		text = strings.ReplaceAll(text, f.Group, "")
+	}
+	if decimal := f.decimal(); decimal != defaultDecimal {
+		text = strings.ReplaceAll(text, decimal, defaultDecimal)
+	}
+	return text
+}
+
+// ParseFloat parses a number written in this format.
+func (f LocaleFormat) ParseFloat(text string) (float64, error) {
+	v, err := strconv.ParseFloat(f.Canonical(strings.TrimSpace(text)), 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error parsing number '%v'", text)
+	}
+	return v, nil
+}
+
+// FormatDate formats the date of t.
+func (f LocaleFormat) FormatDate(t time.Time) string {
+	return t.Format(f.dateLayout())
+}
+
+// FormatTime formats the time of day of t.
+func (f LocaleFormat) FormatTime(t time.Time) string {
+	return t.Format(f.timeLayout())
+}
+
+// FormatDateTime formats the date and time of day of t.
+func (f LocaleFormat) FormatDateTime(t time.Time) string {
+	return t.Format(f.dateLayout() + " " + f.timeLayout())
+}
+
+// ParseDate parses a date written in this format, in the local time zone.
+func (f LocaleFormat) ParseDate(text string) (time.Time, error) {
+	t, err := time.ParseInLocation(f.dateLayout(), strings.TrimSpace(text), time.Local)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "error parsing date '%v'", text)
+	}
+	return t, nil
+}
+
+func (f LocaleFormat) decimal() string {
+	if f.Decimal == "" {
+		return defaultDecimal
+	}
+	return f.Decimal
+}
+
+func (f LocaleFormat) dateLayout() string {
+	if f.Date == "" {
+		return defaultDateLayout
+	}
+	return f.Date
+}
+
+func (f LocaleFormat) timeLayout() string {
+	if f.Time == "" {
+		return defaultTimeLayout
+	}
+	return f.Time
+}
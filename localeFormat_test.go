@@ -0,0 +1,90 @@
+package dfx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		loc       string
+		value     float64
+		precision int
+		expected  string
+	}{
+		{"en", 1234567.891, 2, "1,234,567.89"},
+		{"en", -1234.5, 1, "-1,234.5"},
+		{"en", 999, 0, "999"},
+		{"de", 1234.5, 2, "1.234,50"},
+		{"de-AT", 0.25, 3, "0,250"},
+		{"fr-FR", 12345, 0, "12 345"},
+		{"de-CH", 1234.5, 1, "1'234.5"},
+		{"xx", 1234.5, 1, "1,234.5"},
+	}
+	for _, tt := range tests {
+		if got := FormatFor(tt.loc).FormatFloat(tt.value, tt.precision); got != tt.expected {
+			t.Errorf("%v: FormatFloat(%v, %d) = %q, expected %q", tt.loc, tt.value, tt.precision, got, tt.expected)
+		}
+	}
+	if got := (LocaleFormat{}).FormatInt(-1234567); got != "-1234567" {
+		t.Errorf("expected no grouping without a separator, got %q", got)
+	}
+}
+
+func TestParseLocaleFloat(t *testing.T) {
+	de := FormatFor("de")
+	v, err := de.ParseFloat(" 1.234,5 ")
+	if err != nil || v != 1234.5 {
+		t.Errorf("expected 1234.5, got %v (%v)", v, err)
+	}
+	if _, err := de.ParseFloat("abc"); err == nil {
+		t.Error("expected an error for text that is not a number")
+	}
+	if got := FormatFor("fr").Canonical("1 500,25 ms"); got != "1500.25ms" {
+		t.Errorf("expected '1500.25ms', got %q", got)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	when := time.Date(2026, 3, 14, 15, 9, 0, 0, time.Local)
+	tests := map[string]string{
+		"en":    "03/14/2026 3:09 PM",
+		"en-GB": "14/03/2026 15:09",
+		"de":    "14.03.2026 15:09",
+		"ja":    "2026/03/14 15:09",
+	}
+	for loc, expected := range tests {
+		if got := FormatFor(loc).FormatDateTime(when); got != expected {
+			t.Errorf("%v: expected %q, got %q", loc, expected, got)
+		}
+	}
+	parsed, err := FormatFor("de").ParseDate("14.03.2026")
+	if err != nil || !parsed.Equal(time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("expected March 14, got %v (%v)", parsed, err)
+	}
+	if got := (LocaleFormat{}).FormatDate(when); got != "2026-03-14" {
+		t.Errorf("expected the default layout, got %q", got)
+	}
+}
+
+func TestCurrentFormat(t *testing.T) {
+	withLocale(t)
+	t.Cleanup(func() { SetFormat(nil) })
+
+	SetLocale("de")
+	if got := CurrentFormat().Decimal; got != "," {
+		t.Errorf("expected the locale decimal ',', got %q", got)
+	}
+	SetFormat(&LocaleFormat{Decimal: "."})
+	if got := CurrentFormat().Decimal; got != "." {
+		t.Errorf("expected the override decimal '.', got %q", got)
+	}
+	SetFormat(nil)
+
+	if got := FormatNumber(1234.5, NumberOptions{Precision: 1, Unit: "Hz"}); got != "1.234,5 Hz" {
+		t.Errorf("expected '1.234,5 Hz', got %q", got)
+	}
+	if got := FormatNumber(1234.5, NumberOptions{Precision: 1, Format: &LocaleFormat{}}); got != "1234.5" {
+		t.Errorf("expected the per-control format, got %q", got)
+	}
+}
@@ -0,0 +1,205 @@
+package dfx
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// log field constants
+const (
+	LogCollapsedValueLength = 40 // field values longer than this are truncated until the entry is expanded
+	LogExpandFieldsLength   = 80 // entries whose fields are longer than this are expandable
+
+	logChipPadding  = 3
+	logChipSpacing  = 4
+	logChipRounding = 3
+	logChipAlpha    = 0.12
+)
+
+// logFieldKind classifies a field value for coloring.
+type logFieldKind int
+
+const (
+	logFieldString logFieldKind = iota
+	logFieldNumber
+	logFieldBool
+	logFieldNull
+	logFieldObject
+)
+
+// logField is one key/value pair from a message's JSON fields.
+type logField struct {
+	key   string
+	value string // display form: strings unquoted, everything else as compact JSON
+	kind  logFieldKind
+}
+
+// parseLogFields parses a JSON object of fields, keeping the key order. returns nil if
+// fields is not a JSON object.
+func parseLogFields(fields string) []logField {
+	dec := json.NewDecoder(strings.NewReader(fields))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	var parsed []logField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		key, _ := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil
+		}
+		parsed = append(parsed, newLogField(key, raw))
+	}
+	return parsed
+}
+
+func newLogField(key string, raw json.RawMessage) logField {
+	f := logField{key: key, value: string(raw)}
+	switch raw[0] {
+	case '"':
+		f.kind = logFieldString
+		if s, err := strconv.Unquote(string(raw)); err == nil {
+			f.value = s
+		}
+	case 't', 'f':
+		f.kind = logFieldBool
+	case 'n':
+		f.kind = logFieldNull
+	case '{', '[':
+		f.kind = logFieldObject
+		var compact bytes.Buffer
+		if json.Compact(&compact, raw) == nil {
+			f.value = compact.String()
+		}
+	default:
+		f.kind = logFieldNumber
+	}
+	return f
+}
+
+// fieldsOf returns the parsed fields of msg, caching them by sequence number.
+func (lv *LogViewer) fieldsOf(msg *LogMessage) []logField {
+	if msg.Fields == "" {
+		return nil
+	}
+	if lv.fieldCache == nil {
+		lv.fieldCache = make(map[uint64][]logField)
+	}
+	fields, found := lv.fieldCache[msg.seq]
+	if !found {
+		fields = parseLogFields(msg.Fields)
+		lv.fieldCache[msg.seq] = fields
+	}
+	return fields
+}
+
+// expandable reports whether msg has more to show than fits on one collapsed line.
+func (lv *LogViewer) expandable(msg *LogMessage) bool {
+	if strings.Contains(msg.Message, "\n") {
+		return true
+	}
+	return lv.ShowFields && len(msg.Fields) > LogExpandFieldsLength
+}
+
+// ExpandSelection expands the selected entries.
+func (lv *LogViewer) ExpandSelection() {
+	if lv.expanded == nil {
+		lv.expanded = make(map[uint64]bool)
+	}
+	for seq := range lv.selected {
+		lv.expanded[seq] = true
+	}
+}
+
+// CollapseAll collapses every expanded entry.
+func (lv *LogViewer) CollapseAll() {
+	clear(lv.expanded)
+}
+
+// toggleExpanded expands or collapses the entry with sequence number seq.
+func (lv *LogViewer) toggleExpanded(seq uint64) {
+	if lv.expanded == nil {
+		lv.expanded = make(map[uint64]bool)
+	}
+	if lv.expanded[seq] {
+		delete(lv.expanded, seq)
+	} else {
+		lv.expanded[seq] = true
+	}
+}
+
+// drawExpandMarker draws the clickable marker ending a collapsed expandable entry.
+func (lv *LogViewer) drawExpandMarker(seq uint64) {
+	pos := imgui.CursorScreenPos()
+	imgui.TextColored(lv.colors.fieldKey, " [+]")
+	size := imgui.ItemRectSize()
+	imgui.SetCursorScreenPos(pos)
+	if imgui.InvisibleButton("##expand", size) {
+		lv.toggleExpanded(seq)
+	}
+	imgui.SetItemTooltip(T("dfx.log.expandTip"))
+}
+
+// fieldColor returns the color for values of kind.
+func (lv *LogViewer) fieldColor(kind logFieldKind) imgui.Vec4 {
+	switch kind {
+	case logFieldNumber:
+		return lv.colors.number
+	case logFieldBool:
+		return lv.colors.boolean
+	case logFieldNull:
+		return lv.colors.fieldKey
+	default:
+		return lv.colors.fields
+	}
+}
+
+// drawFieldChips renders fields inline as key=value chips with long values truncated.
+func (lv *LogViewer) drawFieldChips(fields []logField) {
+	dl := imgui.WindowDrawList()
+	for _, f := range fields {
+		value := f.value
+		if len(value) > LogCollapsedValueLength {
+			value = value[:LogCollapsedValueLength] + "..."
+		}
+		key := f.key + "="
+		valueColor := lv.fieldColor(f.kind)
+
+		imgui.SameLineV(0, logChipSpacing)
+		pos := imgui.CursorScreenPos()
+		size := imgui.CalcTextSize(key + value).Add(imgui.Vec2{X: 2 * logChipPadding})
+		background := valueColor
+		background.W = logChipAlpha
+		dl.AddRectFilledV(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(background), logChipRounding, imgui.DrawFlagsNone)
+
+		imgui.SetCursorScreenPos(pos.Add(imgui.Vec2{X: logChipPadding}))
+		imgui.TextColored(lv.colors.fieldKey, key)
+		lv.highlightMatches(key)
+		imgui.SameLineV(0, 0)
+		imgui.TextColored(valueColor, value)
+		lv.highlightMatches(value)
+		imgui.SameLineV(0, logChipPadding)
+		imgui.Dummy(imgui.Vec2{})
+	}
+}
+
+// drawExpandedFields renders fields one per line below an expanded entry.
+func (lv *LogViewer) drawExpandedFields(fields []logField, indent float32) {
+	for _, f := range fields {
+		imgui.SetCursorPosX(indent)
+		imgui.TextColored(lv.colors.fieldKey, f.key+":")
+		imgui.SameLineV(0, logChipSpacing)
+		imgui.PushTextWrapPos()
+		imgui.TextColored(lv.fieldColor(f.kind), f.value)
+		imgui.PopTextWrapPos()
+		lv.highlightMatches(f.value)
+	}
+}
@@ -0,0 +1,92 @@
+package dfx
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// log follow constants
+const (
+	logPillMargin = 12
+)
+
+// Paused reports whether following is paused because the view was scrolled away from
+// the newest messages.
+func (lv *LogViewer) Paused() bool {
+	return lv.paused
+}
+
+// Follow resumes following and scrolls to the newest message.
+func (lv *LogViewer) Follow() {
+	lv.paused = false
+}
+
+// pause stops following until the view returns to the newest messages.
+func (lv *LogViewer) pause() {
+	if lv.paused || lv.Buffer == nil {
+		return
+	}
+	lv.paused = true
+	lv.pausedSeq = lv.Buffer.nextSeq()
+}
+
+// trackScroll updates follow state from the current scroll position and reports whether
+// the view should scroll to the newest message. scrolling up pauses following; scrolling
+// back down to the bottom resumes it.
+func (lv *LogViewer) trackScroll(scrollY, maxY float32) bool {
+	if !lv.AutoScroll {
+		lv.lastScrollY = scrollY
+		return false
+	}
+	switch {
+	case lv.paused && scrollY >= maxY && scrollY > lv.lastScrollY:
+		lv.paused = false
+	case !lv.paused && scrollY < lv.lastScrollY && scrollY < maxY:
+		lv.pause()
+	}
+	lv.lastScrollY = scrollY
+	return !lv.paused
+}
+
+// newMessages returns the number of displayed lines added since following paused.
+func (lv *LogViewer) newMessages() int {
+	n := len(lv.rows)
+	first := sort.Search(n, func(row int) bool {
+		return lv.rowSeq(row) >= lv.pausedSeq
+	})
+	return n - first
+}
+
+// drawFollowPill draws the button offering to jump back to new messages while following
+// is paused. drawn last inside the log child so it sits above the lines.
+func (lv *LogViewer) drawFollowPill() {
+	if !lv.AutoScroll || !lv.paused {
+		return
+	}
+	count := lv.newMessages()
+	if count == 0 {
+		return
+	}
+	label := fmt.Sprintf("%v %d new messages", fonts.ICON_ARROW_DOWNWARD, count)
+	if count == 1 {
+		label = fonts.ICON_ARROW_DOWNWARD + " 1 new message"
+	}
+
+	style := imgui.CurrentStyle()
+	size := imgui.CalcTextSize(label).Add(style.FramePadding().Mul(2))
+	pos := imgui.WindowPos().Add(imgui.Vec2{
+		X: (imgui.WindowWidth() - size.X) / 2,
+		Y: imgui.WindowHeight() - size.Y - logPillMargin,
+	})
+	imgui.SetCursorScreenPos(pos)
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameRounding, size.Y/2)
+	imgui.PushStyleColorVec4(imgui.ColButton, style.Colors()[imgui.ColButtonActive])
+	if imgui.Button(label) {
+		lv.Follow()
+	}
+	imgui.PopStyleColor()
+	imgui.PopStyleVar()
+}
@@ -0,0 +1,301 @@
+package dfx
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// log search constants
+const (
+	logSearchWidth       = 240
+	logDimmedAlpha       = 0.4
+	logFocusSearchKeys   = "Ctrl+F"
+	logNextMatchKeys     = "F3"
+	logPreviousMatchKeys = "Shift+F3"
+)
+
+// logMatcher finds search matches in log text.
+type logMatcher struct {
+	re    *regexp.Regexp
+	text  string
+	match bool // case-sensitive plain text
+}
+
+// newLogMatcher compiles a search. returns nil for an empty search.
+func newLogMatcher(search string, regex, matchCase bool) (*logMatcher, error) {
+	if search == "" {
+		return nil, nil
+	}
+	if regex {
+		pattern := search
+		if !matchCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return &logMatcher{re: re}, nil
+	}
+	if !matchCase {
+		search = strings.ToLower(search)
+	}
+	return &logMatcher{text: search, match: matchCase}, nil
+}
+
+// matches reports whether s contains a match.
+func (m *logMatcher) matches(s string) bool {
+	if m.re != nil {
+		return m.re.MatchString(s)
+	}
+	if !m.match {
+		s = strings.ToLower(s)
+	}
+	return strings.Contains(s, m.text)
+}
+
+// find returns the byte ranges of the matches in s.
+func (m *logMatcher) find(s string) [][2]int {
+	var ranges [][2]int
+	if m.re != nil {
+		for _, loc := range m.re.FindAllStringIndex(s, -1) {
+			if loc[1] > loc[0] {
+				ranges = append(ranges, [2]int{loc[0], loc[1]})
+			}
+		}
+		return ranges
+	}
+	haystack := s
+	if !m.match {
+		haystack = strings.ToLower(s)
+	}
+	// lowercasing can change byte lengths for some scripts; fall back to no highlight
+	if len(haystack) != len(s) {
+		return nil
+	}
+	for offset := 0; ; {
+		i := strings.Index(haystack[offset:], m.text)
+		if i < 0 {
+			return ranges
+		}
+		start := offset + i
+		ranges = append(ranges, [2]int{start, start + len(m.text)})
+		offset = start + len(m.text)
+	}
+}
+
+// logRowsKey captures the inputs the displayed rows depend on.
+type logRowsKey struct {
+	changes    uint64
+	level      slog.Level
+	search     string
+	regex      bool
+	matchCase  bool
+	hide       bool
+	showFunc   bool
+	showFields bool
+	filters    uint64
+}
+
+// matchesMessage reports whether the visible parts of msg match the search.
+func (lv *LogViewer) matchesMessage(msg *LogMessage) bool {
+	if lv.matcher.matches(msg.Message) {
+		return true
+	}
+	if lv.ShowFunc && lv.matcher.matches(msg.Func) {
+		return true
+	}
+	return lv.ShowFields && lv.matcher.matches(msg.Fields)
+}
+
+// updateRows recomputes the displayed rows and search matches when the buffer or the
+// filters have changed.
+func (lv *LogViewer) updateRows() {
+	key := logRowsKey{
+		changes:    lv.Buffer.changes(),
+		level:      lv.LevelFilter,
+		search:     lv.Search,
+		regex:      lv.SearchRegex,
+		matchCase:  lv.SearchMatchCase,
+		hide:       lv.HideNonMatching,
+		showFunc:   lv.ShowFunc,
+		showFields: lv.ShowFields,
+		filters:    lv.filterChanges,
+	}
+	if key == lv.rowsKey && lv.rows != nil {
+		return
+	}
+	searchChanged := key.search != lv.rowsKey.search || key.regex != lv.rowsKey.regex || key.matchCase != lv.rowsKey.matchCase
+	lv.rowsKey = key
+
+	lv.matcher, lv.matchErr = newLogMatcher(lv.Search, lv.SearchRegex, lv.SearchMatchCase)
+
+	lv.rows = lv.rows[:0]
+	lv.rowMatches = lv.rowMatches[:0]
+	lv.matches = lv.matches[:0]
+	if lv.rows == nil {
+		lv.rows = []int{}
+	}
+	discovered := make(map[string]bool, len(lv.sources))
+	for _, source := range lv.sources {
+		discovered[source] = true
+	}
+	lv.Buffer.Range(func(index int, msg *LogMessage) bool {
+		source := logSource(msg)
+		if !discovered[source] {
+			discovered[source] = true
+			lv.sources = append(lv.sources, source)
+		}
+		if !lv.showMessage(msg, source) {
+			return true
+		}
+		matched := lv.matcher != nil && lv.matchesMessage(msg)
+		if lv.matcher != nil && lv.HideNonMatching && !matched {
+			return true
+		}
+		if matched {
+			lv.matches = append(lv.matches, len(lv.rows))
+		}
+		lv.rows = append(lv.rows, index)
+		lv.rowMatches = append(lv.rowMatches, matched)
+		return true
+	})
+
+	sort.Strings(lv.sources)
+	lv.pruneEntries()
+
+	if searchChanged || lv.current >= len(lv.matches) {
+		lv.current = -1
+	}
+}
+
+// NextMatch moves to the next line matching the search, wrapping around.
+func (lv *LogViewer) NextMatch() {
+	lv.stepMatch(1)
+}
+
+// PreviousMatch moves to the previous line matching the search, wrapping around.
+func (lv *LogViewer) PreviousMatch() {
+	lv.stepMatch(-1)
+}
+
+// MatchCount returns the number of displayed lines matching the search.
+func (lv *LogViewer) MatchCount() int {
+	return len(lv.matches)
+}
+
+// FocusSearch shows the search bar and gives it keyboard focus.
+func (lv *LogViewer) FocusSearch() {
+	lv.ShowSearchBar = true
+	lv.focusSearch = true
+}
+
+func (lv *LogViewer) stepMatch(dir int) {
+	n := len(lv.matches)
+	if n == 0 {
+		return
+	}
+	if lv.current < 0 {
+		if dir > 0 {
+			lv.current = 0
+		} else {
+			lv.current = n - 1
+		}
+	} else {
+		lv.current = ((lv.current+dir)%n + n) % n
+	}
+	lv.scrollToRow = lv.matches[lv.current]
+	lv.pause()
+}
+
+// registerSearchActions adds the search keyboard shortcuts.
+func (lv *LogViewer) registerSearchActions() {
+	actions := lv.Container.Actions()
+	actions.MustRegister("Find in Log", logFocusSearchKeys, lv.FocusSearch)
+	actions.MustRegister("Next Match", logNextMatchKeys, lv.NextMatch)
+	actions.MustRegister("Previous Match", logPreviousMatchKeys, lv.PreviousMatch)
+}
+
+// drawSearchBar renders the search input, options and match navigation.
+func (lv *LogViewer) drawSearchBar() {
+	imgui.PushIDStr("##logSearch")
+	defer imgui.PopID()
+
+	imgui.AlignTextToFramePadding()
+	imgui.TextUnformatted(fonts.ICON_SEARCH)
+	imgui.SameLine()
+
+	if lv.focusSearch {
+		imgui.SetKeyboardFocusHere()
+		lv.focusSearch = false
+	}
+	imgui.SetNextItemWidth(logSearchWidth)
+	imgui.InputTextWithHint("##search", T("dfx.log.search"), &lv.Search, imgui.InputTextFlagsNone, nil)
+	if imgui.IsItemFocused() && (imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter)) {
+		if imgui.CurrentIO().KeyShift() {
+			lv.PreviousMatch()
+		} else {
+			lv.NextMatch()
+		}
+	}
+
+	imgui.SameLine()
+	IconToggle(".*", "", "regular expression", &lv.SearchRegex)
+	imgui.SameLine()
+	IconToggle("Aa", "", "match case", &lv.SearchMatchCase)
+	imgui.SameLine()
+	IconToggle(fonts.ICON_FILTER_LIST, "", "hide non-matching lines", &lv.HideNonMatching)
+
+	imgui.SameLine()
+	if IconButton(fonts.ICON_KEYBOARD_ARROW_UP, "", "previous match ("+logPreviousMatchKeys+")") {
+		lv.PreviousMatch()
+	}
+	imgui.SameLine()
+	if IconButton(fonts.ICON_KEYBOARD_ARROW_DOWN, "", "next match ("+logNextMatchKeys+")") {
+		lv.NextMatch()
+	}
+
+	imgui.SameLine()
+	switch {
+	case lv.matchErr != nil:
+		imgui.TextColored(ThemeColors().Error, "invalid pattern")
+		imgui.SetItemTooltip(lv.matchErr.Error())
+	case lv.Search == "":
+	case len(lv.matches) == 0:
+		imgui.TextDisabled(T("dfx.log.noMatches"))
+	case lv.current >= 0:
+		imgui.TextUnformatted(fmt.Sprintf("%d of %d", lv.current+1, len(lv.matches)))
+	default:
+		imgui.TextUnformatted(fmt.Sprintf("%d matches", len(lv.matches)))
+	}
+}
+
+// highlightMatches draws match highlights over the last rendered text item.
+func (lv *LogViewer) highlightMatches(text string) {
+	if lv.matcher == nil {
+		return
+	}
+	ranges := lv.matcher.find(text)
+	if len(ranges) == 0 {
+		return
+	}
+	origin := imgui.ItemRectMin()
+	height := imgui.ItemRectSize().Y
+	// ranges are measured along a single line; skip wrapped or multi-line text
+	if height > imgui.TextLineHeight()+1 {
+		return
+	}
+	dl := imgui.WindowDrawList()
+	color := imgui.ColorConvertFloat4ToU32(lv.colors.match)
+	for _, r := range ranges {
+		x0 := imgui.CalcTextSize(text[:r[0]]).X
+		x1 := imgui.CalcTextSize(text[:r[1]]).X
+		dl.AddRectFilled(origin.Add(imgui.Vec2{X: x0}), origin.Add(imgui.Vec2{X: x1, Y: height}), color)
+	}
+}
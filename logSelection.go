@@ -0,0 +1,261 @@
+package dfx
+
+import (
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// log selection constants
+const (
+	logCopyKeys = "Ctrl+C"
+)
+
+// logClick is a click on a displayed line, recorded while drawing and applied afterwards
+// (the buffer is locked while lines are drawn).
+type logClick struct {
+	row    int
+	extend bool // shift: select the range from the anchor
+	toggle bool // ctrl: add or remove the line
+	menu   bool // right button: open the context menu
+	expand bool // double click: expand or collapse the entry
+}
+
+// drawRowSelectable draws the full-width selectable behind a line and records clicks on it.
+// the id scope it opens stays pushed while the line is drawn; renderRow pops it.
+func (lv *LogViewer) drawRowSelectable(row int, msg *LogMessage, height float32) {
+	imgui.PushIDInt(int32(msg.seq))
+	size := imgui.Vec2{Y: height}
+	if imgui.SelectableBoolV("##logRow", lv.selected[msg.seq], imgui.SelectableFlagsAllowOverlap, size) {
+		io := imgui.CurrentIO()
+		lv.click = &logClick{row: row, extend: io.KeyShift(), toggle: io.KeyCtrl()}
+	}
+	if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && lv.expandable(msg) {
+		lv.click = &logClick{row: row, expand: true}
+	}
+	if imgui.IsItemClickedV(imgui.MouseButtonRight) {
+		lv.click = &logClick{row: row, menu: true}
+	}
+}
+
+// applyClick applies the click recorded while drawing, if any.
+func (lv *LogViewer) applyClick() {
+	if lv.click == nil {
+		return
+	}
+	click := lv.click
+	lv.click = nil
+	if click.menu {
+		// right-clicking outside the selection selects the clicked line
+		if !lv.rowSelected(click.row) {
+			lv.clickRow(click.row, false, false)
+		}
+		lv.openContext = true
+		return
+	}
+	if click.expand {
+		lv.toggleExpanded(lv.rowSeq(click.row))
+		return
+	}
+	lv.clickRow(click.row, click.extend, click.toggle)
+}
+
+// clickRow updates the selection for a click on the displayed line at row: a plain click
+// selects just that line, toggle adds or removes it, and extend selects the range from
+// the last clicked line.
+func (lv *LogViewer) clickRow(row int, extend, toggle bool) {
+	if row < 0 || row >= len(lv.rows) {
+		return
+	}
+	if lv.selected == nil {
+		lv.selected = make(map[uint64]bool)
+	}
+	seq := lv.rowSeq(row)
+
+	if extend && lv.hasAnchor {
+		if anchorRow := lv.seqRow(lv.anchor); anchorRow >= 0 {
+			from, to := anchorRow, row
+			if from > to {
+				from, to = to, from
+			}
+			if !toggle {
+				clear(lv.selected)
+			}
+			lv.Buffer.rangeIndices(lv.rows[from:to+1], func(_ int, msg *LogMessage) {
+				lv.selected[msg.seq] = true
+			})
+			return
+		}
+	}
+
+	if toggle {
+		if lv.selected[seq] {
+			delete(lv.selected, seq)
+		} else {
+			lv.selected[seq] = true
+		}
+	} else {
+		clear(lv.selected)
+		lv.selected[seq] = true
+	}
+	lv.anchor = seq
+	lv.hasAnchor = true
+}
+
+// rowSeq returns the sequence number of the message displayed at row.
+func (lv *LogViewer) rowSeq(row int) uint64 {
+	var seq uint64
+	lv.Buffer.rangeIndices(lv.rows[row:row+1], func(_ int, msg *LogMessage) {
+		seq = msg.seq
+	})
+	return seq
+}
+
+// seqRow returns the displayed row of the message with sequence number seq, or -1.
+func (lv *LogViewer) seqRow(seq uint64) int {
+	found := -1
+	lv.Buffer.rangeIndices(lv.rows, func(i int, msg *LogMessage) {
+		if found < 0 && msg.seq == seq {
+			found = i
+		}
+	})
+	return found
+}
+
+func (lv *LogViewer) rowSelected(row int) bool {
+	return row >= 0 && row < len(lv.rows) && lv.selected[lv.rowSeq(row)]
+}
+
+// SelectAll selects every displayed line.
+func (lv *LogViewer) SelectAll() {
+	if lv.selected == nil {
+		lv.selected = make(map[uint64]bool)
+	}
+	lv.Buffer.rangeIndices(lv.rows, func(_ int, msg *LogMessage) {
+		lv.selected[msg.seq] = true
+	})
+}
+
+// ClearSelection deselects all lines.
+func (lv *LogViewer) ClearSelection() {
+	clear(lv.selected)
+	lv.hasAnchor = false
+}
+
+// SelectedMessages returns copies of the selected messages that are currently displayed,
+// in display order.
+func (lv *LogViewer) SelectedMessages() []LogMessage {
+	if len(lv.selected) == 0 || lv.Buffer == nil {
+		return nil
+	}
+	var msgs []LogMessage
+	lv.Buffer.rangeIndices(lv.rows, func(_ int, msg *LogMessage) {
+		if lv.selected[msg.seq] {
+			msgs = append(msgs, *msg)
+		}
+	})
+	return msgs
+}
+
+// SelectedText returns the selected lines formatted as in LogBuffer.AllText.
+func (lv *LogViewer) SelectedText() string {
+	var out strings.Builder
+	for _, msg := range lv.SelectedMessages() {
+		out.WriteString(formatLogMessage(&msg))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// SelectedFieldsJSON returns the structured fields of the selected lines as JSON: the
+// fields object of a single line, or an array of objects for several. lines without
+// fields are skipped.
+func (lv *LogViewer) SelectedFieldsJSON() string {
+	var fields []string
+	for _, msg := range lv.SelectedMessages() {
+		if msg.Fields != "" {
+			fields = append(fields, msg.Fields)
+		}
+	}
+	switch len(fields) {
+	case 0:
+		return ""
+	case 1:
+		return fields[0]
+	default:
+		return "[" + strings.Join(fields, ",") + "]"
+	}
+}
+
+// CopySelection copies the selected lines to the clipboard.
+func (lv *LogViewer) CopySelection() {
+	if text := lv.SelectedText(); text != "" {
+		imgui.SetClipboardText(text)
+	}
+}
+
+// CopySelectedFields copies the structured fields of the selected lines to the clipboard as JSON.
+func (lv *LogViewer) CopySelectedFields() {
+	if text := lv.SelectedFieldsJSON(); text != "" {
+		imgui.SetClipboardText(text)
+	}
+}
+
+// pruneEntries forgets per-entry state for messages that are no longer in the buffer.
+func (lv *LogViewer) pruneEntries() {
+	first := lv.Buffer.firstSeq()
+	for seq := range lv.selected {
+		if seq < first {
+			delete(lv.selected, seq)
+		}
+	}
+	for seq := range lv.expanded {
+		if seq < first {
+			delete(lv.expanded, seq)
+		}
+	}
+	for seq := range lv.fieldCache {
+		if seq < first {
+			delete(lv.fieldCache, seq)
+		}
+	}
+	lv.variableRows.Forget(func(seq uint64) bool { return seq >= first })
+}
+
+// registerSelectionActions adds the copy keyboard shortcut.
+func (lv *LogViewer) registerSelectionActions() {
+	lv.Container.Actions().MustRegister("Copy Selected Lines", logCopyKeys, lv.CopySelection)
+}
+
+// drawContextMenu renders the right-click menu for the selected lines.
+func (lv *LogViewer) drawContextMenu() {
+	if lv.openContext {
+		imgui.OpenPopupStr("##logContext")
+		lv.openContext = false
+	}
+	if !imgui.BeginPopup("##logContext") {
+		return
+	}
+	if imgui.MenuItemBoolV(fonts.ICON_CONTENT_COPY+" Copy", logCopyKeys, false, len(lv.selected) > 0) {
+		lv.CopySelection()
+	}
+	if imgui.MenuItemBoolV(fonts.ICON_DATA_OBJECT+" Copy Fields as JSON", "", false, lv.SelectedFieldsJSON() != "") {
+		lv.CopySelectedFields()
+	}
+	imgui.Separator()
+	if imgui.MenuItemBoolV(T("dfx.log.expand"), "", false, len(lv.selected) > 0) {
+		lv.ExpandSelection()
+	}
+	if imgui.MenuItemBoolV(T("dfx.log.collapseAll"), "", false, len(lv.expanded) > 0) {
+		lv.CollapseAll()
+	}
+	imgui.Separator()
+	if imgui.MenuItemBool(T("dfx.log.selectAll")) {
+		lv.SelectAll()
+	}
+	if imgui.MenuItemBoolV(T("dfx.log.clearSelection"), "", false, len(lv.selected) > 0) {
+		lv.ClearSelection()
+	}
+	imgui.EndPopup()
+}
@@ -0,0 +1,151 @@
+package dfx
+
+import (
+	"log/slog"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// logLevels are the levels offered by the toolbar, in display order.
+var logLevels = []struct {
+	level slog.Level
+	label string
+}{
+	{slog.LevelDebug, "Debug"},
+	{slog.LevelInfo, "Info"},
+	{slog.LevelWarn, "Warning"},
+	{slog.LevelError, "Error"},
+}
+
+// logSource returns the source a message is filtered by: its df/dl channel when it has
+// one, otherwise the function that logged it.
+func logSource(msg *LogMessage) string {
+	if msg.Channel != "" {
+		return msg.Channel
+	}
+	return msg.Func
+}
+
+// showMessage reports whether msg passes the level and source filters.
+func (lv *LogViewer) showMessage(msg *LogMessage, source string) bool {
+	if msg.Level < lv.LevelFilter || lv.hiddenLevels[msg.Level] {
+		return false
+	}
+	return !lv.hiddenSources[source]
+}
+
+// LevelVisible reports whether messages at level are shown.
+func (lv *LogViewer) LevelVisible(level slog.Level) bool {
+	return level >= lv.LevelFilter && !lv.hiddenLevels[level]
+}
+
+// SetLevelVisible shows or hides messages at level. showing a level below LevelFilter
+// lowers LevelFilter to it.
+func (lv *LogViewer) SetLevelVisible(level slog.Level, visible bool) {
+	if lv.hiddenLevels == nil {
+		lv.hiddenLevels = make(map[slog.Level]bool)
+	}
+	if visible && level < lv.LevelFilter {
+		// levels between the new minimum and the old one stay hidden
+		for _, l := range logLevels {
+			if l.level > level && l.level < lv.LevelFilter {
+				lv.hiddenLevels[l.level] = true
+			}
+		}
+		lv.LevelFilter = level
+	}
+	if visible {
+		delete(lv.hiddenLevels, level)
+	} else {
+		lv.hiddenLevels[level] = true
+	}
+	lv.filterChanges++
+}
+
+// Sources returns the message sources (df/dl channels, or functions for messages without
+// a channel) seen in the buffer, sorted.
+func (lv *LogViewer) Sources() []string {
+	return append([]string(nil), lv.sources...)
+}
+
+// SourceVisible reports whether messages from source are shown.
+func (lv *LogViewer) SourceVisible(source string) bool {
+	return !lv.hiddenSources[source]
+}
+
+// SetSourceVisible shows or hides messages from source.
+func (lv *LogViewer) SetSourceVisible(source string, visible bool) {
+	if lv.hiddenSources == nil {
+		lv.hiddenSources = make(map[string]bool)
+	}
+	if visible {
+		delete(lv.hiddenSources, source)
+	} else {
+		lv.hiddenSources[source] = true
+	}
+	lv.filterChanges++
+}
+
+// drawToolbar renders the level checkboxes and the sources and columns menus.
+func (lv *LogViewer) drawToolbar() {
+	imgui.PushIDStr("##logToolbar")
+	defer imgui.PopID()
+
+	for i, l := range logLevels {
+		if i > 0 {
+			imgui.SameLine()
+		}
+		if visible, changed := Checkbox(l.label, lv.LevelVisible(l.level)); changed {
+			lv.SetLevelVisible(l.level, visible)
+		}
+	}
+
+	imgui.SameLine()
+	if imgui.Button(fonts.ICON_FILTER_ALT + " Sources") {
+		imgui.OpenPopupStr("##sources")
+	}
+	if imgui.BeginPopup("##sources") {
+		if imgui.SmallButton("All") {
+			for _, source := range lv.sources {
+				lv.SetSourceVisible(source, true)
+			}
+		}
+		imgui.SameLine()
+		if imgui.SmallButton("None") {
+			for _, source := range lv.sources {
+				lv.SetSourceVisible(source, false)
+			}
+		}
+		imgui.Separator()
+		if len(lv.sources) == 0 {
+			imgui.TextDisabled(T("dfx.log.noSources"))
+		}
+		for _, source := range lv.sources {
+			label := source
+			if label == "" {
+				label = "(unknown)"
+			}
+			if imgui.MenuItemBoolV(label+"##"+source, "", lv.SourceVisible(source), true) {
+				lv.SetSourceVisible(source, !lv.SourceVisible(source))
+			}
+		}
+		imgui.EndPopup()
+	}
+
+	imgui.SameLine()
+	if imgui.Button(fonts.ICON_VIEW_COLUMN + " Columns") {
+		imgui.OpenPopupStr("##columns")
+	}
+	if imgui.BeginPopup("##columns") {
+		imgui.MenuItemBoolPtr(T("dfx.log.time"), "", &lv.ShowTime)
+		imgui.MenuItemBoolPtr(T("dfx.log.function"), "", &lv.ShowFunc)
+		imgui.MenuItemBoolPtr(T("dfx.log.fields"), "", &lv.ShowFields)
+		imgui.EndPopup()
+	}
+
+	if lv.ToolbarExtra != nil {
+		imgui.SameLine()
+		lv.ToolbarExtra()
+	}
+}
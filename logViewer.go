@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"log/slog"
 	"runtime"
 	"strings"
@@ -18,13 +19,18 @@ const (
 	LogTimeFormat = "[%8.3f]" // time formatting for log entries
 )
 
+// package-wide log viewer colors. they now default to zero, meaning the current theme's
+// semantic colors; a non-zero value overrides the theme for every LogViewer that does not
+// set its own color.
+//
+// Deprecated: set the LogViewer color fields, or the theme's semantic colors, instead.
 var (
-	LogTimeColor     = imgui.Vec4{X: 0.5, Y: 0.5, Z: 0.5, W: 1.0}
-	LogDebugColor    = imgui.Vec4{X: 0.0, Y: 0.0, Z: 1.0, W: 1.0}
-	LogWarningColor  = imgui.Vec4{X: 1.0, Y: 1.0, Z: 0.0, W: 1.0}
-	LogErrorColor    = imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0}
-	LogFunctionColor = imgui.Vec4{X: 0.023, Y: 0.596, Z: 0.603, W: 1.0}
-	LogFieldsColor   = imgui.Vec4{X: 0.203, Y: 0.886, Z: 0.886, W: 1.0}
+	LogTimeColor     imgui.Vec4
+	LogDebugColor    imgui.Vec4
+	LogWarningColor  imgui.Vec4
+	LogErrorColor    imgui.Vec4
+	LogFunctionColor imgui.Vec4
+	LogFieldsColor   imgui.Vec4
 )
 
 // LogMessage represents a single log entry.
@@ -32,23 +38,34 @@ type LogMessage struct {
 	Time    time.Time
 	Level   slog.Level
 	Func    string
+	Channel string // df/dl channel the message was logged to, if any
 	Fields  string
 	Message string
+
+	seq uint64 // position in the buffer's history, assigned by LogBuffer.Add
 }
 
-// LogBuffer is a thread-safe circular buffer for log messages.
+// LogBuffer is a thread-safe circular buffer for log messages. storage grows as messages
+// arrive, up to the maximum size, after which each message added overwrites the oldest;
+// adding is amortized O(1) and never moves the messages already held.
 type LogBuffer struct {
-	messages []LogMessage
-	head     int // write position
-	count    int // number of valid entries
+	messages []LogMessage // ring storage, grown by append until it holds maxSize
+	head     int          // write position
+	count    int          // number of valid entries
 	maxSize  int
+	version  uint64 // incremented on every change
+	added    uint64 // total messages added; numbers each message
 	mu       sync.RWMutex
 }
 
+// logBufferInitialSize bounds the storage a new LogBuffer allocates up front.
+const logBufferInitialSize = 256
+
 // NewLogBuffer creates a new log buffer with the specified maximum size.
 func NewLogBuffer(maxSize int) *LogBuffer {
+	maxSize = max(maxSize, 1)
 	return &LogBuffer{
-		messages: make([]LogMessage, maxSize),
+		messages: make([]LogMessage, 0, min(maxSize, logBufferInitialSize)),
 		maxSize:  maxSize,
 	}
 }
@@ -59,40 +76,143 @@ func (lb *LogBuffer) Add(msg LogMessage) {
 	lb.mu.Lock()
 	defer lb.mu.Unlock()
 
-	lb.messages[lb.head] = msg
-	lb.head = (lb.head + 1) % lb.maxSize
+	msg.seq = lb.added
+	lb.added++
+	if lb.head < len(lb.messages) {
+		lb.messages[lb.head] = msg
+	} else {
+		lb.messages = append(lb.messages, msg)
+	}
+	lb.head++
+	if lb.head == lb.maxSize {
+		lb.head = 0
+	}
 	if lb.count < lb.maxSize {
 		lb.count++
 	}
+	lb.version++
+}
+
+// segments returns the messages in the buffer as two contiguous runs, oldest first. the
+// caller holds the read lock.
+func (lb *LogBuffer) segments() (older, newer []LogMessage) {
+	start := lb.head - lb.count
+	if start < 0 {
+		return lb.messages[start+len(lb.messages):], lb.messages[:lb.head]
+	}
+	return lb.messages[start:lb.head], nil
 }
 
 // Messages returns a copy of all messages in the buffer in order.
 func (lb *LogBuffer) Messages() []LogMessage {
+	return lb.Tail(-1)
+}
+
+// Tail returns a copy of the newest n messages in the buffer in order, or of all of them
+// when n is negative or more than the buffer holds.
+func (lb *LogBuffer) Tail(n int) []LogMessage {
 	lb.mu.RLock()
 	defer lb.mu.RUnlock()
 
-	msgs := make([]LogMessage, lb.count)
-	start := (lb.head - lb.count + lb.maxSize) % lb.maxSize
-	for i := 0; i < lb.count; i++ {
-		msgs[i] = lb.messages[(start+i)%lb.maxSize]
+	if n < 0 || n > lb.count {
+		n = lb.count
+	}
+	older, newer := lb.segments()
+	if skip := lb.count - n; skip > len(older) {
+		newer = newer[skip-len(older):]
+		older = nil
+	} else {
+		older = older[skip:]
+	}
+	msgs := make([]LogMessage, 0, n)
+	msgs = append(msgs, older...)
+	return append(msgs, newer...)
+}
+
+// All returns an iterator over the messages in the buffer, oldest first, with their
+// indices. the read lock is held for the whole iteration, so the loop must not add to the
+// buffer; each message pointer is only valid during its iteration.
+func (lb *LogBuffer) All() iter.Seq2[int, *LogMessage] {
+	return func(yield func(int, *LogMessage) bool) {
+		lb.mu.RLock()
+		defer lb.mu.RUnlock()
+
+		older, newer := lb.segments()
+		for i := range older {
+			if !yield(i, &older[i]) {
+				return
+			}
+		}
+		for i := range newer {
+			if !yield(len(older)+i, &newer[i]) {
+				return
+			}
+		}
 	}
-	return msgs
 }
 
 // Range calls f for each log message in the buffer while holding the read lock.
 // iteration stops early if f returns false.
 // the message pointer is only valid during the callback.
 func (lb *LogBuffer) Range(f func(index int, msg *LogMessage) bool) {
+	for i, msg := range lb.All() {
+		if !f(i, msg) {
+			break
+		}
+	}
+}
+
+// locked calls f while holding the read lock; f may read messages with at.
+func (lb *LogBuffer) locked(f func()) {
 	lb.mu.RLock()
 	defer lb.mu.RUnlock()
+	f()
+}
 
-	start := (lb.head - lb.count + lb.maxSize) % lb.maxSize
-	for i := 0; i < lb.count; i++ {
-		idx := (start + i) % lb.maxSize
-		if !f(i, &lb.messages[idx]) {
-			break
+// rangeIndices calls f for the messages at the given indices (0 = oldest) while holding
+// the read lock. out-of-range indices are skipped. f receives the position within indices.
+func (lb *LogBuffer) rangeIndices(indices []int, f func(i int, msg *LogMessage)) {
+	lb.locked(func() {
+		for i, index := range indices {
+			if msg := lb.at(index); msg != nil {
+				f(i, msg)
+			}
 		}
+	})
+}
+
+// at returns the message at index (0 = oldest), or nil when out of range. the caller
+// holds the read lock (see locked).
+func (lb *LogBuffer) at(index int) *LogMessage {
+	if index < 0 || index >= lb.count {
+		return nil
+	}
+	slot := lb.head - lb.count + index
+	if slot < 0 {
+		slot += len(lb.messages)
 	}
+	return &lb.messages[slot]
+}
+
+// firstSeq returns the sequence number of the oldest message in the buffer.
+func (lb *LogBuffer) firstSeq() uint64 {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.added - uint64(lb.count)
+}
+
+// nextSeq returns the sequence number the next added message will receive.
+func (lb *LogBuffer) nextSeq() uint64 {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.added
+}
+
+// changes returns a counter that increases whenever the buffer is modified.
+func (lb *LogBuffer) changes() uint64 {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.version
 }
 
 // Clear removes all messages from the buffer.
@@ -100,8 +220,11 @@ func (lb *LogBuffer) Clear() {
 	lb.mu.Lock()
 	defer lb.mu.Unlock()
 
+	clear(lb.messages) // release the messages' strings
+	lb.messages = lb.messages[:0]
 	lb.head = 0
 	lb.count = 0
+	lb.version++
 }
 
 // AllText returns all log messages as a single formatted string.
@@ -110,26 +233,32 @@ func (lb *LogBuffer) AllText() string {
 	defer lb.mu.RUnlock()
 
 	var out strings.Builder
-	start := (lb.head - lb.count + lb.maxSize) % lb.maxSize
-	for i := 0; i < lb.count; i++ {
-		msg := lb.messages[(start+i)%lb.maxSize]
-		fields := ""
-		if msg.Fields != "" {
-			fields = " " + msg.Fields
+	older, newer := lb.segments()
+	for _, run := range [][]LogMessage{older, newer} {
+		for i := range run {
+			out.WriteString(formatLogMessage(&run[i]))
+			out.WriteString("\n")
 		}
-		out.WriteString(strings.TrimSuffix(
-			fmt.Sprintf("[%v] %8s %v%v %v",
-				msg.Time.Format(time.RFC3339Nano),
-				msg.Level,
-				msg.Func,
-				fields,
-				msg.Message),
-			"\n"))
-		out.WriteString("\n")
 	}
 	return out.String()
 }
 
+// formatLogMessage formats msg as a single line of text, without a trailing newline.
+func formatLogMessage(msg *LogMessage) string {
+	fields := ""
+	if msg.Fields != "" {
+		fields = " " + msg.Fields
+	}
+	return strings.TrimSuffix(
+		fmt.Sprintf("[%v] %8s %v%v %v",
+			msg.Time.Format(time.RFC3339Nano),
+			msg.Level,
+			msg.Func,
+			fields,
+			msg.Message),
+		"\n")
+}
+
 // Count returns the number of messages in the buffer.
 func (lb *LogBuffer) Count() int {
 	lb.mu.RLock()
@@ -141,18 +270,84 @@ func (lb *LogBuffer) Count() int {
 type LogViewer struct {
 	Container
 	Buffer              *LogBuffer
-	AutoScroll          bool
+	AutoScroll          bool       // follow new messages; pauses while scrolled up (see Paused)
 	LevelFilter         slog.Level // minimum level to show
 	ShowTime            bool
 	ShowFunc            bool
 	ShowFields          bool
 	ShowDisabledMessage bool
 	DisabledMessage     string
+
+	// colors (zero value = use the current theme's semantic colors)
+	TimeColor     imgui.Vec4 // defaults to Muted
+	DebugColor    imgui.Vec4 // defaults to Info
+	WarningColor  imgui.Vec4 // defaults to Warning
+	ErrorColor    imgui.Vec4 // defaults to Error
+	FunctionColor imgui.Vec4 // defaults to Accent
+	FieldsColor   imgui.Vec4 // defaults to Highlight
+	MatchColor    imgui.Vec4 // search match highlight; defaults to translucent Highlight
+	FieldKeyColor imgui.Vec4 // field names and null values; defaults to Muted
+	NumberColor   imgui.Vec4 // numeric field values; defaults to Info
+	BoolColor     imgui.Vec4 // boolean field values; defaults to Success
+
+	// structured fields and long entries
+	FieldChips   bool // render fields as key=value chips instead of raw JSON
+	WrapMessages bool // wrap long messages at the window edge
+
+	// toolbar
+	ShowToolbar  bool   // draw level, source and column filters above the log
+	ToolbarExtra func() // optional: draws additional controls at the end of the toolbar
+
+	Kinetic KineticScroller // momentum after flicks, when kinetic scrolling is on (see SetKineticScrolling)
+
+	// search
+	ShowSearchBar   bool   // draw the search bar above the log
+	Search          string // current search text
+	SearchRegex     bool   // treat Search as a regular expression
+	SearchMatchCase bool   // case-sensitive search
+	HideNonMatching bool   // hide lines that don't match the search instead of dimming them
+
+	colors logColors // colors resolved for the current frame
+
+	rows        []int      // buffer indices of the displayed lines
+	rowMatches  []bool     // whether each displayed line matches the search
+	matches     []int      // positions in rows of matching lines
+	rowsKey     logRowsKey // inputs rows were computed from
+	matcher     *logMatcher
+	matchErr    error
+	current     int // index into matches of the current match (-1 = none)
+	scrollToRow int // row to bring into view on the next frame (-1 = none)
+	focusSearch bool
+
+	hiddenLevels  map[slog.Level]bool
+	hiddenSources map[string]bool
+	sources       []string // sources discovered in the buffer, sorted
+	filterChanges uint64   // incremented when level or source visibility changes
+
+	selected    map[uint64]bool // selected messages, by sequence number
+	anchor      uint64          // sequence number shift-click ranges extend from
+	hasAnchor   bool
+	click       *logClick // click on a line, applied after the lines are drawn
+	openContext bool
+
+	expanded     map[uint64]bool       // expanded entries, by sequence number
+	fieldCache   map[uint64][]logField // parsed fields, by sequence number
+	variableRows *VariableList         // measured line heights for variable-height layout
+
+	paused      bool    // following paused by scrolling up
+	pausedSeq   uint64  // sequence number of the first message added while paused
+	lastScrollY float32 // scroll position on the previous frame
+}
+
+// logColors holds log viewer colors resolved against the current theme.
+type logColors struct {
+	time, debug, warning, error, function, fields, match imgui.Vec4
+	fieldKey, number, boolean                            imgui.Vec4
 }
 
 // NewLogViewer creates a new log viewer component.
 func NewLogViewer(buffer *LogBuffer) *LogViewer {
-	return &LogViewer{
+	lv := &LogViewer{
 		Container:           Container{Visible: true},
 		Buffer:              buffer,
 		AutoScroll:          true,
@@ -162,7 +357,19 @@ func NewLogViewer(buffer *LogBuffer) *LogViewer {
 		ShowFields:          true,
 		ShowDisabledMessage: true,
 		DisabledMessage:     "logging capture disabled",
+		ShowToolbar:         true,
+		ShowSearchBar:       true,
+		FieldChips:          true,
+		hiddenLevels:        make(map[slog.Level]bool),
+		hiddenSources:       make(map[string]bool),
+		selected:            make(map[uint64]bool),
+		current:             -1,
+		scrollToRow:         -1,
+		variableRows:        NewVariableList(),
 	}
+	lv.registerSearchActions()
+	lv.registerSelectionActions()
+	return lv
 }
 
 // Draw renders the log viewer.
@@ -179,57 +386,133 @@ func (lv *LogViewer) Draw(state *State) {
 		return
 	}
 
+	// resolve colors against the current theme
+	tc := ThemeColors()
+	matchDefault := tc.Highlight
+	matchDefault.W = 0.35
+	lv.colors = logColors{
+		time:     themeColor(lv.TimeColor, themeColor(LogTimeColor, tc.Muted)),
+		debug:    themeColor(lv.DebugColor, themeColor(LogDebugColor, tc.Info)),
+		warning:  themeColor(lv.WarningColor, themeColor(LogWarningColor, tc.Warning)),
+		error:    themeColor(lv.ErrorColor, themeColor(LogErrorColor, tc.Error)),
+		function: themeColor(lv.FunctionColor, themeColor(LogFunctionColor, tc.Accent)),
+		fields:   themeColor(lv.FieldsColor, themeColor(LogFieldsColor, tc.Highlight)),
+		match:    themeColor(lv.MatchColor, matchDefault),
+		fieldKey: themeColor(lv.FieldKeyColor, tc.Muted),
+		number:   themeColor(lv.NumberColor, tc.Info),
+		boolean:  themeColor(lv.BoolColor, tc.Success),
+	}
+
+	if lv.ShowToolbar {
+		lv.drawToolbar()
+		if lv.ShowSearchBar {
+			imgui.SameLine()
+		}
+	}
+	if lv.ShowSearchBar {
+		lv.drawSearchBar()
+	}
+	lv.updateRows()
+
 	// create scrollable child window for log messages
 	imgui.PushStyleVarFloat(imgui.StyleVarScrollbarSize, 9)
 	imgui.BeginChildStr("##logViewerContent")
 	imgui.PushStyleVarVec2(imgui.StyleVarItemSpacing, imgui.Vec2{X: 0, Y: 0})
 	PushFont(MonospaceFont)
 
-	// get count for clipper (single lock acquisition)
-	count := lv.Buffer.Count()
-
-	// use list clipper for efficient rendering
-	clipper := imgui.NewListClipper()
-	if count > 0 {
-		clipper.Begin(int32(count))
-		for clipper.Step() {
-			start := int(clipper.DisplayStart())
-			end := int(clipper.DisplayEnd())
-
-			// iterate only over visible range using Range to avoid copying
-			lv.Buffer.Range(func(index int, msg *LogMessage) bool {
-				// only process messages in visible range
-				if index < start {
-					return true // continue to next message
-				}
-				if index >= end {
-					return false // stop iteration (past visible range)
-				}
-
-				// skip messages below filter level
-				if msg.Level < lv.LevelFilter {
-					return true // continue to next message
-				}
-
-				lv.renderMessage(msg, state)
-				return true // continue to next message
-			})
-		}
+	if lv.WrapMessages || len(lv.expanded) > 0 {
+		lv.drawVariableRows(state)
+	} else {
+		lv.drawUniformRows(state)
 	}
+	lv.applyClick()
 
-	// auto-scroll to bottom
-	if lv.AutoScroll && imgui.ScrollY() >= imgui.ScrollMaxY() {
+	// follow new messages unless the user has scrolled up
+	if lv.trackScroll(imgui.ScrollY(), imgui.ScrollMaxY()) {
 		imgui.SetScrollHereYV(1.0)
 	}
+	lv.Kinetic.Update()
 
 	PopFont()
 	imgui.PopStyleVar()
+	lv.drawFollowPill()
+	lv.drawContextMenu()
 	imgui.EndChild()
 	imgui.PopStyleVar() // pop scrollbar size
 
 	drawContainerExtensions(&lv.Container, state)
 }
 
+// drawUniformRows renders the displayed lines with a list clipper; every line is one
+// text line high.
+func (lv *LogViewer) drawUniformRows(state *State) {
+	// bring the current match into view
+	lineHeight := imgui.TextLineHeight()
+	if lv.scrollToRow >= 0 {
+		imgui.SetScrollYFloat(float32(lv.scrollToRow)*lineHeight - imgui.WindowHeight()/2)
+		lv.scrollToRow = -1
+	}
+
+	lv.Buffer.locked(func() {
+		VirtualList(len(lv.rows), 0, func(row int) {
+			if msg := lv.Buffer.at(lv.rows[row]); msg != nil {
+				lv.renderRow(row, msg, lineHeight, state)
+			}
+		})
+	})
+}
+
+// drawVariableRows renders the displayed lines when wrapped or expanded entries make
+// their heights differ. heights are measured as lines are drawn and cached by sequence
+// number.
+func (lv *LogViewer) drawVariableRows(state *State) {
+	lineHeight := imgui.TextLineHeight()
+	if lv.scrollToRow >= 0 {
+		lv.variableRows.ScrollTo(lv.scrollToRow, 0.5)
+		lv.scrollToRow = -1
+	}
+	lv.Buffer.locked(func() {
+		key := func(row int) uint64 {
+			if msg := lv.Buffer.at(lv.rows[row]); msg != nil {
+				return msg.seq
+			}
+			return 0
+		}
+		lv.variableRows.Draw(len(lv.rows), key, func(row int) {
+			if msg := lv.Buffer.at(lv.rows[row]); msg != nil {
+				height, measured := lv.variableRows.Height(msg.seq)
+				if !measured {
+					height = lineHeight
+				}
+				lv.renderRow(row, msg, height, state)
+			}
+		})
+	})
+}
+
+// renderRow renders a displayed line over its selectable, dimming it or marking it as the
+// current match while a search is active. height is the line's expected height.
+func (lv *LogViewer) renderRow(row int, msg *LogMessage, height float32, state *State) {
+	pos := imgui.CursorScreenPos()
+	lv.drawRowSelectable(row, msg, height)
+	defer imgui.PopID()
+	imgui.SetCursorScreenPos(pos)
+
+	searching := lv.matcher != nil
+	if searching && lv.current >= 0 && lv.current < len(lv.matches) && lv.matches[lv.current] == row {
+		size := imgui.Vec2{X: imgui.ContentRegionAvail().X, Y: height}
+		imgui.WindowDrawList().AddRectFilled(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(lv.colors.match))
+	}
+	dimmed := searching && !lv.rowMatches[row]
+	if dimmed {
+		imgui.PushStyleVarFloat(imgui.StyleVarAlpha, logDimmedAlpha)
+	}
+	lv.renderMessage(msg, state)
+	if dimmed {
+		imgui.PopStyleVar()
+	}
+}
+
 func (lv *LogViewer) shouldRenderDisabledMessage() bool {
 	if !lv.ShowDisabledMessage {
 		return false
@@ -250,39 +533,93 @@ func (lv *LogViewer) shouldRenderDisabledMessage() bool {
 func (lv *LogViewer) renderMessage(msg *LogMessage, state *State) {
 	// render time if enabled
 	if lv.ShowTime {
-		// calculate relative time
-		relativeTime := msg.Time.Sub(state.App.startTime).Seconds()
-		imgui.TextColored(LogTimeColor, fmt.Sprintf(LogTimeFormat, relativeTime))
+		// calculate relative time; records without a time, or viewers drawn outside an
+		// app, leave the column blank
+		var elapsed time.Duration
+		blank := msg.Time.IsZero() || state.App == nil
+		if !blank {
+			elapsed = msg.Time.Sub(state.App.startTime)
+		}
+		imgui.TextColored(lv.colors.time, logTimeText(elapsed, blank))
 		imgui.SameLine()
 	}
 
 	// render level with appropriate color
 	switch msg.Level {
 	case slog.LevelDebug:
-		imgui.TextColored(LogDebugColor, "   DEBUG")
+		imgui.TextColored(lv.colors.debug, "   DEBUG")
 	case slog.LevelInfo:
 		imgui.TextUnformatted("    INFO")
 	case slog.LevelWarn:
-		imgui.TextColored(LogWarningColor, " WARNING")
+		imgui.TextColored(lv.colors.warning, " WARNING")
 	case slog.LevelError:
-		imgui.TextColored(LogErrorColor, "   ERROR")
+		imgui.TextColored(lv.colors.error, "   ERROR")
 	}
 
 	// render function if enabled
 	if lv.ShowFunc && msg.Func != "" {
 		imgui.SameLine()
-		imgui.TextColored(LogFunctionColor, " "+msg.Func+" ")
+		text := " " + msg.Func + " "
+		imgui.TextColored(lv.colors.function, text)
+		lv.highlightMatches(text)
 	}
 
-	// render fields if enabled and present
+	expandable := lv.expandable(msg)
+	expanded := expandable && lv.expanded[msg.seq]
+
+	// render fields if enabled and present; expanded entries list them below the message
+	var fields []logField
 	if lv.ShowFields && msg.Fields != "" {
-		imgui.SameLine()
-		imgui.TextColored(LogFieldsColor, msg.Fields+" ")
+		if lv.FieldChips {
+			fields = lv.fieldsOf(msg)
+		}
+		switch {
+		case expanded:
+		case fields != nil:
+			lv.drawFieldChips(fields)
+			imgui.SameLineV(0, logChipSpacing)
+			imgui.Dummy(imgui.Vec2{})
+		default:
+			imgui.SameLine()
+			text := msg.Fields + " "
+			imgui.TextColored(lv.colors.fields, text)
+			lv.highlightMatches(text)
+		}
 	}
 
 	// render message
 	imgui.SameLine()
-	imgui.TextUnformatted(msg.Message)
+	indent := imgui.CursorPosX()
+	message := msg.Message
+	if !expanded {
+		if i := strings.IndexByte(message, '\n'); i >= 0 {
+			message = message[:i]
+		}
+	}
+	if lv.WrapMessages || expanded {
+		imgui.PushTextWrapPos()
+		imgui.TextUnformatted(message)
+		imgui.PopTextWrapPos()
+	} else {
+		imgui.TextUnformatted(message)
+	}
+	lv.highlightMatches(message)
+
+	if expandable && !expanded {
+		imgui.SameLine()
+		lv.drawExpandMarker(msg.seq)
+	}
+	if expanded && lv.ShowFields && msg.Fields != "" {
+		if fields != nil {
+			lv.drawExpandedFields(fields, indent)
+		} else {
+			imgui.SetCursorPosX(indent)
+			imgui.PushTextWrapPos()
+			imgui.TextColored(lv.colors.fields, msg.Fields)
+			imgui.PopTextWrapPos()
+			lv.highlightMatches(msg.Fields)
+		}
+	}
 }
 
 // SlogHandlerOptions configures the slog handler integration.
@@ -293,13 +630,17 @@ type SlogHandlerOptions struct {
 }
 
 // SlogHandler is a slog.Handler implementation that writes to a LogBuffer.
-// this provides integration with the df/dl logging framework.
+// this provides integration with the df/dl logging framework. handlers are immutable:
+// WithAttrs and WithGroup return independent copies, so loggers can be derived and
+// shared across goroutines freely. groups render as nested objects in the fields.
 type SlogHandler struct {
 	buffer     *LogBuffer
 	trimPrefix string
 	minLevel   slog.Level
 	startTime  time.Time
-	attrs      []slog.Attr
+	fields     map[string]any // attrs added by WithAttrs, nested under their groups
+	groups     []string       // groups opened by WithGroup
+	channel    string         // df/dl channel added by WithAttrs
 }
 
 // NewSlogHandler creates a new slog handler that writes to a log buffer.
@@ -331,36 +672,33 @@ func (h *SlogHandler) Handle(_ context.Context, rec slog.Record) error {
 	msg := LogMessage{
 		Time:    rec.Time,
 		Level:   rec.Level,
+		Channel: h.channel,
 		Message: rec.Message,
 	}
 
 	// extract function name from caller
-	fs := runtime.CallersFrames([]uintptr{rec.PC})
-	f, _ := fs.Next()
-	fStr := f.Function
-	if h.trimPrefix != "" {
-		fStr = strings.TrimPrefix(fStr, h.trimPrefix)
+	if rec.PC != 0 {
+		fs := runtime.CallersFrames([]uintptr{rec.PC})
+		f, _ := fs.Next()
+		msg.Func = strings.TrimPrefix(f.Function, h.trimPrefix)
 	}
-	msg.Func = fStr
 
 	// extract attributes
-	rec.AddAttrs(h.attrs...)
+	fields := h.fields
 	if rec.NumAttrs() > 0 {
-		fieldsMap := make(map[string]interface{}, rec.NumAttrs())
+		attrs := make([]slog.Attr, 0, rec.NumAttrs())
 		rec.Attrs(func(a slog.Attr) bool {
-			// skip channel key (df/dl internal)
-			if a.Key != dl.ChannelKey {
-				fieldsMap[a.Key] = a.Value.Any()
-			}
+			attrs = append(attrs, a)
 			return true
 		})
-		if len(fieldsMap) > 0 {
-			fields, err := json.Marshal(fieldsMap)
-			if err != nil {
-				return err
-			}
-			msg.Fields = string(fields)
+		fields = addSlogAttrs(fields, h.groups, attrs, &msg.Channel)
+	}
+	if len(fields) > 0 {
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return err
 		}
+		msg.Fields = string(encoded)
 	}
 
 	h.buffer.Add(msg)
@@ -370,12 +708,76 @@ func (h *SlogHandler) Handle(_ context.Context, rec slog.Record) error {
 
 // WithAttrs implements slog.Handler.
 func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
 	derived := *h
-	derived.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	derived.fields = addSlogAttrs(h.fields, h.groups, attrs, &derived.channel)
 	return &derived
 }
 
 // WithGroup implements slog.Handler.
-func (h *SlogHandler) WithGroup(_ string) slog.Handler {
-	return h
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	derived := *h
+	derived.groups = append(append([]string{}, h.groups...), name)
+	return &derived
+}
+
+// addSlogAttrs returns a copy of fields with attrs added under the group path. maps along
+// the path are copied and everything else is shared, so fields is never modified. the
+// df/dl channel key is stored in channel instead of the fields when it appears outside
+// any group.
+func addSlogAttrs(fields map[string]any, groups []string, attrs []slog.Attr, channel *string) map[string]any {
+	out := make(map[string]any, len(fields)+len(attrs))
+	for k, v := range fields {
+		out[k] = v
+	}
+	if len(groups) > 0 {
+		child, _ := out[groups[0]].(map[string]any)
+		if nested := addSlogAttrs(child, groups[1:], attrs, nil); len(nested) > 0 {
+			out[groups[0]] = nested
+		}
+		return out
+	}
+	for _, a := range attrs {
+		addSlogAttr(out, a, channel)
+	}
+	return out
+}
+
+// addSlogAttr adds a to dst following the slog.Handler rules: values are resolved, empty
+// attrs and empty groups are dropped, and groups without a key are inlined.
+func addSlogAttr(dst map[string]any, a slog.Attr, channel *string) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		if len(group) == 0 {
+			return
+		}
+		if a.Key == "" {
+			for _, ga := range group {
+				addSlogAttr(dst, ga, channel)
+			}
+			return
+		}
+		child, _ := dst[a.Key].(map[string]any)
+		dst[a.Key] = addSlogAttrs(child, nil, group, nil)
+		return
+	}
+	// the channel key (df/dl internal) identifies the source rather than a field
+	if channel != nil && a.Key == dl.ChannelKey {
+		*channel = a.Value.String()
+		return
+	}
+	value := a.Value.Any()
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	dst[a.Key] = value
 }
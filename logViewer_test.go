@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
 	"testing"
+	"testing/slogtest"
 	"time"
+
+	"github.com/michaelquigley/df/dl"
 )
 
 func parseFields(t *testing.T, fields string) map[string]interface{} {
@@ -107,3 +111,490 @@ func TestLogViewer_ShouldRenderDisabledMessage(t *testing.T) {
 		t.Fatalf("expected invisible log viewer to suppress disabled rendering")
 	}
 }
+
+func TestLogMatcher_PlainAndRegex(t *testing.T) {
+	m, err := newLogMatcher("error", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.find("Error: an error"); len(got) != 2 || got[0] != [2]int{0, 5} || got[1] != [2]int{10, 15} {
+		t.Fatalf("expected two case-insensitive matches, got '%v'", got)
+	}
+
+	m, _ = newLogMatcher("error", false, true)
+	if m.matches("Error") {
+		t.Fatalf("expected case-sensitive search not to match 'Error'")
+	}
+
+	m, err = newLogMatcher(`id=\d+`, true, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.find("user id=42 ID=7"); len(got) != 2 || got[0] != [2]int{5, 10} {
+		t.Fatalf("expected regex matches, got '%v'", got)
+	}
+
+	if _, err := newLogMatcher("(", true, false); err == nil {
+		t.Fatalf("expected invalid regex error")
+	}
+	if m, _ := newLogMatcher("", false, false); m != nil {
+		t.Fatalf("expected no matcher for empty search")
+	}
+}
+
+func newSearchTestViewer() *LogViewer {
+	buffer := NewLogBuffer(16)
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "starting server"})
+	buffer.Add(LogMessage{Level: slog.LevelDebug, Message: "server detail"})
+	buffer.Add(LogMessage{Level: slog.LevelWarn, Message: "slow request"})
+	buffer.Add(LogMessage{Level: slog.LevelError, Message: "server failed", Func: "main.run"})
+	return NewLogViewer(buffer)
+}
+
+func TestLogViewer_SearchDimsOrHides(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.Search = "server"
+	lv.updateRows()
+
+	// debug line is below the level filter
+	if len(lv.rows) != 3 || lv.MatchCount() != 2 {
+		t.Fatalf("expected 3 rows with 2 matches, got %v rows, %v matches", len(lv.rows), lv.MatchCount())
+	}
+	if lv.rowMatches[1] {
+		t.Fatalf("expected 'slow request' not to match")
+	}
+
+	lv.HideNonMatching = true
+	lv.updateRows()
+	if len(lv.rows) != 2 || lv.rows[0] != 0 || lv.rows[1] != 3 {
+		t.Fatalf("expected only matching rows '[0 3]', got '%v'", lv.rows)
+	}
+}
+
+func TestLogViewer_SearchFuncRespectsColumns(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.Search = "main.run"
+	lv.updateRows()
+	if lv.MatchCount() != 1 {
+		t.Fatalf("expected func match, got %v", lv.MatchCount())
+	}
+
+	lv.ShowFunc = false
+	lv.updateRows()
+	if lv.MatchCount() != 0 {
+		t.Fatalf("expected hidden func column not to match, got %v", lv.MatchCount())
+	}
+}
+
+func TestLogViewer_MatchNavigationWraps(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.Search = "server"
+	lv.updateRows()
+
+	lv.NextMatch()
+	if lv.current != 0 || lv.scrollToRow != 0 {
+		t.Fatalf("expected first match, got %v", lv.current)
+	}
+	lv.NextMatch()
+	lv.NextMatch()
+	if lv.current != 0 {
+		t.Fatalf("expected wrap to first match, got %v", lv.current)
+	}
+	lv.PreviousMatch()
+	if lv.current != 1 || lv.scrollToRow != 2 {
+		t.Fatalf("expected last match at row 2, got %v/%v", lv.current, lv.scrollToRow)
+	}
+
+	// changing the search resets navigation
+	lv.Search = "slow"
+	lv.updateRows()
+	if lv.current != -1 {
+		t.Fatalf("expected navigation reset, got %v", lv.current)
+	}
+}
+
+func TestLogViewer_SourceFilter(t *testing.T) {
+	buffer := NewLogBuffer(16)
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "a", Func: "main.run"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "b", Func: "main.run", Channel: "net"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "c", Func: "audio.open"})
+	lv := NewLogViewer(buffer)
+	lv.updateRows()
+
+	sources := lv.Sources()
+	if len(sources) != 3 || sources[0] != "audio.open" || sources[1] != "main.run" || sources[2] != "net" {
+		t.Fatalf("expected sorted sources, got '%v'", sources)
+	}
+
+	lv.SetSourceVisible("net", false)
+	lv.updateRows()
+	if len(lv.rows) != 2 || lv.rows[0] != 0 || lv.rows[1] != 2 {
+		t.Fatalf("expected rows '[0 2]', got '%v'", lv.rows)
+	}
+
+	lv.SetSourceVisible("net", true)
+	lv.updateRows()
+	if len(lv.rows) != 3 {
+		t.Fatalf("expected all rows, got '%v'", lv.rows)
+	}
+}
+
+func TestLogViewer_LevelVisibility(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.SetLevelVisible(slog.LevelWarn, false)
+	lv.updateRows()
+	if len(lv.rows) != 2 || lv.rows[0] != 0 || lv.rows[1] != 3 {
+		t.Fatalf("expected info and error rows, got '%v'", lv.rows)
+	}
+
+	// enabling debug lowers the filter but keeps warnings hidden
+	lv.SetLevelVisible(slog.LevelDebug, true)
+	lv.updateRows()
+	if lv.LevelFilter != slog.LevelDebug || lv.LevelVisible(slog.LevelWarn) || !lv.LevelVisible(slog.LevelInfo) {
+		t.Fatalf("unexpected level visibility")
+	}
+	if len(lv.rows) != 3 {
+		t.Fatalf("expected 3 rows, got '%v'", lv.rows)
+	}
+}
+
+func TestSlogHandler_CapturesChannel(t *testing.T) {
+	buffer := NewLogBuffer(16)
+	handler := NewSlogHandler(buffer, &SlogHandlerOptions{MinLevel: slog.LevelInfo, StartTime: time.Now()})
+
+	rec := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	rec.AddAttrs(slog.String(dl.ChannelKey, "net"), slog.Int("port", 80))
+	if err := handler.Handle(context.Background(), rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := buffer.Messages()[0]
+	if msg.Channel != "net" {
+		t.Fatalf("expected channel 'net', got '%v'", msg.Channel)
+	}
+	if _, found := parseFields(t, msg.Fields)[dl.ChannelKey]; found {
+		t.Fatalf("expected channel to be excluded from fields")
+	}
+}
+
+func TestLogViewer_ClickSelection(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.updateRows() // rows: info, warn, error
+
+	lv.clickRow(0, false, false)
+	lv.clickRow(2, true, false)
+	if msgs := lv.SelectedMessages(); len(msgs) != 3 {
+		t.Fatalf("expected shift-click to select 3 lines, got %v", len(msgs))
+	}
+
+	lv.clickRow(1, false, true)
+	msgs := lv.SelectedMessages()
+	if len(msgs) != 2 || msgs[0].Message != "starting server" || msgs[1].Message != "server failed" {
+		t.Fatalf("expected ctrl-click to deselect the middle line, got '%v'", msgs)
+	}
+
+	lv.clickRow(1, false, false)
+	if msgs := lv.SelectedMessages(); len(msgs) != 1 || msgs[0].Message != "slow request" {
+		t.Fatalf("expected click to select only the clicked line, got '%v'", msgs)
+	}
+
+	lv.ClearSelection()
+	if lv.SelectedText() != "" {
+		t.Fatalf("expected empty selection")
+	}
+}
+
+func TestLogViewer_SelectionFollowsBufferWrap(t *testing.T) {
+	buffer := NewLogBuffer(3)
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "a"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "b"})
+	lv := NewLogViewer(buffer)
+	lv.updateRows()
+	lv.clickRow(1, false, false)
+
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "c"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "d"})
+	lv.updateRows()
+	if msgs := lv.SelectedMessages(); len(msgs) != 1 || msgs[0].Message != "b" {
+		t.Fatalf("expected 'b' to stay selected, got '%v'", msgs)
+	}
+
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "e"})
+	lv.updateRows()
+	if len(lv.selected) != 0 {
+		t.Fatalf("expected evicted line to be dropped from the selection")
+	}
+}
+
+func TestLogViewer_SelectedFieldsJSON(t *testing.T) {
+	buffer := NewLogBuffer(8)
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "a", Fields: `{"port":80}`})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "b"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "c", Fields: `{"host":"x"}`})
+	lv := NewLogViewer(buffer)
+	lv.updateRows()
+
+	lv.clickRow(0, false, false)
+	if got := lv.SelectedFieldsJSON(); got != `{"port":80}` {
+		t.Fatalf("expected single fields object, got '%v'", got)
+	}
+
+	lv.SelectAll()
+	var parsed []map[string]interface{}
+	if err := json.Unmarshal([]byte(lv.SelectedFieldsJSON()), &parsed); err != nil || len(parsed) != 2 {
+		t.Fatalf("expected array of 2 field objects, got '%v' (%v)", lv.SelectedFieldsJSON(), err)
+	}
+	if lines := lv.SelectedText(); len(lines) == 0 || lines[len(lines)-1] != '\n' {
+		t.Fatalf("expected newline-terminated text, got '%v'", lines)
+	}
+}
+
+func TestParseLogFields(t *testing.T) {
+	fields := parseLogFields(`{"name":"srv","port":8080,"tls":true,"peer":null,"tags":["a", "b"]}`)
+	expected := []logField{
+		{key: "name", value: "srv", kind: logFieldString},
+		{key: "port", value: "8080", kind: logFieldNumber},
+		{key: "tls", value: "true", kind: logFieldBool},
+		{key: "peer", value: "null", kind: logFieldNull},
+		{key: "tags", value: `["a","b"]`, kind: logFieldObject},
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %v fields, got '%v'", len(expected), fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Fatalf("field %v: expected '%v', got '%v'", i, expected[i], fields[i])
+		}
+	}
+
+	if parseLogFields("not json") != nil || parseLogFields(`["a"]`) != nil {
+		t.Fatalf("expected nil for non-object fields")
+	}
+}
+
+func TestLogViewer_ExpandableEntries(t *testing.T) {
+	buffer := NewLogBuffer(2)
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "short"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "first\nsecond"})
+	lv := NewLogViewer(buffer)
+	lv.updateRows()
+
+	msgs := buffer.Messages()
+	if lv.expandable(&msgs[0]) || !lv.expandable(&msgs[1]) {
+		t.Fatalf("expected only the multi-line message to be expandable")
+	}
+	long := LogMessage{Fields: `{"payload":"` + string(make([]byte, LogExpandFieldsLength)) + `"}`}
+	if !lv.expandable(&long) {
+		t.Fatalf("expected large fields to be expandable")
+	}
+
+	lv.clickRow(1, false, false)
+	lv.ExpandSelection()
+	if !lv.expanded[msgs[1].seq] {
+		t.Fatalf("expected selected entry to expand")
+	}
+
+	// expansion state is dropped once the entry leaves the buffer
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "a"})
+	buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "b"})
+	lv.updateRows()
+	if len(lv.expanded) != 0 {
+		t.Fatalf("expected evicted entry to be forgotten")
+	}
+}
+
+func TestLogViewer_FollowPausesOnScrollUp(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.updateRows()
+
+	if !lv.trackScroll(100, 100) {
+		t.Fatalf("expected to follow at the bottom")
+	}
+	if lv.trackScroll(60, 100) || !lv.Paused() {
+		t.Fatalf("expected scrolling up to pause following")
+	}
+
+	lv.Buffer.Add(LogMessage{Level: slog.LevelInfo, Message: "new one"})
+	lv.Buffer.Add(LogMessage{Level: slog.LevelDebug, Message: "filtered"})
+	lv.updateRows()
+	if n := lv.newMessages(); n != 1 {
+		t.Fatalf("expected 1 new displayed message, got %v", n)
+	}
+
+	// content growth while paused does not resume
+	if lv.trackScroll(60, 140) {
+		t.Fatalf("expected to stay paused")
+	}
+	// scrolling back down to the bottom resumes
+	if !lv.trackScroll(140, 140) || lv.Paused() {
+		t.Fatalf("expected scrolling to the bottom to resume following")
+	}
+
+	lv.trackScroll(100, 140)
+	lv.Follow()
+	if !lv.trackScroll(100, 140) {
+		t.Fatalf("expected Follow to resume following")
+	}
+}
+
+func TestLogViewer_FollowIgnoresShrinkingContent(t *testing.T) {
+	lv := newSearchTestViewer()
+	lv.trackScroll(100, 100)
+	// clearing the buffer clamps the scroll position to the new maximum
+	if !lv.trackScroll(0, 0) || lv.Paused() {
+		t.Fatalf("expected shrinking content not to pause following")
+	}
+
+	lv.AutoScroll = false
+	if lv.trackScroll(0, 0) {
+		t.Fatalf("expected no following with AutoScroll disabled")
+	}
+}
+
+func TestSlogHandler_Conformance(t *testing.T) {
+	buffer := NewLogBuffer(64)
+	handler := NewSlogHandler(buffer, &SlogHandlerOptions{MinLevel: slog.LevelDebug})
+
+	results := func() []map[string]any {
+		var entries []map[string]any
+		for _, msg := range buffer.Messages() {
+			entry := map[string]any{}
+			if msg.Fields != "" {
+				if err := json.Unmarshal([]byte(msg.Fields), &entry); err != nil {
+					t.Fatalf("invalid fields '%v': %v", msg.Fields, err)
+				}
+			}
+			entry[slog.MessageKey] = msg.Message
+			entry[slog.LevelKey] = msg.Level
+			if !msg.Time.IsZero() {
+				entry[slog.TimeKey] = msg.Time
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+	if err := slogtest.TestHandler(handler, results); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlogHandler_GroupsAreIndependent(t *testing.T) {
+	buffer := NewLogBuffer(16)
+	base := slog.New(NewSlogHandler(buffer, nil)).With("app", "dfx")
+	server := base.WithGroup("server").With("port", 80)
+
+	server.Info("a", "conn", 1)
+	base.Info("b", "conn", 2)
+	server.WithGroup("tls").Info("c", "version", "1.3")
+
+	messages := buffer.Messages()
+	a := parseFields(t, messages[0].Fields)
+	if a["app"] != "dfx" || a["server"].(map[string]interface{})["conn"] != float64(1) {
+		t.Fatalf("unexpected grouped fields '%v'", messages[0].Fields)
+	}
+	b := parseFields(t, messages[1].Fields)
+	if _, found := b["server"]; found || b["conn"] != float64(2) {
+		t.Fatalf("expected base logger to be unaffected by derived groups, got '%v'", messages[1].Fields)
+	}
+	c := parseFields(t, messages[2].Fields)
+	tls := c["server"].(map[string]interface{})["tls"].(map[string]interface{})
+	if tls["version"] != "1.3" || c["server"].(map[string]interface{})["port"] != float64(80) {
+		t.Fatalf("unexpected nested fields '%v'", messages[2].Fields)
+	}
+}
+
+func logMessages(buffer *LogBuffer) []string {
+	var out []string
+	for _, msg := range buffer.All() {
+		out = append(out, msg.Message)
+	}
+	return out
+}
+
+func TestLogBuffer_Ring(t *testing.T) {
+	buffer := NewLogBuffer(4)
+	for _, m := range []string{"a", "b", "c"} {
+		buffer.Add(LogMessage{Message: m})
+	}
+	if got := logMessages(buffer); strings.Join(got, "") != "abc" {
+		t.Fatalf("expected 'abc' before wrapping, got %v", got)
+	}
+	for _, m := range []string{"d", "e", "f"} {
+		buffer.Add(LogMessage{Message: m})
+	}
+	if got := logMessages(buffer); strings.Join(got, "") != "cdef" {
+		t.Fatalf("expected 'cdef' after wrapping, got %v", got)
+	}
+	if len(buffer.messages) != 4 {
+		t.Fatalf("expected storage to stop growing at the maximum size, got %d", len(buffer.messages))
+	}
+	for n, want := range map[int]string{-1: "cdef", 0: "", 1: "f", 3: "def", 9: "cdef"} {
+		got := ""
+		for _, msg := range buffer.Tail(n) {
+			got += msg.Message
+		}
+		if got != want {
+			t.Fatalf("expected tail %d to be '%v', got '%v'", n, want, got)
+		}
+	}
+
+	indices := []int{}
+	buffer.Range(func(index int, msg *LogMessage) bool {
+		indices = append(indices, index)
+		return index < 2
+	})
+	if len(indices) != 3 || indices[2] != 2 {
+		t.Fatalf("expected range to stop after index 2, got %v", indices)
+	}
+
+	buffer.Clear()
+	if buffer.Count() != 0 || len(logMessages(buffer)) != 0 {
+		t.Fatal("expected clear to empty the buffer")
+	}
+	buffer.Add(LogMessage{Message: "g"})
+	if got := logMessages(buffer); len(got) != 1 || got[0] != "g" {
+		t.Fatalf("expected 'g' after clearing, got %v", got)
+	}
+}
+
+func BenchmarkLogBuffer_Add(b *testing.B) {
+	buffer := NewLogBuffer(10_000)
+	msg := LogMessage{Level: slog.LevelInfo, Message: "request handled"}
+	b.ReportAllocs()
+	for b.Loop() {
+		buffer.Add(msg)
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "msgs/s")
+}
+
+func BenchmarkLogBuffer_AddParallel(b *testing.B) {
+	buffer := NewLogBuffer(10_000)
+	msg := LogMessage{Level: slog.LevelInfo, Message: "request handled"}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buffer.Add(msg)
+		}
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "msgs/s")
+}
+
+func BenchmarkSlogHandler(b *testing.B) {
+	logger := slog.New(NewSlogHandler(NewLogBuffer(10_000), nil))
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Info("request handled", "status", 200, "path", "/api")
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "msgs/s")
+}
+
+func BenchmarkLogBuffer_Tail(b *testing.B) {
+	buffer := NewLogBuffer(100_000)
+	for i := 0; i < 150_000; i++ {
+		buffer.Add(LogMessage{Message: "request handled"})
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		buffer.Tail(DebugLogTail)
+	}
+}
@@ -0,0 +1,215 @@
+package dfx
+
+import (
+	"unicode"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// common input masks
+const (
+	MaskTime     = "##:##:##"        // hours, minutes and seconds
+	MaskDate     = "####-##-##"      // ISO date
+	MaskIPv4     = "999.999.999.999" // dotted quad; type "." to end a short octet
+	MaskHexColor = "\\#HHHHHH"       // rgb hex color
+)
+
+// passwordRevealed holds the password inputs whose text is shown, by id.
+var passwordRevealed = make(map[imgui.ID]bool)
+
+// PasswordInput is a text input showing its text as masked characters, with a button
+// that reveals it. the text can be pasted with Ctrl+V or from the right-click menu, but
+// not copied or cut. returns (newValue, changed) following dfx conventions.
+func PasswordInput(label string, value string) (string, bool) {
+	imgui.PushIDStr(label)
+	defer imgui.PopID()
+	id := imgui.IDStr("reveal")
+	revealed := passwordRevealed[id]
+
+	spacing := imgui.CurrentStyle().ItemInnerSpacing().X
+	icon, tooltip := fonts.ICON_VISIBILITY, T("dfx.password.show")
+	if revealed {
+		icon, tooltip = fonts.ICON_VISIBILITY_OFF, T("dfx.password.hide")
+	}
+	imgui.SetNextItemWidth(max(imgui.CalcItemWidth()-IconButtonWidth(icon, "")-spacing, 1))
+	flags := imgui.InputTextFlagsNone
+	if !revealed {
+		flags |= imgui.InputTextFlagsPassword
+	}
+	newValue, changed := inputText("##password", value, flags, nil)
+	if imgui.BeginPopupContextItem() {
+		if imgui.MenuItemBoolV(T("dfx.password.paste"), "", false, imgui.ClipboardText() != "") {
+			newValue, changed = imgui.ClipboardText(), true
+		}
+		imgui.EndPopup()
+	}
+
+	imgui.SameLineV(0, spacing)
+	if IconToggle(icon, "", tooltip, &revealed) {
+		if revealed {
+			passwordRevealed[id] = true
+		} else {
+			delete(passwordRevealed, id)
+		}
+	}
+
+	if text := visibleLabel(label); text != "" {
+		imgui.SameLineV(0, spacing)
+		imgui.AlignTextToFramePadding()
+		imgui.TextUnformatted(text)
+	}
+	return newValue, changed && newValue != value
+}
+
+// MaskedInput is a text input constrained to a pattern. in mask, '#' takes a digit, '9'
+// an optional digit (a run of them needs at least one), 'A' a letter, '*' a letter or
+// digit and 'H' a hex digit; any other character, or one escaped with '\', is a literal
+// inserted as the text reaches it. rejected characters are dropped as they are typed, and
+// typing a literal skips the optional slots before it, so MaskIPv4 accepts "10.0.0.1".
+// returns (newValue, changed) with the text as shown, literals included; MaskComplete
+// reports whether the mask is filled.
+func MaskedInput(label string, value string, mask string) (string, bool) {
+	shown := ApplyMask(mask, value)
+	newValue, _ := inputText(label, shown, imgui.InputTextFlagsCallbackEdit, func(data imgui.InputTextCallbackData) int {
+		text := data.Buf()
+		formatted := ApplyMask(mask, text)
+		if formatted == text {
+			return 0
+		}
+		cursor := len(ApplyMask(mask, text[:min(int(data.CursorPos()), len(text))]))
+		data.DeleteChars(0, data.BufTextLen())
+		data.InsertChars(0, formatted)
+		data.SetCursorPos(int32(cursor))
+		return 0
+	})
+	newValue = ApplyMask(mask, newValue)
+	return newValue, newValue != value
+}
+
+// maskSlot is one position of a parsed mask.
+type maskSlot struct {
+	kind    rune // slot character ('#', '9', 'A', '*', 'H'), or 0 for a literal
+	literal rune
+}
+
+// parseMask splits a mask into slots, resolving escapes.
+func parseMask(mask string) []maskSlot {
+	var slots []maskSlot
+	escaped := false
+	for _, r := range mask {
+		switch {
+		case escaped:
+			slots = append(slots, maskSlot{literal: r})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#' || r == '9' || r == 'A' || r == '*' || r == 'H':
+			slots = append(slots, maskSlot{kind: r})
+		default:
+			slots = append(slots, maskSlot{literal: r})
+		}
+	}
+	return slots
+}
+
+// accepts reports whether a slot takes r.
+func (s maskSlot) accepts(r rune) bool {
+	switch s.kind {
+	case '#', '9':
+		return r >= '0' && r <= '9'
+	case 'A':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	case 'H':
+		return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+	}
+	return false
+}
+
+// ApplyMask fits text to mask as MaskedInput does as it is typed: characters fill the
+// slots they fit, literals are inserted before the next character, and characters that
+// fit nowhere are dropped.
+func ApplyMask(mask, text string) string {
+	out, _ := fitMask(parseMask(mask), text)
+	return out
+}
+
+// MaskComplete reports whether text fills mask: every literal and required slot, and at
+// least one slot of each run of optional digits.
+func MaskComplete(mask, text string) bool {
+	slots := parseMask(mask)
+	out, filled := fitMask(slots, text)
+	if out != text {
+		return false
+	}
+	for i := 0; i < len(slots); i++ {
+		if slots[i].kind != '9' {
+			if !filled[i] {
+				return false
+			}
+			continue
+		}
+		run := false
+		for ; i < len(slots) && slots[i].kind == '9'; i++ {
+			run = run || filled[i]
+		}
+		if !run {
+			return false
+		}
+		i--
+	}
+	return true
+}
+
+// fitMask fits text to slots, returning the result and which slots were filled.
+func fitMask(slots []maskSlot, text string) (string, []bool) {
+	out := make([]rune, 0, len(slots))
+	filled := make([]bool, len(slots))
+	slot := 0
+	for _, r := range text {
+		// literals are only inserted when the character after them fits
+		mark, markSlot := len(out), slot
+		consumed := false
+		for slot < len(slots) && !consumed {
+			s := slots[slot]
+			switch {
+			case s.kind == 0:
+				out = append(out, s.literal)
+				filled[slot] = true
+				slot++
+				consumed = r == s.literal
+				continue
+			case s.accepts(r):
+				out = append(out, r)
+				filled[slot] = true
+				slot++
+				consumed = true
+				continue
+			case s.kind == '9':
+				// a literal after the optional slots ends them early
+				next := slot
+				for next < len(slots) && slots[next].kind == '9' {
+					next++
+				}
+				if next < len(slots) && slots[next].kind == 0 && slots[next].literal == r {
+					out = append(out, r)
+					filled[next] = true
+					slot = next + 1
+					consumed = true
+					continue
+				}
+			}
+			break
+		}
+		if !consumed {
+			out = out[:mark]
+			for i := markSlot; i < slot; i++ {
+				filled[i] = false
+			}
+			slot = markSlot
+		}
+	}
+	return string(out), filled
+}
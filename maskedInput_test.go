@@ -0,0 +1,52 @@
+package dfx
+
+import "testing"
+
+func TestApplyMask(t *testing.T) {
+	tests := []struct {
+		mask, text, expected string
+	}{
+		{MaskTime, "123456", "12:34:56"},
+		{MaskTime, "12:34", "12:34"},
+		{MaskTime, "12", "12"},
+		{MaskTime, "12x", "12"},
+		{MaskTime, "1a2b3", "12:3"},
+		{MaskTime, "1234567", "12:34:56"},
+		{MaskDate, "20260314", "2026-03-14"},
+		{MaskIPv4, "10.0.0.1", "10.0.0.1"},
+		{MaskIPv4, "192168001001", "192.168.001.001"},
+		{MaskIPv4, "10..1", "10..1"},
+		{MaskIPv4, "10a.1", "10.1"},
+		{MaskHexColor, "ff8800", "#ff8800"},
+		{MaskHexColor, "#FG80", "#F80"},
+		{"AA-##", "ab12", "ab-12"},
+		{"**", "a1!", "a1"},
+		{"", "anything", ""},
+	}
+	for _, tt := range tests {
+		if got := ApplyMask(tt.mask, tt.text); got != tt.expected {
+			t.Errorf("ApplyMask(%q, %q) = %q, expected %q", tt.mask, tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestMaskComplete(t *testing.T) {
+	tests := []struct {
+		mask, text string
+		expected   bool
+	}{
+		{MaskTime, "12:34:56", true},
+		{MaskTime, "12:34:5", false},
+		{MaskTime, "123456", false}, // not as shown
+		{MaskIPv4, "10.0.0.1", true},
+		{MaskIPv4, "192.168.001.001", true},
+		{MaskIPv4, "10.0.0.", false},
+		{MaskIPv4, "10..0.1", false},
+		{MaskHexColor, "#ff8800", true},
+	}
+	for _, tt := range tests {
+		if got := MaskComplete(tt.mask, tt.text); got != tt.expected {
+			t.Errorf("MaskComplete(%q, %q) = %v, expected %v", tt.mask, tt.text, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package dfx
+
+import (
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// MatrixRouter constants
+const (
+	matrixLevelDrag = 0.005 // level change per pixel dragged
+	matrixLevelStep = 0.05  // level change per wheel notch
+)
+
+// RouterChannel is a source or destination of a MatrixRouter.
+type RouterChannel struct {
+	ID   string
+	Name string
+}
+
+// RoutePoint is a connected crosspoint of a Routing.
+type RoutePoint struct {
+	Source      string  `json:"source"`
+	Destination string  `json:"destination"`
+	Level       float32 `json:"level"`
+}
+
+// Routing is the serializable state of a MatrixRouter: the connected crosspoints with
+// their levels, and the muted and soloed sources and destinations, all by channel id.
+type Routing struct {
+	Points             []RoutePoint `json:"points,omitempty"`
+	MutedSources       []string     `json:"mutedSources,omitempty"`
+	SoloedSources      []string     `json:"soloedSources,omitempty"`
+	MutedDestinations  []string     `json:"mutedDestinations,omitempty"`
+	SoloedDestinations []string     `json:"soloedDestinations,omitempty"`
+}
+
+// Level returns the level of the crosspoint from source to destination, and whether it
+// is connected.
+func (r *Routing) Level(source, destination string) (float32, bool) {
+	if i := r.point(source, destination); i >= 0 {
+		return r.Points[i].Level, true
+	}
+	return 0, false
+}
+
+// Connect connects source to destination at level, or sets the level if connected.
+func (r *Routing) Connect(source, destination string, level float32) {
+	if i := r.point(source, destination); i >= 0 {
+		r.Points[i].Level = level
+		return
+	}
+	r.Points = append(r.Points, RoutePoint{Source: source, Destination: destination, Level: level})
+}
+
+// Disconnect disconnects source from destination.
+func (r *Routing) Disconnect(source, destination string) {
+	r.Points = slices.DeleteFunc(r.Points, func(p RoutePoint) bool {
+		return p.Source == source && p.Destination == destination
+	})
+}
+
+func (r *Routing) point(source, destination string) int {
+	return slices.IndexFunc(r.Points, func(p RoutePoint) bool {
+		return p.Source == source && p.Destination == destination
+	})
+}
+
+// SetSourceMuted mutes or unmutes a source.
+func (r *Routing) SetSourceMuted(id string, muted bool) {
+	setMember(&r.MutedSources, id, muted)
+}
+
+// SetSourceSoloed solos or unsolos a source.
+func (r *Routing) SetSourceSoloed(id string, soloed bool) {
+	setMember(&r.SoloedSources, id, soloed)
+}
+
+// SetDestinationMuted mutes or unmutes a destination.
+func (r *Routing) SetDestinationMuted(id string, muted bool) {
+	setMember(&r.MutedDestinations, id, muted)
+}
+
+// SetDestinationSoloed solos or unsolos a destination.
+func (r *Routing) SetDestinationSoloed(id string, soloed bool) {
+	setMember(&r.SoloedDestinations, id, soloed)
+}
+
+// Gain returns the level that actually passes from source to destination: the
+// crosspoint's level, or 0 when it is not connected, when either end is muted, or when
+// other sources (or destinations) are soloed and this one is not. solo wins over mute.
+func (r *Routing) Gain(source, destination string) float32 {
+	level, connected := r.Level(source, destination)
+	if !connected || !audible(source, r.MutedSources, r.SoloedSources) || !audible(destination, r.MutedDestinations, r.SoloedDestinations) {
+		return 0
+	}
+	return level
+}
+
+// audible applies mute and solo to a channel.
+func audible(id string, muted, soloed []string) bool {
+	if len(soloed) > 0 {
+		return slices.Contains(soloed, id)
+	}
+	return !slices.Contains(muted, id)
+}
+
+// setMember adds id to or removes it from list.
+func setMember(list *[]string, id string, member bool) {
+	found := slices.Contains(*list, id)
+	switch {
+	case member && !found:
+		*list = append(*list, id)
+	case !member && found:
+		*list = slices.DeleteFunc(*list, func(s string) bool { return s == id })
+	}
+}
+
+// MatrixRouter is a routing matrix: a row per source and a column per destination, with
+// a crosspoint at each intersection. click a crosspoint to connect or disconnect it; with
+// Levels, drag a connected crosspoint up or down (or use the wheel) to set its level,
+// shown as the height of its fill, and double-click to reset it. each row and column
+// header has mute and solo buttons. crosspoints that are connected but silenced by mute
+// or solo are drawn dimmed.
+type MatrixRouter struct {
+	Container
+	Sources      []RouterChannel
+	Destinations []RouterChannel
+	Routing      *Routing
+	Levels       bool                       // crosspoints have levels
+	DefaultLevel float32                    // level of new crosspoints (0 = 1)
+	CellSize     float32                    // crosspoint height, and minimum width (0 = 1.5 frame heights)
+	Format       func(level float32) string // level readout (nil = percent)
+	OnChange     func(routing *Routing)     // called after each change
+
+	dragged bool // the pressed crosspoint was dragged, so releasing it does not toggle
+}
+
+// NewMatrixRouter creates a router between sources and destinations with an empty
+// routing.
+func NewMatrixRouter(sources, destinations []RouterChannel) *MatrixRouter {
+	return &MatrixRouter{Container: Container{Visible: true}, Sources: sources, Destinations: destinations, Routing: &Routing{}}
+}
+
+// defaultLevel returns DefaultLevel, defaulting to 1.
+func (mr *MatrixRouter) defaultLevel() float32 {
+	if mr.DefaultLevel <= 0 {
+		return 1
+	}
+	return mr.DefaultLevel
+}
+
+// readout formats a level for tooltips.
+func (mr *MatrixRouter) readout(level float32) string {
+	if mr.Format != nil {
+		return mr.Format(level)
+	}
+	return CurrentFormat().FormatFloat(float64(level*100), 0) + "%"
+}
+
+// Draw implements Component.
+func (mr *MatrixRouter) Draw(state *State) {
+	if !mr.Visible || mr.Routing == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("matrixRouter", mr))
+	defer imgui.PopID()
+
+	style := imgui.CurrentStyle()
+	spacing := style.ItemInnerSpacing().X
+	button := imgui.FrameHeight()
+	cell := mr.CellSize
+	if cell <= 0 {
+		cell = button * 1.5
+	}
+	column := cell
+	rowHeader := float32(0)
+	for _, dst := range mr.Destinations {
+		column = max(column, imgui.CalcTextSize(dst.Name).X+style.FramePadding().X*2, button*2+spacing)
+	}
+	for _, src := range mr.Sources {
+		rowHeader = max(rowHeader, imgui.CalcTextSize(src.Name).X)
+	}
+	rowHeader += button*2 + spacing*3
+	headerHeight := imgui.TextLineHeightWithSpacing() + button + spacing
+
+	origin := imgui.CursorScreenPos()
+	hoverRow, hoverColumn := mr.hoveredCell(origin, rowHeader, headerHeight, column, cell)
+	changed := false
+
+	for j, dst := range mr.Destinations {
+		x := origin.X + rowHeader + float32(j)*column
+		mr.drawName(imgui.Vec2{X: x, Y: origin.Y}, column, dst.Name, j == hoverColumn)
+		imgui.SetCursorScreenPos(imgui.Vec2{X: x, Y: origin.Y + imgui.TextLineHeightWithSpacing()})
+		imgui.PushIDStr("dst_" + dst.ID)
+		if mr.drawMuteSolo(mr.Routing.MutedDestinations, mr.Routing.SoloedDestinations, dst.ID, mr.Routing.SetDestinationMuted, mr.Routing.SetDestinationSoloed) {
+			changed = true
+		}
+		imgui.PopID()
+	}
+
+	for i, src := range mr.Sources {
+		y := origin.Y + headerHeight + float32(i)*cell
+		imgui.SetCursorScreenPos(imgui.Vec2{X: origin.X, Y: y + (cell-button)/2})
+		imgui.PushIDStr("src_" + src.ID)
+		if mr.drawMuteSolo(mr.Routing.MutedSources, mr.Routing.SoloedSources, src.ID, mr.Routing.SetSourceMuted, mr.Routing.SetSourceSoloed) {
+			changed = true
+		}
+		imgui.PopID()
+		nameX := origin.X + button*2 + spacing*2
+		mr.drawName(imgui.Vec2{X: nameX, Y: y + (cell-imgui.TextLineHeight())/2}, rowHeader-nameX+origin.X, src.Name, i == hoverRow)
+
+		for j, dst := range mr.Destinations {
+			pos := imgui.Vec2{X: origin.X + rowHeader + float32(j)*column, Y: y}
+			if mr.drawCrosspoint(pos, imgui.Vec2{X: column - 1, Y: cell - 1}, src, dst, i == hoverRow || j == hoverColumn) {
+				changed = true
+			}
+		}
+	}
+
+	imgui.SetCursorScreenPos(origin)
+	imgui.Dummy(imgui.Vec2{X: rowHeader + float32(len(mr.Destinations))*column, Y: headerHeight + float32(len(mr.Sources))*cell})
+
+	if changed && mr.OnChange != nil {
+		mr.OnChange(mr.Routing)
+	}
+	drawContainerExtensions(&mr.Container, state)
+}
+
+// hoveredCell returns the row and column of the crosspoint under the pointer, or -1.
+func (mr *MatrixRouter) hoveredCell(origin imgui.Vec2, rowHeader, headerHeight, column, cell float32) (int, int) {
+	if !imgui.IsWindowHovered() {
+		return -1, -1
+	}
+	return matrixCell(imgui.MousePos().Sub(origin), rowHeader, headerHeight, column, cell, len(mr.Sources), len(mr.Destinations))
+}
+
+// matrixCell returns the row and column of the crosspoint at p (relative to the top
+// left of the matrix), or -1 for each when p is outside the crosspoints.
+func matrixCell(p imgui.Vec2, rowHeader, headerHeight, column, cell float32, rows, columns int) (int, int) {
+	x, y := p.X-rowHeader, p.Y-headerHeight
+	if x < 0 || y < 0 {
+		return -1, -1
+	}
+	row, col := int(y/cell), int(x/column)
+	if row >= rows || col >= columns {
+		return -1, -1
+	}
+	return row, col
+}
+
+// drawName draws a header name clipped to width, highlighted in the accent color when
+// its row or column is hovered.
+func (mr *MatrixRouter) drawName(pos imgui.Vec2, width float32, name string, highlight bool) {
+	color := imgui.CurrentStyle().Colors()[imgui.ColText]
+	if highlight {
+		color = ThemeColors().Accent
+	}
+	drawList := imgui.WindowDrawList()
+	drawList.PushClipRectV(pos, imgui.Vec2{X: pos.X + width, Y: pos.Y + imgui.FrameHeight()}, true)
+	drawList.AddTextVec2(pos, imgui.ColorConvertFloat4ToU32(color), name)
+	drawList.PopClipRect()
+}
+
+// drawMuteSolo draws the mute and solo buttons of a channel. returns true when either
+// was toggled.
+func (mr *MatrixRouter) drawMuteSolo(muted, soloed []string, id string, setMuted, setSoloed func(string, bool)) bool {
+	changed := false
+	isMuted, isSoloed := slices.Contains(muted, id), slices.Contains(soloed, id)
+	if routerToggle(T("dfx.router.mute"), T("dfx.router.muteTooltip"), isMuted, ThemeColors().Error) {
+		setMuted(id, !isMuted)
+		changed = true
+	}
+	imgui.SameLineV(0, imgui.CurrentStyle().ItemInnerSpacing().X)
+	if routerToggle(T("dfx.router.solo"), T("dfx.router.soloTooltip"), isSoloed, ThemeColors().Warning) {
+		setSoloed(id, !isSoloed)
+		changed = true
+	}
+	return changed
+}
+
+// routerToggle draws a square button filled with color while on. returns true when
+// clicked.
+func routerToggle(label, tooltip string, on bool, color imgui.Vec4) bool {
+	if on {
+		imgui.PushStyleColorVec4(imgui.ColButton, color)
+		imgui.PushStyleColorVec4(imgui.ColButtonHovered, color)
+	}
+	size := imgui.FrameHeight()
+	clicked := imgui.ButtonV(label+"##"+tooltip, imgui.Vec2{X: size, Y: size})
+	if on {
+		imgui.PopStyleColorV(2)
+	}
+	imgui.SetItemTooltip(tooltip)
+	return clicked
+}
+
+// drawCrosspoint draws and handles the crosspoint from src to dst. returns true when the
+// routing changed.
+func (mr *MatrixRouter) drawCrosspoint(pos, size imgui.Vec2, src, dst RouterChannel, crosshair bool) bool {
+	r := mr.Routing
+	imgui.SetCursorScreenPos(pos)
+	imgui.PushIDStr(src.ID + "\x00" + dst.ID)
+	imgui.InvisibleButton("##crosspoint", size)
+	imgui.PopID()
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+	level, connected := r.Level(src.ID, dst.ID)
+	changed := false
+
+	if imgui.IsItemActivated() {
+		mr.dragged = false
+	}
+	if mr.Levels && connected {
+		newLevel := level
+		if active && imgui.IsMouseDraggingV(imgui.MouseButtonLeft, 2) {
+			mr.dragged = true
+			newLevel -= imgui.CurrentIO().MouseDelta().Y * matrixLevelDrag
+		}
+		if hovered {
+			newLevel += imgui.CurrentIO().MouseWheel() * matrixLevelStep
+			if imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+				newLevel, mr.dragged = mr.defaultLevel(), true
+			}
+		}
+		if newLevel = clamp(newLevel, 0, 1); newLevel != level {
+			r.Connect(src.ID, dst.ID, newLevel)
+			level, changed = newLevel, true
+		}
+	}
+	if imgui.IsItemDeactivated() && hovered && !mr.dragged {
+		if connected {
+			r.Disconnect(src.ID, dst.ID)
+		} else {
+			r.Connect(src.ID, dst.ID, mr.defaultLevel())
+			level = mr.defaultLevel()
+		}
+		connected, changed = !connected, true
+	}
+
+	colors := imgui.CurrentStyle().Colors()
+	frame := colors[imgui.ColFrameBg]
+	switch {
+	case hovered:
+		frame = colors[imgui.ColFrameBgHovered]
+	case crosshair:
+		frame = colors[imgui.ColFrameBgActive]
+	}
+	drawList := imgui.WindowDrawList()
+	maxPos := pos.Add(size)
+	drawList.AddRectFilled(pos, maxPos, imgui.ColorConvertFloat4ToU32(frame))
+	if connected {
+		fill := ThemeColors().Accent
+		if r.Gain(src.ID, dst.ID) == 0 {
+			fill = ThemeColors().Muted
+		}
+		inset := size.Y / 5
+		top := pos.Y + inset
+		if mr.Levels {
+			top = maxPos.Y - inset - (size.Y-inset*2)*level
+		}
+		drawList.AddRectFilled(imgui.Vec2{X: pos.X + inset, Y: top}, imgui.Vec2{X: maxPos.X - inset, Y: maxPos.Y - inset}, imgui.ColorConvertFloat4ToU32(fill))
+	}
+	if hovered {
+		tooltip := src.Name + " -> " + dst.Name
+		if connected && mr.Levels {
+			tooltip += ": " + mr.readout(level)
+		}
+		imgui.SetTooltip(tooltip)
+	}
+	return changed
+}
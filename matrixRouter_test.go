@@ -0,0 +1,86 @@
+package dfx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestRoutingConnect(t *testing.T) {
+	r := &Routing{}
+	r.Connect("in1", "out1", 0.5)
+	r.Connect("in1", "out1", 0.8)
+	if level, connected := r.Level("in1", "out1"); !connected || level != 0.8 || len(r.Points) != 1 {
+		t.Errorf("expected one crosspoint at 0.8, got %v", r.Points)
+	}
+	r.Disconnect("in1", "out1")
+	if _, connected := r.Level("in1", "out1"); connected {
+		t.Error("expected the crosspoint disconnected")
+	}
+}
+
+func TestRoutingGain(t *testing.T) {
+	r := &Routing{}
+	r.Connect("in1", "out1", 0.5)
+	r.Connect("in2", "out1", 1)
+	r.Connect("in2", "out2", 1)
+
+	r.SetSourceMuted("in1", true)
+	if r.Gain("in1", "out1") != 0 || r.Gain("in2", "out1") != 1 {
+		t.Error("expected the muted source silenced and the others not")
+	}
+
+	// solo wins over mute, and silences the sources that are not soloed
+	r.SetSourceSoloed("in1", true)
+	if r.Gain("in1", "out1") != 0.5 || r.Gain("in2", "out1") != 0 {
+		t.Errorf("expected only the soloed source audible, got %v and %v", r.Gain("in1", "out1"), r.Gain("in2", "out1"))
+	}
+	r.SetSourceSoloed("in1", false)
+	r.SetSourceMuted("in1", false)
+
+	r.SetDestinationSoloed("out2", true)
+	if r.Gain("in2", "out1") != 0 || r.Gain("in2", "out2") != 1 {
+		t.Error("expected only the soloed destination audible")
+	}
+	if r.Gain("in1", "out2") != 0 {
+		t.Error("expected no gain through a disconnected crosspoint")
+	}
+}
+
+func TestRoutingJSON(t *testing.T) {
+	r := &Routing{}
+	r.Connect("in1", "out2", 0.25)
+	r.SetDestinationMuted("out1", true)
+	r.SetDestinationMuted("out1", true)
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored Routing
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&restored, r) || len(restored.MutedDestinations) != 1 {
+		t.Errorf("expected the routing to round trip, got %s", data)
+	}
+}
+
+func TestMatrixCell(t *testing.T) {
+	tests := []struct {
+		p           imgui.Vec2
+		row, column int
+	}{
+		{imgui.Vec2{X: 105, Y: 45}, 0, 0},
+		{imgui.Vec2{X: 170, Y: 95}, 2, 1},
+		{imgui.Vec2{X: 50, Y: 45}, -1, -1},   // row header
+		{imgui.Vec2{X: 105, Y: 10}, -1, -1},  // column header
+		{imgui.Vec2{X: 105, Y: 200}, -1, -1}, // below the last row
+	}
+	for _, tt := range tests {
+		if row, column := matrixCell(tt.p, 100, 40, 60, 20, 4, 3); row != tt.row || column != tt.column {
+			t.Errorf("matrixCell(%v) = %v, %v, expected %v, %v", tt.p, row, column, tt.row, tt.column)
+		}
+	}
+}
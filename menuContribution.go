@@ -0,0 +1,95 @@
+package dfx
+
+import "reflect"
+
+// MenuContribution is implemented by components that add menus to the app's menu bar and
+// items to its toolbar while they are drawn, such as the component of the active
+// workspace, instead of the app switching its menus by hand. contributions are gathered
+// from the components drawn in the previous frame, in drawing order.
+type MenuContribution interface {
+	// ContributeMenus draws menus into the main menu bar, after Config.MenuBar. a menu
+	// begun with the label of one already drawn (imgui.BeginMenu("File")) adds its items
+	// to the end of that menu.
+	ContributeMenus(state *State)
+
+	// ContributeToolbar returns the items added to ActionBars that show contributions.
+	ContributeToolbar() []*ActionBarItem
+}
+
+// Contribution is a MenuContribution built from a menu function and toolbar items, for
+// contributing on behalf of a component (see Workspace.SetContribution).
+type Contribution struct {
+	Menus   func(state *State) // draws the contributed menus (nil = none)
+	Toolbar []*ActionBarItem   // contributed toolbar items
+}
+
+// ContributeMenus implements MenuContribution.
+func (c *Contribution) ContributeMenus(state *State) {
+	if c.Menus != nil {
+		c.Menus(state)
+	}
+}
+
+// ContributeToolbar implements MenuContribution.
+func (c *Contribution) ContributeToolbar() []*ActionBarItem {
+	return c.Toolbar
+}
+
+// contributions tracks the MenuContributions drawn each frame. it is only used from the UI
+// thread.
+var contributions contributionTracker
+
+// contributionTracker collects the contributions of the components drawn in a frame, and
+// keeps those of the last complete frame as the active ones.
+type contributionTracker struct {
+	frame  []MenuContribution // drawn so far this frame
+	active []MenuContribution // drawn in the frame before
+}
+
+// drawn adds comp when it contributes and is visible.
+func (t *contributionTracker) drawn(comp Component) {
+	c, ok := comp.(MenuContribution)
+	if !ok || !componentVisible(comp) {
+		return
+	}
+	if reflect.TypeOf(c).Comparable() {
+		for _, existing := range t.frame {
+			if existing == c {
+				return
+			}
+		}
+	}
+	t.frame = append(t.frame, c)
+}
+
+// endFrame makes the contributions drawn this frame the active ones.
+func (t *contributionTracker) endFrame() {
+	clear(t.active)
+	t.active, t.frame = t.frame, t.active[:0]
+}
+
+// drawMenus draws the menus of the active contributions.
+func (t *contributionTracker) drawMenus(state *State) {
+	for _, c := range t.active {
+		c.ContributeMenus(state)
+	}
+}
+
+// appendToolbar appends the toolbar items of the active contributions to items, each
+// group after a divider.
+func (t *contributionTracker) appendToolbar(items []*ActionBarItem) []*ActionBarItem {
+	for _, c := range t.active {
+		contributed := c.ContributeToolbar()
+		if len(contributed) == 0 {
+			continue
+		}
+		if len(items) > 0 {
+			items = append(items, contributionDivider)
+		}
+		items = append(items, contributed...)
+	}
+	return items
+}
+
+// contributionDivider separates contributed toolbar items from those before them.
+var contributionDivider = &ActionBarItem{Separator: true}
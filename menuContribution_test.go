@@ -0,0 +1,87 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+)
+
+type contributingProbe struct {
+	Container
+	toolbar []*ActionBarItem
+}
+
+func (p *contributingProbe) Draw(state *State)                   {}
+func (p *contributingProbe) ContributeMenus(state *State)        {}
+func (p *contributingProbe) ContributeToolbar() []*ActionBarItem { return p.toolbar }
+
+func TestContributions_ActiveAfterFrame(t *testing.T) {
+	var tracker contributionTracker
+	a := &contributingProbe{Container: Container{Visible: true}, toolbar: []*ActionBarItem{{Label: "a"}}}
+	b := &contributingProbe{Container: Container{Visible: true}, toolbar: []*ActionBarItem{{Label: "b"}}}
+	hidden := &contributingProbe{toolbar: []*ActionBarItem{{Label: "hidden"}}}
+
+	tracker.drawn(a)
+	tracker.drawn(a)
+	tracker.drawn(hidden)
+	tracker.drawn(NewFunc(func(state *State) {}))
+	tracker.drawn(b)
+	if got := tracker.appendToolbar(nil); len(got) != 0 {
+		t.Fatalf("contributions apply from the end of the frame: expected empty, got '%v'", got)
+	}
+
+	tracker.endFrame()
+	own := &ActionBarItem{Label: "own"}
+	items := tracker.appendToolbar([]*ActionBarItem{own})
+	if !slices.Equal(items, []*ActionBarItem{own, contributionDivider, a.toolbar[0], contributionDivider, b.toolbar[0]}) {
+		t.Fatalf("expected '%v', got '%v'", []*ActionBarItem{own, contributionDivider, a.toolbar[0], contributionDivider, b.toolbar[0]}, items)
+	}
+	if got := tracker.appendToolbar(nil); !slices.Equal(got, []*ActionBarItem{a.toolbar[0], contributionDivider, b.toolbar[0]}) {
+		t.Fatalf("expected '%v', got '%v'", []*ActionBarItem{a.toolbar[0], contributionDivider, b.toolbar[0]}, got)
+	}
+
+	// not drawn in the next frame, no longer contributing
+	tracker.endFrame()
+	if got := tracker.appendToolbar(nil); len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
+
+func TestContribution_Funcs(t *testing.T) {
+	drawn := 0
+	item := &ActionBarItem{Label: "x"}
+	c := &Contribution{Menus: func(state *State) { drawn++ }, Toolbar: []*ActionBarItem{item}}
+	c.ContributeMenus(nil)
+	if drawn != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, drawn)
+	}
+	if got := c.ContributeToolbar(); !slices.Equal(got, []*ActionBarItem{item}) {
+		t.Fatalf("expected '%v', got '%v'", []*ActionBarItem{item}, got)
+	}
+	(&Contribution{}).ContributeMenus(nil)
+}
+
+func TestWorkspace_ContributionFollowsCurrent(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("edit", "Edit", NewFunc(func(state *State) {}))
+	ws.Add("mix", "Mix", NewFunc(func(state *State) {}))
+	if got := ws.ContributeToolbar(); got != nil {
+		t.Fatalf("expected nil, got '%v'", got)
+	}
+
+	item := &ActionBarItem{Label: "solo"}
+	if !ws.SetContribution("mix", &Contribution{Toolbar: []*ActionBarItem{item}}) {
+		t.Fatal("expected ws.SetContribution(\"mix\", &Contribution{Toolbar: []*ActionBarItem{item}})")
+	}
+	if ws.SetContribution("missing", &Contribution{}) {
+		t.Fatal("unexpected ws.SetContribution(\"missing\", &Contribution{})")
+	}
+	if got := ws.ContributeToolbar(); got != nil {
+		t.Fatalf("expected nil, got '%v'", got)
+	}
+
+	ws.Switch("mix")
+	if got := ws.ContributeToolbar(); !slices.Equal(got, []*ActionBarItem{item}) {
+		t.Fatalf("expected '%v', got '%v'", []*ActionBarItem{item}, got)
+	}
+	ws.ContributeMenus(nil)
+}
@@ -0,0 +1,192 @@
+package midi
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Binding maps a control to a MIDI continuous controller.
+type Binding struct {
+	Channel    uint8 // 0-15
+	Controller uint8 // 0-127
+}
+
+func (b Binding) String() string {
+	return fmt.Sprintf("ch%d cc%d", b.Channel+1, b.Controller)
+}
+
+// Control connects the control with id (any stable string, such as the control's label)
+// to its MIDI binding. call it each frame after drawing the control, with the control's
+// value normalized to 0..1 and whether the user changed it:
+//
+//	gain, changed = dfx.FaderN("gain", gain, params)
+//	gain, changed = mm.Control("gain", gain, changed)
+//
+// when the bound controller moved since the last frame the received value is returned
+// with changed set; otherwise a change made by the user is sent to the open outputs as a
+// control change, so motorized faders and LED rings follow. unbound controls pass
+// through unchanged.
+func (m *Manager) Control(id string, value float32, changed bool) (float32, bool) {
+	b, bound := m.bindings[id]
+	if !bound {
+		return value, changed
+	}
+	if received, found := m.received[id]; found {
+		delete(m.received, id)
+		return received, true
+	}
+	if changed {
+		_ = m.Send(CC(b.Channel, b.Controller, uint8(math.Round(float64(clamp01(value))*127))))
+	}
+	return value, changed
+}
+
+// ControlF is Control for a value in the range min..max.
+func (m *Manager) ControlF(id string, value, min, max float32, changed bool) (float32, bool) {
+	if max == min {
+		return value, changed
+	}
+	normalized, changed := m.Control(id, (value-min)/(max-min), changed)
+	return min + normalized*(max-min), changed
+}
+
+// Learn arms MIDI learn for the control with id: the next control change received binds
+// its controller to the control. arming another control replaces the pending learn.
+func (m *Manager) Learn(id string) {
+	m.learning = id
+}
+
+// CancelLearn disarms a pending MIDI learn.
+func (m *Manager) CancelLearn() {
+	m.learning = ""
+}
+
+// Learning returns the id of the control waiting for MIDI learn, or "".
+func (m *Manager) Learning() string {
+	return m.learning
+}
+
+// Bind binds the control with id to a controller, replacing any existing binding of
+// either; a controller drives one control.
+func (m *Manager) Bind(id string, b Binding) {
+	for other, existing := range m.bindings {
+		if existing == b && other != id {
+			delete(m.bindings, other)
+		}
+	}
+	m.bindings[id] = b
+	m.bound(id)
+}
+
+// Unbind removes the binding of the control with id.
+func (m *Manager) Unbind(id string) {
+	if _, found := m.bindings[id]; !found {
+		return
+	}
+	delete(m.bindings, id)
+	delete(m.received, id)
+	m.bound(id)
+}
+
+// BindingOf returns the binding of the control with id.
+func (m *Manager) BindingOf(id string) (Binding, bool) {
+	b, found := m.bindings[id]
+	return b, found
+}
+
+// LearnMenu adds a right-click menu to the last drawn control, offering MIDI learn and
+// removing the binding, and outlines the control while it waits for MIDI learn.
+func (m *Manager) LearnMenu(id string) {
+	if m.learning == id {
+		imgui.WindowDrawList().AddRectV(imgui.ItemRectMin(), imgui.ItemRectMax(), imgui.ColorU32Col(imgui.ColDragDropTarget), 0, imgui.DrawFlagsNone, 2)
+	}
+	if !imgui.BeginPopupContextItemV("##midiLearn", imgui.PopupFlagsMouseButtonRight) {
+		return
+	}
+	defer imgui.EndPopup()
+	if m.learning == id {
+		if imgui.MenuItemBool("Cancel MIDI Learn") {
+			m.CancelLearn()
+		}
+	} else if imgui.MenuItemBool("MIDI Learn") {
+		m.Learn(id)
+	}
+	b, bound := m.bindings[id]
+	if imgui.MenuItemBoolV(fmt.Sprintf("Forget MIDI Binding (%v)", b), "", false, bound) {
+		m.Unbind(id)
+	}
+}
+
+// controlChange completes a pending MIDI learn and records the value for a bound control.
+func (m *Manager) controlChange(msg Message) {
+	b := Binding{Channel: msg.Channel, Controller: msg.Key}
+	if m.learning != "" {
+		id := m.learning
+		m.learning = ""
+		m.Bind(id, b)
+	}
+	for id, existing := range m.bindings {
+		if existing == b {
+			m.received[id] = msg.Normalized()
+		}
+	}
+}
+
+func (m *Manager) bound(id string) {
+	if m.OnBind != nil {
+		m.OnBind(id)
+	}
+}
+
+// CaptureState returns the bindings in a form the dfx config codecs can save; it has the
+// same shape as dfx.StatefulComponent, so a Manager can be persisted alongside components.
+func (m *Manager) CaptureState() map[string]any {
+	bindings := make(map[string]any, len(m.bindings))
+	for id, b := range m.bindings {
+		bindings[id] = map[string]any{"channel": int(b.Channel), "controller": int(b.Controller)}
+	}
+	return map[string]any{"bindings": bindings}
+}
+
+// RestoreState replaces the bindings with those saved by CaptureState.
+func (m *Manager) RestoreState(state map[string]any) {
+	bindings, ok := state["bindings"].(map[string]any)
+	if !ok {
+		return
+	}
+	clear(m.bindings)
+	clear(m.received)
+	for id, v := range bindings {
+		saved, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		channel, ok1 := stateInt(saved["channel"])
+		controller, ok2 := stateInt(saved["controller"])
+		if ok1 && ok2 && channel >= 0 && channel < 16 && controller >= 0 && controller < 128 {
+			m.bindings[id] = Binding{Channel: uint8(channel), Controller: uint8(controller)}
+		}
+	}
+}
+
+// stateInt reads a number from restored state, whatever numeric type the config codec
+// decoded it as.
+func stateInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func clamp01(v float32) float32 {
+	return min(max(v, 0), 1)
+}
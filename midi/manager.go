@@ -0,0 +1,189 @@
+package midi
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Driver adapts a MIDI library to the Manager. ports are identified by name.
+type Driver interface {
+	// Inputs returns the names of the available input ports.
+	Inputs() ([]string, error)
+	// Outputs returns the names of the available output ports.
+	Outputs() ([]string, error)
+	// OpenInput opens an input port. receive is called with each raw message and may be
+	// called from any goroutine; closing the returned closer stops delivery.
+	OpenInput(name string, receive func(data []byte)) (io.Closer, error)
+	// OpenOutput opens an output port.
+	OpenOutput(name string) (Output, error)
+}
+
+// Output is an open MIDI output port.
+type Output interface {
+	Send(data []byte) error
+	Close() error
+}
+
+// Manager owns the open MIDI ports of an application. input arrives on driver goroutines
+// and is queued; Update, called once per frame from the UI thread (e.g. from
+// dfx.Config.OnTick), dispatches it to OnMessage and to bound controls. every other
+// method must also be called from the UI thread.
+type Manager struct {
+	Driver    Driver
+	OnMessage func(msg Message) // called from Update for every message received
+	OnBind    func(id string)   // called when a control is bound or unbound, e.g. to save the bindings
+
+	mu    sync.Mutex
+	queue []Message
+
+	inputs   map[string]io.Closer
+	outputs  map[string]Output
+	bindings map[string]Binding
+	received map[string]float32 // values of bound controls received since they were last drawn
+	learning string
+}
+
+// NewManager creates a manager with no open ports.
+func NewManager(driver Driver) *Manager {
+	return &Manager{
+		Driver:   driver,
+		inputs:   make(map[string]io.Closer),
+		outputs:  make(map[string]Output),
+		bindings: make(map[string]Binding),
+		received: make(map[string]float32),
+	}
+}
+
+// Inputs returns the names of the available input ports.
+func (m *Manager) Inputs() ([]string, error) {
+	return m.Driver.Inputs()
+}
+
+// Outputs returns the names of the available output ports.
+func (m *Manager) Outputs() ([]string, error) {
+	return m.Driver.Outputs()
+}
+
+// OpenInput starts receiving from the input port with name. opening an open port does
+// nothing.
+func (m *Manager) OpenInput(name string) error {
+	if _, found := m.inputs[name]; found {
+		return nil
+	}
+	closer, err := m.Driver.OpenInput(name, func(data []byte) {
+		if msg, ok := Decode(data); ok {
+			msg.Port = name
+			m.mu.Lock()
+			m.queue = append(m.queue, msg)
+			m.mu.Unlock()
+		}
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error opening midi input '%v'", name)
+	}
+	m.inputs[name] = closer
+	return nil
+}
+
+// OpenOutput opens the output port with name; Send and bound controls send to every open
+// output. opening an open port does nothing.
+func (m *Manager) OpenOutput(name string) error {
+	if _, found := m.outputs[name]; found {
+		return nil
+	}
+	out, err := m.Driver.OpenOutput(name)
+	if err != nil {
+		return errors.Wrapf(err, "error opening midi output '%v'", name)
+	}
+	m.outputs[name] = out
+	return nil
+}
+
+// CloseInput stops receiving from the input port with name.
+func (m *Manager) CloseInput(name string) error {
+	closer, found := m.inputs[name]
+	if !found {
+		return nil
+	}
+	delete(m.inputs, name)
+	return errors.Wrapf(closer.Close(), "error closing midi input '%v'", name)
+}
+
+// CloseOutput closes the output port with name.
+func (m *Manager) CloseOutput(name string) error {
+	out, found := m.outputs[name]
+	if !found {
+		return nil
+	}
+	delete(m.outputs, name)
+	return errors.Wrapf(out.Close(), "error closing midi output '%v'", name)
+}
+
+// OpenInputs returns the names of the open input ports, sorted.
+func (m *Manager) OpenInputs() []string {
+	return sortedKeys(m.inputs)
+}
+
+// OpenOutputs returns the names of the open output ports, sorted.
+func (m *Manager) OpenOutputs() []string {
+	return sortedKeys(m.outputs)
+}
+
+// Close closes every open port, returning the first error.
+func (m *Manager) Close() error {
+	var first error
+	for _, name := range m.OpenInputs() {
+		if err := m.CloseInput(name); err != nil && first == nil {
+			first = err
+		}
+	}
+	for _, name := range m.OpenOutputs() {
+		if err := m.CloseOutput(name); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Send sends a message to every open output port, returning the first error.
+func (m *Manager) Send(msg Message) error {
+	data := msg.Encode()
+	var first error
+	for _, name := range m.OpenOutputs() {
+		if err := m.outputs[name].Send(data); err != nil && first == nil {
+			first = errors.Wrapf(err, "error sending to midi output '%v'", name)
+		}
+	}
+	return first
+}
+
+// Update dispatches the messages received since the last call: control changes complete
+// a pending MIDI learn and update bound controls, then every message is passed to
+// OnMessage.
+func (m *Manager) Update() {
+	m.mu.Lock()
+	queue := m.queue
+	m.queue = nil
+	m.mu.Unlock()
+
+	for _, msg := range queue {
+		if msg.Kind == ControlChange {
+			m.controlChange(msg)
+		}
+		if m.OnMessage != nil {
+			m.OnMessage(msg)
+		}
+	}
+}
+
+func sortedKeys[V any](ports map[string]V) []string {
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,133 @@
+package midi
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+// testDriver is a Driver with one input and one output that records what is sent.
+type testDriver struct {
+	receive func([]byte)
+	sent    [][]byte
+}
+
+func (d *testDriver) Inputs() ([]string, error)  { return []string{"keys"}, nil }
+func (d *testDriver) Outputs() ([]string, error) { return []string{"motors"}, nil }
+
+func (d *testDriver) OpenInput(name string, receive func([]byte)) (io.Closer, error) {
+	d.receive = receive
+	return io.NopCloser(nil), nil
+}
+
+func (d *testDriver) OpenOutput(name string) (Output, error) { return d, nil }
+
+func (d *testDriver) Send(data []byte) error {
+	d.sent = append(d.sent, data)
+	return nil
+}
+
+func (d *testDriver) Close() error { return nil }
+
+func testManager(t *testing.T) (*Manager, *testDriver) {
+	driver := &testDriver{}
+	m := NewManager(driver)
+	if err := m.OpenInput("keys"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.OpenOutput("motors"); err != nil {
+		t.Fatal(err)
+	}
+	return m, driver
+}
+
+func TestManagerDispatch(t *testing.T) {
+	m, driver := testManager(t)
+	var got []Message
+	m.OnMessage = func(msg Message) { got = append(got, msg) }
+
+	go driver.receive([]byte{0x90, 60, 100})
+	done := make(chan bool)
+	go func() {
+		driver.receive([]byte{0xf8}) // clock messages are dropped
+		done <- true
+	}()
+	<-done
+	for len(got) == 0 {
+		m.Update()
+	}
+	if len(got) != 1 || got[0].Kind != NoteOn || got[0].Port != "keys" {
+		t.Fatalf("unexpected messages %+v", got)
+	}
+
+	if !slices.Equal(m.OpenInputs(), []string{"keys"}) || !slices.Equal(m.OpenOutputs(), []string{"motors"}) {
+		t.Fatalf("unexpected open ports")
+	}
+	if err := m.Close(); err != nil || len(m.OpenInputs()) != 0 {
+		t.Fatalf("expected ports closed")
+	}
+}
+
+func TestManagerLearnAndControl(t *testing.T) {
+	m, driver := testManager(t)
+	binds := 0
+	m.OnBind = func(id string) { binds++ }
+
+	// unbound controls pass through
+	if v, changed := m.Control("gain", 0.5, true); v != 0.5 || !changed || len(driver.sent) != 0 {
+		t.Fatalf("expected unbound control untouched")
+	}
+
+	m.Learn("gain")
+	driver.receive([]byte{0xb2, 7, 127})
+	m.Update()
+	if b, ok := m.BindingOf("gain"); !ok || b != (Binding{Channel: 2, Controller: 7}) || m.Learning() != "" || binds != 1 {
+		t.Fatalf("expected gain learned as ch3 cc7, got %v %v", b, ok)
+	}
+	if v, changed := m.Control("gain", 0.5, false); v != 1 || !changed {
+		t.Fatalf("expected learning message applied, got %v %v", v, changed)
+	}
+
+	// incoming values win over the drawn value, and are not echoed back
+	driver.receive([]byte{0xb2, 7, 0})
+	m.Update()
+	if v, changed := m.ControlF("gain", 5, -10, 10, false); v != -10 || !changed || len(driver.sent) != 0 {
+		t.Fatalf("expected -10 from cc, got %v %v", v, changed)
+	}
+
+	// user changes are sent
+	m.Control("gain", 0.5, true)
+	if len(driver.sent) != 1 || !slices.Equal(driver.sent[0], []byte{0xb2, 7, 64}) {
+		t.Fatalf("expected cc sent, got %x", driver.sent)
+	}
+
+	// a controller drives one control
+	m.Bind("pan", Binding{Channel: 2, Controller: 7})
+	if _, ok := m.BindingOf("gain"); ok {
+		t.Fatalf("expected gain unbound when pan took its controller")
+	}
+}
+
+func TestManagerState(t *testing.T) {
+	m, _ := testManager(t)
+	m.Bind("gain", Binding{Channel: 0, Controller: 7})
+	m.Bind("pan", Binding{Channel: 15, Controller: 10})
+	state := m.CaptureState()
+
+	// config codecs may decode numbers as float64
+	saved := state["bindings"].(map[string]any)
+	saved["pan"] = map[string]any{"channel": float64(15), "controller": float64(10)}
+	saved["bad"] = map[string]any{"channel": 16, "controller": 1}
+
+	other := NewManager(&testDriver{})
+	other.RestoreState(state)
+	if b, _ := other.BindingOf("pan"); b != (Binding{Channel: 15, Controller: 10}) {
+		t.Fatalf("unexpected pan binding %v", b)
+	}
+	if _, ok := other.BindingOf("gain"); !ok {
+		t.Fatalf("expected gain binding restored")
+	}
+	if _, ok := other.BindingOf("bad"); ok {
+		t.Fatalf("expected invalid binding dropped")
+	}
+}
@@ -0,0 +1,125 @@
+// Package midi connects dfx controls to MIDI hardware. it has no MIDI dependency of its
+// own: a Driver adapts whichever MIDI library the application uses (rtmidi, portmidi,
+// CoreMIDI, ...) to a handful of byte-oriented calls, and the Manager handles the rest:
+// port management, dispatching input onto the UI thread, sending control changes and
+// MIDI learn.
+package midi
+
+import (
+	"fmt"
+)
+
+// Kind is the type of a MIDI channel message.
+type Kind int
+
+const (
+	NoteOff Kind = iota
+	NoteOn
+	ControlChange
+	ProgramChange
+	PitchBend
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NoteOff:
+		return "note off"
+	case NoteOn:
+		return "note on"
+	case ControlChange:
+		return "cc"
+	case ProgramChange:
+		return "program"
+	case PitchBend:
+		return "pitch bend"
+	}
+	return "unknown"
+}
+
+// Message is a decoded MIDI channel message.
+type Message struct {
+	Port    string // input port the message arrived on (empty for outgoing messages)
+	Kind    Kind
+	Channel uint8 // 0-15
+	Key     uint8 // note number, controller number or program
+	Value   uint8 // velocity or controller value, 0-127
+	Bend    int16 // pitch bend, -8192 to 8191
+}
+
+// Normalized returns the message value scaled to 0..1 (pitch bend to -1..1).
+func (m Message) Normalized() float32 {
+	if m.Kind == PitchBend {
+		return float32(m.Bend) / 8192
+	}
+	return float32(m.Value) / 127
+}
+
+func (m Message) String() string {
+	switch m.Kind {
+	case NoteOn, NoteOff:
+		return fmt.Sprintf("ch%d %v %d vel %d", m.Channel+1, m.Kind, m.Key, m.Value)
+	case ControlChange:
+		return fmt.Sprintf("ch%d cc%d = %d", m.Channel+1, m.Key, m.Value)
+	case ProgramChange:
+		return fmt.Sprintf("ch%d program %d", m.Channel+1, m.Key)
+	case PitchBend:
+		return fmt.Sprintf("ch%d pitch bend %d", m.Channel+1, m.Bend)
+	}
+	return m.Kind.String()
+}
+
+// CC creates a control change message.
+func CC(channel, controller, value uint8) Message {
+	return Message{Kind: ControlChange, Channel: channel & 0x0f, Key: controller & 0x7f, Value: value & 0x7f}
+}
+
+// Decode parses a raw MIDI channel message. system and running-status messages are not
+// supported and return false. a note on with velocity 0 is decoded as a note off.
+func Decode(data []byte) (Message, bool) {
+	if len(data) < 2 || data[0] < 0x80 || data[0] >= 0xf0 {
+		return Message{}, false
+	}
+	msg := Message{Channel: data[0] & 0x0f, Key: data[1] & 0x7f}
+	status := data[0] & 0xf0
+	if status != 0xc0 && len(data) < 3 {
+		return Message{}, false
+	}
+	switch status {
+	case 0x80:
+		msg.Kind, msg.Value = NoteOff, data[2]&0x7f
+	case 0x90:
+		msg.Kind, msg.Value = NoteOn, data[2]&0x7f
+		if msg.Value == 0 {
+			msg.Kind = NoteOff
+		}
+	case 0xb0:
+		msg.Kind, msg.Value = ControlChange, data[2]&0x7f
+	case 0xc0:
+		msg.Kind = ProgramChange
+	case 0xe0:
+		msg.Kind, msg.Key = PitchBend, 0
+		msg.Bend = int16(int(data[1]&0x7f)|int(data[2]&0x7f)<<7) - 8192
+	default:
+		return Message{}, false
+	}
+	return msg, true
+}
+
+// Encode returns the raw bytes of a message.
+func (m Message) Encode() []byte {
+	channel := m.Channel & 0x0f
+	switch m.Kind {
+	case NoteOff:
+		return []byte{0x80 | channel, m.Key & 0x7f, m.Value & 0x7f}
+	case NoteOn:
+		return []byte{0x90 | channel, m.Key & 0x7f, m.Value & 0x7f}
+	case ControlChange:
+		return []byte{0xb0 | channel, m.Key & 0x7f, m.Value & 0x7f}
+	case ProgramChange:
+		return []byte{0xc0 | channel, m.Key & 0x7f}
+	case PitchBend:
+		bend := int(m.Bend) + 8192
+		return []byte{0xe0 | channel, byte(bend & 0x7f), byte(bend >> 7 & 0x7f)}
+	}
+	return nil
+}
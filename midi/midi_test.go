@@ -0,0 +1,39 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeEncode(t *testing.T) {
+	cases := []struct {
+		data []byte
+		want Message
+	}{
+		{[]byte{0x90, 60, 100}, Message{Kind: NoteOn, Key: 60, Value: 100}},
+		{[]byte{0x83, 60, 0}, Message{Kind: NoteOff, Channel: 3, Key: 60}},
+		{[]byte{0xb1, 7, 127}, Message{Kind: ControlChange, Channel: 1, Key: 7, Value: 127}},
+		{[]byte{0xc2, 5}, Message{Kind: ProgramChange, Channel: 2, Key: 5}},
+		{[]byte{0xe0, 0, 0x40}, Message{Kind: PitchBend}},
+		{[]byte{0xe0, 0x7f, 0x7f}, Message{Kind: PitchBend, Bend: 8191}},
+	}
+	for _, c := range cases {
+		msg, ok := Decode(c.data)
+		if !ok || msg != c.want {
+			t.Fatalf("Decode(%x) = %+v, %v; expected %+v", c.data, msg, ok, c.want)
+		}
+		if encoded := msg.Encode(); !bytes.Equal(encoded, c.data) {
+			t.Fatalf("expected %+v to encode as %x, got %x", msg, c.data, encoded)
+		}
+	}
+
+	// note on with velocity 0 is a note off
+	if msg, _ := Decode([]byte{0x90, 60, 0}); msg.Kind != NoteOff {
+		t.Fatalf("expected note off, got %v", msg.Kind)
+	}
+	for _, data := range [][]byte{{0xf8}, {0x3c, 0x40}, {0xb0, 7}, nil} {
+		if _, ok := Decode(data); ok {
+			t.Fatalf("expected %x rejected", data)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package dfx
+
+import "github.com/AllenDang/cimgui-go/imgui"
+
+// Minimap constants
+const (
+	MinimapMargin = 0.05 // share of the overview left empty around the content
+)
+
+// MinimapSource is a scrollable component a Minimap can give an overview of. positions
+// are in the source's own content coordinates, and the minimap keeps their aspect ratio.
+// NodeGraph, Timeline and ScrollArea are minimap sources.
+type MinimapSource interface {
+	MinimapBounds() (imgui.Vec2, imgui.Vec2)   // extent of the content, min and max corners
+	MinimapViewport() (imgui.Vec2, imgui.Vec2) // visible part of the content
+	MinimapScrollTo(origin imgui.Vec2)         // scroll the visible part's top left to origin
+}
+
+// MinimapPainter is a MinimapSource that draws its content in the overview; sources that
+// are not painters show their bounds as a plain rectangle.
+type MinimapPainter interface {
+	DrawMinimap(drawList *imgui.DrawList, toMinimap func(imgui.Vec2) imgui.Vec2)
+}
+
+// Minimap draws a scaled overview of a MinimapSource with its visible part outlined.
+// dragging the outline scrolls the source, and clicking elsewhere centers the visible part
+// on the click. the overview follows the source's content as it grows or shrinks.
+type Minimap struct {
+	Container
+	Source        MinimapSource
+	Width         float32    // overview width (0 = available width)
+	Height        float32    // overview height (0 = available height)
+	ViewportColor imgui.Vec4 // outline of the visible part (zero = theme accent)
+
+	dragging   bool
+	dragOffset imgui.Vec2       // pointer position within the visible part, in content units
+	dragView   minimapTransform // overview mapping frozen for the drag
+}
+
+// NewMinimap creates an overview of source.
+func NewMinimap(source MinimapSource) *Minimap {
+	return &Minimap{Container: Container{Visible: true}, Source: source}
+}
+
+// minimapTransform maps content coordinates into the overview rectangle.
+type minimapTransform struct {
+	origin imgui.Vec2 // screen position of min
+	min    imgui.Vec2 // content position at origin
+	scale  float32    // screen pixels per content unit
+}
+
+func (m minimapTransform) toMinimap(p imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: m.origin.X + (p.X-m.min.X)*m.scale, Y: m.origin.Y + (p.Y-m.min.Y)*m.scale}
+}
+
+func (m minimapTransform) toContent(p imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: m.min.X + (p.X-m.origin.X)/m.scale, Y: m.min.Y + (p.Y-m.origin.Y)/m.scale}
+}
+
+// fitMinimap returns the mapping that fits the rectangle lo..hi, with a margin, centered
+// in the overview at pos with size.
+func fitMinimap(lo, hi, pos, size imgui.Vec2) minimapTransform {
+	width, height := max(hi.X-lo.X, 1), max(hi.Y-lo.Y, 1)
+	usable := size.Mul(1 - 2*MinimapMargin)
+	scale := min(usable.X/width, usable.Y/height)
+	return minimapTransform{
+		origin: imgui.Vec2{X: pos.X + (size.X-width*scale)/2, Y: pos.Y + (size.Y-height*scale)/2},
+		min:    lo,
+		scale:  scale,
+	}
+}
+
+// unionRect returns the smallest rectangle holding both a and b.
+func unionRect(aMin, aMax, bMin, bMax imgui.Vec2) (imgui.Vec2, imgui.Vec2) {
+	return imgui.Vec2{X: min(aMin.X, bMin.X), Y: min(aMin.Y, bMin.Y)}, imgui.Vec2{X: max(aMax.X, bMax.X), Y: max(aMax.Y, bMax.Y)}
+}
+
+// Draw implements Component.
+func (mm *Minimap) Draw(state *State) {
+	if !mm.Visible || mm.Source == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("minimap", mm))
+	defer imgui.PopID()
+
+	size := imgui.ContentRegionAvail()
+	if mm.Width > 0 {
+		size.X = mm.Width
+	}
+	if mm.Height > 0 {
+		size.Y = mm.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton("##minimap", size)
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+
+	boundsMin, boundsMax := mm.Source.MinimapBounds()
+	viewMin, viewMax := mm.Source.MinimapViewport()
+	lo, hi := unionRect(boundsMin, boundsMax, viewMin, viewMax)
+	transform := fitMinimap(lo, hi, pos, size)
+	if mm.dragging {
+		transform = mm.dragView
+	}
+	mm.handleMouse(transform, viewMin, viewMax, hovered, active)
+
+	drawList := imgui.WindowDrawList()
+	colors := imgui.CurrentStyle().Colors()
+	drawList.PushClipRectV(pos, pos.Add(size), true)
+	drawList.AddRectFilled(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]))
+	if painter, ok := mm.Source.(MinimapPainter); ok {
+		painter.DrawMinimap(drawList, transform.toMinimap)
+	} else {
+		content := colors[imgui.ColBorder]
+		content.W *= 0.5
+		drawList.AddRectFilled(transform.toMinimap(boundsMin), transform.toMinimap(boundsMax), imgui.ColorConvertFloat4ToU32(content))
+	}
+	viewMin, viewMax = mm.Source.MinimapViewport()
+	outline := themeColor(mm.ViewportColor, ThemeColors().Accent)
+	drawList.AddRect(transform.toMinimap(viewMin), transform.toMinimap(viewMax), imgui.ColorConvertFloat4ToU32(outline))
+	outline.W *= 0.15
+	drawList.AddRectFilled(transform.toMinimap(viewMin), transform.toMinimap(viewMax), imgui.ColorConvertFloat4ToU32(outline))
+	drawList.PopClipRect()
+
+	drawContainerExtensions(&mm.Container, state)
+}
+
+// handleMouse drags the visible part, centering it on a click outside it first.
+func (mm *Minimap) handleMouse(transform minimapTransform, viewMin, viewMax imgui.Vec2, hovered, active bool) {
+	if !active {
+		mm.dragging = false
+		return
+	}
+	at := transform.toContent(imgui.MousePos())
+	if hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft) {
+		mm.dragging, mm.dragView = true, transform
+		if rectsOverlap(viewMin, viewMax, at, at) {
+			mm.dragOffset = at.Sub(viewMin)
+		} else {
+			mm.dragOffset = viewMax.Sub(viewMin).Mul(0.5)
+		}
+	}
+	if mm.dragging {
+		mm.Source.MinimapScrollTo(at.Sub(mm.dragOffset))
+	}
+}
@@ -0,0 +1,59 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestFitMinimap(t *testing.T) {
+	// 1000x500 content into a 200x200 overview: the width limits the scale
+	m := fitMinimap(imgui.Vec2{X: -100, Y: 0}, imgui.Vec2{X: 900, Y: 500}, imgui.Vec2{X: 10, Y: 20}, imgui.Vec2{X: 200, Y: 200})
+	if m.scale != 0.18 {
+		t.Errorf("expected scale 0.18, got %v", m.scale)
+	}
+	if p := m.toMinimap(imgui.Vec2{X: 400, Y: 250}); p.X != 110 || p.Y != 120 {
+		t.Errorf("expected the content centered at (110, 120), got %v", p)
+	}
+	if p := m.toContent(m.toMinimap(imgui.Vec2{X: 30, Y: 40})); p.X < 29.99 || p.X > 30.01 || p.Y < 39.99 || p.Y > 40.01 {
+		t.Errorf("expected the mapping to round trip, got %v", p)
+	}
+}
+
+func TestUnionRect(t *testing.T) {
+	lo, hi := unionRect(imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 10, Y: 10}, imgui.Vec2{X: -5, Y: 5}, imgui.Vec2{X: 5, Y: 20})
+	if lo != (imgui.Vec2{X: -5, Y: 0}) || hi != (imgui.Vec2{X: 10, Y: 20}) {
+		t.Errorf("expected (-5, 0)..(10, 20), got %v..%v", lo, hi)
+	}
+}
+
+func TestMinimapSources(t *testing.T) {
+	sa := NewScrollArea(nil)
+	sa.viewSize, sa.maxScroll, sa.scroll = imgui.Vec2{X: 100, Y: 200}, imgui.Vec2{Y: 800}, imgui.Vec2{Y: 300}
+	if _, hi := sa.MinimapBounds(); hi != (imgui.Vec2{X: 100, Y: 1000}) {
+		t.Errorf("expected 100x1000 of content, got %v", hi)
+	}
+	if lo, hi := sa.MinimapViewport(); lo.Y != 300 || hi.Y != 500 {
+		t.Errorf("expected 300..500 visible, got %v..%v", lo, hi)
+	}
+	sa.MinimapScrollTo(imgui.Vec2{X: 50, Y: 900})
+	if sa.pendingY != 800 || sa.pendingX != 0 {
+		t.Errorf("expected the scroll clamped to (0, 800), got (%v, %v)", sa.pendingX, sa.pendingY)
+	}
+
+	ng := NewNodeGraph(NewGraph())
+	ng.zoom, ng.pan, ng.viewSize = 2, imgui.Vec2{X: 10, Y: 20}, imgui.Vec2{X: 400, Y: 300}
+	if lo, hi := ng.MinimapViewport(); lo != ng.pan || hi != (imgui.Vec2{X: 210, Y: 170}) {
+		t.Errorf("expected (10, 20)..(210, 170), got %v..%v", lo, hi)
+	}
+
+	tl := NewTimeline(TimelineSeconds, &TimelineTrack{Height: 40, Clips: []*TimelineClip{{Start: 0, Length: 20}}}, &TimelineTrack{Height: 40})
+	tl.viewSize = imgui.Vec2{X: 500, Y: 60}
+	if _, hi := tl.MinimapBounds(); hi != (imgui.Vec2{X: 1000, Y: 80}) {
+		t.Errorf("expected 1000x80 of content, got %v", hi)
+	}
+	tl.MinimapScrollTo(imgui.Vec2{X: 250, Y: 50})
+	if tl.scrollX != 5 || tl.scrollY != 20 {
+		t.Errorf("expected scrolled to 5s and 20 pixels, got %v and %v", tl.scrollX, tl.scrollY)
+	}
+}
@@ -0,0 +1,136 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// window placement constants
+const (
+	placementGrabHeight = 32 // height of the strip along the top of a window that must stay reachable
+	placementMinVisible = 64 // width of that strip that must be on a monitor
+)
+
+// Monitor describes a connected monitor, in the screen coordinates used for window positions.
+type Monitor struct {
+	Index    int     // position in App.Monitors; 0 is the primary monitor
+	Area     Bounds  // the whole monitor
+	WorkArea Bounds  // the monitor without task bars, docks and menu bars
+	Scale    float32 // content scale (DPI scale)
+}
+
+// Contains returns true if the point x, y is on the monitor.
+func (m Monitor) Contains(x, y float32) bool {
+	return x >= m.Area.X && x < m.Area.X+m.Area.W && y >= m.Area.Y && y < m.Area.Y+m.Area.H
+}
+
+// Monitors returns the connected monitors. the list is filled in by the platform backend
+// once the first frame has started, so call it from OnTick, a component or a window callback.
+func (app *App) Monitors() []Monitor {
+	if app.backend == nil {
+		return nil
+	}
+	var monitors []Monitor
+	for i, pm := range imgui.CurrentPlatformIO().Monitors().Slice() {
+		pos, size := pm.MainPos(), pm.MainSize()
+		workPos, workSize := pm.WorkPos(), pm.WorkSize()
+		monitors = append(monitors, Monitor{
+			Index:    i,
+			Area:     Bounds{X: pos.X, Y: pos.Y, W: size.X, H: size.Y},
+			WorkArea: Bounds{X: workPos.X, Y: workPos.Y, W: workSize.X, H: workSize.Y},
+			Scale:    pm.DpiScale(),
+		})
+	}
+	return monitors
+}
+
+// WindowMonitor returns the monitor holding the center of the window.
+func (app *App) WindowMonitor() (Monitor, bool) {
+	x, y := app.GetWindowPos()
+	width, height := app.GetWindowSize()
+	return monitorAt(app.Monitors(), float32(x)+float32(width)/2, float32(y)+float32(height)/2)
+}
+
+// monitorAt returns the monitor holding the point x, y.
+func monitorAt(monitors []Monitor, x, y float32) (Monitor, bool) {
+	for _, m := range monitors {
+		if m.Contains(x, y) {
+			return m, true
+		}
+	}
+	return Monitor{}, false
+}
+
+// ValidateWindowConfig fits a saved window placement to the current monitors. a window whose
+// top strip is still reachable on a monitor keeps its position, moved down below the top of
+// that monitor's work area if needed; a window that would be off-screen, say because its
+// monitor was disconnected, is centered on its saved Monitor, or on the primary monitor when
+// that is gone. the size is clamped to the chosen monitor's work area. without monitors, cfg
+// is returned unchanged.
+func ValidateWindowConfig(cfg WindowConfig, monitors []Monitor) WindowConfig {
+	if len(monitors) == 0 {
+		return cfg
+	}
+	if m, ok := reachableMonitor(cfg, monitors); ok {
+		cfg.Width, cfg.Height = clampWindowSize(cfg.Width, cfg.Height, m.WorkArea)
+		if top := int(m.WorkArea.Y); cfg.Y < top {
+			cfg.Y = top
+		}
+		cfg.Monitor = m.Index
+		return cfg
+	}
+
+	m := monitors[0]
+	if cfg.Monitor > 0 && cfg.Monitor < len(monitors) {
+		m = monitors[cfg.Monitor]
+	}
+	cfg.Width, cfg.Height = clampWindowSize(cfg.Width, cfg.Height, m.WorkArea)
+	cfg.X = int(m.WorkArea.X + (m.WorkArea.W-float32(cfg.Width))/2)
+	cfg.Y = int(m.WorkArea.Y + (m.WorkArea.H-float32(cfg.Height))/2)
+	cfg.Monitor = m.Index
+	return cfg
+}
+
+// reachableMonitor returns the monitor showing most of the strip along the top of the
+// window, when enough of it is shown to grab the window.
+func reachableMonitor(cfg WindowConfig, monitors []Monitor) (Monitor, bool) {
+	grab := Bounds{X: float32(cfg.X), Y: float32(cfg.Y), W: float32(cfg.Width), H: float32(min(cfg.Height, placementGrabHeight))}
+	best, bestWidth := Monitor{}, float32(0)
+	for _, m := range monitors {
+		w, h := overlap(grab, m.WorkArea)
+		if h > 0 && w > bestWidth {
+			best, bestWidth = m, w
+		}
+	}
+	return best, bestWidth >= min(placementMinVisible, float32(cfg.Width))
+}
+
+// overlap returns the width and height of the intersection of a and b.
+func overlap(a, b Bounds) (float32, float32) {
+	w := min(a.X+a.W, b.X+b.W) - max(a.X, b.X)
+	h := min(a.Y+a.H, b.Y+b.H) - max(a.Y, b.Y)
+	return max(w, 0), max(h, 0)
+}
+
+// clampWindowSize limits a window size to a work area.
+func clampWindowSize(width, height int, area Bounds) (int, int) {
+	return min(width, int(area.W)), min(height, int(area.H))
+}
+
+// checkPlacement moves the window back onto a monitor when its restored placement is off
+// screen, once monitors are known on the first frame.
+func (app *App) checkPlacement() {
+	if app.placed || app.config.DisablePlacementCheck || app.backend == nil {
+		return
+	}
+	app.placed = true
+	x, y := app.GetWindowPos()
+	width, height := app.GetWindowSize()
+	current := WindowConfig{X: x, Y: y, Width: width, Height: height, Monitor: app.config.Monitor}
+	fitted := ValidateWindowConfig(current, app.Monitors())
+	if fitted.Width != width || fitted.Height != height {
+		app.backend.SetWindowSize(fitted.Width, fitted.Height)
+	}
+	if fitted.X != x || fitted.Y != y {
+		app.backend.SetWindowPos(fitted.X, fitted.Y)
+	}
+}
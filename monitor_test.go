@@ -0,0 +1,51 @@
+package dfx
+
+import "testing"
+
+func testMonitors() []Monitor {
+	return []Monitor{
+		{Index: 0, Area: Bounds{X: 0, Y: 0, W: 1920, H: 1080}, WorkArea: Bounds{X: 0, Y: 30, W: 1920, H: 1050}},
+		{Index: 1, Area: Bounds{X: 1920, Y: 0, W: 1280, H: 1024}, WorkArea: Bounds{X: 1920, Y: 0, W: 1280, H: 1024}},
+	}
+}
+
+func TestValidateWindowConfig_KeepsReachableWindow(t *testing.T) {
+	cfg := WindowConfig{X: 2000, Y: 100, Width: 800, Height: 600}
+	if got := ValidateWindowConfig(cfg, testMonitors()); got.X != 2000 || got.Y != 100 || got.Monitor != 1 {
+		t.Fatalf("expected window kept on monitor '1', got '%+v'", got)
+	}
+
+	// hanging off the right edge, but the title strip can still be grabbed
+	cfg = WindowConfig{X: 3000, Y: 100, Width: 800, Height: 600}
+	if got := ValidateWindowConfig(cfg, testMonitors()); got.X != 3000 {
+		t.Fatalf("expected partially visible window kept, got '%+v'", got)
+	}
+
+	// above the work area; moved down below the menu bar
+	cfg = WindowConfig{X: 100, Y: 10, Width: 800, Height: 600}
+	if got := ValidateWindowConfig(cfg, testMonitors()); got.X != 100 || got.Y != 30 {
+		t.Fatalf("expected window moved to '100,30', got '%+v'", got)
+	}
+}
+
+func TestValidateWindowConfig_RecentersOffscreenWindow(t *testing.T) {
+	// the second monitor was disconnected
+	cfg := WindowConfig{X: 2000, Y: 100, Width: 800, Height: 600, Monitor: 1}
+	got := ValidateWindowConfig(cfg, testMonitors()[:1])
+	if got.X != 560 || got.Y != 255 || got.Monitor != 0 {
+		t.Fatalf("expected window centered on the primary monitor, got '%+v'", got)
+	}
+
+	// off-screen, but its monitor is still connected
+	cfg = WindowConfig{X: -5000, Y: 100, Width: 2000, Height: 1200, Monitor: 1}
+	got = ValidateWindowConfig(cfg, testMonitors())
+	if got.Width != 1280 || got.Height != 1024 || got.X != 1920 || got.Y != 0 || got.Monitor != 1 {
+		t.Fatalf("expected window fitted to monitor '1', got '%+v'", got)
+	}
+
+	// no monitor information
+	cfg = WindowConfig{X: -5000, Y: 100, Width: 800, Height: 600}
+	if got := ValidateWindowConfig(cfg, nil); got != cfg {
+		t.Fatalf("expected unchanged config, got '%+v'", got)
+	}
+}
@@ -32,13 +32,20 @@ func NewMultiGrid() *MultiGrid {
 	}
 }
 
-// AddComponent adds a named component to the collection
+// AddComponent adds a named component to the collection, unmounting a component it
+// replaces (see Lifecycle).
 func (mg *MultiGrid) AddComponent(id string, component Component) {
+	if old, found := mg.components[id]; found && old != component {
+		UnmountComponent(old)
+	}
 	mg.components[id] = component
 }
 
-// RemoveComponent removes a component from the collection
+// RemoveComponent removes a component from the collection and unmounts it
 func (mg *MultiGrid) RemoveComponent(id string) {
+	if old, found := mg.components[id]; found {
+		UnmountComponent(old)
+	}
 	delete(mg.components, id)
 }
 
@@ -68,13 +75,7 @@ func (mg *MultiGrid) Draw(state *State) {
 		return
 	}
 
-	layoutState := &State{
-		Size:     state.Size,
-		Position: state.Position,
-		IO:       state.IO,
-		App:      state.App,
-		Parent:   mg,
-	}
+	layoutState := state.WithParent(mg)
 
 	// handle input first (for resize operations, etc)
 	if mg.layout != nil {
@@ -94,16 +95,43 @@ type FlexLayout struct {
 	arrangement [][]string // component IDs arranged in rows/columns
 	rowHeights  []int      // heights for each row (0 = auto-size)
 	colWidths   [][]int    // widths for each column in each row (0 = auto-size)
+	minSizes    map[string]MinSize
+	collapsed   map[flexCell]flexCollapse
+
+	// Collapsible enables snap-to-collapse: dragging a splitter well past a cell's minimum
+	// size collapses the cell to a thin restore bar. clicking the bar restores it.
+	Collapsible bool
 
 	// resizing state
-	dragging     bool
-	dragType     DragType
-	dragRowIndex int
-	dragColIndex int
-	dragRowPrev  int
-	dragColPrev  int
-	deltaRow     int
-	deltaCol     int
+	dragging       bool
+	dragSuppressed bool // the current press reset the layout; ignore it until released
+	dragType       DragType
+	dragRowIndex   int
+	dragColIndex   int
+	dragRowPrev    int
+	dragColPrev    int
+	deltaRow       int
+	deltaCol       int
+	dragOffset     int // accumulated drag distance since the splitter was grabbed
+	dragStartPrev  int // size of the cell before the splitter when grabbed
+	dragStartNext  int // size of the cell after the splitter when grabbed
+}
+
+// MinSize is the minimum content size of a component in a FlexLayout.
+type MinSize struct {
+	Width  int
+	Height int
+}
+
+// flexCell identifies a row (col == -1) or a column within a row.
+type flexCell struct {
+	row, col int
+}
+
+// flexCollapse remembers how to restore a collapsed cell.
+type flexCollapse struct {
+	restore int // size before collapsing
+	partner int // index of the neighbouring row/column that absorbed the space
 }
 
 type DragType int
@@ -115,10 +143,12 @@ const (
 )
 
 const (
-	multiGridMargin      = 2
-	multiGridSpacing     = 4
-	multiGridSplitWidth  = 10
-	multiGridSplitHeight = 11
+	multiGridMargin        = 2
+	multiGridSpacing       = 4
+	multiGridSplitWidth    = 10
+	multiGridSplitHeight   = 11
+	multiGridMinCellSize   = 24 // minimum content size when none is set
+	multiGridCollapsedSize = 8  // content size of a collapsed cell's restore bar
 )
 
 // NewFlexLayout creates a flexible layout with the given arrangement
@@ -127,6 +157,9 @@ func NewFlexLayout(arrangement [][]string) *FlexLayout {
 		arrangement: arrangement,
 		rowHeights:  make([]int, len(arrangement)),
 		colWidths:   make([][]int, len(arrangement)),
+		minSizes:    make(map[string]MinSize),
+		collapsed:   make(map[flexCell]flexCollapse),
+		Collapsible: true,
 	}
 
 	// initialize column width slices
@@ -177,16 +210,43 @@ func (fl *FlexLayout) SetColWidths(widths [][]int) {
 	}
 }
 
+// SetMinSize sets the minimum content size for a component. splitters stop at the
+// minimum, or collapse the cell when dragged well past it (see Collapsible).
+func (fl *FlexLayout) SetMinSize(componentID string, width, height int) {
+	fl.minSizes[componentID] = MinSize{Width: width, Height: height}
+}
+
+// ResetSizes returns all rows and columns to equal sizing and restores collapsed cells.
+func (fl *FlexLayout) ResetSizes() {
+	for i := range fl.rowHeights {
+		fl.rowHeights[i] = 0
+		fl.resetColumns(i)
+	}
+	fl.collapsed = make(map[flexCell]flexCollapse)
+}
+
+// RowCollapsed reports whether a row is collapsed.
+func (fl *FlexLayout) RowCollapsed(row int) bool {
+	_, collapsed := fl.collapsed[flexCell{row, -1}]
+	return collapsed
+}
+
+// ColumnCollapsed reports whether a column within a row is collapsed.
+func (fl *FlexLayout) ColumnCollapsed(row, col int) bool {
+	_, collapsed := fl.collapsed[flexCell{row, col}]
+	return collapsed
+}
+
 // HandleInput processes mouse input for resize operations
 func (fl *FlexLayout) HandleInput(state *State) {
 	// handle resize completion
 	if fl.dragging {
 		if fl.dragType == DragRow && fl.dragRowIndex >= 0 && fl.dragRowPrev >= 0 {
-			fl.rowHeights[fl.dragRowIndex] -= fl.deltaRow
-			fl.rowHeights[fl.dragRowPrev] += fl.deltaRow
+			fl.dragOffset += fl.deltaRow
+			fl.resizeRows(fl.dragRowPrev, fl.dragRowIndex)
 		} else if fl.dragType == DragColumn && fl.dragRowIndex >= 0 && fl.dragColIndex >= 0 && fl.dragColPrev >= 0 {
-			fl.colWidths[fl.dragRowIndex][fl.dragColIndex] -= fl.deltaCol
-			fl.colWidths[fl.dragRowIndex][fl.dragColPrev] += fl.deltaCol
+			fl.dragOffset += fl.deltaCol
+			fl.resizeColumns(fl.dragRowIndex, fl.dragColPrev, fl.dragColIndex)
 		}
 		fl.dragging = false
 		fl.dragType = DragNone
@@ -219,7 +279,21 @@ func (fl *FlexLayout) Arrange(components map[string]Component, state *State) {
 			if imgui.IsItemHovered() {
 				imgui.SetMouseCursor(imgui.MouseCursorResizeNS)
 			}
-			if imgui.IsItemActive() {
+			if imgui.IsItemActivated() {
+				fl.dragSuppressed = false
+				fl.dragOffset = 0
+				fl.dragStartPrev = fl.rowHeights[i-1]
+				fl.dragStartNext = fl.rowHeights[i]
+			}
+			if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+				// double-click resets rows to equal sizing
+				for r := range fl.rowHeights {
+					fl.rowHeights[r] = 0
+					delete(fl.collapsed, flexCell{r, -1})
+				}
+				fl.dragSuppressed = true
+			}
+			if imgui.IsItemActive() && !fl.dragSuppressed {
 				fl.dragging = true
 				fl.dragType = DragRow
 				fl.deltaRow = int(imgui.CurrentIO().MouseDelta().Y)
@@ -250,6 +324,15 @@ func (fl *FlexLayout) Arrange(components map[string]Component, state *State) {
 			imgui.SetCursorPos(cursor.Add(imgui.Vec2{X: 0, Y: multiGridSplitWidth}))
 		}
 
+		// a collapsed row is drawn as a restore bar across its width
+		if fl.RowCollapsed(i) {
+			if fl.drawRestoreBar(fmt.Sprintf("row_%d_restore", i), imgui.Vec2{X: rowSize.X, Y: multiGridCollapsedSize}) {
+				fl.restore(flexCell{i, -1})
+			}
+			cursor.Y += float32(rowHeight)
+			continue
+		}
+
 		// arrange columns in this row
 		fl.sizeColumns(state.Size, i)
 		colCursor := imgui.CursorPos()
@@ -269,7 +352,18 @@ func (fl *FlexLayout) Arrange(components map[string]Component, state *State) {
 				if imgui.IsItemHovered() {
 					imgui.SetMouseCursor(imgui.MouseCursorResizeEW)
 				}
-				if imgui.IsItemActive() {
+				if imgui.IsItemActivated() {
+					fl.dragSuppressed = false
+					fl.dragOffset = 0
+					fl.dragStartPrev = fl.colWidths[i][j-1]
+					fl.dragStartNext = fl.colWidths[i][j]
+				}
+				if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+					// double-click resets the row's columns to equal sizing
+					fl.resetColumns(i)
+					fl.dragSuppressed = true
+				}
+				if imgui.IsItemActive() && !fl.dragSuppressed {
 					fl.dragging = true
 					fl.dragType = DragColumn
 					fl.deltaCol = int(imgui.CurrentIO().MouseDelta().X)
@@ -301,8 +395,12 @@ func (fl *FlexLayout) Arrange(components map[string]Component, state *State) {
 				imgui.SetCursorPos(colCursor.Add(imgui.Vec2{X: multiGridSplitWidth, Y: 0}))
 			}
 
-			// draw the component
-			if component, exists := components[componentID]; exists {
+			// draw the component, or a restore bar when the column is collapsed
+			if fl.ColumnCollapsed(i, j) {
+				if fl.drawRestoreBar(fmt.Sprintf("row_%d_col_%d_restore", i, j), imgui.Vec2{X: multiGridCollapsedSize, Y: rowSize.Y}) {
+					fl.restore(flexCell{i, j})
+				}
+			} else if component, exists := components[componentID]; exists {
 				fl.drawComponent(component, colSize, componentID, state)
 			}
 
@@ -316,14 +414,7 @@ func (fl *FlexLayout) Arrange(components map[string]Component, state *State) {
 // drawComponent renders a component in a child window
 func (fl *FlexLayout) drawComponent(component Component, size imgui.Vec2, id string, state *State) {
 	if imgui.BeginChildStrV(fmt.Sprintf("mg_%s", id), size, 0, imgui.WindowFlagsNoScrollbar) {
-		childState := &State{
-			Size:     size,
-			Position: imgui.Vec2{},
-			IO:       imgui.CurrentIO(),
-			App:      state.App,
-			Parent:   state.Parent,
-		}
-		component.Draw(childState)
+		DrawChild(component, state.Child(size, imgui.Vec2{}))
 	}
 	imgui.EndChild()
 }
@@ -355,12 +446,19 @@ func (fl *FlexLayout) sizeRows(size imgui.Vec2) {
 		}
 	}
 
-	// distribute overage/underage
+	// distribute overage/underage (collapsed rows keep their size)
 	if allocated != maxY {
-		diff := maxY - allocated
-		sharePerRow := diff / len(fl.rowHeights)
+		var open []int
 		for i := range fl.rowHeights {
-			fl.rowHeights[i] += sharePerRow
+			if !fl.RowCollapsed(i) {
+				open = append(open, i)
+			}
+		}
+		if len(open) > 0 {
+			sharePerRow := (maxY - allocated) / len(open)
+			for _, i := range open {
+				fl.rowHeights[i] += sharePerRow
+			}
 		}
 	}
 }
@@ -393,14 +491,173 @@ func (fl *FlexLayout) sizeColumns(size imgui.Vec2, rowIndex int) {
 		}
 	}
 
-	// distribute overage/underage
+	// distribute overage/underage (collapsed columns keep their size)
 	if allocated != maxX {
-		diff := maxX - allocated
-		sharePerCol := diff / len(colWidths)
+		var open []int
 		for j := range colWidths {
-			colWidths[j] += sharePerCol
+			if !fl.ColumnCollapsed(rowIndex, j) {
+				open = append(open, j)
+			}
+		}
+		if len(open) > 0 {
+			sharePerCol := (maxX - allocated) / len(open)
+			for _, j := range open {
+				colWidths[j] += sharePerCol
+			}
+		}
+	}
+}
+
+// drawRestoreBar draws the bar shown in place of a collapsed cell. returns true when clicked.
+func (fl *FlexLayout) drawRestoreBar(id string, size imgui.Vec2) bool {
+	clicked := imgui.ButtonV("##"+id, size)
+	if imgui.IsItemHovered() {
+		imgui.SetMouseCursor(imgui.MouseCursorHand)
+		imgui.SetItemTooltip(T("dfx.grid.restore"))
+	}
+	return clicked
+}
+
+// resizeRows applies the current drag to the rows on either side of a splitter.
+func (fl *FlexLayout) resizeRows(prev, next int) {
+	a, b, collapseA, collapseB := resizeSplit(
+		fl.dragStartPrev, fl.dragStartNext, fl.dragOffset,
+		fl.rowMin(prev), fl.rowMin(next),
+		fl.rowOverhead(prev)+multiGridCollapsedSize, fl.rowOverhead(next)+multiGridCollapsedSize,
+		fl.Collapsible,
+	)
+	fl.rowHeights[prev], fl.rowHeights[next] = a, b
+	fl.setCollapsed(flexCell{prev, -1}, collapseA, next, fl.dragStartPrev)
+	fl.setCollapsed(flexCell{next, -1}, collapseB, prev, fl.dragStartNext)
+}
+
+// resizeColumns applies the current drag to the columns on either side of a splitter.
+func (fl *FlexLayout) resizeColumns(row, prev, next int) {
+	a, b, collapseA, collapseB := resizeSplit(
+		fl.dragStartPrev, fl.dragStartNext, fl.dragOffset,
+		fl.colMin(row, prev), fl.colMin(row, next),
+		fl.colOverhead(prev)+multiGridCollapsedSize, fl.colOverhead(next)+multiGridCollapsedSize,
+		fl.Collapsible,
+	)
+	fl.colWidths[row][prev], fl.colWidths[row][next] = a, b
+	fl.setCollapsed(flexCell{row, prev}, collapseA, next, fl.dragStartPrev)
+	fl.setCollapsed(flexCell{row, next}, collapseB, prev, fl.dragStartNext)
+}
+
+// setCollapsed records or clears the collapsed state of a cell.
+func (fl *FlexLayout) setCollapsed(cell flexCell, collapsed bool, partner, restore int) {
+	if !collapsed {
+		delete(fl.collapsed, cell)
+		return
+	}
+	if _, already := fl.collapsed[cell]; !already {
+		fl.collapsed[cell] = flexCollapse{restore: restore, partner: partner}
+	}
+}
+
+// restore expands a collapsed cell back to its previous size, taking the space from
+// the neighbour that absorbed it.
+func (fl *FlexLayout) restore(cell flexCell) {
+	entry, ok := fl.collapsed[cell]
+	if !ok {
+		return
+	}
+	delete(fl.collapsed, cell)
+
+	sizes := fl.rowHeights
+	partnerMin := fl.rowMin(entry.partner)
+	index := cell.row
+	if cell.col >= 0 {
+		sizes = fl.colWidths[cell.row]
+		partnerMin = fl.colMin(cell.row, entry.partner)
+		index = cell.col
+	}
+	if entry.partner < 0 || entry.partner >= len(sizes) {
+		return
+	}
+	total := sizes[index] + sizes[entry.partner]
+	size := entry.restore
+	if size > total-partnerMin {
+		size = total - partnerMin
+	}
+	if size < sizes[index] {
+		return
+	}
+	sizes[index], sizes[entry.partner] = size, total-size
+}
+
+// resetColumns returns a row's columns to equal sizing.
+func (fl *FlexLayout) resetColumns(row int) {
+	for j := range fl.colWidths[row] {
+		fl.colWidths[row][j] = 0
+		delete(fl.collapsed, flexCell{row, j})
+	}
+}
+
+// rowOverhead is the space a row uses for its splitter.
+func (fl *FlexLayout) rowOverhead(row int) int {
+	if row > 0 {
+		return multiGridSplitHeight
+	}
+	return 0
+}
+
+// colOverhead is the space a column uses for spacing and its splitter.
+func (fl *FlexLayout) colOverhead(col int) int {
+	if col > 0 {
+		return multiGridSpacing + multiGridSplitHeight
+	}
+	return multiGridSpacing
+}
+
+// rowMin is the minimum height of a row: the largest minimum height of its components.
+func (fl *FlexLayout) rowMin(row int) int {
+	minHeight := multiGridMinCellSize
+	if row >= 0 && row < len(fl.arrangement) {
+		for _, id := range fl.arrangement[row] {
+			if h := fl.minSizes[id].Height; h > minHeight {
+				minHeight = h
+			}
 		}
 	}
+	return fl.rowOverhead(row) + minHeight
+}
+
+// colMin is the minimum width of a column.
+func (fl *FlexLayout) colMin(row, col int) int {
+	minWidth := multiGridMinCellSize
+	if row >= 0 && row < len(fl.arrangement) && col >= 0 && col < len(fl.arrangement[row]) {
+		if w := fl.minSizes[fl.arrangement[row][col]].Width; w > minWidth {
+			minWidth = w
+		}
+	}
+	return fl.colOverhead(col) + minWidth
+}
+
+// resizeSplit moves a splitter between two cells of sizes startA and startB by offset
+// pixels, keeping the total constant. cells stop at their minimum size; when collapsible
+// and dragged past half the minimum, a cell snaps to its collapsed size instead.
+func resizeSplit(startA, startB, offset, minA, minB, collapsedA, collapsedB int, collapsible bool) (a, b int, collapseA, collapseB bool) {
+	total := startA + startB
+	a = startA + offset
+	if a < minA {
+		if collapsible && a < minA/2 {
+			a, collapseA = collapsedA, true
+		} else {
+			a = minA
+		}
+	}
+	b = total - a
+	if b < minB {
+		if collapsible && b < minB/2 {
+			b, collapseB = collapsedB, true
+		} else {
+			b = minB
+		}
+		a = total - b
+		collapseA = false
+	}
+	return a, b, collapseA, collapseB
 }
 
 // GridLayout provides fixed-position grid layout with no interactive resizing
@@ -486,14 +743,7 @@ func (gl *GridLayout) Arrange(components map[string]Component, state *State) {
 		componentSize := imgui.Vec2{X: sizeX, Y: sizeY}
 
 		if imgui.BeginChildStrV(fmt.Sprintf("grid_%s", componentID), componentSize, 0, imgui.WindowFlagsNoScrollbar) {
-			childState := &State{
-				Size:     componentSize,
-				Position: imgui.Vec2{X: posX, Y: posY},
-				IO:       imgui.CurrentIO(),
-				App:      state.App,
-				Parent:   state.Parent,
-			}
-			component.Draw(childState)
+			DrawChild(component, state.Child(componentSize, imgui.Vec2{X: posX, Y: posY}))
 		}
 		imgui.EndChild()
 	}
@@ -31,3 +31,78 @@ func TestMultiGrid_LayoutStateParentIsMultiGrid(t *testing.T) {
 		t.Fatalf("expected Arrange parent to be multigrid, got '%T'", layout.arrangeParent)
 	}
 }
+
+func TestResizeSplit_ClampsToMinimum(t *testing.T) {
+	a, b, collapseA, collapseB := resizeSplit(100, 100, -70, 40, 40, 8, 8, true)
+	if a != 40 || b != 160 || collapseA || collapseB {
+		t.Fatalf("expected '40/160' without collapse, got '%v/%v' (%v, %v)", a, b, collapseA, collapseB)
+	}
+
+	a, b, _, _ = resizeSplit(100, 100, 70, 40, 40, 8, 8, true)
+	if a != 160 || b != 40 {
+		t.Fatalf("expected '160/40', got '%v/%v'", a, b)
+	}
+}
+
+func TestResizeSplit_CollapsesPastHalfMinimum(t *testing.T) {
+	a, b, collapseA, collapseB := resizeSplit(100, 100, -85, 40, 40, 8, 8, true)
+	if a != 8 || b != 192 || !collapseA || collapseB {
+		t.Fatalf("expected first cell collapsed to '8/192', got '%v/%v' (%v, %v)", a, b, collapseA, collapseB)
+	}
+
+	a, b, collapseA, collapseB = resizeSplit(100, 100, 85, 40, 40, 8, 8, true)
+	if a != 192 || b != 8 || collapseA || !collapseB {
+		t.Fatalf("expected second cell collapsed to '192/8', got '%v/%v' (%v, %v)", a, b, collapseA, collapseB)
+	}
+
+	// dragging back out of a collapse restores a normal size
+	a, b, collapseA, _ = resizeSplit(8, 192, 60, 40, 40, 8, 8, true)
+	if a != 68 || b != 132 || collapseA {
+		t.Fatalf("expected '68/132' without collapse, got '%v/%v' (%v)", a, b, collapseA)
+	}
+}
+
+func TestResizeSplit_NotCollapsible(t *testing.T) {
+	a, _, collapseA, _ := resizeSplit(100, 100, -95, 40, 40, 8, 8, false)
+	if a != 40 || collapseA {
+		t.Fatalf("expected clamp to '40' without collapse, got '%v' (%v)", a, collapseA)
+	}
+}
+
+func TestFlexLayout_MinSizes(t *testing.T) {
+	fl := NewFlexLayout([][]string{{"a", "b"}, {"c"}})
+	fl.SetMinSize("b", 120, 80)
+
+	if got := fl.rowMin(0); got != 80 {
+		t.Fatalf("expected row 0 minimum '80', got '%v'", got)
+	}
+	if got := fl.rowMin(1); got != multiGridSplitHeight+multiGridMinCellSize {
+		t.Fatalf("expected row 1 minimum to include splitter, got '%v'", got)
+	}
+	if got := fl.colMin(0, 0); got != multiGridSpacing+multiGridMinCellSize {
+		t.Fatalf("expected default column minimum, got '%v'", got)
+	}
+	if got := fl.colMin(0, 1); got != multiGridSpacing+multiGridSplitHeight+120 {
+		t.Fatalf("expected column minimum '%v', got '%v'", multiGridSpacing+multiGridSplitHeight+120, got)
+	}
+}
+
+func TestFlexLayout_RestoreCollapsedColumn(t *testing.T) {
+	fl := NewFlexLayout([][]string{{"a", "b"}})
+	fl.colWidths[0] = []int{20, 380}
+	fl.collapsed[flexCell{0, 0}] = flexCollapse{restore: 150, partner: 1}
+
+	fl.restore(flexCell{0, 0})
+	if fl.ColumnCollapsed(0, 0) {
+		t.Fatalf("expected column to be restored")
+	}
+	if fl.colWidths[0][0] != 150 || fl.colWidths[0][1] != 250 {
+		t.Fatalf("expected '150/250', got '%v'", fl.colWidths[0])
+	}
+
+	fl.collapsed[flexCell{0, 1}] = flexCollapse{restore: 100, partner: 0}
+	fl.ResetSizes()
+	if fl.ColumnCollapsed(0, 1) || fl.colWidths[0][0] != 0 {
+		t.Fatalf("expected reset to clear collapse and sizes")
+	}
+}
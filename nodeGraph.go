@@ -0,0 +1,778 @@
+package dfx
+
+import (
+	"math"
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// NodeGraph constants
+const (
+	NodeGraphPinRadius    = 5    // pin radius in pixels at zoom 1
+	NodeGraphMinZoom      = 0.25 // furthest zoom out
+	NodeGraphMaxZoom      = 3    // deepest zoom in
+	NodeGraphGridSpacing  = 32   // canvas units between grid lines
+	nodeGraphMinNodeWidth = 80   // narrowest node, in canvas units
+	nodeGraphHitSlop      = 3    // extra pixels around pins and links that still hit them
+	nodeGraphZoomStep     = 1.15 // zoom factor per wheel notch
+	nodeGraphLinkSegments = 24   // segments links are drawn and hit tested with
+	nodeGraphMinTextZoom  = 0.4  // below this zoom, node text is not drawn
+)
+
+// node graph drag modes
+const (
+	graphDragNone = iota
+	graphDragNodes
+	graphDragLink
+	graphDragBox
+	graphDragPan
+)
+
+// graphPinRef identifies a pin of a node.
+type graphPinRef struct {
+	node   string
+	pin    string
+	output bool
+}
+
+// NodeGraph edits a Graph on a pannable, zoomable canvas: drag a node's title or body to
+// move it (the selected nodes move together), drag from a pin to another to connect them,
+// and drag from a connected input to move or remove its link. click selects a node (Ctrl
+// adds or removes), dragging on the empty canvas selects the nodes in the box, and Delete
+// removes the selected nodes. right-click a node to delete it or a link to disconnect it.
+// the wheel zooms around the pointer and a middle drag pans. links are checked with
+// Graph.CanConnect and then Validate; while a link is dragged over a pin it cannot
+// connect to, the reason is shown.
+type NodeGraph struct {
+	Container
+	Graph      *Graph
+	Height     float32               // canvas height (0 = available height)
+	TypeColors map[string]imgui.Vec4 // pin and link colors by pin type (missing = theme accent)
+
+	Validate     func(link GraphLink) error // vetoes links Graph.CanConnect allows
+	OnConnect    func(link GraphLink)       // a link was made
+	OnDisconnect func(link GraphLink)       // a link was removed
+	OnChange     func(graph *Graph)         // nodes were moved or removed, or links changed
+	OnSelect     func(ids []string)         // the selection changed
+
+	zoom     float32
+	pan      imgui.Vec2 // canvas position at the top left
+	viewSize imgui.Vec2 // canvas size when last drawn
+	selected map[string]bool
+
+	drag        int
+	dragPin     graphPinRef // pin a link is dragged from
+	dragFrom    imgui.Vec2  // canvas position the drag started at
+	moved       bool        // nodes moved during the drag
+	contextNode string      // right-clicked node
+	contextLink GraphLink   // right-clicked link
+}
+
+// NewNodeGraph creates an editor for graph.
+func NewNodeGraph(graph *Graph) *NodeGraph {
+	return &NodeGraph{Container: Container{Visible: true}, Graph: graph, zoom: 1, selected: make(map[string]bool)}
+}
+
+// Selected returns the ids of the selected nodes, in graph order.
+func (ng *NodeGraph) Selected() []string {
+	var ids []string
+	for _, node := range ng.Graph.Nodes {
+		if ng.selected[node.ID] {
+			ids = append(ids, node.ID)
+		}
+	}
+	return ids
+}
+
+// Select selects the node with id, adding it to the selection when add is set.
+func (ng *NodeGraph) Select(id string, add bool) {
+	if !add {
+		clear(ng.selected)
+	}
+	ng.selected[id] = true
+}
+
+// ClearSelection deselects every node.
+func (ng *NodeGraph) ClearSelection() {
+	clear(ng.selected)
+}
+
+// graphView maps canvas positions to the screen.
+type graphView struct {
+	origin imgui.Vec2 // screen position of the canvas top left
+	pan    imgui.Vec2
+	zoom   float32
+}
+
+func (v graphView) toScreen(p imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: v.origin.X + (p.X-v.pan.X)*v.zoom, Y: v.origin.Y + (p.Y-v.pan.Y)*v.zoom}
+}
+
+func (v graphView) toCanvas(p imgui.Vec2) imgui.Vec2 {
+	return imgui.Vec2{X: v.pan.X + (p.X-v.origin.X)/v.zoom, Y: v.pan.Y + (p.Y-v.origin.Y)/v.zoom}
+}
+
+// nodeMetrics are the sizes nodes are laid out with, in canvas units.
+type nodeMetrics struct {
+	title   float32 // title bar height
+	row     float32 // pin row height
+	padding float32
+	measure func(text string) float32
+}
+
+// currentNodeMetrics returns the metrics of the current font and style.
+func currentNodeMetrics() nodeMetrics {
+	return nodeMetrics{
+		title:   imgui.FrameHeight(),
+		row:     imgui.TextLineHeightWithSpacing(),
+		padding: imgui.CurrentStyle().FramePadding().X,
+		measure: func(text string) float32 { return imgui.CalcTextSize(text).X },
+	}
+}
+
+// nodeLayout is the placement of a node and its pins, in canvas units.
+type nodeLayout struct {
+	min, max imgui.Vec2
+	title    float32 // bottom of the title bar
+	inputs   []imgui.Vec2
+	outputs  []imgui.Vec2
+}
+
+// layoutNode places node: the title bar on top, inputs down the left edge and outputs down
+// the right edge, wide enough for the title and the longest pin names side by side.
+func layoutNode(node *GraphNode, m nodeMetrics) nodeLayout {
+	widest := func(pins []GraphPin) float32 {
+		w := float32(0)
+		for _, pin := range pins {
+			w = max(w, m.measure(pin.Name))
+		}
+		return w
+	}
+	width := max(m.measure(node.Title)+m.padding*2, widest(node.Inputs)+widest(node.Outputs)+m.padding*2+m.row*2, nodeGraphMinNodeWidth)
+	rows := max(len(node.Inputs), len(node.Outputs))
+	l := nodeLayout{
+		min:   imgui.Vec2{X: node.X, Y: node.Y},
+		max:   imgui.Vec2{X: node.X + width, Y: node.Y + m.title + float32(rows)*m.row + m.padding},
+		title: node.Y + m.title,
+	}
+	for i := range node.Inputs {
+		l.inputs = append(l.inputs, imgui.Vec2{X: node.X, Y: l.title + (float32(i)+0.5)*m.row})
+	}
+	for i := range node.Outputs {
+		l.outputs = append(l.outputs, imgui.Vec2{X: node.X + width, Y: l.title + (float32(i)+0.5)*m.row})
+	}
+	return l
+}
+
+// linkCurve returns the bezier control points of a link from an output at from to an
+// input at to, leaving and entering horizontally.
+func linkCurve(from, to imgui.Vec2, zoom float32) [4]imgui.Vec2 {
+	dx := max(float32(math.Abs(float64(to.X-from.X)))/2, 50*zoom)
+	return [4]imgui.Vec2{from, {X: from.X + dx, Y: from.Y}, {X: to.X - dx, Y: to.Y}, to}
+}
+
+// linkDistance returns how far p is from the bezier c.
+func linkDistance(c [4]imgui.Vec2, p imgui.Vec2) float32 {
+	best := float32(math.MaxFloat32)
+	prev := c[0]
+	for i := 1; i <= nodeGraphLinkSegments; i++ {
+		s := float32(i) / nodeGraphLinkSegments
+		next := imgui.Vec2{X: cubicBezier(c[0].X, c[1].X, c[2].X, c[3].X, s), Y: cubicBezier(c[0].Y, c[1].Y, c[2].Y, c[3].Y, s)}
+		best = min(best, segmentDistance(prev, next, p))
+		prev = next
+	}
+	return best
+}
+
+// segmentDistance returns how far p is from the line segment a..b.
+func segmentDistance(a, b, p imgui.Vec2) float32 {
+	ab := b.Sub(a)
+	length := ab.X*ab.X + ab.Y*ab.Y
+	if length == 0 {
+		return distance(a, p)
+	}
+	s := clamp(((p.X-a.X)*ab.X+(p.Y-a.Y)*ab.Y)/length, 0, 1)
+	return distance(imgui.Vec2{X: a.X + ab.X*s, Y: a.Y + ab.Y*s}, p)
+}
+
+// rectsOverlap reports whether the rectangles a and b (min and max corners) overlap.
+func rectsOverlap(aMin, aMax, bMin, bMax imgui.Vec2) bool {
+	return aMin.X <= bMax.X && bMin.X <= aMax.X && aMin.Y <= bMax.Y && bMin.Y <= aMax.Y
+}
+
+// Draw implements Component.
+func (ng *NodeGraph) Draw(state *State) {
+	if !ng.Visible || ng.Graph == nil {
+		return
+	}
+	if ng.selected == nil {
+		ng.selected = make(map[string]bool)
+	}
+	ng.zoom = clamp(ng.zoom, NodeGraphMinZoom, NodeGraphMaxZoom)
+	imgui.PushIDStr(componentID("nodeGraph", ng))
+	defer imgui.PopID()
+
+	size := imgui.ContentRegionAvail()
+	if ng.Height > 0 {
+		size.Y = ng.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	ng.viewSize = size
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButtonV("##graph", size, imgui.ButtonFlagsMouseButtonLeft|imgui.ButtonFlagsMouseButtonRight|imgui.ButtonFlagsMouseButtonMiddle)
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+
+	ng.handleWheel(hovered, pos)
+	view := graphView{origin: pos, pan: ng.pan, zoom: ng.zoom}
+	layouts := ng.layouts()
+	changed := ng.handleMouse(view, layouts, hovered, active)
+	if len(ng.selected) > 0 && imgui.IsWindowFocused() && imgui.IsKeyPressedBool(imgui.KeyDelete) {
+		for _, id := range ng.Selected() {
+			ng.removeNode(id)
+		}
+		changed = true
+	}
+	if ng.drawContextMenus() {
+		changed = true
+	}
+
+	// nodes may have moved or gone
+	layouts = ng.layouts()
+	drawList := imgui.WindowDrawList()
+	drawList.PushClipRectV(pos, pos.Add(size), true)
+	ng.drawCanvas(drawList, view, size)
+	ng.drawLinks(drawList, view, layouts)
+	ng.drawNodes(drawList, view, layouts)
+	ng.drawDrag(drawList, view, layouts)
+	drawList.PopClipRect()
+
+	if changed && ng.OnChange != nil {
+		ng.OnChange(ng.Graph)
+	}
+	drawContainerExtensions(&ng.Container, state)
+}
+
+// layouts lays out every node with the current metrics.
+func (ng *NodeGraph) layouts() map[string]nodeLayout {
+	m := currentNodeMetrics()
+	layouts := make(map[string]nodeLayout, len(ng.Graph.Nodes))
+	for _, node := range ng.Graph.Nodes {
+		layouts[node.ID] = layoutNode(node, m)
+	}
+	return layouts
+}
+
+// handleWheel zooms around the pointer.
+func (ng *NodeGraph) handleWheel(hovered bool, pos imgui.Vec2) {
+	wheel := imgui.CurrentIO().MouseWheel()
+	if !hovered || wheel == 0 {
+		return
+	}
+	mouse := imgui.MousePos()
+	at := graphView{origin: pos, pan: ng.pan, zoom: ng.zoom}.toCanvas(mouse)
+	ng.zoom = clamp(ng.zoom*float32(math.Pow(nodeGraphZoomStep, float64(wheel))), NodeGraphMinZoom, NodeGraphMaxZoom)
+	ng.pan = imgui.Vec2{X: at.X - (mouse.X-pos.X)/ng.zoom, Y: at.Y - (mouse.Y-pos.Y)/ng.zoom}
+}
+
+// handleMouse starts, continues and ends drags. returns true when the graph changed.
+func (ng *NodeGraph) handleMouse(view graphView, layouts map[string]nodeLayout, hovered, active bool) bool {
+	mouse := imgui.MousePos()
+	changed := false
+	switch {
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft):
+		changed = ng.startDrag(view, layouts, mouse)
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonMiddle):
+		ng.drag = graphDragPan
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonRight):
+		if node := ng.nodeAt(view, layouts, mouse); node != nil {
+			ng.contextNode = node.ID
+			imgui.OpenPopupStr("##graphNode")
+		} else if link, found := ng.linkAt(view, layouts, mouse); found {
+			ng.contextLink = link
+			imgui.OpenPopupStr("##graphLink")
+		}
+	}
+	if !active {
+		if ng.endDrag(view, layouts, mouse) {
+			changed = true
+		}
+		return changed
+	}
+
+	delta := imgui.CurrentIO().MouseDelta()
+	switch ng.drag {
+	case graphDragNodes:
+		if delta.X != 0 || delta.Y != 0 {
+			for _, node := range ng.Graph.Nodes {
+				if ng.selected[node.ID] {
+					node.X += delta.X / view.zoom
+					node.Y += delta.Y / view.zoom
+				}
+			}
+			ng.moved = true
+		}
+	case graphDragPan:
+		ng.pan = imgui.Vec2{X: ng.pan.X - delta.X/view.zoom, Y: ng.pan.Y - delta.Y/view.zoom}
+	case graphDragLink:
+		if target, found := ng.pinAt(view, layouts, mouse); found {
+			if _, err := ng.dragLink(target); err != nil {
+				imgui.SetTooltip(err.Error())
+			}
+		}
+	}
+	return changed
+}
+
+// startDrag starts dragging a link from a pin, moving nodes, or selecting with a box.
+// returns true when the graph changed (a link was picked up from an input).
+func (ng *NodeGraph) startDrag(view graphView, layouts map[string]nodeLayout, mouse imgui.Vec2) bool {
+	ctrl := imgui.CurrentIO().KeyCtrl()
+	ng.dragFrom = view.toCanvas(mouse)
+	if pin, found := ng.pinAt(view, layouts, mouse); found {
+		ng.drag, ng.dragPin = graphDragLink, pin
+		if links := ng.Graph.LinksTo(pin.node, pin.pin); !pin.output && len(links) > 0 {
+			// pick up the link into this input and drag it from its output
+			link := links[len(links)-1]
+			ng.disconnect(link)
+			ng.dragPin = graphPinRef{node: link.FromNode, pin: link.FromPin, output: true}
+			return true
+		}
+		return false
+	}
+	node := ng.nodeAt(view, layouts, mouse)
+	if node == nil {
+		ng.drag = graphDragBox
+		return false
+	}
+	switch {
+	case ctrl && ng.selected[node.ID]:
+		delete(ng.selected, node.ID)
+		ng.selectionChanged()
+		return false
+	case ctrl:
+		ng.Select(node.ID, true)
+		ng.selectionChanged()
+	case !ng.selected[node.ID]:
+		ng.Select(node.ID, false)
+		ng.selectionChanged()
+	}
+	// bring the node to the front
+	i := slices.Index(ng.Graph.Nodes, node)
+	ng.Graph.Nodes = append(slices.Delete(ng.Graph.Nodes, i, i+1), node)
+	ng.drag, ng.moved = graphDragNodes, false
+	return false
+}
+
+// endDrag finishes a drag: connecting a dragged link, selecting the boxed nodes, or
+// reporting moved nodes. returns true when the graph changed.
+func (ng *NodeGraph) endDrag(view graphView, layouts map[string]nodeLayout, mouse imgui.Vec2) bool {
+	drag := ng.drag
+	ng.drag = graphDragNone
+	switch drag {
+	case graphDragNodes:
+		return ng.moved
+	case graphDragLink:
+		target, found := ng.pinAt(view, layouts, mouse)
+		if !found {
+			return false
+		}
+		link, err := ng.dragLink(target)
+		if err != nil {
+			return false
+		}
+		replaced, _ := ng.Graph.Connect(link)
+		for _, old := range replaced {
+			if ng.OnDisconnect != nil {
+				ng.OnDisconnect(old)
+			}
+		}
+		if ng.OnConnect != nil {
+			ng.OnConnect(link)
+		}
+		return true
+	case graphDragBox:
+		ng.selectBox(layouts, ng.dragFrom, view.toCanvas(mouse), imgui.CurrentIO().KeyCtrl())
+	}
+	return false
+}
+
+// selectBox selects the nodes overlapping the box from a to b, adding to the selection
+// when add is set.
+func (ng *NodeGraph) selectBox(layouts map[string]nodeLayout, a, b imgui.Vec2, add bool) {
+	boxMin := imgui.Vec2{X: min(a.X, b.X), Y: min(a.Y, b.Y)}
+	boxMax := imgui.Vec2{X: max(a.X, b.X), Y: max(a.Y, b.Y)}
+	before := ng.Selected()
+	if !add {
+		clear(ng.selected)
+	}
+	for _, node := range ng.Graph.Nodes {
+		if l := layouts[node.ID]; rectsOverlap(l.min, l.max, boxMin, boxMax) {
+			ng.selected[node.ID] = true
+		}
+	}
+	if !slices.Equal(before, ng.Selected()) {
+		ng.selectionChanged()
+	}
+}
+
+// dragLink returns the link from the pin being dragged to target, output to input, or
+// why it cannot be made.
+func (ng *NodeGraph) dragLink(target graphPinRef) (GraphLink, error) {
+	from, to := ng.dragPin, target
+	if from.output == to.output {
+		if from.output {
+			return GraphLink{}, errors.New("cannot connect two outputs")
+		}
+		return GraphLink{}, errors.New("cannot connect two inputs")
+	}
+	if !from.output {
+		from, to = to, from
+	}
+	link := GraphLink{FromNode: from.node, FromPin: from.pin, ToNode: to.node, ToPin: to.pin}
+	return link, ng.check(link)
+}
+
+// check returns why link cannot be made, asking the graph and then Validate.
+func (ng *NodeGraph) check(link GraphLink) error {
+	if err := ng.Graph.CanConnect(link); err != nil {
+		return err
+	}
+	if ng.Validate != nil {
+		return ng.Validate(link)
+	}
+	return nil
+}
+
+// disconnect removes link, reporting it.
+func (ng *NodeGraph) disconnect(link GraphLink) {
+	if ng.Graph.Disconnect(link) && ng.OnDisconnect != nil {
+		ng.OnDisconnect(link)
+	}
+}
+
+// removeNode removes a node and its links, reporting the links.
+func (ng *NodeGraph) removeNode(id string) {
+	for _, link := range ng.Graph.RemoveNode(id) {
+		if ng.OnDisconnect != nil {
+			ng.OnDisconnect(link)
+		}
+	}
+	if ng.selected[id] {
+		delete(ng.selected, id)
+		ng.selectionChanged()
+	}
+}
+
+func (ng *NodeGraph) selectionChanged() {
+	if ng.OnSelect != nil {
+		ng.OnSelect(ng.Selected())
+	}
+}
+
+// pinAt returns the pin under the pointer, searching the front node first.
+func (ng *NodeGraph) pinAt(view graphView, layouts map[string]nodeLayout, mouse imgui.Vec2) (graphPinRef, bool) {
+	reach := NodeGraphPinRadius*view.zoom + nodeGraphHitSlop
+	for i := len(ng.Graph.Nodes) - 1; i >= 0; i-- {
+		node := ng.Graph.Nodes[i]
+		l := layouts[node.ID]
+		for j, p := range l.inputs {
+			if distance(view.toScreen(p), mouse) <= reach {
+				return graphPinRef{node: node.ID, pin: node.Inputs[j].ID}, true
+			}
+		}
+		for j, p := range l.outputs {
+			if distance(view.toScreen(p), mouse) <= reach {
+				return graphPinRef{node: node.ID, pin: node.Outputs[j].ID, output: true}, true
+			}
+		}
+	}
+	return graphPinRef{}, false
+}
+
+// nodeAt returns the front node under the pointer, or nil.
+func (ng *NodeGraph) nodeAt(view graphView, layouts map[string]nodeLayout, mouse imgui.Vec2) *GraphNode {
+	at := view.toCanvas(mouse)
+	for i := len(ng.Graph.Nodes) - 1; i >= 0; i-- {
+		node := ng.Graph.Nodes[i]
+		if l := layouts[node.ID]; rectsOverlap(l.min, l.max, at, at) {
+			return node
+		}
+	}
+	return nil
+}
+
+// linkAt returns the link under the pointer.
+func (ng *NodeGraph) linkAt(view graphView, layouts map[string]nodeLayout, mouse imgui.Vec2) (GraphLink, bool) {
+	for _, link := range ng.Graph.Links {
+		if c, found := ng.linkCurve(view, layouts, link); found && linkDistance(c, mouse) <= nodeGraphHitSlop+2 {
+			return link, true
+		}
+	}
+	return GraphLink{}, false
+}
+
+// linkCurve returns the screen bezier of link.
+func (ng *NodeGraph) linkCurve(view graphView, layouts map[string]nodeLayout, link GraphLink) ([4]imgui.Vec2, bool) {
+	from, fromFound := ng.pinPos(layouts, graphPinRef{node: link.FromNode, pin: link.FromPin, output: true})
+	to, toFound := ng.pinPos(layouts, graphPinRef{node: link.ToNode, pin: link.ToPin})
+	if !fromFound || !toFound {
+		return [4]imgui.Vec2{}, false
+	}
+	return linkCurve(view.toScreen(from), view.toScreen(to), view.zoom), true
+}
+
+// pinPos returns the canvas position of a pin.
+func (ng *NodeGraph) pinPos(layouts map[string]nodeLayout, ref graphPinRef) (imgui.Vec2, bool) {
+	node := ng.Graph.Node(ref.node)
+	if node == nil {
+		return imgui.Vec2{}, false
+	}
+	pins, positions := node.Inputs, layouts[ref.node].inputs
+	if ref.output {
+		pins, positions = node.Outputs, layouts[ref.node].outputs
+	}
+	for i, pin := range pins {
+		if pin.ID == ref.pin && i < len(positions) {
+			return positions[i], true
+		}
+	}
+	return imgui.Vec2{}, false
+}
+
+// pinType returns the type of a pin.
+func (ng *NodeGraph) pinType(ref graphPinRef) string {
+	node := ng.Graph.Node(ref.node)
+	if node == nil {
+		return ""
+	}
+	find := node.input
+	if ref.output {
+		find = node.output
+	}
+	pin, _ := find(ref.pin)
+	return pin.Type
+}
+
+// typeColor returns the color of pins and links of a type.
+func (ng *NodeGraph) typeColor(pinType string) uint32 {
+	if color, found := ng.TypeColors[pinType]; found {
+		return imgui.ColorConvertFloat4ToU32(color)
+	}
+	return imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+}
+
+// drawContextMenus draws the menus of the right-clicked node or link. returns true when
+// the graph changed.
+func (ng *NodeGraph) drawContextMenus() bool {
+	changed := false
+	if imgui.BeginPopup("##graphNode") {
+		if imgui.MenuItemBool(T("dfx.graph.delete")) {
+			ng.removeNode(ng.contextNode)
+			changed = true
+		}
+		imgui.EndPopup()
+	}
+	if imgui.BeginPopup("##graphLink") {
+		if imgui.MenuItemBool(T("dfx.graph.disconnect")) {
+			ng.disconnect(ng.contextLink)
+			changed = true
+		}
+		imgui.EndPopup()
+	}
+	return changed
+}
+
+// drawCanvas draws the background and grid.
+func (ng *NodeGraph) drawCanvas(drawList *imgui.DrawList, view graphView, size imgui.Vec2) {
+	colors := imgui.CurrentStyle().Colors()
+	drawList.AddRectFilled(view.origin, view.origin.Add(size), imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]))
+	spacing := NodeGraphGridSpacing * view.zoom
+	if spacing < curveEditorMinGridGap {
+		return
+	}
+	grid := colors[imgui.ColBorder]
+	grid.W *= 0.4
+	color := imgui.ColorConvertFloat4ToU32(grid)
+	first := view.toScreen(imgui.Vec2{
+		X: float32(math.Ceil(float64(view.pan.X/NodeGraphGridSpacing))) * NodeGraphGridSpacing,
+		Y: float32(math.Ceil(float64(view.pan.Y/NodeGraphGridSpacing))) * NodeGraphGridSpacing,
+	})
+	for x := first.X; x <= view.origin.X+size.X; x += spacing {
+		drawList.AddLine(imgui.Vec2{X: x, Y: view.origin.Y}, imgui.Vec2{X: x, Y: view.origin.Y + size.Y}, color)
+	}
+	for y := first.Y; y <= view.origin.Y+size.Y; y += spacing {
+		drawList.AddLine(imgui.Vec2{X: view.origin.X, Y: y}, imgui.Vec2{X: view.origin.X + size.X, Y: y}, color)
+	}
+}
+
+// drawLinks draws the links in the color of their output pin's type.
+func (ng *NodeGraph) drawLinks(drawList *imgui.DrawList, view graphView, layouts map[string]nodeLayout) {
+	thickness := max(2*view.zoom, 1)
+	for _, link := range ng.Graph.Links {
+		if c, found := ng.linkCurve(view, layouts, link); found {
+			color := ng.typeColor(ng.pinType(graphPinRef{node: link.FromNode, pin: link.FromPin, output: true}))
+			drawList.AddBezierCubicV(c[0], c[1], c[2], c[3], color, thickness, nodeGraphLinkSegments)
+		}
+	}
+}
+
+// drawNodes draws the nodes back to front, outlining the selected ones.
+func (ng *NodeGraph) drawNodes(drawList *imgui.DrawList, view graphView, layouts map[string]nodeLayout) {
+	style := imgui.CurrentStyle()
+	colors := style.Colors()
+	rounding := style.FrameRounding() * view.zoom
+	body := imgui.ColorConvertFloat4ToU32(colors[imgui.ColPopupBg])
+	title := imgui.ColorConvertFloat4ToU32(colors[imgui.ColHeader])
+	border := imgui.ColorConvertFloat4ToU32(colors[imgui.ColBorder])
+	accent := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	text := imgui.ColorConvertFloat4ToU32(colors[imgui.ColText])
+	font, fontSize := imgui.CurrentFont(), imgui.FontSize()*view.zoom
+	m := currentNodeMetrics()
+	radius := NodeGraphPinRadius * view.zoom
+
+	connected := make(map[graphPinRef]bool)
+	for _, link := range ng.Graph.Links {
+		connected[graphPinRef{node: link.FromNode, pin: link.FromPin, output: true}] = true
+		connected[graphPinRef{node: link.ToNode, pin: link.ToPin}] = true
+	}
+	drawPin := func(node *GraphNode, pin GraphPin, at imgui.Vec2, output bool) {
+		center := view.toScreen(at)
+		color := ng.typeColor(pin.Type)
+		if connected[graphPinRef{node: node.ID, pin: pin.ID, output: output}] {
+			drawList.AddCircleFilled(center, radius, color)
+		} else {
+			drawList.AddCircleFilled(center, radius, body)
+			drawList.AddCircleV(center, radius, color, 0, max(view.zoom, 1))
+		}
+		if view.zoom < nodeGraphMinTextZoom || pin.Name == "" {
+			return
+		}
+		x := at.X + m.padding
+		if output {
+			x = at.X - m.padding - m.measure(pin.Name)
+		}
+		textPos := view.toScreen(imgui.Vec2{X: x, Y: at.Y - imgui.FontSize()/2})
+		drawList.AddTextFontPtr(font, fontSize, textPos, text, pin.Name)
+	}
+
+	for _, node := range ng.Graph.Nodes {
+		l := layouts[node.ID]
+		minPos, maxPos := view.toScreen(l.min), view.toScreen(l.max)
+		titleMax := view.toScreen(imgui.Vec2{X: l.max.X, Y: l.title})
+		drawList.AddRectFilledV(minPos, maxPos, body, rounding, imgui.DrawFlagsNone)
+		drawList.AddRectFilledV(minPos, titleMax, title, rounding, imgui.DrawFlagsRoundCornersTop)
+		if ng.selected[node.ID] {
+			drawList.AddRectV(minPos, maxPos, accent, rounding, imgui.DrawFlagsNone, 2)
+		} else {
+			drawList.AddRectV(minPos, maxPos, border, rounding, imgui.DrawFlagsNone, 1)
+		}
+		if view.zoom >= nodeGraphMinTextZoom {
+			titlePos := view.toScreen(imgui.Vec2{X: l.min.X + m.padding, Y: l.min.Y + (m.title-imgui.FontSize())/2})
+			drawList.PushClipRectV(minPos, titleMax, true)
+			drawList.AddTextFontPtr(font, fontSize, titlePos, text, node.Title)
+			drawList.PopClipRect()
+		}
+		for i, pin := range node.Inputs {
+			drawPin(node, pin, l.inputs[i], false)
+		}
+		for i, pin := range node.Outputs {
+			drawPin(node, pin, l.outputs[i], true)
+		}
+	}
+}
+
+// drawDrag draws the link or selection box being dragged.
+func (ng *NodeGraph) drawDrag(drawList *imgui.DrawList, view graphView, layouts map[string]nodeLayout) {
+	mouse := imgui.MousePos()
+	switch ng.drag {
+	case graphDragLink:
+		at, found := ng.pinPos(layouts, ng.dragPin)
+		if !found {
+			return
+		}
+		from, to := view.toScreen(at), mouse
+		if !ng.dragPin.output {
+			from, to = to, from
+		}
+		c := linkCurve(from, to, view.zoom)
+		drawList.AddBezierCubicV(c[0], c[1], c[2], c[3], ng.typeColor(ng.pinType(ng.dragPin)), max(2*view.zoom, 1), nodeGraphLinkSegments)
+	case graphDragBox:
+		accent := ThemeColors().Accent
+		start := view.toScreen(ng.dragFrom)
+		boxMin := imgui.Vec2{X: min(start.X, mouse.X), Y: min(start.Y, mouse.Y)}
+		boxMax := imgui.Vec2{X: max(start.X, mouse.X), Y: max(start.Y, mouse.Y)}
+		drawList.AddRect(boxMin, boxMax, imgui.ColorConvertFloat4ToU32(accent))
+		accent.W *= 0.15
+		drawList.AddRectFilled(boxMin, boxMax, imgui.ColorConvertFloat4ToU32(accent))
+	}
+}
+
+// MinimapBounds implements MinimapSource: the canvas area the nodes cover.
+func (ng *NodeGraph) MinimapBounds() (imgui.Vec2, imgui.Vec2) {
+	if ng.Graph == nil || len(ng.Graph.Nodes) == 0 {
+		return imgui.Vec2{}, imgui.Vec2{}
+	}
+	layouts := ng.layouts()
+	lo, hi := layouts[ng.Graph.Nodes[0].ID].min, layouts[ng.Graph.Nodes[0].ID].max
+	for _, l := range layouts {
+		lo, hi = unionRect(lo, hi, l.min, l.max)
+	}
+	return lo, hi
+}
+
+// MinimapViewport implements MinimapSource: the canvas area last drawn.
+func (ng *NodeGraph) MinimapViewport() (imgui.Vec2, imgui.Vec2) {
+	return ng.pan, ng.pan.Add(ng.viewSize.Div(max(ng.zoom, NodeGraphMinZoom)))
+}
+
+// MinimapScrollTo implements MinimapSource.
+func (ng *NodeGraph) MinimapScrollTo(origin imgui.Vec2) {
+	ng.pan = origin
+}
+
+// DrawMinimap implements MinimapPainter, drawing nodes as blocks and links as lines.
+func (ng *NodeGraph) DrawMinimap(drawList *imgui.DrawList, toMinimap func(imgui.Vec2) imgui.Vec2) {
+	if ng.Graph == nil {
+		return
+	}
+	layouts := ng.layouts()
+	for _, link := range ng.Graph.Links {
+		from, fromFound := ng.pinPos(layouts, graphPinRef{node: link.FromNode, pin: link.FromPin, output: true})
+		to, toFound := ng.pinPos(layouts, graphPinRef{node: link.ToNode, pin: link.ToPin})
+		if fromFound && toFound {
+			color := ng.typeColor(ng.pinType(graphPinRef{node: link.FromNode, pin: link.FromPin, output: true}))
+			drawList.AddLine(toMinimap(from), toMinimap(to), color)
+		}
+	}
+	colors := imgui.CurrentStyle().Colors()
+	node := imgui.ColorConvertFloat4ToU32(colors[imgui.ColHeader])
+	selected := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	for _, n := range ng.Graph.Nodes {
+		color := node
+		if ng.selected[n.ID] {
+			color = selected
+		}
+		l := layouts[n.ID]
+		drawList.AddRectFilled(toMinimap(l.min), toMinimap(l.max), color)
+	}
+}
+
+// CaptureState implements StatefulComponent.
+func (ng *NodeGraph) CaptureState() map[string]any {
+	return map[string]any{"zoom": ng.zoom, "panX": ng.pan.X, "panY": ng.pan.Y}
+}
+
+// RestoreState implements StatefulComponent.
+func (ng *NodeGraph) RestoreState(state map[string]any) {
+	if zoom, ok := stateFloat(state["zoom"]); ok {
+		ng.zoom = clamp(float32(zoom), NodeGraphMinZoom, NodeGraphMaxZoom)
+	}
+	if x, ok := stateFloat(state["panX"]); ok {
+		ng.pan.X = float32(x)
+	}
+	if y, ok := stateFloat(state["panY"]); ok {
+		ng.pan.Y = float32(y)
+	}
+}
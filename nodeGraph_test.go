@@ -0,0 +1,105 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+func testNodeMetrics() nodeMetrics {
+	return nodeMetrics{title: 20, row: 16, padding: 4, measure: func(text string) float32 { return float32(len(text)) * 7 }}
+}
+
+func TestGraphView(t *testing.T) {
+	view := graphView{origin: imgui.Vec2{X: 100, Y: 50}, pan: imgui.Vec2{X: 10, Y: 20}, zoom: 2}
+	if p := view.toScreen(imgui.Vec2{X: 20, Y: 30}); p.X != 120 || p.Y != 70 {
+		t.Errorf("expected (120, 70), got %v", p)
+	}
+	if p := view.toCanvas(imgui.Vec2{X: 120, Y: 70}); p.X != 20 || p.Y != 30 {
+		t.Errorf("expected (20, 30), got %v", p)
+	}
+}
+
+func TestLayoutNode(t *testing.T) {
+	node := &GraphNode{X: 10, Y: 20, Title: "Mixer",
+		Inputs:  []GraphPin{{Name: "Left input"}, {Name: "Right input"}, {Name: "Aux"}},
+		Outputs: []GraphPin{{Name: "Out"}},
+	}
+	l := layoutNode(node, testNodeMetrics())
+	// the longest input (77) and output (21) names, padding and a gap of two rows
+	if width := l.max.X - l.min.X; width != 77+21+8+32 {
+		t.Errorf("expected width 138, got %v", width)
+	}
+	if height := l.max.Y - l.min.Y; height != 20+3*16+4 {
+		t.Errorf("expected height 72, got %v", height)
+	}
+	if p := l.inputs[1]; p.X != 10 || p.Y != 20+20+24 {
+		t.Errorf("expected the second input at (10, 64), got %v", p)
+	}
+	if p := l.outputs[0]; p.X != l.max.X || p.Y != 48 {
+		t.Errorf("expected the output on the right edge at 48, got %v", p)
+	}
+
+	narrow := layoutNode(&GraphNode{Title: "A"}, testNodeMetrics())
+	if width := narrow.max.X - narrow.min.X; width != nodeGraphMinNodeWidth {
+		t.Errorf("expected the minimum width, got %v", width)
+	}
+}
+
+func TestLinkDistance(t *testing.T) {
+	c := linkCurve(imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 200, Y: 0}, 1)
+	if d := linkDistance(c, imgui.Vec2{X: 100, Y: 0}); d > 0.01 {
+		t.Errorf("expected a point on a straight link to be on it, got %v", d)
+	}
+	if d := linkDistance(c, imgui.Vec2{X: 100, Y: 10}); d < 9.99 || d > 10.01 {
+		t.Errorf("expected 10 away, got %v", d)
+	}
+}
+
+func TestNodeGraphDragLink(t *testing.T) {
+	ng := NewNodeGraph(testGraph())
+	ng.dragPin = graphPinRef{node: "vca", pin: "gain"}
+	link, err := ng.dragLink(graphPinRef{node: "lfo", pin: "out", output: true})
+	if err != nil || link != (GraphLink{FromNode: "lfo", FromPin: "out", ToNode: "vca", ToPin: "gain"}) {
+		t.Errorf("expected a link from the output dragged to, got %+v, %v", link, err)
+	}
+	if _, err := ng.dragLink(graphPinRef{node: "vca", pin: "in"}); err == nil {
+		t.Error("expected two inputs to be rejected")
+	}
+	veto := errors.New("no modulation")
+	ng.Validate = func(GraphLink) error { return veto }
+	if _, err := ng.dragLink(graphPinRef{node: "lfo", pin: "out", output: true}); err != veto {
+		t.Errorf("expected Validate to veto the link, got %v", err)
+	}
+}
+
+func TestNodeGraphSelectBox(t *testing.T) {
+	ng := NewNodeGraph(testGraph())
+	m := testNodeMetrics()
+	ng.Graph.Node("osc").X, ng.Graph.Node("lfo").X, ng.Graph.Node("vca").X = 0, 200, 400
+	layouts := make(map[string]nodeLayout)
+	for _, node := range ng.Graph.Nodes {
+		layouts[node.ID] = layoutNode(node, m)
+	}
+	var selected []string
+	ng.OnSelect = func(ids []string) { selected = ids }
+	ng.selectBox(layouts, imgui.Vec2{X: 250, Y: 10}, imgui.Vec2{X: 50, Y: 30}, false)
+	if len(selected) != 2 || selected[0] != "osc" || selected[1] != "lfo" {
+		t.Errorf("expected osc and lfo boxed, got %v", selected)
+	}
+	ng.selectBox(layouts, imgui.Vec2{X: 410, Y: 0}, imgui.Vec2{X: 420, Y: 5}, true)
+	if got := ng.Selected(); len(got) != 3 {
+		t.Errorf("expected vca added to the selection, got %v", got)
+	}
+}
+
+func TestNodeGraphState(t *testing.T) {
+	ng := NewNodeGraph(NewGraph())
+	ng.zoom, ng.pan = 2, imgui.Vec2{X: -40, Y: 120}
+	restored := NewNodeGraph(NewGraph())
+	restored.RestoreState(ng.CaptureState())
+	if restored.zoom != 2 || restored.pan != ng.pan {
+		t.Errorf("expected zoom 2 and pan %v, got %v and %v", ng.pan, restored.zoom, restored.pan)
+	}
+}
@@ -0,0 +1,442 @@
+package dfx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// NumberOptions configures NumberInput and IntInput.
+type NumberOptions struct {
+	Min       float32       // lower bound (Min == Max = unbounded)
+	Max       float32       // upper bound
+	Step      float32       // wheel step (0 = 1/100 of the range, or 1 for ints and 0.1 for unbounded floats)
+	DragSpeed float32       // change per pixel dragged (0 = Step)
+	Precision int           // decimal places displayed (ignored by IntInput)
+	Unit      string        // unit displayed after the value and accepted when typing ("ms", "dB", "Hz")
+	SI        bool          // display with SI prefixes (10k, 3.5m)
+	Format    *LocaleFormat // decimal and thousands separators (nil = CurrentFormat())
+}
+
+// numberEdit holds the typed-entry state of the NumberInput being edited, if any.
+var numberEdit struct {
+	id      imgui.ID
+	text    string
+	focus   bool
+	dragged imgui.ID // input dragged during its current activation
+	drag    float32  // unrounded value while dragging
+}
+
+// NumberInput draws a numeric field. drag horizontally to scrub the value, use the wheel
+// to step it (Ctrl = 10x faster, Alt = 10x slower, as WheelSlider), or click to type a
+// value. typed values may use SI prefixes and the unit ("10k", "3.5ms", "-6dB") and
+// simple arithmetic ("2*440", "(1+2)/4"). values are displayed and typed with the
+// separators of the locale format. values are clamped to Min and Max.
+// returns (newValue, changed) following dfx conventions.
+func NumberInput(label string, value float32, opts NumberOptions) (float32, bool) {
+	return numberInput(label, value, opts, false)
+}
+
+// IntInput is NumberInput for integers; dragged, stepped and typed values are rounded.
+func IntInput(label string, value int, opts NumberOptions) (int, bool) {
+	opts.Precision = 0
+	v, changed := numberInput(label, float32(value), opts, true)
+	return int(v), changed
+}
+
+func numberInput(label string, value float32, opts NumberOptions, integer bool) (float32, bool) {
+	id := imgui.IDStr(label)
+	size := imgui.Vec2{X: imgui.CalcItemWidth(), Y: imgui.FrameHeight()}
+	newValue := value
+
+	if numberEdit.id == id {
+		newValue = numberTypedEntry(label, value, size.X, opts, integer)
+	} else {
+		newValue = numberScrub(label, value, size, opts, integer)
+	}
+
+	if text := visibleLabel(label); text != "" {
+		imgui.SameLineV(0, imgui.CurrentStyle().ItemInnerSpacing().X)
+		imgui.AlignTextToFramePadding()
+		imgui.TextUnformatted(text)
+	}
+	return newValue, newValue != value
+}
+
+// numberTypedEntry draws the text field used while typing a value.
+func numberTypedEntry(label string, value, width float32, opts NumberOptions, integer bool) float32 {
+	if numberEdit.focus {
+		imgui.SetKeyboardFocusHere()
+		numberEdit.focus = false
+	}
+	imgui.SetNextItemWidth(width)
+	flags := imgui.InputTextFlagsEnterReturnsTrue | imgui.InputTextFlagsAutoSelectAll
+	entered := imgui.InputTextWithHint("##"+label, "", &numberEdit.text, flags, nil)
+	if imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		numberEdit.id = 0
+		return value
+	}
+	if !entered && !imgui.IsItemDeactivated() {
+		return value
+	}
+	numberEdit.id = 0
+	parsed, err := ParseNumber(numberFormat(opts).Canonical(numberEdit.text), opts.Unit)
+	if err != nil {
+		return value
+	}
+	return constrainNumber(float32(parsed), opts, integer)
+}
+
+// numberScrub draws the value as a frame that can be dragged, wheeled or clicked to type.
+func numberScrub(label string, value float32, size imgui.Vec2, opts NumberOptions, integer bool) float32 {
+	id := imgui.IDStr(label)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(label, size)
+	hovered := imgui.IsItemHovered()
+	active := imgui.IsItemActive()
+	io := imgui.CurrentIO()
+
+	if imgui.IsItemActivated() {
+		numberEdit.dragged = 0
+		numberEdit.drag = value
+	}
+	newValue := value
+	if active && imgui.IsMouseDraggingV(imgui.MouseButtonLeft, 2) {
+		numberEdit.dragged = id
+		if dx := io.MouseDelta().X; dx != 0 {
+			// accumulate unrounded so slow drags still move integer inputs
+			numberEdit.drag = constrainNumber(numberEdit.drag+dx*numberModifier(numberDragSpeed(opts, integer)), opts, false)
+			newValue = constrainNumber(numberEdit.drag, opts, integer)
+		}
+	}
+	if imgui.IsItemDeactivated() && numberEdit.dragged != id {
+		// a click without dragging starts typing
+		numberEdit.id = id
+		numberEdit.text = FormatNumber(value, opts)
+		numberEdit.focus = true
+	}
+	if hovered {
+		imgui.SetMouseCursor(imgui.MouseCursorResizeEW)
+		if wheel := io.MouseWheel(); wheel != 0 {
+			newValue = constrainNumber(newValue+wheel*numberModifier(numberStep(opts, integer)), opts, integer)
+		}
+	}
+
+	// frame, with the position within the range shown as a fill
+	style := imgui.CurrentStyle()
+	colors := style.Colors()
+	frame := colors[imgui.ColFrameBg]
+	switch {
+	case active:
+		frame = colors[imgui.ColFrameBgActive]
+	case hovered:
+		frame = colors[imgui.ColFrameBgHovered]
+	}
+	dl := imgui.WindowDrawList()
+	dl.AddRectFilledV(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(frame), style.FrameRounding(), imgui.DrawFlagsNone)
+	if opts.Max > opts.Min {
+		fill := colors[imgui.ColSliderGrab]
+		fill.W *= 0.35
+		fraction := (newValue - opts.Min) / (opts.Max - opts.Min)
+		dl.AddRectFilledV(pos, pos.Add(imgui.Vec2{X: size.X * fraction, Y: size.Y}), imgui.ColorConvertFloat4ToU32(fill), style.FrameRounding(), imgui.DrawFlagsNone)
+	}
+	if border := style.FrameBorderSize(); border > 0 {
+		// outline like imgui frames, e.g. the error outline of WithValidation
+		dl.AddRectV(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(colors[imgui.ColBorder]), style.FrameRounding(), imgui.DrawFlagsNone, border)
+	}
+	text := FormatNumber(newValue, opts)
+	textSize := imgui.CalcTextSize(text)
+	textPos := pos.Add(imgui.Vec2{X: (size.X - textSize.X) / 2, Y: (size.Y - textSize.Y) / 2})
+	dl.AddTextVec2(textPos, imgui.ColorConvertFloat4ToU32(colors[imgui.ColText]), text)
+
+	return newValue
+}
+
+// visibleLabel returns the part of an imgui label before any "##" id suffix.
+func visibleLabel(label string) string {
+	if i := strings.Index(label, "##"); i >= 0 {
+		return label[:i]
+	}
+	return label
+}
+
+// numberModifier scales amount by the wheel modifiers: Ctrl = faster, Alt = slower.
+func numberModifier(amount float32) float32 {
+	io := imgui.CurrentIO()
+	if io.KeyCtrl() {
+		return amount * wheelMultiplierFast
+	}
+	if io.KeyAlt() {
+		return amount / wheelMultiplierSlow
+	}
+	return amount
+}
+
+// numberStep returns the wheel step for opts.
+func numberStep(opts NumberOptions, integer bool) float32 {
+	switch {
+	case opts.Step > 0:
+		return opts.Step
+	case integer:
+		return 1
+	case opts.Max > opts.Min:
+		return (opts.Max - opts.Min) / 100
+	default:
+		return 0.1
+	}
+}
+
+// numberDragSpeed returns the change per pixel dragged for opts.
+func numberDragSpeed(opts NumberOptions, integer bool) float32 {
+	if opts.DragSpeed > 0 {
+		return opts.DragSpeed
+	}
+	if integer && opts.Step <= 0 {
+		// whole numbers change too quickly at one per pixel
+		return 0.25
+	}
+	return numberStep(opts, integer)
+}
+
+// constrainNumber clamps value to the bounds in opts, rounding it for integer inputs.
+func constrainNumber(value float32, opts NumberOptions, integer bool) float32 {
+	if opts.Max > opts.Min {
+		value = clamp(value, opts.Min, opts.Max)
+	}
+	if integer {
+		value = float32(math.Round(float64(value)))
+	}
+	return value
+}
+
+// FormatNumber formats value for display with the precision, SI prefix, unit and locale
+// format in opts.
+func FormatNumber(value float32, opts NumberOptions) string {
+	v := float64(value)
+	prefix := ""
+	if opts.SI {
+		v, prefix = siScale(v)
+	}
+	text := numberFormat(opts).FormatFloat(v, opts.Precision)
+	if prefix != "" || opts.Unit != "" {
+		text += " " + prefix + opts.Unit
+	}
+	return text
+}
+
+// numberFormat returns the locale format for opts.
+func numberFormat(opts NumberOptions) LocaleFormat {
+	if opts.Format != nil {
+		return *opts.Format
+	}
+	return CurrentFormat()
+}
+
+// siPrefixes are the SI prefixes accepted by ParseNumber, with their multipliers.
+var siPrefixes = map[rune]float64{
+	'p': 1e-12,
+	'n': 1e-9,
+	'u': 1e-6,
+	'µ': 1e-6,
+	'm': 1e-3,
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+}
+
+// siScale scales v into [1, 1000) and returns the SI prefix for the scale.
+func siScale(v float64) (float64, string) {
+	abs := math.Abs(v)
+	switch {
+	case abs == 0:
+		return v, ""
+	case abs >= 1e9:
+		return v / 1e9, "G"
+	case abs >= 1e6:
+		return v / 1e6, "M"
+	case abs >= 1e3:
+		return v / 1e3, "k"
+	case abs >= 1:
+		return v, ""
+	case abs >= 1e-3:
+		return v * 1e3, "m"
+	case abs >= 1e-6:
+		return v * 1e6, "u"
+	case abs >= 1e-9:
+		return v * 1e9, "n"
+	default:
+		return v * 1e12, "p"
+	}
+}
+
+// ParseNumber evaluates typed numeric input. numbers may carry an SI prefix and the unit
+// ("10k", "3.5ms", "-6dB"), and may be combined with + - * / and parentheses.
+func ParseNumber(text, unit string) (float64, error) {
+	p := &numberParser{text: []rune(text), unit: unit}
+	v, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	p.space()
+	if p.pos < len(p.text) {
+		return 0, fmt.Errorf("unexpected '%v' in '%v'", string(p.text[p.pos:]), text)
+	}
+	return v, nil
+}
+
+// numberParser is a recursive descent parser for ParseNumber.
+type numberParser struct {
+	text []rune
+	pos  int
+	unit string
+}
+
+func (p *numberParser) space() {
+	for p.pos < len(p.text) && unicode.IsSpace(p.text[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *numberParser) peek() rune {
+	p.space()
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+func (p *numberParser) expr() (float64, error) {
+	v, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			rhs, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			v += rhs
+		case '-':
+			p.pos++
+			rhs, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			v -= rhs
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *numberParser) term() (float64, error) {
+	v, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '*':
+			p.pos++
+			rhs, err := p.factor()
+			if err != nil {
+				return 0, err
+			}
+			v *= rhs
+		case '/':
+			p.pos++
+			rhs, err := p.factor()
+			if err != nil {
+				return 0, err
+			}
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			v /= rhs
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *numberParser) factor() (float64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		v, err := p.factor()
+		return -v, err
+	case '+':
+		p.pos++
+		return p.factor()
+	case '(':
+		p.pos++
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return v, nil
+	case 0:
+		return 0, fmt.Errorf("expected a number")
+	}
+	return p.number()
+}
+
+// number parses a literal with an optional SI prefix and unit suffix.
+func (p *numberParser) number() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.text) {
+		r := p.text[p.pos]
+		exponent := (r == 'e' || r == 'E') && p.pos+1 < len(p.text) && (unicode.IsDigit(p.text[p.pos+1]) || p.text[p.pos+1] == '-' || p.text[p.pos+1] == '+')
+		sign := (r == '-' || r == '+') && p.pos > start && (p.text[p.pos-1] == 'e' || p.text[p.pos-1] == 'E')
+		if !unicode.IsDigit(r) && r != '.' && !exponent && !sign {
+			break
+		}
+		p.pos++
+	}
+	literal := string(p.text[start:p.pos])
+	v, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number '%v'", literal)
+	}
+
+	p.space()
+	suffixStart := p.pos
+	for p.pos < len(p.text) && unicode.IsLetter(p.text[p.pos]) {
+		p.pos++
+	}
+	suffix := string(p.text[suffixStart:p.pos])
+	multiplier, ok := p.suffixMultiplier(suffix)
+	if !ok {
+		return 0, fmt.Errorf("unknown suffix '%v'", suffix)
+	}
+	return v * multiplier, nil
+}
+
+// suffixMultiplier interprets a suffix as the unit, an SI prefix, or a prefixed unit.
+func (p *numberParser) suffixMultiplier(suffix string) (float64, bool) {
+	if suffix == "" || strings.EqualFold(suffix, p.unit) {
+		return 1, true
+	}
+	prefix := []rune(suffix)[0]
+	rest := string([]rune(suffix)[1:])
+	multiplier, found := siPrefixes[prefix]
+	if !found {
+		return 0, false
+	}
+	if rest == "" || strings.EqualFold(rest, p.unit) {
+		return multiplier, true
+	}
+	return 0, false
+}
@@ -0,0 +1,87 @@
+package dfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		text     string
+		unit     string
+		expected float64
+	}{
+		{"42", "", 42},
+		{" -1.5 ", "", -1.5},
+		{"10k", "Hz", 10000},
+		{"10 kHz", "Hz", 10000},
+		{"3.5ms", "s", 0.0035},
+		{"-6dB", "dB", -6},
+		{"-6 db", "dB", -6},
+		{"5m", "m", 5},
+		{"5mm", "m", 0.005},
+		{"2.2M", "", 2.2e6},
+		{"1e3", "", 1000},
+		{"1.5e-3", "", 0.0015},
+		{"2*440", "Hz", 880},
+		{"(1+2)/4", "", 0.75},
+		{"1k - 250", "", 750},
+		{"-(2+3)*2", "", -10},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.text, tt.unit)
+		if err != nil {
+			t.Fatalf("'%v': unexpected error: %v", tt.text, err)
+		}
+		if math.Abs(got-tt.expected) > 1e-9 {
+			t.Fatalf("'%v': expected %v, got %v", tt.text, tt.expected, got)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "10x", "1/0", "(1+2", "1 2", "5s"} {
+		if _, err := ParseNumber(bad, "Hz"); err == nil {
+			t.Fatalf("'%v': expected error", bad)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	if got := FormatNumber(0.5, NumberOptions{Precision: 2}); got != "0.50" {
+		t.Fatalf("unexpected '%v'", got)
+	}
+	if got := FormatNumber(-6, NumberOptions{Precision: 1, Unit: "dB"}); got != "-6.0 dB" {
+		t.Fatalf("unexpected '%v'", got)
+	}
+	if got := FormatNumber(12500, NumberOptions{Precision: 1, Unit: "Hz", SI: true}); got != "12.5 kHz" {
+		t.Fatalf("unexpected '%v'", got)
+	}
+	if got := FormatNumber(0.0035, NumberOptions{Precision: 1, Unit: "s", SI: true}); got != "3.5 ms" {
+		t.Fatalf("unexpected '%v'", got)
+	}
+
+	// formatted values parse back
+	opts := NumberOptions{Precision: 3, Unit: "Hz", SI: true}
+	if v, err := ParseNumber(FormatNumber(440, opts), opts.Unit); err != nil || v != 440 {
+		t.Fatalf("expected round trip, got %v (%v)", v, err)
+	}
+}
+
+func TestConstrainNumber(t *testing.T) {
+	opts := NumberOptions{Min: 0, Max: 10}
+	if constrainNumber(12, opts, false) != 10 || constrainNumber(-1, opts, false) != 0 {
+		t.Fatalf("expected clamping")
+	}
+	if constrainNumber(1e6, NumberOptions{}, false) != 1e6 {
+		t.Fatalf("expected unbounded value")
+	}
+	if constrainNumber(2.6, opts, true) != 3 {
+		t.Fatalf("expected rounding")
+	}
+
+	if numberStep(opts, false) != 0.1 || numberStep(NumberOptions{}, true) != 1 || numberStep(NumberOptions{Step: 5}, true) != 5 {
+		t.Fatalf("unexpected default steps")
+	}
+	if visibleLabel("Gain##ch1") != "Gain" || visibleLabel("##hidden") != "" || visibleLabel("Pan") != "Pan" {
+		t.Fatalf("unexpected visible labels")
+	}
+}
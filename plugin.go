@@ -0,0 +1,216 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// PluginSymbol is the function a component plugin exports to create its component:
+//
+//	func NewComponent() dfx.Component
+const PluginSymbol = "NewComponent"
+
+// DefaultPluginPollInterval is how often PluginHost checks plugins for changes.
+const DefaultPluginPollInterval = 500 * time.Millisecond
+
+// PluginLoader opens the plugin file at path and returns its component factory, the
+// function exported as PluginSymbol.
+type PluginLoader func(path string) (func() Component, error)
+
+// PluginHost loads components from plugins by name, and reloads them when they are
+// rebuilt, so a panel can be iterated on without restarting the app. a plugin named
+// "mixer" is loaded from Dir/mixer.so by Loader. the goplugin package provides a Loader
+// for Go plugins (go build -buildmode=plugin); it lives apart from dfx so only apps using
+// it link the plugin runtime.
+//
+// call Tick once per frame from the UI thread (e.g. from Config.OnTick) to reload changed
+// plugins.
+type PluginHost struct {
+	Dir          string                       // directory holding <name>.so plugins
+	Loader       PluginLoader                 // opens plugins, such as goplugin.Open
+	PollInterval time.Duration                // change detection interval; negative disables watching (default DefaultPluginPollInterval)
+	OnLoad       func(name string)            // called after a plugin is loaded or reloaded
+	OnError      func(name string, err error) // called when loading a plugin fails
+
+	panels   map[string]*PluginPanel
+	order    []string
+	lastPoll time.Time
+
+	now  func() time.Time
+	stat func(path string) (time.Time, error)
+}
+
+// NewPluginHost creates a host loading plugins from dir with loader.
+func NewPluginHost(dir string, loader PluginLoader) *PluginHost {
+	return &PluginHost{
+		Dir:          dir,
+		Loader:       loader,
+		PollInterval: DefaultPluginPollInterval,
+		panels:       make(map[string]*PluginPanel),
+		now:          time.Now,
+		stat:         pluginModTime,
+	}
+}
+
+// Path returns the file the plugin name is loaded from.
+func (h *PluginHost) Path(name string) string {
+	return filepath.Join(h.Dir, name+".so")
+}
+
+// Panel returns the panel showing the plugin name, loading the plugin the first time.
+func (h *PluginHost) Panel(name string) *PluginPanel {
+	if p, found := h.panels[name]; found {
+		return p
+	}
+	p := &PluginPanel{Container: Container{Visible: true}, Name: name, host: h}
+	h.panels[name] = p
+	h.order = append(h.order, name)
+	_ = h.load(p)
+	return p
+}
+
+// Reload loads the plugin name again, keeping the panel's current component if it fails.
+func (h *PluginHost) Reload(name string) error {
+	return h.load(h.Panel(name))
+}
+
+// Tick reloads the plugins whose files changed, once the file has not been written to for
+// PollInterval, so a plugin still being built is not loaded half written.
+func (h *PluginHost) Tick() {
+	interval := h.PollInterval
+	if interval < 0 {
+		return
+	}
+	if interval == 0 {
+		interval = DefaultPluginPollInterval
+	}
+	now := h.now()
+	if now.Sub(h.lastPoll) < interval {
+		return
+	}
+	h.lastPoll = now
+	for _, name := range h.order {
+		p := h.panels[name]
+		modTime, err := h.stat(h.Path(name))
+		if err != nil || modTime.Equal(p.modTime) || modTime.Equal(p.failedTime) || now.Sub(modTime) < interval {
+			continue
+		}
+		_ = h.load(p)
+	}
+}
+
+// load opens the plugin of p and replaces its component, carrying the state of a
+// StatefulComponent over to the new one.
+func (h *PluginHost) load(p *PluginPanel) error {
+	path := h.Path(p.Name)
+	modTime, err := h.stat(path)
+	if err == nil && h.Loader == nil {
+		err = errors.New("no plugin loader")
+	}
+	if err == nil {
+		var factory func() Component
+		if factory, err = h.Loader(path); err == nil {
+			err = p.replace(factory())
+		}
+	}
+	if err != nil {
+		p.err = errors.Wrapf(err, "error loading plugin '%v'", p.Name)
+		p.failedTime = modTime
+		if h.OnError != nil {
+			h.OnError(p.Name, p.err)
+		}
+		return p.err
+	}
+	p.modTime, p.failedTime, p.err = modTime, time.Time{}, nil
+	if h.OnLoad != nil {
+		h.OnLoad(p.Name)
+	}
+	return nil
+}
+
+func pluginModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// PluginPanel shows the component loaded from a plugin, swapping in the new component
+// whenever the plugin is reloaded. while the plugin is not loaded it shows why, with a
+// button to retry. create panels with PluginHost.Panel.
+type PluginPanel struct {
+	Container
+	Name string
+
+	host       *PluginHost
+	component  Component
+	modTime    time.Time // modification time of the loaded plugin
+	failedTime time.Time // modification time of the plugin that last failed to load
+	err        error
+}
+
+// Component returns the loaded component, or nil.
+func (p *PluginPanel) Component() Component {
+	return p.component
+}
+
+// Err returns the error from the last attempt to load the plugin, or nil.
+func (p *PluginPanel) Err() error {
+	return p.err
+}
+
+// replace swaps in the component from a newly loaded plugin.
+func (p *PluginPanel) replace(next Component) error {
+	if next == nil {
+		return errors.Errorf("'%v' returned nil", PluginSymbol)
+	}
+	if old, ok := p.component.(StatefulComponent); ok {
+		if restorer, ok := next.(StatefulComponent); ok {
+			if state := old.CaptureState(); state != nil {
+				restorer.RestoreState(state)
+			}
+		}
+	}
+	if p.component != nil {
+		UnmountComponent(p.component)
+	}
+	p.component = next
+	return nil
+}
+
+// Draw implements Component.
+func (p *PluginPanel) Draw(state *State) {
+	if !p.Visible {
+		return
+	}
+	if p.component != nil {
+		DrawChild(p.component, state.WithParent(p))
+	} else {
+		imgui.PushIDStr(componentID("pluginPanel", p))
+		if p.err != nil {
+			imgui.PushTextWrapPos()
+			imgui.TextColored(ThemeColors().Error, p.err.Error())
+			imgui.PopTextWrapPos()
+		} else {
+			imgui.TextDisabled(T("dfx.plugin.missing", p.Name))
+		}
+		if imgui.Button(T("dfx.plugin.reload")) && p.host != nil {
+			_ = p.host.load(p)
+		}
+		imgui.PopID()
+	}
+	drawContainerExtensions(&p.Container, state)
+}
+
+// Actions implements Component by delegating to the loaded component.
+func (p *PluginPanel) Actions() *ActionRegistry {
+	if p.component != nil {
+		return p.component.Actions()
+	}
+	return p.Container.Actions()
+}
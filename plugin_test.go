@@ -0,0 +1,83 @@
+package dfx
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type pluginTestComponent struct {
+	Container
+	version int
+	count   int
+}
+
+func (c *pluginTestComponent) CaptureState() map[string]any {
+	return map[string]any{"count": c.count}
+}
+
+func (c *pluginTestComponent) RestoreState(state map[string]any) {
+	c.count = state["count"].(int)
+}
+
+func newPluginTestHost() (*PluginHost, map[string]time.Time, *time.Time) {
+	clock := time.Unix(1000, 0)
+	files := map[string]time.Time{}
+	versions := 0
+	h := NewPluginHost("plugins", func(path string) (func() Component, error) {
+		if files[path].IsZero() {
+			return nil, errors.New("broken")
+		}
+		versions++
+		version := versions
+		return func() Component { return &pluginTestComponent{version: version} }, nil
+	})
+	h.now = func() time.Time { return clock }
+	h.stat = func(path string) (time.Time, error) {
+		if t, found := files[path]; found {
+			return t, nil
+		}
+		return time.Time{}, os.ErrNotExist
+	}
+	return h, files, &clock
+}
+
+func TestPluginHost_LoadAndReload(t *testing.T) {
+	h, files, clock := newPluginTestHost()
+	path := h.Path("mixer")
+
+	// missing plugin: the panel reports why, and loads once the plugin is built
+	p := h.Panel("mixer")
+	if p.Component() != nil || p.Err() == nil {
+		t.Fatalf("expected missing plugin error, got '%v'", p.Err())
+	}
+	files[path] = clock.Add(-time.Second)
+	h.Tick()
+	first, ok := p.Component().(*pluginTestComponent)
+	if !ok || p.Err() != nil {
+		t.Fatalf("expected plugin loaded, got '%v'", p.Err())
+	}
+	first.count = 7
+
+	// a rebuild is only loaded once the file has settled, and keeps the state
+	*clock = clock.Add(time.Second)
+	files[path] = *clock
+	h.Tick()
+	if p.Component() != first {
+		t.Fatalf("expected plugin still being written not to load")
+	}
+	*clock = clock.Add(time.Second)
+	h.Tick()
+	second := p.Component().(*pluginTestComponent)
+	if second == first || second.count != 7 {
+		t.Fatalf("expected reload carrying state '7', got '%+v'", second)
+	}
+
+	// a broken build keeps the current component, and is not retried until rebuilt
+	files[path] = time.Time{}
+	if err := h.Reload("mixer"); err == nil || p.Component() != second {
+		t.Fatalf("expected failed reload to keep the component, got '%v'", err)
+	}
+}
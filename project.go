@@ -0,0 +1,457 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// project constants
+const (
+	projectPromptID         = "##dfxProjectPrompt"
+	projectRecoveryPromptID = "##dfxProjectRecovery"
+	projectRecoverySuffix   = ".recovery"
+	projectUntitledName     = "untitled"
+)
+
+// Project manages the document an app edits: New, Open, Save and Save As, dirty tracking,
+// a "*" in the window title while there are unsaved changes, a prompt to save them before
+// they would be lost, and autosave to a recovery file.
+//
+// the document is any pointer the Codec can serialize; without a Codec it is saved like a
+// config, in the format selected by the file extension. with Undo set, the document is
+// dirty whenever its undo history has left the saved state; call MarkDirty for changes
+// made outside of it.
+//
+// call Tick once per frame from Config.OnTick, and wire OnClose into Config.OnClose:
+//
+//	project := dfx.NewProject(func() interface{} { return &Song{} })
+//	project.Undo = undo
+//	project.AppTitle = "Composer"
+//	app := dfx.New(root, dfx.Config{OnTick: project.Tick, OnClose: project.OnClose})
+type Project struct {
+	Document    interface{}        // pointer to the document model
+	NewDocument func() interface{} // returns an empty document, for New and to load into
+	Codec       ConfigCodec        // document serialization (nil = selected by file extension)
+	Undo        *UndoSystem        // optional; dirty tracking follows its history
+	Recent      *RecentFiles       // optional; opened and saved paths are recorded in it
+	AppTitle    string             // when set, Tick keeps the window title at Title(AppTitle)
+
+	// ChoosePath asks for a path to save to (save) or to open, starting at current. returns
+	// false when cancelled. without it, Open and SaveAs need a path, and Save of a document
+	// never saved fails.
+	ChoosePath func(save bool, current string) (string, bool)
+
+	// autosave: every AutosaveInterval, a dirty document is written to a recovery file
+	// beside it (untitled documents go in RecoveryDir), removed again when it is saved or
+	// discarded. opening a document with a recovery file offers to restore it.
+	AutosaveInterval time.Duration // 0 = no autosave
+	RecoveryDir      string        // directory for untitled documents' recovery files ("" = not autosaved)
+
+	OnChange func(p *Project) // called after the document is replaced or saved, or its dirty state changes
+	OnError  func(error)      // called when loading, saving or autosaving fails
+
+	path         string
+	modified     bool   // changed outside the undo history
+	dirtyShown   bool   // dirty state last reported to OnChange
+	titleShown   string // window title last set
+	lastAutosave time.Time
+	pending      func() // action waiting for the unsaved changes prompt
+	prompt       bool   // open the unsaved changes prompt
+	recovery     bool   // open the recovery prompt
+	now          func() time.Time
+}
+
+// NewProject creates a project holding a new document from newDocument.
+func NewProject(newDocument func() interface{}) *Project {
+	return &Project{
+		Document:    newDocument(),
+		NewDocument: newDocument,
+	}
+}
+
+// Path returns the file the document was opened from or last saved to ("" = never saved).
+func (p *Project) Path() string {
+	return p.path
+}
+
+// Name returns the file name of the document, or T("dfx.doc.untitled").
+func (p *Project) Name() string {
+	if p.path == "" {
+		return T("dfx.doc.untitled")
+	}
+	return filepath.Base(p.path)
+}
+
+// Dirty reports whether the document has unsaved changes.
+func (p *Project) Dirty() bool {
+	return p.modified || (p.Undo != nil && !p.Undo.Clean())
+}
+
+// MarkDirty records a change made outside of the undo history.
+func (p *Project) MarkDirty() {
+	p.modified = true
+}
+
+// Title returns the window title for the document: its name, then app when not empty,
+// prefixed with "*" while there are unsaved changes.
+func (p *Project) Title(app string) string {
+	title := p.Name()
+	if p.Dirty() {
+		title = "*" + title
+	}
+	if app != "" {
+		title += " - " + app
+	}
+	return title
+}
+
+// New replaces the document with an empty one, after offering to save unsaved changes.
+func (p *Project) New() {
+	p.guard(func() {
+		p.replace(p.NewDocument(), "")
+	})
+}
+
+// Open loads the document at path, or at a path from ChoosePath when empty, after
+// offering to save unsaved changes.
+func (p *Project) Open(path string) {
+	p.guard(func() {
+		if path == "" {
+			var ok bool
+			if path, ok = p.choosePath(false); !ok {
+				return
+			}
+		}
+		p.report(p.open(path))
+	})
+}
+
+// Save saves the document to its path, asking for one with ChoosePath when it was never
+// saved.
+func (p *Project) Save() error {
+	return p.SaveAs(p.path)
+}
+
+// SaveAs saves the document to path, or to a path from ChoosePath when empty, which
+// becomes the document's path.
+func (p *Project) SaveAs(path string) error {
+	if path == "" {
+		var ok bool
+		if path, ok = p.choosePath(true); !ok {
+			return errors.New("no path to save the document to")
+		}
+	}
+	err := p.save(path)
+	p.report(err)
+	return err
+}
+
+// OnClose implements Config.OnClose: while there are unsaved changes, it keeps the window
+// open and offers to save them first.
+func (p *Project) OnClose(app *App) {
+	if !p.Dirty() {
+		return
+	}
+	app.SetShouldClose(false)
+	p.guard(app.Stop)
+}
+
+// RecoveryPath returns the file the document is autosaved to ("" = it is not).
+func (p *Project) RecoveryPath() string {
+	if p.path != "" {
+		return filepath.Join(filepath.Dir(p.path), "."+filepath.Base(p.path)+projectRecoverySuffix)
+	}
+	if p.RecoveryDir != "" {
+		return filepath.Join(p.RecoveryDir, projectUntitledName+projectRecoverySuffix)
+	}
+	return ""
+}
+
+// HasRecovery reports whether an autosaved version of the document is waiting to be
+// recovered: a recovery file newer than the document.
+func (p *Project) HasRecovery() bool {
+	path := p.RecoveryPath()
+	if path == "" {
+		return false
+	}
+	recovery, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if p.path == "" {
+		return true
+	}
+	saved, err := os.Stat(p.path)
+	return err != nil || recovery.ModTime().After(saved.ModTime())
+}
+
+// Recover loads the autosaved version of the document, which is dirty until saved.
+func (p *Project) Recover() error {
+	doc, err := p.load(p.RecoveryPath(), p.codecFor(p.path))
+	if err != nil {
+		p.report(err)
+		return err
+	}
+	p.Document = doc
+	p.modified = true
+	p.changed()
+	return nil
+}
+
+// DiscardRecovery removes the recovery file.
+func (p *Project) DiscardRecovery() {
+	if path := p.RecoveryPath(); path != "" {
+		_ = os.Remove(path)
+	}
+}
+
+// Tick keeps the window title current, autosaves, and draws the prompts. call it once per
+// frame, e.g. from Config.OnTick.
+func (p *Project) Tick(app *App) {
+	if p.AppTitle != "" {
+		if title := p.Title(p.AppTitle); title != p.titleShown {
+			p.titleShown = title
+			app.SetWindowTitle(title)
+		}
+	}
+	if dirty := p.Dirty(); dirty != p.dirtyShown {
+		p.dirtyShown = dirty
+		if p.OnChange != nil {
+			p.OnChange(p)
+		}
+	}
+	p.autosave()
+	p.drawPrompts()
+}
+
+// guard runs action now when there are no unsaved changes, and otherwise once the user
+// has saved or discarded them.
+func (p *Project) guard(action func()) {
+	if !p.Dirty() {
+		action()
+		return
+	}
+	p.pending = action
+	p.prompt = true
+}
+
+// resolve finishes the unsaved changes prompt: saving first when save, then running the
+// pending action, unless the save failed or was cancelled.
+func (p *Project) resolve(save bool) {
+	action := p.pending
+	p.pending = nil
+	if save && p.Save() != nil {
+		return
+	}
+	if !save {
+		p.DiscardRecovery()
+	}
+	if action != nil {
+		action()
+	}
+}
+
+// replace makes doc, stored at path, the document, with a clean history.
+func (p *Project) replace(doc interface{}, path string) {
+	p.DiscardRecovery()
+	p.Document = doc
+	p.path = path
+	p.markClean()
+	if p.Undo != nil {
+		p.Undo.Clear()
+		p.Undo.MarkClean()
+	}
+	p.changed()
+}
+
+// open loads the document at path, offering to recover its autosaved version.
+func (p *Project) open(path string) error {
+	path = recentFilePath(path)
+	doc, err := p.load(path, p.codecFor(path))
+	if err != nil {
+		return err
+	}
+	p.Document = doc
+	p.path = path
+	p.markClean()
+	if p.Undo != nil {
+		p.Undo.Clear()
+		p.Undo.MarkClean()
+	}
+	if p.Recent != nil {
+		p.Recent.RecordOpen(path)
+	}
+	p.recovery = p.HasRecovery()
+	p.changed()
+	return nil
+}
+
+// load reads a new document from path with codec.
+func (p *Project) load(path string, codec ConfigCodec) (interface{}, error) {
+	if codec == nil {
+		return nil, errors.Errorf("no codec for document '%v'", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading document '%v'", path)
+	}
+	doc := p.NewDocument()
+	if err := codec.Merge(doc, data); err != nil {
+		return nil, errors.Wrapf(err, "error decoding document '%v'", path)
+	}
+	return doc, nil
+}
+
+// save writes the document to path, which becomes its path, and marks it clean.
+func (p *Project) save(path string) error {
+	path = recentFilePath(path)
+	codec := p.codecFor(path)
+	if codec == nil {
+		return errors.Errorf("no codec for document '%v'", path)
+	}
+	if err := saveConfigWith(codec, path, p.Document); err != nil {
+		return err
+	}
+	p.DiscardRecovery() // of the untitled document, or of the old path
+	p.path = path
+	p.DiscardRecovery()
+	p.markClean()
+	if p.Undo != nil {
+		p.Undo.MarkClean()
+	}
+	if p.Recent != nil {
+		p.Recent.RecordOpen(path)
+	}
+	p.changed()
+	return nil
+}
+
+// autosave writes a dirty document to its recovery file every AutosaveInterval.
+func (p *Project) autosave() {
+	if p.AutosaveInterval <= 0 {
+		return
+	}
+	now := p.clock()
+	if p.lastAutosave.IsZero() {
+		p.lastAutosave = now
+	}
+	if now.Sub(p.lastAutosave) < p.AutosaveInterval {
+		return
+	}
+	p.lastAutosave = now
+	path := p.RecoveryPath()
+	codec := p.codecFor(p.path)
+	if !p.Dirty() || path == "" || codec == nil {
+		return
+	}
+	data, err := codec.Marshal(p.Document)
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+			err = writeFileAtomic(path, data, 0600)
+		}
+	}
+	if err != nil {
+		p.report(errors.Wrapf(err, "error autosaving document to '%v'", path))
+	}
+}
+
+// codecFor returns Codec, or the codec for the extension of path. untitled documents
+// without a Codec are autosaved as JSON.
+func (p *Project) codecFor(path string) ConfigCodec {
+	if p.Codec != nil {
+		return p.Codec
+	}
+	if path == "" {
+		return jsonCodec{}
+	}
+	codec, err := ConfigCodecFor(path)
+	if err != nil {
+		return nil
+	}
+	return codec
+}
+
+func (p *Project) choosePath(save bool) (string, bool) {
+	if p.ChoosePath == nil {
+		return "", false
+	}
+	return p.ChoosePath(save, p.path)
+}
+
+func (p *Project) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+func (p *Project) markClean() {
+	p.modified = false
+}
+
+// changed reports a new document, path or dirty state to OnChange.
+func (p *Project) changed() {
+	p.dirtyShown = p.Dirty()
+	if p.OnChange != nil {
+		p.OnChange(p)
+	}
+}
+
+func (p *Project) report(err error) {
+	if err != nil && p.OnError != nil {
+		p.OnError(err)
+	}
+}
+
+// drawPrompts draws the unsaved changes and recovery prompts while they are open.
+func (p *Project) drawPrompts() {
+	if p.prompt {
+		imgui.OpenPopupStr(projectPromptID)
+		p.prompt = false
+	}
+	if p.recovery {
+		imgui.OpenPopupStr(projectRecoveryPromptID)
+		p.recovery = false
+	}
+
+	if imgui.BeginPopupModalV(T("dfx.doc.unsavedTitle")+projectPromptID, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		imgui.Text(T("dfx.doc.unsaved", p.Name()))
+		imgui.Separator()
+		switch {
+		case imgui.Button(T("dfx.doc.save")):
+			imgui.CloseCurrentPopup()
+			p.resolve(true)
+		case sameLineButton(T("dfx.doc.discard")):
+			imgui.CloseCurrentPopup()
+			p.resolve(false)
+		case sameLineButton(T("dfx.doc.cancel")) || imgui.IsKeyPressedBool(imgui.KeyEscape):
+			imgui.CloseCurrentPopup()
+			p.pending = nil
+		}
+		imgui.EndPopup()
+	}
+
+	if imgui.BeginPopupModalV(T("dfx.doc.recoveryTitle")+projectRecoveryPromptID, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		imgui.Text(T("dfx.doc.recovery", p.Name()))
+		imgui.Separator()
+		switch {
+		case imgui.Button(T("dfx.doc.recover")):
+			imgui.CloseCurrentPopup()
+			_ = p.Recover()
+		case sameLineButton(T("dfx.doc.discard")):
+			imgui.CloseCurrentPopup()
+			p.DiscardRecovery()
+		}
+		imgui.EndPopup()
+	}
+}
+
+// sameLineButton draws a button on the line of the previous item.
+func sameLineButton(label string) bool {
+	imgui.SameLine()
+	return imgui.Button(label)
+}
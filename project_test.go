@@ -0,0 +1,113 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type projectTestDocument struct {
+	Title string
+	Notes []string
+}
+
+func newProjectTestDocument() interface{} {
+	return &projectTestDocument{Title: "new"}
+}
+
+func TestProject_SaveOpenDirty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "song.json")
+	p := NewProject(newProjectTestDocument)
+	p.Undo = NewUndoSystem()
+	changes := 0
+	p.OnChange = func(*Project) { changes++ }
+
+	if p.Dirty() || p.Title("App") != "Untitled - App" {
+		t.Fatalf("expected a clean untitled document, got '%v'", p.Title("App"))
+	}
+	if err := p.Save(); err == nil {
+		t.Fatalf("expected saving an untitled document without ChoosePath to fail")
+	}
+
+	value := 0
+	p.Undo.Run(&undoTestCommand{value: &value, to: 1})
+	p.Document.(*projectTestDocument).Title = "edited"
+	if !p.Dirty() || p.Title("App") != "*Untitled - App" {
+		t.Fatalf("expected a dirty document, got '%v'", p.Title("App"))
+	}
+
+	p.ChoosePath = func(save bool, current string) (string, bool) { return path, save }
+	if err := p.Save(); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+	if p.Dirty() || p.Name() != "song.json" || changes == 0 {
+		t.Fatalf("expected a clean saved document, got '%v' dirty %v", p.Name(), p.Dirty())
+	}
+
+	// new and open run at once when there is nothing to lose
+	p.New()
+	if p.Document.(*projectTestDocument).Title != "new" || p.Path() != "" {
+		t.Fatalf("expected a new document")
+	}
+	p.Open(path)
+	if p.Document.(*projectTestDocument).Title != "edited" || p.Path() != path {
+		t.Fatalf("expected the saved document opened, got '%+v'", p.Document)
+	}
+
+	// with unsaved changes they wait for the prompt
+	p.MarkDirty()
+	p.New()
+	if p.Path() != path || p.pending == nil {
+		t.Fatalf("expected new to wait for the unsaved changes prompt")
+	}
+	p.resolve(false)
+	if p.Path() != "" || p.Dirty() {
+		t.Fatalf("expected changes discarded and a new document")
+	}
+}
+
+func TestProject_AutosaveRecover(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "song.json")
+	clock := time.Now()
+
+	p := NewProject(newProjectTestDocument)
+	p.now = func() time.Time { return clock }
+	p.AutosaveInterval = time.Minute
+	if err := p.SaveAs(path); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+
+	p.Document.(*projectTestDocument).Notes = []string{"c", "e"}
+	p.MarkDirty()
+	p.autosave()
+	if p.HasRecovery() {
+		t.Fatalf("expected no autosave before the interval")
+	}
+	clock = clock.Add(time.Minute)
+	p.autosave()
+	if _, err := os.Stat(p.RecoveryPath()); err != nil {
+		t.Fatalf("expected a recovery file: %v", err)
+	}
+
+	// reopening offers the autosaved changes
+	reopened := NewProject(newProjectTestDocument)
+	reopened.Open(path)
+	if !reopened.recovery || len(reopened.Document.(*projectTestDocument).Notes) != 0 {
+		t.Fatalf("expected the saved document opened with recovery offered")
+	}
+	if err := reopened.Recover(); err != nil {
+		t.Fatalf("unexpected recover error: %v", err)
+	}
+	if len(reopened.Document.(*projectTestDocument).Notes) != 2 || !reopened.Dirty() {
+		t.Fatalf("expected the autosaved document recovered and dirty, got '%+v'", reopened.Document)
+	}
+
+	if err := reopened.Save(); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+	if _, err := os.Stat(reopened.RecoveryPath()); !os.IsNotExist(err) {
+		t.Fatalf("expected the recovery file removed on save")
+	}
+}
@@ -0,0 +1,106 @@
+package dfx
+
+import "github.com/AllenDang/cimgui-go/imgui"
+
+const quitConfirmPopupID = "##dfxQuitConfirm"
+
+// QuitConfirm asks to save unsaved changes when the window is closed, with Save, Discard and
+// Cancel, for apps keeping their changes in an UndoSystem or their own model rather than a
+// Project (which has its own OnClose). set it with App.ConfirmQuit:
+//
+//	app.ConfirmQuit(&dfx.QuitConfirm{Undo: undo, Save: song.Save})
+type QuitConfirm struct {
+	Undo  *UndoSystem  // changes are unsaved while its history is not clean; marked clean once saved
+	Dirty func() bool  // reports unsaved changes, with or instead of Undo
+	Save  func() error // saves the changes; the window closes once it succeeds (nil = no Save button)
+	Name  string       // what has unsaved changes, named in the prompt (empty = a generic prompt)
+
+	confirmed bool   // changes were saved or discarded; the next close goes ahead
+	prompt    bool   // open the prompt
+	status    string // error of the last save
+}
+
+// Unsaved reports whether there are unsaved changes.
+func (q *QuitConfirm) Unsaved() bool {
+	return (q.Undo != nil && !q.Undo.Clean()) || (q.Dirty != nil && q.Dirty())
+}
+
+// intercept keeps the window open and opens the prompt when there are unsaved changes.
+// returns true when it did.
+func (q *QuitConfirm) intercept(app *App) bool {
+	if q.confirmed {
+		q.confirmed = false
+		return false
+	}
+	if !q.Unsaved() {
+		return false
+	}
+	app.SetShouldClose(false)
+	q.prompt = true
+	q.status = ""
+	return true
+}
+
+// resolve finishes the prompt, saving first when save, then closing the window. returns
+// false when the save failed, leaving the prompt open.
+func (q *QuitConfirm) resolve(app *App, save bool) bool {
+	if save {
+		if err := q.Save(); err != nil {
+			q.status = T("dfx.quit.failed", err)
+			return false
+		}
+		if q.Undo != nil {
+			q.Undo.MarkClean()
+		}
+	}
+	q.confirmed = true
+	app.Close()
+	return true
+}
+
+// draw draws the prompt while it is open.
+func (q *QuitConfirm) draw(app *App) {
+	if q.prompt {
+		q.prompt = false
+		imgui.OpenPopupStr(quitConfirmPopupID)
+	}
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(T("dfx.doc.unsavedTitle")+quitConfirmPopupID, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		return
+	}
+	if q.Name != "" {
+		imgui.Text(T("dfx.doc.unsaved", q.Name))
+	} else {
+		imgui.Text(T("dfx.quit.unsaved"))
+	}
+	if q.status != "" {
+		imgui.TextUnformatted(q.status)
+	}
+	imgui.Separator()
+	save := q.Save != nil && imgui.Button(T("dfx.doc.save"))
+	if q.Save != nil {
+		imgui.SameLine()
+	}
+	discard := imgui.Button(T("dfx.doc.discard"))
+	cancel := sameLineButton(T("dfx.doc.cancel")) || imgui.IsKeyPressedBool(imgui.KeyEscape)
+	switch {
+	case save:
+		if q.resolve(app, true) {
+			imgui.CloseCurrentPopup()
+		}
+	case discard:
+		imgui.CloseCurrentPopup()
+		q.resolve(app, false)
+	case cancel:
+		imgui.CloseCurrentPopup()
+	}
+	imgui.EndPopup()
+}
+
+// ConfirmQuit makes closing the window ask to save or discard unsaved changes first, as
+// described by confirm. Config.OnClose is called once they are saved or discarded, or when
+// there are none. nil turns the prompt off.
+func (app *App) ConfirmQuit(confirm *QuitConfirm) {
+	app.quit = confirm
+}
@@ -0,0 +1,97 @@
+package dfx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQuitConfirm_PromptsForUnsavedChanges(t *testing.T) {
+	closes := 0
+	app := New(NewFunc(func(state *State) {}), Config{OnClose: func(*App) { closes++ }})
+	undo := NewUndoSystem()
+	saveErr := errors.New("disk full")
+	saves := 0
+	confirm := &QuitConfirm{Undo: undo, Save: func() error {
+		saves++
+		return saveErr
+	}}
+	app.ConfirmQuit(confirm)
+
+	// nothing unsaved: closes straight away
+	app.onClose()
+	if closes != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, closes)
+	}
+	if confirm.prompt {
+		t.Fatal("unexpected confirm.prompt")
+	}
+
+	value := 0
+	undo.Run(&undoTestCommand{value: &value, to: 1})
+	app.onClose()
+	if closes != 1 {
+		t.Fatalf("OnClose waits for the prompt: expected '%v', got '%v'", 1, closes)
+	}
+	if !confirm.prompt {
+		t.Fatal("expected confirm.prompt")
+	}
+
+	// a failed save keeps the prompt open
+	if confirm.resolve(app, true) {
+		t.Fatal("unexpected confirm.resolve(app, true)")
+	}
+	if !strings.Contains(confirm.status, "disk full") {
+		t.Fatalf("expected '%v' to contain '%v'", confirm.status, "disk full")
+	}
+	if undo.Clean() {
+		t.Fatal("unexpected undo.Clean()")
+	}
+
+	saveErr = nil
+	if !confirm.resolve(app, true) {
+		t.Fatal("expected confirm.resolve(app, true)")
+	}
+	if saves != 2 {
+		t.Fatalf("expected '%v', got '%v'", 2, saves)
+	}
+	if !undo.Clean() {
+		t.Fatal("expected undo.Clean()")
+	}
+	app.onClose()
+	if closes != 2 {
+		t.Fatalf("expected '%v', got '%v'", 2, closes)
+	}
+	if confirm.confirmed {
+		t.Fatal("only the confirmed close goes ahead: unexpected confirm.confirmed")
+	}
+}
+
+func TestQuitConfirm_DiscardAndDirtyFunc(t *testing.T) {
+	closes := 0
+	app := New(NewFunc(func(state *State) {}), Config{OnClose: func(*App) { closes++ }})
+	dirty := true
+	confirm := &QuitConfirm{Dirty: func() bool { return dirty }}
+	app.ConfirmQuit(confirm)
+	if !confirm.Unsaved() {
+		t.Fatal("expected confirm.Unsaved()")
+	}
+
+	app.onClose()
+	if closes != 0 {
+		t.Fatalf("expected '%v', got '%v'", 0, closes)
+	}
+	if !confirm.resolve(app, false) {
+		t.Fatal("expected confirm.resolve(app, false)")
+	}
+	app.onClose()
+	if closes != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, closes)
+	}
+
+	app.ConfirmQuit(nil)
+	app.onClose()
+	if closes != 2 {
+		t.Fatalf("expected '%v', got '%v'", 2, closes)
+	}
+}
@@ -0,0 +1,278 @@
+package dfx
+
+import (
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// RangeSliderParams configures RangeSlider.
+type RangeSliderParams struct {
+	// Taper curve (affects UI feel, not values)
+	// nil = linear taper
+	Taper Taper
+
+	// MinGap is the smallest allowed distance between the low and high values, in value units
+	MinGap float32
+
+	// Dimensions (0 = item width, frame height)
+	Width  float32
+	Height float32
+
+	// Display options
+	Format      func(value float32) string // optional: custom tooltip format (default: 3 decimals in CurrentFormat())
+	ShowTooltip bool                       // show the range on hover (default true)
+
+	// Mouse wheel sensitivity
+	WheelSteps float32 // default 100.0 (finer = more steps)
+
+	// FillColor is drawn between the handles (zero value uses the theme's Accent)
+	FillColor imgui.Vec4
+}
+
+// DefaultRangeSliderParams returns sensible default parameters.
+func DefaultRangeSliderParams() RangeSliderParams {
+	return RangeSliderParams{
+		Taper:       LinearTaper(),
+		ShowTooltip: true,
+		WheelSteps:  100.0,
+	}
+}
+
+// range slider parts that can be dragged
+const (
+	rangeHandleNone = iota
+	rangeHandleLo
+	rangeHandleHi
+	rangeHandleBand // the fill between the handles; moves both
+)
+
+// rangeDrag holds the drag state of the RangeSlider being dragged, if any.
+var rangeDrag struct {
+	id     imgui.ID
+	handle int
+	grab   float32 // UI position where a band drag started
+	lo, hi float32 // UI positions of the handles when a band drag started
+}
+
+// RangeSlider draws a horizontal slider with two handles selecting the range lo..hi
+// within min..max. drag a handle to move it, or drag the fill between the handles to
+// move both. the mouse wheel moves the handle nearest the pointer (Ctrl = 10x faster,
+// Alt = 10x slower). the handles are kept at least params.MinGap apart.
+// returns (newLo, newHi, changed) following dfx conventions.
+func RangeSlider(label string, lo, hi, min, max float32, params RangeSliderParams) (float32, float32, bool) {
+	if params.Taper == nil {
+		params.Taper = LinearTaper()
+	}
+	if params.WheelSteps == 0 {
+		params.WheelSteps = 100.0
+	}
+	style := imgui.CurrentStyle()
+	size := imgui.Vec2{X: params.Width, Y: params.Height}
+	if size.X <= 0 {
+		size.X = imgui.CalcItemWidth()
+	}
+	if size.Y <= 0 {
+		size.Y = imgui.FrameHeight()
+	}
+	grab := style.GrabMinSize()
+	track := size.X - grab // handles are centered on the track, inside the frame
+
+	lo, hi = constrainRange(lo, hi, min, max, params.MinGap, rangeHandleNone)
+	newLo, newHi := lo, hi
+
+	id := imgui.IDStr(label)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButton(label, size)
+	hovered := imgui.IsItemHovered()
+	active := imgui.IsItemActive()
+	io := imgui.CurrentIO()
+
+	loUI := rangeToUI(lo, min, max, params.Taper)
+	hiUI := rangeToUI(hi, min, max, params.Taper)
+	mouseUI := float32(0.5)
+	if track > 0 {
+		mouseUI = clamp((imgui.MousePos().X-pos.X-grab/2)/track, 0, 1)
+	}
+
+	if imgui.IsItemActivated() {
+		rangeDrag.id = id
+		rangeDrag.handle = nearestRangeHandle(mouseUI, loUI, hiUI, grab/2/max32(track, 1))
+		rangeDrag.grab = mouseUI
+		rangeDrag.lo, rangeDrag.hi = loUI, hiUI
+	}
+	if active && rangeDrag.id == id {
+		switch rangeDrag.handle {
+		case rangeHandleLo:
+			newLo = rangeFromUI(mouseUI, min, max, params.Taper)
+		case rangeHandleHi:
+			newHi = rangeFromUI(mouseUI, min, max, params.Taper)
+		case rangeHandleBand:
+			// move both handles, keeping their distance in UI space
+			delta := clamp(mouseUI-rangeDrag.grab, -rangeDrag.lo, 1-rangeDrag.hi)
+			newLo = rangeFromUI(rangeDrag.lo+delta, min, max, params.Taper)
+			newHi = rangeFromUI(rangeDrag.hi+delta, min, max, params.Taper)
+		}
+		newLo, newHi = constrainRange(newLo, newHi, min, max, params.MinGap, rangeDrag.handle)
+	}
+	if imgui.IsItemDeactivated() && rangeDrag.id == id {
+		rangeDrag.id = 0
+	}
+
+	// Handle mouse wheel
+	if hovered && !active {
+		if wheel := io.MouseWheel(); wheel != 0 {
+			fraction := float32(1.0 / params.WheelSteps)
+			if io.KeyCtrl() {
+				fraction *= wheelMultiplierFast
+			} else if io.KeyAlt() {
+				fraction /= wheelMultiplierSlow
+			}
+			handle := rangeHandleLo
+			if abs32(mouseUI-hiUI) < abs32(mouseUI-loUI) || (loUI == hiUI && mouseUI > hiUI) {
+				handle = rangeHandleHi
+			}
+			if handle == rangeHandleLo {
+				newLo = rangeFromUI(clamp(loUI+wheel*fraction, 0, 1), min, max, params.Taper)
+			} else {
+				newHi = rangeFromUI(clamp(hiUI+wheel*fraction, 0, 1), min, max, params.Taper)
+			}
+			newLo, newHi = constrainRange(newLo, newHi, min, max, params.MinGap, handle)
+		}
+	}
+
+	// track, fill and handles
+	colors := style.Colors()
+	frame := colors[imgui.ColFrameBg]
+	switch {
+	case active:
+		frame = colors[imgui.ColFrameBgActive]
+	case hovered:
+		frame = colors[imgui.ColFrameBgHovered]
+	}
+	dl := imgui.WindowDrawList()
+	dl.AddRectFilledV(pos, pos.Add(size), imgui.ColorConvertFloat4ToU32(frame), style.FrameRounding(), imgui.DrawFlagsNone)
+
+	loX := pos.X + grab/2 + rangeToUI(newLo, min, max, params.Taper)*track
+	hiX := pos.X + grab/2 + rangeToUI(newHi, min, max, params.Taper)*track
+	fill := themeColor(params.FillColor, ThemeColors().Accent)
+	fill.W *= 0.6
+	dl.AddRectFilled(imgui.Vec2{X: loX, Y: pos.Y + 2}, imgui.Vec2{X: hiX, Y: pos.Y + size.Y - 2}, imgui.ColorConvertFloat4ToU32(fill))
+
+	for _, h := range []struct {
+		x      float32
+		handle int
+	}{{loX, rangeHandleLo}, {hiX, rangeHandleHi}} {
+		color := colors[imgui.ColSliderGrab]
+		if active && rangeDrag.id == id && (rangeDrag.handle == h.handle || rangeDrag.handle == rangeHandleBand) {
+			color = colors[imgui.ColSliderGrabActive]
+		}
+		dl.AddRectFilledV(imgui.Vec2{X: h.x - grab/2, Y: pos.Y + 2}, imgui.Vec2{X: h.x + grab/2, Y: pos.Y + size.Y - 2},
+			imgui.ColorConvertFloat4ToU32(color), style.GrabRounding(), imgui.DrawFlagsNone)
+	}
+
+	// Show tooltip
+	if params.ShowTooltip && (hovered || active) {
+		format := params.Format
+		if format == nil {
+			format = func(v float32) string { return CurrentFormat().FormatFloat(float64(v), 3) }
+		}
+		imgui.SetTooltip(format(newLo) + " - " + format(newHi))
+	}
+
+	if text := visibleLabel(label); text != "" {
+		imgui.SameLineV(0, style.ItemInnerSpacing().X)
+		imgui.AlignTextToFramePadding()
+		imgui.TextUnformatted(text)
+	}
+
+	return newLo, newHi, newLo != lo || newHi != hi
+}
+
+// RangeSliderI is RangeSlider for integer ranges; values are rounded.
+func RangeSliderI(label string, lo, hi, min, max int, params RangeSliderParams) (int, int, bool) {
+	newLo, newHi, _ := RangeSlider(label, float32(lo), float32(hi), float32(min), float32(max), params)
+	rlo, rhi := int(math.Round(float64(newLo))), int(math.Round(float64(newHi)))
+	return rlo, rhi, rlo != lo || rhi != hi
+}
+
+// rangeToUI maps value within min..max to a tapered 0-1 UI position.
+func rangeToUI(value, min, max float32, taper Taper) float32 {
+	if max <= min {
+		return 0
+	}
+	return taper.Apply(clamp((value-min)/(max-min), 0, 1))
+}
+
+// rangeFromUI maps a tapered 0-1 UI position back to a value within min..max.
+func rangeFromUI(pos, min, max float32, taper Taper) float32 {
+	return taper.Invert(clamp(pos, 0, 1))*(max-min) + min
+}
+
+// nearestRangeHandle returns the part of a range slider at UI position pos. radius is
+// half a handle's width in UI units; the band is only grabbed between the handles.
+func nearestRangeHandle(pos, lo, hi, radius float32) int {
+	if pos > lo+radius && pos < hi-radius {
+		return rangeHandleBand
+	}
+	if lo == hi {
+		if pos > hi {
+			return rangeHandleHi
+		}
+		return rangeHandleLo
+	}
+	if abs32(pos-hi) < abs32(pos-lo) {
+		return rangeHandleHi
+	}
+	return rangeHandleLo
+}
+
+// constrainRange clamps lo and hi to min..max and keeps them at least gap apart. the
+// handle that moved gives way; when neither moved, lo is kept and hi is pushed.
+func constrainRange(lo, hi, min, max, gap float32, moved int) (float32, float32) {
+	gap = clamp(gap, 0, max-min)
+	lo = clamp(lo, min, max)
+	hi = clamp(hi, min, max)
+	if moved == rangeHandleNone && lo > hi {
+		lo, hi = hi, lo
+	}
+	if hi-lo >= gap {
+		return lo, hi
+	}
+	if moved == rangeHandleHi {
+		hi = lo + gap
+		if hi > max {
+			hi = max
+			lo = max - gap
+		}
+		return lo, hi
+	}
+	if moved == rangeHandleLo {
+		lo = hi - gap
+		if lo < min {
+			lo = min
+			hi = min + gap
+		}
+		return lo, hi
+	}
+	hi = lo + gap
+	if hi > max {
+		hi = max
+		lo = max - gap
+	}
+	return lo, hi
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,73 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestConstrainRange(t *testing.T) {
+	tests := []struct {
+		lo, hi   float32
+		gap      float32
+		moved    int
+		expectLo float32
+		expectHi float32
+	}{
+		{2, 8, 0, rangeHandleNone, 2, 8},
+		{-5, 15, 0, rangeHandleNone, 0, 10},
+		{8, 2, 0, rangeHandleNone, 2, 8},    // swapped when neither handle moved
+		{4, 5, 2, rangeHandleLo, 3, 5},      // moving lo stops short of hi
+		{4, 5, 2, rangeHandleHi, 4, 6},      // moving hi stops short of lo
+		{0.5, 1, 2, rangeHandleLo, 0, 2},    // gap pushes hi at the bottom of the range
+		{9, 9.5, 2, rangeHandleHi, 8, 10},   // gap pushes lo at the top of the range
+		{4, 5, 2, rangeHandleBand, 4, 6},    // band drags keep lo
+		{0, 10, 20, rangeHandleNone, 0, 10}, // gap limited to the range
+	}
+	for _, tt := range tests {
+		lo, hi := constrainRange(tt.lo, tt.hi, 0, 10, tt.gap, tt.moved)
+		if lo != tt.expectLo || hi != tt.expectHi {
+			t.Fatalf("constrainRange(%v, %v, gap %v, moved %v): expected %v-%v, got %v-%v",
+				tt.lo, tt.hi, tt.gap, tt.moved, tt.expectLo, tt.expectHi, lo, hi)
+		}
+	}
+}
+
+func TestRangeUIMapping(t *testing.T) {
+	taper := LogTaper(3)
+	for _, v := range []float32{20, 100, 1000, 20000} {
+		pos := rangeToUI(v, 20, 20000, taper)
+		if pos < 0 || pos > 1 {
+			t.Fatalf("expected position in 0-1, got %v", pos)
+		}
+		if back := rangeFromUI(pos, 20, 20000, taper); abs32(back-v) > v*0.001 {
+			t.Fatalf("expected %v, got %v", v, back)
+		}
+	}
+	if rangeToUI(5, 0, 10, LinearTaper()) != 0.5 {
+		t.Fatalf("expected linear mapping")
+	}
+	if rangeToUI(5, 10, 10, LinearTaper()) != 0 {
+		t.Fatalf("expected empty range to map to 0")
+	}
+}
+
+func TestNearestRangeHandle(t *testing.T) {
+	if h := nearestRangeHandle(0.1, 0.2, 0.8, 0.02); h != rangeHandleLo {
+		t.Fatalf("expected lo, got %v", h)
+	}
+	if h := nearestRangeHandle(0.9, 0.2, 0.8, 0.02); h != rangeHandleHi {
+		t.Fatalf("expected hi, got %v", h)
+	}
+	if h := nearestRangeHandle(0.5, 0.2, 0.8, 0.02); h != rangeHandleBand {
+		t.Fatalf("expected band, got %v", h)
+	}
+	if h := nearestRangeHandle(0.21, 0.2, 0.8, 0.02); h != rangeHandleLo {
+		t.Fatalf("expected lo within its handle, got %v", h)
+	}
+	// coincident handles split by side
+	if h := nearestRangeHandle(0.6, 0.5, 0.5, 0.02); h != rangeHandleHi {
+		t.Fatalf("expected hi, got %v", h)
+	}
+	if h := nearestRangeHandle(0.4, 0.5, 0.5, 0.02); h != rangeHandleLo {
+		t.Fatalf("expected lo, got %v", h)
+	}
+}
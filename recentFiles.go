@@ -0,0 +1,316 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// DefaultRecentFilesMax is how many unpinned files RecentFiles remembers by default.
+const DefaultRecentFilesMax = 10
+
+// RecentFile is an entry in a recent files list.
+type RecentFile struct {
+	Path   string
+	Opened time.Time
+	Pinned bool // pinned files are listed first, and are never dropped or cleared
+}
+
+// RecentFilesConfig is the persisted state of a RecentFiles list. embed it in an app
+// config to save it with that, or give RecentFiles a Path to save it to its own file.
+type RecentFilesConfig struct {
+	Files []RecentFile // most recently opened first
+}
+
+// RecentFiles is a most-recently-used file list, shown as an Open Recent submenu with
+// DrawMenu, or on a start page with a RecentFilesView.
+//
+// call RecordOpen whenever the app opens a file. when Path is set, each change is saved
+// there with SaveConfig, or marked dirty in Manager when set.
+type RecentFiles struct {
+	Config  *RecentFilesConfig
+	Max     int               // unpinned files remembered (0 = DefaultRecentFilesMax)
+	Path    string            // optional config file path saved on change
+	Manager *ConfigManager    // optional; when set with Path, changes mark the config dirty instead of saving directly
+	OnOpen  func(path string) // called when a file is chosen from the list
+	OnError func(error)       // called when saving the list fails
+
+	exists func(path string) bool // replaces the file system check in tests
+}
+
+// NewRecentFiles creates a recent files list kept in config, or in a new config when nil.
+func NewRecentFiles(config *RecentFilesConfig) *RecentFiles {
+	if config == nil {
+		config = &RecentFilesConfig{}
+	}
+	return &RecentFiles{Config: config}
+}
+
+// LoadRecentFiles creates a recent files list saved at path, loading it when the file
+// exists and pruning the files that no longer do.
+func LoadRecentFiles(path string) (*RecentFiles, error) {
+	rf := NewRecentFiles(nil)
+	rf.Path = path
+	if err := LoadConfig(path, rf.Config); err != nil {
+		return rf, err
+	}
+	rf.Prune()
+	return rf, nil
+}
+
+// RecordOpen moves path to the top of the list, adding it when new, and drops the oldest
+// unpinned files beyond Max.
+func (rf *RecentFiles) RecordOpen(path string) {
+	path = recentFilePath(path)
+	entry := RecentFile{Path: path}
+	if i := rf.index(path); i >= 0 {
+		entry = rf.Config.Files[i]
+		rf.Config.Files = slices.Delete(rf.Config.Files, i, i+1)
+	}
+	entry.Opened = time.Now()
+	rf.Config.Files = slices.Insert(rf.Config.Files, 0, entry)
+	rf.trim()
+	rf.save()
+}
+
+// Remove drops path from the list.
+func (rf *RecentFiles) Remove(path string) {
+	if i := rf.index(recentFilePath(path)); i >= 0 {
+		rf.Config.Files = slices.Delete(rf.Config.Files, i, i+1)
+		rf.save()
+	}
+}
+
+// Pin pins or unpins path. unpinning can drop the oldest files beyond Max.
+func (rf *RecentFiles) Pin(path string, pinned bool) {
+	i := rf.index(recentFilePath(path))
+	if i < 0 || rf.Config.Files[i].Pinned == pinned {
+		return
+	}
+	rf.Config.Files[i].Pinned = pinned
+	rf.trim()
+	rf.save()
+}
+
+// Clear drops every file that is not pinned.
+func (rf *RecentFiles) Clear() {
+	n := len(rf.Config.Files)
+	rf.Config.Files = slices.DeleteFunc(rf.Config.Files, func(f RecentFile) bool { return !f.Pinned })
+	if len(rf.Config.Files) != n {
+		rf.save()
+	}
+}
+
+// Prune drops the files that no longer exist, pinned or not. returns how many were dropped.
+func (rf *RecentFiles) Prune() int {
+	n := len(rf.Config.Files)
+	rf.Config.Files = slices.DeleteFunc(rf.Config.Files, func(f RecentFile) bool { return !rf.fileExists(f.Path) })
+	pruned := n - len(rf.Config.Files)
+	if pruned > 0 {
+		rf.save()
+	}
+	return pruned
+}
+
+// Files returns the list as shown: pinned files first, then the rest, each most recently
+// opened first.
+func (rf *RecentFiles) Files() []RecentFile {
+	files := slices.Clone(rf.Config.Files)
+	slices.SortStableFunc(files, func(a, b RecentFile) int {
+		switch {
+		case a.Pinned && !b.Pinned:
+			return -1
+		case !a.Pinned && b.Pinned:
+			return 1
+		}
+		return 0
+	})
+	return files
+}
+
+// Open calls OnOpen for path and moves it to the top of the list, or drops it from the
+// list when the file no longer exists.
+func (rf *RecentFiles) Open(path string) {
+	if !rf.fileExists(path) {
+		rf.Remove(path)
+		return
+	}
+	rf.RecordOpen(path)
+	if rf.OnOpen != nil {
+		rf.OnOpen(path)
+	}
+}
+
+// DrawMenu draws the list as a submenu of the current menu, titled label (default "Open
+// Recent"), disabled when the list is empty. choosing a file opens it with Open.
+//
+//	if imgui.BeginMenu("File") {
+//		recent.DrawMenu("")
+//		imgui.EndMenu()
+//	}
+func (rf *RecentFiles) DrawMenu(label string) {
+	if label == "" {
+		label = T("dfx.recent.menu")
+	}
+	files := rf.Files()
+	if !imgui.BeginMenuV(label, len(files) > 0) {
+		return
+	}
+	open := ""
+	for i, f := range files {
+		if i > 0 && files[i-1].Pinned && !f.Pinned {
+			imgui.Separator()
+		}
+		text := filepath.Base(f.Path)
+		if f.Pinned {
+			text = fonts.ICON_PUSH_PIN + " " + text
+		}
+		if imgui.MenuItemBool(text + "##" + f.Path) {
+			open = f.Path
+		}
+		imgui.SetItemTooltip(f.Path)
+	}
+	imgui.Separator()
+	if imgui.MenuItemBool(T("dfx.recent.clear")) {
+		rf.Clear()
+	}
+	imgui.EndMenu()
+
+	if open != "" {
+		rf.Open(open)
+	}
+}
+
+// index returns the position of path in the list, or -1.
+func (rf *RecentFiles) index(path string) int {
+	return slices.IndexFunc(rf.Config.Files, func(f RecentFile) bool { return f.Path == path })
+}
+
+// trim drops the oldest unpinned files beyond Max.
+func (rf *RecentFiles) trim() {
+	limit := rf.Max
+	if limit <= 0 {
+		limit = DefaultRecentFilesMax
+	}
+	kept := 0
+	rf.Config.Files = slices.DeleteFunc(rf.Config.Files, func(f RecentFile) bool {
+		if f.Pinned {
+			return false
+		}
+		kept++
+		return kept > limit
+	})
+}
+
+// save persists the list when it has a Path.
+func (rf *RecentFiles) save() {
+	if rf.Path == "" {
+		return
+	}
+	if rf.Manager != nil {
+		rf.Manager.MarkDirty(rf.Path)
+		return
+	}
+	if err := SaveConfig(rf.Path, rf.Config); err != nil && rf.OnError != nil {
+		rf.OnError(err)
+	}
+}
+
+// recentFilePath returns path made absolute and clean, so one file has one entry.
+func recentFilePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// fileExists reports whether the file at path still exists.
+func (rf *RecentFiles) fileExists(path string) bool {
+	if rf.exists != nil {
+		return rf.exists(path)
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// RecentFilesView is a start page list of recent files: click a file to open it, and use
+// the buttons beside it to pin or remove it.
+type RecentFilesView struct {
+	Container
+	Recent *RecentFiles
+}
+
+// NewRecentFilesView creates a view of recent.
+func NewRecentFilesView(recent *RecentFiles) *RecentFilesView {
+	return &RecentFilesView{Container: Container{Visible: true}, Recent: recent}
+}
+
+// Draw implements Component.
+func (v *RecentFilesView) Draw(state *State) {
+	if !v.Visible {
+		return
+	}
+	imgui.PushIDStr(componentID("recentFilesView", v))
+	defer imgui.PopID()
+
+	files := v.Recent.Files()
+	if len(files) == 0 {
+		imgui.TextDisabled(T("dfx.recent.none"))
+	}
+
+	var open, remove string
+	pin := -1
+	buttons := IconButtonWidth(fonts.ICON_PUSH_PIN, "") + IconButtonWidth(fonts.ICON_CLOSE, "") + imgui.CurrentStyle().ItemSpacing().X
+	for i, f := range files {
+		imgui.PushIDStr(f.Path)
+		width := imgui.ContentRegionAvail().X - buttons - imgui.CurrentStyle().ItemSpacing().X
+		if imgui.SelectableBoolV(filepath.Base(f.Path), false, imgui.SelectableFlagsNone, imgui.Vec2{X: max(width, 1)}) {
+			open = f.Path
+		}
+		imgui.SetItemTooltip(f.Path)
+		drawRecentFileDir(filepath.Base(f.Path), filepath.Dir(f.Path))
+
+		imgui.SameLine()
+		pinned := f.Pinned
+		pinTooltip := T("dfx.recent.pin")
+		if pinned {
+			pinTooltip = T("dfx.recent.unpin")
+		}
+		if IconToggle(fonts.ICON_PUSH_PIN, "", pinTooltip, &pinned) {
+			pin = i
+		}
+		imgui.SameLine()
+		if IconButton(fonts.ICON_CLOSE, "", T("dfx.recent.remove")) {
+			remove = f.Path
+		}
+		imgui.PopID()
+	}
+
+	switch {
+	case open != "":
+		v.Recent.Open(open)
+	case remove != "":
+		v.Recent.Remove(remove)
+	case pin >= 0:
+		v.Recent.Pin(files[pin].Path, !files[pin].Pinned)
+	}
+
+	drawContainerExtensions(&v.Container, state)
+}
+
+// drawRecentFileDir draws dir dimmed after name in the selectable just drawn, clipped to it.
+func drawRecentFileDir(name, dir string) {
+	min, max := imgui.ItemRectMin(), imgui.ItemRectMax()
+	x := min.X + imgui.CalcTextSize(name).X + imgui.CurrentStyle().ItemSpacing().X
+	if x >= max.X {
+		return
+	}
+	dl := imgui.WindowDrawList()
+	dl.PushClipRect(min, max)
+	dl.AddTextVec2(imgui.Vec2{X: x, Y: min.Y}, imgui.ColorU32Col(imgui.ColTextDisabled), dir)
+	dl.PopClipRect()
+}
@@ -0,0 +1,95 @@
+package dfx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func recentPaths(files []RecentFile) []string {
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, filepath.Base(f.Path))
+	}
+	return paths
+}
+
+func TestRecentFiles_RecordOpen(t *testing.T) {
+	rf := NewRecentFiles(nil)
+	rf.Max = 3
+	for _, name := range []string{"a", "b", "c", "a", "d"} {
+		rf.RecordOpen(name)
+	}
+	// reopening moves to the top; the oldest beyond Max are dropped
+	if paths := recentPaths(rf.Files()); !reflect.DeepEqual(paths, []string{"d", "a", "c"}) {
+		t.Fatalf("expected 'd a c', got '%v'", paths)
+	}
+	if !filepath.IsAbs(rf.Config.Files[0].Path) {
+		t.Fatalf("expected absolute paths, got '%v'", rf.Config.Files[0].Path)
+	}
+
+	// pinned files are listed first, and are not counted against Max or cleared
+	rf.Pin("c", true)
+	rf.RecordOpen("e")
+	rf.RecordOpen("f")
+	if paths := recentPaths(rf.Files()); !reflect.DeepEqual(paths, []string{"c", "f", "e", "d"}) {
+		t.Fatalf("expected 'c f e d', got '%v'", paths)
+	}
+	rf.Clear()
+	if paths := recentPaths(rf.Files()); !reflect.DeepEqual(paths, []string{"c"}) {
+		t.Fatalf("expected pinned 'c' kept, got '%v'", paths)
+	}
+}
+
+func TestRecentFiles_PruneAndOpen(t *testing.T) {
+	rf := NewRecentFiles(nil)
+	missing := map[string]bool{}
+	rf.exists = func(path string) bool { return !missing[filepath.Base(path)] }
+	var opened []string
+	rf.OnOpen = func(path string) { opened = append(opened, filepath.Base(path)) }
+
+	for _, name := range []string{"a", "b", "c"} {
+		rf.RecordOpen(name)
+	}
+	missing["b"] = true
+	rf.Open(rf.Config.Files[1].Path) // b
+	if len(opened) != 0 || len(rf.Config.Files) != 2 {
+		t.Fatalf("expected missing file dropped rather than opened, got '%v' '%v'", opened, recentPaths(rf.Files()))
+	}
+	rf.Open(rf.Config.Files[1].Path) // a
+	if !reflect.DeepEqual(opened, []string{"a"}) || filepath.Base(rf.Config.Files[0].Path) != "a" {
+		t.Fatalf("expected 'a' opened and moved to the top, got '%v' '%v'", opened, recentPaths(rf.Files()))
+	}
+
+	missing["c"] = true
+	if pruned := rf.Prune(); pruned != 1 {
+		t.Fatalf("expected 1 pruned, got %d", pruned)
+	}
+}
+
+func TestLoadRecentFiles(t *testing.T) {
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.txt")
+	if err := os.WriteFile(kept, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "recent.json")
+
+	rf, err := LoadRecentFiles(path)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	rf.RecordOpen(filepath.Join(dir, "gone.txt"))
+	rf.RecordOpen(kept)
+	rf.Pin(kept, true)
+
+	loaded, err := LoadRecentFiles(path)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	files := loaded.Files()
+	if len(files) != 1 || files[0].Path != kept || !files[0].Pinned {
+		t.Fatalf("expected pinned '%v' loaded and missing file pruned, got '%+v'", kept, files)
+	}
+}
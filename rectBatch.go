@@ -0,0 +1,108 @@
+package dfx
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// rectBatchChunk is the most rectangles emitted in one reservation, keeping each well
+// inside the vertex range 16-bit indices address.
+const rectBatchChunk = 4096
+
+// rectBatch collects filled rectangles to add to a draw list together. adding them reserves
+// the vertices and indices once and writes them directly, instead of calling into imgui
+// for each rectangle, which dominates the cost of components drawing hundreds of small
+// rectangles, such as meters. the rectangles are kept until reset, so they can be drawn
+// again in later frames.
+type rectBatch struct {
+	rects []batchRect
+}
+
+type batchRect struct {
+	min, max imgui.Vec2
+	col      uint32
+}
+
+// reset empties the batch, keeping its storage.
+func (b *rectBatch) reset() {
+	b.rects = b.rects[:0]
+}
+
+// add adds a rectangle from min to max, relative to the origin the batch is drawn at.
+func (b *rectBatch) add(min, max imgui.Vec2, col uint32) {
+	b.rects = append(b.rects, batchRect{min: min, max: max, col: col})
+}
+
+// len returns the number of rectangles in the batch.
+func (b *rectBatch) len() int {
+	return len(b.rects)
+}
+
+// draw adds the rectangles to dl, offset by origin, filled like AddRectFilled without
+// rounding.
+func (b *rectBatch) draw(dl *imgui.DrawList, origin imgui.Vec2) {
+	if len(b.rects) == 0 {
+		return
+	}
+	uv := dl.Data().TexUvWhitePixel()
+	layout := drawLayout()
+	vtxSize, posOffset, uvOffset, colOffset, idxSize := layout.vtxSize, layout.posOffset, layout.uvOffset, layout.colOffset, layout.idxSize
+
+	for start := 0; start < len(b.rects); start += rectBatchChunk {
+		rects := b.rects[start:min(start+rectBatchChunk, len(b.rects))]
+		n := len(rects)
+		dl.PrimReserve(int32(n*6), int32(n*4))
+		base := dl.VtxCurrentIdx()
+		vtxPtr := unsafe.Pointer(dl.VtxWritePtr().CData)
+		idxPtr := unsafe.Pointer(dl.IdxWritePtr())
+		vtx := unsafe.Slice((*byte)(vtxPtr), n*4*vtxSize)
+		idx := unsafe.Slice((*byte)(idxPtr), n*6*idxSize)
+
+		for i, r := range rects {
+			x0, y0 := origin.X+r.min.X, origin.Y+r.min.Y
+			x1, y1 := origin.X+r.max.X, origin.Y+r.max.Y
+			corners := [4][2]float32{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+			for c, p := range corners {
+				v := vtx[(i*4+c)*vtxSize:]
+				*(*[2]float32)(unsafe.Pointer(&v[posOffset])) = p
+				*(*imgui.Vec2)(unsafe.Pointer(&v[uvOffset])) = uv
+				*(*uint32)(unsafe.Pointer(&v[colOffset])) = r.col
+			}
+			// two triangles, wound as PrimRect winds them
+			first := base + uint32(i*4)
+			for k, corner := range [6]uint32{0, 1, 2, 0, 2, 3} {
+				writeDrawIdx(idx[(i*6+k)*idxSize:], idxSize, first+corner)
+			}
+		}
+
+		// advance past what was written, as the Prim* functions do
+		dl.SetVtxWritePtr(imgui.NewDrawVertFromC(unsafe.Add(vtxPtr, len(vtx))))
+		dl.SetIdxWritePtr((*imgui.DrawIdx)(unsafe.Add(idxPtr, len(idx))))
+		dl.SetVtxCurrentIdx(base + uint32(n*4))
+	}
+}
+
+// drawBufferLayout is the layout of draw list vertices and indices, fixed when imgui is
+// built.
+type drawBufferLayout struct {
+	vtxSize, posOffset, uvOffset, colOffset, idxSize int
+}
+
+// drawLayout returns the draw buffer layout, asked of imgui once, as asking allocates.
+var drawLayout = sync.OnceValue(func() drawBufferLayout {
+	var l drawBufferLayout
+	l.vtxSize, l.posOffset, l.uvOffset, l.colOffset = imgui.VertexBufferLayout()
+	l.idxSize = imgui.IndexBufferLayout()
+	return l
+})
+
+// writeDrawIdx writes index i to b in the draw list's index size.
+func writeDrawIdx(b []byte, size int, i uint32) {
+	if size == 4 {
+		*(*uint32)(unsafe.Pointer(&b[0])) = i
+		return
+	}
+	*(*uint16)(unsafe.Pointer(&b[0])) = uint16(i)
+}
@@ -0,0 +1,426 @@
+// Package remote exposes selected application state over a WebSocket/JSON protocol, for
+// remote dashboards and test harnesses. values are registered with Bind (read-write) or
+// Watch (read-only); clients observe them and push changes, which are validated and
+// applied on the UI thread by Bridge.Update.
+//
+// the protocol is one JSON object per text message. the server sends
+//
+//	{"type":"snapshot","values":{"gain":0.5,"muted":false}}  on connect and on "get"
+//	{"type":"update","name":"gain","value":0.6}               when a value changes
+//	{"type":"error","name":"gain","error":"out of range"}     when a change is rejected
+//
+// and accepts
+//
+//	{"type":"set","name":"gain","value":0.6}
+//	{"type":"get"}
+package remote
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultInterval is how often Update checks the values for changes.
+const DefaultInterval = 50 * time.Millisecond
+
+// message is a protocol message in either direction.
+type message struct {
+	Type   string                     `json:"type"`
+	Name   string                     `json:"name,omitempty"`
+	Value  json.RawMessage            `json:"value,omitempty"`
+	Values map[string]json.RawMessage `json:"values,omitempty"`
+	Error  string                     `json:"error,omitempty"`
+}
+
+// binding is a registered value.
+type binding struct {
+	get func() any
+	set func(raw json.RawMessage) error // nil for watched values
+}
+
+// request is a client message waiting for Update.
+type request struct {
+	client *client
+	msg    message
+}
+
+// clientQueue is how many messages may wait to be written to a client. a client that
+// falls this far behind is disconnected, rather than holding up the UI thread.
+const clientQueue = 256
+
+// clientRequests is how many messages from a client may wait for Update. a client that
+// sends faster than the UI thread applies them is disconnected, rather than growing the
+// queue without limit.
+const clientRequests = 64
+
+// client is a connected websocket client. messages are queued by the UI thread and
+// written by the client's own goroutine.
+type client struct {
+	conn    *wsConn
+	out     chan []byte
+	closed  chan struct{}
+	once    sync.Once
+	pending int // requests waiting for Update, guarded by Bridge.mu
+}
+
+func newClient(conn *wsConn) *client {
+	return &client{conn: conn, out: make(chan []byte, clientQueue), closed: make(chan struct{})}
+}
+
+// close disconnects the client, once.
+func (c *client) close() {
+	c.once.Do(func() {
+		close(c.closed)
+		_ = c.conn.Close()
+	})
+}
+
+// Bridge serves registered values to websocket clients. registration, Update and Close
+// must be called from the UI thread; Handler may be served from any goroutine. Update only
+// queues messages, so a slow client never blocks the UI thread.
+type Bridge struct {
+	Interval time.Duration                  // minimum time between change checks (0 = DefaultInterval)
+	OnSet    func(name string)              // called from Update after a client changed a value
+	OnError  func(err error)                // called with connection errors, from any goroutine (nil = ignored)
+	Validate func(name string, v any) error // called before any client change is applied (nil = accept)
+
+	// Token, when set, must be given as the token query parameter of the upgrade request
+	// (ws://localhost:8765/?token=...).
+	Token string
+
+	// AllowedOrigins lists the origins browsers may connect from ("*" = any), such as
+	// "http://localhost:3000" for a dashboard. browsers send the Origin of the page making
+	// the request, so without this list no web page can reach the bridge; clients that
+	// send no Origin, such as test harnesses, are not affected.
+	AllowedOrigins []string
+
+	bindings map[string]*binding
+	last     map[string][]byte // encoded values last sent
+	checked  time.Time
+
+	mu       sync.Mutex
+	clients  map[*client]bool
+	requests []request
+	listener net.Listener
+}
+
+// NewBridge creates a bridge with no registered values.
+func NewBridge() *Bridge {
+	return &Bridge{
+		bindings: make(map[string]*binding),
+		last:     make(map[string][]byte),
+		clients:  make(map[*client]bool),
+	}
+}
+
+// Bind registers the value at ptr under name. clients may change it; validate, when not
+// nil, can reject a change by returning an error, which is reported to the client.
+func Bind[T any](b *Bridge, name string, ptr *T, validate func(v T) error) {
+	b.bindings[name] = &binding{
+		get: func() any { return *ptr },
+		set: func(raw json.RawMessage) error {
+			var v T
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return errors.Wrapf(err, "invalid value for '%v'", name)
+			}
+			if b.Validate != nil {
+				if err := b.Validate(name, v); err != nil {
+					return err
+				}
+			}
+			if validate != nil {
+				if err := validate(v); err != nil {
+					return err
+				}
+			}
+			*ptr = v
+			return nil
+		},
+	}
+	delete(b.last, name)
+}
+
+// Watch registers a read-only value under name, read with get on the UI thread.
+func Watch[T any](b *Bridge, name string, get func() T) {
+	b.bindings[name] = &binding{get: func() any { return get() }}
+	delete(b.last, name)
+}
+
+// Unregister removes the value registered under name.
+func (b *Bridge) Unregister(name string) {
+	delete(b.bindings, name)
+	delete(b.last, name)
+}
+
+// Names returns the registered names, sorted.
+func (b *Bridge) Names() []string {
+	names := make([]string, 0, len(b.bindings))
+	for name := range b.bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handler returns the http handler that upgrades requests to websocket connections, to
+// mount on an existing server. Listen serves it on its own.
+func (b *Bridge) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := b.authorize(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			b.error(err)
+			return
+		}
+		conn, err := wsUpgrade(w, r)
+		if err != nil {
+			b.error(err)
+			return
+		}
+		c := newClient(conn)
+		b.mu.Lock()
+		b.clients[c] = true
+		// the snapshot is built by Update, on the UI thread
+		b.requests = append(b.requests, request{client: c, msg: message{Type: "get"}})
+		c.pending++
+		b.mu.Unlock()
+		go b.read(c)
+		go b.write(c)
+	})
+}
+
+// authorize rejects upgrade requests from origins not allowed, or without the token.
+func (b *Bridge) authorize(r *http.Request) error {
+	if origin := r.Header.Get("Origin"); origin != "" && !slices.Contains(b.AllowedOrigins, "*") && !slices.Contains(b.AllowedOrigins, origin) {
+		return errors.Errorf("origin '%v' not allowed", origin)
+	}
+	if b.Token != "" && subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(b.Token)) != 1 {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// Listen serves the bridge on addr (e.g. "localhost:8765") until Close.
+func (b *Bridge) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on '%v'", addr)
+	}
+	b.mu.Lock()
+	b.listener = listener
+	b.mu.Unlock()
+	go func() {
+		if err := http.Serve(listener, b.Handler()); err != nil && !errors.Is(err, net.ErrClosed) {
+			b.error(errors.Wrap(err, "error serving remote bridge"))
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address Listen is serving on, or nil.
+func (b *Bridge) Addr() net.Addr {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.listener == nil {
+		return nil
+	}
+	return b.listener.Addr()
+}
+
+// Clients returns the number of connected clients.
+func (b *Bridge) Clients() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.clients)
+}
+
+// Close stops listening and disconnects every client.
+func (b *Bridge) Close() error {
+	b.mu.Lock()
+	listener := b.listener
+	b.listener = nil
+	clients := b.clients
+	b.clients = make(map[*client]bool)
+	b.requests = nil
+	b.mu.Unlock()
+
+	for c := range clients {
+		c.close()
+	}
+	if listener != nil {
+		return errors.Wrap(listener.Close(), "error closing remote bridge")
+	}
+	return nil
+}
+
+// Update applies the changes clients pushed since the last call, answers snapshot
+// requests and, at most once per Interval, sends values that changed to every client.
+// call it once per frame from the UI thread, e.g. from dfx.Config.OnTick.
+func (b *Bridge) Update() {
+	b.mu.Lock()
+	requests := b.requests
+	b.requests = nil
+	for _, req := range requests {
+		req.client.pending--
+	}
+	b.mu.Unlock()
+
+	changed := false
+	for _, req := range requests {
+		switch req.msg.Type {
+		case "get":
+			b.send(req.client, message{Type: "snapshot", Values: b.snapshot()})
+		case "set":
+			if err := b.set(req.msg.Name, req.msg.Value); err != nil {
+				b.send(req.client, message{Type: "error", Name: req.msg.Name, Error: err.Error()})
+				continue
+			}
+			changed = true
+			if b.OnSet != nil {
+				b.OnSet(req.msg.Name)
+			}
+		default:
+			b.send(req.client, message{Type: "error", Error: fmt.Sprintf("unknown message type '%v'", req.msg.Type)})
+		}
+	}
+
+	interval := b.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if !changed && time.Since(b.checked) < interval {
+		return
+	}
+	b.checked = time.Now()
+	for _, name := range b.Names() {
+		data, err := json.Marshal(b.bindings[name].get())
+		if err != nil {
+			continue
+		}
+		if last, found := b.last[name]; found && bytes.Equal(last, data) {
+			continue
+		}
+		b.last[name] = data
+		b.broadcast(message{Type: "update", Name: name, Value: data})
+	}
+}
+
+// set applies a client change to a bound value.
+func (b *Bridge) set(name string, raw json.RawMessage) error {
+	bound, found := b.bindings[name]
+	if !found {
+		return errors.Errorf("unknown value '%v'", name)
+	}
+	if bound.set == nil {
+		return errors.Errorf("value '%v' is read-only", name)
+	}
+	if len(raw) == 0 {
+		return errors.Errorf("missing value for '%v'", name)
+	}
+	return bound.set(raw)
+}
+
+// snapshot encodes every registered value.
+func (b *Bridge) snapshot() map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage, len(b.bindings))
+	for name, bound := range b.bindings {
+		data, err := json.Marshal(bound.get())
+		if err != nil {
+			continue
+		}
+		values[name] = data
+	}
+	return values
+}
+
+// read queues the messages of a client until it disconnects.
+func (b *Bridge) read(c *client) {
+	defer b.drop(c)
+	for {
+		data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			b.send(c, message{Type: "error", Error: "invalid message: " + err.Error()})
+			continue
+		}
+		b.mu.Lock()
+		flooding := c.pending >= clientRequests
+		if b.clients[c] && !flooding {
+			b.requests = append(b.requests, request{client: c, msg: msg})
+			c.pending++
+		}
+		b.mu.Unlock()
+		if flooding {
+			b.error(errors.New("remote client sending too fast; disconnecting"))
+			return
+		}
+	}
+}
+
+// write sends the messages queued for a client until it disconnects.
+func (b *Bridge) write(c *client) {
+	defer b.drop(c)
+	for {
+		select {
+		case data := <-c.out:
+			if err := c.conn.WriteText(data); err != nil {
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// send queues a message for a client without waiting, disconnecting a client whose queue
+// is full.
+func (b *Bridge) send(c *client, msg message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		b.error(errors.Wrap(err, "error encoding message"))
+		return
+	}
+	select {
+	case c.out <- data:
+	default:
+		b.error(errors.New("remote client too slow; disconnecting"))
+		b.drop(c)
+	}
+}
+
+func (b *Bridge) broadcast(msg message) {
+	b.mu.Lock()
+	clients := make([]*client, 0, len(b.clients))
+	for c := range b.clients {
+		clients = append(clients, c)
+	}
+	b.mu.Unlock()
+	for _, c := range clients {
+		b.send(c, msg)
+	}
+}
+
+func (b *Bridge) drop(c *client) {
+	b.mu.Lock()
+	delete(b.clients, c)
+	b.mu.Unlock()
+	c.close()
+}
+
+func (b *Bridge) error(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+}
@@ -0,0 +1,322 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// testClient is a minimal websocket client for exercising the bridge.
+type testClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dial(t *testing.T, server *httptest.Server) *testClient {
+	t.Helper()
+	c, resp := upgrade(t, server, "/", "")
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %v", resp.Status)
+	}
+	return c
+}
+
+// upgrade sends an upgrade request for target with the extra header lines.
+func upgrade(t *testing.T, server *httptest.Server, target, header string) (*testClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_, err = io.WriteString(conn, "GET "+target+" HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n"+header+"\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testClient{conn: conn, r: r}, resp
+}
+
+func (c *testClient) send(t *testing.T, v any) {
+	t.Helper()
+	payload, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testClient) receive(t *testing.T) message {
+	t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var header [2]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		t.Fatal(err)
+	}
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			t.Fatal(err)
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		t.Fatal(err)
+	}
+	var msg message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// pump calls Update once the reader goroutine has queued a request.
+func pump(t *testing.T, b *Bridge) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		b.mu.Lock()
+		pending := len(b.requests)
+		b.mu.Unlock()
+		if pending > 0 {
+			b.Update()
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("no request arrived")
+}
+
+func TestBridgeSnapshotAndSet(t *testing.T) {
+	b := NewBridge()
+	gain := 0.5
+	frames := 10
+	Bind(b, "gain", &gain, func(v float64) error {
+		if v < 0 || v > 1 {
+			return errors.New("out of range")
+		}
+		return nil
+	})
+	Watch(b, "frames", func() int { return frames })
+	var set []string
+	b.OnSet = func(name string) { set = append(set, name) }
+
+	server := httptest.NewServer(b.Handler())
+	defer server.Close()
+	defer b.Close()
+	c := dial(t, server)
+
+	pump(t, b)
+	snapshot := c.receive(t)
+	if snapshot.Type != "snapshot" || string(snapshot.Values["gain"]) != "0.5" || string(snapshot.Values["frames"]) != "10" {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	// every value is sent the first time it is checked
+	updates := map[string]string{}
+	for range 2 {
+		msg := c.receive(t)
+		updates[msg.Name] = string(msg.Value)
+	}
+	if updates["gain"] != "0.5" || updates["frames"] != "10" {
+		t.Fatalf("unexpected updates %v", updates)
+	}
+
+	c.send(t, map[string]any{"type": "set", "name": "gain", "value": 0.75})
+	pump(t, b)
+	if gain != 0.75 || len(set) != 1 || set[0] != "gain" {
+		t.Fatalf("expected gain 0.75 set once, got %v %v", gain, set)
+	}
+	if msg := c.receive(t); msg.Type != "update" || msg.Name != "gain" || string(msg.Value) != "0.75" {
+		t.Fatalf("unexpected update %+v", msg)
+	}
+
+	c.send(t, map[string]any{"type": "set", "name": "gain", "value": 2})
+	pump(t, b)
+	if msg := c.receive(t); msg.Type != "error" || msg.Error != "out of range" || gain != 0.75 {
+		t.Fatalf("expected rejected change, got %+v gain %v", msg, gain)
+	}
+
+	c.send(t, map[string]any{"type": "set", "name": "frames", "value": 3})
+	pump(t, b)
+	if msg := c.receive(t); msg.Type != "error" || !strings.Contains(msg.Error, "read-only") {
+		t.Fatalf("expected read-only error, got %+v", msg)
+	}
+
+	c.send(t, map[string]any{"type": "set", "name": "gain", "value": "loud"})
+	pump(t, b)
+	if msg := c.receive(t); msg.Type != "error" || gain != 0.75 {
+		t.Fatalf("expected decode error, got %+v gain %v", msg, gain)
+	}
+}
+
+func TestBridgeBroadcastsChanges(t *testing.T) {
+	b := NewBridge()
+	b.Interval = time.Nanosecond
+	count := 1
+	Watch(b, "count", func() int { return count })
+
+	server := httptest.NewServer(b.Handler())
+	defer server.Close()
+	defer b.Close()
+	c := dial(t, server)
+
+	pump(t, b)
+	if msg := c.receive(t); msg.Type != "snapshot" {
+		t.Fatalf("expected snapshot, got %+v", msg)
+	}
+	if msg := c.receive(t); msg.Type != "update" || string(msg.Value) != "1" {
+		t.Fatalf("expected first update, got %+v", msg)
+	}
+
+	// the unchanged value is not resent, so the next message is the change
+	time.Sleep(time.Millisecond)
+	b.Update()
+	count = 2
+	time.Sleep(time.Millisecond)
+	b.Update()
+	if msg := c.receive(t); msg.Name != "count" || string(msg.Value) != "2" {
+		t.Fatalf("expected count 2, got %+v", msg)
+	}
+}
+
+func TestBridgeValidate(t *testing.T) {
+	b := NewBridge()
+	name := "a"
+	Bind(b, "name", &name, nil)
+	b.Validate = func(name string, v any) error {
+		if v == "" {
+			return errors.New("empty")
+		}
+		return nil
+	}
+	if err := b.set("name", json.RawMessage(`""`)); err == nil || err.Error() != "empty" {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if err := b.set("name", json.RawMessage(`"b"`)); err != nil || name != "b" {
+		t.Fatalf("expected name b, got %v %v", name, err)
+	}
+	if err := b.set("missing", json.RawMessage(`1`)); err == nil {
+		t.Fatal("expected unknown value error")
+	}
+}
+
+func TestBridgeSlowClient(t *testing.T) {
+	b := NewBridge()
+	// a client that never reads: its writes block
+	server, peer := net.Pipe()
+	defer peer.Close()
+	c := newClient(&wsConn{conn: server})
+	b.clients[c] = true
+	go b.write(c)
+
+	start := time.Now()
+	for i := 0; i < clientQueue+10; i++ {
+		b.broadcast(message{Type: "update", Name: "n", Value: json.RawMessage("1")})
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected sending to return at once, took %v", elapsed)
+	}
+	if b.Clients() != 0 {
+		t.Fatalf("expected the stalled client to be disconnected, got %v clients", b.Clients())
+	}
+}
+
+func TestBridgeRequestFlood(t *testing.T) {
+	b := NewBridge()
+	var errs []error
+	var mu sync.Mutex
+	b.OnError = func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+	server := httptest.NewServer(b.Handler())
+	defer server.Close()
+	defer b.Close()
+	c := dial(t, server)
+
+	// Update is never called, so every request stays queued
+	for i := 0; i < clientRequests+10; i++ {
+		c.send(t, map[string]any{"type": "get"})
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for b.Clients() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if b.Clients() != 0 {
+		t.Fatalf("expected the flooding client to be disconnected, got %v clients", b.Clients())
+	}
+	b.mu.Lock()
+	pending := len(b.requests)
+	b.mu.Unlock()
+	if pending > clientRequests {
+		t.Fatalf("expected at most %v pending requests, got %v", clientRequests, pending)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "too fast") {
+		t.Fatalf("expected one flood error, got %v", errs)
+	}
+
+	// the requests already queued are still answered without trouble
+	b.Update()
+}
+
+func TestBridgeAuthorize(t *testing.T) {
+	b := NewBridge()
+	server := httptest.NewServer(b.Handler())
+	defer server.Close()
+	defer b.Close()
+
+	// pages in a browser may not connect unless their origin is allowed
+	if _, resp := upgrade(t, server, "/", "Origin: http://evil.example\r\n"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for a foreign origin, got %v", resp.Status)
+	}
+	b.AllowedOrigins = []string{"http://localhost:3000"}
+	if _, resp := upgrade(t, server, "/", "Origin: http://localhost:3000\r\n"); resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101 for an allowed origin, got %v", resp.Status)
+	}
+
+	b.Token = "secret"
+	if _, resp := upgrade(t, server, "/", ""); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 without the token, got %v", resp.Status)
+	}
+	if _, resp := upgrade(t, server, "/?token=wrong", ""); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 with a wrong token, got %v", resp.Status)
+	}
+	if _, resp := upgrade(t, server, "/?token=secret", ""); resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101 with the token, got %v", resp.Status)
+	}
+}
+
+func TestWebSocketAccept(t *testing.T) {
+	if accept := wsAccept("dGhlIHNhbXBsZSBub25jZQ=="); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("unexpected accept %v", accept)
+	}
+}
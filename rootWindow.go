@@ -0,0 +1,62 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// BackgroundFunc draws a backdrop into dl, filling the root window from min to max. it is
+// drawn after the root window's background and before the root component.
+type BackgroundFunc func(app *App, dl *imgui.DrawList, min, max imgui.Vec2)
+
+// GradientBackground returns a BackgroundFunc filling the root window with a vertical
+// gradient from top to bottom.
+func GradientBackground(top, bottom imgui.Vec4) BackgroundFunc {
+	return func(app *App, dl *imgui.DrawList, min, max imgui.Vec2) {
+		t, b := imgui.ColorConvertFloat4ToU32(top), imgui.ColorConvertFloat4ToU32(bottom)
+		dl.AddRectFilledMultiColor(min, max, t, t, b, b)
+	}
+}
+
+// ImageBackground returns a BackgroundFunc drawing texture across the root window, laid out
+// with scale.
+func ImageBackground(texture *Texture, scale ImageScale) BackgroundFunc {
+	return func(app *App, dl *imgui.DrawList, min, max imgui.Vec2) {
+		if texture == nil {
+			return
+		}
+		ref, ok := texture.TextureRef()
+		if !ok {
+			return
+		}
+		layout := layoutImage(texture.Size(), max.Sub(min), scale, imgui.Vec2{}, imgui.Vec2{})
+		pos := min.Add(layout.offset)
+		dl.AddImageV(ref, pos, pos.Add(layout.size), layout.uv0, layout.uv1, imgui.ColorConvertFloat4ToU32(imageTint(imgui.Vec4{})))
+	}
+}
+
+// pushRootStyle applies Config's root window padding, border and background for the next
+// window. returns the number of style vars and colors to pop once the window has begun.
+func (app *App) pushRootStyle() (vars, colors int) {
+	if app.config.RootPadding != nil {
+		imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, *app.config.RootPadding)
+		vars++
+	}
+	if app.config.RootBorder > 0 {
+		imgui.PushStyleVarFloat(imgui.StyleVarWindowBorderSize, app.config.RootBorder)
+		vars++
+	}
+	if app.config.RootBackground != (imgui.Vec4{}) {
+		imgui.PushStyleColorVec4(imgui.ColWindowBg, app.config.RootBackground)
+		colors++
+	}
+	return vars, colors
+}
+
+// drawRootBackground draws Config.OnDrawBackground across the current (root) window.
+func (app *App) drawRootBackground() {
+	if app.config.OnDrawBackground == nil {
+		return
+	}
+	min := imgui.WindowPos()
+	app.config.OnDrawBackground(app, imgui.WindowDrawList(), min, min.Add(imgui.WindowSize()))
+}
@@ -0,0 +1,64 @@
+package dfx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+
+	"github.com/AllenDang/cimgui-go/backend/glfwbackend"
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// DefaultScreenshotFrames is the number of frames drawn before a screenshot is taken, so
+// layouts and animations settle.
+const DefaultScreenshotFrames = 3
+
+// screenshotMode returns true when the app renders a screenshot and exits (Config.Screenshot).
+func (app *App) screenshotMode() bool {
+	return app.config.Screenshot != ""
+}
+
+// prepareScreenshot hides the window and hooks frame capture for screenshot mode. call it
+// before the window is created.
+func (app *App) prepareScreenshot() error {
+	if !app.screenshotMode() {
+		return nil
+	}
+	if app.config.CaptureFrame == nil {
+		return errors.New("screenshot mode needs Config.CaptureFrame to read the rendered frame")
+	}
+	app.backend.SetWindowFlags(glfwbackend.GLFWWindowFlagsVisible, 0)
+	app.backend.SetAfterRenderHook(app.afterScreenshotFrame)
+	return nil
+}
+
+// afterScreenshotFrame captures the frame once enough frames were drawn, after any replay
+// ended, saves it and stops the app. it runs after rendering, with the GL context current.
+func (app *App) afterScreenshotFrame() {
+	frames := app.config.ScreenshotFrames
+	if frames <= 0 {
+		frames = DefaultScreenshotFrames
+	}
+	if !app.running || app.replaying() || app.frameCount+1 < app.replayEnd+uint64(frames) {
+		return
+	}
+	io := imgui.CurrentIO()
+	size := io.DisplaySize()
+	scale := io.DisplayFramebufferScale()
+	img, err := app.config.CaptureFrame(int(size.X*scale.X), int(size.Y*scale.Y))
+	if err == nil {
+		err = writePNG(app.config.Screenshot, img)
+	}
+	app.screenshotErr = errors.Wrap(err, "error taking screenshot")
+	app.Stop()
+}
+
+// writePNG saves img to path as a PNG.
+func writePNG(path string, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return errors.Wrap(err, "error encoding png")
+	}
+	return writeFileAtomic(path, buf.Bytes(), 0644)
+}
@@ -0,0 +1,279 @@
+package dfx
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ScrollArea is a scrolling region around a content component, with programmatic control
+// of the scroll position: ScrollTo, ScrollToItem and ScrollToBottom move it, and the
+// getters report where it was at the end of the last frame. with StickToBottom it keeps
+// following the end of growing content (chat, logs) until the user scrolls up.
+//
+// content marks the items it may be asked to scroll to by calling Item as it draws them:
+//
+//	area := dfx.NewScrollArea(dfx.NewFunc(func(state *dfx.State) {
+//	    for _, msg := range messages {
+//	        area.Item(msg.Id)
+//	        imgui.TextWrapped(msg.Text)
+//	    }
+//	}))
+//
+// the scroll position is saved and restored as StatefulComponent state.
+type ScrollArea struct {
+	Container
+	Content       Component
+	Horizontal    bool               // allow horizontal scrolling
+	Border        bool               // draw a border around the area
+	StickToBottom bool               // follow the end of the content while scrolled to the bottom
+	OnScroll      func(x, y float32) // called when the scroll position changes
+	Kinetic       KineticScroller    // momentum after flicks, when kinetic scrolling is on (see SetKineticScrolling)
+
+	// scrollbar styling (zero = theme)
+	ScrollbarSize     float32
+	ScrollbarRounding float32
+	ScrollbarColor    imgui.Vec4 // track
+	GrabColor         imgui.Vec4
+	GrabHoveredColor  imgui.Vec4
+	GrabActiveColor   imgui.Vec4
+
+	scroll    imgui.Vec2 // position at the end of the last frame
+	maxScroll imgui.Vec2
+	viewSize  imgui.Vec2 // visible size at the end of the last frame
+	drawn     bool       // drawn at least once, so the content size is known
+
+	pendingX, pendingY float32 // requested positions (< 0 = none)
+	toBottom           bool
+	item               string  // requested item ("" = none)
+	itemAlign          float32 // where the item lands in the view (0 = top, 1 = bottom)
+}
+
+// NewScrollArea creates a scroll area around content.
+func NewScrollArea(content Component) *ScrollArea {
+	return &ScrollArea{
+		Container: Container{Visible: true},
+		Content:   content,
+		pendingX:  -1,
+		pendingY:  -1,
+	}
+}
+
+// ScrollTo scrolls to a vertical position in pixels from the top of the content.
+func (sa *ScrollArea) ScrollTo(y float32) {
+	sa.pendingY = max(y, 0)
+	sa.toBottom = false
+	sa.item = ""
+}
+
+// ScrollToX scrolls to a horizontal position in pixels from the left of the content.
+func (sa *ScrollArea) ScrollToX(x float32) {
+	sa.pendingX = max(x, 0)
+}
+
+// ScrollToBottom scrolls to the end of the content.
+func (sa *ScrollArea) ScrollToBottom() {
+	sa.toBottom = true
+	sa.pendingY = -1
+	sa.item = ""
+}
+
+// ScrollToItem scrolls the item the content marks with id (see Item) to the top of the
+// view, the next time it is drawn.
+func (sa *ScrollArea) ScrollToItem(id string) {
+	sa.ScrollToItemAt(id, 0)
+}
+
+// ScrollToItemAt scrolls the item marked with id to align within the view: 0 puts it at
+// the top, 0.5 in the middle and 1 at the bottom.
+func (sa *ScrollArea) ScrollToItemAt(id string, align float32) {
+	sa.item = id
+	sa.itemAlign = align
+	sa.pendingY = -1
+	sa.toBottom = false
+}
+
+// Item marks the position of an item at the cursor; call it from the content's Draw just
+// before drawing the item. an item requested with ScrollToItem is scrolled into place.
+func (sa *ScrollArea) Item(id string) {
+	if sa.item != "" && sa.item == id {
+		imgui.SetScrollHereYV(sa.itemAlign)
+		sa.item = ""
+	}
+}
+
+// ScrollX returns the horizontal scroll position.
+func (sa *ScrollArea) ScrollX() float32 {
+	return sa.scroll.X
+}
+
+// ScrollY returns the vertical scroll position.
+func (sa *ScrollArea) ScrollY() float32 {
+	return sa.scroll.Y
+}
+
+// MaxScrollX returns the largest horizontal scroll position.
+func (sa *ScrollArea) MaxScrollX() float32 {
+	return sa.maxScroll.X
+}
+
+// MaxScrollY returns the largest vertical scroll position.
+func (sa *ScrollArea) MaxScrollY() float32 {
+	return sa.maxScroll.Y
+}
+
+// AtBottom reports whether the area is scrolled to the end of its content.
+func (sa *ScrollArea) AtBottom() bool {
+	return atScrollEnd(sa.scroll.Y, sa.maxScroll.Y)
+}
+
+// Draw implements Component.
+func (sa *ScrollArea) Draw(state *State) {
+	if !sa.Visible {
+		return
+	}
+	var size imgui.Vec2
+	if state != nil {
+		size = state.Size
+	}
+
+	vars, colors := sa.pushScrollbarStyle()
+	childFlags := imgui.ChildFlagsNone
+	if sa.Border {
+		childFlags |= imgui.ChildFlagsBorders
+	}
+	windowFlags := imgui.WindowFlagsNone
+	if sa.Horizontal {
+		windowFlags |= imgui.WindowFlagsHorizontalScrollbar
+	}
+	if imgui.BeginChildStrV(fmt.Sprintf("##scrollArea_%p", sa), size, childFlags, windowFlags) {
+		following := sa.StickToBottom && atScrollEnd(imgui.ScrollY(), imgui.ScrollMaxY())
+		sa.applyPending()
+
+		if sa.Content != nil {
+			DrawChild(sa.Content, state.Child(imgui.ContentRegionAvail(), imgui.Vec2{}).WithParent(sa))
+		}
+
+		if sa.toBottom || (following && sa.pendingY < 0 && sa.item == "") {
+			imgui.SetScrollHereYV(1.0)
+			sa.toBottom = false
+		}
+		sa.Kinetic.Update()
+		sa.drawn = true
+		sa.viewSize = imgui.WindowSize()
+		sa.update(imgui.Vec2{X: imgui.ScrollX(), Y: imgui.ScrollY()}, imgui.Vec2{X: imgui.ScrollMaxX(), Y: imgui.ScrollMaxY()})
+	}
+	imgui.EndChild()
+	imgui.PopStyleColorV(colors)
+	imgui.PopStyleVarV(vars)
+
+	drawContainerExtensions(&sa.Container, state)
+}
+
+// applyPending applies requested scroll positions. positions wait for the first frame
+// to have measured the content, so restored positions are not clamped to nothing.
+func (sa *ScrollArea) applyPending() {
+	if !sa.drawn {
+		return
+	}
+	if sa.pendingY >= 0 {
+		imgui.SetScrollYFloat(sa.pendingY)
+		sa.pendingY = -1
+	}
+	if sa.pendingX >= 0 {
+		imgui.SetScrollXFloat(sa.pendingX)
+		sa.pendingX = -1
+	}
+}
+
+// update records the scroll position at the end of a frame and reports changes.
+func (sa *ScrollArea) update(scroll, maxScroll imgui.Vec2) {
+	changed := scroll != sa.scroll
+	sa.scroll = scroll
+	sa.maxScroll = maxScroll
+	if changed && sa.OnScroll != nil {
+		sa.OnScroll(scroll.X, scroll.Y)
+	}
+}
+
+// pushScrollbarStyle pushes the configured scrollbar style and returns the number of
+// style vars and colors pushed.
+func (sa *ScrollArea) pushScrollbarStyle() (int32, int32) {
+	var vars, colors int32
+	if sa.ScrollbarSize > 0 {
+		imgui.PushStyleVarFloat(imgui.StyleVarScrollbarSize, sa.ScrollbarSize)
+		vars++
+	}
+	if sa.ScrollbarRounding > 0 {
+		imgui.PushStyleVarFloat(imgui.StyleVarScrollbarRounding, sa.ScrollbarRounding)
+		vars++
+	}
+	for _, c := range []struct {
+		col   imgui.Col
+		color imgui.Vec4
+	}{
+		{imgui.ColScrollbarBg, sa.ScrollbarColor},
+		{imgui.ColScrollbarGrab, sa.GrabColor},
+		{imgui.ColScrollbarGrabHovered, sa.GrabHoveredColor},
+		{imgui.ColScrollbarGrabActive, sa.GrabActiveColor},
+	} {
+		if c.color != (imgui.Vec4{}) {
+			imgui.PushStyleColorVec4(c.col, c.color)
+			colors++
+		}
+	}
+	return vars, colors
+}
+
+// atScrollEnd reports whether a scroll position is at (or within a pixel of) its end.
+func atScrollEnd(scroll, maxScroll float32) bool {
+	return scroll >= maxScroll-1
+}
+
+// MinimapBounds implements MinimapSource: the content, in pixels.
+func (sa *ScrollArea) MinimapBounds() (imgui.Vec2, imgui.Vec2) {
+	return imgui.Vec2{}, sa.viewSize.Add(sa.maxScroll)
+}
+
+// MinimapViewport implements MinimapSource.
+func (sa *ScrollArea) MinimapViewport() (imgui.Vec2, imgui.Vec2) {
+	return sa.scroll, sa.scroll.Add(sa.viewSize)
+}
+
+// MinimapScrollTo implements MinimapSource.
+func (sa *ScrollArea) MinimapScrollTo(origin imgui.Vec2) {
+	sa.ScrollTo(clamp(origin.Y, 0, sa.maxScroll.Y))
+	sa.ScrollToX(clamp(origin.X, 0, sa.maxScroll.X))
+}
+
+// CaptureState implements StatefulComponent.
+func (sa *ScrollArea) CaptureState() map[string]any {
+	state := map[string]any{"scrollX": float64(sa.scroll.X), "scrollY": float64(sa.scroll.Y)}
+	if sa.StickToBottom {
+		state["atBottom"] = sa.AtBottom()
+	}
+	return state
+}
+
+// RestoreState implements StatefulComponent; the position is applied once the content
+// has been measured.
+func (sa *ScrollArea) RestoreState(state map[string]any) {
+	if atBottom, ok := state["atBottom"].(bool); ok && atBottom {
+		sa.ScrollToBottom()
+		return
+	}
+	if y, ok := stateFloat(state["scrollY"]); ok {
+		sa.ScrollTo(float32(y))
+	}
+	if x, ok := stateFloat(state["scrollX"]); ok {
+		sa.ScrollToX(float32(x))
+	}
+}
+
+// ChildActions returns the content for action traversal.
+func (sa *ScrollArea) ChildActions() []Component {
+	if sa.Content == nil {
+		return sa.Children
+	}
+	return append([]Component{sa.Content}, sa.Children...)
+}
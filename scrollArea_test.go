@@ -0,0 +1,66 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestScrollAreaRequests(t *testing.T) {
+	sa := NewScrollArea(nil)
+	sa.ScrollTo(-10)
+	if sa.pendingY != 0 {
+		t.Fatalf("expected negative positions to clamp to 0, got %v", sa.pendingY)
+	}
+	sa.ScrollToItemAt("item", 0.5)
+	if sa.pendingY >= 0 || sa.item != "item" || sa.itemAlign != 0.5 {
+		t.Fatal("expected the item request to replace the position request")
+	}
+	sa.ScrollToBottom()
+	if !sa.toBottom || sa.item != "" {
+		t.Fatal("expected the bottom request to replace the item request")
+	}
+	sa.ScrollTo(40)
+	if sa.toBottom || sa.pendingY != 40 {
+		t.Fatal("expected the position request to replace the bottom request")
+	}
+}
+
+func TestScrollAreaPosition(t *testing.T) {
+	sa := NewScrollArea(nil)
+	var calls int
+	sa.OnScroll = func(x, y float32) { calls++ }
+
+	sa.update(imgui.Vec2{Y: 10}, imgui.Vec2{Y: 100})
+	sa.update(imgui.Vec2{Y: 10}, imgui.Vec2{Y: 100})
+	if calls != 1 {
+		t.Fatalf("expected OnScroll once, got %d", calls)
+	}
+	if sa.ScrollY() != 10 || sa.MaxScrollY() != 100 || sa.AtBottom() {
+		t.Fatalf("unexpected position %v of %v", sa.ScrollY(), sa.MaxScrollY())
+	}
+	sa.update(imgui.Vec2{Y: 99.5}, imgui.Vec2{Y: 100})
+	if !sa.AtBottom() {
+		t.Fatal("expected to be at the bottom within a pixel")
+	}
+}
+
+func TestScrollAreaState(t *testing.T) {
+	sa := NewScrollArea(nil)
+	sa.update(imgui.Vec2{X: 5, Y: 30}, imgui.Vec2{X: 50, Y: 100})
+	state := sa.CaptureState()
+
+	restored := NewScrollArea(nil)
+	restored.RestoreState(state)
+	if restored.pendingX != 5 || restored.pendingY != 30 {
+		t.Fatalf("expected restored position 5,30, got %v,%v", restored.pendingX, restored.pendingY)
+	}
+
+	sa.StickToBottom = true
+	sa.update(imgui.Vec2{Y: 100}, imgui.Vec2{Y: 100})
+	restored = NewScrollArea(nil)
+	restored.RestoreState(sa.CaptureState())
+	if !restored.toBottom {
+		t.Fatal("expected a sticky area at the bottom to restore to the bottom")
+	}
+}
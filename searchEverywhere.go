@@ -0,0 +1,534 @@
+package dfx
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// search overlay constants
+const (
+	searchKeys            = "Ctrl+P"
+	searchOverlayWidth    = 520
+	searchOverlayTop      = 0.15 // top of the overlay, as a fraction of the window height
+	searchResultsHeight   = 360
+	DefaultSearchPerGroup = 8
+)
+
+// fuzzy match scores
+const (
+	fuzzyMatchScore       = 16 // each matched rune
+	fuzzyBoundaryBonus    = 24 // matched rune starts s or a word
+	fuzzyConsecutiveBonus = 24 // matched rune follows the previous match
+	fuzzyGapStartPenalty  = 8  // runes skipped between matches
+	fuzzyGapPenalty       = 1  // each rune skipped between matches
+	fuzzyLeadPenalty      = 1  // each rune before the first match, up to fuzzyMaxLead
+	fuzzyMaxLead          = 8
+)
+
+// SearchResult is an entry offered by a SearchProvider.
+type SearchResult struct {
+	Label   string // shown and matched against the query
+	Detail  string // shown dimmed after the label, such as a path or shortcut
+	Score   int    // rank within the provider's section (higher first)
+	Run     func() // called when the result is chosen, after the overlay closes
+	matches []int  // byte offsets in Label of the runes matching the query
+}
+
+// SearchProvider supplies results to a SearchOverlay, shown in a section of their own.
+type SearchProvider interface {
+	// Name is the title of the provider's section.
+	Name() string
+	// Search returns the results for query, which is empty when the overlay opens.
+	// results are ranked by Score.
+	Search(query string) []SearchResult
+}
+
+// NewSearchProvider creates a provider whose results are the items that fuzzy match the
+// query by label, ranked by how well they match. items is called on each search.
+func NewSearchProvider(name string, items func() []SearchResult) SearchProvider {
+	return &searchProvider{name: name, items: items}
+}
+
+type searchProvider struct {
+	name  string
+	items func() []SearchResult
+}
+
+func (p *searchProvider) Name() string { return p.name }
+
+func (p *searchProvider) Search(query string) []SearchResult {
+	return FuzzyFilter(p.items(), query)
+}
+
+// FuzzyFilter returns the results whose labels fuzzy match query, scored by how well they
+// match. all results are returned, unscored, for an empty query.
+func FuzzyFilter(results []SearchResult, query string) []SearchResult {
+	if query == "" {
+		return results
+	}
+	var matched []SearchResult
+	for _, result := range results {
+		if score, matches, ok := fuzzyMatch(result.Label, query); ok {
+			result.Score, result.matches = score, matches
+			matched = append(matched, result)
+		}
+	}
+	return matched
+}
+
+// ActionSearch searches the actions the app would dispatch keys to: those of the focused
+// component and the root hierarchy, then the global actions. choosing one runs its handler.
+func ActionSearch(app *App) SearchProvider {
+	return NewSearchProvider(T("dfx.search.actions"), func() []SearchResult {
+		return actionResults(app.dispatchRegistries())
+	})
+}
+
+// actionResults lists the actions of registries with handlers, each once.
+func actionResults(registries []*ActionRegistry) []SearchResult {
+	var results []SearchResult
+	seen := make(map[*Action]bool)
+	for _, registry := range registries {
+		for _, action := range registry.actions {
+			if action.Handler == nil || seen[action] {
+				continue
+			}
+			seen[action] = true
+			label := action.Label
+			if label == "" {
+				label = action.Id
+			}
+			results = append(results, SearchResult{
+				Label:  label,
+				Detail: formatShortcutLabel(action.mods, action.key),
+				Run:    action.Handler,
+			})
+		}
+	}
+	return results
+}
+
+// WorkspaceSearch searches the workspaces of ws, and of the loaded workspaces nested in
+// it. choosing one switches to it.
+func WorkspaceSearch(ws *Workspace) SearchProvider {
+	return NewSearchProvider(T("dfx.search.workspaces"), func() []SearchResult {
+		return workspaceResults(ws, "", nil)
+	})
+}
+
+// workspaceResults lists the workspaces of ws, with detail naming the enclosing workspace
+// and enter switching the enclosing workspaces to it.
+func workspaceResults(ws *Workspace, detail string, enter func()) []SearchResult {
+	var results []SearchResult
+	for _, item := range ws.items {
+		id := item.Id
+		switchTo := func() {
+			if enter != nil {
+				enter()
+			}
+			ws.Switch(id)
+		}
+		results = append(results, SearchResult{Label: item.Name, Detail: detail, Run: switchTo})
+		if nested, ok := item.Component.(*Workspace); ok {
+			results = append(results, workspaceResults(nested, item.Name, switchTo)...)
+		}
+	}
+	return results
+}
+
+// FileTreeSearch searches the files under the tree's root that pass its Filter. choosing
+// one selects it in the tree and opens it with OnDoubleClick.
+func FileTreeSearch(tree *FileTree) SearchProvider {
+	return NewSearchProvider(T("dfx.search.files"), func() []SearchResult {
+		var results []SearchResult
+		var visit func(node *FileNode)
+		visit = func(node *FileNode) {
+			if node == nil || (tree.Filter != nil && !tree.Filter(node)) {
+				return
+			}
+			if !node.Dir {
+				results = append(results, SearchResult{
+					Label:  node.Name,
+					Detail: node.Parent.Path(),
+					Run: func() {
+						tree.SelectNode(node)
+						if tree.OnDoubleClick != nil {
+							tree.OnDoubleClick(node)
+						}
+					},
+				})
+			}
+			for _, child := range node.Children {
+				visit(child)
+			}
+		}
+		visit(tree.Root)
+		return results
+	})
+}
+
+// RecentFilesSearch searches a recent files list. choosing a file opens it with Open.
+func RecentFilesSearch(recent *RecentFiles) SearchProvider {
+	return NewSearchProvider(T("dfx.search.recent"), func() []SearchResult {
+		var results []SearchResult
+		for _, file := range recent.Files() {
+			path := file.Path
+			results = append(results, SearchResult{
+				Label:  filepath.Base(path),
+				Detail: filepath.Dir(path),
+				Run:    func() { recent.Open(path) },
+			})
+		}
+		return results
+	})
+}
+
+// searchGroup is a provider's section of the overlay.
+type searchGroup struct {
+	name    string
+	results []SearchResult
+}
+
+// SearchOverlay is a search-everywhere palette: a query field over the results of its
+// providers, in a section per provider, each ranked by how well it matches. sections with
+// better matches come first. arrows move through the results, Enter chooses one and Escape
+// closes the overlay.
+//
+// App.EnableSearch creates one that opens with Ctrl+P.
+type SearchOverlay struct {
+	Container
+	Providers []SearchProvider
+	PerGroup  int // results shown per section (0 = DefaultSearchPerGroup)
+
+	open     bool
+	focus    bool
+	query    string
+	groups   []searchGroup
+	selected int
+	scroll   bool // scroll the selected result into view
+}
+
+// NewSearchOverlay creates an overlay searching providers.
+func NewSearchOverlay(providers ...SearchProvider) *SearchOverlay {
+	return &SearchOverlay{Container: Container{Visible: true}, Providers: providers}
+}
+
+// AddProvider adds a provider, shown after the existing ones when their matches are
+// equally good.
+func (s *SearchOverlay) AddProvider(provider SearchProvider) {
+	s.Providers = append(s.Providers, provider)
+}
+
+// Open shows the overlay with an empty query.
+func (s *SearchOverlay) Open() {
+	s.open, s.focus, s.query = true, true, ""
+	s.refresh()
+}
+
+// Close hides the overlay.
+func (s *SearchOverlay) Close() {
+	s.open = false
+	s.groups = nil
+}
+
+// Toggle opens the overlay, or closes it when open.
+func (s *SearchOverlay) Toggle() {
+	if s.open {
+		s.Close()
+	} else {
+		s.Open()
+	}
+}
+
+// IsOpen reports whether the overlay is shown.
+func (s *SearchOverlay) IsOpen() bool {
+	return s.open
+}
+
+// Query returns the current query.
+func (s *SearchOverlay) Query() string {
+	return s.query
+}
+
+// SetQuery replaces the query and searches again.
+func (s *SearchOverlay) SetQuery(query string) {
+	s.query = query
+	s.refresh()
+}
+
+// refresh asks every provider for the results of the query.
+func (s *SearchOverlay) refresh() {
+	s.groups = searchGroups(s.Providers, s.query, s.PerGroup)
+	s.selected = 0
+}
+
+// searchGroups collects the sections for query: each provider's results ranked by score,
+// limited to perGroup, and the sections ordered by their best score. empty sections are
+// left out.
+func searchGroups(providers []SearchProvider, query string, perGroup int) []searchGroup {
+	if perGroup <= 0 {
+		perGroup = DefaultSearchPerGroup
+	}
+	var groups []searchGroup
+	for _, provider := range providers {
+		results := slices.Clone(provider.Search(query))
+		if len(results) == 0 {
+			continue
+		}
+		slices.SortStableFunc(results, func(a, b SearchResult) int { return b.Score - a.Score })
+		groups = append(groups, searchGroup{name: provider.Name(), results: results[:min(len(results), perGroup)]})
+	}
+	slices.SortStableFunc(groups, func(a, b searchGroup) int { return b.results[0].Score - a.results[0].Score })
+	return groups
+}
+
+// result returns the i'th result across the sections.
+func (s *SearchOverlay) result(i int) (SearchResult, bool) {
+	for _, group := range s.groups {
+		if i < len(group.results) {
+			return group.results[i], true
+		}
+		i -= len(group.results)
+	}
+	return SearchResult{}, false
+}
+
+// count returns the number of results across the sections.
+func (s *SearchOverlay) count() int {
+	n := 0
+	for _, group := range s.groups {
+		n += len(group.results)
+	}
+	return n
+}
+
+// choose closes the overlay and runs the i'th result.
+func (s *SearchOverlay) choose(i int) {
+	result, ok := s.result(i)
+	if !ok {
+		return
+	}
+	s.Close()
+	if result.Run != nil {
+		result.Run()
+	}
+}
+
+// Draw implements Component, showing the overlay while it is open.
+func (s *SearchOverlay) Draw(state *State) {
+	if !s.Visible || !s.open {
+		return
+	}
+	viewport := imgui.MainViewport()
+	pos := viewport.Pos().Add(imgui.Vec2{X: viewport.Size().X / 2, Y: viewport.Size().Y * searchOverlayTop})
+	imgui.SetNextWindowPosV(pos, imgui.CondAlways, imgui.Vec2{X: 0.5})
+	imgui.SetNextWindowSize(imgui.Vec2{X: searchOverlayWidth})
+	flags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsAlwaysAutoResize | imgui.WindowFlagsNoSavedSettings |
+		imgui.WindowFlagsNoMove
+	if imgui.BeginV(fmt.Sprintf("##searchEverywhere_%p", s), nil, flags) {
+		imgui.InternalBringWindowToDisplayFront(imgui.InternalCurrentWindow())
+		s.drawContent()
+	}
+	imgui.End()
+
+	drawContainerExtensions(&s.Container, state)
+}
+
+// drawContent draws the query field and the results, handling the keys.
+func (s *SearchOverlay) drawContent() {
+	if s.focus {
+		imgui.SetKeyboardFocusHere()
+		s.focus = false
+	} else if !imgui.IsWindowFocusedV(imgui.FocusedFlagsRootAndChildWindows) {
+		s.Close() // clicked elsewhere
+		return
+	}
+
+	imgui.SetNextItemWidth(-1)
+	if imgui.InputTextWithHint("##query", T("dfx.search.hint"), &s.query, imgui.InputTextFlagsNone, nil) {
+		s.refresh()
+	}
+
+	n := s.count()
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyEscape):
+		s.Close()
+		return
+	case imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter):
+		s.choose(s.selected)
+		return
+	case n > 0 && imgui.IsKeyPressedBool(imgui.KeyDownArrow):
+		s.selected, s.scroll = (s.selected+1)%n, true
+	case n > 0 && imgui.IsKeyPressedBool(imgui.KeyUpArrow):
+		s.selected, s.scroll = (s.selected-1+n)%n, true
+	}
+
+	if n == 0 {
+		imgui.TextDisabled(T("dfx.search.none"))
+		return
+	}
+	height := min(float32(n+2*len(s.groups))*imgui.FrameHeightWithSpacing(), searchResultsHeight)
+	if imgui.BeginChildStrV("##results", imgui.Vec2{Y: height}, 0, 0) {
+		i := 0
+		for _, group := range s.groups {
+			imgui.SeparatorText(group.name)
+			for _, result := range group.results {
+				selected := i == s.selected
+				if drawSearchResult(i, result, selected) {
+					imgui.EndChild()
+					s.choose(i)
+					return
+				}
+				if selected && s.scroll {
+					imgui.SetScrollHereYV(0.5)
+					s.scroll = false
+				}
+				i++
+			}
+		}
+	}
+	imgui.EndChild()
+}
+
+// drawSearchResult draws a result, its matching runes in the accent color, and reports
+// whether it was clicked.
+func drawSearchResult(i int, result SearchResult, selected bool) bool {
+	imgui.PushIDInt(int32(i))
+	defer imgui.PopID()
+	clicked := imgui.SelectableBoolV("##result", selected, imgui.SelectableFlagsNone, imgui.Vec2{Y: imgui.TextLineHeight()})
+	pos := imgui.ItemRectMin()
+	text := imgui.ColorConvertFloat4ToU32(imgui.CurrentStyle().Colors()[imgui.ColText])
+	accent := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	drawList := imgui.WindowDrawList()
+	for _, span := range matchSpans(result.Label, result.matches) {
+		color := text
+		if span.matched {
+			color = accent
+		}
+		drawList.AddTextVec2(pos, color, span.text)
+		pos.X += imgui.CalcTextSize(span.text).X
+	}
+	if result.Detail != "" {
+		pos.X += imgui.CurrentStyle().ItemSpacing().X * 2
+		drawList.AddTextVec2(pos, imgui.ColorConvertFloat4ToU32(imgui.CurrentStyle().Colors()[imgui.ColTextDisabled]), result.Detail)
+	}
+	return clicked
+}
+
+// labelSpan is a run of a label that matches the query, or does not.
+type labelSpan struct {
+	text    string
+	matched bool
+}
+
+// matchSpans splits label into runs of matched and unmatched runes, given the byte offsets
+// of the matched runes.
+func matchSpans(label string, matches []int) []labelSpan {
+	var spans []labelSpan
+	next := 0
+	for offset, r := range label {
+		matched := next < len(matches) && matches[next] == offset
+		if matched {
+			next++
+		}
+		end := offset + utf8.RuneLen(r)
+		if n := len(spans); n > 0 && spans[n-1].matched == matched {
+			spans[n-1].text = label[offset-len(spans[n-1].text) : end]
+		} else {
+			spans = append(spans, labelSpan{text: label[offset:end], matched: matched})
+		}
+	}
+	return spans
+}
+
+// fuzzyMatch matches the runes of query, in order and ignoring case, against s. it
+// returns the best score over the ways to match and the byte offsets in s of the matched
+// runes. matches starting s or a word, and runs of consecutive matches, score higher;
+// skipped runes score lower.
+func fuzzyMatch(s, query string) (int, []int, bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+	text, pattern := []rune(s), []rune(query)
+	if len(pattern) > len(text) {
+		return 0, nil, false
+	}
+
+	// score[j][i] is the best score of matching pattern[:j+1] with pattern[j] at text[i],
+	// from[j][i] the position of pattern[j-1] in that match (-1 = no match)
+	score := make([][]int, len(pattern))
+	from := make([][]int, len(pattern))
+	for j := range pattern {
+		score[j], from[j] = make([]int, len(text)), make([]int, len(text))
+		for i := range text {
+			from[j][i] = -1
+			if !equalFoldRune(text[i], pattern[j]) {
+				continue
+			}
+			bonus := fuzzyMatchScore
+			if wordStart(text, i) {
+				bonus += fuzzyBoundaryBonus
+			}
+			if j == 0 {
+				score[j][i], from[j][i] = bonus-min(i, fuzzyMaxLead)*fuzzyLeadPenalty, i
+				continue
+			}
+			for k := j - 1; k < i; k++ {
+				if from[j-1][k] < 0 {
+					continue
+				}
+				candidate := score[j-1][k] + bonus
+				if k == i-1 {
+					candidate += fuzzyConsecutiveBonus
+				} else {
+					candidate -= fuzzyGapStartPenalty + (i-k-1)*fuzzyGapPenalty
+				}
+				if from[j][i] < 0 || candidate > score[j][i] {
+					score[j][i], from[j][i] = candidate, k
+				}
+			}
+		}
+	}
+
+	last := len(pattern) - 1
+	end := -1
+	for i := range text {
+		if from[last][i] >= 0 && (end < 0 || score[last][i] > score[last][end]) {
+			end = i
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	offsets := make([]int, 0, len(text))
+	for offset := range s {
+		offsets = append(offsets, offset)
+	}
+	matches := make([]int, len(pattern))
+	for j, i := last, end; j >= 0; j-- {
+		matches[j] = offsets[i]
+		i = from[j][i]
+	}
+	return score[last][end], matches, true
+}
+
+// wordStart reports whether the rune at i starts text or a word: it follows a separator,
+// or is an upper case letter following a lower case one.
+func wordStart(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, r := text[i-1], text[i]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	case unicode.IsLower(prev) && unicode.IsUpper(r):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package dfx
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	score, matches, ok := fuzzyMatch("Open File", "of")
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if !slices.Equal(matches, []int{0, 5}) {
+		t.Fatalf("expected '%v', got '%v'", []int{0, 5}, matches)
+	}
+	if score <= 0 {
+		t.Fatalf("expected '%v' > '%v'", score, 0)
+	}
+
+	_, _, ok = fuzzyMatch("Open File", "fo")
+	if ok {
+		t.Fatal("unexpected ok")
+	}
+
+	_, matches, ok = fuzzyMatch("Ünïcode Name", "ün")
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if !slices.Equal(matches, []int{0, 2}) {
+		t.Fatalf("expected '%v', got '%v'", []int{0, 2}, matches)
+	} // byte offsets
+
+	// word starts beat the first occurrence
+	_, matches, _ = fuzzyMatch("save as new", "sn")
+	if !slices.Equal(matches, []int{0, 8}) {
+		t.Fatalf("expected '%v', got '%v'", []int{0, 8}, matches)
+	}
+	_, matches, _ = fuzzyMatch("openRecentFile", "orf")
+	if !slices.Equal(matches, []int{0, 4, 10}) {
+		t.Fatalf("expected '%v', got '%v'", []int{0, 4, 10}, matches)
+	}
+}
+
+func TestFuzzyMatch_Ranking(t *testing.T) {
+	score := func(s string) int {
+		score, _, ok := fuzzyMatch(s, "term")
+		if !ok {
+			t.Fatal("expected ok")
+		}
+		return score
+	}
+	if score("Terminal") <= score("New Terminal") {
+		t.Fatalf("expected '%v' > '%v'", score("Terminal"), score("New Terminal"))
+	}
+	if score("New Terminal") <= score("Toggle Error Marks") {
+		t.Fatalf("expected '%v' > '%v'", score("New Terminal"), score("Toggle Error Marks"))
+	}
+	if score("Toggle Error Marks") <= score("Attach Debugger Memory") {
+		t.Fatalf("expected '%v' > '%v'", score("Toggle Error Marks"), score("Attach Debugger Memory"))
+	}
+}
+
+func TestMatchSpans(t *testing.T) {
+	if got := matchSpans("Open File", []int{0, 1, 5}); !slices.Equal(got, []labelSpan{{"Op", true}, {"en ", false}, {"F", true}, {"ile", false}}) {
+		t.Fatalf("expected '%v', got '%v'", []labelSpan{{"Op", true}, {"en ", false}, {"F", true}, {"ile", false}}, got)
+	}
+	if got := matchSpans("plain", nil); !slices.Equal(got, []labelSpan{{"plain", false}}) {
+		t.Fatalf("expected '%v', got '%v'", []labelSpan{{"plain", false}}, got)
+	}
+}
+
+func staticProvider(name string, labels ...string) SearchProvider {
+	return NewSearchProvider(name, func() []SearchResult {
+		var results []SearchResult
+		for _, label := range labels {
+			results = append(results, SearchResult{Label: label})
+		}
+		return results
+	})
+}
+
+func TestSearchGroups(t *testing.T) {
+	providers := []SearchProvider{
+		staticProvider("Actions", "Close Window", "Copy", "Format"),
+		staticProvider("Files", "config.yaml", "main.go"),
+		staticProvider("Empty"),
+	}
+
+	groups := searchGroups(providers, "", 1)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2, got %d", len(groups))
+	} // empty sections are left out
+	if got := groups[0].name; got != "Actions" {
+		t.Fatalf("expected '%v', got '%v'", "Actions", got)
+	}
+	if got := groups[0].results; !reflect.DeepEqual(got, []SearchResult{{Label: "Close Window"}}) {
+		t.Fatalf("expected '%v', got '%v'", []SearchResult{{Label: "Close Window"}}, got)
+	}
+
+	groups = searchGroups(providers, "conf", 0)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1, got %d", len(groups))
+	}
+	if got := groups[0].results[0].Label; got != "config.yaml" {
+		t.Fatalf("expected '%v', got '%v'", "config.yaml", got)
+	}
+
+	// each section is ranked by score, and ties keep the provider order
+	groups = searchGroups(providers, "co", 0)
+	if got := groups[0].name; got != "Actions" {
+		t.Fatalf("expected '%v', got '%v'", "Actions", got)
+	}
+	if got := groups[0].results[0].Label; got != "Copy" {
+		t.Fatalf("expected '%v', got '%v'", "Copy", got)
+	}
+	if got := groups[0].results[1].Label; got != "Close Window" {
+		t.Fatalf("expected '%v', got '%v'", "Close Window", got)
+	}
+
+	// the section with the best match comes first
+	groups = searchGroups(providers, "ma", 0)
+	if got := groups[0].name; got != "Files" {
+		t.Fatalf("expected '%v', got '%v'", "Files", got)
+	}
+	if got := groups[1].results[0].Label; got != "Format" {
+		t.Fatalf("expected '%v', got '%v'", "Format", got)
+	}
+}
+
+func TestActionResults(t *testing.T) {
+	global := NewActionRegistry()
+	global.MustRegister("Save", "Ctrl+S", func() {})
+	local := NewActionRegistry()
+	local.MustRegisterAction(NewMenuAction("Find...", "Ctrl+F", func() {}))
+	local.MustRegister("No Handler", "F3", nil)
+
+	results := actionResults([]*ActionRegistry{local, global, local})
+	if len(results) != 2 {
+		t.Fatalf("expected 2, got %d", len(results))
+	}
+	if got := results[0].Label; got != "Find..." {
+		t.Fatalf("expected '%v', got '%v'", "Find...", got)
+	}
+	if got := results[0].Detail; got != "Ctrl+F" {
+		t.Fatalf("expected '%v', got '%v'", "Ctrl+F", got)
+	}
+	if got := results[1].Label; got != "Save" {
+		t.Fatalf("expected '%v', got '%v'", "Save", got)
+	}
+}
+
+func TestWorkspaceResults(t *testing.T) {
+	outer := NewWorkspace()
+	inner := NewWorkspace()
+	inner.Add("a", "Alpha", &Container{})
+	inner.Add("b", "Beta", &Container{})
+	outer.Add("home", "Home", &Container{})
+	outer.Add("inner", "Inner", inner)
+
+	results := workspaceResults(outer, "", nil)
+	if len(results) != 4 {
+		t.Fatalf("expected 4, got %d", len(results))
+	}
+	if got := results[3].Label; got != "Beta" {
+		t.Fatalf("expected '%v', got '%v'", "Beta", got)
+	}
+	if got := results[3].Detail; got != "Inner" {
+		t.Fatalf("expected '%v', got '%v'", "Inner", got)
+	}
+
+	results[3].Run()
+	if got := outer.Current(); got != "inner" {
+		t.Fatalf("expected '%v', got '%v'", "inner", got)
+	}
+	if got := inner.Current(); got != "b" {
+		t.Fatalf("expected '%v', got '%v'", "b", got)
+	}
+}
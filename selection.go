@@ -0,0 +1,167 @@
+package dfx
+
+// SelectionMode controls how many items a Selection may hold.
+type SelectionMode int
+
+const (
+	SelectSingle SelectionMode = iota // at most one item
+	SelectMulti                       // any number of items (Ctrl+click toggles, Shift+click selects a range)
+	SelectNone                        // selection disabled
+)
+
+// Selection is the set of selected items in a ListView or TreeView, identified by stable
+// keys (model indices for lists, node ids for trees). it remembers the anchor of the last
+// plain or Ctrl click, from which Shift+click ranges are selected.
+type Selection[K comparable] struct {
+	Mode     SelectionMode
+	OnChange func() // called after the selection changes from a click or keyboard navigation
+
+	keys      map[K]bool
+	order     []K // keys in selection order
+	anchor    K
+	hasAnchor bool
+}
+
+// NewSelection creates an empty selection.
+func NewSelection[K comparable](mode SelectionMode) *Selection[K] {
+	return &Selection[K]{Mode: mode}
+}
+
+// Contains reports whether key is selected.
+func (s *Selection[K]) Contains(key K) bool {
+	return s.keys[key]
+}
+
+// Len returns the number of selected items.
+func (s *Selection[K]) Len() int {
+	return len(s.keys)
+}
+
+// Keys returns the selected keys in the order they were selected.
+func (s *Selection[K]) Keys() []K {
+	return append([]K(nil), s.order...)
+}
+
+// First returns the first selected key, or false when nothing is selected.
+func (s *Selection[K]) First() (K, bool) {
+	if len(s.order) == 0 {
+		var zero K
+		return zero, false
+	}
+	return s.order[0], true
+}
+
+// Anchor returns the key Shift+click ranges start from, or false when there is none.
+func (s *Selection[K]) Anchor() (K, bool) {
+	return s.anchor, s.hasAnchor
+}
+
+// Set selects just key, and makes it the anchor.
+func (s *Selection[K]) Set(key K) {
+	if s.Mode == SelectNone {
+		return
+	}
+	s.Clear()
+	s.add(key)
+	s.anchor, s.hasAnchor = key, true
+}
+
+// Add selects key in addition to the current selection (replacing it in single mode).
+func (s *Selection[K]) Add(key K) {
+	if s.Mode == SelectSingle {
+		s.Set(key)
+		return
+	}
+	if s.Mode == SelectMulti {
+		s.add(key)
+	}
+}
+
+// Remove deselects key.
+func (s *Selection[K]) Remove(key K) {
+	if !s.keys[key] {
+		return
+	}
+	delete(s.keys, key)
+	for i, k := range s.order {
+		if k == key {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Toggle selects key if it is not selected and deselects it otherwise.
+func (s *Selection[K]) Toggle(key K) {
+	if s.keys[key] {
+		s.Remove(key)
+	} else {
+		s.Add(key)
+	}
+}
+
+// SetAll replaces the selection with keys (only the first in single mode).
+func (s *Selection[K]) SetAll(keys []K) {
+	s.Clear()
+	for _, key := range keys {
+		s.Add(key)
+		if s.Mode != SelectMulti {
+			break
+		}
+	}
+}
+
+// Clear deselects everything and forgets the anchor.
+func (s *Selection[K]) Clear() {
+	clear(s.keys)
+	s.order = s.order[:0]
+	s.hasAnchor = false
+}
+
+// Click applies a click on key: a plain click selects just key, toggle (Ctrl) adds or
+// removes it, and extend (Shift) selects the range from the anchor to key. span returns
+// the keys from one key to another inclusive, in display order.
+func (s *Selection[K]) Click(key K, extend, toggle bool, span func(from, to K) []K) {
+	switch {
+	case s.Mode == SelectNone:
+		return
+
+	case s.Mode == SelectSingle:
+		s.Set(key)
+
+	case extend && s.hasAnchor && span != nil:
+		anchor := s.anchor
+		if !toggle {
+			s.Clear()
+		}
+		for _, k := range span(anchor, key) {
+			s.add(k)
+		}
+		s.anchor, s.hasAnchor = anchor, true
+
+	case toggle:
+		s.Toggle(key)
+		s.anchor, s.hasAnchor = key, true
+
+	default:
+		s.Set(key)
+	}
+	s.changed()
+}
+
+func (s *Selection[K]) add(key K) {
+	if s.keys == nil {
+		s.keys = make(map[K]bool)
+	}
+	if s.keys[key] {
+		return
+	}
+	s.keys[key] = true
+	s.order = append(s.order, key)
+}
+
+func (s *Selection[K]) changed() {
+	if s.OnChange != nil {
+		s.OnChange()
+	}
+}
@@ -0,0 +1,82 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+)
+
+func spanInts(from, to int) []int {
+	if from > to {
+		from, to = to, from
+	}
+	var keys []int
+	for k := from; k <= to; k++ {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestSelectionClick(t *testing.T) {
+	s := NewSelection[int](SelectMulti)
+	changes := 0
+	s.OnChange = func() { changes++ }
+
+	s.Click(3, false, false, spanInts)
+	if !slices.Equal(s.Keys(), []int{3}) {
+		t.Fatalf("expected [3], got %v", s.Keys())
+	}
+	s.Click(6, true, false, spanInts)
+	if !slices.Equal(s.Keys(), []int{3, 4, 5, 6}) {
+		t.Fatalf("expected range 3-6, got %v", s.Keys())
+	}
+	// shift again re-spans from the same anchor
+	s.Click(1, true, false, spanInts)
+	if !slices.Equal(s.Keys(), []int{1, 2, 3}) {
+		t.Fatalf("expected range 1-3, got %v", s.Keys())
+	}
+	s.Click(2, false, true, spanInts)
+	if s.Contains(2) || s.Len() != 2 {
+		t.Fatalf("expected 2 toggled off, got %v", s.Keys())
+	}
+	if anchor, ok := s.Anchor(); !ok || anchor != 2 {
+		t.Fatalf("expected anchor 2, got %v", anchor)
+	}
+	s.Click(9, false, false, spanInts)
+	if !slices.Equal(s.Keys(), []int{9}) {
+		t.Fatalf("expected [9], got %v", s.Keys())
+	}
+	if changes != 5 {
+		t.Fatalf("expected 5 changes, got %v", changes)
+	}
+}
+
+func TestSelectionModes(t *testing.T) {
+	single := NewSelection[string](SelectSingle)
+	single.Click("a", false, false, nil)
+	single.Click("b", true, true, nil)
+	if !slices.Equal(single.Keys(), []string{"b"}) {
+		t.Fatalf("expected [b], got %v", single.Keys())
+	}
+	single.SetAll([]string{"x", "y"})
+	if !slices.Equal(single.Keys(), []string{"x"}) {
+		t.Fatalf("expected [x], got %v", single.Keys())
+	}
+
+	none := NewSelection[string](SelectNone)
+	none.Click("a", false, false, nil)
+	none.Add("b")
+	if none.Len() != 0 {
+		t.Fatalf("expected empty selection, got %v", none.Keys())
+	}
+
+	multi := NewSelection[string](SelectMulti)
+	multi.SetAll([]string{"c", "a", "c"})
+	multi.Remove("c")
+	if first, ok := multi.First(); !ok || first != "a" || multi.Len() != 1 {
+		t.Fatalf("expected [a], got %v", multi.Keys())
+	}
+	multi.Clear()
+	if _, ok := multi.First(); ok {
+		t.Fatalf("expected empty selection")
+	}
+}
@@ -0,0 +1,506 @@
+package dfx
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// settings layout constants
+const (
+	settingsCategoryWidth = 160 // width of the category list
+	settingsGeneralName   = "General"
+)
+
+// Settings is a component that generates a settings panel from a config struct.
+//
+// nested structs become categories (top-level) or collapsible sections (deeper levels),
+// and each exported field gets a widget chosen from its type. fields are described with
+// struct tags:
+//
+//	Volume float32 `label:"Output Volume" desc:"master output level" min:"0" max:"1"`
+//	Mode   string  `options:"fast,balanced,accurate"`
+//	Tint   []float32 `widget:"color"` // 3 (rgb) or 4 (rgba) components
+//	Secret string  `settings:"-"`
+//
+// labels, descriptions and section names pass through T, so they may be translation keys.
+//
+// edits are made to a working copy. Apply copies the working copy into Config and
+// persists it; Revert discards edits; Reset to Defaults loads Defaults into the working copy.
+type Settings struct {
+	Container
+	Config   interface{}    // pointer to the live config struct
+	Defaults interface{}    // optional pointer to a struct holding default values
+	Path     string         // optional config file path used when applying
+	Manager  *ConfigManager // optional; when set with Path, Apply marks the config dirty instead of saving directly
+	OnApply  func()         // called after changes are applied
+	OnError  func(error)    // called when persisting applied changes fails
+
+	draft    reflect.Value
+	sections []*settingsSection
+	selected int
+}
+
+// settingsSection is a category of fields, possibly containing nested sections.
+type settingsSection struct {
+	name     string
+	desc     string
+	index    []int // field index path from the root struct; nil for the root
+	fields   []settingsField
+	children []*settingsSection
+}
+
+// settingsField describes a single editable field.
+type settingsField struct {
+	name    string
+	label   string
+	desc    string
+	index   []int
+	kind    reflect.Kind
+	min     float64
+	max     float64
+	ranged  bool
+	options []string
+	widget  string
+	format  string
+}
+
+// NewSettings creates a settings panel for config, which must be a pointer to a struct.
+// defaults may be nil.
+func NewSettings(config, defaults interface{}) *Settings {
+	s := &Settings{
+		Container: Container{Visible: true},
+		Config:    config,
+		Defaults:  defaults,
+	}
+	s.Revert()
+	return s
+}
+
+// Dirty reports whether the working copy differs from the live config.
+func (s *Settings) Dirty() bool {
+	if !s.draft.IsValid() {
+		return false
+	}
+	return !reflect.DeepEqual(s.draft.Interface(), reflect.ValueOf(s.Config).Elem().Interface())
+}
+
+// Apply copies the working copy into Config and persists it.
+func (s *Settings) Apply() error {
+	if !s.draft.IsValid() {
+		return nil
+	}
+	reflect.ValueOf(s.Config).Elem().Set(deepCopyValue(s.draft))
+
+	var err error
+	if s.Path != "" {
+		if s.Manager != nil {
+			s.Manager.MarkDirty(s.Path)
+		} else {
+			err = SaveConfig(s.Path, s.Config)
+		}
+	}
+	if s.OnApply != nil {
+		s.OnApply()
+	}
+	if err != nil && s.OnError != nil {
+		s.OnError(err)
+	}
+	return err
+}
+
+// Revert discards edits by reloading the working copy from Config.
+func (s *Settings) Revert() {
+	v := reflect.ValueOf(s.Config)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		s.draft = reflect.Value{}
+		return
+	}
+	draft := reflect.New(v.Elem().Type()).Elem()
+	draft.Set(deepCopyValue(v.Elem()))
+	s.draft = draft
+	if s.sections == nil {
+		s.sections = buildSettingsSections(v.Elem().Type())
+	}
+}
+
+// ResetToDefaults loads Defaults into the working copy. the change still has to be applied.
+func (s *Settings) ResetToDefaults() {
+	if !s.draft.IsValid() || s.Defaults == nil {
+		return
+	}
+	d := reflect.ValueOf(s.Defaults)
+	if d.Kind() == reflect.Ptr {
+		d = d.Elem()
+	}
+	if d.Type() != s.draft.Type() {
+		return
+	}
+	s.draft.Set(deepCopyValue(d))
+}
+
+// Draw renders the settings panel.
+func (s *Settings) Draw(state *State) {
+	if !s.Visible {
+		return
+	}
+	if !s.draft.IsValid() {
+		imgui.TextDisabled(T("dfx.settings.notStruct"))
+		return
+	}
+
+	footerHeight := imgui.FrameHeightWithSpacing() + imgui.CurrentStyle().ItemSpacing().Y
+	bodyHeight := imgui.ContentRegionAvail().Y - footerHeight
+
+	if len(s.sections) > 1 {
+		if s.selected >= len(s.sections) {
+			s.selected = 0
+		}
+		imgui.BeginChildStrV("##settingsCategories", imgui.Vec2{X: settingsCategoryWidth, Y: bodyHeight}, imgui.ChildFlagsBorders, 0)
+		for i, section := range s.sections {
+			if imgui.SelectableBoolV(T(section.name)+"##"+section.name, i == s.selected, 0, imgui.Vec2{}) {
+				s.selected = i
+			}
+			if section.desc != "" {
+				imgui.SetItemTooltip(T(section.desc))
+			}
+		}
+		imgui.EndChild()
+		imgui.SameLine()
+	}
+
+	imgui.BeginChildStrV("##settingsFields", imgui.Vec2{X: 0, Y: bodyHeight}, 0, 0)
+	if len(s.sections) > 0 {
+		section := s.sections[s.selected]
+		if section.desc != "" {
+			imgui.TextWrapped(T(section.desc))
+			imgui.Separator()
+		}
+		s.drawSection(section)
+	}
+	imgui.EndChild()
+
+	s.drawFooter()
+
+	drawContainerExtensions(&s.Container, state)
+}
+
+// drawFooter renders the Apply, Revert and Reset to Defaults buttons below a separator.
+func (s *Settings) drawFooter() {
+	imgui.Separator()
+	dirty := s.Dirty()
+	if !dirty {
+		imgui.BeginDisabled()
+	}
+	if imgui.Button(T("dfx.settings.apply")) {
+		_ = s.Apply()
+	}
+	imgui.SameLine()
+	if imgui.Button(T("dfx.settings.revert")) {
+		s.Revert()
+	}
+	if !dirty {
+		imgui.EndDisabled()
+	}
+	if s.Defaults != nil {
+		imgui.SameLine()
+		if imgui.Button(T("dfx.settings.reset")) {
+			s.ResetToDefaults()
+		}
+	}
+}
+
+// drawSection renders the fields of a section followed by its nested sections.
+func (s *Settings) drawSection(section *settingsSection) {
+	imgui.PushIDStr(section.name)
+	defer imgui.PopID()
+
+	for _, field := range section.fields {
+		s.drawField(field)
+	}
+	for _, child := range section.children {
+		if imgui.CollapsingHeaderTreeNodeFlagsV(T(child.name)+"##"+child.name, imgui.TreeNodeFlagsDefaultOpen) {
+			if child.desc != "" {
+				imgui.SetItemTooltip(T(child.desc))
+			}
+			imgui.Indent()
+			s.drawSection(child)
+			imgui.Unindent()
+		}
+	}
+}
+
+// settingsDataType returns the imgui data type matching an integer kind.
+func settingsDataType(kind reflect.Kind) imgui.DataType {
+	switch kind {
+	case reflect.Int8:
+		return imgui.DataTypeS8
+	case reflect.Int16:
+		return imgui.DataTypeS16
+	case reflect.Int32:
+		return imgui.DataTypeS32
+	case reflect.Uint8:
+		return imgui.DataTypeU8
+	case reflect.Uint16:
+		return imgui.DataTypeU16
+	case reflect.Uint32:
+		return imgui.DataTypeU32
+	case reflect.Int:
+		if strconv.IntSize == 32 {
+			return imgui.DataTypeS32
+		}
+		return imgui.DataTypeS64
+	case reflect.Uint:
+		if strconv.IntSize == 32 {
+			return imgui.DataTypeU32
+		}
+		return imgui.DataTypeU64
+	case reflect.Uint64:
+		return imgui.DataTypeU64
+	default:
+		return imgui.DataTypeS64
+	}
+}
+
+// drawField renders the widget for a single field of the working copy.
+func (s *Settings) drawField(f settingsField) {
+	v := s.draft.FieldByIndex(f.index)
+	label := T(f.label) + "##" + f.name
+
+	switch f.kind {
+	case reflect.Bool:
+		if nv, changed := Checkbox(label, v.Bool()); changed {
+			v.SetBool(nv)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case len(f.options) > 0:
+			if idx, changed := Combo(label, int(v.Int()), f.options); changed {
+				v.SetInt(int64(idx))
+			}
+		case f.ranged:
+			if nv, changed := SliderInt(label, int(v.Int()), int(f.min), int(f.max)); changed {
+				v.SetInt(int64(nv))
+			}
+		default:
+			// edited in place, at the field's own width; the draft is heap allocated
+			imgui.InputScalar(label, settingsDataType(f.kind), v.Addr().Pointer())
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if f.ranged {
+			i := int32(v.Uint())
+			if imgui.SliderIntV(label, &i, int32(f.min), int32(f.max), "%d", 0) && i >= 0 {
+				v.SetUint(uint64(i))
+			}
+		} else {
+			imgui.InputScalar(label, settingsDataType(f.kind), v.Addr().Pointer())
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if f.ranged {
+			fv := float32(v.Float())
+			if imgui.SliderFloatV(label, &fv, float32(f.min), float32(f.max), f.format, 0) {
+				v.SetFloat(float64(fv))
+			}
+		} else {
+			fv := v.Float()
+			if imgui.InputDouble(label, &fv) {
+				v.SetFloat(fv)
+			}
+		}
+
+	case reflect.String:
+		if len(f.options) > 0 {
+			current := 0
+			for i, opt := range f.options {
+				if opt == v.String() {
+					current = i
+					break
+				}
+			}
+			if idx, changed := Combo(label, current, f.options); changed {
+				v.SetString(f.options[idx])
+			}
+		} else if nv, changed := Input(label, v.String()); changed {
+			v.SetString(nv)
+		}
+
+	case reflect.Slice:
+		col, _ := v.Interface().([]float32)
+		if len(col) == 3 {
+			if r, g, b, changed := ColorEdit3(label, col[0], col[1], col[2]); changed {
+				col[0], col[1], col[2] = r, g, b
+			}
+		} else if len(col) == 4 {
+			if r, g, b, a, changed := ColorEdit4(label, col[0], col[1], col[2], col[3]); changed {
+				col[0], col[1], col[2], col[3] = r, g, b, a
+			}
+		}
+	}
+
+	if f.desc != "" {
+		imgui.SetItemTooltip(T(f.desc))
+	}
+}
+
+// buildSettingsSections walks a struct type and groups its fields into sections.
+// top-level scalar fields go into a "General" section; each top-level struct field
+// becomes its own section.
+func buildSettingsSections(t reflect.Type) []*settingsSection {
+	root := &settingsSection{name: settingsGeneralName}
+	collectSettingsFields(root, t, nil)
+
+	var sections []*settingsSection
+	if len(root.fields) > 0 {
+		sections = append(sections, &settingsSection{name: root.name, fields: root.fields})
+	}
+	return append(sections, root.children...)
+}
+
+func collectSettingsFields(section *settingsSection, t reflect.Type, prefix []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("settings") == "-" {
+			continue
+		}
+		index := append(append([]int{}, prefix...), i)
+
+		if sf.Type.Kind() == reflect.Struct {
+			child := &settingsSection{
+				name:  settingsLabel(sf),
+				desc:  sf.Tag.Get("desc"),
+				index: index,
+			}
+			collectSettingsFields(child, sf.Type, index)
+			if len(child.fields) > 0 || len(child.children) > 0 {
+				section.children = append(section.children, child)
+			}
+			continue
+		}
+
+		if f, ok := newSettingsField(sf, index); ok {
+			section.fields = append(section.fields, f)
+		}
+	}
+}
+
+// newSettingsField builds a field descriptor, returning false for unsupported types.
+func newSettingsField(sf reflect.StructField, index []int) (settingsField, bool) {
+	f := settingsField{
+		name:   sf.Name,
+		label:  settingsLabel(sf),
+		desc:   sf.Tag.Get("desc"),
+		index:  index,
+		kind:   sf.Type.Kind(),
+		widget: sf.Tag.Get("widget"),
+		format: sf.Tag.Get("format"),
+	}
+	if f.format == "" {
+		f.format = "%.3f"
+	}
+	if opts := sf.Tag.Get("options"); opts != "" {
+		for _, opt := range strings.Split(opts, ",") {
+			f.options = append(f.options, strings.TrimSpace(opt))
+		}
+	}
+	minTag, maxTag := sf.Tag.Get("min"), sf.Tag.Get("max")
+	if minTag != "" && maxTag != "" {
+		minV, err1 := strconv.ParseFloat(minTag, 64)
+		maxV, err2 := strconv.ParseFloat(maxTag, 64)
+		if err1 == nil && err2 == nil {
+			f.min, f.max, f.ranged = minV, maxV, true
+		}
+	}
+
+	switch f.kind {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return f, true
+	case reflect.Slice:
+		return f, f.widget == "color" && sf.Type.Elem().Kind() == reflect.Float32
+	}
+	return f, false
+}
+
+// settingsLabel returns the label tag, or a title-cased version of the field name.
+func settingsLabel(sf reflect.StructField) string {
+	if label := sf.Tag.Get("label"); label != "" {
+		return label
+	}
+	return splitCamelCase(sf.Name)
+}
+
+// splitCamelCase turns "MaxFrameRate" into "Max Frame Rate", keeping acronyms together.
+func splitCamelCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// deepCopyValue returns a copy of v that shares no slices, maps or pointers with it.
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(deepCopyValue(v.Elem()))
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v) // copies unexported fields shallowly
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+	}
+	return v
+}
@@ -0,0 +1,383 @@
+package dfx
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// settings tree layout constants
+const (
+	settingsTreeGutter = 6 // left margin holding the modified markers
+	settingsTreeMarker = 3 // width of a modified marker
+)
+
+// SettingsTree is a Settings panel laid out as one tree of collapsible sections instead of
+// categories, in the style of modern preferences windows:
+//
+//   - the search box filters rows by label, description or field name, expanding every
+//     section holding a match (Ctrl+F focuses it)
+//   - Up and Down move a cursor through the rows, Left and Right collapse and expand
+//     sections, Enter edits the field under the cursor, and Delete resets it
+//   - a field that differs from Defaults (or, without Defaults, from Config) is marked,
+//     as is each section holding one, and has a button resetting just that field
+//
+// like Settings, edits are made to a working copy and kept by Apply.
+type SettingsTree struct {
+	Settings
+	Search     string // the search box text
+	SearchHint string // hint shown in an empty search box (default: "Search settings")
+
+	collapsed       map[string]bool // sections collapsed by the user, by row key
+	searchCollapsed map[string]bool // sections collapsed while searching; cleared when the search changes
+	lastSearch      string
+	cursor          string // row key under the keyboard cursor
+	scrollToCursor  bool
+	editCursor      bool
+	focusSearch     bool
+}
+
+// settingsTreeRow is a displayed row of a SettingsTree: a section header or a field.
+type settingsTreeRow struct {
+	key     string // path of section names, and the field name, joined by "/"
+	parent  string // key of the enclosing section ("" at the top)
+	depth   int
+	section *settingsSection // set for header rows
+	field   *settingsField   // set for field rows
+}
+
+// NewSettingsTree creates a tree settings panel for config, which must be a pointer to a
+// struct. defaults may be nil.
+func NewSettingsTree(config, defaults interface{}) *SettingsTree {
+	s := &SettingsTree{
+		collapsed:       make(map[string]bool),
+		searchCollapsed: make(map[string]bool),
+	}
+	s.Container = Container{Visible: true}
+	s.Config = config
+	s.Defaults = defaults
+	s.Revert()
+	return s
+}
+
+// FocusSearch gives the search box keyboard focus.
+func (s *SettingsTree) FocusSearch() {
+	s.focusSearch = true
+}
+
+// Draw renders the search box, the tree and the Apply, Revert and Reset to Defaults buttons.
+func (s *SettingsTree) Draw(state *State) {
+	if !s.Visible {
+		return
+	}
+	if !s.draft.IsValid() {
+		imgui.TextDisabled(T("dfx.settings.notStruct"))
+		return
+	}
+	imgui.PushIDStr(componentID("settingsTree", s))
+	defer imgui.PopID()
+
+	s.drawSearch()
+
+	footerHeight := imgui.FrameHeightWithSpacing() + imgui.CurrentStyle().ItemSpacing().Y
+	bodyHeight := imgui.ContentRegionAvail().Y - footerHeight
+	if imgui.BeginChildStrV("##settingsTree", imgui.Vec2{X: 0, Y: bodyHeight}, imgui.ChildFlagsBorders, 0) {
+		if imgui.IsWindowFocused() && !imgui.IsAnyItemActive() {
+			s.handleKeys(s.rows())
+		}
+		s.drawRows(s.rows())
+	}
+	imgui.EndChild()
+
+	s.drawFooter()
+
+	drawContainerExtensions(&s.Container, state)
+}
+
+// drawSearch renders the search box, and reopens the sections collapsed during the
+// previous search when the text changes.
+func (s *SettingsTree) drawSearch() {
+	if s.focusSearch {
+		imgui.SetKeyboardFocusHere()
+		s.focusSearch = false
+	}
+	hint := s.SearchHint
+	if hint == "" {
+		hint = T("dfx.settings.search")
+	}
+	imgui.SetNextItemWidth(-1)
+	imgui.InputTextWithHint("##search", hint, &s.Search, imgui.InputTextFlagsNone, nil)
+	if s.Search != s.lastSearch {
+		s.lastSearch = s.Search
+		clear(s.searchCollapsed)
+	}
+}
+
+// rows returns the displayed rows: every section and field when there is no search, and
+// otherwise the fields matching it, or in a section matching it, under their sections.
+func (s *SettingsTree) rows() []settingsTreeRow {
+	query := strings.ToLower(strings.TrimSpace(s.Search))
+	var rows []settingsTreeRow
+	for _, section := range s.sections {
+		rows = s.appendSectionRows(rows, section, "", 0, query, false)
+	}
+	return rows
+}
+
+// appendSectionRows appends the header of section and, when it is open, its rows. matched
+// is set when an enclosing section matched the search, which shows all of its fields.
+func (s *SettingsTree) appendSectionRows(rows []settingsTreeRow, section *settingsSection, parent string, depth int, query string, matched bool) []settingsTreeRow {
+	key := section.name
+	if parent != "" {
+		key = parent + "/" + section.name
+	}
+	matched = matched || settingsMatches(query, section.name, section.desc)
+
+	var body []settingsTreeRow
+	for i := range section.fields {
+		f := &section.fields[i]
+		if query == "" || matched || settingsMatches(query, f.label, f.desc, f.name) {
+			body = append(body, settingsTreeRow{key: key + "/" + f.name, parent: key, depth: depth + 1, field: f})
+		}
+	}
+	for _, child := range section.children {
+		body = s.appendSectionRows(body, child, key, depth+1, query, matched)
+	}
+	if query != "" && !matched && len(body) == 0 {
+		return rows
+	}
+
+	rows = append(rows, settingsTreeRow{key: key, parent: parent, depth: depth, section: section})
+	if s.open(key) {
+		rows = append(rows, body...)
+	}
+	return rows
+}
+
+// settingsMatches reports whether any of texts, translated or not, contains query, which
+// is lower case. everything matches an empty query.
+func settingsMatches(query string, texts ...string) bool {
+	if query == "" {
+		return true
+	}
+	for _, text := range texts {
+		if text == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(T(text)), query) || strings.Contains(strings.ToLower(text), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// open reports whether the section with key shows its rows. searching expands every
+// section until it is collapsed during that search.
+func (s *SettingsTree) open(key string) bool {
+	if strings.TrimSpace(s.Search) != "" {
+		return !s.searchCollapsed[key]
+	}
+	return !s.collapsed[key]
+}
+
+// setOpen expands or collapses the section with key.
+func (s *SettingsTree) setOpen(key string, open bool) {
+	collapsed := s.collapsed
+	if strings.TrimSpace(s.Search) != "" {
+		collapsed = s.searchCollapsed
+	}
+	if open {
+		delete(collapsed, key)
+	} else {
+		collapsed[key] = true
+	}
+}
+
+// baseline returns the values a field is compared against to mark it modified: Defaults,
+// or Config when there are none.
+func (s *SettingsTree) baseline() reflect.Value {
+	if s.Defaults != nil {
+		d := reflect.ValueOf(s.Defaults)
+		if d.Kind() == reflect.Ptr {
+			d = d.Elem()
+		}
+		if d.Type() == s.draft.Type() {
+			return d
+		}
+	}
+	return reflect.ValueOf(s.Config).Elem()
+}
+
+// fieldModified reports whether the working copy of f differs from the baseline.
+func (s *SettingsTree) fieldModified(f *settingsField) bool {
+	return !reflect.DeepEqual(s.draft.FieldByIndex(f.index).Interface(), s.baseline().FieldByIndex(f.index).Interface())
+}
+
+// sectionModified reports whether any field in section, or in the sections it holds, is
+// modified.
+func (s *SettingsTree) sectionModified(section *settingsSection) bool {
+	for i := range section.fields {
+		if s.fieldModified(&section.fields[i]) {
+			return true
+		}
+	}
+	for _, child := range section.children {
+		if s.sectionModified(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// resetField loads the baseline value of f into the working copy.
+func (s *SettingsTree) resetField(f *settingsField) {
+	s.draft.FieldByIndex(f.index).Set(deepCopyValue(s.baseline().FieldByIndex(f.index)))
+}
+
+// handleKeys moves the cursor through rows and acts on the row under it.
+func (s *SettingsTree) handleKeys(rows []settingsTreeRow) {
+	io := imgui.CurrentIO()
+	if io.KeyCtrl() && imgui.IsKeyPressedBool(imgui.KeyF) {
+		s.FocusSearch()
+		return
+	}
+	if len(rows) == 0 {
+		return
+	}
+	index := settingsTreeRowIndex(rows, s.cursor)
+	if index < 0 {
+		if imgui.IsKeyPressedBool(imgui.KeyDownArrow) || imgui.IsKeyPressedBool(imgui.KeyUpArrow) {
+			s.moveCursor(rows, 0)
+		}
+		return
+	}
+	row := rows[index]
+
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyDownArrow):
+		s.moveCursor(rows, index+1)
+	case imgui.IsKeyPressedBool(imgui.KeyUpArrow):
+		s.moveCursor(rows, index-1)
+	case imgui.IsKeyPressedBool(imgui.KeyHome):
+		s.moveCursor(rows, 0)
+	case imgui.IsKeyPressedBool(imgui.KeyEnd):
+		s.moveCursor(rows, len(rows)-1)
+	case imgui.IsKeyPressedBool(imgui.KeyLeftArrow):
+		if row.section != nil && s.open(row.key) {
+			s.setOpen(row.key, false)
+		} else if row.parent != "" {
+			s.moveCursor(rows, settingsTreeRowIndex(rows, row.parent))
+		}
+	case imgui.IsKeyPressedBool(imgui.KeyRightArrow):
+		if row.section != nil {
+			if !s.open(row.key) {
+				s.setOpen(row.key, true)
+			} else {
+				s.moveCursor(rows, index+1)
+			}
+		}
+	case imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter):
+		if row.section != nil {
+			s.setOpen(row.key, !s.open(row.key))
+		} else {
+			s.editCursor = true
+		}
+	case imgui.IsKeyPressedBool(imgui.KeyDelete):
+		if row.field != nil {
+			s.resetField(row.field)
+		}
+	}
+}
+
+// moveCursor puts the cursor on the row at index, clamped to rows, and scrolls it into view.
+func (s *SettingsTree) moveCursor(rows []settingsTreeRow, index int) {
+	if len(rows) == 0 {
+		return
+	}
+	s.cursor = rows[max(0, min(index, len(rows)-1))].key
+	s.scrollToCursor = true
+}
+
+// settingsTreeRowIndex returns the index of the row with key, or -1.
+func settingsTreeRowIndex(rows []settingsTreeRow, key string) int {
+	for i, row := range rows {
+		if row.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawRows draws rows, indented by depth, with modified markers in the left gutter and the
+// cursor row outlined.
+func (s *SettingsTree) drawRows(rows []settingsTreeRow) {
+	style := imgui.CurrentStyle()
+	accent := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	dl := imgui.WindowDrawList()
+
+	for _, row := range rows {
+		imgui.PushIDStr(row.key)
+		start := imgui.CursorScreenPos()
+		width := imgui.ContentRegionAvail().X
+		indent := settingsTreeGutter + float32(row.depth)*style.IndentSpacing()
+		imgui.IndentV(indent)
+
+		var modified bool
+		if row.section != nil {
+			modified = s.sectionModified(row.section)
+			s.drawHeader(row)
+		} else {
+			modified = s.drawFieldRow(row)
+		}
+
+		imgui.UnindentV(indent)
+		end := imgui.Vec2{X: start.X + width, Y: imgui.ItemRectMax().Y}
+		if modified {
+			dl.AddRectFilled(start, imgui.Vec2{X: start.X + settingsTreeMarker, Y: end.Y}, accent)
+		}
+		if imgui.IsWindowHovered() && imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && imgui.IsMouseHoveringRect(start, end) {
+			s.cursor = row.key
+		}
+		if row.key == s.cursor {
+			dl.AddRect(start, end, imgui.ColorConvertFloat4ToU32(style.Colors()[imgui.ColNavCursor]))
+			if s.scrollToCursor {
+				imgui.SetScrollHereYV(0.5)
+				s.scrollToCursor = false
+			}
+		}
+		imgui.PopID()
+	}
+}
+
+// drawHeader draws a section header row, which expands and collapses the section.
+func (s *SettingsTree) drawHeader(row settingsTreeRow) {
+	open := s.open(row.key)
+	imgui.SetNextItemOpen(open)
+	flags := imgui.TreeNodeFlagsNoTreePushOnOpen | imgui.TreeNodeFlagsSpanAvailWidth
+	if nowOpen := imgui.TreeNodeExStrV(T(row.section.name)+"##header", flags); nowOpen != open {
+		s.setOpen(row.key, nowOpen)
+	}
+	if row.section.desc != "" {
+		imgui.SetItemTooltip(T(row.section.desc))
+	}
+}
+
+// drawFieldRow draws a field row, with a reset button when the field is modified.
+// returns whether it is modified.
+func (s *SettingsTree) drawFieldRow(row settingsTreeRow) bool {
+	if s.editCursor && row.key == s.cursor {
+		imgui.SetKeyboardFocusHere()
+		s.editCursor = false
+	}
+	s.drawField(*row.field)
+	if !s.fieldModified(row.field) {
+		return false
+	}
+	imgui.SameLine()
+	if IconButton(fonts.ICON_UNDO, "", T("dfx.settings.resetRow")) {
+		s.resetField(row.field)
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package dfx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func settingsTreeKeys(rows []settingsTreeRow) []string {
+	var keys []string
+	for _, row := range rows {
+		keys = append(keys, row.key)
+	}
+	return keys
+}
+
+func TestSettingsTree_Rows(t *testing.T) {
+	cfg := settingsTestConfig{}
+	s := NewSettingsTree(&cfg, nil)
+
+	all := []string{
+		"General", "General/Name", "General/MaxRetries",
+		"Audio", "Audio/Volume", "Audio/Device",
+		"Display", "Display/Tint", "Display/Scaled", "Display/Scaled/Factor",
+	}
+	if keys := settingsTreeKeys(s.rows()); !reflect.DeepEqual(keys, all) {
+		t.Fatalf("expected '%v', got '%v'", all, keys)
+	}
+
+	s.setOpen("Display/Scaled", false)
+	s.setOpen("Audio", false)
+	expected := []string{"General", "General/Name", "General/MaxRetries", "Audio", "Display", "Display/Tint", "Display/Scaled"}
+	if keys := settingsTreeKeys(s.rows()); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected collapsed '%v', got '%v'", expected, keys)
+	}
+
+	// searching expands matching sections, and matches labels and descriptions
+	s.Search = "output"
+	expected = []string{"Audio", "Audio/Volume", "Audio/Device"} // the section's desc matches
+	if keys := settingsTreeKeys(s.rows()); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected '%v', got '%v'", expected, keys)
+	}
+	s.Search = "FACTOR"
+	expected = []string{"Display", "Display/Scaled", "Display/Scaled/Factor"}
+	if keys := settingsTreeKeys(s.rows()); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected '%v', got '%v'", expected, keys)
+	}
+
+	// collapsing during a search leaves the sections collapsed without one alone
+	s.setOpen("Display", false)
+	if keys := settingsTreeKeys(s.rows()); !reflect.DeepEqual(keys, []string{"Display"}) {
+		t.Fatalf("expected collapsed search result, got '%v'", keys)
+	}
+	s.Search = ""
+	if !s.open("Display") || s.open("Audio") {
+		t.Fatalf("expected collapse state kept apart from the search")
+	}
+
+	s.Search = "nothing"
+	if rows := s.rows(); len(rows) != 0 {
+		t.Fatalf("expected no rows, got '%v'", settingsTreeKeys(rows))
+	}
+}
+
+func TestSettingsTree_ModifiedReset(t *testing.T) {
+	defaults := settingsTestConfig{Name: "default", Audio: settingsTestAudio{Volume: 0.5}}
+	cfg := settingsTestConfig{Name: "current", Audio: settingsTestAudio{Volume: 0.5}}
+	s := NewSettingsTree(&cfg, &defaults)
+
+	general, audio := s.sections[0], s.sections[1]
+	name, volume := &general.fields[0], &audio.fields[0]
+	if !s.fieldModified(name) || s.fieldModified(volume) {
+		t.Fatalf("expected only the name modified from defaults")
+	}
+	if !s.sectionModified(general) || s.sectionModified(audio) {
+		t.Fatalf("expected only the general section modified")
+	}
+
+	s.resetField(name)
+	if s.fieldModified(name) || s.draft.FieldByName("Name").String() != "default" {
+		t.Fatalf("expected name reset to the default")
+	}
+	if cfg.Name != "current" {
+		t.Fatalf("expected reset made to the working copy, got '%v'", cfg.Name)
+	}
+
+	// without defaults, fields are compared with the live config
+	s = NewSettingsTree(&cfg, nil)
+	s.draft.FieldByName("Audio").FieldByName("Volume").SetFloat(1)
+	volume = &s.sections[1].fields[0]
+	if !s.fieldModified(volume) {
+		t.Fatalf("expected edited volume modified")
+	}
+	s.resetField(volume)
+	if s.Dirty() {
+		t.Fatalf("expected clean settings after reset")
+	}
+}
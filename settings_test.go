@@ -0,0 +1,147 @@
+package dfx
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+type settingsTestAudio struct {
+	Volume float32 `label:"Output Volume" desc:"master level" min:"0" max:"1"`
+	Device string  `options:"default, usb, hdmi"`
+}
+
+type settingsTestDisplay struct {
+	Tint   []float32 `widget:"color"`
+	Scaled struct {
+		Factor float64
+	}
+}
+
+type settingsTestConfig struct {
+	Name       string
+	MaxRetries int
+	Secret     string `settings:"-"`
+	Tags       []string
+	Audio      settingsTestAudio `desc:"audio output"`
+	Display    settingsTestDisplay
+	hidden     int
+}
+
+func TestBuildSettingsSections(t *testing.T) {
+	sections := buildSettingsSections(reflect.TypeOf(settingsTestConfig{}))
+	if len(sections) != 3 {
+		t.Fatalf("expected 3 sections, got %d", len(sections))
+	}
+
+	general := sections[0]
+	if general.name != settingsGeneralName {
+		t.Fatalf("expected first section '%v', got '%v'", settingsGeneralName, general.name)
+	}
+	// Secret is skipped by tag, Tags is unsupported, hidden is unexported
+	if len(general.fields) != 2 || general.fields[1].label != "Max Retries" {
+		t.Fatalf("expected fields 'Name' and 'Max Retries', got '%v'", general.fields)
+	}
+
+	audio := sections[1]
+	if audio.name != "Audio" || audio.desc != "audio output" {
+		t.Fatalf("expected audio section with description, got '%v' '%v'", audio.name, audio.desc)
+	}
+	volume := audio.fields[0]
+	if volume.label != "Output Volume" || !volume.ranged || volume.max != 1 {
+		t.Fatalf("expected ranged 'Output Volume' field, got '%+v'", volume)
+	}
+	device := audio.fields[1]
+	if !reflect.DeepEqual(device.options, []string{"default", "usb", "hdmi"}) {
+		t.Fatalf("expected trimmed options, got '%v'", device.options)
+	}
+
+	display := sections[2]
+	if len(display.fields) != 1 || len(display.children) != 1 || display.children[0].fields[0].name != "Factor" {
+		t.Fatalf("expected color field and nested 'Scaled' section, got '%+v'", display)
+	}
+}
+
+func TestSplitCamelCase(t *testing.T) {
+	for in, expected := range map[string]string{
+		"Name":         "Name",
+		"MaxFrameRate": "Max Frame Rate",
+		"HTTPPort":     "HTTP Port",
+		"UseGPU":       "Use GPU",
+	} {
+		if got := splitCamelCase(in); got != expected {
+			t.Fatalf("expected '%v', got '%v'", expected, got)
+		}
+	}
+}
+
+func TestSettings_ApplyRevertReset(t *testing.T) {
+	defaults := settingsTestConfig{Name: "default", Tags: []string{"a"}}
+	cfg := settingsTestConfig{Name: "current", Tags: []string{"b"}}
+	s := NewSettings(&cfg, &defaults)
+	s.Path = filepath.Join(t.TempDir(), "settings.json")
+
+	if s.Dirty() {
+		t.Fatalf("expected clean settings after creation")
+	}
+
+	// edits to the working copy don't touch the live config
+	s.draft.FieldByName("Name").SetString("edited")
+	s.draft.FieldByName("Tags").Index(0).SetString("edited")
+	if cfg.Name != "current" || cfg.Tags[0] != "b" {
+		t.Fatalf("expected live config untouched, got '%v' '%v'", cfg.Name, cfg.Tags)
+	}
+	if !s.Dirty() {
+		t.Fatalf("expected dirty settings after edit")
+	}
+
+	s.Revert()
+	if s.Dirty() {
+		t.Fatalf("expected clean settings after revert")
+	}
+
+	s.ResetToDefaults()
+	applied := false
+	s.OnApply = func() { applied = true }
+	if err := s.Apply(); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+	if !applied || cfg.Name != "default" || cfg.Tags[0] != "a" {
+		t.Fatalf("expected defaults applied, got '%v' '%v'", cfg.Name, cfg.Tags)
+	}
+	if defaults.Tags[0] != "a" {
+		t.Fatalf("expected defaults untouched")
+	}
+
+	var saved settingsTestConfig
+	if err := LoadConfig(s.Path, &saved); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if saved.Name != "default" {
+		t.Fatalf("expected saved name 'default', got '%v'", saved.Name)
+	}
+}
+
+func TestSettingsDataType(t *testing.T) {
+	// each integer kind is edited at its own width, so 64-bit values are not truncated
+	for kind, expected := range map[reflect.Kind]imgui.DataType{
+		reflect.Int8:   imgui.DataTypeS8,
+		reflect.Int16:  imgui.DataTypeS16,
+		reflect.Int32:  imgui.DataTypeS32,
+		reflect.Int64:  imgui.DataTypeS64,
+		reflect.Uint8:  imgui.DataTypeU8,
+		reflect.Uint16: imgui.DataTypeU16,
+		reflect.Uint32: imgui.DataTypeU32,
+		reflect.Uint64: imgui.DataTypeU64,
+	} {
+		if got := settingsDataType(kind); got != expected {
+			t.Fatalf("expected '%v' for '%v', got '%v'", expected, kind, got)
+		}
+	}
+	if strconv.IntSize == 64 && (settingsDataType(reflect.Int) != imgui.DataTypeS64 || settingsDataType(reflect.Uint) != imgui.DataTypeU64) {
+		t.Fatalf("expected int and uint to be edited as 64-bit values")
+	}
+}
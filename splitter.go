@@ -0,0 +1,275 @@
+package dfx
+
+import "github.com/AllenDang/cimgui-go/imgui"
+
+// SplitterOrientation selects how a Splitter arranges its panes.
+type SplitterOrientation int
+
+const (
+	SplitterHorizontal SplitterOrientation = iota // panes side by side, vertical divider
+	SplitterVertical                              // panes stacked, horizontal divider
+)
+
+// SplitterSizing selects which size a Splitter preserves when its space changes.
+type SplitterSizing int
+
+const (
+	SplitterSizeRatio  SplitterSizing = iota // first pane keeps Ratio of the available space
+	SplitterSizeFirst                        // first pane keeps Pixels; second pane absorbs changes
+	SplitterSizeSecond                       // second pane keeps Pixels; first pane absorbs changes
+)
+
+// Splitter constants
+const (
+	SplitterDefaultDividerSize = 6
+	SplitterDefaultRatio       = 0.5
+)
+
+// Splitter is a two-pane component separated by a draggable divider. it is a lighter
+// alternative to MultiGrid for simple two-pane layouts, and splitters nest: either pane
+// may itself be a Splitter.
+type Splitter struct {
+	Container
+	First       Component                   // left (horizontal) or top (vertical) pane
+	Second      Component                   // right (horizontal) or bottom (vertical) pane
+	Orientation SplitterOrientation         // pane arrangement
+	Sizing      SplitterSizing              // which size is preserved when the splitter is resized
+	Ratio       float32                     // first pane fraction for SplitterSizeRatio
+	Pixels      float32                     // fixed pane size for SplitterSizeFirst/SplitterSizeSecond
+	MinFirst    float32                     // minimum first pane size
+	MinSecond   float32                     // minimum second pane size
+	DividerSize float32                     // thickness of the divider
+	OnResize    func(first, second float32) // optional callback when the divider is dragged
+}
+
+// NewSplitter creates a splitter with the panes sharing the space equally.
+func NewSplitter(orientation SplitterOrientation, first, second Component) *Splitter {
+	return &Splitter{
+		Container:   Container{Visible: true},
+		First:       first,
+		Second:      second,
+		Orientation: orientation,
+		Ratio:       SplitterDefaultRatio,
+		DividerSize: SplitterDefaultDividerSize,
+	}
+}
+
+// SetRatio switches to ratio sizing with the first pane taking ratio of the space.
+func (s *Splitter) SetRatio(ratio float32) {
+	s.Sizing = SplitterSizeRatio
+	s.Ratio = clamp(ratio, 0, 1)
+}
+
+// SetFirstSize switches to fixed sizing of the first pane.
+func (s *Splitter) SetFirstSize(pixels float32) {
+	s.Sizing = SplitterSizeFirst
+	s.Pixels = pixels
+}
+
+// SetSecondSize switches to fixed sizing of the second pane.
+func (s *Splitter) SetSecondSize(pixels float32) {
+	s.Sizing = SplitterSizeSecond
+	s.Pixels = pixels
+}
+
+// Draw implements Component.
+func (s *Splitter) Draw(state *State) {
+	if !s.Visible {
+		return
+	}
+
+	horizontal := s.Orientation == SplitterHorizontal
+	total := state.Size.Y
+	if horizontal {
+		total = state.Size.X
+	}
+	divider := s.dividerSize()
+	first, second := s.paneSizes(total)
+
+	imgui.PushIDStr(componentID("splitter", s))
+	imgui.PushStyleVarVec2(imgui.StyleVarItemSpacing, imgui.Vec2{})
+
+	s.drawPane("##first", s.First, s.paneVec(first, state.Size), state)
+	if horizontal {
+		imgui.SameLine()
+	}
+	s.drawDivider(s.paneVec(divider, state.Size), first, total)
+	if horizontal {
+		imgui.SameLine()
+	}
+	s.drawPane("##second", s.Second, s.paneVec(second, state.Size), state)
+
+	imgui.PopStyleVar()
+	imgui.PopID()
+
+	drawContainerExtensions(&s.Container, state)
+}
+
+// drawPane renders one pane in a child window.
+func (s *Splitter) drawPane(id string, component Component, size imgui.Vec2, state *State) {
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{})
+	if imgui.BeginChildStrV(id, size, 0, imgui.WindowFlagsNoScrollbar) {
+		if component != nil {
+			DrawChild(component, state.Child(size, imgui.Vec2{}).WithParent(s))
+		}
+	}
+	imgui.EndChild()
+	imgui.PopStyleVar()
+}
+
+// drawDivider renders the divider and applies drags to it.
+func (s *Splitter) drawDivider(size imgui.Vec2, first, total float32) {
+	imgui.InvisibleButton("##divider", size)
+
+	cursor := imgui.MouseCursorResizeEW
+	if s.Orientation == SplitterVertical {
+		cursor = imgui.MouseCursorResizeNS
+	}
+	if imgui.IsItemHovered() || imgui.IsItemActive() {
+		imgui.SetMouseCursor(cursor)
+
+		color := imgui.CurrentStyle().Colors()[imgui.ColButtonHovered]
+		if imgui.IsItemActive() {
+			color = imgui.CurrentStyle().Colors()[imgui.ColButtonActive]
+		}
+		min := imgui.ItemRectMin()
+		max := imgui.ItemRectMax()
+		a, b := min, max
+		if s.Orientation == SplitterHorizontal {
+			a.X = (min.X + max.X) / 2
+			b.X = a.X
+		} else {
+			a.Y = (min.Y + max.Y) / 2
+			b.Y = a.Y
+		}
+		imgui.WindowDrawList().AddLine(a, b, imgui.ColorConvertFloat4ToU32(color))
+	}
+
+	if imgui.IsItemActive() {
+		delta := imgui.CurrentIO().MouseDelta()
+		d := delta.X
+		if s.Orientation == SplitterVertical {
+			d = delta.Y
+		}
+		if d != 0 {
+			s.moveDivider(first+d, total)
+		}
+	}
+}
+
+// moveDivider places the divider so the first pane is first pixels wide, honoring the
+// minimum sizes, and stores the result according to the sizing mode.
+func (s *Splitter) moveDivider(first, total float32) {
+	available := total - s.dividerSize()
+	if available <= 0 {
+		return
+	}
+	first = s.clampFirst(first, available)
+	second := available - first
+
+	switch s.Sizing {
+	case SplitterSizeFirst:
+		s.Pixels = first
+	case SplitterSizeSecond:
+		s.Pixels = second
+	default:
+		s.Ratio = first / available
+	}
+	if s.OnResize != nil {
+		s.OnResize(first, second)
+	}
+}
+
+// paneSizes returns the sizes of the two panes along the split axis.
+func (s *Splitter) paneSizes(total float32) (first, second float32) {
+	available := total - s.dividerSize()
+	if available <= 0 {
+		return 0, 0
+	}
+	switch s.Sizing {
+	case SplitterSizeFirst:
+		first = s.Pixels
+	case SplitterSizeSecond:
+		first = available - s.Pixels
+	default:
+		first = available * clamp(s.Ratio, 0, 1)
+	}
+	first = s.clampFirst(first, available)
+	return first, available - first
+}
+
+// clampFirst limits the first pane size so both panes respect their minimums. when the
+// minimums cannot both be met, the first pane's minimum wins.
+func (s *Splitter) clampFirst(first, available float32) float32 {
+	if max := available - s.MinSecond; first > max {
+		first = max
+	}
+	if first < s.MinFirst {
+		first = s.MinFirst
+	}
+	return clamp(first, 0, available)
+}
+
+// paneVec converts a size along the split axis into a pane size.
+func (s *Splitter) paneVec(size float32, full imgui.Vec2) imgui.Vec2 {
+	if s.Orientation == SplitterHorizontal {
+		return imgui.Vec2{X: size, Y: full.Y}
+	}
+	return imgui.Vec2{X: full.X, Y: size}
+}
+
+func (s *Splitter) dividerSize() float32 {
+	if s.DividerSize > 0 {
+		return s.DividerSize
+	}
+	return SplitterDefaultDividerSize
+}
+
+// CaptureState implements StatefulComponent, including the state of stateful panes.
+func (s *Splitter) CaptureState() map[string]any {
+	state := map[string]any{"ratio": float64(s.Ratio), "pixels": float64(s.Pixels)}
+	if first, ok := s.First.(StatefulComponent); ok {
+		state["first"] = first.CaptureState()
+	}
+	if second, ok := s.Second.(StatefulComponent); ok {
+		state["second"] = second.CaptureState()
+	}
+	return state
+}
+
+// RestoreState implements StatefulComponent.
+func (s *Splitter) RestoreState(state map[string]any) {
+	if ratio, ok := stateFloat(state["ratio"]); ok {
+		s.Ratio = float32(ratio)
+	}
+	if pixels, ok := stateFloat(state["pixels"]); ok {
+		s.Pixels = float32(pixels)
+	}
+	if first, ok := s.First.(StatefulComponent); ok {
+		if m, ok := state["first"].(map[string]any); ok {
+			first.RestoreState(m)
+		}
+	}
+	if second, ok := s.Second.(StatefulComponent); ok {
+		if m, ok := state["second"].(map[string]any); ok {
+			second.RestoreState(m)
+		}
+	}
+}
+
+// LocalActions returns splitter-local actions.
+func (s *Splitter) LocalActions() *ActionRegistry {
+	return s.Container.Actions()
+}
+
+// ChildActions returns the panes for action traversal.
+func (s *Splitter) ChildActions() []Component {
+	var children []Component
+	if s.First != nil {
+		children = append(children, s.First)
+	}
+	if s.Second != nil {
+		children = append(children, s.Second)
+	}
+	return children
+}
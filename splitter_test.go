@@ -0,0 +1,75 @@
+package dfx
+
+import "testing"
+
+func TestSplitter_RatioSizing(t *testing.T) {
+	s := NewSplitter(SplitterHorizontal, nil, nil)
+	s.DividerSize = 10
+	s.SetRatio(0.25)
+
+	first, second := s.paneSizes(410)
+	if first != 100 || second != 300 {
+		t.Fatalf("expected '100/300', got '%v/%v'", first, second)
+	}
+
+	// ratio sizing scales with the available space
+	first, second = s.paneSizes(810)
+	if first != 200 || second != 600 {
+		t.Fatalf("expected '200/600', got '%v/%v'", first, second)
+	}
+}
+
+func TestSplitter_PixelSizing(t *testing.T) {
+	s := NewSplitter(SplitterVertical, nil, nil)
+	s.DividerSize = 10
+	s.SetSecondSize(120)
+
+	first, second := s.paneSizes(510)
+	if first != 380 || second != 120 {
+		t.Fatalf("expected '380/120', got '%v/%v'", first, second)
+	}
+
+	s.SetFirstSize(50)
+	first, second = s.paneSizes(510)
+	if first != 50 || second != 450 {
+		t.Fatalf("expected '50/450', got '%v/%v'", first, second)
+	}
+}
+
+func TestSplitter_MinSizes(t *testing.T) {
+	s := NewSplitter(SplitterHorizontal, nil, nil)
+	s.DividerSize = 10
+	s.MinFirst = 80
+	s.MinSecond = 150
+
+	s.moveDivider(20, 410)
+	if first, _ := s.paneSizes(410); first != 80 {
+		t.Fatalf("expected first pane clamped to '80', got '%v'", first)
+	}
+
+	s.moveDivider(390, 410)
+	if _, second := s.paneSizes(410); second != 150 {
+		t.Fatalf("expected second pane clamped to '150', got '%v'", second)
+	}
+}
+
+func TestSplitter_StateRoundTrip(t *testing.T) {
+	inner := NewSplitter(SplitterVertical, nil, nil)
+	outer := NewSplitter(SplitterHorizontal, nil, inner)
+	outer.SetRatio(0.3)
+	inner.SetSecondSize(200)
+
+	state := outer.CaptureState()
+
+	restoredInner := NewSplitter(SplitterVertical, nil, nil)
+	restoredInner.Sizing = SplitterSizeSecond
+	restored := NewSplitter(SplitterHorizontal, nil, restoredInner)
+	restored.RestoreState(state)
+
+	if restored.Ratio != 0.3 {
+		t.Fatalf("expected ratio '0.3', got '%v'", restored.Ratio)
+	}
+	if restoredInner.Pixels != 200 {
+		t.Fatalf("expected nested pixels '200', got '%v'", restoredInner.Pixels)
+	}
+}
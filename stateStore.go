@@ -0,0 +1,210 @@
+package dfx
+
+import (
+	"time"
+)
+
+// stateSyncInterval is how often the app captures the state of bound components into
+// its StateStore.
+const stateSyncInterval = 500 * time.Millisecond
+
+// StateStore is a small key/value store of UI state scoped by component id, saved with
+// the app's config. components reach it through State.Store to keep values such as
+// column widths:
+//
+//	widths := state.Store.GetFloats("mixer.table", "widths")
+//	state.Store.Set("mixer.table", "widths", widths)
+//
+// StatefulComponents drawn with a Container.StateID are bound to the store under that id:
+// their state is restored the first time they are drawn and captured periodically, so
+// ScrollArea positions, TreeView expansion and the like persist with no config structs
+// of their own.
+//
+// embed the store in a config struct saved with SaveConfig or a ConfigManager, and set
+// it as Config.StateStore. values must be ones the config codecs can serialize, and come
+// back as the codec decodes them (see StatefulComponent). a nil store ignores Set and
+// returns nothing from Get, so components work without one. the store is only used
+// from the UI thread.
+type StateStore struct {
+	Components map[string]map[string]any // state by component id
+
+	bound    map[string]StatefulComponent
+	lastSync time.Time
+}
+
+// NewStateStore creates an empty store.
+func NewStateStore() *StateStore {
+	return &StateStore{Components: make(map[string]map[string]any)}
+}
+
+// Get returns the value of key for the component id.
+func (s *StateStore) Get(id, key string) (any, bool) {
+	if s == nil {
+		return nil, false
+	}
+	v, found := s.Components[id][key]
+	return v, found
+}
+
+// Set stores the value of key for the component id.
+func (s *StateStore) Set(id, key string, value any) {
+	if s == nil {
+		return
+	}
+	if s.Components == nil {
+		s.Components = make(map[string]map[string]any)
+	}
+	values := s.Components[id]
+	if values == nil {
+		values = make(map[string]any)
+		s.Components[id] = values
+	}
+	values[key] = value
+}
+
+// GetFloat returns the number stored for key, or def.
+func (s *StateStore) GetFloat(id, key string, def float64) float64 {
+	v, _ := s.Get(id, key)
+	if f, ok := stateFloat(v); ok {
+		return f
+	}
+	return def
+}
+
+// GetBool returns the bool stored for key, or def.
+func (s *StateStore) GetBool(id, key string, def bool) bool {
+	v, _ := s.Get(id, key)
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return def
+}
+
+// GetString returns the string stored for key, or def.
+func (s *StateStore) GetString(id, key, def string) string {
+	v, _ := s.Get(id, key)
+	if str, ok := v.(string); ok {
+		return str
+	}
+	return def
+}
+
+// GetStrings returns the list of strings stored for key, or nil.
+func (s *StateStore) GetStrings(id, key string) []string {
+	v, _ := s.Get(id, key)
+	list, _ := stateStrings(v)
+	return list
+}
+
+// GetFloats returns the list of numbers stored for key, or nil.
+func (s *StateStore) GetFloats(id, key string) []float64 {
+	v, _ := s.Get(id, key)
+	list, _ := stateFloats(v)
+	return list
+}
+
+// Delete removes the state of the component id, and unbinds it.
+func (s *StateStore) Delete(id string) {
+	if s == nil {
+		return
+	}
+	delete(s.Components, id)
+	delete(s.bound, id)
+}
+
+// Bind keeps the state of sc in the store under id: state stored for id is restored now,
+// and sc's state is captured on every sync. binding another component to id replaces
+// the previous one. DrawChild binds components that have a Container.StateID.
+func (s *StateStore) Bind(id string, sc StatefulComponent) {
+	if s == nil || id == "" {
+		return
+	}
+	if s.bound[id] == sc {
+		return
+	}
+	if s.bound == nil {
+		s.bound = make(map[string]StatefulComponent)
+	}
+	s.bound[id] = sc
+	if state, found := s.Components[id]; found {
+		sc.RestoreState(state)
+	}
+}
+
+// Unbind captures the state of the component bound to id a last time and stops
+// following it.
+func (s *StateStore) Unbind(id string) {
+	if s == nil {
+		return
+	}
+	if sc, found := s.bound[id]; found {
+		s.capture(id, sc)
+		delete(s.bound, id)
+	}
+}
+
+// Sync captures the state of all bound components. the app syncs periodically and on
+// shutdown; call it before saving the store yourself.
+func (s *StateStore) Sync() {
+	if s == nil {
+		return
+	}
+	for id, sc := range s.bound {
+		s.capture(id, sc)
+	}
+}
+
+// sync captures bound state when stateSyncInterval has passed since the last sync.
+func (s *StateStore) sync(now time.Time) {
+	if s == nil || now.Sub(s.lastSync) < stateSyncInterval {
+		return
+	}
+	s.lastSync = now
+	s.Sync()
+}
+
+func (s *StateStore) capture(id string, sc StatefulComponent) {
+	state := sc.CaptureState()
+	if state == nil {
+		return
+	}
+	if s.Components == nil {
+		s.Components = make(map[string]map[string]any)
+	}
+	s.Components[id] = state
+}
+
+// bindComponentState binds comp to store when it is a StatefulComponent with a StateID.
+func bindComponentState(store *StateStore, comp Component) {
+	sc, ok := comp.(StatefulComponent)
+	if !ok {
+		return
+	}
+	if c, ok := comp.(interface{ stateID() string }); ok {
+		store.Bind(c.stateID(), sc)
+	}
+}
+
+// stateFloats reads a list of numbers from restored component state, which the config
+// codecs decode as []any.
+func stateFloats(v any) ([]float64, bool) {
+	switch list := v.(type) {
+	case []float64:
+		return list, true
+	case []float32:
+		out := make([]float64, len(list))
+		for i, f := range list {
+			out[i] = float64(f)
+		}
+		return out, true
+	case []any:
+		out := make([]float64, 0, len(list))
+		for _, item := range list {
+			if f, ok := stateFloat(item); ok {
+				out = append(out, f)
+			}
+		}
+		return out, true
+	}
+	return nil, false
+}
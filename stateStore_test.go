@@ -0,0 +1,132 @@
+package dfx
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+type statefulProbe struct {
+	Container
+	value string
+}
+
+func (p *statefulProbe) Draw(state *State) {}
+
+func (p *statefulProbe) CaptureState() map[string]any {
+	return map[string]any{"value": p.value}
+}
+
+func (p *statefulProbe) RestoreState(state map[string]any) {
+	if v, ok := state["value"].(string); ok {
+		p.value = v
+	}
+}
+
+func TestStateStore_GetSet(t *testing.T) {
+	store := &StateStore{}
+	store.Set("table", "widths", []any{120.0, 80})
+	store.Set("table", "sorted", true)
+	store.Set("filter", "text", "abc")
+
+	if got := store.GetFloats("table", "widths"); !slices.Equal(got, []float64{120, 80}) {
+		t.Fatalf("expected '%v', got '%v'", []float64{120, 80}, got)
+	}
+	if !store.GetBool("table", "sorted", false) {
+		t.Fatal("expected store.GetBool(\"table\", \"sorted\", false)")
+	}
+	if got := store.GetString("filter", "text", ""); got != "abc" {
+		t.Fatalf("expected '%v', got '%v'", "abc", got)
+	}
+	if got := store.GetFloat("filter", "missing", 2.5); got != 2.5 {
+		t.Fatalf("expected '%v', got '%v'", 2.5, got)
+	}
+
+	store.Delete("table")
+	_, found := store.Get("table", "widths")
+	if found {
+		t.Fatal("unexpected found")
+	}
+}
+
+func TestStateStore_NilIsSafe(t *testing.T) {
+	var store *StateStore
+	store.Set("a", "b", 1)
+	store.Bind("a", &statefulProbe{})
+	store.Sync()
+	if got := store.GetString("a", "b", "def"); got != "def" {
+		t.Fatalf("expected '%v', got '%v'", "def", got)
+	}
+	if got := store.GetStrings("a", "b"); got != nil {
+		t.Fatalf("expected nil, got '%v'", got)
+	}
+}
+
+func TestStateStore_BindRestoresAndSyncCaptures(t *testing.T) {
+	store := NewStateStore()
+	store.Set("panel", "value", "saved")
+	p := &statefulProbe{Container: Container{Visible: true, StateID: "panel"}}
+
+	DrawChild(p, &State{Store: store})
+	if p.value != "saved" {
+		t.Fatalf("expected '%v', got '%v'", "saved", p.value)
+	}
+
+	p.value = "changed"
+	DrawChild(p, &State{Store: store}) // already bound; not restored again
+	if p.value != "changed" {
+		t.Fatalf("expected '%v', got '%v'", "changed", p.value)
+	}
+
+	now := time.Now()
+	store.sync(now)
+	if got := store.GetString("panel", "value", ""); got != "changed" {
+		t.Fatalf("expected '%v', got '%v'", "changed", got)
+	}
+
+	p.value = "later"
+	store.sync(now.Add(stateSyncInterval / 2))
+	if got := store.GetString("panel", "value", ""); got != "changed" {
+		t.Fatalf("expected '%v', got '%v'", "changed", got)
+	}
+	store.Unbind("panel")
+	if got := store.GetString("panel", "value", ""); got != "later" {
+		t.Fatalf("expected '%v', got '%v'", "later", got)
+	}
+}
+
+func TestStateStore_NoStateIDIsNotBound(t *testing.T) {
+	store := NewStateStore()
+	DrawChild(&statefulProbe{Container: Container{Visible: true}}, &State{Store: store})
+	store.Sync()
+	if got := store.Components; len(got) != 0 {
+		t.Fatalf("expected empty, got '%v'", got)
+	}
+}
+
+func TestStateStore_RoundTripsThroughConfig(t *testing.T) {
+	type appConfig struct {
+		UI *StateStore
+	}
+	for _, ext := range []string{".json", ".yaml", ".toml"} {
+		path := filepath.Join(t.TempDir(), "config"+ext)
+		saved := appConfig{UI: NewStateStore()}
+		saved.UI.Set("tree", "expanded", []string{"a", "b"})
+		saved.UI.Set("scroll", "y", 42.0)
+		if err := SaveConfig(path, &saved); err != nil {
+			t.Fatal(ext, err)
+		}
+
+		loaded := appConfig{UI: NewStateStore()}
+		if err := LoadConfig(path, &loaded); err != nil {
+			t.Fatal(ext, err)
+		}
+		if got := loaded.UI.GetStrings("tree", "expanded"); !slices.Equal(got, []string{"a", "b"}) {
+			t.Fatalf("expected '%v', got '%v'", []string{"a", "b"}, got)
+		}
+		if got := loaded.UI.GetFloat("scroll", "y", 0); got != 42.0 {
+			t.Fatalf("expected '%v', got '%v'", 42.0, got)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package dfx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// stringCacheLimit is the most strings a stringCache keeps before it empties.
+const stringCacheLimit = 4096
+
+// stringCache keeps strings formatted while drawing, keyed by everything they are
+// formatted from, so components drawing the same values frame after frame format them
+// once instead of allocating them every frame. it empties when full, so values that keep
+// changing, such as a fader being dragged, cost no more than formatting them directly.
+// UI thread only.
+type stringCache[K comparable] struct {
+	format  func(K) string
+	entries map[K]string
+}
+
+// newStringCache makes a cache formatting missing strings with format, which must depend
+// only on the key.
+func newStringCache[K comparable](format func(K) string) *stringCache[K] {
+	return &stringCache[K]{format: format}
+}
+
+// get returns the string for key, formatting it when missing.
+func (c *stringCache[K]) get(key K) string {
+	if text, found := c.entries[key]; found {
+		return text
+	}
+	if c.entries == nil || len(c.entries) >= stringCacheLimit {
+		c.entries = make(map[K]string)
+	}
+	text := c.format(key)
+	c.entries[key] = text
+	return text
+}
+
+// componentIDKey identifies the strings componentID returns.
+type componentIDKey struct {
+	name string
+	addr uintptr
+}
+
+var componentIDs = newStringCache(func(k componentIDKey) string {
+	return k.name + "_0x" + strconv.FormatUint(uint64(k.addr), 16)
+})
+
+// componentID returns the imgui id string of a component drawn under name, the same as
+// fmt.Sprintf("%s_%p", name, comp), without formatting it every frame.
+func componentID[T any](name string, comp *T) string {
+	return componentIDs.get(componentIDKey{name: name, addr: uintptr(unsafe.Pointer(comp))})
+}
+
+// floatTextKey identifies the strings floatText returns.
+type floatTextKey struct {
+	value     float32
+	precision int
+	format    LocaleFormat
+}
+
+var floatTexts = newStringCache(func(k floatTextKey) string {
+	return k.format.FormatFloat(float64(k.value), k.precision)
+})
+
+// floatText formats value in the current format, as controls label their values.
+func floatText(value float32, precision int) string {
+	return floatTexts.get(floatTextKey{value: value, precision: precision, format: CurrentFormat()})
+}
+
+// logTimeKey identifies the strings logTimeText returns.
+type logTimeKey struct {
+	format  string
+	elapsed time.Duration
+	blank   bool
+}
+
+var logTimeTexts = newStringCache(func(k logTimeKey) string {
+	text := fmt.Sprintf(k.format, k.elapsed.Seconds())
+	if k.blank {
+		return strings.Repeat(" ", len(text))
+	}
+	return text
+})
+
+// logTimeText returns the time column of a log message elapsed after the app started, or
+// spaces as wide as it when blank.
+func logTimeText(elapsed time.Duration, blank bool) string {
+	if blank {
+		elapsed = 0
+	}
+	return logTimeTexts.get(logTimeKey{format: LogTimeFormat, elapsed: elapsed, blank: blank})
+}
@@ -0,0 +1,91 @@
+package dfx
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStringCache(t *testing.T) {
+	formatted := 0
+	cache := newStringCache(func(k int) string {
+		formatted++
+		return fmt.Sprint(k)
+	})
+	if got := cache.get(1); got != "1" {
+		t.Fatalf("expected '%v', got '%v'", "1", got)
+	}
+	if got := cache.get(1); got != "1" {
+		t.Fatalf("expected '%v', got '%v'", "1", got)
+	}
+	if formatted != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, formatted)
+	}
+
+	// emptied when full, so values that keep changing stay bounded
+	for i := 0; i < stringCacheLimit+10; i++ {
+		cache.get(i)
+	}
+	if len(cache.entries) > stringCacheLimit {
+		t.Fatalf("expected '%v' <= '%v'", len(cache.entries), stringCacheLimit)
+	}
+	if got := cache.get(5); got != "5" {
+		t.Fatalf("expected '%v', got '%v'", "5", got)
+	}
+}
+
+func TestComponentID(t *testing.T) {
+	c := &Canvas{}
+	if got := componentID("canvas", c); got != fmt.Sprintf("canvas_%p", c) {
+		t.Fatalf("expected '%v', got '%v'", fmt.Sprintf("canvas_%p", c), got)
+	}
+	if componentID("canvas", &Canvas{}) == componentID("canvas", c) {
+		t.Fatalf("expected a value other than '%v'", componentID("canvas", c))
+	}
+	if got := testing.AllocsPerRun(10, func() { componentID("canvas", c) }); got != 0.0 {
+		t.Fatalf("expected '%v', got '%v'", 0.0, got)
+	}
+}
+
+func TestFloatText(t *testing.T) {
+	defer SetFormat(nil)
+	SetFormat(&LocaleFormat{Decimal: ",", Group: "."})
+	if got := floatText(1234.5, 2); got != "1.234,50" {
+		t.Fatalf("expected '%v', got '%v'", "1.234,50", got)
+	}
+	if got := testing.AllocsPerRun(10, func() { floatText(1234.5, 2) }); got != 0.0 {
+		t.Fatalf("expected '%v', got '%v'", 0.0, got)
+	}
+
+	// the format is part of the key
+	SetFormat(&LocaleFormat{Decimal: "."})
+	if got := floatText(1234.5, 2); got != "1234.50" {
+		t.Fatalf("expected '%v', got '%v'", "1234.50", got)
+	}
+}
+
+func TestLogTimeText(t *testing.T) {
+	if got := logTimeText(1500*time.Millisecond, false); got != fmt.Sprintf(LogTimeFormat, 1.5) {
+		t.Fatalf("expected '%v', got '%v'", fmt.Sprintf(LogTimeFormat, 1.5), got)
+	}
+	blank := logTimeText(0, true)
+	if blank != strings.Repeat(" ", len(fmt.Sprintf(LogTimeFormat, 0.0))) {
+		t.Fatalf("expected '%v', got '%v'", strings.Repeat(" ", len(fmt.Sprintf(LogTimeFormat, 0.0))), blank)
+	}
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	fallbacks, n := localeFallbacks("pt-BR")
+	if got := fallbacks[:n]; !slices.Equal(got, []string{"pt-BR", "pt", DefaultLocale}) {
+		t.Fatalf("expected '%v', got '%v'", []string{"pt-BR", "pt", DefaultLocale}, got)
+	}
+	fallbacks, n = localeFallbacks(DefaultLocale)
+	if got := fallbacks[:n]; !slices.Equal(got, []string{DefaultLocale}) {
+		t.Fatalf("expected '%v', got '%v'", []string{DefaultLocale}, got)
+	}
+	if got := testing.AllocsPerRun(10, func() { T("dfx.router.mute") }); got != 0.0 {
+		t.Fatalf("expected '%v', got '%v'", 0.0, got)
+	}
+}
@@ -1,8 +1,6 @@
 package dfx
 
 import (
-	"math"
-
 	"github.com/AllenDang/cimgui-go/imgui"
 )
 
@@ -28,13 +26,6 @@ const (
 	DefaultGrabRounding      = 2
 )
 
-// pxPerFrame calculates pixels to animate per frame for smooth transitions.
-func pxPerFrame(targetSize float32, transitionMs int) float32 {
-	msFrame := FramerateToMs / imgui.CurrentIO().Framerate()
-	frames := float32(transitionMs) / msFrame
-	return float32(math.Ceil(float64(targetSize) / float64(frames)))
-}
-
 // DefaultStyle sets up the default ImGui style parameters
 // this should be called after font setup but before theme application
 func DefaultStyle() {
@@ -62,3 +53,14 @@ func DefaultStyle() {
 	style.SetScrollbarRounding(DefaultScrollbarRounding)
 	style.SetGrabRounding(DefaultGrabRounding)
 }
+
+// HighContrastStyle applies DefaultStyle with borders around frames, windows and child
+// windows, so controls stand apart from the background; use it with HighContrast.
+// DefaultStyle restores the default borders.
+func HighContrastStyle() {
+	DefaultStyle()
+	style := imgui.CurrentStyle()
+	style.SetWindowBorderSize(1)
+	style.SetChildBorderSize(1)
+	style.SetFrameBorderSize(1)
+}
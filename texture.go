@@ -0,0 +1,209 @@
+package dfx
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// Texture is an image held in GPU memory, created by a TextureManager. textures can be
+// created from any goroutine: the upload is deferred until the texture is first drawn.
+// release textures you no longer need; GPU memory is not garbage collected.
+type Texture struct {
+	Width  int
+	Height int
+
+	manager  *TextureManager
+	key      string
+	rgba     *image.RGBA // pixels waiting to be uploaded
+	ref      imgui.TextureRef
+	free     func()
+	uploaded bool
+	released bool
+}
+
+// TextureRef uploads the texture if needed and returns its imgui reference, for use with
+// imgui image functions. returns false once the texture has been released. call only
+// from the UI thread.
+func (t *Texture) TextureRef() (imgui.TextureRef, bool) {
+	m := t.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t.released {
+		return imgui.TextureRef{}, false
+	}
+	if !t.uploaded {
+		t.ref, t.free = m.upload(t.rgba)
+		t.rgba = nil
+		t.uploaded = true
+	}
+	return t.ref, true
+}
+
+// Size returns the texture dimensions in pixels.
+func (t *Texture) Size() imgui.Vec2 {
+	return imgui.Vec2{X: float32(t.Width), Y: float32(t.Height)}
+}
+
+// Release frees the texture. the GPU memory is reclaimed at the start of the next frame,
+// so Release is safe to call from any goroutine, and while the texture is being drawn.
+func (t *Texture) Release() {
+	m := t.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.release(t)
+}
+
+// Released reports whether the texture has been released.
+func (t *Texture) Released() bool {
+	t.manager.mu.Lock()
+	defer t.manager.mu.Unlock()
+	return t.released
+}
+
+// TextureManager creates GPU textures from images and tracks them until they are
+// released. App owns one (see App.Textures) and updates it once per frame.
+type TextureManager struct {
+	mu      sync.Mutex
+	keyed   map[string]*Texture
+	live    map[*Texture]struct{}
+	pending []func() // frees waiting for the next Update
+
+	create func(rgba *image.RGBA) (imgui.TextureRef, func()) // nil = use the backend
+}
+
+// NewTextureManager creates an empty texture manager.
+func NewTextureManager() *TextureManager {
+	return &TextureManager{
+		keyed: make(map[string]*Texture),
+		live:  make(map[*Texture]struct{}),
+	}
+}
+
+// Create makes an unkeyed texture from img.
+func (m *TextureManager) Create(img image.Image) *Texture {
+	return m.Load("", img)
+}
+
+// Load makes a texture from img and stores it under key, releasing any texture already
+// stored there. an empty key creates an unkeyed texture.
+func (m *TextureManager) Load(key string, img image.Image) *Texture {
+	rgba := backend.ImageToRgba(img)
+	t := &Texture{
+		Width:   rgba.Bounds().Dx(),
+		Height:  rgba.Bounds().Dy(),
+		manager: m,
+		key:     key,
+		rgba:    rgba,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if key != "" {
+		if old, found := m.keyed[key]; found {
+			m.release(old)
+		}
+		m.keyed[key] = t
+	}
+	m.live[t] = struct{}{}
+	return t
+}
+
+// LoadBytes decodes a PNG or JPEG image and stores it under key.
+func (m *TextureManager) LoadBytes(key string, data []byte) (*Texture, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding image '%v'", key)
+	}
+	return m.Load(key, img), nil
+}
+
+// LoadFile decodes a PNG or JPEG file into a texture keyed by its path. a texture already
+// loaded from path is returned without reading the file again.
+func (m *TextureManager) LoadFile(path string) (*Texture, error) {
+	if t := m.Get(path); t != nil {
+		return t, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading image '%v'", path)
+	}
+	return m.LoadBytes(path, data)
+}
+
+// Get returns the texture stored under key, or nil.
+func (m *TextureManager) Get(key string) *Texture {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.keyed[key]
+}
+
+// Release releases the texture stored under key, if any.
+func (m *TextureManager) Release(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, found := m.keyed[key]; found {
+		m.release(t)
+	}
+}
+
+// ReleaseAll releases every texture the manager created.
+func (m *TextureManager) ReleaseAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for t := range m.live {
+		m.release(t)
+	}
+}
+
+// Count returns the number of textures that have not been released.
+func (m *TextureManager) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.live)
+}
+
+// Update frees the GPU memory of released textures. call once per frame from the UI
+// thread, before drawing.
+func (m *TextureManager) Update() {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+
+	for _, free := range pending {
+		free()
+	}
+}
+
+// release marks t released and schedules its GPU memory to be freed. the caller holds m.mu.
+func (m *TextureManager) release(t *Texture) {
+	if t.released {
+		return
+	}
+	t.released = true
+	t.rgba = nil
+	delete(m.live, t)
+	if t.key != "" && m.keyed[t.key] == t {
+		delete(m.keyed, t.key)
+	}
+	if t.uploaded && t.free != nil {
+		m.pending = append(m.pending, t.free)
+	}
+}
+
+func (m *TextureManager) upload(rgba *image.RGBA) (imgui.TextureRef, func()) {
+	if m.create != nil {
+		return m.create(rgba)
+	}
+	tex := backend.NewTextureFromRgba(rgba)
+	return tex.ID, tex.Release
+}
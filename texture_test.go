@@ -0,0 +1,88 @@
+package dfx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// newTestTextureManager returns a manager with a fake upload that counts live GPU textures.
+func newTestTextureManager() (*TextureManager, *int) {
+	gpu := 0
+	m := NewTextureManager()
+	m.create = func(rgba *image.RGBA) (imgui.TextureRef, func()) {
+		gpu++
+		return imgui.TextureRef{}, func() { gpu-- }
+	}
+	return m, &gpu
+}
+
+func TestTextureManager_DeferredUploadAndRelease(t *testing.T) {
+	m, gpu := newTestTextureManager()
+	tex := m.Create(image.NewRGBA(image.Rect(0, 0, 4, 2)))
+	if tex.Width != 4 || tex.Height != 2 || m.Count() != 1 {
+		t.Fatalf("unexpected texture %vx%v (count %v)", tex.Width, tex.Height, m.Count())
+	}
+	if *gpu != 0 {
+		t.Fatalf("expected upload to wait until first use")
+	}
+
+	if _, ok := tex.TextureRef(); !ok || *gpu != 1 {
+		t.Fatalf("expected texture to upload on first use")
+	}
+	tex.TextureRef()
+	if *gpu != 1 {
+		t.Fatalf("expected a single upload, got %v", *gpu)
+	}
+
+	tex.Release()
+	if _, ok := tex.TextureRef(); ok || !tex.Released() || m.Count() != 0 {
+		t.Fatalf("expected texture to be released")
+	}
+	if *gpu != 1 {
+		t.Fatalf("expected GPU memory to be freed on the next update")
+	}
+	m.Update()
+	if *gpu != 0 {
+		t.Fatalf("expected GPU memory freed, got %v", *gpu)
+	}
+}
+
+func TestTextureManager_KeyedReplaceAndReleaseAll(t *testing.T) {
+	m, gpu := newTestTextureManager()
+	first := m.Load("logo", image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	first.TextureRef()
+	second := m.Load("logo", image.NewRGBA(image.Rect(0, 0, 2, 2)))
+	if !first.Released() || m.Get("logo") != second {
+		t.Fatalf("expected load to replace the keyed texture")
+	}
+
+	// never-uploaded textures have nothing to free
+	m.Create(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	m.ReleaseAll()
+	m.Update()
+	if m.Count() != 0 || m.Get("logo") != nil || *gpu != 0 {
+		t.Fatalf("expected all textures released, count %v, gpu %v", m.Count(), *gpu)
+	}
+}
+
+func TestTextureManager_LoadBytes(t *testing.T) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 3, 5))); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestTextureManager()
+	tex, err := m.LoadBytes("icon", data.Bytes())
+	if err != nil || tex.Width != 3 || tex.Height != 5 {
+		t.Fatalf("unexpected result %v, %v", tex, err)
+	}
+	if _, err := m.LoadBytes("bad", []byte("not an image")); err == nil {
+		t.Fatalf("expected decode error")
+	}
+	if _, err := m.LoadFile("/nonexistent/image.png"); err == nil {
+		t.Fatalf("expected read error")
+	}
+}
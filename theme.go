@@ -6,12 +6,72 @@ import "github.com/AllenDang/cimgui-go/imgui"
 type Theme interface {
 	Apply() // applies the theme to ImGui style
 	Name() string
+	Colors() SemanticColors // named colors consulted by dfx components
+}
+
+// SemanticColors holds named color tokens that components use instead of
+// hard-coded colors, so switching themes restyles everything consistently.
+type SemanticColors struct {
+	Accent    imgui.Vec4 // primary accent (drag handles, active indicators)
+	Highlight imgui.Vec4 // secondary accent (log functions, links)
+	Muted     imgui.Vec4 // de-emphasized text (timestamps, hints)
+	Info      imgui.Vec4 // informational/debug messages
+	Success   imgui.Vec4 // success states
+	Warning   imgui.Vec4 // warning states
+	Error     imgui.Vec4 // error states
+
+	MeterLow  imgui.Vec4 // meter green zone
+	MeterMid  imgui.Vec4 // meter yellow zone
+	MeterHigh imgui.Vec4 // meter red zone
+	MeterOff  imgui.Vec4 // inactive meter segments/background
+	MeterPeak imgui.Vec4 // peak hold indicator
+	MeterClip imgui.Vec4 // clip indicator
+}
+
+// DefaultDarkColors returns the semantic colors used by dark themes.
+func DefaultDarkColors() SemanticColors {
+	return SemanticColors{
+		Accent:    imgui.Vec4{X: 0.976, Y: 0.259, Z: 0.259, W: 1.0},
+		Highlight: imgui.Vec4{X: 0.203, Y: 0.886, Z: 0.886, W: 1.0},
+		Muted:     imgui.Vec4{X: 0.5, Y: 0.5, Z: 0.5, W: 1.0},
+		Info:      imgui.Vec4{X: 0.333, Y: 0.553, Z: 1.0, W: 1.0},
+		Success:   imgui.Vec4{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0},
+		Warning:   imgui.Vec4{X: 1.0, Y: 0.878, Z: 0.0, W: 1.0},
+		Error:     imgui.Vec4{X: 1.0, Y: 0.2, Z: 0.2, W: 1.0},
+		MeterLow:  imgui.Vec4{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0},
+		MeterMid:  imgui.Vec4{X: 0.9, Y: 0.8, Z: 0.1, W: 1.0},
+		MeterHigh: imgui.Vec4{X: 0.9, Y: 0.2, Z: 0.2, W: 1.0},
+		MeterOff:  imgui.Vec4{X: 0.15, Y: 0.15, Z: 0.15, W: 1.0},
+		MeterPeak: imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.9},
+		MeterClip: imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0},
+	}
+}
+
+// DefaultLightColors returns the semantic colors used by light themes.
+// status colors are darkened to keep contrast against bright backgrounds.
+func DefaultLightColors() SemanticColors {
+	return SemanticColors{
+		Accent:    imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 1.0},
+		Highlight: imgui.Vec4{X: 0.0, Y: 0.502, Z: 0.525, W: 1.0},
+		Muted:     imgui.Vec4{X: 0.45, Y: 0.45, Z: 0.45, W: 1.0},
+		Info:      imgui.Vec4{X: 0.110, Y: 0.337, Z: 0.800, W: 1.0},
+		Success:   imgui.Vec4{X: 0.098, Y: 0.553, Z: 0.157, W: 1.0},
+		Warning:   imgui.Vec4{X: 0.718, Y: 0.494, Z: 0.0, W: 1.0},
+		Error:     imgui.Vec4{X: 0.800, Y: 0.098, Z: 0.098, W: 1.0},
+		MeterLow:  imgui.Vec4{X: 0.157, Y: 0.690, Z: 0.157, W: 1.0},
+		MeterMid:  imgui.Vec4{X: 0.898, Y: 0.718, Z: 0.0, W: 1.0},
+		MeterHigh: imgui.Vec4{X: 0.878, Y: 0.157, Z: 0.157, W: 1.0},
+		MeterOff:  imgui.Vec4{X: 0.820, Y: 0.820, Z: 0.835, W: 1.0},
+		MeterPeak: imgui.Vec4{X: 0.1, Y: 0.1, Z: 0.1, W: 0.9},
+		MeterClip: imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0},
+	}
 }
 
 // HueColorScheme creates themes based on HSV color space
 // this allows dynamic theme generation with consistent relationships
 type HueColorScheme struct {
 	name           string
+	Light          bool // light variant (dark text on a bright background)
 	Hue            int
 	TextSaturation float32
 	TextValue      float32
@@ -41,10 +101,45 @@ func NewHueColorScheme(name string, hue int, sat, value float32) *HueColorScheme
 	}
 }
 
+// NewLightHueColorScheme creates a light variant of an HSV-based color scheme.
+// hue and sat follow the same 0-255 convention as NewHueColorScheme; value
+// controls the brightness of the background, from which the darker area,
+// accent and text values are derived.
+func NewLightHueColorScheme(name string, hue int, sat, value float32) *HueColorScheme {
+	return &HueColorScheme{
+		name:           name,
+		Light:          true,
+		Hue:            hue,
+		TextSaturation: (sat / 4) / 255.0,
+		TextValue:      ((255 - value) / 2) / 255.0,
+		MainSaturation: sat / 255.0,
+		MainValue:      (value * 0.8) / 255.0,
+		AreaSaturation: (sat / 5) / 255.0,
+		AreaValue:      (value * 0.92) / 255.0,
+		BgSaturation:   (sat / 8) / 255.0,
+		BgValue:        value / 255.0,
+	}
+}
+
 func (s *HueColorScheme) Name() string {
 	return s.name
 }
 
+// Colors returns semantic colors with the accent tokens derived from the hue.
+func (s *HueColorScheme) Colors() SemanticColors {
+	colors := DefaultDarkColors()
+	if s.Light {
+		colors = DefaultLightColors()
+	}
+	accent := imgui.Color{}
+	accent.SetHSV(float32(s.Hue)/255.0, 0.7, 0.95)
+	if s.Light {
+		accent.SetHSV(float32(s.Hue)/255.0, 0.8, 0.7)
+	}
+	colors.Accent = imgui.Vec4{X: accent.FieldValue.X, Y: accent.FieldValue.Y, Z: accent.FieldValue.Z, W: 1}
+	return colors
+}
+
 func (s *HueColorScheme) Apply() {
 	// create color values from HSV
 	text := imgui.Color{}
@@ -56,6 +151,14 @@ func (s *HueColorScheme) Apply() {
 	bg := imgui.Color{}
 	bg.SetHSV(float32(s.Hue)/255.0, s.BgSaturation, s.BgValue)
 
+	// light variants need visible frames and a lighter modal dim
+	frameAlpha := float32(0)
+	dimColor := imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 0.35}
+	if s.Light {
+		frameAlpha = 0.6
+		dimColor = imgui.Vec4{X: 0.8, Y: 0.8, Z: 0.8, W: 0.35}
+	}
+
 	// apply to ImGui style
 	colors := imgui.CurrentStyle().Colors()
 	colors[imgui.ColText] = imgui.Vec4{X: text.FieldValue.X, Y: text.FieldValue.Y, Z: text.FieldValue.Z, W: 1}
@@ -65,7 +168,7 @@ func (s *HueColorScheme) Apply() {
 	colors[imgui.ColPopupBg] = imgui.Vec4{X: area.FieldValue.X, Y: area.FieldValue.Y, Z: area.FieldValue.Z, W: 1}
 	colors[imgui.ColBorder] = imgui.Vec4{X: text.FieldValue.X, Y: text.FieldValue.Y, Z: text.FieldValue.Z, W: 0.3}
 	colors[imgui.ColBorderShadow] = imgui.Vec4{X: 0, Y: 0, Z: 0, W: 0}
-	colors[imgui.ColFrameBg] = imgui.Vec4{X: area.FieldValue.X, Y: area.FieldValue.Y, Z: area.FieldValue.Z, W: 0.0}
+	colors[imgui.ColFrameBg] = imgui.Vec4{X: area.FieldValue.X, Y: area.FieldValue.Y, Z: area.FieldValue.Z, W: frameAlpha}
 	colors[imgui.ColFrameBgHovered] = imgui.Vec4{X: main.FieldValue.X, Y: main.FieldValue.Y, Z: main.FieldValue.Z, W: 0.68}
 	colors[imgui.ColFrameBgActive] = imgui.Vec4{X: main.FieldValue.X, Y: main.FieldValue.Y, Z: main.FieldValue.Z, W: 1}
 	colors[imgui.ColTitleBg] = imgui.Vec4{X: main.FieldValue.X, Y: main.FieldValue.Y, Z: main.FieldValue.Z, W: 0.45}
@@ -99,7 +202,7 @@ func (s *HueColorScheme) Apply() {
 	colors[imgui.ColPlotHistogram] = imgui.Vec4{X: text.FieldValue.X, Y: text.FieldValue.Y, Z: text.FieldValue.Z, W: 0.63}
 	colors[imgui.ColPlotHistogramHovered] = imgui.Vec4{X: main.FieldValue.X, Y: main.FieldValue.Y, Z: main.FieldValue.Z, W: 1}
 	colors[imgui.ColTextSelectedBg] = imgui.Vec4{X: main.FieldValue.X, Y: main.FieldValue.Y, Z: main.FieldValue.Z, W: 0.43}
-	colors[imgui.ColModalWindowDimBg] = imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 0.35}
+	colors[imgui.ColModalWindowDimBg] = dimColor
 	imgui.CurrentStyle().SetColors(&colors)
 }
 
@@ -110,6 +213,10 @@ func (m *ModernTheme) Name() string {
 	return "Modern Dark"
 }
 
+func (m *ModernTheme) Colors() SemanticColors {
+	return DefaultDarkColors()
+}
+
 func (m *ModernTheme) Apply() {
 	colors := imgui.CurrentStyle().Colors()
 	colors[imgui.ColText] = imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 1.0}
@@ -165,6 +272,167 @@ func (m *ModernTheme) Apply() {
 	imgui.CurrentStyle().SetColors(&colors)
 }
 
+// ModernLightTheme implements a predefined light theme, the counterpart of ModernTheme
+type ModernLightTheme struct{}
+
+func (m *ModernLightTheme) Name() string {
+	return "Modern Light"
+}
+
+func (m *ModernLightTheme) Colors() SemanticColors {
+	return DefaultLightColors()
+}
+
+func (m *ModernLightTheme) Apply() {
+	colors := imgui.CurrentStyle().Colors()
+	colors[imgui.ColText] = imgui.Vec4{X: 0.086, Y: 0.086, Z: 0.098, W: 1.0}
+	colors[imgui.ColTextDisabled] = imgui.Vec4{X: 0.086, Y: 0.086, Z: 0.098, W: 0.45}
+	colors[imgui.ColWindowBg] = imgui.Vec4{X: 0.961, Y: 0.961, Z: 0.965, W: 0.98}
+	colors[imgui.ColChildBg] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.0}
+	colors[imgui.ColPopupBg] = imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.98}
+	colors[imgui.ColBorder] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.18}
+	colors[imgui.ColBorderShadow] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.0}
+	colors[imgui.ColFrameBg] = imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.9}
+	colors[imgui.ColFrameBgHovered] = imgui.Vec4{X: 0.878, Y: 0.878, Z: 0.890, W: 0.9}
+	colors[imgui.ColFrameBgActive] = imgui.Vec4{X: 0.820, Y: 0.820, Z: 0.835, W: 1.0}
+	colors[imgui.ColTitleBg] = imgui.Vec4{X: 0.878, Y: 0.878, Z: 0.890, W: 1.0}
+	colors[imgui.ColTitleBgActive] = imgui.Vec4{X: 0.820, Y: 0.820, Z: 0.835, W: 1.0}
+	colors[imgui.ColTitleBgCollapsed] = imgui.Vec4{X: 0.878, Y: 0.878, Z: 0.890, W: 0.5}
+	colors[imgui.ColMenuBarBg] = imgui.Vec4{X: 0.906, Y: 0.906, Z: 0.914, W: 1.0}
+	colors[imgui.ColScrollbarBg] = imgui.Vec4{X: 0.98, Y: 0.98, Z: 0.98, W: 0.53}
+	colors[imgui.ColScrollbarGrab] = imgui.Vec4{X: 0.686, Y: 0.686, Z: 0.686, W: 1.0}
+	colors[imgui.ColScrollbarGrabHovered] = imgui.Vec4{X: 0.588, Y: 0.588, Z: 0.588, W: 1.0}
+	colors[imgui.ColScrollbarGrabActive] = imgui.Vec4{X: 0.490, Y: 0.490, Z: 0.490, W: 1.0}
+	colors[imgui.ColCheckMark] = imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 1.0}
+	colors[imgui.ColSliderGrab] = imgui.Vec4{X: 0.294, Y: 0.294, Z: 0.314, W: 1.0}
+	colors[imgui.ColSliderGrabActive] = imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 1.0}
+	colors[imgui.ColButton] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.08}
+	colors[imgui.ColButtonHovered] = imgui.Vec4{X: 1.0, Y: 0.690, Z: 0.098, W: 0.8}
+	colors[imgui.ColButtonActive] = imgui.Vec4{X: 0.910, Y: 0.220, Z: 0.220, W: 1.0}
+	colors[imgui.ColHeader] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.08}
+	colors[imgui.ColHeaderHovered] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.14}
+	colors[imgui.ColHeaderActive] = imgui.Vec4{X: 0.910, Y: 0.220, Z: 0.220, W: 1.0}
+	colors[imgui.ColSeparator] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.18}
+	colors[imgui.ColSeparatorHovered] = imgui.Vec4{X: 0.098, Y: 0.4, Z: 0.749, W: 0.78}
+	colors[imgui.ColSeparatorActive] = imgui.Vec4{X: 0.098, Y: 0.4, Z: 0.749, W: 1.0}
+	colors[imgui.ColResizeGrip] = imgui.Vec4{X: 0.259, Y: 0.588, Z: 0.976, W: 0.2}
+	colors[imgui.ColResizeGripHovered] = imgui.Vec4{X: 0.259, Y: 0.588, Z: 0.976, W: 0.67}
+	colors[imgui.ColResizeGripActive] = imgui.Vec4{X: 0.259, Y: 0.588, Z: 0.976, W: 0.95}
+	colors[imgui.ColTab] = imgui.Vec4{X: 0.878, Y: 0.878, Z: 0.890, W: 1.0}
+	colors[imgui.ColTabHovered] = imgui.Vec4{X: 1.0, Y: 0.690, Z: 0.098, W: 0.8}
+	colors[imgui.ColTabSelected] = imgui.Vec4{X: 0.910, Y: 0.220, Z: 0.220, W: 1.0}
+	colors[imgui.ColPlotLines] = imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 1.0}
+	colors[imgui.ColPlotLinesHovered] = imgui.Vec4{X: 0.910, Y: 0.349, Z: 0.271, W: 1.0}
+	colors[imgui.ColPlotHistogram] = imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 1.0}
+	colors[imgui.ColPlotHistogramHovered] = imgui.Vec4{X: 1.0, Y: 0.690, Z: 0.098, W: 0.8}
+	colors[imgui.ColTableHeaderBg] = imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 0.25}
+	colors[imgui.ColTableBorderStrong] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.3}
+	colors[imgui.ColTableBorderLight] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.12}
+	colors[imgui.ColTableRowBg] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.0}
+	colors[imgui.ColTableRowBgAlt] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.04}
+	colors[imgui.ColTextSelectedBg] = imgui.Vec4{X: 0.851, Y: 0.180, Z: 0.180, W: 0.35}
+	colors[imgui.ColDragDropTarget] = imgui.Vec4{X: 0.910, Y: 0.220, Z: 0.220, W: 0.9}
+	colors[imgui.ColNavWindowingHighlight] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.468}
+	colors[imgui.ColNavWindowingDimBg] = imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 0.2}
+	colors[imgui.ColModalWindowDimBg] = imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 0.35}
+	imgui.CurrentStyle().SetColors(&colors)
+}
+
+// HighContrastColors returns the semantic colors of the high-contrast theme: pure hues
+// at full strength against black.
+func HighContrastColors() SemanticColors {
+	return SemanticColors{
+		Accent:    imgui.Vec4{X: 1.0, Y: 0.898, Z: 0.0, W: 1.0},
+		Highlight: imgui.Vec4{X: 0.0, Y: 1.0, Z: 1.0, W: 1.0},
+		Muted:     imgui.Vec4{X: 0.8, Y: 0.8, Z: 0.8, W: 1.0},
+		Info:      imgui.Vec4{X: 0.4, Y: 0.8, Z: 1.0, W: 1.0},
+		Success:   imgui.Vec4{X: 0.0, Y: 1.0, Z: 0.0, W: 1.0},
+		Warning:   imgui.Vec4{X: 1.0, Y: 0.647, Z: 0.0, W: 1.0},
+		Error:     imgui.Vec4{X: 1.0, Y: 0.302, Z: 0.302, W: 1.0},
+		MeterLow:  imgui.Vec4{X: 0.0, Y: 1.0, Z: 0.0, W: 1.0},
+		MeterMid:  imgui.Vec4{X: 1.0, Y: 1.0, Z: 0.0, W: 1.0},
+		MeterHigh: imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0},
+		MeterOff:  imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 1.0},
+		MeterPeak: imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 1.0},
+		MeterClip: imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0},
+	}
+}
+
+// HighContrastTheme implements a predefined high-contrast theme for low-vision users:
+// white text and borders on black, with a yellow accent for focus, selection and active
+// controls. pair it with HighContrastStyle to outline every control.
+type HighContrastTheme struct{}
+
+func (h *HighContrastTheme) Name() string {
+	return "High Contrast"
+}
+
+func (h *HighContrastTheme) Colors() SemanticColors {
+	return HighContrastColors()
+}
+
+func (h *HighContrastTheme) Apply() {
+	white := imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 1.0}
+	black := imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 1.0}
+	yellow := imgui.Vec4{X: 1.0, Y: 0.898, Z: 0.0, W: 1.0}
+	cyan := imgui.Vec4{X: 0.0, Y: 1.0, Z: 1.0, W: 1.0}
+	gray := imgui.Vec4{X: 0.2, Y: 0.2, Z: 0.2, W: 1.0}
+	dim := imgui.Vec4{X: 0.1, Y: 0.1, Z: 0.1, W: 1.0}
+
+	colors := imgui.CurrentStyle().Colors()
+	colors[imgui.ColText] = white
+	colors[imgui.ColTextDisabled] = imgui.Vec4{X: 0.7, Y: 0.7, Z: 0.7, W: 1.0}
+	colors[imgui.ColWindowBg] = black
+	colors[imgui.ColChildBg] = black
+	colors[imgui.ColPopupBg] = black
+	colors[imgui.ColBorder] = white
+	colors[imgui.ColBorderShadow] = imgui.Vec4{}
+	colors[imgui.ColFrameBg] = dim
+	colors[imgui.ColFrameBgHovered] = gray
+	colors[imgui.ColFrameBgActive] = gray
+	colors[imgui.ColTitleBg] = black
+	colors[imgui.ColTitleBgActive] = gray
+	colors[imgui.ColTitleBgCollapsed] = black
+	colors[imgui.ColMenuBarBg] = dim
+	colors[imgui.ColScrollbarBg] = black
+	colors[imgui.ColScrollbarGrab] = white
+	colors[imgui.ColScrollbarGrabHovered] = yellow
+	colors[imgui.ColScrollbarGrabActive] = yellow
+	colors[imgui.ColCheckMark] = yellow
+	colors[imgui.ColSliderGrab] = white
+	colors[imgui.ColSliderGrabActive] = yellow
+	colors[imgui.ColButton] = dim
+	colors[imgui.ColButtonHovered] = gray
+	colors[imgui.ColButtonActive] = imgui.Vec4{X: 0.4, Y: 0.36, Z: 0.0, W: 1.0}
+	colors[imgui.ColHeader] = gray
+	colors[imgui.ColHeaderHovered] = imgui.Vec4{X: 0.3, Y: 0.3, Z: 0.3, W: 1.0}
+	colors[imgui.ColHeaderActive] = imgui.Vec4{X: 0.4, Y: 0.36, Z: 0.0, W: 1.0}
+	colors[imgui.ColSeparator] = white
+	colors[imgui.ColSeparatorHovered] = yellow
+	colors[imgui.ColSeparatorActive] = yellow
+	colors[imgui.ColResizeGrip] = white
+	colors[imgui.ColResizeGripHovered] = yellow
+	colors[imgui.ColResizeGripActive] = yellow
+	colors[imgui.ColTab] = dim
+	colors[imgui.ColTabHovered] = gray
+	colors[imgui.ColTabSelected] = imgui.Vec4{X: 0.4, Y: 0.36, Z: 0.0, W: 1.0}
+	colors[imgui.ColPlotLines] = white
+	colors[imgui.ColPlotLinesHovered] = yellow
+	colors[imgui.ColPlotHistogram] = cyan
+	colors[imgui.ColPlotHistogramHovered] = yellow
+	colors[imgui.ColTableHeaderBg] = gray
+	colors[imgui.ColTableBorderStrong] = white
+	colors[imgui.ColTableBorderLight] = imgui.Vec4{X: 0.6, Y: 0.6, Z: 0.6, W: 1.0}
+	colors[imgui.ColTableRowBg] = imgui.Vec4{}
+	colors[imgui.ColTableRowBgAlt] = imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.08}
+	colors[imgui.ColTextSelectedBg] = imgui.Vec4{X: 1.0, Y: 0.898, Z: 0.0, W: 0.45}
+	colors[imgui.ColDragDropTarget] = yellow
+	colors[imgui.ColNavWindowingHighlight] = yellow
+	colors[imgui.ColNavWindowingDimBg] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.8}
+	colors[imgui.ColModalWindowDimBg] = imgui.Vec4{X: 0.0, Y: 0.0, Z: 0.0, W: 0.8}
+	imgui.CurrentStyle().SetColors(&colors)
+}
+
 // predefined themes for convenience
 var (
 	BlueTheme   = NewHueColorScheme("Blue", 240, 50, 180)
@@ -172,9 +440,41 @@ var (
 	RedTheme    = NewHueColorScheme("Red", 0, 45, 175)
 	PurpleTheme = NewHueColorScheme("Purple", 270, 35, 165)
 	ModernDark  = &ModernTheme{}
+
+	BlueLightTheme  = NewLightHueColorScheme("Blue Light", 160, 110, 245)
+	GreenLightTheme = NewLightHueColorScheme("Green Light", 85, 90, 240)
+	ModernLight     = &ModernLightTheme{}
+
+	HighContrast = &HighContrastTheme{}
 )
 
+// currentTheme is the most recently applied theme
+var currentTheme Theme
+
 // SetTheme applies a theme to the current ImGui style
 func SetTheme(theme Theme) {
 	theme.Apply()
+	currentTheme = theme
+}
+
+// CurrentTheme returns the most recently applied theme, or nil if none has been set.
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// ThemeColors returns the semantic colors of the current theme.
+// falls back to DefaultDarkColors when no theme has been applied.
+func ThemeColors() SemanticColors {
+	if currentTheme == nil {
+		return DefaultDarkColors()
+	}
+	return currentTheme.Colors()
+}
+
+// themeColor returns override unless it is the zero value, in which case the theme token is used.
+func themeColor(override, token imgui.Vec4) imgui.Vec4 {
+	if override == (imgui.Vec4{}) {
+		return token
+	}
+	return override
 }
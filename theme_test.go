@@ -0,0 +1,47 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestThemeColor_ZeroUsesToken(t *testing.T) {
+	token := imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}
+	if got := themeColor(imgui.Vec4{}, token); got != token {
+		t.Fatalf("expected token color, got '%v'", got)
+	}
+	override := imgui.Vec4{X: 0, Y: 1, Z: 0, W: 1}
+	if got := themeColor(override, token); got != override {
+		t.Fatalf("expected override color, got '%v'", got)
+	}
+}
+
+func TestThemeColors_DefaultsToDarkWithoutTheme(t *testing.T) {
+	previous := currentTheme
+	currentTheme = nil
+	defer func() { currentTheme = previous }()
+
+	if ThemeColors() != DefaultDarkColors() {
+		t.Fatalf("expected dark semantic colors when no theme is applied")
+	}
+}
+
+func TestHueColorScheme_LightVariantUsesLightColors(t *testing.T) {
+	light := NewLightHueColorScheme("test", 160, 100, 240)
+	if !light.Light {
+		t.Fatalf("expected light scheme to be flagged 'Light'")
+	}
+	if light.BgValue <= light.TextValue {
+		t.Fatalf("expected light background brighter than text, got bg '%v' text '%v'", light.BgValue, light.TextValue)
+	}
+	colors := light.Colors()
+	if colors.Error != DefaultLightColors().Error {
+		t.Fatalf("expected light scheme to use light status colors")
+	}
+
+	dark := NewHueColorScheme("test", 160, 100, 240)
+	if dark.Colors().Error != DefaultDarkColors().Error {
+		t.Fatalf("expected dark scheme to use dark status colors")
+	}
+}
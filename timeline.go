@@ -0,0 +1,648 @@
+package dfx
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TimelineUnits selects what timeline positions count.
+type TimelineUnits int
+
+const (
+	TimelineSeconds TimelineUnits = iota // positions are seconds; the ruler shows minutes and seconds
+	TimelineBeats                        // positions are beats; the ruler shows bars and beats
+)
+
+// Timeline constants
+const (
+	TimelineDefaultZoom        = 50  // pixels per unit (second or beat)
+	TimelineDefaultHeaderWidth = 120 // width of the track name column
+	TimelineMinZoom            = 1
+	TimelineMaxZoom            = 2000
+	timelineMinTickGap         = 60  // pixels between labelled ruler ticks, at least
+	timelineEdgeGrab           = 5   // pixels at each clip end that resize it
+	timelineMinClipPixels      = 4   // shortest clip, in pixels, when not snapping
+	timelineZoomStep           = 1.2 // zoom factor per wheel notch
+)
+
+// timeline drag modes
+const (
+	timelineDragNone = iota
+	timelineDragSeek
+	timelineDragMove
+	timelineDragResizeStart
+	timelineDragResizeEnd
+)
+
+// TimelineClip is a span of time on a track.
+type TimelineClip struct {
+	Start  float64    // position, in the timeline's units
+	Length float64    // duration, in the timeline's units
+	Label  string     // text drawn in the clip
+	Color  imgui.Vec4 // fill color (zero = theme accent)
+	Data   any        // application data
+}
+
+// End returns the position the clip ends at.
+func (c *TimelineClip) End() float64 {
+	return c.Start + c.Length
+}
+
+// TimelineTrack is a row of clips.
+type TimelineTrack struct {
+	Name   string
+	Clips  []*TimelineClip
+	Height float32 // row height (0 = two frame heights)
+}
+
+// Timeline shows tracks of clips against a time ruler, the foundation for sequencers and
+// arrangement views. clips are selected by clicking (Ctrl adds or removes), moved by
+// dragging and resized by dragging either end; the selected clips move together. clicking
+// or dragging in the ruler moves the playhead. Ctrl+wheel zooms around the pointer,
+// Shift+wheel scrolls time and the wheel scrolls the tracks. positions snap to Snap unless
+// Shift is held.
+type Timeline struct {
+	Container
+	Tracks      []*TimelineTrack
+	Units       TimelineUnits
+	BeatsPerBar int     // beats in a bar for TimelineBeats (0 = 4)
+	Length      float64 // extent of the timeline (0 = the end of the last clip)
+	Snap        float64 // grid positions snap to, in units (0 = none)
+	HeaderWidth float32 // track name column width (0 = TimelineDefaultHeaderWidth)
+	Height      float32 // timeline height (0 = available height)
+
+	OnSeek        func(t float64)             // the playhead was moved in the ruler
+	OnClipsChange func(clips []*TimelineClip) // clips were moved or resized, when the drag ends
+	OnSelect      func(clips []*TimelineClip) // the selection changed
+
+	time     float64    // playhead
+	zoom     float32    // pixels per unit
+	scrollX  float64    // time at the left edge of the tracks
+	scrollY  float32    // pixels scrolled down the tracks
+	viewSize imgui.Vec2 // size of the track area when last drawn
+	selected map[*TimelineClip]bool
+
+	drag      int
+	dragClip  *TimelineClip
+	dragFrom  float64                      // pointer time when the drag started
+	dragStart map[*TimelineClip][2]float64 // start and length of the dragged clips when the drag started
+}
+
+// NewTimeline creates a timeline of tracks measured in units.
+func NewTimeline(units TimelineUnits, tracks ...*TimelineTrack) *Timeline {
+	return &Timeline{
+		Container: Container{Visible: true},
+		Tracks:    tracks,
+		Units:     units,
+		zoom:      TimelineDefaultZoom,
+		selected:  make(map[*TimelineClip]bool),
+	}
+}
+
+// Time returns the playhead position.
+func (tl *Timeline) Time() float64 {
+	return tl.time
+}
+
+// SetTime moves the playhead, e.g. as playback advances. it does not call OnSeek.
+func (tl *Timeline) SetTime(t float64) {
+	tl.time = max(t, 0)
+}
+
+// Selected returns the selected clips, in track order.
+func (tl *Timeline) Selected() []*TimelineClip {
+	var clips []*TimelineClip
+	for _, track := range tl.Tracks {
+		for _, clip := range track.Clips {
+			if tl.selected[clip] {
+				clips = append(clips, clip)
+			}
+		}
+	}
+	return clips
+}
+
+// Select selects clip, adding it to the selection when add is set.
+func (tl *Timeline) Select(clip *TimelineClip, add bool) {
+	if !add {
+		clear(tl.selected)
+	}
+	tl.selected[clip] = true
+}
+
+// ClearSelection deselects every clip.
+func (tl *Timeline) ClearSelection() {
+	clear(tl.selected)
+}
+
+// timelineView maps time to the x axis of the track area.
+type timelineView struct {
+	left   float32 // screen x of the track area
+	width  float32
+	scroll float64 // time at left
+	zoom   float32 // pixels per unit
+}
+
+func (v timelineView) toX(t float64) float32 {
+	return v.left + float32(t-v.scroll)*v.zoom
+}
+
+func (v timelineView) toTime(x float32) float64 {
+	return v.scroll + float64((x-v.left)/v.zoom)
+}
+
+// beatsPerBar returns the bar length, defaulting to 4.
+func (tl *Timeline) beatsPerBar() int {
+	if tl.BeatsPerBar <= 0 {
+		return 4
+	}
+	return tl.BeatsPerBar
+}
+
+// extent returns the length of the timeline.
+func (tl *Timeline) extent() float64 {
+	if tl.Length > 0 {
+		return tl.Length
+	}
+	end := 0.0
+	for _, track := range tl.Tracks {
+		for _, clip := range track.Clips {
+			end = max(end, clip.End())
+		}
+	}
+	return end
+}
+
+// trackHeight returns the height of a track row.
+func trackHeight(track *TimelineTrack) float32 {
+	if track.Height > 0 {
+		return track.Height
+	}
+	return imgui.FrameHeight() * 2
+}
+
+// tracksHeight returns the height of all the track rows.
+func (tl *Timeline) tracksHeight() float32 {
+	total := float32(0)
+	for _, track := range tl.Tracks {
+		total += trackHeight(track)
+	}
+	return total
+}
+
+// snapStep returns the grid positions snap to: Snap, or none while Shift is held.
+func (tl *Timeline) snapStep() float64 {
+	if imgui.CurrentIO().KeyShift() {
+		return 0
+	}
+	return max(tl.Snap, 0)
+}
+
+// snapTime rounds t to a multiple of step (0 = no snapping).
+func snapTime(t, step float64) float64 {
+	if step <= 0 {
+		return t
+	}
+	return math.Round(t/step) * step
+}
+
+// Draw implements Component.
+func (tl *Timeline) Draw(state *State) {
+	if !tl.Visible {
+		return
+	}
+	if tl.selected == nil {
+		tl.selected = make(map[*TimelineClip]bool)
+	}
+	if tl.zoom <= 0 {
+		tl.zoom = TimelineDefaultZoom
+	}
+	imgui.PushIDStr(componentID("timeline", tl))
+	defer imgui.PopID()
+
+	pos := imgui.CursorScreenPos()
+	size := imgui.ContentRegionAvail()
+	if tl.Height > 0 {
+		size.Y = tl.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	header := tl.HeaderWidth
+	if header <= 0 {
+		header = TimelineDefaultHeaderWidth
+	}
+	header = min(header, size.X/2)
+	rulerHeight := imgui.FrameHeight()
+
+	imgui.InvisibleButton("##timeline", size)
+	hovered, active := imgui.IsItemHovered(), imgui.IsItemActive()
+	view := timelineView{left: pos.X + header, width: size.X - header, scroll: tl.scrollX, zoom: tl.zoom}
+	tracksTop := pos.Y + rulerHeight
+	tl.viewSize = imgui.Vec2{X: view.width, Y: size.Y - rulerHeight}
+
+	tl.handleWheel(hovered, &view, size.Y-rulerHeight)
+	tl.handleMouse(view, pos, header, tracksTop, hovered, active)
+
+	drawList := imgui.WindowDrawList()
+	drawList.PushClipRectV(pos, pos.Add(size), true)
+	tl.drawTracks(drawList, view, pos, size, header, tracksTop)
+	tl.drawRuler(drawList, view, pos, header, rulerHeight)
+	tl.drawPlayhead(drawList, view, pos, size, rulerHeight)
+	drawList.PopClipRect()
+
+	drawContainerExtensions(&tl.Container, state)
+}
+
+// handleWheel zooms around the pointer (Ctrl), scrolls time (Shift) or scrolls tracks.
+func (tl *Timeline) handleWheel(hovered bool, view *timelineView, tracksHeight float32) {
+	io := imgui.CurrentIO()
+	wheel := io.MouseWheel()
+	if !hovered || wheel == 0 {
+		return
+	}
+	switch {
+	case io.KeyCtrl():
+		mouseX := imgui.MousePos().X
+		at := view.toTime(mouseX)
+		tl.zoom = clamp(tl.zoom*float32(math.Pow(timelineZoomStep, float64(wheel))), TimelineMinZoom, TimelineMaxZoom)
+		tl.scrollX = at - float64((mouseX-view.left)/tl.zoom)
+	case io.KeyShift():
+		tl.scrollX -= float64(wheel * view.width / 10 / tl.zoom)
+	default:
+		tl.scrollY = clamp(tl.scrollY-wheel*imgui.TextLineHeightWithSpacing()*3, 0, max(tl.tracksHeight()-tracksHeight, 0))
+	}
+	tl.scrollX = max(tl.scrollX, 0)
+	view.scroll, view.zoom = tl.scrollX, tl.zoom
+}
+
+// handleMouse seeks, selects, moves and resizes.
+func (tl *Timeline) handleMouse(view timelineView, pos imgui.Vec2, header, tracksTop float32, hovered, active bool) {
+	mouse := imgui.MousePos()
+	if hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && mouse.X >= view.left {
+		if mouse.Y < tracksTop {
+			tl.drag = timelineDragSeek
+		} else {
+			tl.startClipDrag(view, mouse, tracksTop)
+		}
+	}
+	if !active {
+		tl.endDrag()
+		if hovered && mouse.Y >= tracksTop {
+			if _, mode := tl.clipAt(view, mouse, tracksTop); mode == timelineDragResizeStart || mode == timelineDragResizeEnd {
+				imgui.SetMouseCursor(imgui.MouseCursorResizeEW)
+			}
+		}
+		return
+	}
+
+	at, step := view.toTime(mouse.X), tl.snapStep()
+	switch tl.drag {
+	case timelineDragSeek:
+		t := snapTime(max(at, 0), step)
+		if t != tl.time {
+			tl.time = t
+			if tl.OnSeek != nil {
+				tl.OnSeek(t)
+			}
+		}
+	case timelineDragMove:
+		tl.moveClips(at-tl.dragFrom, step)
+	case timelineDragResizeStart, timelineDragResizeEnd:
+		imgui.SetMouseCursor(imgui.MouseCursorResizeEW)
+		minLength := float64(timelineMinClipPixels / view.zoom)
+		if step > 0 {
+			minLength = step
+		}
+		start := tl.dragStart[tl.dragClip]
+		tl.dragClip.Start, tl.dragClip.Length = resizeClip(start[0], start[1], snapTime(at, step), minLength, tl.drag == timelineDragResizeStart)
+	}
+}
+
+// startClipDrag selects the clip under the pointer and starts moving or resizing it, or
+// clears the selection when there is none.
+func (tl *Timeline) startClipDrag(view timelineView, mouse imgui.Vec2, tracksTop float32) {
+	clip, mode := tl.clipAt(view, mouse, tracksTop)
+	ctrl := imgui.CurrentIO().KeyCtrl()
+	if clip == nil {
+		if !ctrl && len(tl.selected) > 0 {
+			tl.ClearSelection()
+			tl.selectionChanged()
+		}
+		return
+	}
+	switch {
+	case ctrl && tl.selected[clip]:
+		delete(tl.selected, clip)
+		tl.selectionChanged()
+		return
+	case ctrl:
+		tl.Select(clip, true)
+		tl.selectionChanged()
+	case !tl.selected[clip]:
+		tl.Select(clip, false)
+		tl.selectionChanged()
+	}
+	tl.drag, tl.dragClip, tl.dragFrom = mode, clip, view.toTime(mouse.X)
+	tl.dragStart = make(map[*TimelineClip][2]float64)
+	if mode == timelineDragMove {
+		for selected := range tl.selected {
+			tl.dragStart[selected] = [2]float64{selected.Start, selected.Length}
+		}
+	} else {
+		tl.dragStart[clip] = [2]float64{clip.Start, clip.Length}
+	}
+}
+
+// moveClips moves the dragged clips by delta from where the drag started, snapping the
+// clip under the pointer to step and keeping every clip at or after 0.
+func (tl *Timeline) moveClips(delta, step float64) {
+	anchor := tl.dragStart[tl.dragClip][0]
+	delta = snapTime(anchor+delta, step) - anchor
+	for _, start := range tl.dragStart {
+		delta = max(delta, -start[0])
+	}
+	for clip, start := range tl.dragStart {
+		clip.Start = start[0] + delta
+	}
+}
+
+// resizeClip moves one end of a clip to t, keeping it at least minLength long and the
+// start at or after 0. returns the new start and length.
+func resizeClip(start, length, t, minLength float64, fromStart bool) (float64, float64) {
+	if fromStart {
+		end := start + length
+		start = max(min(t, end-minLength), 0)
+		return start, end - start
+	}
+	return start, max(t-start, minLength)
+}
+
+// endDrag finishes a drag, reporting the clips it changed.
+func (tl *Timeline) endDrag() {
+	if tl.drag == timelineDragMove || tl.drag == timelineDragResizeStart || tl.drag == timelineDragResizeEnd {
+		var changed []*TimelineClip
+		for _, track := range tl.Tracks {
+			for _, clip := range track.Clips {
+				if start, found := tl.dragStart[clip]; found && (start[0] != clip.Start || start[1] != clip.Length) {
+					changed = append(changed, clip)
+				}
+			}
+		}
+		if len(changed) > 0 && tl.OnClipsChange != nil {
+			tl.OnClipsChange(changed)
+		}
+	}
+	tl.drag, tl.dragClip, tl.dragStart = timelineDragNone, nil, nil
+}
+
+func (tl *Timeline) selectionChanged() {
+	if tl.OnSelect != nil {
+		tl.OnSelect(tl.Selected())
+	}
+}
+
+// clipAt returns the clip under the pointer and whether the pointer is on its body or
+// one of its ends.
+func (tl *Timeline) clipAt(view timelineView, mouse imgui.Vec2, tracksTop float32) (*TimelineClip, int) {
+	y := tracksTop - tl.scrollY
+	for _, track := range tl.Tracks {
+		height := trackHeight(track)
+		if mouse.Y >= y && mouse.Y < y+height {
+			for i := len(track.Clips) - 1; i >= 0; i-- {
+				if mode := clipRegion(view, track.Clips[i], mouse.X); mode != timelineDragNone {
+					return track.Clips[i], mode
+				}
+			}
+			return nil, timelineDragNone
+		}
+		y += height
+	}
+	return nil, timelineDragNone
+}
+
+// clipRegion returns the drag a press at x starts on clip: resizing near either end
+// (inside the clip, or just outside short clips), moving elsewhere on it, or none.
+func clipRegion(view timelineView, clip *TimelineClip, x float32) int {
+	x0, x1 := view.toX(clip.Start), view.toX(clip.End())
+	grab := min(float32(timelineEdgeGrab), (x1-x0)/3)
+	switch {
+	case x < x0-1 || x > x1+1:
+		return timelineDragNone
+	case x <= x0+grab:
+		return timelineDragResizeStart
+	case x >= x1-grab:
+		return timelineDragResizeEnd
+	default:
+		return timelineDragMove
+	}
+}
+
+// drawTracks draws the track names, grid and clips.
+func (tl *Timeline) drawTracks(drawList *imgui.DrawList, view timelineView, pos, size imgui.Vec2, header, tracksTop float32) {
+	style := imgui.CurrentStyle()
+	colors := style.Colors()
+	bottom := pos.Y + size.Y
+	right := pos.X + size.X
+	drawList.AddRectFilled(imgui.Vec2{X: pos.X, Y: tracksTop}, imgui.Vec2{X: right, Y: bottom}, imgui.ColorConvertFloat4ToU32(colors[imgui.ColFrameBg]))
+
+	// snap grid, with bar lines stronger
+	grid := colors[imgui.ColBorder]
+	grid.W *= 0.4
+	if tl.Snap > 0 && float32(tl.Snap)*view.zoom >= curveEditorMinGridGap {
+		first := math.Ceil(view.toTime(view.left)/tl.Snap) * tl.Snap
+		for t := first; view.toX(t) <= right; t += tl.Snap {
+			drawList.AddLine(imgui.Vec2{X: view.toX(t), Y: tracksTop}, imgui.Vec2{X: view.toX(t), Y: bottom}, imgui.ColorConvertFloat4ToU32(grid))
+		}
+	}
+	if extent := tl.extent(); extent > 0 {
+		endX := view.toX(extent)
+		drawList.AddLine(imgui.Vec2{X: endX, Y: tracksTop}, imgui.Vec2{X: endX, Y: bottom}, imgui.ColorConvertFloat4ToU32(colors[imgui.ColBorder]))
+	}
+
+	textColor := imgui.ColorConvertFloat4ToU32(colors[imgui.ColText])
+	headerColor := imgui.ColorConvertFloat4ToU32(colors[imgui.ColHeader])
+	separator := imgui.ColorConvertFloat4ToU32(colors[imgui.ColBorder])
+	y := tracksTop - tl.scrollY
+	for _, track := range tl.Tracks {
+		height := trackHeight(track)
+		if y+height >= tracksTop && y <= bottom {
+			drawList.PushClipRectV(imgui.Vec2{X: view.left, Y: tracksTop}, imgui.Vec2{X: right, Y: bottom}, true)
+			for _, clip := range track.Clips {
+				tl.drawClip(drawList, view, clip, y, height, textColor)
+			}
+			drawList.PopClipRect()
+
+			drawList.PushClipRectV(imgui.Vec2{X: pos.X, Y: tracksTop}, imgui.Vec2{X: view.left, Y: bottom}, true)
+			drawList.AddRectFilled(imgui.Vec2{X: pos.X, Y: y}, imgui.Vec2{X: view.left, Y: y + height}, headerColor)
+			drawList.AddTextVec2(imgui.Vec2{X: pos.X + style.FramePadding().X, Y: y + (height-imgui.TextLineHeight())/2}, textColor, track.Name)
+			drawList.PopClipRect()
+			drawList.AddLine(imgui.Vec2{X: pos.X, Y: y + height}, imgui.Vec2{X: right, Y: y + height}, separator)
+		}
+		y += height
+	}
+}
+
+// drawClip draws a clip in its track row, outlined when selected.
+func (tl *Timeline) drawClip(drawList *imgui.DrawList, view timelineView, clip *TimelineClip, y, height float32, textColor uint32) {
+	rounding := imgui.CurrentStyle().FrameRounding()
+	minPos := imgui.Vec2{X: view.toX(clip.Start), Y: y + 2}
+	maxPos := imgui.Vec2{X: max(view.toX(clip.End()), minPos.X+1), Y: y + height - 2}
+	fill := themeColor(clip.Color, ThemeColors().Accent)
+	fill.W *= 0.65
+	drawList.AddRectFilledV(minPos, maxPos, imgui.ColorConvertFloat4ToU32(fill), rounding, imgui.DrawFlagsNone)
+	if tl.selected[clip] {
+		drawList.AddRectV(minPos, maxPos, textColor, rounding, imgui.DrawFlagsNone, 2)
+	}
+	if clip.Label != "" {
+		drawList.PushClipRectV(minPos, maxPos, true)
+		padding := imgui.CurrentStyle().FramePadding().X
+		drawList.AddTextVec2(imgui.Vec2{X: minPos.X + padding, Y: minPos.Y + 2}, textColor, clip.Label)
+		drawList.PopClipRect()
+	}
+}
+
+// drawRuler draws the time ruler with labelled ticks.
+func (tl *Timeline) drawRuler(drawList *imgui.DrawList, view timelineView, pos imgui.Vec2, header, height float32) {
+	colors := imgui.CurrentStyle().Colors()
+	right := view.left + view.width
+	drawList.AddRectFilled(pos, imgui.Vec2{X: right, Y: pos.Y + height}, imgui.ColorConvertFloat4ToU32(colors[imgui.ColMenuBarBg]))
+	textColor := imgui.ColorConvertFloat4ToU32(colors[imgui.ColText])
+	tickColor := imgui.ColorConvertFloat4ToU32(colors[imgui.ColBorder])
+
+	step := rulerStep(tl.Units, tl.beatsPerBar(), view.zoom)
+	drawList.PushClipRectV(imgui.Vec2{X: view.left, Y: pos.Y}, imgui.Vec2{X: right, Y: pos.Y + height}, true)
+	first := math.Floor(view.scroll/step) * step
+	for t := first; view.toX(t) <= right; t += step {
+		x := view.toX(t)
+		drawList.AddLine(imgui.Vec2{X: x, Y: pos.Y + height/2}, imgui.Vec2{X: x, Y: pos.Y + height}, tickColor)
+		drawList.AddTextVec2(imgui.Vec2{X: x + 3, Y: pos.Y}, textColor, rulerLabel(tl.Units, tl.beatsPerBar(), t, step))
+	}
+	drawList.PopClipRect()
+}
+
+// drawPlayhead draws the playhead across the ruler and tracks.
+func (tl *Timeline) drawPlayhead(drawList *imgui.DrawList, view timelineView, pos, size imgui.Vec2, rulerHeight float32) {
+	x := view.toX(tl.time)
+	if x < view.left || x > view.left+view.width {
+		return
+	}
+	color := imgui.ColorConvertFloat4ToU32(ThemeColors().Accent)
+	drawList.AddLineV(imgui.Vec2{X: x, Y: pos.Y}, imgui.Vec2{X: x, Y: pos.Y + size.Y}, color, 2)
+	half := rulerHeight / 3
+	drawList.AddTriangleFilled(imgui.Vec2{X: x - half, Y: pos.Y}, imgui.Vec2{X: x + half, Y: pos.Y}, imgui.Vec2{X: x, Y: pos.Y + half}, color)
+}
+
+// rulerSecondSteps and rulerBeatSteps are the tick spacings the ruler chooses from.
+var (
+	rulerSecondSteps = []float64{0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1, 2, 5, 10, 15, 30, 60, 120, 300, 600}
+	rulerBeatSteps   = []float64{0.25, 0.5, 1}
+)
+
+// rulerStep returns the tick spacing, in units, that keeps labelled ticks at least
+// timelineMinTickGap pixels apart at zoom.
+func rulerStep(units TimelineUnits, beatsPerBar int, zoom float32) float64 {
+	minStep := float64(timelineMinTickGap / zoom)
+	if units == TimelineBeats {
+		for _, step := range rulerBeatSteps {
+			if step >= minStep {
+				return step
+			}
+		}
+		bar := float64(beatsPerBar)
+		for bars := 1.0; ; bars *= 2 {
+			if bars*bar >= minStep {
+				return bars * bar
+			}
+		}
+	}
+	for _, step := range rulerSecondSteps {
+		if step >= minStep {
+			return step
+		}
+	}
+	return rulerSecondSteps[len(rulerSecondSteps)-1] * math.Ceil(minStep/rulerSecondSteps[len(rulerSecondSteps)-1])
+}
+
+// rulerLabel labels a tick at t: "bar" or "bar.beat" (both from 1) for beats, and
+// seconds ("1.5s") or minutes and seconds ("2:05") for seconds.
+func rulerLabel(units TimelineUnits, beatsPerBar int, t, step float64) string {
+	if units == TimelineBeats {
+		bar := int(math.Floor(t/float64(beatsPerBar)+1e-9)) + 1
+		beat := t - float64(bar-1)*float64(beatsPerBar)
+		if math.Abs(beat) < 1e-9 {
+			return strconv.Itoa(bar)
+		}
+		return strconv.Itoa(bar) + "." + strconv.FormatFloat(beat+1, 'f', -1, 64)
+	}
+	decimals := 0
+	for s := step; s < 1 && decimals < 3; s *= 10 {
+		decimals++
+	}
+	if t >= 60 {
+		minutes := int(t / 60)
+		seconds := CurrentFormat().FormatFloat(t-float64(minutes*60), decimals)
+		if t-float64(minutes*60) < 10 {
+			seconds = "0" + seconds
+		}
+		return strconv.Itoa(minutes) + ":" + seconds
+	}
+	return CurrentFormat().FormatFloat(t, decimals) + "s"
+}
+
+// MinimapBounds implements MinimapSource. the content is measured in pixels at the
+// current zoom: time across and the track rows down.
+func (tl *Timeline) MinimapBounds() (imgui.Vec2, imgui.Vec2) {
+	return imgui.Vec2{}, imgui.Vec2{X: float32(tl.extent()) * tl.zoom, Y: tl.tracksHeight()}
+}
+
+// MinimapViewport implements MinimapSource.
+func (tl *Timeline) MinimapViewport() (imgui.Vec2, imgui.Vec2) {
+	origin := imgui.Vec2{X: float32(tl.scrollX) * tl.zoom, Y: tl.scrollY}
+	return origin, origin.Add(tl.viewSize)
+}
+
+// MinimapScrollTo implements MinimapSource.
+func (tl *Timeline) MinimapScrollTo(origin imgui.Vec2) {
+	tl.scrollX = max(float64(origin.X/tl.zoom), 0)
+	tl.scrollY = clamp(origin.Y, 0, max(tl.tracksHeight()-tl.viewSize.Y, 0))
+}
+
+// DrawMinimap implements MinimapPainter, drawing clips as blocks and the playhead.
+func (tl *Timeline) DrawMinimap(drawList *imgui.DrawList, toMinimap func(imgui.Vec2) imgui.Vec2) {
+	y := float32(0)
+	for _, track := range tl.Tracks {
+		height := trackHeight(track)
+		for _, clip := range track.Clips {
+			fill := themeColor(clip.Color, ThemeColors().Accent)
+			fill.W *= 0.65
+			minPos := toMinimap(imgui.Vec2{X: float32(clip.Start) * tl.zoom, Y: y + 1})
+			maxPos := toMinimap(imgui.Vec2{X: float32(clip.End()) * tl.zoom, Y: y + height - 1})
+			drawList.AddRectFilled(minPos, imgui.Vec2{X: max(maxPos.X, minPos.X+1), Y: maxPos.Y}, imgui.ColorConvertFloat4ToU32(fill))
+		}
+		y += height
+	}
+	x := float32(tl.time) * tl.zoom
+	drawList.AddLine(toMinimap(imgui.Vec2{X: x}), toMinimap(imgui.Vec2{X: x, Y: y}), imgui.ColorConvertFloat4ToU32(ThemeColors().Accent))
+}
+
+// CaptureState implements StatefulComponent.
+func (tl *Timeline) CaptureState() map[string]any {
+	return map[string]any{"zoom": tl.zoom, "scrollX": tl.scrollX, "scrollY": tl.scrollY, "time": tl.time}
+}
+
+// RestoreState implements StatefulComponent.
+func (tl *Timeline) RestoreState(state map[string]any) {
+	if zoom, ok := stateFloat(state["zoom"]); ok {
+		tl.zoom = clamp(float32(zoom), TimelineMinZoom, TimelineMaxZoom)
+	}
+	if scrollX, ok := stateFloat(state["scrollX"]); ok {
+		tl.scrollX = max(scrollX, 0)
+	}
+	if scrollY, ok := stateFloat(state["scrollY"]); ok {
+		tl.scrollY = max(float32(scrollY), 0)
+	}
+	if t, ok := stateFloat(state["time"]); ok {
+		tl.time = max(t, 0)
+	}
+}
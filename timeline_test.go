@@ -0,0 +1,136 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestTimelineView(t *testing.T) {
+	view := timelineView{left: 100, width: 400, scroll: 2, zoom: 50}
+	if x := view.toX(4); x != 200 {
+		t.Errorf("expected 4 at x 200, got %v", x)
+	}
+	if at := view.toTime(350); at != 7 {
+		t.Errorf("expected x 350 at 7, got %v", at)
+	}
+}
+
+func TestRulerStep(t *testing.T) {
+	tests := []struct {
+		units    TimelineUnits
+		zoom     float32
+		expected float64
+	}{
+		{TimelineSeconds, 50, 2},
+		{TimelineSeconds, 1000, 0.1},
+		{TimelineSeconds, 0.05, 1200},
+		{TimelineBeats, 240, 0.25},
+		{TimelineBeats, 60, 1},
+		{TimelineBeats, 10, 8},
+	}
+	for _, tt := range tests {
+		if got := rulerStep(tt.units, 4, tt.zoom); got != tt.expected {
+			t.Errorf("rulerStep(%v, %v) = %v, expected %v", tt.units, tt.zoom, got, tt.expected)
+		}
+	}
+}
+
+func TestRulerLabel(t *testing.T) {
+	tests := []struct {
+		units    TimelineUnits
+		at, step float64
+		expected string
+	}{
+		{TimelineBeats, 0, 1, "1"},
+		{TimelineBeats, 4, 1, "2"},
+		{TimelineBeats, 5, 1, "2.2"},
+		{TimelineBeats, 5.5, 0.5, "2.2.5"},
+		{TimelineSeconds, 1.5, 0.5, "1.5s"},
+		{TimelineSeconds, 30, 10, "30s"},
+		{TimelineSeconds, 125, 5, "2:05"},
+	}
+	for _, tt := range tests {
+		if got := rulerLabel(tt.units, 4, tt.at, tt.step); got != tt.expected {
+			t.Errorf("rulerLabel(%v, %v, %v) = %q, expected %q", tt.units, tt.at, tt.step, got, tt.expected)
+		}
+	}
+}
+
+func TestClipRegion(t *testing.T) {
+	view := timelineView{zoom: 10}
+	clip := &TimelineClip{Start: 2, Length: 4} // x 20..60
+	tests := []struct {
+		x        float32
+		expected int
+	}{
+		{10, timelineDragNone},
+		{21, timelineDragResizeStart},
+		{40, timelineDragMove},
+		{58, timelineDragResizeEnd},
+		{70, timelineDragNone},
+	}
+	for _, tt := range tests {
+		if got := clipRegion(view, clip, tt.x); got != tt.expected {
+			t.Errorf("clipRegion at %v = %v, expected %v", tt.x, got, tt.expected)
+		}
+	}
+}
+
+func TestResizeClip(t *testing.T) {
+	if start, length := resizeClip(2, 4, 8, 1, false); start != 2 || length != 6 {
+		t.Errorf("expected the end moved to 8, got %v+%v", start, length)
+	}
+	if start, length := resizeClip(2, 4, 1, 1, false); start != 2 || length != 1 {
+		t.Errorf("expected the minimum length, got %v+%v", start, length)
+	}
+	if start, length := resizeClip(2, 4, 3, 1, true); start != 3 || length != 3 {
+		t.Errorf("expected the start moved to 3, got %v+%v", start, length)
+	}
+	if start, length := resizeClip(2, 4, -1, 1, true); start != 0 || length != 6 {
+		t.Errorf("expected the start kept at 0, got %v+%v", start, length)
+	}
+}
+
+func TestTimelineMoveClips(t *testing.T) {
+	a := &TimelineClip{Start: 1, Length: 2}
+	b := &TimelineClip{Start: 4, Length: 1}
+	tl := NewTimeline(TimelineBeats, &TimelineTrack{Clips: []*TimelineClip{a, b}})
+	tl.dragClip = b
+	tl.dragStart = map[*TimelineClip][2]float64{a: {a.Start, a.Length}, b: {b.Start, b.Length}}
+
+	tl.moveClips(1.2, 0.5)
+	if a.Start != 2 || b.Start != 5 {
+		t.Errorf("expected both clips moved by the snapped 1, got %v and %v", a.Start, b.Start)
+	}
+	tl.moveClips(-3, 0)
+	if a.Start != 0 || b.Start != 3 {
+		t.Errorf("expected the move stopped at 0, got %v and %v", a.Start, b.Start)
+	}
+}
+
+func TestTimelineSelection(t *testing.T) {
+	a, b := &TimelineClip{Start: 0, Length: 1}, &TimelineClip{Start: 1, Length: 1}
+	tl := NewTimeline(TimelineSeconds, &TimelineTrack{Clips: []*TimelineClip{a}}, &TimelineTrack{Clips: []*TimelineClip{b}})
+	tl.Select(b, false)
+	tl.Select(a, true)
+	if selected := tl.Selected(); len(selected) != 2 || selected[0] != a || selected[1] != b {
+		t.Errorf("expected both clips in track order, got %v", selected)
+	}
+	tl.Select(b, false)
+	if selected := tl.Selected(); len(selected) != 1 || selected[0] != b {
+		t.Errorf("expected only b selected, got %v", selected)
+	}
+	if tl.extent() != 2 {
+		t.Errorf("expected the extent at the last clip end, got %v", tl.extent())
+	}
+}
+
+func TestTimelineState(t *testing.T) {
+	tl := NewTimeline(TimelineSeconds)
+	tl.zoom, tl.scrollX, tl.scrollY = 120, 8, 30
+	tl.SetTime(3.5)
+	restored := NewTimeline(TimelineSeconds)
+	restored.RestoreState(tl.CaptureState())
+	if restored.zoom != 120 || restored.scrollX != 8 || restored.scrollY != 30 || restored.Time() != 3.5 {
+		t.Errorf("expected the view and playhead restored, got %v %v %v %v", restored.zoom, restored.scrollX, restored.scrollY, restored.Time())
+	}
+}
@@ -0,0 +1,169 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// TitleBar constants
+const (
+	TitleBarDefaultHeight = 32
+	titleBarButtonAspect  = 1.5 // width of the window buttons, relative to the bar height
+)
+
+// TitleBar is a dfx-drawn title bar for frameless windows (Config.Frameless): the window
+// icon and title, optional widgets such as menus, and minimize, maximize and close buttons.
+// dragging the bar moves the window and double-clicking it toggles maximize.
+type TitleBar struct {
+	Container
+	Title      string    // title shown (empty = the window title)
+	Icon       *Texture  // drawn before the title (nil = none)
+	Height     float32   // bar height (0 = TitleBarDefaultHeight)
+	Content    Component // optional widgets drawn after the title, such as menus or a search field
+	OnMinimize func()    // minimizes the window; the minimize button is only shown when set
+	NoMaximize bool      // hides the maximize button, and double-clicking does nothing
+}
+
+// NewTitleBar creates a title bar showing the window title.
+func NewTitleBar() *TitleBar {
+	return &TitleBar{Container: Container{Visible: true}}
+}
+
+// height returns the bar height.
+func (t *TitleBar) height() float32 {
+	if t.Height > 0 {
+		return t.Height
+	}
+	return TitleBarDefaultHeight
+}
+
+// Draw implements Component, drawing the bar into the current window.
+func (t *TitleBar) Draw(state *State) {
+	if !t.Visible {
+		return
+	}
+	imgui.PushIDStr(componentID("titleBar", t))
+	defer imgui.PopID()
+
+	height := t.height()
+	origin := imgui.CursorScreenPos()
+	width := imgui.ContentRegionAvail().X
+	buttons := t.buttonCount()
+	buttonWidth := height * titleBarButtonAspect
+
+	// the bar itself is an invisible button behind the widgets, grabbed to move the window
+	imgui.SetNextItemAllowOverlap()
+	imgui.InvisibleButton("##grab", imgui.Vec2{X: max(width-float32(buttons)*buttonWidth, 1), Y: height})
+	if state.App != nil {
+		if !t.NoMaximize && imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+			state.App.ToggleMaximize()
+		} else if imgui.IsItemActivated() {
+			state.App.beginWindowMove()
+		}
+	}
+
+	// icon, title and content
+	pad := imgui.CurrentStyle().WindowPadding().X
+	imgui.SetCursorScreenPos(imgui.Vec2{X: origin.X + pad, Y: origin.Y})
+	if t.Icon != nil {
+		iconSize := height * 0.6
+		imgui.SetCursorScreenPos(imgui.Vec2{X: origin.X + pad, Y: origin.Y + (height-iconSize)/2})
+		DrawImage(t.Icon, imgui.Vec2{X: iconSize, Y: iconSize}, ImageFit)
+		imgui.SameLine()
+	}
+	imgui.SetCursorScreenPos(imgui.Vec2{X: imgui.CursorScreenPos().X, Y: origin.Y + (height-imgui.TextLineHeight())/2})
+	imgui.TextUnformatted(t.title(state))
+	if t.Content != nil {
+		imgui.SameLine()
+		imgui.SetCursorScreenPos(imgui.Vec2{X: imgui.CursorScreenPos().X, Y: origin.Y + (height-imgui.FrameHeight())/2})
+		DrawChild(t.Content, state.WithParent(t))
+	}
+
+	// window buttons, right-aligned
+	imgui.SetCursorScreenPos(imgui.Vec2{X: origin.X + width - float32(buttons)*buttonWidth, Y: origin.Y})
+	t.drawButtons(state, imgui.Vec2{X: buttonWidth, Y: height})
+
+	drawContainerExtensions(&t.Container, state)
+}
+
+// title returns the title shown.
+func (t *TitleBar) title(state *State) string {
+	if t.Title != "" || state.App == nil {
+		return t.Title
+	}
+	return state.App.title
+}
+
+// buttonCount returns the number of window buttons shown.
+func (t *TitleBar) buttonCount() int {
+	count := 1
+	if t.OnMinimize != nil {
+		count++
+	}
+	if !t.NoMaximize {
+		count++
+	}
+	return count
+}
+
+// drawButtons draws the minimize, maximize and close buttons.
+func (t *TitleBar) drawButtons(state *State, size imgui.Vec2) {
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameRounding, 0)
+	imgui.PushStyleVarVec2(imgui.StyleVarItemSpacing, imgui.Vec2{})
+	imgui.PushStyleColorVec4(imgui.ColButton, imgui.Vec4{})
+	defer func() {
+		imgui.PopStyleColor()
+		imgui.PopStyleVarV(2)
+	}()
+
+	if t.OnMinimize != nil {
+		if titleBarButton(fonts.ICON_MINIMIZE, T("dfx.title.minimize"), size) {
+			t.OnMinimize()
+		}
+		imgui.SameLine()
+	}
+	if !t.NoMaximize {
+		icon, tooltip := fonts.ICON_CROP_SQUARE, T("dfx.title.maximize")
+		if state.App != nil && state.App.Maximized() {
+			icon, tooltip = fonts.ICON_FILTER_NONE, T("dfx.title.restore")
+		}
+		if titleBarButton(icon, tooltip, size) && state.App != nil {
+			state.App.ToggleMaximize()
+		}
+		imgui.SameLine()
+	}
+
+	imgui.PushStyleColorVec4(imgui.ColButtonHovered, ThemeColors().Error)
+	imgui.PushStyleColorVec4(imgui.ColButtonActive, ThemeColors().Error)
+	if titleBarButton(fonts.ICON_CLOSE, T("dfx.title.close"), size) && state.App != nil {
+		state.App.Close()
+	}
+	imgui.PopStyleColorV(2)
+}
+
+// titleBarButton draws a window button filling size.
+func titleBarButton(icon, tooltip string, size imgui.Vec2) bool {
+	clicked := imgui.ButtonV(icon+"##"+tooltip, size)
+	imgui.SetItemTooltip(tooltip)
+	return clicked
+}
+
+// drawTitleBar draws the app's title bar along the top of the viewport. returns its height.
+func (app *App) drawTitleBar() float32 {
+	t := app.titleBar
+	if t == nil || !t.Visible {
+		return 0
+	}
+	height := t.height()
+	flags := imgui.WindowFlagsNoTitleBar | imgui.WindowFlagsNoSavedSettings |
+		imgui.WindowFlagsNoScrollbar | imgui.WindowFlagsNoScrollWithMouse
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{})
+	imgui.PushStyleVarFloat(imgui.StyleVarWindowBorderSize, 0)
+	open := imgui.InternalBeginViewportSideBar("##dfx_titlebar", imgui.MainViewport(), imgui.DirUp, height, flags)
+	imgui.PopStyleVarV(2)
+	if open {
+		DrawChild(t, app.frameState(imgui.Vec2{X: imgui.MainViewport().Size().X, Y: height}))
+	}
+	imgui.End()
+	return height
+}
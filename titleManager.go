@@ -0,0 +1,98 @@
+package dfx
+
+import "strings"
+
+// TitleSeparator separates the parts of titles composed by FormatTitle.
+const TitleSeparator = " - "
+
+// TitleParts are the parts a window title is composed from.
+type TitleParts struct {
+	App       string // application name
+	Document  string // open document, such as a file name (empty = none)
+	Dirty     bool   // the document has unsaved changes
+	Workspace string // current workspace (empty = none)
+}
+
+// FormatTitle composes "*document - workspace - app", leaving out empty parts. the dirty
+// marker goes before the first part shown.
+func FormatTitle(p TitleParts) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{p.Document, p.Workspace, p.App} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	title := strings.Join(parts, TitleSeparator)
+	if p.Dirty {
+		title = "*" + title
+	}
+	return title
+}
+
+// TitleManager composes the window title from the application name, the open document,
+// whether it has unsaved changes and the current workspace, and sets it in the frame after
+// any of them changes, instead of the app formatting and setting the title itself. get the
+// app's manager with App.Titles. UI thread only; use App.Dispatch from other goroutines.
+type TitleManager struct {
+	Format    func(TitleParts) string // composes the title (nil = FormatTitle)
+	Workspace *Workspace              // when set, the name of its current workspace is followed
+	Undo      *UndoSystem             // when set, the document is also dirty while it is not clean
+
+	parts   TitleParts
+	applied string // title last set, so titles set with App.SetWindowTitle stay until a part changes
+}
+
+// newTitleManager creates a manager titling the app name.
+func newTitleManager(appName string) *TitleManager {
+	return &TitleManager{parts: TitleParts{App: appName}}
+}
+
+// SetAppName sets the application name.
+func (t *TitleManager) SetAppName(name string) {
+	t.parts.App = name
+}
+
+// SetDocumentTitle sets the open document, such as its file name (empty = none).
+func (t *TitleManager) SetDocumentTitle(document string) {
+	t.parts.Document = document
+}
+
+// SetDirty sets whether the document has unsaved changes.
+func (t *TitleManager) SetDirty(dirty bool) {
+	t.parts.Dirty = dirty
+}
+
+// SetWorkspaceTitle sets the current workspace shown, when Workspace is not followed.
+func (t *TitleManager) SetWorkspaceTitle(workspace string) {
+	t.parts.Workspace = workspace
+}
+
+// Parts returns the current parts, including those followed from Workspace and Undo.
+func (t *TitleManager) Parts() TitleParts {
+	p := t.parts
+	if t.Workspace != nil {
+		p.Workspace = t.Workspace.CurrentName()
+	}
+	if t.Undo != nil && !t.Undo.Clean() {
+		p.Dirty = true
+	}
+	return p
+}
+
+// Title returns the composed title.
+func (t *TitleManager) Title() string {
+	if t.Format != nil {
+		return t.Format(t.Parts())
+	}
+	return FormatTitle(t.Parts())
+}
+
+// update returns the composed title and whether it changed since it was last set.
+func (t *TitleManager) update() (string, bool) {
+	title := t.Title()
+	if title == t.applied {
+		return title, false
+	}
+	t.applied = title
+	return title, true
+}
@@ -0,0 +1,100 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestFormatTitle(t *testing.T) {
+	if got := FormatTitle(TitleParts{App: "My App"}); got != "My App" {
+		t.Fatalf("expected '%v', got '%v'", "My App", got)
+	}
+	if got := FormatTitle(TitleParts{App: "My App", Document: "song.dfx", Dirty: true, Workspace: "Mix"}); got != "*song.dfx - Mix - My App" {
+		t.Fatalf("expected '%v', got '%v'", "*song.dfx - Mix - My App", got)
+	}
+	if got := FormatTitle(TitleParts{App: "My App", Dirty: true}); got != "*My App" {
+		t.Fatalf("expected '%v', got '%v'", "*My App", got)
+	}
+}
+
+func TestTitleManager_UpdatesWhenPartsChange(t *testing.T) {
+	titles := newTitleManager("My App")
+	title, changed := titles.update()
+	if !changed {
+		t.Fatal("expected changed")
+	}
+	if title != "My App" {
+		t.Fatalf("expected '%v', got '%v'", "My App", title)
+	}
+	_, changed = titles.update()
+	if changed {
+		t.Fatal("unexpected changed")
+	}
+
+	titles.SetDocumentTitle("song.dfx")
+	title, changed = titles.update()
+	if !changed {
+		t.Fatal("expected changed")
+	}
+	if title != "song.dfx - My App" {
+		t.Fatalf("expected '%v', got '%v'", "song.dfx - My App", title)
+	}
+
+	titles.Format = func(p TitleParts) string { return p.App + ": " + p.Document }
+	if got := titles.Title(); got != "My App: song.dfx" {
+		t.Fatalf("expected '%v', got '%v'", "My App: song.dfx", got)
+	}
+}
+
+func TestTitleManager_FollowsWorkspaceAndUndo(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("edit", "Edit", NewFunc(func(state *State) {}))
+	ws.Add("mix", "Mix", NewFunc(func(state *State) {}))
+	undo := NewUndoSystem()
+
+	titles := newTitleManager("My App")
+	titles.Workspace = ws
+	titles.Undo = undo
+	titles.SetDocumentTitle("song.dfx")
+	if got := titles.Title(); got != "song.dfx - Edit - My App" {
+		t.Fatalf("expected '%v', got '%v'", "song.dfx - Edit - My App", got)
+	}
+
+	ws.Switch("mix")
+	value := 0
+	undo.Run(&undoTestCommand{value: &value, to: 1})
+	if got := titles.Title(); got != "*song.dfx - Mix - My App" {
+		t.Fatalf("expected '%v', got '%v'", "*song.dfx - Mix - My App", got)
+	}
+
+	undo.MarkClean()
+	if got := titles.Title(); got != "song.dfx - Mix - My App" {
+		t.Fatalf("expected '%v', got '%v'", "song.dfx - Mix - My App", got)
+	}
+}
+
+func TestApp_Titles(t *testing.T) {
+	app := New(NewFunc(func(state *State) {}), Config{Title: "window", Info: AppInfo{Name: "My App"}})
+	if got := app.Titles().Title(); got != "My App" {
+		t.Fatalf("expected '%v', got '%v'", "My App", got)
+	}
+	if app.Titles() != app.Titles() {
+		t.Fatal("expected the same instance")
+	}
+
+	app.updateTitle()
+	if app.title != "My App" {
+		t.Fatalf("expected '%v', got '%v'", "My App", app.title)
+	}
+
+	// a title set by hand stays until a part changes
+	app.SetWindowTitle("custom")
+	app.updateTitle()
+	if app.title != "custom" {
+		t.Fatalf("expected '%v', got '%v'", "custom", app.title)
+	}
+	app.Titles().SetDirty(true)
+	app.updateTitle()
+	if app.title != "*My App" {
+		t.Fatalf("expected '%v', got '%v'", "*My App", app.title)
+	}
+}
@@ -0,0 +1,687 @@
+package dfx
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TreeModel supplies the nodes displayed by a TreeView. nodes are identified by string
+// ids that must be unique within the tree; ids that stay the same across runs let the
+// expansion state and selection be persisted.
+type TreeModel interface {
+	// Children returns the ids of the children of the node with id, or the root nodes
+	// when id is empty. it is only called when a node is first expanded, so large or
+	// expensive trees are loaded lazily.
+	Children(id string) []string
+	// Label returns the text shown for the node.
+	Label(id string) string
+	// Icon returns an icon glyph shown before the label, or "" for none.
+	Icon(id string) string
+	// IsLeaf reports whether the node has no children (and no expansion arrow).
+	IsLeaf(id string) bool
+}
+
+// TreeItem is a node of a StaticTree.
+type TreeItem struct {
+	Id       string
+	Label    string
+	Icon     string
+	Children []*TreeItem
+}
+
+// StaticTree is a TreeModel over an in-memory hierarchy of TreeItems.
+type StaticTree struct {
+	roots []*TreeItem
+	items map[string]*TreeItem
+}
+
+// NewStaticTree creates a tree model with roots as its top-level nodes.
+func NewStaticTree(roots ...*TreeItem) *StaticTree {
+	st := &StaticTree{roots: roots, items: make(map[string]*TreeItem)}
+	var index func(items []*TreeItem)
+	index = func(items []*TreeItem) {
+		for _, item := range items {
+			st.items[item.Id] = item
+			index(item.Children)
+		}
+	}
+	index(roots)
+	return st
+}
+
+// Item returns the item with id, or nil.
+func (st *StaticTree) Item(id string) *TreeItem {
+	return st.items[id]
+}
+
+// Children implements TreeModel.
+func (st *StaticTree) Children(id string) []string {
+	items := st.roots
+	if id != "" {
+		item := st.items[id]
+		if item == nil {
+			return nil
+		}
+		items = item.Children
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.Id
+	}
+	return ids
+}
+
+// Label implements TreeModel.
+func (st *StaticTree) Label(id string) string {
+	if item := st.items[id]; item != nil {
+		return item.Label
+	}
+	return id
+}
+
+// Icon implements TreeModel.
+func (st *StaticTree) Icon(id string) string {
+	if item := st.items[id]; item != nil {
+		return item.Icon
+	}
+	return ""
+}
+
+// IsLeaf implements TreeModel.
+func (st *StaticTree) IsLeaf(id string) bool {
+	item := st.items[id]
+	return item == nil || len(item.Children) == 0
+}
+
+// TreeDropPosition is where a dragged node is dropped relative to the target node.
+type TreeDropPosition int
+
+const (
+	TreeDropBefore TreeDropPosition = iota // as the previous sibling of the target
+	TreeDropInto                           // as a child of the target
+	TreeDropAfter                          // as the next sibling of the target
+)
+
+// TreeView displays the nodes of a TreeModel as an expandable tree. children are loaded
+// when a node is first expanded, and only the visible rows are drawn. nodes are selected
+// with the mouse (Ctrl and Shift extend the selection in multi mode) or the arrow keys;
+// Left and Right collapse and expand. with Checkboxes set each node gets a checkbox that
+// also checks its descendants, and setting OnDrop lets nodes be dragged onto each other.
+type TreeView struct {
+	Container
+	Model      TreeModel
+	Selection  *Selection[string] // selected node ids
+	Checkboxes bool               // show a checkbox on each node
+	Height     float32            // tree height (0 = fill the available region)
+	OnActivate func(id string)    // called when a node is double-clicked or Enter is pressed
+	OnExpand   func(id string, expanded bool)
+	OnCheck    func(id string, checked bool) // called when a checkbox is clicked
+
+	// CanDrop reports whether the dragged node may be dropped at target (nil = always).
+	CanDrop func(id, target string, pos TreeDropPosition) bool
+	// OnDrop moves the dragged node in the model; dragging is enabled when it is set. the
+	// tree reloads the children of both parents afterwards.
+	OnDrop func(id, target string, pos TreeDropPosition)
+
+	children  map[string][]string // loaded children by parent id ("" = roots)
+	parents   map[string]string
+	expanded  map[string]bool
+	checked   map[string]bool
+	rows      []treeRow
+	rowsDirty bool
+	cursor    string // id of the keyboard cursor ("" = none)
+	scrollTo  bool
+	pageRows  int
+}
+
+// treeRow is a displayed node.
+type treeRow struct {
+	id    string
+	depth int
+}
+
+// NewTreeView creates a single-selection tree view of model.
+func NewTreeView(model TreeModel) *TreeView {
+	return &TreeView{
+		Container: Container{Visible: true},
+		Model:     model,
+		Selection: NewSelection[string](SelectSingle),
+		children:  make(map[string][]string),
+		parents:   make(map[string]string),
+		expanded:  make(map[string]bool),
+		checked:   make(map[string]bool),
+		rowsDirty: true,
+		pageRows:  10,
+	}
+}
+
+// Refresh reloads the children of the node with id on the next frame, or the whole tree
+// when id is empty. call it after the model changes; expansion state is kept.
+func (tv *TreeView) Refresh(id string) {
+	if id == "" {
+		clear(tv.children)
+		clear(tv.parents)
+	} else {
+		tv.forget(id)
+	}
+	tv.rowsDirty = true
+}
+
+// IsExpanded reports whether the node with id is expanded.
+func (tv *TreeView) IsExpanded(id string) bool {
+	return tv.expanded[id]
+}
+
+// SetExpanded expands or collapses the node with id.
+func (tv *TreeView) SetExpanded(id string, expanded bool) {
+	if tv.expanded[id] == expanded {
+		return
+	}
+	if expanded {
+		tv.expanded[id] = true
+	} else {
+		delete(tv.expanded, id)
+	}
+	tv.rowsDirty = true
+	if tv.OnExpand != nil {
+		tv.OnExpand(id, expanded)
+	}
+}
+
+// ExpandedIDs returns the ids of the expanded nodes in sorted order, including those
+// hidden under a collapsed parent.
+func (tv *TreeView) ExpandedIDs() []string {
+	ids := make([]string, 0, len(tv.expanded))
+	for id := range tv.expanded {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Reveal expands the ancestors of the node with id, moves the keyboard cursor to it and
+// brings it into view. the ancestors must already be loaded.
+func (tv *TreeView) Reveal(id string) {
+	for parent, ok := tv.parents[id]; ok && parent != ""; parent, ok = tv.parents[parent] {
+		tv.SetExpanded(parent, true)
+	}
+	tv.cursor = id
+	tv.scrollTo = true
+}
+
+// Parent returns the id of the parent of the node with id ("" for root nodes), or false
+// when the node has not been loaded.
+func (tv *TreeView) Parent(id string) (string, bool) {
+	parent, ok := tv.parents[id]
+	return parent, ok
+}
+
+// DisplayedIDs returns the ids of the displayed nodes in display order.
+func (tv *TreeView) DisplayedIDs() []string {
+	tv.updateRows()
+	ids := make([]string, len(tv.rows))
+	for i, row := range tv.rows {
+		ids[i] = row.id
+	}
+	return ids
+}
+
+// CheckState returns the checkbox state of the node with id.
+func (tv *TreeView) CheckState(id string) CheckState {
+	if tv.checked[id] {
+		return Checked
+	}
+	if tv.anyChecked(id) {
+		return PartiallyChecked
+	}
+	return Unchecked
+}
+
+// SetChecked checks or unchecks the node with id along with its loaded descendants, and
+// updates its ancestors: a parent is checked when all of its children are.
+func (tv *TreeView) SetChecked(id string, checked bool) {
+	tv.checkDescendants(id, checked)
+	for parent, ok := tv.parents[id]; ok && parent != ""; parent, ok = tv.parents[parent] {
+		all := true
+		for _, child := range tv.children[parent] {
+			if !tv.checked[child] {
+				all = false
+				break
+			}
+		}
+		tv.setCheck(parent, all)
+	}
+}
+
+// CheckedIDs returns the ids of the checked nodes in sorted order.
+func (tv *TreeView) CheckedIDs() []string {
+	ids := make([]string, 0, len(tv.checked))
+	for id := range tv.checked {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Draw implements Component.
+func (tv *TreeView) Draw(state *State) {
+	if !tv.Visible || tv.Model == nil {
+		return
+	}
+	imgui.PushIDStr(componentID("treeView", tv))
+	defer imgui.PopID()
+
+	tv.updateRows()
+	imgui.BeginChildStrV("##nodes", imgui.Vec2{Y: tv.Height}, imgui.ChildFlagsNone, imgui.WindowFlagsHorizontalScrollbar)
+	height := imgui.FrameHeight()
+	stride := height + imgui.CurrentStyle().ItemSpacing().Y
+	tv.pageRows = max(1, int(imgui.WindowHeight()/stride)-1)
+
+	if imgui.IsWindowFocused() && !imgui.IsAnyItemActive() {
+		tv.handleKeys()
+	}
+	tv.drawRows(stride)
+	imgui.EndChild()
+
+	drawContainerExtensions(&tv.Container, state)
+}
+
+// drawRows draws the visible rows; every row is stride apart.
+func (tv *TreeView) drawRows(stride float32) {
+	if tv.scrollTo {
+		tv.scrollTo = false
+		tv.updateRows()
+		if row := tv.rowOf(tv.cursor); row >= 0 {
+			top := float32(row) * stride
+			if top < imgui.ScrollY() {
+				imgui.SetScrollYFloat(top)
+			} else if bottom := top + stride; bottom > imgui.ScrollY()+imgui.WindowHeight() {
+				imgui.SetScrollYFloat(bottom - imgui.WindowHeight() + 2*imgui.CurrentStyle().WindowPadding().Y)
+			}
+		}
+	}
+
+	// rows may change while drawing (expanding, dropping); finish the frame with this list
+	rows := tv.rows
+	VirtualList(len(rows), stride, func(row int) {
+		tv.drawRow(rows, row)
+	})
+}
+
+// drawRow draws one node: indentation, an optional checkbox, then the tree node itself.
+func (tv *TreeView) drawRow(rows []treeRow, row int) {
+	r := rows[row]
+	imgui.PushIDStr(r.id)
+	defer imgui.PopID()
+
+	indent := float32(r.depth) * imgui.TreeNodeToLabelSpacing()
+	if indent > 0 {
+		imgui.IndentV(indent)
+		defer imgui.UnindentV(indent)
+	}
+
+	if tv.Checkboxes {
+		if check, changed := TriStateCheckbox("##check", tv.CheckState(r.id)); changed {
+			value := check == Checked
+			tv.SetChecked(r.id, value)
+			if tv.OnCheck != nil {
+				tv.OnCheck(r.id, value)
+			}
+		}
+		imgui.SameLine()
+	}
+
+	leaf := tv.Model.IsLeaf(r.id)
+	flags := imgui.TreeNodeFlagsOpenOnArrow | imgui.TreeNodeFlagsOpenOnDoubleClick |
+		imgui.TreeNodeFlagsSpanAvailWidth | imgui.TreeNodeFlagsNoTreePushOnOpen | imgui.TreeNodeFlagsFramePadding
+	if leaf {
+		flags |= imgui.TreeNodeFlagsLeaf
+	}
+	if tv.Selection.Contains(r.id) {
+		flags |= imgui.TreeNodeFlagsSelected
+	}
+
+	label := tv.Model.Label(r.id)
+	if icon := tv.Model.Icon(r.id); icon != "" {
+		label = icon + " " + label
+	}
+	imgui.SetNextItemOpenV(tv.expanded[r.id], imgui.CondAlways)
+	open := imgui.TreeNodeExStrV(label+"##node", flags)
+
+	if !leaf && open != tv.expanded[r.id] {
+		tv.SetExpanded(r.id, open)
+	}
+	if imgui.IsItemClicked() && !imgui.IsItemToggledOpen() {
+		io := imgui.CurrentIO()
+		tv.clickNode(r.id, io.KeyShift(), io.KeyCtrl())
+	}
+	if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && leaf {
+		tv.cursor = r.id
+		tv.activate()
+	}
+	if tv.OnDrop != nil {
+		DragSource(tv.dragKind(), r.id, DragText(label))
+		tv.dropTarget(r.id)
+	}
+}
+
+// dragKind returns the drag-and-drop payload kind, unique to this tree so nodes are only
+// dropped within it.
+func (tv *TreeView) dragKind() string {
+	return fmt.Sprintf("dfx/tree_%p", tv)
+}
+
+// dropTarget accepts a node dragged over the last item, positioned by where the mouse is:
+// the top quarter drops before the target, the bottom quarter after it and the middle
+// into it (before or after halves for leaves).
+func (tv *TreeView) dropTarget(target string) {
+	id, ok := DragPayload[string](tv.dragKind())
+	if !ok || id == target {
+		return
+	}
+	top, bottom := imgui.ItemRectMin().Y, imgui.ItemRectMax().Y
+	pos := treeDropPosition(imgui.MousePos().Y-top, bottom-top, tv.Model.IsLeaf(target))
+	if !tv.canDrop(id, target, pos) {
+		return
+	}
+
+	// the drop indicator is a line between rows, or a frame around the target
+	delivered := acceptDrop(tv.dragKind(), func(topLeft, bottomRight imgui.Vec2) {
+		if pos == TreeDropInto {
+			HighlightDropTarget(topLeft, bottomRight)
+		} else {
+			highlightDropLine(topLeft, bottomRight, pos == TreeDropAfter)
+		}
+	})
+	if delivered {
+		tv.drop(id, target, pos)
+	}
+}
+
+// treeDropPosition maps the mouse offset y within a row of the given height to a drop
+// position.
+func treeDropPosition(y, height float32, leaf bool) TreeDropPosition {
+	if leaf {
+		if y < height/2 {
+			return TreeDropBefore
+		}
+		return TreeDropAfter
+	}
+	switch {
+	case y < height/4:
+		return TreeDropBefore
+	case y > height*3/4:
+		return TreeDropAfter
+	}
+	return TreeDropInto
+}
+
+// canDrop reports whether id may be dropped at target; a node can never be dropped onto
+// itself or one of its descendants.
+func (tv *TreeView) canDrop(id, target string, pos TreeDropPosition) bool {
+	for t, ok := target, true; ok && t != ""; t, ok = tv.parents[t] {
+		if t == id {
+			return false
+		}
+	}
+	return tv.CanDrop == nil || tv.CanDrop(id, target, pos)
+}
+
+// drop hands a completed drag to OnDrop and reloads the affected parents.
+func (tv *TreeView) drop(id, target string, pos TreeDropPosition) {
+	if !tv.canDrop(id, target, pos) {
+		return
+	}
+	oldParent := tv.parents[id]
+	newParent := tv.parents[target]
+	if pos == TreeDropInto {
+		newParent = target
+		tv.SetExpanded(target, true)
+	}
+	tv.OnDrop(id, target, pos)
+	tv.Refresh(oldParent)
+	tv.Refresh(newParent)
+}
+
+// handleKeys applies keyboard navigation while the tree is focused.
+func (tv *TreeView) handleKeys() {
+	n := len(tv.rows)
+	if n == 0 {
+		return
+	}
+	io := imgui.CurrentIO()
+	row := tv.rowOf(tv.cursor)
+	shift := io.KeyShift()
+
+	switch {
+	case imgui.IsKeyPressedBool(imgui.KeyDownArrow):
+		tv.moveCursor(row+1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyUpArrow):
+		if row < 0 {
+			row = n
+		}
+		tv.moveCursor(row-1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyPageDown):
+		tv.moveCursor(row+tv.pageRows, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyPageUp):
+		tv.moveCursor(row-tv.pageRows, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyHome):
+		tv.moveCursor(0, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyEnd):
+		tv.moveCursor(n-1, shift)
+	case imgui.IsKeyPressedBool(imgui.KeyRightArrow):
+		tv.expandCursor()
+	case imgui.IsKeyPressedBool(imgui.KeyLeftArrow):
+		tv.collapseCursor()
+	case imgui.IsKeyPressedBool(imgui.KeySpace) && tv.Checkboxes && tv.cursor != "":
+		checked := tv.CheckState(tv.cursor) != Checked
+		tv.SetChecked(tv.cursor, checked)
+		if tv.OnCheck != nil {
+			tv.OnCheck(tv.cursor, checked)
+		}
+	case imgui.IsKeyPressedBool(imgui.KeyEnter) || imgui.IsKeyPressedBool(imgui.KeyKeypadEnter):
+		tv.activate()
+	case io.KeyCtrl() && imgui.IsKeyPressedBool(imgui.KeyA):
+		tv.SelectAll()
+	}
+}
+
+// expandCursor expands the cursor node, or moves to its first child when it is already
+// expanded.
+func (tv *TreeView) expandCursor() {
+	if tv.cursor == "" || tv.Model.IsLeaf(tv.cursor) {
+		return
+	}
+	if !tv.expanded[tv.cursor] {
+		tv.SetExpanded(tv.cursor, true)
+		return
+	}
+	tv.updateRows()
+	tv.moveCursor(tv.rowOf(tv.cursor)+1, false)
+}
+
+// collapseCursor collapses the cursor node, or moves to its parent when it is already
+// collapsed.
+func (tv *TreeView) collapseCursor() {
+	if tv.cursor == "" {
+		return
+	}
+	if tv.expanded[tv.cursor] && !tv.Model.IsLeaf(tv.cursor) {
+		tv.SetExpanded(tv.cursor, false)
+		return
+	}
+	if parent := tv.parents[tv.cursor]; parent != "" {
+		tv.updateRows()
+		tv.moveCursor(tv.rowOf(parent), false)
+	}
+}
+
+// SelectAll selects every displayed node (multi selection only).
+func (tv *TreeView) SelectAll() {
+	if tv.Selection.Mode != SelectMulti {
+		return
+	}
+	tv.Selection.SetAll(tv.DisplayedIDs())
+	tv.Selection.changed()
+}
+
+// clickNode applies a click on the node with id to the selection.
+func (tv *TreeView) clickNode(id string, extend, toggle bool) {
+	tv.cursor = id
+	tv.Selection.Click(id, extend, toggle, tv.span)
+}
+
+// moveCursor moves the keyboard cursor to row (clamped) and selects its node, extending
+// the selection from the anchor when extend is set.
+func (tv *TreeView) moveCursor(row int, extend bool) {
+	n := len(tv.rows)
+	if n == 0 {
+		return
+	}
+	row = min(max(row, 0), n-1)
+	tv.cursor = tv.rows[row].id
+	tv.scrollTo = true
+	tv.Selection.Click(tv.cursor, extend, false, tv.span)
+}
+
+func (tv *TreeView) activate() {
+	if tv.cursor != "" && tv.OnActivate != nil {
+		tv.OnActivate(tv.cursor)
+	}
+}
+
+// updateRows flattens the expanded part of the tree into rows, loading the children of
+// newly expanded nodes.
+func (tv *TreeView) updateRows() {
+	if !tv.rowsDirty {
+		return
+	}
+	tv.rowsDirty = false
+	tv.rows = tv.rows[:0]
+	tv.appendRows("", 0)
+}
+
+func (tv *TreeView) appendRows(parent string, depth int) {
+	for _, id := range tv.load(parent) {
+		tv.rows = append(tv.rows, treeRow{id: id, depth: depth})
+		if tv.expanded[id] && !tv.Model.IsLeaf(id) {
+			tv.appendRows(id, depth+1)
+		}
+	}
+}
+
+// load returns the children of parent, asking the model the first time.
+func (tv *TreeView) load(parent string) []string {
+	if children, found := tv.children[parent]; found {
+		return children
+	}
+	children := tv.Model.Children(parent)
+	tv.children[parent] = children
+	for _, child := range children {
+		tv.parents[child] = parent
+	}
+	if tv.checked[parent] {
+		// newly loaded children of a checked node start checked
+		for _, child := range children {
+			tv.checked[child] = true
+		}
+	}
+	return children
+}
+
+// forget drops the loaded descendants of id so they are reloaded from the model.
+func (tv *TreeView) forget(id string) {
+	for _, child := range tv.children[id] {
+		if tv.parents[child] == id {
+			delete(tv.parents, child)
+		}
+		tv.forget(child)
+	}
+	delete(tv.children, id)
+}
+
+// rowOf returns the displayed row of the node with id, or -1.
+func (tv *TreeView) rowOf(id string) int {
+	if id == "" {
+		return -1
+	}
+	for row, r := range tv.rows {
+		if r.id == id {
+			return row
+		}
+	}
+	return -1
+}
+
+// span returns the ids displayed from one node to another, inclusive.
+func (tv *TreeView) span(from, to string) []string {
+	a, b := tv.rowOf(from), tv.rowOf(to)
+	if a < 0 || b < 0 {
+		return []string{to}
+	}
+	if a > b {
+		a, b = b, a
+	}
+	ids := make([]string, 0, b-a+1)
+	for row := a; row <= b; row++ {
+		ids = append(ids, tv.rows[row].id)
+	}
+	return ids
+}
+
+func (tv *TreeView) setCheck(id string, checked bool) {
+	if checked {
+		tv.checked[id] = true
+	} else {
+		delete(tv.checked, id)
+	}
+}
+
+func (tv *TreeView) checkDescendants(id string, checked bool) {
+	tv.setCheck(id, checked)
+	for _, child := range tv.children[id] {
+		tv.checkDescendants(child, checked)
+	}
+}
+
+// anyChecked reports whether any loaded descendant of id is checked.
+func (tv *TreeView) anyChecked(id string) bool {
+	for _, child := range tv.children[id] {
+		if tv.checked[child] || tv.anyChecked(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// CaptureState implements StatefulComponent, saving the expanded, selected and checked
+// node ids.
+func (tv *TreeView) CaptureState() map[string]any {
+	return map[string]any{
+		"expanded": tv.ExpandedIDs(),
+		"selected": tv.Selection.Keys(),
+		"checked":  tv.CheckedIDs(),
+	}
+}
+
+// RestoreState implements StatefulComponent. nodes that no longer exist are ignored.
+func (tv *TreeView) RestoreState(state map[string]any) {
+	if expanded, ok := stateStrings(state["expanded"]); ok {
+		clear(tv.expanded)
+		for _, id := range expanded {
+			tv.expanded[id] = true
+		}
+		tv.rowsDirty = true
+	}
+	if selected, ok := stateStrings(state["selected"]); ok {
+		tv.Selection.SetAll(selected)
+	}
+	if checked, ok := stateStrings(state["checked"]); ok {
+		clear(tv.checked)
+		for _, id := range checked {
+			tv.checked[id] = true
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package dfx
+
+import (
+	"slices"
+	"testing"
+)
+
+// countingTree wraps a StaticTree, recording which nodes had their children loaded.
+type countingTree struct {
+	*StaticTree
+	loaded []string
+}
+
+func (ct *countingTree) Children(id string) []string {
+	ct.loaded = append(ct.loaded, id)
+	return ct.StaticTree.Children(id)
+}
+
+func testTreeModel() *countingTree {
+	return &countingTree{StaticTree: NewStaticTree(
+		&TreeItem{Id: "a", Label: "A", Children: []*TreeItem{
+			{Id: "a1", Label: "A1"},
+			{Id: "a2", Label: "A2", Children: []*TreeItem{{Id: "a2x", Label: "A2X"}}},
+		}},
+		&TreeItem{Id: "b", Label: "B", Children: []*TreeItem{{Id: "b1", Label: "B1"}}},
+		&TreeItem{Id: "c", Label: "C"},
+	)}
+}
+
+func TestTreeViewLazyExpansion(t *testing.T) {
+	model := testTreeModel()
+	tv := NewTreeView(model)
+	if !slices.Equal(tv.DisplayedIDs(), []string{"a", "b", "c"}) {
+		t.Fatalf("expected roots only, got %v", tv.DisplayedIDs())
+	}
+	if !slices.Equal(model.loaded, []string{""}) {
+		t.Fatalf("expected only the roots loaded, got %v", model.loaded)
+	}
+
+	tv.SetExpanded("a", true)
+	tv.SetExpanded("a2", true)
+	if !slices.Equal(tv.DisplayedIDs(), []string{"a", "a1", "a2", "a2x", "b", "c"}) {
+		t.Fatalf("unexpected rows %v", tv.DisplayedIDs())
+	}
+	if tv.rows[3].depth != 2 {
+		t.Fatalf("expected a2x at depth 2, got %v", tv.rows[3].depth)
+	}
+
+	// collapsing hides the subtree but keeps its expansion state
+	tv.SetExpanded("a", false)
+	if !slices.Equal(tv.DisplayedIDs(), []string{"a", "b", "c"}) || !tv.IsExpanded("a2") {
+		t.Fatalf("unexpected rows %v", tv.DisplayedIDs())
+	}
+	tv.SetExpanded("a", true)
+	if len(model.loaded) != 3 {
+		t.Fatalf("expected children cached, got loads %v", model.loaded)
+	}
+	tv.Refresh("a")
+	tv.updateRows()
+	if len(model.loaded) != 5 {
+		t.Fatalf("expected a and a2 reloaded, got loads %v", model.loaded)
+	}
+}
+
+func TestTreeViewKeyboardAndSelection(t *testing.T) {
+	tv := NewTreeView(testTreeModel())
+	tv.Selection.Mode = SelectMulti
+	tv.updateRows()
+
+	tv.moveCursor(0, false)
+	tv.expandCursor()
+	tv.updateRows()
+	tv.expandCursor()
+	if tv.cursor != "a1" {
+		t.Fatalf("expected cursor on first child, got %v", tv.cursor)
+	}
+	tv.collapseCursor()
+	if tv.cursor != "a" || !tv.IsExpanded("a") {
+		t.Fatalf("expected cursor moved to parent, got %v", tv.cursor)
+	}
+	tv.collapseCursor()
+	if tv.IsExpanded("a") {
+		t.Fatalf("expected a collapsed")
+	}
+
+	tv.SetExpanded("a", true)
+	tv.updateRows()
+	tv.clickNode("a1", false, false)
+	tv.clickNode("b", true, false)
+	if !slices.Equal(tv.Selection.Keys(), []string{"a1", "a2", "b"}) {
+		t.Fatalf("expected displayed range, got %v", tv.Selection.Keys())
+	}
+}
+
+func TestTreeViewCheckboxes(t *testing.T) {
+	tv := NewTreeView(testTreeModel())
+	tv.Checkboxes = true
+	tv.SetExpanded("a", true)
+	tv.updateRows()
+
+	tv.SetChecked("a", true)
+	if tv.CheckState("a1") != Checked || tv.CheckState("a2") != Checked {
+		t.Fatalf("expected loaded children checked")
+	}
+	// children loaded later inherit the check
+	tv.SetExpanded("a2", true)
+	tv.updateRows()
+	if tv.CheckState("a2x") != Checked {
+		t.Fatalf("expected lazily loaded child checked")
+	}
+
+	tv.SetChecked("a2x", false)
+	if tv.CheckState("a2") != Unchecked || tv.CheckState("a") != PartiallyChecked {
+		t.Fatalf("expected a2 unchecked and a partial, got %v %v", tv.CheckState("a2"), tv.CheckState("a"))
+	}
+	tv.SetChecked("a2x", true)
+	if tv.CheckState("a") != Checked {
+		t.Fatalf("expected a checked once all children are")
+	}
+}
+
+func TestTreeViewDrop(t *testing.T) {
+	tv := NewTreeView(testTreeModel())
+	tv.SetExpanded("a", true)
+	tv.updateRows()
+
+	var dropped []string
+	tv.OnDrop = func(id, target string, pos TreeDropPosition) { dropped = append(dropped, id+">"+target) }
+	if tv.canDrop("a", "a1", TreeDropInto) {
+		t.Fatalf("expected drop into own descendant rejected")
+	}
+	tv.drop("c", "a", TreeDropInto)
+	if !slices.Equal(dropped, []string{"c>a"}) {
+		t.Fatalf("expected drop delivered, got %v", dropped)
+	}
+	tv.CanDrop = func(id, target string, pos TreeDropPosition) bool { return pos != TreeDropInto }
+	tv.drop("c", "b", TreeDropInto)
+	if len(dropped) != 1 {
+		t.Fatalf("expected CanDrop to veto the drop")
+	}
+
+	if treeDropPosition(1, 20, false) != TreeDropBefore || treeDropPosition(10, 20, false) != TreeDropInto ||
+		treeDropPosition(19, 20, false) != TreeDropAfter || treeDropPosition(10, 20, true) != TreeDropAfter {
+		t.Fatalf("unexpected drop positions")
+	}
+}
+
+func TestTreeViewState(t *testing.T) {
+	tv := NewTreeView(testTreeModel())
+	tv.SetExpanded("b", true)
+	tv.SetExpanded("a", true)
+	tv.Selection.Set("a1")
+	tv.SetChecked("c", true)
+	state := tv.CaptureState()
+
+	// simulate a config round trip, which decodes lists as []any
+	restored := map[string]any{}
+	for key, ids := range state {
+		var list []any
+		for _, id := range ids.([]string) {
+			list = append(list, id)
+		}
+		restored[key] = list
+	}
+	other := NewTreeView(testTreeModel())
+	other.RestoreState(restored)
+	if !slices.Equal(other.ExpandedIDs(), []string{"a", "b"}) || !other.Selection.Contains("a1") || other.CheckState("c") != Checked {
+		t.Fatalf("unexpected restored state %v", other.CaptureState())
+	}
+	if !slices.Equal(other.DisplayedIDs(), []string{"a", "a1", "a2", "b", "b1", "c"}) {
+		t.Fatalf("unexpected rows %v", other.DisplayedIDs())
+	}
+}
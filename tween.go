@@ -0,0 +1,159 @@
+package dfx
+
+import (
+	"slices"
+	"time"
+)
+
+// Tween animates a float value from From to To over Duration, reporting each frame's
+// value to OnUpdate. tweens are driven by a TweenManager; App owns one (see App.Tweens)
+// that is updated once per frame before drawing.
+type Tween struct {
+	From       float32
+	To         float32
+	Duration   time.Duration // transition length; zero completes on the first update
+	Delay      time.Duration // wait before the tween starts
+	Easing     Easing        // easing curve (nil = DefaultEasing)
+	OnUpdate   func(value float32)
+	OnComplete func() // called once after the final OnUpdate; not called when cancelled
+
+	start     time.Time
+	done      bool
+	cancelled bool
+	next      []*Tween
+}
+
+// NewTween creates a tween from from to to over duration.
+func NewTween(from, to float32, duration time.Duration, onUpdate func(value float32)) *Tween {
+	return &Tween{From: from, To: to, Duration: duration, OnUpdate: onUpdate}
+}
+
+// Then schedules next to start when t completes, and returns next so chains can be
+// written fluently: a.Then(b).Then(c). chained tweens do not start if t is cancelled.
+func (t *Tween) Then(next *Tween) *Tween {
+	t.next = append(t.next, next)
+	return next
+}
+
+// Cancel stops the tween without calling OnComplete; tweens chained after it never start.
+func (t *Tween) Cancel() {
+	t.cancelled = true
+	for _, next := range t.next {
+		next.Cancel()
+	}
+}
+
+// Done reports whether the tween completed or was cancelled.
+func (t *Tween) Done() bool {
+	return t.done || t.cancelled
+}
+
+// value returns the eased value at progress p.
+func (t *Tween) value(p float32) float32 {
+	easing := t.Easing
+	if easing == nil {
+		easing = DefaultEasing
+	}
+	return t.From + (t.To-t.From)*easing(p)
+}
+
+// TweenManager drives a set of tweens. call Update once per frame.
+type TweenManager struct {
+	tweens []*Tween
+	now    func() time.Time
+}
+
+// NewTweenManager creates an empty tween manager.
+func NewTweenManager() *TweenManager {
+	return &TweenManager{}
+}
+
+// Start begins running t (after its Delay) and returns it.
+func (m *TweenManager) Start(t *Tween) *Tween {
+	t.start = m.clock()
+	t.done = false
+	m.tweens = append(m.tweens, t)
+	return t
+}
+
+// Tween creates and starts a tween from from to to over duration.
+func (m *TweenManager) Tween(from, to float32, duration time.Duration, easing Easing, onUpdate func(value float32)) *Tween {
+	t := NewTween(from, to, duration, onUpdate)
+	t.Easing = easing
+	return m.Start(t)
+}
+
+// CancelAll cancels every running tween. it is safe to call from tween callbacks; the
+// cancelled tweens are dropped by the next Update.
+func (m *TweenManager) CancelAll() {
+	for _, t := range m.tweens {
+		t.Cancel()
+	}
+}
+
+// Active returns the number of tweens that are running or waiting out their delay.
+func (m *TweenManager) Active() int {
+	count := 0
+	for _, t := range m.tweens {
+		if !t.Done() {
+			count++
+		}
+	}
+	return count
+}
+
+// Update advances all tweens, invoking their callbacks and starting chained tweens
+// whose predecessors completed.
+func (m *TweenManager) Update() {
+	now := m.clock()
+	// m.tweens stays live while the callbacks run so CancelAll and Active see every
+	// tween; tweens started during the update are appended and first advanced next frame.
+	n := len(m.tweens)
+	var remaining []*Tween
+	for _, t := range m.tweens[:n] {
+		if t.Done() {
+			continue
+		}
+		elapsed := now.Sub(t.start) - t.Delay
+		if elapsed < 0 {
+			remaining = append(remaining, t)
+			continue
+		}
+
+		p := float32(1)
+		if t.Duration > 0 && elapsed < t.Duration {
+			p = float32(elapsed) / float32(t.Duration)
+		}
+		if t.OnUpdate != nil {
+			t.OnUpdate(t.value(p))
+		}
+		if t.cancelled {
+			continue
+		}
+		if p < 1 {
+			remaining = append(remaining, t)
+			continue
+		}
+
+		t.done = true
+		if t.OnComplete != nil {
+			t.OnComplete()
+		}
+		for _, next := range t.next {
+			if !next.cancelled {
+				next.start = now
+				next.done = false
+				m.tweens = append(m.tweens, next)
+			}
+		}
+	}
+	// callbacks may have cancelled tweens that were already kept.
+	m.tweens = slices.DeleteFunc(append(remaining, m.tweens[n:]...), (*Tween).Done)
+}
+
+func (m *TweenManager) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
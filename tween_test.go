@@ -0,0 +1,127 @@
+package dfx
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestTweenManager(clock *time.Time) *TweenManager {
+	m := NewTweenManager()
+	m.now = func() time.Time { return *clock }
+	return m
+}
+
+func TestTweenManager_UpdatesAndCompletes(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestTweenManager(&clock)
+
+	var values []float32
+	completed := 0
+	tw := m.Tween(0, 100, time.Second, EaseLinear, func(v float32) { values = append(values, v) })
+	tw.OnComplete = func() { completed++ }
+
+	clock = clock.Add(500 * time.Millisecond)
+	m.Update()
+	clock = clock.Add(time.Second)
+	m.Update()
+	m.Update()
+
+	if len(values) != 2 || values[0] != 50 || values[1] != 100 {
+		t.Fatalf("expected updates '[50 100]', got '%v'", values)
+	}
+	if completed != 1 || !tw.Done() || m.Active() != 0 {
+		t.Fatalf("expected one completion, got %v (done %v, active %v)", completed, tw.Done(), m.Active())
+	}
+}
+
+func TestTweenManager_Delay(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestTweenManager(&clock)
+
+	var last float32 = -1
+	tw := NewTween(0, 10, time.Second, func(v float32) { last = v })
+	tw.Delay = time.Second
+	tw.Easing = EaseLinear
+	m.Start(tw)
+
+	clock = clock.Add(500 * time.Millisecond)
+	m.Update()
+	if last != -1 {
+		t.Fatalf("expected no update during delay, got '%v'", last)
+	}
+	clock = clock.Add(time.Second)
+	m.Update()
+	if last != 5 {
+		t.Fatalf("expected '5', got '%v'", last)
+	}
+}
+
+func TestTweenManager_Chaining(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestTweenManager(&clock)
+
+	var order []string
+	first := m.Tween(0, 1, time.Second, nil, nil)
+	first.OnComplete = func() { order = append(order, "first") }
+	second := first.Then(NewTween(1, 2, time.Second, nil))
+	second.OnComplete = func() { order = append(order, "second") }
+
+	clock = clock.Add(time.Second)
+	m.Update()
+	if m.Active() != 1 {
+		t.Fatalf("expected chained tween to start, active '%v'", m.Active())
+	}
+	clock = clock.Add(time.Second)
+	m.Update()
+	if len(order) != 2 || order[1] != "second" {
+		t.Fatalf("expected '[first second]', got '%v'", order)
+	}
+}
+
+func TestTweenManager_Cancel(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestTweenManager(&clock)
+
+	updates := 0
+	first := m.Tween(0, 1, time.Second, nil, func(float32) { updates++ })
+	second := first.Then(NewTween(1, 2, time.Second, func(float32) { updates++ }))
+	first.OnComplete = func() { t.Fatalf("unexpected completion of cancelled tween") }
+
+	first.Cancel()
+	clock = clock.Add(2 * time.Second)
+	m.Update()
+	m.Update()
+	if updates != 0 || !second.Done() || m.Active() != 0 {
+		t.Fatalf("expected cancelled chain to never run, got %v updates", updates)
+	}
+}
+
+func TestTweenManager_CancelAllFromCallback(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	m := newTestTweenManager(&clock)
+
+	var active []int
+	laterUpdates := 0
+	first := m.Tween(0, 1, time.Second, nil, func(float32) {
+		active = append(active, m.Active())
+		m.CancelAll()
+	})
+	later := m.Tween(0, 1, time.Second, nil, func(float32) { laterUpdates++ })
+	chained := later.Then(NewTween(1, 2, time.Second, nil))
+	started := m.Tween(0, 1, time.Second, nil, nil)
+
+	clock = clock.Add(500 * time.Millisecond)
+	m.Update()
+	clock = clock.Add(time.Second)
+	m.Update()
+
+	if len(active) != 1 || active[0] != 3 {
+		t.Fatalf("expected '[3]' active inside the callback, got '%v'", active)
+	}
+	if laterUpdates != 0 || !first.Done() || !later.Done() || !chained.Done() || !started.Done() {
+		t.Fatalf("expected every tween cancelled, got %v updates", laterUpdates)
+	}
+	if m.Active() != 0 || len(m.tweens) != 0 {
+		t.Fatalf("expected no active tweens, got %v", m.Active())
+	}
+}
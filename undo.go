@@ -38,9 +38,10 @@ type FullCommand interface {
 // UndoSystem manages command history and undo/redo operations.
 type UndoSystem struct {
 	// RunF is called whenever a command is executed, useful for tracking modifications
-	RunF func(Command)
-	undo []Command
-	redo []Command
+	RunF  func(Command)
+	undo  []Command
+	redo  []Command
+	clean int // undo depth of the clean state, or -1 once that state can't be reached
 }
 
 // NewUndoSystem creates a new undo system.
@@ -58,14 +59,21 @@ func (us *UndoSystem) Run(command Command) {
 	command.Run()
 
 	// attempt to merge with previous command if both support it
-	if len(us.undo) > 0 {
+	depth := len(us.undo)
+	merged := false
+	if depth > 0 {
 		if mergeableCmd, ok := command.(MergeableCommand); ok {
-			if mergeableCmd.Merge(us.undo[len(us.undo)-1]) {
-				us.undo = us.undo[:len(us.undo)-1]
+			if mergeableCmd.Merge(us.undo[depth-1]) {
+				us.undo = us.undo[:depth-1]
+				merged = true
 			}
 		}
 	}
 
+	// the clean state is lost when it was on the redo stack, or was the merged command
+	if us.clean > depth || (merged && us.clean == depth) {
+		us.clean = -1
+	}
 	us.undo = append(us.undo, command)
 	us.redo = nil
 }
@@ -90,12 +98,28 @@ func (us *UndoSystem) Redo() {
 	}
 }
 
-// Clear removes all commands from both undo and redo stacks.
+// Clear removes all commands from both undo and redo stacks. a clean history stays clean.
 func (us *UndoSystem) Clear() {
+	if us.Clean() {
+		us.clean = 0
+	} else {
+		us.clean = -1
+	}
 	us.undo = nil
 	us.redo = nil
 }
 
+// MarkClean records the current state as clean, as when the document is saved.
+func (us *UndoSystem) MarkClean() {
+	us.clean = len(us.undo)
+}
+
+// Clean reports whether undo and redo have returned to the state recorded by MarkClean
+// (initially, the state before any command ran).
+func (us *UndoSystem) Clean() bool {
+	return us.clean == len(us.undo)
+}
+
 // CanUndo returns true if there are commands that can be undone.
 func (us *UndoSystem) CanUndo() bool {
 	return len(us.undo) > 0
@@ -0,0 +1,72 @@
+package dfx
+
+import "testing"
+
+type undoTestCommand struct {
+	value *int
+	to    int
+	from  int
+	merge bool
+}
+
+func (c *undoTestCommand) Description() string { return "set" }
+func (c *undoTestCommand) Run()                { c.from, *c.value = *c.value, c.to }
+func (c *undoTestCommand) Undo()               { *c.value = c.from }
+
+func (c *undoTestCommand) Merge(other Command) bool {
+	if prev, ok := other.(*undoTestCommand); ok && c.merge {
+		c.from = prev.from
+		return true
+	}
+	return false
+}
+
+func TestUndoSystem_Clean(t *testing.T) {
+	value := 0
+	us := NewUndoSystem()
+	if !us.Clean() {
+		t.Fatalf("expected a new history clean")
+	}
+
+	us.Run(&undoTestCommand{value: &value, to: 1})
+	us.MarkClean()
+	us.Run(&undoTestCommand{value: &value, to: 2})
+	if us.Clean() {
+		t.Fatalf("expected dirty after a command")
+	}
+	us.Undo()
+	if !us.Clean() {
+		t.Fatalf("expected clean after undoing back to the saved state")
+	}
+	us.Undo()
+	if us.Clean() {
+		t.Fatalf("expected dirty after undoing past the saved state")
+	}
+	us.Redo()
+	if !us.Clean() {
+		t.Fatalf("expected clean after redoing to the saved state")
+	}
+
+	// merging into the saved command leaves the saved state behind for good
+	us.Run(&undoTestCommand{value: &value, to: 3, merge: true})
+	us.Undo()
+	us.Redo()
+	if us.Clean() {
+		t.Fatalf("expected dirty after merging into the saved command")
+	}
+
+	// a saved state on the redo stack is lost when a new command runs
+	us.MarkClean()
+	us.Undo()
+	us.Run(&undoTestCommand{value: &value, to: 4})
+	us.Undo()
+	if us.Clean() {
+		t.Fatalf("expected the discarded saved state unreachable")
+	}
+
+	us.MarkClean()
+	us.Clear()
+	if !us.Clean() {
+		t.Fatalf("expected a clean history to stay clean when cleared")
+	}
+}
@@ -0,0 +1,147 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/michaelquigley/dfx/fonts"
+)
+
+// validation constants
+const (
+	validationBorderSize = 1.5 // frame border width of a control holding an invalid value
+)
+
+// Validation configures a validated control.
+type Validation[T any] struct {
+	Validate func(value T) error // rejects a value, returning the reason shown to the user (nil = all valid)
+	Deferred bool                // validate on Enter or when the control loses focus, not on every change
+}
+
+// validationDraft is an edited value a validated control holds back from its caller:
+// rejected, or waiting for Enter or blur when validation is deferred.
+type validationDraft struct {
+	value any
+	err   error
+}
+
+// validationDrafts holds the drafts of validated controls, by id.
+var validationDrafts = make(map[imgui.ID]*validationDraft)
+
+// WithValidation wraps a control so its edits reach the caller only once they validate.
+// draw draws the control showing value and returns (newValue, changed) like the dfx
+// controls. a rejected value stays in the control, which is outlined in the theme error
+// color and followed by an error icon whose tooltip gives the reason. id identifies the
+// control across frames; its label is a good choice.
+//
+//	port, changed, err := dfx.WithValidation("Port", port, dfx.Validation[int]{Validate: checkPort},
+//	    func(v int) (int, bool) { return dfx.IntInput("Port", v, dfx.NumberOptions{}) })
+//
+// returns (newValue, changed, err), where err is the reason the value in the control was
+// rejected, or nil.
+func WithValidation[T any](id string, value T, v Validation[T], draw func(value T) (T, bool)) (T, bool, error) {
+	key := imgui.IDStr(id)
+	draft := validationDrafts[key]
+	shown := value
+	if draft != nil {
+		shown = draft.value.(T)
+	}
+
+	invalid := draft != nil && draft.err != nil
+	if invalid {
+		imgui.PushStyleColorVec4(imgui.ColBorder, ThemeColors().Error)
+		imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, validationBorderSize)
+	}
+	imgui.BeginGroup()
+	next, edited := draw(shown)
+	imgui.EndGroup()
+	finished := imgui.IsItemDeactivated()
+	if invalid {
+		imgui.PopStyleVar()
+		imgui.PopStyleColor()
+	}
+	if !edited {
+		next = shown
+	}
+
+	draft, commit := advanceValidation(draft, edited, next, finished, v)
+	var err error
+	if draft != nil {
+		validationDrafts[key] = draft
+		err = draft.err
+	} else {
+		delete(validationDrafts, key)
+	}
+	if err != nil {
+		drawValidationError(err)
+	}
+	if commit {
+		return next, true, nil
+	}
+	return value, false, err
+}
+
+// advanceValidation applies one frame of a validated control to its draft: an edit
+// replaces the draft value, and the draft is checked on every edit, or when the control
+// finishes editing if validation is deferred. returns the draft to keep (nil once it is
+// committed) and whether to commit the draft value.
+func advanceValidation[T any](draft *validationDraft, edited bool, next T, finished bool, v Validation[T]) (*validationDraft, bool) {
+	if edited {
+		if draft == nil {
+			draft = &validationDraft{}
+		}
+		draft.value, draft.err = next, nil
+		if !v.Deferred {
+			return checkDraft(draft, next, v)
+		}
+	}
+	if v.Deferred && finished && draft != nil {
+		return checkDraft(draft, draft.value.(T), v)
+	}
+	return draft, false
+}
+
+func checkDraft[T any](draft *validationDraft, value T, v Validation[T]) (*validationDraft, bool) {
+	if v.Validate != nil {
+		if err := v.Validate(value); err != nil {
+			draft.err = err
+			return draft, false
+		}
+	}
+	return nil, true
+}
+
+// drawValidationError draws the error icon after a rejected control, with the reason as
+// its tooltip.
+func drawValidationError(err error) {
+	imgui.SameLineV(0, imgui.CurrentStyle().ItemInnerSpacing().X)
+	imgui.AlignTextToFramePadding()
+	imgui.TextColored(ThemeColors().Error, fonts.ICON_ERROR)
+	imgui.SetItemTooltip(err.Error())
+}
+
+// InputWithValidation is Input with validation; see WithValidation.
+func InputWithValidation(label, value string, v Validation[string]) (string, bool, error) {
+	return WithValidation(label, value, v, func(value string) (string, bool) {
+		return Input(label, value)
+	})
+}
+
+// InputMultilineWithValidation is InputMultiline with validation; see WithValidation.
+func InputMultilineWithValidation(label, value string, width, height float32, v Validation[string]) (string, bool, error) {
+	return WithValidation(label, value, v, func(value string) (string, bool) {
+		return InputMultiline(label, value, width, height)
+	})
+}
+
+// NumberInputWithValidation is NumberInput with validation; see WithValidation.
+func NumberInputWithValidation(label string, value float32, opts NumberOptions, v Validation[float32]) (float32, bool, error) {
+	return WithValidation(label, value, v, func(value float32) (float32, bool) {
+		return NumberInput(label, value, opts)
+	})
+}
+
+// IntInputWithValidation is IntInput with validation; see WithValidation.
+func IntInputWithValidation(label string, value int, opts NumberOptions, v Validation[int]) (int, bool, error) {
+	return WithValidation(label, value, v, func(value int) (int, bool) {
+		return IntInput(label, value, opts)
+	})
+}
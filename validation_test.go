@@ -0,0 +1,70 @@
+package dfx
+
+import (
+	"errors"
+	"testing"
+)
+
+func positive(v int) error {
+	if v <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func TestValidationImmediate(t *testing.T) {
+	v := Validation[int]{Validate: positive}
+
+	draft, commit := advanceValidation(nil, true, -1, false, v)
+	if commit || draft == nil || draft.err == nil || draft.value.(int) != -1 {
+		t.Fatalf("expected a rejected draft holding -1, got %+v (commit %v)", draft, commit)
+	}
+
+	// an idle frame keeps the rejected draft
+	draft, commit = advanceValidation(draft, false, 0, true, v)
+	if commit || draft == nil || draft.err == nil {
+		t.Fatalf("expected the rejected draft to remain, got %+v (commit %v)", draft, commit)
+	}
+
+	draft, commit = advanceValidation(draft, true, 5, false, v)
+	if !commit || draft != nil {
+		t.Errorf("expected 5 to commit and drop the draft, got %+v (commit %v)", draft, commit)
+	}
+}
+
+func TestValidationDeferred(t *testing.T) {
+	calls := 0
+	v := Validation[int]{Deferred: true, Validate: func(value int) error {
+		calls++
+		return positive(value)
+	}}
+
+	draft, commit := advanceValidation(nil, true, 3, false, v)
+	if commit || draft == nil || calls != 0 {
+		t.Fatalf("expected an unchecked draft while editing, got %+v (commit %v, %d calls)", draft, commit, calls)
+	}
+	draft, commit = advanceValidation(draft, true, -3, false, v)
+	draft, commit = advanceValidation(draft, false, 0, true, v)
+	if commit || draft == nil || draft.err == nil || calls != 1 {
+		t.Fatalf("expected -3 rejected on finishing, got %+v (commit %v, %d calls)", draft, commit, calls)
+	}
+
+	draft, _ = advanceValidation(draft, true, 8, false, v)
+	if draft.err != nil {
+		t.Error("expected the error to clear while editing again")
+	}
+	draft, commit = advanceValidation(draft, false, 0, true, v)
+	if !commit || draft != nil {
+		t.Errorf("expected 8 to commit on finishing, got %+v (commit %v)", draft, commit)
+	}
+
+	if draft, commit = advanceValidation[int](nil, false, 0, true, v); draft != nil || commit {
+		t.Error("expected finishing without edits to do nothing")
+	}
+}
+
+func TestValidationWithoutValidator(t *testing.T) {
+	if draft, commit := advanceValidation(nil, true, "anything", false, Validation[string]{}); !commit || draft != nil {
+		t.Errorf("expected every value to commit without a validator, got %+v (commit %v)", draft, commit)
+	}
+}
@@ -0,0 +1,181 @@
+package dfx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// RenderHook draws a custom OpenGL scene for a Viewport3D. dfx has no GL bindings of its
+// own; the hook uses the app's.
+type RenderHook interface {
+	// Render draws the scene into a framebuffer of frame.Width x frame.Height pixels,
+	// creating or resizing it as needed, and returns the GL texture holding the result (0
+	// = nothing to show). it is called on the UI thread while the viewport is drawn, with
+	// the GL context current and before imgui renders, so it must restore the framebuffer
+	// binding, viewport and any other GL state it changes.
+	Render(frame ViewportFrame) uint32
+
+	// Release frees the hook's GL resources. it is called with the GL context current when
+	// the viewport is unmounted, including when the app shuts down.
+	Release()
+}
+
+// ViewportFrame describes a frame a RenderHook renders.
+type ViewportFrame struct {
+	Width     int           // framebuffer size in pixels
+	Height    int           //
+	Resized   bool          // the size changed since the previous frame (true on the first)
+	Now       time.Time     // frame clock (see State.Now)
+	DeltaTime time.Duration // time since the previous frame
+	Input     ViewportInput // mouse input over the viewport
+}
+
+// ViewportInput is the mouse input a Viewport3D forwards to its hook, in framebuffer
+// pixels from the top-left corner of the viewport.
+type ViewportInput struct {
+	Mouse   imgui.Vec2 // pointer position
+	Delta   imgui.Vec2 // pointer movement since the previous frame
+	Wheel   imgui.Vec2 // wheel movement while hovered (Y = vertical)
+	Hovered bool       // the pointer is over the viewport
+	Focused bool       // the viewport was clicked last, so keyboard input is meant for it
+	// buttons held after being pressed on the viewport (left, right, middle); drags keep
+	// going outside it
+	Buttons [3]bool
+}
+
+// Viewport3D shows a scene rendered by a RenderHook into a framebuffer, as an imgui image
+// sized to the space it is drawn in, forwarding mouse input to the hook. it implements
+// Unmounter, releasing the hook when unmounted.
+type Viewport3D struct {
+	Container
+	Hook   RenderHook
+	Width  float32 // viewport width (0 = available width)
+	Height float32 // viewport height (0 = available height)
+	NoFlip bool    // draw the texture as is; GL framebuffers are bottom-up, so it is flipped by default
+
+	pressed [3]bool
+	focused bool
+	width   int
+	height  int
+	texture uint32
+	ref     *imgui.TextureRef
+}
+
+// NewViewport3D creates a viewport rendered by hook.
+func NewViewport3D(hook RenderHook) *Viewport3D {
+	return &Viewport3D{Container: Container{Visible: true}, Hook: hook}
+}
+
+// Draw implements Component.
+func (v *Viewport3D) Draw(state *State) {
+	if !v.Visible {
+		return
+	}
+	imgui.PushIDStr(fmt.Sprintf("viewport3D_%p", v))
+	defer imgui.PopID()
+
+	size := imgui.ContentRegionAvail()
+	if v.Width > 0 {
+		size.X = v.Width
+	}
+	if v.Height > 0 {
+		size.Y = v.Height
+	}
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+	pos := imgui.CursorScreenPos()
+	imgui.InvisibleButtonV("##viewport", size, imgui.ButtonFlagsMouseButtonLeft|imgui.ButtonFlagsMouseButtonRight|imgui.ButtonFlagsMouseButtonMiddle)
+	hovered := imgui.IsItemHovered()
+
+	scale := imgui.CurrentIO().DisplayFramebufferScale()
+	frame := ViewportFrame{Input: v.input(pos, scale, hovered)}
+	frame.Width, frame.Height = viewportPixels(size, scale)
+	frame.Resized = frame.Width != v.width || frame.Height != v.height
+	v.width, v.height = frame.Width, frame.Height
+	if state != nil {
+		frame.Now, frame.DeltaTime = state.Clock(), state.DeltaTime
+	}
+
+	if v.Hook != nil {
+		if ref, ok := v.textureRef(v.Hook.Render(frame)); ok {
+			uv0, uv1 := imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1}
+			if !v.NoFlip {
+				uv0, uv1 = imgui.Vec2{Y: 1}, imgui.Vec2{X: 1}
+			}
+			imgui.WindowDrawList().AddImageV(ref, pos, pos.Add(size), uv0, uv1, imgui.ColorConvertFloat4ToU32(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}))
+		}
+	}
+
+	drawContainerExtensions(&v.Container, state)
+}
+
+// input gathers the mouse input over the viewport at pos.
+func (v *Viewport3D) input(pos, scale imgui.Vec2, hovered bool) ViewportInput {
+	io := imgui.CurrentIO()
+	in := ViewportInput{Hovered: hovered}
+	mouse, delta := imgui.MousePos().Sub(pos), io.MouseDelta()
+	in.Mouse = imgui.Vec2{X: mouse.X * scale.X, Y: mouse.Y * scale.Y}
+	in.Delta = imgui.Vec2{X: delta.X * scale.X, Y: delta.Y * scale.Y}
+	if hovered {
+		in.Wheel = imgui.Vec2{X: io.MouseWheelH(), Y: io.MouseWheel()}
+	}
+	clicked := false
+	for i, button := range []imgui.MouseButton{imgui.MouseButtonLeft, imgui.MouseButtonRight, imgui.MouseButtonMiddle} {
+		if hovered && imgui.IsMouseClickedBool(button) {
+			v.pressed[i] = true
+			clicked = true
+		}
+		if !imgui.IsMouseDown(button) {
+			v.pressed[i] = false
+		}
+	}
+	if clicked {
+		v.focused = true
+	} else if imgui.IsMouseClickedBool(imgui.MouseButtonLeft) {
+		v.focused = false
+	}
+	in.Buttons = v.pressed
+	in.Focused = v.focused
+	return in
+}
+
+// textureRef returns the imgui reference of the GL texture, keeping it while the hook
+// returns the same texture.
+func (v *Viewport3D) textureRef(texture uint32) (imgui.TextureRef, bool) {
+	if texture == 0 {
+		return imgui.TextureRef{}, false
+	}
+	if v.ref == nil || texture != v.texture {
+		v.releaseRef()
+		v.ref = imgui.NewTextureRefTextureID(imgui.TextureID(texture))
+		v.texture = texture
+	}
+	return *v.ref, true
+}
+
+func (v *Viewport3D) releaseRef() {
+	if v.ref != nil {
+		v.ref.Destroy()
+		v.ref, v.texture = nil, 0
+	}
+}
+
+// OnUnmount implements Unmounter, releasing the hook. the size is forgotten, so the next
+// frame drawn reports Resized and the hook can recreate its framebuffer.
+func (v *Viewport3D) OnUnmount() {
+	v.releaseRef()
+	if v.Hook != nil {
+		v.Hook.Release()
+	}
+	v.width, v.height = 0, 0
+	v.pressed, v.focused = [3]bool{}, false
+}
+
+// viewportPixels converts a size in points to framebuffer pixels.
+func viewportPixels(size, scale imgui.Vec2) (int, int) {
+	if scale.X <= 0 || scale.Y <= 0 {
+		scale = imgui.Vec2{X: 1, Y: 1}
+	}
+	return max(int(size.X*scale.X+0.5), 1), max(int(size.Y*scale.Y+0.5), 1)
+}
@@ -0,0 +1,53 @@
+package dfx
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+type testRenderHook struct {
+	released int
+}
+
+func (h *testRenderHook) Render(frame ViewportFrame) uint32 { return 0 }
+func (h *testRenderHook) Release()                          { h.released++ }
+
+func TestViewportPixels(t *testing.T) {
+	w, h := viewportPixels(imgui.Vec2{X: 100, Y: 50.4}, imgui.Vec2{X: 2, Y: 2})
+	if w != 200 {
+		t.Fatalf("expected '%v', got '%v'", 200, w)
+	}
+	if h != 101 {
+		t.Fatalf("expected '%v', got '%v'", 101, h)
+	}
+
+	w, h = viewportPixels(imgui.Vec2{X: 0.1, Y: 10}, imgui.Vec2{})
+	if w != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, w)
+	}
+	if h != 10 {
+		t.Fatalf("expected '%v', got '%v'", 10, h)
+	}
+}
+
+func TestViewport3D_UnmountReleasesHook(t *testing.T) {
+	withLifecycleTracker(t)
+	hook := &testRenderHook{}
+	v := NewViewport3D(hook)
+	lifecycle.drawn(v)
+	v.width, v.height = 640, 480
+
+	UnmountComponent(v)
+	if hook.released != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, hook.released)
+	}
+	if got := v.width; got != 0 {
+		t.Fatalf("expected 0, got '%v'", got)
+	} // the next frame reports Resized
+
+	UnmountComponent(v)
+	if hook.released != 1 {
+		t.Fatalf("expected '%v', got '%v'", 1, hook.released)
+	}
+}
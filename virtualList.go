@@ -0,0 +1,135 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// VirtualList draws count rows of rowHeight pixels (the row stride, including item
+// spacing) in the current window, calling draw only for the rows in view and reserving
+// the space of the rest, so lists of any length cost only their visible rows. a rowHeight
+// of 0 measures the first row. use it inside a scrolling child window.
+//
+//	dfx.VirtualList(len(items), imgui.TextLineHeightWithSpacing(), func(i int) {
+//	    imgui.TextUnformatted(items[i])
+//	})
+func VirtualList(count int, rowHeight float32, draw func(i int)) {
+	if count <= 0 {
+		return
+	}
+	clipper := imgui.NewListClipper()
+	defer clipper.Destroy()
+	if rowHeight > 0 {
+		clipper.BeginV(int32(count), rowHeight)
+	} else {
+		clipper.Begin(int32(count))
+	}
+	for clipper.Step() {
+		for i := int(clipper.DisplayStart()); i < int(clipper.DisplayEnd()); i++ {
+			draw(i)
+		}
+	}
+}
+
+// VariableList draws rows whose heights differ (wrapped text, expandable entries) with
+// the same economy as VirtualList. heights are measured as rows are drawn and cached by
+// row key; rows out of view are skipped using their cached height, or Estimate until
+// they have been seen. the cache is dropped when the content width changes.
+type VariableList struct {
+	Estimate float32 // height of rows not yet measured (0 = one text line)
+
+	heights     map[uint64]float32 // measured heights, by row key
+	width       float32            // content width the heights were measured at
+	scrollTo    int                // row to bring into view on the next draw (-1 = none)
+	scrollAlign float32
+}
+
+// NewVariableList creates a variable-height list.
+func NewVariableList() *VariableList {
+	return &VariableList{heights: make(map[uint64]float32), scrollTo: -1}
+}
+
+// Draw draws count rows in the current window, calling draw for the rows in view. key
+// identifies row i across frames (e.g. a message id) so its measured height follows it
+// as rows are inserted or filtered; nil keys rows by index.
+func (vl *VariableList) Draw(count int, key func(i int) uint64, draw func(i int)) {
+	if width := imgui.ContentRegionAvail().X; width != vl.width {
+		clear(vl.heights)
+		vl.width = width
+	}
+	if vl.heights == nil {
+		vl.heights = make(map[uint64]float32)
+	}
+	estimate := vl.Estimate
+	if estimate <= 0 {
+		estimate = imgui.TextLineHeight()
+	}
+
+	top := imgui.ScrollY()
+	bottom := top + imgui.WindowHeight()
+	y := float32(0)
+	skipped := float32(0)
+	scrollTo := float32(-1)
+	for i := 0; i < count; i++ {
+		k := uint64(i)
+		if key != nil {
+			k = key(i)
+		}
+		height, measured := vl.heights[k]
+		if !measured {
+			height = estimate
+		}
+		if i == vl.scrollTo {
+			scrollTo = y
+		}
+		if y+height < top || y > bottom {
+			y += height
+			skipped += height
+			continue
+		}
+		if skipped > 0 {
+			imgui.Dummy(imgui.Vec2{X: 1, Y: skipped})
+			skipped = 0
+		}
+		start := imgui.CursorPosY()
+		draw(i)
+		height = imgui.CursorPosY() - start
+		vl.heights[k] = height
+		y += height
+	}
+	if skipped > 0 {
+		imgui.Dummy(imgui.Vec2{X: 1, Y: skipped})
+	}
+
+	if scrollTo >= 0 {
+		imgui.SetScrollYFloat(scrollTo - imgui.WindowHeight()*vl.scrollAlign)
+	}
+	vl.scrollTo = -1
+}
+
+// ScrollTo brings row i into view on the next draw, with its top at align of the window
+// height (0 = top, 0.5 = middle).
+func (vl *VariableList) ScrollTo(i int, align float32) {
+	vl.scrollTo = i
+	vl.scrollAlign = align
+}
+
+// Height returns the measured height of the row with key, if it has been drawn.
+func (vl *VariableList) Height(key uint64) (float32, bool) {
+	height, found := vl.heights[key]
+	return height, found
+}
+
+// Forget drops the measured heights of rows for which keep returns false, e.g. rows
+// that no longer exist.
+func (vl *VariableList) Forget(keep func(key uint64) bool) {
+	for key := range vl.heights {
+		if !keep(key) {
+			delete(vl.heights, key)
+		}
+	}
+}
+
+// Reset drops all measured heights.
+func (vl *VariableList) Reset() {
+	clear(vl.heights)
+}
@@ -0,0 +1,50 @@
+package dfx
+
+import (
+	"testing"
+)
+
+func TestVariableListHeights(t *testing.T) {
+	vl := NewVariableList()
+	vl.heights[1] = 20
+	vl.heights[2] = 40
+	vl.heights[3] = 60
+
+	if height, found := vl.Height(2); !found || height != 40 {
+		t.Fatalf("expected height 40, got %v/%v", height, found)
+	}
+	vl.Forget(func(key uint64) bool { return key >= 2 })
+	if _, found := vl.Height(1); found {
+		t.Fatal("expected forgotten height to be dropped")
+	}
+	if _, found := vl.Height(3); !found {
+		t.Fatal("expected kept height to remain")
+	}
+	vl.Reset()
+	if len(vl.heights) != 0 {
+		t.Fatalf("expected no heights after reset, got %v", vl.heights)
+	}
+
+	vl.ScrollTo(7, 0.5)
+	if vl.scrollTo != 7 || vl.scrollAlign != 0.5 {
+		t.Fatalf("unexpected scroll request %v/%v", vl.scrollTo, vl.scrollAlign)
+	}
+}
+
+func TestLogBufferAt(t *testing.T) {
+	lb := NewLogBuffer(3)
+	for _, m := range []string{"a", "b", "c", "d"} {
+		lb.Add(LogMessage{Message: m})
+	}
+	lb.locked(func() {
+		if msg := lb.at(0); msg == nil || msg.Message != "b" {
+			t.Fatalf("expected oldest message 'b', got %v", msg)
+		}
+		if msg := lb.at(2); msg == nil || msg.Message != "d" {
+			t.Fatalf("expected newest message 'd', got %v", msg)
+		}
+		if lb.at(3) != nil || lb.at(-1) != nil {
+			t.Fatal("expected out-of-range indices to return nil")
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package dfx
 
 import (
+	"sync"
 	"time"
 
 	"github.com/AllenDang/cimgui-go/imgui"
@@ -22,6 +23,24 @@ func vuZoneColor(pos float32, colorLow, colorMid, colorHigh imgui.Vec4) imgui.Ve
 	return colorHigh
 }
 
+// vuColors holds meter colors resolved against the current theme for a single frame.
+type vuColors struct {
+	low, mid, high, off, peak, clip imgui.Vec4
+}
+
+// resolveVUColors resolves configured meter colors, using theme tokens for any zero-valued color.
+func resolveVUColors(low, mid, high, off, peak, clip imgui.Vec4) vuColors {
+	tc := ThemeColors()
+	return vuColors{
+		low:  themeColor(low, tc.MeterLow),
+		mid:  themeColor(mid, tc.MeterMid),
+		high: themeColor(high, tc.MeterHigh),
+		off:  themeColor(off, tc.MeterOff),
+		peak: themeColor(peak, tc.MeterPeak),
+		clip: themeColor(clip, tc.MeterClip),
+	}
+}
+
 // VUMeterMode defines the visual rendering style of the meter.
 type VUMeterMode int
 
@@ -35,7 +54,9 @@ const (
 )
 
 // VUMeter is a vertical level meter component.
-// supports any number of channels displayed side by side.
+// supports any number of channels displayed side by side. SetLevel and SetLevels are safe
+// to call from any goroutine, such as an audio callback; the levels are taken when the
+// meter is next drawn, and peaks and clips set between frames are not missed.
 type VUMeter struct {
 	Container
 
@@ -58,11 +79,15 @@ type VUMeter struct {
 	// clip indicator configuration
 	ClipHoldMs int // how long clip indicator stays lit in ms (default: 2000)
 
+	// rendering
+	RedrawThreshold float32 // level and peak changes up to this keep the meter as last drawn (default: 0.002)
+	Textured        bool    // draw highres and segmented meters from textures built once (needs an App)
+
 	// labels (optional, per-channel)
 	Labels      []string // custom labels like "L", "R", "Kick", etc.
 	LabelHeight float32  // height reserved for labels (default: 16)
 
-	// colors (configurable, with sensible defaults)
+	// colors (zero value = use the theme's Meter* semantic colors)
 	ColorLow  imgui.Vec4 // green zone (0-60%)
 	ColorMid  imgui.Vec4 // yellow zone (60-80%)
 	ColorHigh imgui.Vec4 // red zone (80-100%)
@@ -70,13 +95,34 @@ type VUMeter struct {
 	ColorPeak imgui.Vec4 // peak indicator color
 	ColorClip imgui.Vec4 // clip indicator color (bright red)
 
+	// levels set from any goroutine, taken by Draw
+	levelMu      sync.Mutex
+	pending      []float32 // latest level set per channel
+	pendingHighs []float32 // highest level set per channel since they were last taken
+
 	// internal state
 	levels    []float32   // current level per channel (0.0-1.0)
+	highs     []float32   // highest level per channel since the last frame
 	peaks     []float32   // peak level per channel
 	peakTimes []time.Time // when each peak was set
 	clipped   []bool      // whether channel has clipped
 	clipTimes []time.Time // when each clip occurred
-	lastFrame time.Time   // for delta time calculation
+	colors    vuColors    // colors resolved for the current frame
+
+	batch    rectBatch // rectangles of the meter as last built, relative to its top left
+	drawn    vuDrawn   // what batch shows
+	strips   *vuStrips // segment textures, when Textured
+	stripLit []float32 // lit height of each channel's strip, when textured
+}
+
+// vuDrawn is the state of the meter its batch was built from.
+type vuDrawn struct {
+	valid    bool
+	geometry vuGeometry
+	textured bool
+	levels   []float32
+	peaks    []float32
+	clipped  []bool
 }
 
 // NewVUMeter creates a new VU meter with the specified number of channels.
@@ -98,18 +144,11 @@ func NewVUMeter(channelCount int) *VUMeter {
 		// clip defaults
 		ClipHoldMs: 2000,
 
+		// rendering defaults
+		RedrawThreshold: 0.002,
+
 		// label defaults
 		LabelHeight: 14,
-
-		// default colors
-		ColorLow:  imgui.Vec4{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0},    // green
-		ColorMid:  imgui.Vec4{X: 0.9, Y: 0.8, Z: 0.1, W: 1.0},    // yellow
-		ColorHigh: imgui.Vec4{X: 0.9, Y: 0.2, Z: 0.2, W: 1.0},    // red
-		ColorOff:  imgui.Vec4{X: 0.15, Y: 0.15, Z: 0.15, W: 1.0}, // dark gray
-		ColorPeak: imgui.Vec4{X: 1.0, Y: 1.0, Z: 1.0, W: 0.9},    // white
-		ColorClip: imgui.Vec4{X: 1.0, Y: 0.0, Z: 0.0, W: 1.0},    // bright red
-
-		lastFrame: time.Now(),
 	}
 
 	v.Visible = true
@@ -122,7 +161,13 @@ func NewVUMeter(channelCount int) *VUMeter {
 func (v *VUMeter) initChannels(count int) {
 	now := time.Now()
 
+	v.levelMu.Lock()
+	v.pending = make([]float32, count)
+	v.pendingHighs = make([]float32, count)
+	v.levelMu.Unlock()
+
 	v.levels = make([]float32, count)
+	v.highs = make([]float32, count)
 	v.peaks = make([]float32, count)
 	v.peakTimes = make([]time.Time, count)
 	v.clipped = make([]bool, count)
@@ -147,21 +192,44 @@ func (v *VUMeter) SetChannelCount(count int) {
 	v.initChannels(count)
 }
 
-// SetLevel sets the level for a single channel (0.0 to 1.0).
+// SetLevel sets the level for a single channel (0.0 to 1.0). it is safe to call from any
+// goroutine.
 func (v *VUMeter) SetLevel(channel int, level float32) {
-	if channel < 0 || channel >= len(v.levels) {
+	v.levelMu.Lock()
+	defer v.levelMu.Unlock()
+	if channel < 0 || channel >= len(v.pending) {
 		return
 	}
-	v.levels[channel] = clamp(level, 0, 1)
+	v.setPending(channel, level)
 }
 
-// SetLevels sets the levels for all channels at once.
+// SetLevels sets the levels for all channels at once. it is safe to call from any
+// goroutine; levels is not kept.
 func (v *VUMeter) SetLevels(levels []float32) {
-	for i := 0; i < len(levels) && i < len(v.levels); i++ {
-		v.levels[i] = clamp(levels[i], 0, 1)
+	v.levelMu.Lock()
+	defer v.levelMu.Unlock()
+	for i := 0; i < len(levels) && i < len(v.pending); i++ {
+		v.setPending(i, levels[i])
 	}
 }
 
+// setPending sets the level of a channel until the meter is drawn. the caller holds
+// levelMu.
+func (v *VUMeter) setPending(channel int, level float32) {
+	level = clamp(level, 0, 1)
+	v.pending[channel] = level
+	v.pendingHighs[channel] = max(v.pendingHighs[channel], level)
+}
+
+// takeLevels takes the levels set since the last frame for drawing.
+func (v *VUMeter) takeLevels() {
+	v.levelMu.Lock()
+	defer v.levelMu.Unlock()
+	copy(v.levels, v.pending)
+	copy(v.highs, v.pendingHighs)
+	copy(v.pendingHighs, v.pending)
+}
+
 // SetLabel sets the label for a single channel.
 func (v *VUMeter) SetLabel(channel int, label string) {
 	// grow labels slice if needed
@@ -191,60 +259,42 @@ func (v *VUMeter) Draw(state *State) {
 		return
 	}
 
-	// calculate delta time for peak decay
-	now := time.Now()
-	deltaTime := float32(now.Sub(v.lastFrame).Seconds())
-	v.lastFrame = now
+	// peaks decay by the time since the last frame
+	now := state.Clock()
+	var deltaTime float32
+	if state != nil {
+		deltaTime = float32(state.DeltaTime.Seconds())
+	}
 
-	// update peaks and clip indicators
-	v.updatePeaks(deltaTime)
-	v.updateClip()
+	// resolve colors against the current theme
+	v.colors = resolveVUColors(v.ColorLow, v.ColorMid, v.ColorHigh, v.ColorOff, v.ColorPeak, v.ColorClip)
+
+	// update peaks and clip indicators from the levels set since the last frame
+	v.takeLevels()
+	v.updatePeaks(now, deltaTime)
+	v.updateClip(now)
 
 	// get draw position and draw list
 	cursor := imgui.CursorScreenPos()
 	dl := imgui.WindowDrawList()
 
-	// calculate clip indicator height (fixed size for all modes)
-	clipHeight := float32(8)
-	clipGap := float32(2)
-	meterHeight := v.Height - v.LabelHeight - clipHeight - clipGap
-
-	// draw each channel
-	for ch := 0; ch < len(v.levels); ch++ {
-		xOffset := float32(ch) * (v.ChannelWidth + v.ChannelGap)
-
-		// draw clip indicator at top
-		clipTop := cursor.Y
-		clipBottom := clipTop + clipHeight
-		clipLeft := cursor.X + xOffset
-		clipRight := clipLeft + v.ChannelWidth
-
-		var clipColor imgui.Vec4
-		if v.clipped[ch] {
-			clipColor = v.ColorClip
-		} else {
-			clipColor = v.ColorOff
-		}
-		dl.AddRectFilled(
-			imgui.Vec2{X: clipLeft, Y: clipTop},
-			imgui.Vec2{X: clipRight, Y: clipBottom},
-			imgui.ColorConvertFloat4ToU32(clipColor),
-		)
-
-		// draw meter based on mode
-		meterTop := clipBottom + clipGap
-		level := v.levels[ch]
-		peakLevel := v.peaks[ch]
-
-		switch v.Mode {
-		case VUMeterHighres:
-			v.drawHighresChannel(dl, cursor, ch, xOffset, level, peakLevel, meterTop, meterHeight)
-		case VUMeterSegmented:
-			v.drawSegmentedChannel(dl, cursor, ch, xOffset, level, peakLevel, meterTop, meterHeight)
-		default: // VUMeterSolid
-			v.drawSolidChannel(dl, cursor, ch, xOffset, level, peakLevel, meterTop, meterHeight)
-		}
+	// rebuild the meter's rectangles only when it looks different
+	geometry := v.geometry()
+	if v.strips != nil && (v.strips.geometry != geometry || !v.Textured) {
+		v.releaseStrips()
+	}
+	if v.Textured && v.Mode != VUMeterSolid && v.strips == nil && state != nil && state.App != nil {
+		count, height, gap := v.segmentLayout(geometry.meterHeight())
+		v.strips = newVUStrips(state.App.Textures(), geometry, v.colors.palette(), count, height, gap)
+	}
+	textured := v.strips.ready()
+	if !v.drawnCurrent(geometry, textured) {
+		v.rebuild(geometry, textured)
 	}
+	if textured {
+		v.strips.draw(dl, cursor, v.stripLit)
+	}
+	v.batch.draw(dl, cursor)
 
 	// draw labels at bottom using consistent font metrics
 	v.drawLabels(cursor, dl)
@@ -255,6 +305,100 @@ func (v *VUMeter) Draw(state *State) {
 	drawContainerExtensions(&v.Container, state)
 }
 
+// clip indicator size (fixed for all modes)
+const (
+	vuClipHeight = float32(8)
+	vuClipGap    = float32(2)
+)
+
+// vuGeometry is what the meter's rectangles depend on besides its levels, peaks and clip
+// indicators; a change rebuilds them.
+type vuGeometry struct {
+	mode         VUMeterMode
+	channels     int
+	height       float32
+	channelWidth float32
+	channelGap   float32
+	labelHeight  float32
+	segmentCount int
+	segmentGap   float32
+	peakHold     bool
+	colors       vuColors
+}
+
+// geometry returns the meter's current geometry.
+func (v *VUMeter) geometry() vuGeometry {
+	return vuGeometry{
+		mode:         v.Mode,
+		channels:     len(v.levels),
+		height:       v.Height,
+		channelWidth: v.ChannelWidth,
+		channelGap:   v.ChannelGap,
+		labelHeight:  v.LabelHeight,
+		segmentCount: v.SegmentCount,
+		segmentGap:   v.SegmentGap,
+		peakHold:     v.PeakHoldMs > 0,
+		colors:       v.colors,
+	}
+}
+
+// meterHeight returns the height of the meter below the clip indicators.
+func (g vuGeometry) meterHeight() float32 {
+	return g.height - g.labelHeight - vuClipHeight - vuClipGap
+}
+
+// drawnCurrent reports whether the batch still shows the meter: the geometry is the same,
+// and no level or peak moved more than RedrawThreshold since it was built.
+func (v *VUMeter) drawnCurrent(geometry vuGeometry, textured bool) bool {
+	if !v.drawn.valid || v.drawn.geometry != geometry || v.drawn.textured != textured {
+		return false
+	}
+	for ch := range v.levels {
+		if abs32(v.levels[ch]-v.drawn.levels[ch]) > v.RedrawThreshold ||
+			abs32(v.peaks[ch]-v.drawn.peaks[ch]) > v.RedrawThreshold ||
+			v.clipped[ch] != v.drawn.clipped[ch] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuild fills the batch with the meter's rectangles, relative to its top left corner.
+// when textured, the segments come from the strips and only the clip indicators and peaks
+// are batched.
+func (v *VUMeter) rebuild(geometry vuGeometry, textured bool) {
+	v.drawn.valid, v.drawn.geometry, v.drawn.textured = true, geometry, textured
+	v.drawn.levels = append(v.drawn.levels[:0], v.levels...)
+	v.drawn.peaks = append(v.drawn.peaks[:0], v.peaks...)
+	v.drawn.clipped = append(v.drawn.clipped[:0], v.clipped...)
+	v.batch.reset()
+	v.stripLit = v.stripLit[:0]
+
+	p := v.colors.palette()
+	meterTop := vuClipHeight + vuClipGap
+	meterHeight := geometry.meterHeight()
+	for ch := range v.levels {
+		left := float32(ch) * (v.ChannelWidth + v.ChannelGap)
+
+		// clip indicator at top
+		clipColor := p.off
+		if v.clipped[ch] {
+			clipColor = p.clip
+		}
+		v.batch.add(imgui.Vec2{X: left, Y: 0}, imgui.Vec2{X: left + v.ChannelWidth, Y: vuClipHeight}, clipColor)
+
+		level, peakLevel := v.levels[ch], v.peaks[ch]
+		switch {
+		case v.Mode == VUMeterSolid:
+			v.addSolidChannel(&v.batch, p, left, level, peakLevel, meterTop, meterHeight)
+		case textured:
+			v.stripLit = append(v.stripLit, v.addStripPeak(&v.batch, p, left, level, peakLevel, meterTop, meterHeight))
+		default:
+			v.addSegmentedChannel(&v.batch, p, left, level, peakLevel, meterTop, meterHeight)
+		}
+	}
+}
+
 // drawLabels renders channel labels with consistent font metrics.
 // uses draw list AddText directly to avoid cursor positioning issues in nested contexts.
 func (v *VUMeter) drawLabels(cursor imgui.Vec2, dl *imgui.DrawList) {
@@ -287,22 +431,15 @@ func (v *VUMeter) drawLabels(cursor imgui.Vec2, dl *imgui.DrawList) {
 	PopFont()
 }
 
-// segmentColor returns the color for a segment based on its position.
-func (v *VUMeter) segmentColor(segment int) imgui.Vec4 {
-	pos := float32(segment) / float32(v.SegmentCount)
-	return vuZoneColor(pos, v.ColorLow, v.ColorMid, v.ColorHigh)
-}
-
 // updatePeaks updates peak hold and decay for all channels.
-func (v *VUMeter) updatePeaks(deltaTime float32) {
+func (v *VUMeter) updatePeaks(now time.Time, deltaTime float32) {
 	if v.PeakHoldMs <= 0 {
 		return
 	}
 
-	now := time.Now()
 	for i, level := range v.levels {
-		if level > v.peaks[i] {
-			v.peaks[i] = level
+		if high := v.highs[i]; high > v.peaks[i] {
+			v.peaks[i] = high
 			v.peakTimes[i] = now
 		} else if now.Sub(v.peakTimes[i]).Milliseconds() > int64(v.PeakHoldMs) {
 			// decay peak after hold time
@@ -318,10 +455,9 @@ func (v *VUMeter) updatePeaks(deltaTime float32) {
 }
 
 // updateClip updates clip indicators for all channels.
-func (v *VUMeter) updateClip() {
-	now := time.Now()
-	for i, level := range v.levels {
-		if level >= 1.0 {
+func (v *VUMeter) updateClip(now time.Time) {
+	for i, high := range v.highs {
+		if high >= 1.0 {
 			v.clipped[i] = true
 			v.clipTimes[i] = now
 		} else if v.clipped[i] && now.Sub(v.clipTimes[i]).Milliseconds() > int64(v.ClipHoldMs) {
@@ -331,139 +467,94 @@ func (v *VUMeter) updateClip() {
 	}
 }
 
-// drawSolidChannel renders a channel as a continuous fill with color zones.
-func (v *VUMeter) drawSolidChannel(dl *imgui.DrawList, cursor imgui.Vec2, ch int, xOffset float32, level, peakLevel float32, meterTop, meterHeight float32) {
-	segLeft := cursor.X + xOffset
-	segRight := segLeft + v.ChannelWidth
+// vuPalette is the meter colors packed for the draw list.
+type vuPalette struct {
+	low, mid, high, off, peak, clip uint32
+}
+
+// palette packs the colors.
+func (c vuColors) palette() vuPalette {
+	return vuPalette{
+		low:  imgui.ColorConvertFloat4ToU32(c.low),
+		mid:  imgui.ColorConvertFloat4ToU32(c.mid),
+		high: imgui.ColorConvertFloat4ToU32(c.high),
+		off:  imgui.ColorConvertFloat4ToU32(c.off),
+		peak: imgui.ColorConvertFloat4ToU32(c.peak),
+		clip: imgui.ColorConvertFloat4ToU32(c.clip),
+	}
+}
+
+// zone returns the packed color for a position based on zone thresholds.
+func (p vuPalette) zone(pos float32) uint32 {
+	if pos < VUZoneGreen {
+		return p.low
+	} else if pos < VUZoneYellow {
+		return p.mid
+	}
+	return p.high
+}
+
+// addSolidChannel adds a channel drawn as a continuous fill with color zones.
+func (v *VUMeter) addSolidChannel(b *rectBatch, p vuPalette, left, level, peakLevel, meterTop, meterHeight float32) {
+	right := left + v.ChannelWidth
 	meterBottom := meterTop + meterHeight
 
-	// draw off background first
-	dl.AddRectFilled(
-		imgui.Vec2{X: segLeft, Y: meterTop},
-		imgui.Vec2{X: segRight, Y: meterBottom},
-		imgui.ColorConvertFloat4ToU32(v.ColorOff),
-	)
+	// off background first
+	b.add(imgui.Vec2{X: left, Y: meterTop}, imgui.Vec2{X: right, Y: meterBottom}, p.off)
 
 	if level > 0 {
-		// fill from bottom up based on level
-		fillHeight := level * meterHeight
-		fillTop := meterBottom - fillHeight
-
-		// draw color zones
-		if level <= VUZoneGreen {
-			// only green zone lit
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: fillTop},
-				imgui.Vec2{X: segRight, Y: meterBottom},
-				imgui.ColorConvertFloat4ToU32(v.ColorLow),
-			)
-		} else if level <= VUZoneYellow {
-			// green fully lit, yellow partially lit
-			greenTop := meterBottom - (VUZoneGreen * meterHeight)
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: greenTop},
-				imgui.Vec2{X: segRight, Y: meterBottom},
-				imgui.ColorConvertFloat4ToU32(v.ColorLow),
-			)
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: fillTop},
-				imgui.Vec2{X: segRight, Y: greenTop},
-				imgui.ColorConvertFloat4ToU32(v.ColorMid),
-			)
-		} else {
-			// all zones lit
-			greenTop := meterBottom - (VUZoneGreen * meterHeight)
-			yellowTop := meterBottom - (VUZoneYellow * meterHeight)
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: greenTop},
-				imgui.Vec2{X: segRight, Y: meterBottom},
-				imgui.ColorConvertFloat4ToU32(v.ColorLow),
-			)
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: yellowTop},
-				imgui.Vec2{X: segRight, Y: greenTop},
-				imgui.ColorConvertFloat4ToU32(v.ColorMid),
-			)
-			dl.AddRectFilled(
-				imgui.Vec2{X: segLeft, Y: fillTop},
-				imgui.Vec2{X: segRight, Y: yellowTop},
-				imgui.ColorConvertFloat4ToU32(v.ColorHigh),
-			)
+		// fill from bottom up based on level, one rectangle per lit zone
+		fillTop := meterBottom - level*meterHeight
+		greenTop := meterBottom - (VUZoneGreen * meterHeight)
+		yellowTop := meterBottom - (VUZoneYellow * meterHeight)
+		b.add(imgui.Vec2{X: left, Y: max(fillTop, greenTop)}, imgui.Vec2{X: right, Y: meterBottom}, p.low)
+		if level > VUZoneGreen {
+			b.add(imgui.Vec2{X: left, Y: max(fillTop, yellowTop)}, imgui.Vec2{X: right, Y: greenTop}, p.mid)
+		}
+		if level > VUZoneYellow {
+			b.add(imgui.Vec2{X: left, Y: fillTop}, imgui.Vec2{X: right, Y: yellowTop}, p.high)
 		}
 	}
 
-	// draw peak indicator as thin line
+	// peak indicator as thin line
 	if v.PeakHoldMs > 0 && peakLevel > 0 {
 		peakY := meterBottom - (peakLevel * meterHeight)
 		peakHeight := float32(2)
-		dl.AddRectFilled(
-			imgui.Vec2{X: segLeft, Y: peakY - peakHeight/2},
-			imgui.Vec2{X: segRight, Y: peakY + peakHeight/2},
-			imgui.ColorConvertFloat4ToU32(v.ColorPeak),
-		)
+		b.add(imgui.Vec2{X: left, Y: peakY - peakHeight/2}, imgui.Vec2{X: right, Y: peakY + peakHeight/2}, p.peak)
 	}
 }
 
-// drawHighresChannel renders a channel with 1px segments and 1px gaps.
-func (v *VUMeter) drawHighresChannel(dl *imgui.DrawList, cursor imgui.Vec2, ch int, xOffset float32, level, peakLevel float32, meterTop, meterHeight float32) {
-	// fixed 1px segment, 1px gap
-	segmentHeight := float32(1)
-	segmentGap := float32(1)
-	segmentCount := int((meterHeight + segmentGap) / (segmentHeight + segmentGap))
+// segmentLayout returns the number, height and gap of the segments of a meter meterHeight
+// tall: fixed 1px segments with 1px gaps in highres mode, SegmentCount segments SegmentGap
+// apart in segmented mode.
+func (v *VUMeter) segmentLayout(meterHeight float32) (count int, height, gap float32) {
+	if v.Mode == VUMeterHighres {
+		return int((meterHeight + 1) / 2), 1, 1
+	}
+	count = v.SegmentCount
+	return count, (meterHeight - (float32(count-1) * v.SegmentGap)) / float32(count), v.SegmentGap
+}
 
+// addSegmentedChannel adds a channel drawn as discrete segments (highres and segmented
+// modes).
+func (v *VUMeter) addSegmentedChannel(b *rectBatch, p vuPalette, left, level, peakLevel, meterTop, meterHeight float32) {
+	segmentCount, segmentHeight, segmentGap := v.segmentLayout(meterHeight)
 	litSegments := int(level * float32(segmentCount))
 	peakSegment := int(peakLevel * float32(segmentCount))
+	right := left + v.ChannelWidth
 
 	for seg := 0; seg < segmentCount; seg++ {
 		segTop := meterTop + meterHeight - float32(seg+1)*(segmentHeight+segmentGap) + segmentGap
 		segBottom := segTop + segmentHeight
-		segLeft := cursor.X + xOffset
-		segRight := segLeft + v.ChannelWidth
 
-		var segColor imgui.Vec4
+		var segColor uint32
 		if seg < litSegments {
-			pos := float32(seg) / float32(segmentCount)
-			segColor = vuZoneColor(pos, v.ColorLow, v.ColorMid, v.ColorHigh)
+			segColor = p.zone(float32(seg) / float32(segmentCount))
 		} else if seg == peakSegment && v.PeakHoldMs > 0 {
-			segColor = v.ColorPeak
+			segColor = p.peak
 		} else {
-			segColor = v.ColorOff
+			segColor = p.off
 		}
-
-		dl.AddRectFilled(
-			imgui.Vec2{X: segLeft, Y: segTop},
-			imgui.Vec2{X: segRight, Y: segBottom},
-			imgui.ColorConvertFloat4ToU32(segColor),
-		)
-	}
-}
-
-// drawSegmentedChannel renders a channel using discrete segments with configurable count and gap.
-func (v *VUMeter) drawSegmentedChannel(dl *imgui.DrawList, cursor imgui.Vec2, ch int, xOffset float32, level, peakLevel float32, meterTop, meterHeight float32) {
-	segmentHeight := (meterHeight - (float32(v.SegmentCount-1) * v.SegmentGap)) / float32(v.SegmentCount)
-
-	litSegments := int(level * float32(v.SegmentCount))
-	peakSegment := int(peakLevel * float32(v.SegmentCount))
-
-	for seg := 0; seg < v.SegmentCount; seg++ {
-		segTop := meterTop + meterHeight - float32(seg+1)*(segmentHeight+v.SegmentGap) + v.SegmentGap
-		segBottom := segTop + segmentHeight
-		segLeft := cursor.X + xOffset
-		segRight := segLeft + v.ChannelWidth
-
-		var segColor imgui.Vec4
-		if seg < litSegments {
-			segColor = v.segmentColor(seg)
-		} else if seg == peakSegment && v.PeakHoldMs > 0 {
-			segColor = v.ColorPeak
-		} else {
-			segColor = v.ColorOff
-		}
-
-		dl.AddRectFilled(
-			imgui.Vec2{X: segLeft, Y: segTop},
-			imgui.Vec2{X: segRight, Y: segBottom},
-			imgui.ColorConvertFloat4ToU32(segColor),
-		)
+		b.add(imgui.Vec2{X: left, Y: segTop}, imgui.Vec2{X: right, Y: segBottom}, segColor)
 	}
 }
@@ -0,0 +1,100 @@
+package dfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// vuStrips holds the segments of a highres or segmented meter as a texture two pixels wide
+// and the meter's height: a column of unlit segments and a column of lit ones. every
+// channel is drawn from it with two images however many segments it has, and the images of
+// all channels share one draw command.
+type vuStrips struct {
+	geometry vuGeometry
+	texture  *Texture
+	rows     int // texture height in pixels
+}
+
+// newVUStrips makes the strips of a meter with the given geometry and segment layout.
+func newVUStrips(textures *TextureManager, geometry vuGeometry, p vuPalette, count int, height, gap float32) *vuStrips {
+	meterHeight := geometry.meterHeight()
+	rows := max(int(math.Ceil(float64(meterHeight))), 1)
+	img := image.NewNRGBA(image.Rect(0, 0, 2, rows))
+	for y := 0; y < rows; y++ {
+		// segments are counted from the bottom; rows sample at their centers
+		d := meterHeight - (float32(y) + 0.5)
+		if d < 0 {
+			continue
+		}
+		seg := int(d / (height + gap))
+		if seg >= count || d-float32(seg)*(height+gap) >= height {
+			continue
+		}
+		img.SetNRGBA(0, y, packedNRGBA(p.off))
+		img.SetNRGBA(1, y, packedNRGBA(p.zone(float32(seg)/float32(count))))
+	}
+	return &vuStrips{geometry: geometry, texture: textures.Create(img), rows: rows}
+}
+
+// packedNRGBA unpacks a draw list color.
+func packedNRGBA(c uint32) color.NRGBA {
+	return color.NRGBA{R: uint8(c), G: uint8(c >> 8), B: uint8(c >> 16), A: uint8(c >> 24)}
+}
+
+// ready reports whether the strips can be drawn.
+func (s *vuStrips) ready() bool {
+	return s != nil && !s.texture.Released()
+}
+
+// draw draws each channel's strip at cursor: the unlit column over the meter, and the lit
+// column over the bottom lit[ch] pixels.
+func (s *vuStrips) draw(dl *imgui.DrawList, cursor imgui.Vec2, lit []float32) {
+	ref, ok := s.texture.TextureRef()
+	if !ok {
+		return
+	}
+	g := s.geometry
+	meterTop := cursor.Y + vuClipHeight + vuClipGap
+	meterHeight := g.meterHeight()
+	bottom := meterHeight / float32(s.rows)
+	for ch, height := range lit {
+		left := cursor.X + float32(ch)*(g.channelWidth+g.channelGap)
+		right := left + g.channelWidth
+		dl.AddImageV(ref, imgui.Vec2{X: left, Y: meterTop}, imgui.Vec2{X: right, Y: meterTop + meterHeight},
+			imgui.Vec2{X: 0.25, Y: 0}, imgui.Vec2{X: 0.25, Y: bottom}, 0xffffffff)
+		if height > 0 {
+			top := meterHeight - height
+			dl.AddImageV(ref, imgui.Vec2{X: left, Y: meterTop + top}, imgui.Vec2{X: right, Y: meterTop + meterHeight},
+				imgui.Vec2{X: 0.75, Y: top / float32(s.rows)}, imgui.Vec2{X: 0.75, Y: bottom}, 0xffffffff)
+		}
+	}
+}
+
+// addStripPeak adds the peak segment of a textured channel, returning the height of the
+// channel's lit segments.
+func (v *VUMeter) addStripPeak(b *rectBatch, p vuPalette, left, level, peakLevel, meterTop, meterHeight float32) float32 {
+	segmentCount, segmentHeight, segmentGap := v.segmentLayout(meterHeight)
+	litSegments := int(level * float32(segmentCount))
+	peakSegment := int(peakLevel * float32(segmentCount))
+	if v.PeakHoldMs > 0 && peakSegment >= litSegments && peakSegment < segmentCount {
+		segTop := meterTop + meterHeight - float32(peakSegment+1)*(segmentHeight+segmentGap) + segmentGap
+		b.add(imgui.Vec2{X: left, Y: segTop}, imgui.Vec2{X: left + v.ChannelWidth, Y: segTop + segmentHeight}, p.peak)
+	}
+	return min(float32(litSegments)*(segmentHeight+segmentGap), meterHeight)
+}
+
+// releaseStrips releases the meter's segment textures.
+func (v *VUMeter) releaseStrips() {
+	if v.strips != nil {
+		v.strips.texture.Release()
+		v.strips = nil
+	}
+}
+
+// OnUnmount implements Unmounter, releasing the segment textures of a Textured meter.
+func (v *VUMeter) OnUnmount() {
+	v.releaseStrips()
+}
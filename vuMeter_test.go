@@ -0,0 +1,148 @@
+package dfx
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestVUMeter_RebuildsPastThreshold(t *testing.T) {
+	v := NewVUMeter(2)
+	v.Mode = VUMeterSegmented
+	v.SetLevels([]float32{0.5, 0.25})
+	v.takeLevels()
+	geometry := v.geometry()
+	v.rebuild(geometry, false)
+	if got := v.batch.len(); got != 2*(1+v.SegmentCount) {
+		t.Fatalf("expected '%v', got '%v'", 2*(1+v.SegmentCount), got)
+	} // a clip indicator and the segments per channel
+	if !v.drawnCurrent(geometry, false) {
+		t.Fatal("expected v.drawnCurrent(geometry, false)")
+	}
+
+	v.SetLevel(0, 0.501)
+	v.takeLevels()
+	if !v.drawnCurrent(geometry, false) {
+		t.Fatal("changes within the threshold keep the meter: expected v.drawnCurrent(geometry, false)")
+	}
+	v.SetLevel(0, 0.51)
+	v.takeLevels()
+	if v.drawnCurrent(geometry, false) {
+		t.Fatal("unexpected v.drawnCurrent(geometry, false)")
+	}
+	v.rebuild(geometry, false)
+
+	v.clipped[1] = true
+	if v.drawnCurrent(geometry, false) {
+		t.Fatal("unexpected v.drawnCurrent(geometry, false)")
+	}
+	v.rebuild(geometry, false)
+
+	v.SegmentCount = 10
+	if v.drawnCurrent(v.geometry(), false) {
+		t.Fatal("unexpected v.drawnCurrent(v.geometry(), false)")
+	}
+	if v.drawnCurrent(geometry, true) {
+		t.Fatal("unexpected v.drawnCurrent(geometry, true)")
+	}
+}
+
+func TestVUMeter_TexturedBatchesPeaksOnly(t *testing.T) {
+	v := NewVUMeter(2)
+	v.Mode = VUMeterSegmented
+	v.SegmentCount = 4
+	v.SegmentGap = 2
+	v.Height, v.LabelHeight = 50, 0 // 40px meter: 8.5px segments
+	v.SetLevels([]float32{0.5, 0})
+	v.takeLevels()
+	v.peaks = []float32{0.8, 0}
+	v.rebuild(v.geometry(), true)
+
+	if got := v.batch.len(); got != 4 {
+		t.Fatalf("expected '%v', got '%v'", 4, got)
+	} // two clip indicators and the peaks, the silent one on the bottom segment
+	if !slices.Equal(v.stripLit, []float32{21, 0}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{21, 0}, v.stripLit)
+	}
+}
+
+func TestVUStrips_Pattern(t *testing.T) {
+	var uploaded *image.RGBA
+	m := NewTextureManager()
+	m.create = func(rgba *image.RGBA) (imgui.TextureRef, func()) {
+		uploaded = rgba
+		return imgui.TextureRef{}, func() {}
+	}
+	geometry := vuGeometry{height: 50} // 40px meter below the clip indicator
+	p := vuPalette{low: 0xff00ff00, mid: 0xff00ffff, off: 0xff202020}
+	s := newVUStrips(m, geometry, p, 4, 8.5, 2)
+	if _, ok := s.texture.TextureRef(); !ok || uploaded == nil {
+		t.Fatal("expected the strips to upload")
+	}
+	if got := uploaded.Bounds(); got != image.Rect(0, 0, 2, 40) {
+		t.Fatalf("expected '%v', got '%v'", image.Rect(0, 0, 2, 40), got)
+	}
+
+	if got := uploaded.RGBAAt(0, 39); got != (color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}, got)
+	} // bottom segment, unlit
+	if got := uploaded.RGBAAt(1, 39); got != (color.RGBA{G: 0xff, A: 0xff}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{G: 0xff, A: 0xff}, got)
+	} // bottom segment, lit low
+	if got := uploaded.RGBAAt(1, 30); got != (color.RGBA{}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{}, got)
+	} // gap above it
+	if got := uploaded.RGBAAt(1, 0); got != (color.RGBA{R: 0xff, G: 0xff, A: 0xff}) {
+		t.Fatalf("expected '%v', got '%v'", color.RGBA{R: 0xff, G: 0xff, A: 0xff}, got)
+	} // top segment, lit mid
+}
+
+func TestVUMeter_KeepsPeaksSetBetweenFrames(t *testing.T) {
+	v := NewVUMeter(2)
+	now := time.Now()
+	v.SetLevels([]float32{1, 0.7})
+	v.SetLevels([]float32{0.2, 0.1})
+	v.takeLevels()
+	v.updatePeaks(now, 0)
+	v.updateClip(now)
+	if !slices.Equal(v.levels, []float32{0.2, 0.1}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{0.2, 0.1}, v.levels)
+	}
+	if !slices.Equal(v.peaks, []float32{1, 0.7}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{1, 0.7}, v.peaks)
+	}
+	if !slices.Equal(v.clipped, []bool{true, false}) {
+		t.Fatalf("expected '%v', got '%v'", []bool{true, false}, v.clipped)
+	}
+
+	// the next frame starts from the latest levels
+	v.takeLevels()
+	if !slices.Equal(v.highs, []float32{0.2, 0.1}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{0.2, 0.1}, v.highs)
+	}
+}
+
+func TestVUMeter_ConcurrentLevels(t *testing.T) {
+	v := NewVUMeter(4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			v.SetLevel(i%4, float32(i%10)/10)
+			v.SetLevels([]float32{0.1, 0.2, 0.3, 0.4})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		v.takeLevels()
+		v.rebuild(v.geometry(), false)
+	}
+	<-done
+	v.takeLevels()
+	if !slices.Equal(v.levels, []float32{0.1, 0.2, 0.3, 0.4}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{0.1, 0.2, 0.3, 0.4}, v.levels)
+	}
+}
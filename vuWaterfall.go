@@ -1,6 +1,7 @@
 package dfx
 
 import (
+	"sync"
 	"time"
 
 	"github.com/AllenDang/cimgui-go/imgui"
@@ -9,6 +10,8 @@ import (
 // VUWaterfall is a scrolling history display of VU levels over time.
 // new data appears at the bottom and scrolls upward.
 // each row shows a horizontal bar whose width represents the level at that time slice.
+// SetLevel, SetLevels and Annotate are safe to call from any goroutine, such as an audio
+// callback.
 type VUWaterfall struct {
 	Container
 
@@ -24,16 +27,25 @@ type VUWaterfall struct {
 	SampleInterval time.Duration // minimum time between samples (default: 16ms)
 
 	// display mode
-	Highres bool // when true, alternates row opacity for scanline effect
+	Highres  bool // when true, alternates row opacity for scanline effect
+	ShowGrid bool // when true, draws a line at each second of history and the meter zone boundaries
 
-	// colors (same as VUMeter for consistency)
+	// export (see ExportPNG and ExportCSV), offered from the context menu
+	ChannelLabels []string                        // CSV column names (default: ch1, ch2, ...)
+	ExportPath    func(ext string) (string, bool) // chooses where to save an export, such as with a save dialog (nil = DefaultExportPath)
+	OnExport      func(path string, err error)    // called after exporting from the context menu
+
+	// colors (zero value = use the theme's Meter* semantic colors)
 	ColorLow  imgui.Vec4 // green zone (0-60%)
 	ColorMid  imgui.Vec4 // yellow zone (60-80%)
 	ColorHigh imgui.Vec4 // red zone (80-100%)
 	ColorOff  imgui.Vec4 // background/inactive
 
-	// internal state
+	// internal state; the history is guarded by mu, as it is added to from any goroutine
+	mu           sync.Mutex
 	history      [][]float32 // circular buffer: history[row][channel]
+	times        []time.Time // when each row was sampled
+	notes        []string    // annotation of each row ("" = none)
 	historyHead  int         // index where next entry will be written
 	historyLen   int         // current number of valid entries
 	channelCount int         // number of channels
@@ -54,12 +66,6 @@ func NewVUWaterfall(channelCount int) *VUWaterfall {
 		HistorySize:    100,
 		SampleInterval: 16 * time.Millisecond, // ~60 samples per second
 
-		// colors (match VUMeter defaults)
-		ColorLow:  imgui.Vec4{X: 0.2, Y: 0.8, Z: 0.2, W: 1.0},    // green
-		ColorMid:  imgui.Vec4{X: 0.9, Y: 0.8, Z: 0.1, W: 1.0},    // yellow
-		ColorHigh: imgui.Vec4{X: 0.9, Y: 0.2, Z: 0.2, W: 1.0},    // red
-		ColorOff:  imgui.Vec4{X: 0.15, Y: 0.15, Z: 0.15, W: 1.0}, // dark gray
-
 		channelCount: channelCount,
 	}
 
@@ -75,6 +81,8 @@ func (w *VUWaterfall) initHistory() {
 	for i := range w.history {
 		w.history[i] = make([]float32, w.channelCount)
 	}
+	w.times = make([]time.Time, w.HistorySize)
+	w.notes = make([]string, w.HistorySize)
 	w.historyHead = 0
 	w.historyLen = 0
 }
@@ -90,6 +98,8 @@ func (w *VUWaterfall) SetChannelCount(count int) {
 	if count == w.channelCount {
 		return
 	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.channelCount = count
 	w.initHistory()
 }
@@ -103,6 +113,8 @@ func (w *VUWaterfall) SetHistorySize(size int) {
 	if size == w.HistorySize {
 		return
 	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.HistorySize = size
 	w.initHistory()
 }
@@ -111,6 +123,8 @@ func (w *VUWaterfall) SetHistorySize(size int) {
 // note: this creates a new row with only this channel set; prefer SetLevels for multi-channel.
 // If SampleInterval is set, samples are throttled to maintain consistent scroll speed.
 func (w *VUWaterfall) SetLevel(channel int, level float32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	if channel < 0 || channel >= w.channelCount {
 		return
 	}
@@ -127,17 +141,15 @@ func (w *VUWaterfall) SetLevel(channel int, level float32) {
 		w.history[w.historyHead][i] = 0
 	}
 	w.history[w.historyHead][channel] = clamp(level, 0, 1)
-
-	// advance head
-	w.historyHead = (w.historyHead + 1) % w.HistorySize
-	if w.historyLen < w.HistorySize {
-		w.historyLen++
-	}
+	w.advanceHead(now)
 }
 
 // SetLevels sets levels for all channels at once and adds a new history entry.
 // If SampleInterval is set, samples are throttled to maintain consistent scroll speed.
 func (w *VUWaterfall) SetLevels(levels []float32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	// throttle samples based on time interval
 	now := time.Now()
 	if w.SampleInterval > 0 && time.Since(w.lastSample) < w.SampleInterval {
@@ -153,14 +165,30 @@ func (w *VUWaterfall) SetLevels(levels []float32) {
 			w.history[w.historyHead][i] = 0
 		}
 	}
+	w.advanceHead(now)
+}
 
-	// advance head
+// advanceHead completes the row at the head, sampled at now, and moves on to the next.
+func (w *VUWaterfall) advanceHead(now time.Time) {
+	w.times[w.historyHead] = now
+	w.notes[w.historyHead] = ""
 	w.historyHead = (w.historyHead + 1) % w.HistorySize
 	if w.historyLen < w.HistorySize {
 		w.historyLen++
 	}
 }
 
+// Annotate labels the newest row, such as to mark an event in the measurement. the row
+// is marked with a line, the label is shown as its tooltip and exported with it.
+func (w *VUWaterfall) Annotate(label string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.historyLen == 0 {
+		return
+	}
+	w.notes[(w.historyHead-1+w.HistorySize)%w.HistorySize] = label
+}
+
 // Width returns the calculated total width of the waterfall.
 func (w *VUWaterfall) Width() float32 {
 	if w.channelCount == 0 {
@@ -174,50 +202,50 @@ func (w *VUWaterfall) Draw(state *State) {
 	if !w.Visible {
 		return
 	}
+	imgui.PushIDStr(componentID("vuWaterfall", w))
+	defer imgui.PopID()
 
 	cursor := imgui.CursorScreenPos()
 	dl := imgui.WindowDrawList()
+	colors := resolveVUColors(w.ColorLow, w.ColorMid, w.ColorHigh, w.ColorOff, imgui.Vec4{}, imgui.Vec4{})
+	w.mu.Lock()
+	w.render(cursor, colors, func(min, max imgui.Vec2, color imgui.Vec4) {
+		dl.AddRectFilled(min, max, imgui.ColorConvertFloat4ToU32(color))
+	})
+	w.mu.Unlock()
 
+	// reserve space for layout
+	imgui.Dummy(imgui.Vec2{X: w.Width(), Y: w.Height})
+	if imgui.IsItemHovered() {
+		if note := w.noteAt(imgui.MousePos().Y - cursor.Y); note != "" {
+			imgui.SetTooltip(note)
+		}
+	}
+	w.drawContextMenu()
+
+	drawContainerExtensions(&w.Container, state)
+}
+
+// render draws the waterfall at origin with fill, which fills a rectangle with a color.
+// the imgui draw list and PNG export both render through it. the caller holds mu.
+func (w *VUWaterfall) render(origin imgui.Vec2, colors vuColors, fill func(min, max imgui.Vec2, color imgui.Vec4)) {
 	totalWidth := w.Width()
 
 	// draw background
-	dl.AddRectFilled(
-		cursor,
-		imgui.Vec2{X: cursor.X + totalWidth, Y: cursor.Y + w.Height},
-		imgui.ColorConvertFloat4ToU32(w.ColorOff),
-	)
-
-	if w.historyLen == 0 {
-		// reserve space and return
-		imgui.Dummy(imgui.Vec2{X: totalWidth, Y: w.Height})
-		drawContainerExtensions(&w.Container, state)
-		return
+	fill(origin, imgui.Vec2{X: origin.X + totalWidth, Y: origin.Y + w.Height}, colors.off)
+	if w.ShowGrid {
+		w.renderZoneGrid(origin, fill)
 	}
 
-	// calculate row positions
-	// we want newest at bottom, oldest at top
-	// so we draw from top (oldest) to bottom (newest)
+	// newest at bottom, oldest at top
+	start, count, yOffset := w.visibleRows()
 	rowStep := w.RowHeight + w.RowGap
-
-	// calculate how many rows fit in the available height
-	maxVisibleRows := int(w.Height / rowStep)
-	visibleRows := w.historyLen
-	if visibleRows > maxVisibleRows {
-		visibleRows = maxVisibleRows
-	}
-
-	// calculate starting index (skip older entries that don't fit)
-	// we want the newest entries, so skip (historyLen - visibleRows) oldest entries
-	skipCount := w.historyLen - visibleRows
-	startIdx := (w.historyHead - w.historyLen + skipCount + w.HistorySize) % w.HistorySize
-
-	// calculate vertical offset to align rows at bottom of display
-	totalRowsHeight := float32(visibleRows) * rowStep
-	yOffset := w.Height - totalRowsHeight
-
-	for row := 0; row < visibleRows; row++ {
-		histIdx := (startIdx + row) % w.HistorySize
-		rowY := cursor.Y + yOffset + float32(row)*rowStep
+	tc := ThemeColors()
+	grid := tc.Muted
+	grid.W *= 0.35
+	for row := 0; row < count; row++ {
+		histIdx := (start + row) % w.HistorySize
+		rowY := origin.Y + yOffset + float32(row)*rowStep
 
 		for ch := 0; ch < w.channelCount; ch++ {
 			level := w.history[histIdx][ch]
@@ -226,7 +254,7 @@ func (w *VUWaterfall) Draw(state *State) {
 			}
 
 			// calculate bar position and size
-			chX := cursor.X + float32(ch)*(w.ChannelWidth+w.ChannelGap)
+			chX := origin.X + float32(ch)*(w.ChannelWidth+w.ChannelGap)
 			barWidth := level * w.ChannelWidth
 
 			// center the bar horizontally within the channel
@@ -234,34 +262,98 @@ func (w *VUWaterfall) Draw(state *State) {
 			barRight := barLeft + barWidth
 
 			// determine color based on level
-			color := vuZoneColor(level, w.ColorLow, w.ColorMid, w.ColorHigh)
+			color := vuZoneColor(level, colors.low, colors.mid, colors.high)
 
 			// in highres mode, reduce opacity on every other row for scanline effect
 			if w.Highres && row%2 == 1 {
 				color.W *= 0.3 // reduce alpha to 30%
 			}
 
-			dl.AddRectFilled(
-				imgui.Vec2{X: barLeft, Y: rowY},
-				imgui.Vec2{X: barRight, Y: rowY + w.RowHeight},
-				imgui.ColorConvertFloat4ToU32(color),
-			)
+			fill(imgui.Vec2{X: barLeft, Y: rowY}, imgui.Vec2{X: barRight, Y: rowY + w.RowHeight}, color)
+		}
+
+		// a line where the history crosses a whole second of age, and at annotations
+		lineMin, lineMax := imgui.Vec2{X: origin.X, Y: rowY}, imgui.Vec2{X: origin.X + totalWidth, Y: rowY + 1}
+		if w.ShowGrid && row > 0 && w.secondBoundary((start+row-1)%w.HistorySize, histIdx) {
+			fill(lineMin, lineMax, grid)
+		}
+		if w.notes[histIdx] != "" {
+			fill(lineMin, lineMax, tc.Accent)
 		}
 	}
+}
 
-	// reserve space for layout
-	imgui.Dummy(imgui.Vec2{X: totalWidth, Y: w.Height})
+// renderZoneGrid draws the boundaries of the meter zones in each channel. bars grow from
+// the channel center, so each boundary is a pair of lines.
+func (w *VUWaterfall) renderZoneGrid(origin imgui.Vec2, fill func(min, max imgui.Vec2, color imgui.Vec4)) {
+	grid := ThemeColors().Muted
+	grid.W *= 0.35
+	for ch := 0; ch < w.channelCount; ch++ {
+		center := origin.X + float32(ch)*(w.ChannelWidth+w.ChannelGap) + w.ChannelWidth/2
+		for _, zone := range []float32{VUZoneGreen, VUZoneYellow} {
+			for _, x := range []float32{center - zone*w.ChannelWidth/2, center + zone*w.ChannelWidth/2} {
+				fill(imgui.Vec2{X: x, Y: origin.Y}, imgui.Vec2{X: x + 1, Y: origin.Y + w.Height}, grid)
+			}
+		}
+	}
+}
 
-	drawContainerExtensions(&w.Container, state)
+// visibleRows returns the history index of the oldest row shown, the number of rows shown
+// and the offset of the first row from the top, which aligns the newest row with the
+// bottom.
+func (w *VUWaterfall) visibleRows() (start, count int, yOffset float32) {
+	rowStep := w.RowHeight + w.RowGap
+	count = w.historyLen
+	if rowStep > 0 {
+		count = min(count, int(w.Height/rowStep))
+	}
+	// skip the oldest entries that don't fit
+	start = (w.historyHead - count + w.HistorySize) % w.HistorySize
+	return start, count, w.Height - float32(count)*rowStep
+}
+
+// secondBoundary returns true when a whole second of age lies between the rows at history
+// indices older and newer, measured from the newest row.
+func (w *VUWaterfall) secondBoundary(older, newer int) bool {
+	newest := w.times[(w.historyHead-1+w.HistorySize)%w.HistorySize]
+	if w.times[older].IsZero() || w.times[newer].IsZero() {
+		return false
+	}
+	return newest.Sub(w.times[older])/time.Second != newest.Sub(w.times[newer])/time.Second
+}
+
+// noteAt returns the annotation of the row at y from the top of the waterfall.
+func (w *VUWaterfall) noteAt(y float32) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	start, count, yOffset := w.visibleRows()
+	rowStep := w.RowHeight + w.RowGap
+	if rowStep <= 0 {
+		return ""
+	}
+	// annotations are thin lines; accept the rows around the pointer too
+	row := int((y - yOffset) / rowStep)
+	for r := row - 1; r <= row+1; r++ {
+		if r >= 0 && r < count {
+			if note := w.notes[(start+r)%w.HistorySize]; note != "" {
+				return note
+			}
+		}
+	}
+	return ""
 }
 
 // Clear resets the history buffer.
 func (w *VUWaterfall) Clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.historyHead = 0
 	w.historyLen = 0
 	for i := range w.history {
 		for j := range w.history[i] {
 			w.history[i][j] = 0
 		}
+		w.times[i] = time.Time{}
+		w.notes[i] = ""
 	}
 }
@@ -0,0 +1,154 @@
+package dfx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/pkg/errors"
+)
+
+// VUSample is a row of VUWaterfall history.
+type VUSample struct {
+	Time   time.Time
+	Levels []float32 // level of each channel (0-1)
+	Note   string    // annotation (see VUWaterfall.Annotate)
+}
+
+// History returns the rows currently shown, oldest first.
+func (w *VUWaterfall) History() []VUSample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	start, count, _ := w.visibleRows()
+	samples := make([]VUSample, count)
+	for row := range samples {
+		idx := (start + row) % w.HistorySize
+		samples[row] = VUSample{
+			Time:   w.times[idx],
+			Levels: append([]float32(nil), w.history[idx]...),
+			Note:   w.notes[idx],
+		}
+	}
+	return samples
+}
+
+// ExportCSV saves the rows currently shown to path as CSV: the sample time, the level of
+// each channel and the annotation, oldest first.
+func (w *VUWaterfall) ExportCSV(path string) error {
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	header := []string{"time"}
+	for ch := 0; ch < w.channelCount; ch++ {
+		header = append(header, w.channelLabel(ch))
+	}
+	if err := out.Write(append(header, "note")); err != nil {
+		return errors.Wrap(err, "error writing csv")
+	}
+	for _, sample := range w.History() {
+		record := []string{sample.Time.Format(time.RFC3339Nano)}
+		for _, level := range sample.Levels {
+			record = append(record, strconv.FormatFloat(float64(level), 'f', 4, 32))
+		}
+		if err := out.Write(append(record, sample.Note)); err != nil {
+			return errors.Wrap(err, "error writing csv")
+		}
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return errors.Wrap(err, "error writing csv")
+	}
+	return writeFileAtomic(path, buf.Bytes(), 0644)
+}
+
+// ExportPNG saves the waterfall as shown, with its grid and annotation lines, to path as
+// a PNG, at one pixel per point.
+func (w *VUWaterfall) ExportPNG(path string) error {
+	return writePNG(path, w.Image())
+}
+
+// Image renders the waterfall as shown, at one pixel per point.
+func (w *VUWaterfall) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, max(int(w.Width()), 1), max(int(w.Height), 1)))
+	colors := resolveVUColors(w.ColorLow, w.ColorMid, w.ColorHigh, w.ColorOff, imgui.Vec4{}, imgui.Vec4{})
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.render(imgui.Vec2{}, colors, func(min, max imgui.Vec2, c imgui.Vec4) {
+		rect := image.Rect(int(min.X), int(min.Y), int(max.X+0.5), int(max.Y+0.5))
+		draw.Draw(img, rect, image.NewUniform(vec4Color(c)), image.Point{}, draw.Over)
+	})
+	return img
+}
+
+// channelLabel returns the CSV column name of channel ch.
+func (w *VUWaterfall) channelLabel(ch int) string {
+	if ch < len(w.ChannelLabels) && w.ChannelLabels[ch] != "" {
+		return w.ChannelLabels[ch]
+	}
+	return fmt.Sprintf("ch%d", ch+1)
+}
+
+// drawContextMenu offers the exports when the waterfall is right-clicked.
+func (w *VUWaterfall) drawContextMenu() {
+	if !imgui.BeginPopupContextItemV("##export", imgui.PopupFlagsMouseButtonRight) {
+		return
+	}
+	if imgui.MenuItemBool(T("dfx.vu.exportPNG")) {
+		w.export(".png", w.ExportPNG)
+	}
+	if imgui.MenuItemBool(T("dfx.vu.exportCSV")) {
+		w.export(".csv", w.ExportCSV)
+	}
+	imgui.EndPopup()
+}
+
+// export saves with save to the path chosen for ext, and reports the result to OnExport.
+func (w *VUWaterfall) export(ext string, save func(path string) error) {
+	var path string
+	var err error
+	if w.ExportPath != nil {
+		var ok bool
+		if path, ok = w.ExportPath(ext); !ok {
+			return
+		}
+	} else {
+		path, err = DefaultExportPath("vu", ext)
+	}
+	if err == nil {
+		err = save(path)
+	}
+	if w.OnExport != nil {
+		w.OnExport(path, err)
+	}
+}
+
+// DefaultExportPath returns a path in the user's home directory for an export, named
+// after prefix and the current time, such as ~/vu-20260102-150405.png.
+func DefaultExportPath(prefix, ext string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting user home directory")
+	}
+	return filepath.Join(home, exportName(prefix, ext, time.Now())), nil
+}
+
+func exportName(prefix, ext string, now time.Time) string {
+	return prefix + "-" + now.Format("20060102-150405") + ext
+}
+
+// vec4Color converts an imgui color to a Go color.
+func vec4Color(c imgui.Vec4) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(clamp(c.X, 0, 1)*255 + 0.5),
+		G: uint8(clamp(c.Y, 0, 1)*255 + 0.5),
+		B: uint8(clamp(c.Z, 0, 1)*255 + 0.5),
+		A: uint8(clamp(c.W, 0, 1)*255 + 0.5),
+	}
+}
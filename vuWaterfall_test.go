@@ -1,6 +1,14 @@
 package dfx
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
 
 func TestSetHistorySize_ClampsInvalidToOne(t *testing.T) {
 	w := NewVUWaterfall(2)
@@ -39,3 +47,88 @@ func TestSetLevels_AfterClampMaintainsValidCircularBuffer(t *testing.T) {
 		t.Fatalf("expected historyHead to remain wrapped at '0', got '%d'", w.historyHead)
 	}
 }
+
+func newTestWaterfall(levels ...[]float32) *VUWaterfall {
+	w := NewVUWaterfall(2)
+	w.SampleInterval = 0
+	w.SetHistorySize(4)
+	for _, l := range levels {
+		w.SetLevels(l)
+	}
+	return w
+}
+
+func TestHistory_OldestFirstWithinWindow(t *testing.T) {
+	w := newTestWaterfall([]float32{0.1, 0.2}, []float32{0.3, 0.4}, []float32{0.5, 0.6})
+	w.Annotate("peak")
+	w.Height = 4 // two rows of RowHeight 2
+
+	history := w.History()
+	if len(history) != 2 {
+		t.Fatalf("expected '2' visible rows, got '%d'", len(history))
+	}
+	if history[0].Levels[0] != 0.3 || history[1].Levels[1] != 0.6 {
+		t.Fatalf("unexpected history %v", history)
+	}
+	if history[0].Note != "" || history[1].Note != "peak" {
+		t.Fatalf("expected the newest row to be annotated, got %v", history)
+	}
+
+	w.SetLevels([]float32{0.7, 0.8})
+	if note := w.History()[0].Note; note != "peak" {
+		t.Fatalf("expected the annotation to scroll with its row, got '%v'", note)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	w := newTestWaterfall([]float32{0.25, 1})
+	w.Annotate("start, take 1")
+	w.ChannelLabels = []string{"left"}
+	path := filepath.Join(t.TempDir(), "vu.csv")
+	if err := w.ExportCSV(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[0] != "time,left,ch2,note" {
+		t.Fatalf("unexpected csv:\n%s", data)
+	}
+	if !strings.HasSuffix(lines[1], `,0.2500,1.0000,"start, take 1"`) {
+		t.Fatalf("unexpected row '%v'", lines[1])
+	}
+}
+
+func TestImage_RendersBarsAndAnnotations(t *testing.T) {
+	w := newTestWaterfall([]float32{1, 0}, []float32{0, 0})
+	w.Annotate("mark")
+	w.ColorLow = imgui.Vec4{X: 0, Y: 1, Z: 0, W: 1}
+	w.ColorHigh = imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}
+	w.ColorOff = imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+
+	img := w.Image()
+	if img.Bounds().Dx() != int(w.Width()) || img.Bounds().Dy() != int(w.Height) {
+		t.Fatalf("unexpected image size %v", img.Bounds())
+	}
+	// the older row (full level, red) sits above the newest, annotated row
+	bottom := int(w.Height)
+	if c := img.RGBAAt(20, bottom-3); c.R != 255 || c.G != 0 {
+		t.Fatalf("expected a red bar, got %v", c)
+	}
+	if c := img.RGBAAt(60, bottom-3); c.R != 0 || c.G != 0 {
+		t.Fatalf("expected the background in the silent channel, got %v", c)
+	}
+	accent := vec4Color(ThemeColors().Accent)
+	if c := img.RGBAAt(60, bottom-2); c.R != accent.R || c.G != accent.G {
+		t.Fatalf("expected the annotation line, got %v", c)
+	}
+}
+
+func TestExportName(t *testing.T) {
+	name := exportName("vu", ".png", time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+	if name != "vu-20260102-150405.png" {
+		t.Fatalf("unexpected name '%v'", name)
+	}
+}
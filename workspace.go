@@ -1,6 +1,20 @@
 package dfx
 
-import "github.com/AllenDang/cimgui-go/imgui"
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// WorkspaceSelectorStyle selects how a Workspace renders its selector.
+type WorkspaceSelectorStyle int
+
+const (
+	// WorkspaceSelectorCombo renders a combo box (the default).
+	WorkspaceSelectorCombo WorkspaceSelectorStyle = iota
+	// WorkspaceSelectorTabs renders a horizontal tab strip with an overflow menu.
+	WorkspaceSelectorTabs
+)
 
 // Workspace manages multiple named components and allows switching between them.
 // provides a high-level component for building applications with multiple views/modes.
@@ -12,14 +26,28 @@ type Workspace struct {
 	items        []*workspaceItem          // ordered list for iteration
 	itemsById    map[string]*workspaceItem // fast lookup by id
 	currentIndex int
+	tabSync      bool // current index changed outside the tab strip; select it on the next draw
+	active       *workspaceItem
+	now          func() time.Time
+	mru          []string // workspace ids, most recently used first
+	switcher     workspaceSwitcher
+	cycling      bool              // next/previous actions registered
+	navKeys      [2]imgui.KeyChord // imgui's window switching keys (next, previous), while taken over
+	navKeysSaved bool              // navKeys holds the keys to restore on hide
 
 	// configuration
-	ShowSelector  bool    // if true, shows a combo selector at the top
-	SelectorLabel string  // label for the combo selector
-	SelectorWidth float32 // width of selector (-1 for auto-width)
+	ShowSelector    bool                   // if true, shows a selector at the top
+	SelectorStyle   WorkspaceSelectorStyle // combo (default) or tab strip
+	SelectorLabel   string                 // label for the combo selector
+	SelectorWidth   float32                // width of combo selector (-1 for auto-width)
+	TabsClosable    bool                   // if true, tabs show a close button (tab style only)
+	UnloadAfter     time.Duration          // if > 0, lazy workspaces inactive for this long are unloaded
+	ShowSwitcher    bool                   // if true, cycling opens an MRU-ordered switcher overlay (see EnableCycling)
+	ShowBreadcrumbs bool                   // if true, shows the path through nested workspaces above the content
 
 	// callbacks
-	OnSwitch func(oldId, newId string) // called when workspace changes (passes IDs)
+	OnSwitch   func(oldId, newId string) // called when workspace changes (passes IDs)
+	OnTabClose func(id string) bool      // called when a tab's close button is clicked; return false to keep it. removes the workspace when nil
 }
 
 // NewWorkspace creates a new workspace manager.
@@ -41,34 +69,67 @@ func NewWorkspace() *Workspace {
 
 // Add adds or replaces a workspace with the given id, display name, and component.
 // if this is the first workspace added, it becomes current.
-// if a workspace with the same id exists, it is replaced.
+// if a workspace with the same id exists, it is replaced and its component unmounted.
 func (ws *Workspace) Add(id, name string, component Component) {
 	// check if already exists
 	existing, exists := ws.itemsById[id]
 
 	if exists {
 		// update existing item
+		if existing == ws.active {
+			ws.deactivate()
+		}
+		if existing.Component != nil && existing.Component != component {
+			UnmountComponent(existing.Component)
+		}
 		existing.Name = name
 		existing.Component = component
+		existing.factory = nil
+		ws.tabSync = true
 	} else {
-		// create new item
-		item := &workspaceItem{
-			Id:        id,
-			Name:      name,
-			Component: component,
-			index:     len(ws.items),
+		ws.addItem(&workspaceItem{Id: id, Name: name, Component: component})
+	}
+}
+
+// AddLazy adds or replaces a workspace whose component is constructed by factory the first
+// time the workspace becomes current. combined with UnloadAfter, the component is released
+// again after it has been inactive for a while and rebuilt on the next switch.
+func (ws *Workspace) AddLazy(id, name string, factory func() Component) {
+	if existing, exists := ws.itemsById[id]; exists {
+		if existing == ws.active {
+			ws.deactivate()
 		}
+		if existing.Component != nil {
+			UnmountComponent(existing.Component)
+		}
+		existing.Name = name
+		existing.Component = nil
+		existing.factory = factory
+		ws.tabSync = true
+	} else {
+		ws.addItem(&workspaceItem{Id: id, Name: name, factory: factory})
+	}
+}
 
-		// add to ordered list
-		ws.items = append(ws.items, item)
+// Loaded reports whether the component for the workspace with the given id currently exists.
+func (ws *Workspace) Loaded(id string) bool {
+	item, exists := ws.itemsById[id]
+	return exists && item.Component != nil
+}
 
-		// add to map
-		ws.itemsById[id] = item
+// addItem appends a new workspace item.
+func (ws *Workspace) addItem(item *workspaceItem) {
+	item.index = len(ws.items)
 
-		// if this is first workspace, make it current
-		if len(ws.items) == 1 {
-			ws.currentIndex = 0
-		}
+	// add to ordered list
+	ws.items = append(ws.items, item)
+
+	// add to map
+	ws.itemsById[item.Id] = item
+
+	// if this is first workspace, make it current
+	if len(ws.items) == 1 {
+		ws.currentIndex = 0
 	}
 }
 
@@ -80,8 +141,16 @@ func (ws *Workspace) Remove(id string) {
 		return
 	}
 
+	if item == ws.active {
+		ws.deactivate()
+	}
+	if item.Component != nil {
+		UnmountComponent(item.Component)
+	}
+
 	idx := item.index
 	delete(ws.itemsById, id)
+	ws.dropMRU(id)
 
 	// remove from ordered list
 	ws.items = append(ws.items[:idx], ws.items[idx+1:]...)
@@ -92,11 +161,16 @@ func (ws *Workspace) Remove(id string) {
 	}
 
 	// adjust current index if needed
+	if idx < ws.currentIndex {
+		ws.currentIndex-- // keep the same workspace current
+	}
 	if len(ws.items) == 0 {
 		ws.currentIndex = 0
 	} else if ws.currentIndex >= len(ws.items) {
 		ws.currentIndex = len(ws.items) - 1
 	}
+	ws.tabSync = true
+	ws.syncActive()
 }
 
 // Switch changes to the workspace with the given id.
@@ -109,7 +183,9 @@ func (ws *Workspace) Switch(id string) bool {
 
 	oldID := ws.Current()
 	ws.currentIndex = item.index
+	ws.tabSync = true
 	newID := ws.Current()
+	ws.syncActive()
 
 	// trigger callback if changed
 	if oldID != newID && ws.OnSwitch != nil {
@@ -128,7 +204,9 @@ func (ws *Workspace) SwitchByIndex(index int) bool {
 
 	oldID := ws.Current()
 	ws.currentIndex = index
+	ws.tabSync = true
 	newID := ws.Current()
+	ws.syncActive()
 
 	// trigger callback if changed
 	if oldID != newID && ws.OnSwitch != nil {
@@ -171,7 +249,67 @@ func (ws *Workspace) CurrentComponent() Component {
 	if ws.currentIndex < 0 || ws.currentIndex >= len(ws.items) {
 		return nil
 	}
-	return ws.items[ws.currentIndex].Component
+	return ws.load(ws.items[ws.currentIndex])
+}
+
+// SetIcon sets an icon (e.g. a fonts.ICON_* glyph) shown before the workspace name in the
+// tab strip selector. returns true if the workspace was found and updated.
+func (ws *Workspace) SetIcon(id, icon string) bool {
+	item, exists := ws.itemsById[id]
+	if !exists {
+		return false
+	}
+	item.Icon = icon
+	return true
+}
+
+// SetBadge sets a count shown as a Badge on the workspace's tab in the tab strip
+// selector (0 = none), such as unread messages or errors. returns true if the workspace
+// was found and updated.
+func (ws *Workspace) SetBadge(id string, count int) bool {
+	item, exists := ws.itemsById[id]
+	if !exists {
+		return false
+	}
+	item.Badge = count
+	return true
+}
+
+// SetContribution sets menus and toolbar items shown while the workspace is current (nil =
+// none), for components that do not implement MenuContribution themselves. returns true if
+// the workspace was found and updated.
+func (ws *Workspace) SetContribution(id string, c MenuContribution) bool {
+	item, exists := ws.itemsById[id]
+	if !exists {
+		return false
+	}
+	item.contribution = c
+	return true
+}
+
+// ContributeMenus implements MenuContribution with the contribution of the current
+// workspace.
+func (ws *Workspace) ContributeMenus(state *State) {
+	if c := ws.currentContribution(); c != nil {
+		c.ContributeMenus(state)
+	}
+}
+
+// ContributeToolbar implements MenuContribution with the contribution of the current
+// workspace.
+func (ws *Workspace) ContributeToolbar() []*ActionBarItem {
+	if c := ws.currentContribution(); c != nil {
+		return c.ContributeToolbar()
+	}
+	return nil
+}
+
+// currentContribution returns the contribution set for the current workspace, if any.
+func (ws *Workspace) currentContribution() MenuContribution {
+	if ws.currentIndex < 0 || ws.currentIndex >= len(ws.items) {
+		return nil
+	}
+	return ws.items[ws.currentIndex].contribution
 }
 
 // SetName changes the display name of a workspace without affecting its Id.
@@ -216,49 +354,201 @@ func (ws *Workspace) WorkspaceNames() []string {
 // draw renders the workspace selector and current component.
 func (ws *Workspace) draw(state *State) {
 	if len(ws.items) == 0 {
-		imgui.Text("no workspaces configured")
+		imgui.Text(T("dfx.workspace.none"))
 		return
 	}
 
+	ws.syncActive()
+	ws.unloadIdle()
+
 	// calculate available size
 	availableSize := state.Size
 	selectorHeight := float32(0)
 
 	// draw selector if enabled
+	startY := imgui.CursorPosY()
 	if ws.ShowSelector {
-		selectorHeight = 30 // approximate height for combo
-
-		// set width if specified
-		if ws.SelectorWidth > 0 {
-			imgui.PushItemWidth(ws.SelectorWidth)
-			defer imgui.PopItemWidth()
+		if ws.SelectorStyle == WorkspaceSelectorTabs {
+			ws.drawTabs()
+		} else {
+			ws.drawCombo()
 		}
 
-		// get display names for combo
-		names := ws.WorkspaceNames()
-
-		// draw combo with display names
-		newIndex, changed := Combo(ws.SelectorLabel, ws.currentIndex, names)
-		if changed {
-			ws.SwitchByIndex(newIndex)
+		// the tab strip may have closed the last workspace
+		if len(ws.items) == 0 {
+			return
 		}
-
-		// add spacing
-		imgui.Spacing()
 	}
+	if ws.ShowBreadcrumbs {
+		ws.DrawBreadcrumbs()
+	}
+	selectorHeight = imgui.CursorPosY() - startY
 
 	// draw current component
 	current := ws.CurrentComponent()
 	if current != nil {
-		// create state for current component with adjusted size
-		componentState := &State{
-			Size:     imgui.Vec2{X: availableSize.X, Y: availableSize.Y - selectorHeight},
-			Position: state.Position,
-			IO:       state.IO,
-			App:      state.App,
-			Parent:   ws,
+		// draw with the size left below the selector
+		size := imgui.Vec2{X: availableSize.X, Y: availableSize.Y - selectorHeight}
+		DrawChild(current, state.Child(size, state.Position).WithParent(ws))
+	}
+
+	ws.drawSwitcher()
+}
+
+// load returns the component for an item, constructing it first if the item is lazy.
+func (ws *Workspace) load(item *workspaceItem) Component {
+	if item.Component == nil && item.factory != nil {
+		item.Component = item.factory()
+		if item.pendingState != nil {
+			if sc, ok := item.Component.(StatefulComponent); ok {
+				sc.RestoreState(item.pendingState)
+			}
+			item.pendingState = nil
+		}
+	}
+	return item.Component
+}
+
+// syncActive tracks the current workspace, constructing it if lazy. components learn they
+// were switched to and away from through OnShow and OnHide (see Lifecycle).
+func (ws *Workspace) syncActive() {
+	var current *workspaceItem
+	if ws.currentIndex >= 0 && ws.currentIndex < len(ws.items) {
+		current = ws.items[ws.currentIndex]
+	}
+	if current == ws.active {
+		return
+	}
+	ws.deactivate()
+	if current != nil {
+		ws.active = current
+		ws.touchMRU(current.Id)
+		ws.load(current)
+	}
+}
+
+// deactivate records when the active workspace was last used.
+func (ws *Workspace) deactivate() {
+	if ws.active == nil {
+		return
+	}
+	ws.active.lastActive = ws.clock()
+	ws.active = nil
+}
+
+// unloadIdle releases lazy workspace components that have been inactive for UnloadAfter,
+// unmounting them (see Unmounter). state of StatefulComponents is kept and restored when
+// the workspace is rebuilt.
+func (ws *Workspace) unloadIdle() {
+	if ws.UnloadAfter <= 0 {
+		return
+	}
+	now := ws.clock()
+	for _, item := range ws.items {
+		if item == ws.active || item.factory == nil || item.Component == nil {
+			continue
+		}
+		if now.Sub(item.lastActive) < ws.UnloadAfter {
+			continue
+		}
+		if sc, ok := item.Component.(StatefulComponent); ok {
+			item.pendingState = sc.CaptureState()
+		}
+		UnmountComponent(item.Component)
+		item.Component = nil
+	}
+}
+
+func (ws *Workspace) clock() time.Time {
+	if ws.now != nil {
+		return ws.now()
+	}
+	return time.Now()
+}
+
+// drawCombo renders the combo selector.
+func (ws *Workspace) drawCombo() {
+	// set width if specified
+	if ws.SelectorWidth > 0 {
+		imgui.PushItemWidth(ws.SelectorWidth)
+		defer imgui.PopItemWidth()
+	}
+
+	// get display names for combo
+	names := ws.WorkspaceNames()
+
+	// draw combo with display names
+	newIndex, changed := Combo(ws.SelectorLabel, ws.currentIndex, names)
+	if changed {
+		ws.SwitchByIndex(newIndex)
+	}
+
+	// add spacing
+	imgui.Spacing()
+}
+
+// drawTabs renders the tab strip selector. tabs scroll when they don't fit, and the
+// list button opens a menu of all workspaces.
+func (ws *Workspace) drawTabs() {
+	flags := imgui.TabBarFlagsFittingPolicyScroll | imgui.TabBarFlagsTabListPopupButton
+	if !imgui.BeginTabBarV("##workspaceTabs", flags) {
+		return
+	}
+
+	sync := ws.tabSync
+	ws.tabSync = false
+	selected := -1
+	closed := ""
+	for i, item := range ws.items {
+		var itemFlags imgui.TabItemFlags
+		if sync && i == ws.currentIndex {
+			itemFlags |= imgui.TabItemFlagsSetSelected
+		}
+
+		open := true
+		var pOpen *bool
+		if ws.TabsClosable {
+			pOpen = &open
 		}
-		current.Draw(componentState)
+		if imgui.BeginTabItemV(item.tabLabel(), pOpen, itemFlags) {
+			selected = i
+			imgui.EndTabItem()
+		}
+		Badge(item.Badge, BadgeParams{})
+		if !open {
+			closed = item.Id
+		}
+	}
+	imgui.EndTabBar()
+
+	// while a programmatic switch is being applied, imgui still reports the old tab
+	if !sync {
+		ws.selectTab(selected)
+	}
+	if closed != "" {
+		ws.closeTab(closed)
+	}
+}
+
+// selectTab switches to the workspace at index when the user selected its tab
+// (-1 = no tab selected).
+func (ws *Workspace) selectTab(index int) {
+	if index >= 0 && index != ws.currentIndex {
+		ws.SwitchByIndex(index)
+		ws.tabSync = false // the tab strip already shows it
+	}
+}
+
+// closeTab removes the workspace whose tab close button was clicked, unless OnTabClose
+// keeps it. closing the current tab switches to its neighbour.
+func (ws *Workspace) closeTab(id string) {
+	if ws.OnTabClose != nil && !ws.OnTabClose(id) {
+		return
+	}
+	current := ws.Current()
+	ws.Remove(id)
+	if newID := ws.Current(); current == id && newID != "" && ws.OnSwitch != nil {
+		ws.OnSwitch(id, newID)
 	}
 }
 
@@ -286,9 +576,51 @@ func (ws *Workspace) ChildActions() []Component {
 	return nil
 }
 
+// CaptureState implements StatefulComponent, allowing workspaces to be nested inside
+// other workspaces while keeping their state.
+func (ws *Workspace) CaptureState() map[string]any {
+	config := CaptureWorkspaceState(ws)
+	states := make(map[string]any, len(config.States))
+	for id, state := range config.States {
+		states[id] = state
+	}
+	return map[string]any{"current": config.Current, "states": states}
+}
+
+// RestoreState implements StatefulComponent.
+func (ws *Workspace) RestoreState(state map[string]any) {
+	config := WorkspaceConfig{States: make(map[string]map[string]any)}
+	config.Current, _ = state["current"].(string)
+	if states, ok := state["states"].(map[string]any); ok {
+		for id, s := range states {
+			if m, ok := s.(map[string]any); ok {
+				config.States[id] = m
+			}
+		}
+	}
+	RestoreWorkspaceState(ws, config)
+}
+
 type workspaceItem struct {
 	Id        string    // stable identifier used in code
 	Name      string    // human-facing display name (can include icons, formatting)
+	Icon      string    // optional icon shown before the name in the tab strip
+	Badge     int       // optional count shown on the tab in the tab strip
 	Component Component // the component to display
 	index     int       // position in the ordered items slice
+
+	factory      func() Component // constructs Component on demand for lazy workspaces
+	pendingState map[string]any   // state to restore once a lazy component is constructed
+	lastActive   time.Time        // when the workspace was last deactivated
+	contribution MenuContribution // menus and toolbar items shown while the workspace is current
+}
+
+// tabLabel returns the item's label in the tab strip. the ### suffix keeps the tab
+// identity stable when the name changes.
+func (item *workspaceItem) tabLabel() string {
+	label := item.Name
+	if item.Icon != "" {
+		label = item.Icon + " " + label
+	}
+	return label + "###" + item.Id
 }
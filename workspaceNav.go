@@ -0,0 +1,223 @@
+package dfx
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// workspace navigation constants
+const (
+	workspaceNextKeys         = "Ctrl+Tab"
+	workspacePreviousKeys     = "Ctrl+Shift+Tab"
+	workspaceSwitcherMinWidth = 240
+	workspaceBreadcrumbSep    = ">"
+)
+
+// WorkspaceBreadcrumb is one level of the path through nested workspaces.
+type WorkspaceBreadcrumb struct {
+	Workspace *Workspace // the workspace at this level
+	Id        string     // id of the current workspace at this level
+	Name      string     // display name of the current workspace at this level
+}
+
+// workspaceSwitcher holds the state of the MRU switcher overlay.
+type workspaceSwitcher struct {
+	open     bool
+	ids      []string
+	selected int
+}
+
+// EnableCycling registers next/previous workspace actions (Ctrl+Tab / Ctrl+Shift+Tab).
+// when ShowSwitcher is set, the actions open an MRU-ordered overlay instead of switching
+// directly; holding Ctrl and pressing Tab moves through it, and releasing Ctrl switches.
+// with nested workspaces, the innermost workspace with cycling enabled handles the keys.
+func (ws *Workspace) EnableCycling() {
+	if ws.cycling {
+		return
+	}
+	ws.cycling = true
+	actions := ws.Container.Actions()
+	actions.MustRegister("Next Workspace", workspaceNextKeys, func() { ws.cycle(1) })
+	actions.MustRegister("Previous Workspace", workspacePreviousKeys, func() { ws.cycle(-1) })
+}
+
+// Next switches to the following workspace in order, wrapping around.
+func (ws *Workspace) Next() bool {
+	if len(ws.items) == 0 {
+		return false
+	}
+	return ws.SwitchByIndex((ws.currentIndex + 1) % len(ws.items))
+}
+
+// Previous switches to the preceding workspace in order, wrapping around.
+func (ws *Workspace) Previous() bool {
+	if len(ws.items) == 0 {
+		return false
+	}
+	return ws.SwitchByIndex((ws.currentIndex - 1 + len(ws.items)) % len(ws.items))
+}
+
+// MRU returns workspace ids ordered from most to least recently used. workspaces that
+// have never been current follow in their normal order.
+func (ws *Workspace) MRU() []string {
+	result := make([]string, 0, len(ws.items))
+	seen := make(map[string]bool, len(ws.items))
+	for _, id := range ws.mru {
+		if _, exists := ws.itemsById[id]; exists {
+			result = append(result, id)
+			seen[id] = true
+		}
+	}
+	for _, item := range ws.items {
+		if !seen[item.Id] {
+			result = append(result, item.Id)
+		}
+	}
+	return result
+}
+
+// Breadcrumbs returns the path from this workspace down through nested workspaces
+// (workspaces whose current component is itself a *Workspace).
+func (ws *Workspace) Breadcrumbs() []WorkspaceBreadcrumb {
+	var crumbs []WorkspaceBreadcrumb
+	for w := ws; w != nil; {
+		id := w.Current()
+		if id == "" {
+			break
+		}
+		crumbs = append(crumbs, WorkspaceBreadcrumb{Workspace: w, Id: id, Name: w.CurrentName()})
+		next, _ := w.CurrentComponent().(*Workspace)
+		w = next
+	}
+	return crumbs
+}
+
+// DrawBreadcrumbs renders the breadcrumb path. clicking a segment opens a menu of the
+// workspaces at that level.
+func (ws *Workspace) DrawBreadcrumbs() {
+	crumbs := ws.Breadcrumbs()
+	imgui.PushIDStr("##workspaceBreadcrumbs")
+	defer imgui.PopID()
+
+	for i, crumb := range crumbs {
+		if i > 0 {
+			imgui.SameLine()
+			imgui.TextDisabled(workspaceBreadcrumbSep)
+			imgui.SameLine()
+		}
+		imgui.PushIDInt(int32(i))
+		popupID := "##breadcrumbLevel"
+		if imgui.SmallButton(crumb.Name) {
+			imgui.OpenPopupStr(popupID)
+		}
+		if imgui.BeginPopup(popupID) {
+			for _, item := range crumb.Workspace.items {
+				if imgui.SelectableBoolV(item.Name, item.Id == crumb.Id, 0, imgui.Vec2{}) {
+					crumb.Workspace.Switch(item.Id)
+				}
+			}
+			imgui.EndPopup()
+		}
+		imgui.PopID()
+	}
+	imgui.Spacing()
+}
+
+// cycle handles the next/previous actions.
+func (ws *Workspace) cycle(dir int) {
+	if !ws.ShowSwitcher {
+		if dir > 0 {
+			ws.Next()
+		} else {
+			ws.Previous()
+		}
+		return
+	}
+
+	if !ws.switcher.open {
+		ids := ws.MRU()
+		if len(ids) < 2 {
+			return
+		}
+		ws.switcher = workspaceSwitcher{open: true, ids: ids}
+	}
+	n := len(ws.switcher.ids)
+	ws.switcher.selected = ((ws.switcher.selected+dir)%n + n) % n
+}
+
+// commitSwitcher closes the switcher overlay, switching to the selected workspace.
+func (ws *Workspace) commitSwitcher(accept bool) {
+	if accept && ws.switcher.selected < len(ws.switcher.ids) {
+		ws.Switch(ws.switcher.ids[ws.switcher.selected])
+	}
+	ws.switcher = workspaceSwitcher{}
+}
+
+// OnHide restores imgui's window switching keys, taken over while cycling is enabled.
+func (ws *Workspace) OnHide() {
+	if !ws.navKeysSaved {
+		return
+	}
+	ws.navKeysSaved = false
+	if ctx := imgui.CurrentContext(); ctx.CData != nil {
+		ctx.SetConfigNavWindowingKeyNext(ws.navKeys[0])
+		ctx.SetConfigNavWindowingKeyPrev(ws.navKeys[1])
+	}
+}
+
+// drawSwitcher renders the MRU switcher overlay while it is open.
+func (ws *Workspace) drawSwitcher() {
+	if ws.cycling && !ws.navKeysSaved {
+		// imgui binds Ctrl+Tab to its own window switching; the workspace owns it while it
+		// is shown, and OnHide gives it back
+		ctx := imgui.CurrentContext()
+		ws.navKeys = [2]imgui.KeyChord{ctx.ConfigNavWindowingKeyNext(), ctx.ConfigNavWindowingKeyPrev()}
+		ws.navKeysSaved = true
+		ctx.SetConfigNavWindowingKeyNext(0)
+		ctx.SetConfigNavWindowingKeyPrev(0)
+	}
+	if !ws.switcher.open {
+		return
+	}
+	io := imgui.CurrentIO()
+	if imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		ws.commitSwitcher(false)
+		return
+	}
+	if !io.KeyCtrl() {
+		ws.commitSwitcher(true)
+		return
+	}
+
+	imgui.SetNextWindowPosV(imgui.MainViewport().Center(), imgui.CondAlways, imgui.Vec2{X: 0.5, Y: 0.5})
+	flags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsAlwaysAutoResize | imgui.WindowFlagsNoSavedSettings |
+		imgui.WindowFlagsNoMove | imgui.WindowFlagsNoFocusOnAppearing | imgui.WindowFlagsNoNav
+	if imgui.BeginV("##workspaceSwitcher", nil, flags) {
+		imgui.TextDisabled(T("dfx.workspace.switch"))
+		imgui.Separator()
+		for i, id := range ws.switcher.ids {
+			size := imgui.Vec2{X: workspaceSwitcherMinWidth}
+			if imgui.SelectableBoolV(ws.GetName(id)+"##"+id, i == ws.switcher.selected, 0, size) {
+				ws.switcher.selected = i
+				ws.commitSwitcher(true)
+				break
+			}
+		}
+	}
+	imgui.End()
+}
+
+// touchMRU moves id to the front of the MRU list.
+func (ws *Workspace) touchMRU(id string) {
+	ws.dropMRU(id)
+	ws.mru = append([]string{id}, ws.mru...)
+}
+
+// dropMRU removes id from the MRU list.
+func (ws *Workspace) dropMRU(id string) {
+	for i, existing := range ws.mru {
+		if existing == id {
+			ws.mru = append(ws.mru[:i], ws.mru[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package dfx
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+type statefulTestComponent struct {
+	Container
+	selected string
+}
+
+func (c *statefulTestComponent) CaptureState() map[string]any {
+	return map[string]any{"selected": c.selected}
+}
+
+func (c *statefulTestComponent) RestoreState(state map[string]any) {
+	c.selected, _ = state["selected"].(string)
+}
+
+func TestWorkspaceState_RoundTripsThroughConfig(t *testing.T) {
+	build := func() (*Workspace, *statefulTestComponent, *Workspace, *statefulTestComponent) {
+		inner := &statefulTestComponent{}
+		nested := NewWorkspace()
+		nested.Add("one", "One", &Container{})
+		nested.Add("two", "Two", inner)
+
+		editor := &statefulTestComponent{}
+		ws := NewWorkspace()
+		ws.Add("editor", "Editor", editor)
+		ws.Add("plain", "Plain", &Container{})
+		ws.Add("nested", "Nested", nested)
+		return ws, editor, nested, inner
+	}
+
+	ws, editor, nested, inner := build()
+	editor.selected = "main.go"
+	inner.selected = "track 3"
+	nested.Switch("two")
+	ws.Switch("nested")
+
+	type appConfig struct {
+		Workspace WorkspaceConfig
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := SaveConfig(path, &appConfig{Workspace: CaptureWorkspaceState(ws)}); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+
+	var loaded appConfig
+	if err := LoadConfig(path, &loaded); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+
+	ws, editor, nested, inner = build()
+	RestoreWorkspaceState(ws, loaded.Workspace)
+
+	if ws.Current() != "nested" {
+		t.Fatalf("expected current 'nested', got '%v'", ws.Current())
+	}
+	if editor.selected != "main.go" {
+		t.Fatalf("expected editor selection 'main.go', got '%v'", editor.selected)
+	}
+	if nested.Current() != "two" {
+		t.Fatalf("expected nested current 'two', got '%v'", nested.Current())
+	}
+	if inner.selected != "track 3" {
+		t.Fatalf("expected inner selection 'track 3', got '%v'", inner.selected)
+	}
+}
+
+func TestRestoreWorkspaceState_IgnoresUnknownWorkspaces(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Switch("b")
+
+	RestoreWorkspaceState(ws, WorkspaceConfig{
+		Current: "removed",
+		States:  map[string]map[string]any{"removed": {"x": 1}},
+	})
+	if ws.Current() != "b" {
+		t.Fatalf("expected current 'b', got '%v'", ws.Current())
+	}
+}
+
+type lifecycleTestComponent struct {
+	statefulTestComponent
+	events *[]string
+	name   string
+}
+
+func newLifecycleTestComponent(events *[]string, name string) *lifecycleTestComponent {
+	c := &lifecycleTestComponent{events: events, name: name}
+	c.Visible = true
+	return c
+}
+
+func (c *lifecycleTestComponent) OnShow()    { *c.events = append(*c.events, "show "+c.name) }
+func (c *lifecycleTestComponent) OnHide()    { *c.events = append(*c.events, "hide "+c.name) }
+func (c *lifecycleTestComponent) OnUnmount() { *c.events = append(*c.events, "unmount "+c.name) }
+
+// drawWorkspaceFrame draws the current workspace component as Workspace.Draw does, without
+// imgui, and ends the frame.
+func drawWorkspaceFrame(ws *Workspace) {
+	ws.syncActive()
+	if current := ws.CurrentComponent(); current != nil {
+		DrawChild(current, &State{})
+	}
+	lifecycle.endFrame()
+}
+
+func TestWorkspace_LazyConstructionAndLifecycle(t *testing.T) {
+	withLifecycleTracker(t)
+	var events []string
+	built := 0
+	factory := func(name string) func() Component {
+		return func() Component {
+			built++
+			return newLifecycleTestComponent(&events, name)
+		}
+	}
+
+	ws := NewWorkspace()
+	ws.AddLazy("a", "A", factory("a"))
+	ws.AddLazy("b", "B", factory("b"))
+	if built != 0 {
+		t.Fatalf("expected no construction before use, got %d", built)
+	}
+
+	ws.syncActive()
+	if built != 1 || !ws.Loaded("a") || ws.Loaded("b") {
+		t.Fatalf("expected only 'a' constructed, got %d", built)
+	}
+
+	drawWorkspaceFrame(ws)
+	ws.Switch("b")
+	drawWorkspaceFrame(ws)
+	ws.Switch("a")
+	drawWorkspaceFrame(ws)
+	expected := []string{"show a", "show b", "hide a", "show a", "hide b"}
+	if len(events) != len(expected) {
+		t.Fatalf("expected events '%v', got '%v'", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("expected events '%v', got '%v'", expected, events)
+		}
+	}
+	if built != 2 {
+		t.Fatalf("expected 2 constructions, got %d", built)
+	}
+}
+
+func TestWorkspace_UnloadIdleKeepsState(t *testing.T) {
+	withLifecycleTracker(t)
+	var events []string
+	clock := time.Unix(1000, 0)
+	built := 0
+
+	ws := NewWorkspace()
+	ws.now = func() time.Time { return clock }
+	ws.UnloadAfter = time.Minute
+	ws.Add("home", "Home", &Container{})
+	ws.AddLazy("heavy", "Heavy", func() Component {
+		built++
+		return newLifecycleTestComponent(&events, "heavy")
+	})
+
+	ws.Switch("heavy")
+	drawWorkspaceFrame(ws)
+	ws.CurrentComponent().(*lifecycleTestComponent).selected = "clip 7"
+	ws.Switch("home")
+	drawWorkspaceFrame(ws)
+
+	clock = clock.Add(30 * time.Second)
+	ws.unloadIdle()
+	if !ws.Loaded("heavy") {
+		t.Fatalf("expected 'heavy' to stay loaded before UnloadAfter")
+	}
+
+	clock = clock.Add(time.Minute)
+	ws.unloadIdle()
+	if ws.Loaded("heavy") {
+		t.Fatalf("expected 'heavy' to be unloaded")
+	}
+	expected := []string{"show heavy", "hide heavy", "unmount heavy"}
+	if !slices.Equal(events, expected) {
+		t.Fatalf("expected events '%v', got '%v'", expected, events)
+	}
+
+	// state survives capture while unloaded and restoration on rebuild
+	if got := CaptureWorkspaceState(ws).States["heavy"]["selected"]; got != "clip 7" {
+		t.Fatalf("expected captured selection 'clip 7', got '%v'", got)
+	}
+	ws.Switch("heavy")
+	if built != 2 {
+		t.Fatalf("expected rebuild, got %d constructions", built)
+	}
+	if got := ws.CurrentComponent().(*lifecycleTestComponent).selected; got != "clip 7" {
+		t.Fatalf("expected restored selection 'clip 7', got '%v'", got)
+	}
+}
+
+func TestWorkspace_RemoveKeepsCurrent(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Add("c", "C", &Container{})
+	ws.Switch("c")
+	ws.Remove("a")
+	if ws.Current() != "c" {
+		t.Fatalf("expected current 'c', got '%v'", ws.Current())
+	}
+}
+
+func TestWorkspace_NextPreviousWrap(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Add("c", "C", &Container{})
+
+	ws.Previous()
+	if ws.Current() != "c" {
+		t.Fatalf("expected 'c', got '%v'", ws.Current())
+	}
+	ws.Next()
+	if ws.Current() != "a" {
+		t.Fatalf("expected 'a', got '%v'", ws.Current())
+	}
+}
+
+func tabLabels(ws *Workspace) []string {
+	var labels []string
+	for _, item := range ws.items {
+		labels = append(labels, item.tabLabel())
+	}
+	return labels
+}
+
+func TestWorkspace_TabOrder(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Add("c", "C", &Container{})
+	ws.SetIcon("b", "*")
+	ws.SetName("c", "Renamed")
+	ws.Add("a", "A2", &Container{}) // replacing keeps the position
+
+	expected := []string{"A2###a", "* B###b", "Renamed###c"}
+	if got := tabLabels(ws); !slices.Equal(got, expected) {
+		t.Fatalf("expected '%v', got '%v'", expected, got)
+	}
+	ws.Remove("b")
+	expected = []string{"A2###a", "Renamed###c"}
+	if got := tabLabels(ws); !slices.Equal(got, expected) {
+		t.Fatalf("expected '%v', got '%v'", expected, got)
+	}
+}
+
+func TestWorkspace_TabActivation(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	var switches []string
+	ws.OnSwitch = func(oldId, newId string) { switches = append(switches, oldId+">"+newId) }
+
+	// clicking a tab switches without asking the tab strip to select it again
+	ws.tabSync = false
+	ws.selectTab(1)
+	if ws.Current() != "b" || ws.tabSync {
+		t.Fatalf("expected 'b' without a tab sync, got '%v' (sync %v)", ws.Current(), ws.tabSync)
+	}
+	ws.selectTab(1)
+	ws.selectTab(-1)
+	if !slices.Equal(switches, []string{"a>b"}) {
+		t.Fatalf("expected one switch 'a>b', got '%v'", switches)
+	}
+
+	// switching in code selects the tab on the next draw
+	ws.Switch("a")
+	if !ws.tabSync {
+		t.Fatalf("expected a tab sync after Switch")
+	}
+}
+
+func TestWorkspace_TabClose(t *testing.T) {
+	ws := NewWorkspace()
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Add("c", "C", &Container{})
+	ws.Switch("b")
+	var switches []string
+	ws.OnSwitch = func(oldId, newId string) { switches = append(switches, oldId+">"+newId) }
+
+	// closing a background tab keeps the current workspace
+	ws.closeTab("a")
+	if ws.Current() != "b" || len(switches) != 0 {
+		t.Fatalf("expected current 'b' without a switch, got '%v' (%v)", ws.Current(), switches)
+	}
+
+	// OnTabClose can keep a tab open
+	ws.OnTabClose = func(id string) bool { return id != "c" }
+	ws.closeTab("c")
+	if !slices.Equal(ws.WorkspaceIds(), []string{"b", "c"}) {
+		t.Fatalf("expected 'c' kept, got '%v'", ws.WorkspaceIds())
+	}
+
+	// closing the current tab activates its neighbour
+	ws.closeTab("b")
+	if ws.Current() != "c" || !slices.Equal(switches, []string{"b>c"}) {
+		t.Fatalf("expected switch to 'c', got '%v' (%v)", ws.Current(), switches)
+	}
+}
+
+func TestWorkspace_SwitcherUsesMRUOrder(t *testing.T) {
+	ws := NewWorkspace()
+	ws.ShowSwitcher = true
+	ws.EnableCycling()
+	ws.EnableCycling()
+	if n := len(ws.LocalActions().actions); n != 2 {
+		t.Fatalf("expected 2 cycling actions, got %d", n)
+	}
+
+	ws.Add("a", "A", &Container{})
+	ws.Add("b", "B", &Container{})
+	ws.Add("c", "C", &Container{})
+	ws.Switch("c")
+	ws.Switch("a")
+
+	mru := ws.MRU()
+	if len(mru) != 3 || mru[0] != "a" || mru[1] != "c" || mru[2] != "b" {
+		t.Fatalf("expected mru 'a c b', got '%v'", mru)
+	}
+
+	// first press selects the previously used workspace, second moves one further
+	ws.cycle(1)
+	if !ws.switcher.open || ws.switcher.ids[ws.switcher.selected] != "c" {
+		t.Fatalf("expected switcher open on 'c'")
+	}
+	ws.cycle(1)
+	ws.commitSwitcher(true)
+	if ws.Current() != "b" || ws.switcher.open {
+		t.Fatalf("expected switch to 'b', got '%v'", ws.Current())
+	}
+
+	// cancelling leaves the current workspace alone
+	ws.cycle(-1)
+	ws.commitSwitcher(false)
+	if ws.Current() != "b" {
+		t.Fatalf("expected current 'b', got '%v'", ws.Current())
+	}
+}
+
+func TestWorkspace_Breadcrumbs(t *testing.T) {
+	inner := NewWorkspace()
+	inner.Add("mix", "Mix", &Container{})
+	inner.Add("master", "Master", &Container{})
+	inner.Switch("master")
+
+	outer := NewWorkspace()
+	outer.Add("edit", "Edit", &Container{})
+	outer.Add("audio", "Audio", inner)
+	outer.Switch("audio")
+
+	crumbs := outer.Breadcrumbs()
+	if len(crumbs) != 2 || crumbs[0].Name != "Audio" || crumbs[1].Id != "master" || crumbs[1].Workspace != inner {
+		t.Fatalf("expected path 'Audio > Master', got '%+v'", crumbs)
+	}
+
+	outer.Switch("edit")
+	if crumbs := outer.Breadcrumbs(); len(crumbs) != 1 {
+		t.Fatalf("expected single crumb, got %d", len(crumbs))
+	}
+}
@@ -0,0 +1,371 @@
+package dfx
+
+import (
+	"math"
+	"strconv"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+const (
+	ZoomPanMinZoom = 0.1  // furthest zoom out, when MinZoom is unset
+	ZoomPanMaxZoom = 10   // deepest zoom in, when MaxZoom is unset
+	zoomPanStep    = 1.15 // zoom factor per wheel notch
+
+	zoomWheelOwner = "##zoomPanWheel"
+
+	zoomFitKeys   = "Shift+1"
+	zoomResetKeys = "Shift+0"
+)
+
+// zoomPanPresets are the zoom levels offered by the readout's menu.
+var zoomPanPresets = []float32{0.25, 0.5, 1, 2, 4}
+
+// ZoomPan draws a content component in a zoomed and panned view, for viewers of images,
+// diagrams and documents. the wheel zooms around the pointer; dragging with the middle
+// button, or the left while holding space, pans. Fit and Reset (Shift+1 and Shift+0) fit the
+// content in the view and return to its actual size.
+//
+// the content draws as it would unzoomed, at the top left of the view, sized by State.Size:
+// ContentSize when set, otherwise the visible part of the view in content units. what it
+// draws is scaled as a whole afterwards, and the pointer is mapped into its coordinates
+// while it draws, so its items are hovered and clicked where they appear. child windows the
+// content begins (ScrollAreas, tables with scrolling) are not scaled.
+//
+// the zoom and pan are saved and restored as StatefulComponent state.
+type ZoomPan struct {
+	Container
+	Content     Component
+	ContentSize imgui.Vec2 // size of the content in its own units (zero = measured as drawn)
+	MinZoom     float32    // zero = ZoomPanMinZoom
+	MaxZoom     float32    // zero = ZoomPanMaxZoom
+	ShowZoom    bool       // show the zoom percentage in the bottom right corner, with a menu of levels
+	OnZoom      func(zoom float32)
+
+	zoom     float32
+	pan      imgui.Vec2 // content position at the top left of the view
+	viewSize imgui.Vec2 // size of the view at the last draw
+	measured imgui.Vec2 // size of the content at the last draw
+	panning  bool
+	fit      bool // fit the content once its size is known
+}
+
+// NewZoomPan creates a zoom and pan view of content, at its actual size.
+func NewZoomPan(content Component) *ZoomPan {
+	zp := &ZoomPan{
+		Container: Container{Visible: true},
+		Content:   content,
+		ShowZoom:  true,
+		zoom:      1,
+	}
+	zp.Container.Actions().MustRegister("Zoom to Fit", zoomFitKeys, zp.Fit)
+	zp.Container.Actions().MustRegister("Actual Size", zoomResetKeys, zp.Reset)
+	return zp
+}
+
+// Zoom returns the zoom factor (1 = actual size).
+func (zp *ZoomPan) Zoom() float32 {
+	return zp.zoom
+}
+
+// SetZoom zooms to zoom around the center of the view.
+func (zp *ZoomPan) SetZoom(zoom float32) {
+	zp.zoomAround(zp.viewSize.Div(2), zoom)
+}
+
+// Pan returns the content position shown at the top left of the view.
+func (zp *ZoomPan) Pan() imgui.Vec2 {
+	return zp.pan
+}
+
+// SetPan shows the content position p at the top left of the view.
+func (zp *ZoomPan) SetPan(p imgui.Vec2) {
+	zp.pan = p
+}
+
+// Fit zooms so the whole content fits in the view, centered. before the content has been
+// drawn it fits once its size is known.
+func (zp *ZoomPan) Fit() {
+	zp.fit = true
+}
+
+// Reset returns to the actual size with the content's top left at the view's.
+func (zp *ZoomPan) Reset() {
+	zp.fit = false
+	zp.pan = imgui.Vec2{}
+	zp.setZoom(1)
+}
+
+// contentSize returns the content size, set or measured.
+func (zp *ZoomPan) contentSize() imgui.Vec2 {
+	if zp.ContentSize.X > 0 && zp.ContentSize.Y > 0 {
+		return zp.ContentSize
+	}
+	return zp.measured
+}
+
+// zoomLimits returns the zoom range.
+func (zp *ZoomPan) zoomLimits() (float32, float32) {
+	lo, hi := zp.MinZoom, zp.MaxZoom
+	if lo <= 0 {
+		lo = ZoomPanMinZoom
+	}
+	if hi <= 0 {
+		hi = ZoomPanMaxZoom
+	}
+	return lo, max(lo, hi)
+}
+
+// setZoom sets the zoom within the limits, reporting changes.
+func (zp *ZoomPan) setZoom(zoom float32) {
+	lo, hi := zp.zoomLimits()
+	zoom = clamp(zoom, lo, hi)
+	if zoom == zp.zoom {
+		return
+	}
+	zp.zoom = zoom
+	if zp.OnZoom != nil {
+		zp.OnZoom(zoom)
+	}
+}
+
+// zoomAround zooms to zoom keeping the content under at, relative to the view's top left,
+// in place.
+func (zp *ZoomPan) zoomAround(at imgui.Vec2, zoom float32) {
+	view := graphView{pan: zp.pan, zoom: zp.zoom}
+	fixed := view.toCanvas(at)
+	zp.setZoom(zoom)
+	zp.pan = imgui.Vec2{X: fixed.X - at.X/zp.zoom, Y: fixed.Y - at.Y/zp.zoom}
+}
+
+// applyFit fits the content in the view once both sizes are known.
+func (zp *ZoomPan) applyFit() {
+	content := zp.contentSize()
+	if !zp.fit || content.X <= 0 || content.Y <= 0 || zp.viewSize.X <= 0 || zp.viewSize.Y <= 0 {
+		return
+	}
+	zp.fit = false
+	zp.setZoom(min(zp.viewSize.X/content.X, zp.viewSize.Y/content.Y))
+	zp.pan = imgui.Vec2{X: (content.X - zp.viewSize.X/zp.zoom) / 2, Y: (content.Y - zp.viewSize.Y/zp.zoom) / 2}
+}
+
+// Draw implements Component.
+func (zp *ZoomPan) Draw(state *State) {
+	if !zp.Visible {
+		return
+	}
+	var size imgui.Vec2
+	if state != nil {
+		size = state.Size
+	}
+	if zp.zoom <= 0 {
+		zp.zoom = 1
+	}
+
+	imgui.PushStyleVarVec2(imgui.StyleVarWindowPadding, imgui.Vec2{})
+	visible := imgui.BeginChildStrV(componentID("##zoomPan", zp), size, imgui.ChildFlagsNone,
+		imgui.WindowFlagsNoScrollbar|imgui.WindowFlagsNoScrollWithMouse)
+	imgui.PopStyleVar()
+	if visible {
+		origin := imgui.WindowPos()
+		zp.viewSize = imgui.WindowSize()
+		zp.applyFit()
+		hovered := imgui.IsWindowHoveredV(imgui.HoveredFlagsChildWindows)
+		zp.handleInput(origin, hovered)
+		zp.drawContent(state, graphView{origin: origin, pan: zp.pan, zoom: zp.zoom}, hovered)
+		if zp.ShowZoom {
+			zp.drawReadout(origin)
+		}
+	}
+	imgui.EndChild()
+
+	drawContainerExtensions(&zp.Container, state)
+}
+
+// handleInput zooms with the wheel and pans with drags.
+func (zp *ZoomPan) handleInput(origin imgui.Vec2, hovered bool) {
+	io := imgui.CurrentIO()
+	mouse := imgui.MousePos()
+	if hovered {
+		// keep the wheel from also scrolling the windows around the view
+		imgui.InternalSetKeyOwner(imgui.KeyMouseWheelY, imgui.IDStr(zoomWheelOwner))
+	}
+	if wheel := io.MouseWheel(); hovered && wheel != 0 {
+		zp.zoomAround(mouse.Sub(origin), zp.zoom*float32(math.Pow(zoomPanStep, float64(wheel))))
+	}
+
+	switch {
+	case zp.panning:
+		if !imgui.IsMouseDown(imgui.MouseButtonLeft) && !imgui.IsMouseDown(imgui.MouseButtonMiddle) {
+			zp.panning = false
+			return
+		}
+		delta := io.MouseDelta()
+		zp.pan = imgui.Vec2{X: zp.pan.X - delta.X/zp.zoom, Y: zp.pan.Y - delta.Y/zp.zoom}
+	case hovered && imgui.IsMouseClickedBool(imgui.MouseButtonMiddle):
+		zp.panning = true
+	case hovered && zp.spaceHeld() && imgui.IsMouseClickedBool(imgui.MouseButtonLeft):
+		zp.panning = true
+	}
+}
+
+// spaceHeld reports whether space is held for panning, rather than typed into an item.
+func (zp *ZoomPan) spaceHeld() bool {
+	return imgui.IsKeyDown(imgui.KeySpace) && !imgui.IsAnyItemActive()
+}
+
+// drawContent draws the content unzoomed, with the pointer mapped into its coordinates,
+// then scales what it drew into the view.
+func (zp *ZoomPan) drawContent(state *State, view graphView, hovered bool) {
+	if zp.Content == nil {
+		return
+	}
+	dl := imgui.WindowDrawList()
+	clipMin, clipMax := dl.ClipRectMin(), dl.ClipRectMax()
+	// a command of its own, so the view's earlier commands keep their clip rects
+	dl.AddDrawCmd()
+	firstCmd := dl.CmdBuffer().Size - 1
+	firstVtx := dl.VtxBuffer().Size
+
+	// clip to the part of the content in view, where the content lays out unzoomed
+	unzoomed := func(p imgui.Vec2) imgui.Vec2 { return view.origin.Add(view.toCanvas(p)) }
+	imgui.PushClipRect(unzoomed(clipMin), unzoomed(clipMax), false)
+
+	io := imgui.CurrentIO()
+	mouse, delta := io.MousePos(), io.MouseDelta()
+	switch {
+	case zp.panning || (hovered && zp.spaceHeld()):
+		// the content ignores the pointer while it pans the view
+		io.SetMousePos(imgui.Vec2{X: -math.MaxFloat32, Y: -math.MaxFloat32})
+		io.SetMouseDelta(imgui.Vec2{})
+	case imgui.IsMousePosValidV(&mouse):
+		io.SetMousePos(unzoomed(mouse))
+		io.SetMouseDelta(delta.Div(view.zoom))
+	}
+
+	imgui.SetCursorScreenPos(view.origin)
+	imgui.BeginGroup()
+	DrawChild(zp.Content, state.Child(zp.contentStateSize(), imgui.Vec2{}).WithParent(zp))
+	imgui.EndGroup()
+	zp.measured = imgui.ItemRectSize()
+
+	io.SetMousePos(mouse)
+	io.SetMouseDelta(delta)
+	zoomDrawList(dl, firstCmd, firstVtx, view, clipMin, clipMax)
+	imgui.PopClipRect()
+}
+
+// contentStateSize returns the size the content is given to draw in.
+func (zp *ZoomPan) contentStateSize() imgui.Vec2 {
+	if zp.ContentSize.X > 0 && zp.ContentSize.Y > 0 {
+		return zp.ContentSize
+	}
+	return zp.viewSize.Div(zp.zoom)
+}
+
+// zoomDrawList scales the vertices from firstVtx and the commands from firstCmd, drawn
+// unzoomed at the view's origin, into the view, clipping them to clipMin..clipMax.
+func zoomDrawList(dl *imgui.DrawList, firstCmd, firstVtx int, view graphView, clipMin, clipMax imgui.Vec2) {
+	zoomed := func(p imgui.Vec2) imgui.Vec2 { return view.toScreen(p.Sub(view.origin)) }
+
+	vertices := dl.VtxBuffer()
+	if n := vertices.Size - firstVtx; n > 0 {
+		layout := drawLayout()
+		vtx := unsafe.Slice((*byte)(unsafe.Pointer(vertices.Data.CData)), vertices.Size*layout.vtxSize)
+		for i := firstVtx; i < vertices.Size; i++ {
+			pos := (*imgui.Vec2)(unsafe.Pointer(&vtx[i*layout.vtxSize+layout.posOffset]))
+			*pos = zoomed(*pos)
+		}
+	}
+
+	cmds := dl.Commands()
+	for i := max(firstCmd, 0); i < len(cmds); i++ {
+		clip := cmds[i].ClipRect()
+		lo, hi := zoomed(imgui.Vec2{X: clip.X, Y: clip.Y}), zoomed(imgui.Vec2{X: clip.Z, Y: clip.W})
+		cmds[i].SetClipRect(imgui.Vec4{
+			X: max(lo.X, clipMin.X), Y: max(lo.Y, clipMin.Y),
+			Z: max(min(hi.X, clipMax.X), clipMin.X), W: max(min(hi.Y, clipMax.Y), clipMin.Y),
+		})
+	}
+}
+
+// zoomPercentTexts labels the readout with a zoom percentage.
+var zoomPercentTexts = newStringCache(func(percent int) string {
+	return strconv.Itoa(percent) + "%##zoomPercent"
+})
+
+// drawReadout draws the zoom percentage in the bottom right corner of the view, opening a
+// menu of zoom levels when clicked.
+func (zp *ZoomPan) drawReadout(origin imgui.Vec2) {
+	label := zoomPercentTexts.get(int(math.Round(float64(zp.zoom * 100))))
+	style := imgui.CurrentStyle()
+	// small buttons pad only horizontally
+	size := imgui.CalcTextSizeV(label, true, -1).Add(imgui.Vec2{X: style.FramePadding().X * 2})
+	imgui.SetCursorScreenPos(origin.Add(zp.viewSize).Sub(size).Sub(style.ItemSpacing()))
+	if imgui.SmallButton(label) {
+		imgui.OpenPopupStr("##zoomLevels")
+	}
+	if !imgui.BeginPopup("##zoomLevels") {
+		return
+	}
+	if imgui.MenuItemBoolV(T("dfx.zoom.fit"), zoomFitKeys, false, true) {
+		zp.Fit()
+	}
+	if imgui.MenuItemBoolV(T("dfx.zoom.actual"), zoomResetKeys, false, true) {
+		zp.Reset()
+	}
+	imgui.Separator()
+	lo, hi := zp.zoomLimits()
+	for _, preset := range zoomPanPresets {
+		if preset < lo || preset > hi {
+			continue
+		}
+		if imgui.MenuItemBoolV(zoomPercentTexts.get(int(preset*100)), "", zp.zoom == preset, true) {
+			zp.SetZoom(preset)
+		}
+	}
+	imgui.EndPopup()
+}
+
+// MinimapBounds implements MinimapSource: the content, in its own units.
+func (zp *ZoomPan) MinimapBounds() (imgui.Vec2, imgui.Vec2) {
+	return imgui.Vec2{}, zp.contentSize()
+}
+
+// MinimapViewport implements MinimapSource: the part of the content in view.
+func (zp *ZoomPan) MinimapViewport() (imgui.Vec2, imgui.Vec2) {
+	return zp.pan, zp.pan.Add(zp.viewSize.Div(zp.zoom))
+}
+
+// MinimapScrollTo implements MinimapSource.
+func (zp *ZoomPan) MinimapScrollTo(origin imgui.Vec2) {
+	zp.pan = origin
+}
+
+// CaptureState implements StatefulComponent.
+func (zp *ZoomPan) CaptureState() map[string]any {
+	return map[string]any{"zoom": zp.zoom, "panX": zp.pan.X, "panY": zp.pan.Y}
+}
+
+// RestoreState implements StatefulComponent.
+func (zp *ZoomPan) RestoreState(state map[string]any) {
+	if zoom, ok := stateFloat(state["zoom"]); ok {
+		lo, hi := zp.zoomLimits()
+		zp.zoom = clamp(float32(zoom), lo, hi)
+	}
+	if x, ok := stateFloat(state["panX"]); ok {
+		zp.pan.X = float32(x)
+	}
+	if y, ok := stateFloat(state["panY"]); ok {
+		zp.pan.Y = float32(y)
+	}
+}
+
+// ChildActions returns the content for action traversal.
+func (zp *ZoomPan) ChildActions() []Component {
+	if zp.Content == nil {
+		return zp.Children
+	}
+	return append([]Component{zp.Content}, zp.Children...)
+}
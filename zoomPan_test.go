@@ -0,0 +1,92 @@
+package dfx
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestZoomPan_ZoomAround(t *testing.T) {
+	zp := NewZoomPan(nil)
+	zp.viewSize = imgui.Vec2{X: 200, Y: 100}
+	at := imgui.Vec2{X: 50, Y: 40}
+	before := graphView{pan: zp.pan, zoom: zp.zoom}.toCanvas(at)
+	zp.zoomAround(at, 2)
+	if got := zp.Zoom(); got != float32(2) {
+		t.Fatalf("expected '%v', got '%v'", float32(2), got)
+	}
+	after := graphView{pan: zp.pan, zoom: zp.zoom}.toCanvas(at)
+	if math.Abs(float64(after.X)-float64(before.X)) > 0.001 {
+		t.Fatalf("the content under the pointer stays put: expected '%v', got '%v'", before.X, after.X)
+	}
+	if math.Abs(float64(after.Y)-float64(before.Y)) > 0.001 {
+		t.Fatalf("expected '%v', got '%v'", before.Y, after.Y)
+	}
+
+	zp.SetZoom(1000)
+	if got := zp.Zoom(); got != float32(ZoomPanMaxZoom) {
+		t.Fatalf("expected '%v', got '%v'", float32(ZoomPanMaxZoom), got)
+	}
+	zp.MinZoom = 0.5
+	zp.SetZoom(0)
+	if got := zp.Zoom(); got != float32(0.5) {
+		t.Fatalf("expected '%v', got '%v'", float32(0.5), got)
+	}
+}
+
+func TestZoomPan_FitAndReset(t *testing.T) {
+	var zooms []float32
+	zp := NewZoomPan(nil)
+	zp.OnZoom = func(zoom float32) { zooms = append(zooms, zoom) }
+	zp.ContentSize = imgui.Vec2{X: 400, Y: 100}
+	zp.Fit()
+	zp.applyFit()
+	if !zp.fit {
+		t.Fatal("waits for the view to be drawn: expected zp.fit")
+	}
+
+	zp.viewSize = imgui.Vec2{X: 200, Y: 200}
+	zp.applyFit()
+	if zp.fit {
+		t.Fatal("unexpected zp.fit")
+	}
+	if got := zp.Zoom(); got != float32(0.5) {
+		t.Fatalf("expected '%v', got '%v'", float32(0.5), got)
+	}
+	if got := zp.Pan(); got != (imgui.Vec2{X: 0, Y: -150}) {
+		t.Fatalf("centered: expected '%v', got '%v'", imgui.Vec2{X: 0, Y: -150}, got)
+	}
+	lo, hi := zp.MinimapViewport()
+	if lo != (imgui.Vec2{X: 0, Y: -150}) {
+		t.Fatalf("expected '%v', got '%v'", imgui.Vec2{X: 0, Y: -150}, lo)
+	}
+	if hi != (imgui.Vec2{X: 400, Y: 250}) {
+		t.Fatalf("expected '%v', got '%v'", imgui.Vec2{X: 400, Y: 250}, hi)
+	}
+
+	zp.Reset()
+	if got := zp.Zoom(); got != float32(1) {
+		t.Fatalf("expected '%v', got '%v'", float32(1), got)
+	}
+	if got := zp.Pan(); got != (imgui.Vec2{}) {
+		t.Fatalf("expected '%v', got '%v'", imgui.Vec2{}, got)
+	}
+	if !slices.Equal(zooms, []float32{0.5, 1}) {
+		t.Fatalf("expected '%v', got '%v'", []float32{0.5, 1}, zooms)
+	}
+}
+
+func TestZoomPan_State(t *testing.T) {
+	zp := NewZoomPan(nil)
+	zp.zoom, zp.pan = 2.5, imgui.Vec2{X: -40, Y: 120}
+	restored := NewZoomPan(nil)
+	restored.RestoreState(zp.CaptureState())
+	if got := restored.Zoom(); got != float32(2.5) {
+		t.Fatalf("expected '%v', got '%v'", float32(2.5), got)
+	}
+	if got := restored.Pan(); got != zp.pan {
+		t.Fatalf("expected '%v', got '%v'", zp.pan, got)
+	}
+}